@@ -0,0 +1,64 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"image"
+	"log"
+	"os"
+	"path/filepath"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// speaks the selected rows and alerts with speech-dispatcher, or writes them to announcements.log
+// in the temporary directory without it; j and k move the selection, e shows an error, and q quits
+func main() {
+	if announcer, err := ui.NewSpeechDispatcherAnnouncer(); err == nil {
+		ui.DefaultAnnouncer = announcer
+	} else {
+		f, err := os.Create(filepath.Join(os.TempDir(), "announcements.log"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		ui.DefaultAnnouncer = ui.NewWriterAnnouncer(f)
+	}
+
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	l := widgets.NewList()
+	l.Title = "Fruits"
+	l.Rows = []string{"Apple", "Banana", "Cherry", "Date"}
+	l.SetRect(0, 0, 30, 8)
+
+	toaster := widgets.NewToaster(image.Rect(0, 0, 60, 20))
+
+	draw := func() {
+		ui.Render(l)
+	}
+	toaster.OnChange = draw
+	draw()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "j", "<Down>":
+			l.ScrollDown()
+		case "k", "<Up>":
+			l.ScrollUp()
+		case "e":
+			toaster.Error("Could not open " + l.Rows[l.SelectedRow])
+		}
+		draw()
+	}
+}
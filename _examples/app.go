@@ -0,0 +1,111 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/app"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// the messages of the model, besides the events of termui
+type (
+	tickMsg    time.Time
+	fetchedMsg []string
+)
+
+// model counts seconds while running, and fetches rows in the background when r is pressed;
+// space pauses the counter, and q quits
+type model struct {
+	running  bool
+	seconds  int
+	fetching bool
+	rows     []string
+
+	counter *widgets.Paragraph
+	list    *widgets.List
+}
+
+// fetch stands for a slow request.
+func fetch() app.Msg {
+	time.Sleep(time.Second)
+	rows := []string{}
+	for i := 0; i < 5; i++ {
+		rows = append(rows, fmt.Sprintf("row %d", rand.Intn(100)))
+	}
+	return fetchedMsg(rows)
+}
+
+func (m model) Init() app.Cmd {
+	return fetch
+}
+
+func (m model) Update(msg app.Msg) (app.Model, app.Cmd) {
+	switch msg := msg.(type) {
+	case ui.Event:
+		switch msg.ID {
+		case "q", "<C-c>":
+			return m, app.Quit
+		case "<Space>":
+			m.running = !m.running
+		case "r":
+			if !m.fetching {
+				m.fetching = true
+				return m, fetch
+			}
+		}
+	case tickMsg:
+		m.seconds++
+	case fetchedMsg:
+		m.fetching = false
+		m.rows = msg
+	}
+	return m, nil
+}
+
+func (m model) Subscriptions() []app.Sub {
+	if !m.running {
+		return nil
+	}
+	return []app.Sub{app.Every("seconds", time.Second, func(now time.Time) app.Msg {
+		return tickMsg(now)
+	})}
+}
+
+func (m model) View() []ui.Drawable {
+	m.counter.Text = fmt.Sprintf("%d seconds", m.seconds)
+	if !m.running {
+		m.counter.Text += " (paused)"
+	}
+	m.list.Rows = m.rows
+	m.list.Title = "Rows"
+	if m.fetching {
+		m.list.Title = "Rows (fetching)"
+	}
+	return []ui.Drawable{m.counter, m.list}
+}
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	counter := widgets.NewParagraph()
+	counter.Title = "Counter"
+	counter.SetRect(0, 0, 30, 3)
+	list := widgets.NewList()
+	list.SetRect(0, 3, 30, 12)
+
+	start := model{running: true, fetching: true, counter: counter, list: list}
+	app.NewProgram(start).Run()
+}
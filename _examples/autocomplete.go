@@ -0,0 +1,87 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+var languages = []string{
+	"Ada", "Assembly", "C", "C#", "C++", "Clojure", "COBOL", "Crystal", "D", "Dart", "Elixir",
+	"Elm", "Erlang", "F#", "Fortran", "Go", "Groovy", "Haskell", "Java", "JavaScript", "Julia",
+	"Kotlin", "Lisp", "Lua", "Nim", "OCaml", "Pascal", "Perl", "PHP", "Prolog", "Python", "R",
+	"Racket", "Ruby", "Rust", "Scala", "Scheme", "Swift", "TypeScript", "Zig",
+}
+
+// searchPackages stands in for a slow remote lookup.
+func searchPackages(text string) []string {
+	time.Sleep(300 * time.Millisecond)
+	suggestions := []string{}
+	for _, suffix := range []string{"", "-cli", "-utils", "-server"} {
+		suggestions = append(suggestions, strings.ToLower(text)+suffix)
+	}
+	return suggestions
+}
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	language := widgets.NewAutocomplete()
+	language.Title = "Language"
+	language.Source = widgets.StaticSuggestions(languages)
+	language.SetRect(0, 0, 30, 3)
+
+	pkg := widgets.NewAutocomplete()
+	pkg.Title = "Package (remote)"
+	pkg.Source = searchPackages
+	pkg.Async = true
+	pkg.MinLength = 2
+	pkg.SetRect(31, 0, 61, 3)
+
+	p := widgets.NewParagraph()
+	p.Text = "<Tab> accepts a suggestion or switches inputs, <C-c> quits"
+	p.SetRect(0, 3, 61, 12)
+
+	inputs := []*widgets.Autocomplete{language, pkg}
+	render := func() {
+		ui.Render(language, pkg, p)
+	}
+	for _, input := range inputs {
+		input.OnAccept = func(suggestion string) {
+			p.Text = fmt.Sprintf("Picked %s", suggestion)
+		}
+	}
+	// suggestions from the remote source arrive in the background
+	pkg.OnSuggest = func([]string) { render() }
+
+	focus := 0
+	inputs[focus].SetFocused(true)
+	render()
+
+	for e := range ui.PollEvents() {
+		switch {
+		case e.ID == "<C-c>":
+			return
+		case e.ID == "<Tab>" && !inputs[focus].IsOpen():
+			inputs[focus].SetFocused(false)
+			focus = (focus + 1) % len(inputs)
+			inputs[focus].SetFocused(true)
+		case !inputs[focus].HandleKey(e.ID) && !inputs[focus].HandleMouse(e):
+			continue
+		}
+		render()
+	}
+}
@@ -7,6 +7,7 @@
 package main
 
 import (
+	"fmt"
 	"log"
 
 	ui "github.com/reaalkhalil/termui"
@@ -20,16 +21,52 @@ func main() {
 	defer ui.Close()
 
 	bc := widgets.NewBarChart()
-	bc.Data = []float64{3, 2, 5, 3, 9, 3}
-	bc.Labels = []string{"S0", "S1", "S2", "S3", "S4", "S5"}
-	bc.Title = "Bar Chart"
+	for i := 0; i < 24; i++ {
+		bc.Data = append(bc.Data, float64(2+(i*7)%9))
+		bc.Labels = append(bc.Labels, fmt.Sprintf("S%d", i))
+	}
+	bc.Title = "Bar Chart (scroll with the arrow keys or mouse wheel)"
 	bc.SetRect(5, 5, 100, 25)
 	bc.BarWidth = 5
 	bc.BarColors = []ui.Color{ui.ColorRed, ui.ColorGreen}
 	bc.LabelStyles = []ui.Style{ui.NewStyle(ui.ColorBlue)}
 	bc.NumStyles = []ui.Style{ui.NewStyle(ui.ColorYellow)}
 
-	ui.Render(bc)
+	hbc := widgets.NewBarChart()
+	hbc.Data = []float64{12, 7, 4, 9}
+	hbc.Labels = []string{"Engineering", "Sales", "Support", "Operations"}
+	hbc.Title = "Horizontal Bar Chart"
+	hbc.SetRect(5, 25, 100, 35)
+	hbc.Horizontal = true
+	hbc.BarWidth = 1
+	hbc.BarColors = []ui.Color{ui.ColorCyan, ui.ColorMagenta}
+
+	gbc := widgets.NewBarChart()
+	gbc.Title = "Grouped Bar Chart"
+	gbc.SetRect(100, 5, 150, 25)
+	gbc.Series = [][]float64{
+		{4, 6, 5, 8, 7},
+		{3, 5, 7, 6, 9},
+	}
+	gbc.SeriesLabels = []string{"last week", "this week"}
+	gbc.ShowLegend = true
+	gbc.Labels = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"}
+	gbc.LabelRotation = widgets.LabelDiagonal
+	gbc.NumFormatter = widgets.NewNumFormatter(0, "h", true)
+	gbc.BarWidth = 3
+	gbc.BarGap = 2
+	gbc.BarColors = []ui.Color{ui.ColorBlue, ui.ColorGreen}
+
+	pl := widgets.NewBarChart()
+	pl.Title = "Profit and Loss"
+	pl.Data = []float64{4, -2, 6, -5, 3}
+	pl.Labels = []string{"Q1", "Q2", "Q3", "Q4", "Q5"}
+	pl.SetRect(100, 25, 150, 35)
+	pl.Horizontal = true
+	pl.BarWidth = 1
+	pl.BarGap = 0
+
+	ui.Render(bc, hbc, gbc, pl)
 
 	uiEvents := ui.PollEvents()
 	for {
@@ -38,5 +75,8 @@ func main() {
 		case "q", "<C-c>":
 			return
 		}
+		if bc.HandleScrollKey(e.ID) || bc.HandleMouse(e) {
+			ui.Render(bc)
+		}
 	}
 }
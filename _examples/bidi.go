@@ -0,0 +1,67 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// draws Hebrew and Arabic text mixed with English, and a TextInput to type some; Escape quits
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	p := widgets.NewParagraph()
+	p.Title = "Paragraph"
+	p.Text = "English text is drawn left to right.\n" +
+		"עברית נכתבת מימין לשמאל, גם עם [מספרים](fg:yellow) כמו 2024.\n" +
+		"العربية أيضا (مع أرقام ١٢٣) تكتب من اليمين إلى اليسار.\n" +
+		"A line starting in English keeps its direction: שלום and مرحبا."
+	p.SetRect(0, 0, 60, 7)
+
+	l := widgets.NewList()
+	l.Title = "List"
+	l.Rows = []string{"one", "שתיים", "ثلاثة", "four (4)"}
+	l.SetRect(0, 7, 30, 13)
+
+	table := widgets.NewTable()
+	table.Title = "Table"
+	table.Rows = [][]string{
+		{"English", "עברית", "العربية"},
+		{"hello", "שלום", "مرحبا"},
+	}
+	table.SetRect(30, 7, 60, 13)
+
+	input := widgets.NewTextInput()
+	input.Title = "TextInput, aligned right"
+	input.Direction = ui.DirectionRTL
+	input.Focused = true
+	input.SetText("כתבו כאן")
+	input.SetRect(0, 13, 60, 16)
+
+	draw := func() {
+		ui.Render(p, l, table, input)
+	}
+	draw()
+
+	for e := range ui.PollEvents() {
+		switch {
+		case e.ID == "<Escape>" || e.ID == "<C-c>":
+			return
+		case e.Type == ui.PasteEvent:
+			input.HandlePaste(e.Payload.(ui.Paste).Text)
+		case e.Type == ui.KeyboardEvent:
+			input.HandleKey(e.ID)
+		}
+		draw()
+	}
+}
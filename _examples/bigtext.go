@@ -0,0 +1,67 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	headline := widgets.NewBigText()
+	headline.Title = "Large, gradient"
+	headline.Text = "99.9%"
+	headline.Size = widgets.BigTextLarge
+	headline.Colors = []ui.Color{ui.NewRGBColor(0x00, 0xc8, 0xff), ui.NewRGBColor(0xc8, 0x00, 0xff)}
+	headline.Gradient = true
+	headline.TextAlignment = ui.AlignCenter
+	headline.SetRect(0, 0, 70, 12)
+
+	countdown := widgets.NewBigText()
+	countdown.Title = "Medium"
+	countdown.TextAlignment = ui.AlignCenter
+	countdown.SetRect(0, 12, 35, 19)
+
+	banner := widgets.NewBigText()
+	banner.Title = "Small, per-letter colors"
+	banner.Text = "TERMUI"
+	banner.Size = widgets.BigTextSmall
+	banner.Colors = []ui.Color{ui.ColorRed, ui.ColorYellow, ui.ColorGreen, ui.ColorCyan, ui.ColorBlue, ui.ColorMagenta}
+	banner.TextAlignment = ui.AlignCenter
+	banner.SetRect(35, 12, 70, 19)
+
+	end := time.Now().Add(10 * time.Minute)
+	draw := func() {
+		left := time.Until(end).Round(time.Second)
+		countdown.Text = fmt.Sprintf("%02d:%02d", int(left.Minutes()), int(left.Seconds())%60)
+		ui.Render(headline, countdown, banner)
+	}
+	draw()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	events := ui.PollEvents()
+	for {
+		select {
+		case e := <-events:
+			if e.ID == "q" || e.ID == "<C-c>" {
+				return
+			}
+		case <-ticker.C:
+			draw()
+		}
+	}
+}
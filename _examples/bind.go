@@ -0,0 +1,65 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// sample sends a signal to ch every interval.
+func sample(ch chan<- float64, interval time.Duration, signal func(t float64) float64) {
+	start := time.Now()
+	for range time.Tick(interval) {
+		ch <- signal(time.Since(start).Seconds())
+	}
+}
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	plot := widgets.NewPlot()
+	plot.Title = "Bound plot (q quits)"
+	plot.SetRect(0, 0, 60, 15)
+
+	sparkline := widgets.NewSparkline()
+	sparkline.LineColor = ui.ColorMagenta
+	sparklines := widgets.NewSparklineGroup(sparkline)
+	sparklines.Title = "Bound sparkline"
+	sparklines.SetRect(0, 15, 60, 20)
+
+	gauge := widgets.NewGauge()
+	gauge.Title = "Bound gauge"
+	gauge.SetRect(0, 20, 60, 23)
+
+	sine, noise, load, level := make(chan float64), make(chan float64), make(chan float64), make(chan float64)
+	go sample(sine, 50*time.Millisecond, func(t float64) float64 { return math.Sin(t * 2) })
+	// a fast source, whose values are drawn in batches
+	go sample(noise, 5*time.Millisecond, func(t float64) float64 { return math.Cos(t) + rand.Float64()/2 })
+	go sample(load, 100*time.Millisecond, func(t float64) float64 { return 1 + rand.Float64() })
+	go sample(level, 200*time.Millisecond, func(t float64) float64 { return 50 + 50*math.Sin(t/2) })
+
+	widgets.Bind(plot, 0, sine)
+	widgets.Bind(plot, 1, noise).SetWindow(100)
+	widgets.Bind(sparklines, 0, load)
+	widgets.Bind(gauge, 0, level)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		}
+	}
+}
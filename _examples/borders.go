@@ -0,0 +1,92 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	items := []ui.Drawable{}
+	for i, preset := range []struct {
+		name string
+		set  ui.BorderSet
+	}{
+		{"Single", ui.BorderSingle},
+		{"Rounded", ui.BorderRounded},
+		{"Double", ui.BorderDouble},
+		{"Thick", ui.BorderThick},
+		{"ASCII", ui.BorderASCII},
+	} {
+		p := widgets.NewParagraph()
+		p.Title = preset.name
+		p.Text = "BorderSet: " + preset.name
+		p.BorderSet = preset.set
+		p.SetRect(i*16, 0, i*16+16, 5)
+		items = append(items, p)
+	}
+
+	// two panes sharing the line between them, the left one drawing it in its own color
+	focused := ui.NewStyle(ui.ColorCyan)
+	left := widgets.NewParagraph()
+	left.Title = "Left"
+	left.Text = "Its right side is drawn in cyan."
+	left.BorderRightStyle = &focused
+	left.SetRect(0, 5, 40, 12)
+	right := widgets.NewParagraph()
+	right.Title = "Right"
+	right.Text = "It leaves out its left side."
+	right.BorderLeft = false
+	right.SetRect(40, 5, 80, 12)
+	items = append(items, left, right)
+
+	// titles aligned on both borders, and a badge counting unread messages
+	inbox := widgets.NewList()
+	inbox.Title = "Inbox"
+	inbox.TitleAlignment = ui.AlignCenter
+	inbox.BottomTitle = "j/k to scroll"
+	inbox.BottomTitleAlignment = ui.AlignRight
+	inbox.Badge = "3 new"
+	inbox.Rows = []string{"Meeting at noon", "Build failed", "Lunch?"}
+	inbox.BorderSet = ui.BorderRounded
+	inbox.SetPadding(0, 1)
+	inbox.SetMargin(1, 2)
+	inbox.SetRect(0, 12, 44, 20)
+	items = append(items, inbox)
+
+	// an empty pane with a shaded background and a watermark
+	empty := widgets.NewTable()
+	empty.Title = "Results"
+	empty.Background = ui.NewCell(ui.SHADED_BLOCKS[1], ui.NewStyle(ui.Color(8)))
+	empty.Watermark = "NO DATA"
+	empty.SetRect(44, 12, 80, 20)
+	items = append(items, empty)
+
+	// a popup floating over the panes, casting a shadow on them
+	popup := widgets.NewParagraph()
+	popup.Title = "Popup"
+	popup.Text = "Press any key to quit."
+	popup.Shadow = true
+	popup.BorderSet = ui.BorderDouble
+	popup.SetRect(24, 8, 56, 13)
+	ui.ShowOverlay(popup)
+
+	ui.Render(items...)
+	for e := range ui.PollEvents() {
+		if e.Type == ui.KeyboardEvent {
+			break
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// latencies returns samples around median with a long tail.
+func latencies(median, spread float64) []float64 {
+	samples := make([]float64, 200)
+	for i := range samples {
+		samples[i] = median + rand.NormFloat64()*spread
+		if rand.Intn(20) == 0 {
+			samples[i] += rand.ExpFloat64() * spread * 6
+		}
+	}
+	return samples
+}
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	bp := widgets.NewBoxPlot()
+	bp.Title = "Request latency (ms)"
+	bp.Labels = []string{"baseline", "pooled", "cached", "batched"}
+	bp.Samples = [][]float64{
+		latencies(42, 6),
+		latencies(35, 5),
+		latencies(12, 3),
+		latencies(28, 9),
+	}
+	bp.SetRect(0, 0, 70, 11)
+
+	summary := widgets.NewBoxPlot()
+	summary.Title = "Precomputed quartiles"
+	summary.Labels = []string{"p50", "p99"}
+	summary.Stats = []widgets.BoxPlotStats{
+		{Min: 1, Q1: 3, Median: 4, Q3: 6, Max: 9},
+		{Min: 8, Q1: 11, Median: 14, Q3: 18, Max: 25, Outliers: []float64{31, 40}},
+	}
+	summary.NumFormatter = func(n float64) string { return fmt.Sprintf("%gs", n) }
+	summary.SetRect(0, 11, 70, 18)
+
+	ui.Render(bp, summary)
+
+	for e := range ui.PollEvents() {
+		if e.Type == ui.KeyboardEvent {
+			break
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"strings"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	crumbs := widgets.NewBreadcrumbs()
+	crumbs.SetPath("/api/v1/organizations/acme/projects/website/deployments/latest", "/")
+	crumbs.Focused = true
+	crumbs.SetRect(0, 0, 50, 1)
+
+	p := widgets.NewParagraph()
+	p.Title = "Resource"
+	p.Text = "<Left> and <Right> move, <Enter> selects an ancestor, <Backspace> goes up, q quits"
+	p.SetRect(0, 1, 50, 8)
+
+	crumbs.OnChange = func(segments []string) {
+		p.Text = "GET /" + strings.Join(segments, "/")
+	}
+
+	ui.Render(crumbs, p)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		}
+		switch e.Type {
+		case ui.KeyboardEvent:
+			crumbs.HandleKey(e.ID)
+		case ui.MouseEvent:
+			crumbs.HandleMouse(e)
+		}
+		ui.Render(crumbs, p)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	count := 0
+	counter := widgets.NewParagraph()
+	counter.Title = "Counter"
+	counter.SetRect(0, 0, 36, 3)
+
+	increment := widgets.NewButton("+1")
+	increment.SetRect(0, 3, 12, 6)
+	reset := widgets.NewButton("Reset")
+	reset.SetRect(12, 3, 24, 6)
+	quit := widgets.NewButton("Quit")
+	quit.SetRect(24, 3, 36, 6)
+
+	update := func() {
+		counter.Text = fmt.Sprintf("%d", count)
+	}
+	update()
+	done := false
+	increment.OnClick = func() { count++; update() }
+	reset.OnClick = func() { count = 0; update() }
+	quit.OnClick = func() { done = true }
+
+	buttons := []*widgets.Button{increment, reset, quit}
+	focus := 0
+	buttons[focus].Focused = true
+	render := func() {
+		ui.Render(counter, increment, reset, quit)
+	}
+	render()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "<Tab>", "<Right>", "l":
+			buttons[focus].Focused = false
+			focus = (focus + 1) % len(buttons)
+			buttons[focus].Focused = true
+		case "<Left>", "h":
+			buttons[focus].Focused = false
+			focus = (focus + len(buttons) - 1) % len(buttons)
+			buttons[focus].Focused = true
+		default:
+			used := buttons[focus].HandleKey(e.ID)
+			for _, button := range buttons {
+				used = button.HandleMouse(e) || used
+			}
+			if !used {
+				continue
+			}
+		}
+		if done {
+			return
+		}
+		render()
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	today := time.Now()
+
+	stay := widgets.NewCalendar()
+	stay.Title = "Stay"
+	stay.Range = true
+	stay.Highlighted = []time.Time{today.AddDate(0, 0, 3), today.AddDate(0, 0, 10)}
+	stay.SetRect(0, 0, 24, 10)
+
+	invoice := widgets.NewDatePicker()
+	invoice.Title = "Invoice date"
+	invoice.SetRect(25, 0, 49, 3)
+
+	p := widgets.NewParagraph()
+	p.Text = "<Tab> switches widgets, <PageUp> and <PageDown> change months, q quits"
+	p.SetRect(0, 10, 49, 14)
+
+	stay.OnSelect = func(start, end time.Time) {
+		p.Text = fmt.Sprintf("Staying %s to %s", start.Format("Jan 2"), end.Format("Jan 2"))
+	}
+	invoice.OnChange = func(date time.Time) {
+		p.Text = fmt.Sprintf("Invoicing on %s", date.Format("Monday, January 2"))
+	}
+
+	inputs := []widgets.FormInput{stay, invoice}
+	focus := 0
+	inputs[focus].SetFocused(true)
+	render := func() {
+		ui.Render(stay, invoice, p)
+	}
+	render()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "<C-c>":
+			return
+		case "q":
+			if !invoice.IsOpen() {
+				return
+			}
+		case "<Tab>":
+			inputs[focus].SetFocused(false)
+			focus = (focus + 1) % len(inputs)
+			inputs[focus].SetFocused(true)
+			render()
+			continue
+		}
+		used := inputs[focus].HandleKey(e.ID)
+		used = stay.HandleMouse(e) || used
+		used = invoice.HandleMouse(e) || used
+		if used {
+			render()
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"math/rand"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	heatmap := widgets.NewCalendarHeatmap()
+	heatmap.Title = "Contributions (arrows move, q quits)"
+	heatmap.Focused = true
+	heatmap.NumFormatter = func(n float64) string {
+		if n == 1 {
+			return "1 contribution"
+		}
+		return widgets.FormatNumber(n) + " contributions"
+	}
+	today := time.Now()
+	for date := heatmap.Start; !date.After(today); date = date.AddDate(0, 0, 1) {
+		value := 0.0
+		if date.Weekday() != time.Saturday && date.Weekday() != time.Sunday {
+			value = float64(rand.Intn(12))
+		}
+		heatmap.SetValue(date, value)
+	}
+	heatmap.SetRect(0, 0, 112, 11)
+
+	ui.Render(heatmap)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "<Resize>":
+			payload := e.Payload.(ui.Resize)
+			heatmap.SetRect(0, 0, payload.Width, 11)
+			ui.Clear()
+		}
+		if heatmap.HandleKey(e.ID) || heatmap.HandleMouse(e) || e.ID == "<Resize>" {
+			ui.Render(heatmap)
+		}
+	}
+}
@@ -5,6 +5,7 @@ package main
 import (
 	"image"
 	"log"
+	"math"
 
 	ui "github.com/reaalkhalil/termui"
 )
@@ -18,8 +19,18 @@ func main() {
 	c := ui.NewCanvas()
 	c.SetRect(0, 0, 50, 50)
 	c.SetLine(image.Pt(0, 0), image.Pt(10, 20), ui.ColorWhite)
+	c.SetCircle(image.Pt(40, 40), 20, ui.ColorGreen)
+	c.SetEllipse(image.Pt(40, 40), 30, 10, ui.ColorYellow)
+	c.SetArc(image.Pt(40, 40), 12, math.Pi, 2*math.Pi, ui.ColorRed)
+	c.SetAlignedText(image.Pt(40, 40), "center", ui.NewStyle(ui.ColorWhite), ui.AlignCenter)
 
-	ui.Render(c)
+	blocks := ui.NewCanvas()
+	blocks.Title = "Sextants"
+	blocks.Mode = ui.CanvasSextant
+	blocks.SetRect(50, 0, 80, 20)
+	blocks.SetCircle(image.Pt(130, 30), 20, ui.ColorCyan)
+
+	ui.Render(c, blocks)
 
 	for e := range ui.PollEvents() {
 		if e.Type == ui.KeyboardEvent {
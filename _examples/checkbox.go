@@ -0,0 +1,97 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	labels := []string{"Show hidden files", "Follow symlinks", "Compress archives"}
+	boxes := []*widgets.Checkbox{}
+	for i, label := range labels {
+		box := widgets.NewCheckbox(label)
+		box.SetRect(0, 3*i, 30, 3*i+3)
+		boxes = append(boxes, box)
+	}
+
+	// the last box reflects the others, partially checked when only some of them are
+	all := widgets.NewCheckbox("All of the above")
+	all.TriState = true
+	all.SetRect(0, 3*len(boxes), 30, 3*len(boxes)+3)
+	update := func() {
+		checked := 0
+		for _, box := range boxes {
+			if box.Checked() {
+				checked++
+			}
+		}
+		switch checked {
+		case 0:
+			all.State = widgets.CheckboxUnchecked
+		case len(boxes):
+			all.State = widgets.CheckboxChecked
+		default:
+			all.State = widgets.CheckboxPartial
+		}
+	}
+	for _, box := range boxes {
+		box.OnChange = func(widgets.CheckboxState) { update() }
+	}
+	all.OnChange = func(state widgets.CheckboxState) {
+		if state == widgets.CheckboxPartial {
+			all.State = widgets.CheckboxUnchecked
+		}
+		for _, box := range boxes {
+			box.State = all.State
+		}
+	}
+
+	items := append(boxes, all)
+	focus := 0
+	items[focus].Focused = true
+	render := func() {
+		drawables := []ui.Drawable{}
+		for _, item := range items {
+			drawables = append(drawables, item)
+		}
+		ui.Render(drawables...)
+	}
+	render()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "j", "<Down>", "<Tab>":
+			items[focus].Focused = false
+			focus = (focus + 1) % len(items)
+			items[focus].Focused = true
+		case "k", "<Up>":
+			items[focus].Focused = false
+			focus = (focus + len(items) - 1) % len(items)
+			items[focus].Focused = true
+		default:
+			used := items[focus].HandleKey(e.ID)
+			for _, item := range items {
+				used = item.HandleMouse(e) || used
+			}
+			if !used {
+				continue
+			}
+		}
+		render()
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	local := widgets.NewClock()
+	local.Title = "Local"
+	local.ShowDate = true
+	local.SetRect(0, 0, 50, 10)
+
+	tokyo := widgets.NewClock()
+	tokyo.Title = "Tokyo"
+	tokyo.Analog = true
+	tokyo.ShowDate = true
+	if location, err := time.LoadLocation("Asia/Tokyo"); err == nil {
+		tokyo.Location = location
+	}
+	tokyo.SetRect(50, 0, 76, 20)
+
+	countdown := widgets.NewClock()
+	countdown.Title = "Tea (space pauses, r resets)"
+	countdown.Mode = widgets.ClockCountdown
+	countdown.Duration = 3 * time.Minute
+	countdown.Colors = []ui.Color{ui.ColorGreen, ui.ColorYellow}
+	countdown.Gradient = true
+	countdown.SetRect(0, 10, 50, 20)
+	countdown.OnDone = func() {
+		countdown.Title = "Tea is ready"
+	}
+
+	stopwatch := widgets.NewClock()
+	stopwatch.Title = "Stopwatch (s starts and stops)"
+	stopwatch.Mode = widgets.ClockStopwatch
+	stopwatch.ShowTenths = true
+	stopwatch.Interval = 100 * time.Millisecond
+	stopwatch.Size = widgets.BigTextSmall
+	stopwatch.SetRect(0, 20, 76, 26)
+
+	clocks := []ui.Drawable{local, tokyo, countdown, stopwatch}
+	render := func() {
+		ui.Render(clocks...)
+	}
+	for _, clock := range []*widgets.Clock{local, tokyo, countdown} {
+		clock.OnFrame = render
+		clock.Start()
+	}
+	stopwatch.OnFrame = render
+	render()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "<Space>":
+			if countdown.Running() {
+				countdown.Stop()
+			} else {
+				countdown.Start()
+			}
+		case "r":
+			countdown.Reset()
+			countdown.Title = "Tea (space pauses, r resets)"
+		case "s":
+			if stopwatch.Running() {
+				stopwatch.Stop()
+			} else {
+				stopwatch.Start()
+			}
+		}
+		render()
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	picker := widgets.NewColorPicker()
+	picker.Title = "1: 16 colors, 2: 256 colors, 3: true color"
+	picker.Focused = true
+	picker.SetRect(0, 0, 46, 20)
+
+	p := widgets.NewParagraph()
+	p.Title = "Preview"
+	p.Text = "Pick a color with <Enter> to restyle this text"
+	p.SetRect(0, 20, 46, 24)
+	picker.OnSelect = func(color ui.Color) {
+		p.TextStyle = ui.NewStyle(color)
+		p.BorderStyle = ui.NewStyle(color)
+	}
+
+	ui.Render(picker, p)
+
+	palettes := map[string]widgets.ColorPalette{
+		"1": widgets.Palette16,
+		"2": widgets.Palette256,
+		"3": widgets.PaletteTrueColor,
+	}
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		}
+		if palette, ok := palettes[e.ID]; ok {
+			picker.Palette = palette
+		} else if !picker.HandleKey(e.ID) && !picker.HandleMouse(e) {
+			continue
+		}
+		ui.Render(picker, p)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	p := widgets.NewParagraph()
+	p.Title = "Editor"
+	p.Text = "Press <C-p> to open the command palette"
+
+	grid := ui.NewGrid()
+	width, height := ui.TerminalDimensions()
+	grid.SetRect(0, 0, width, height)
+	grid.Set(ui.NewRow(1, p))
+
+	palette := widgets.NewCommandPalette()
+	palette.SetRect(width/2-25, 2, width/2+25, 14)
+
+	quit := false
+	status := func(text string) func() {
+		return func() { p.Text = text }
+	}
+	keymap := ui.DefaultKeymap
+	keymap.Bind("File", "Save", status("Saved"), "<C-s>")
+	keymap.Bind("File", "Open recent", status("Opened a recent file"), "<C-r>")
+	keymap.Bind("File", "Quit", func() { quit = true }, "<C-q>")
+	keymap.Bind("View", "Toggle border", func() { p.Border = !p.Border }, "<C-b>")
+	keymap.Bind("View", "Command palette", palette.Open, "<C-p>")
+	keymap.Bind("Edit", "Uppercase selection", status("Uppercased the selection"))
+	keymap.Bind("Edit", "Sort lines", status("Sorted the lines"))
+	palette.OnExecute = func(binding *ui.KeyBinding) {
+		p.Title = fmt.Sprintf("Editor - ran %q", binding.Description)
+	}
+
+	ui.Render(grid)
+
+	for e := range ui.PollEvents() {
+		switch {
+		case e.ID == "<C-c>" && !palette.IsOpen():
+			return
+		case palette.IsOpen():
+			if !palette.HandleKey(e.ID) && !palette.HandleMouse(e) {
+				continue
+			}
+		case e.ID == "<Resize>":
+			payload := e.Payload.(ui.Resize)
+			grid.SetRect(0, 0, payload.Width, payload.Height)
+			ui.Clear()
+		case !keymap.Handle(e.ID):
+			continue
+		}
+		if quit {
+			return
+		}
+		ui.Render(grid)
+	}
+}
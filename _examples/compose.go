@@ -0,0 +1,91 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	series := [][]float64{make([]float64, 200), make([]float64, 200)}
+	for i := range series[0] {
+		series[0][i] = 1 + math.Sin(float64(i)/5)
+		series[1][i] = 1 + math.Cos(float64(i)/5)
+	}
+	colors := []ui.Color{ui.ColorGreen, ui.ColorYellow}
+	colorNames := []string{"green", "yellow"}
+	names := []string{"sin", "cos"}
+
+	// the plot is drawn in the body left by its toolbar, legend, and status line
+	plot := widgets.NewPlot()
+	plot.Title = "Composed chart"
+	plot.AxesColor = ui.ColorWhite
+
+	toolbar := widgets.NewParagraph()
+	toolbar.Border = false
+	toolbar.Text = "[1/2](fg:yellow) toggle series  [q](fg:yellow) quit"
+	plot.Attach("toolbar", ui.RegionHeader, 1, toolbar)
+
+	legend := widgets.NewList()
+	legend.Title = "Legend"
+	plot.Attach("legend", ui.RegionSidebarRight, 14, legend)
+
+	status := widgets.NewParagraph()
+	status.Border = false
+	plot.Attach("status", ui.RegionFooter, 1, status)
+
+	shown := []bool{true, true}
+	update := func() {
+		plot.Data, plot.LineColors, legend.Rows = nil, nil, nil
+		for i, name := range names {
+			legend.Rows = append(legend.Rows, fmt.Sprintf("[%s](fg:%s)", name, colorNames[i]))
+			if shown[i] {
+				plot.Data = append(plot.Data, series[i])
+				plot.LineColors = append(plot.LineColors, colors[i])
+			}
+		}
+		status.Text = fmt.Sprintf("Showing %d of %d series", len(plot.Data), len(series))
+	}
+	update()
+
+	termWidth, termHeight := ui.TerminalDimensions()
+	plot.SetRect(0, 0, termWidth, termHeight)
+	ui.Render(plot)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "1", "2":
+			shown[e.ID[0]-'1'] = !shown[e.ID[0]-'1']
+			update()
+		case "<Resize>":
+			payload := e.Payload.(ui.Resize)
+			plot.SetRect(0, 0, payload.Width, payload.Height)
+			ui.Clear()
+		default:
+			// clicks go to the child under the mouse, toggling the series clicked in the legend
+			if p, ok := ui.MousePoint(e); ok && e.ID == "<MouseLeft>" {
+				if name, _, ok := plot.ChildAt(p); ok && name == "legend" && legend.HandleMouse(e) {
+					shown[legend.SelectedRow] = !shown[legend.SelectedRow]
+					update()
+				}
+			}
+		}
+		ui.Render(plot)
+	}
+}
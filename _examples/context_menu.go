@@ -0,0 +1,83 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"image"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	width, height := ui.TerminalDimensions()
+
+	files := widgets.NewList()
+	files.Title = "Files (right-click or press m)"
+	files.Rows = []string{"README.md", "main.go", "go.mod", "go.sum", "Makefile"}
+	files.SetRect(0, 0, 40, 10)
+
+	p := widgets.NewParagraph()
+	p.SetRect(0, 10, 40, 13)
+
+	status := func(verb string) func() {
+		return func() {
+			p.Text = fmt.Sprintf("%s %s", verb, files.Rows[files.SelectedRow])
+		}
+	}
+	menu := widgets.NewContextMenu(
+		&widgets.MenuItem{Label: "Open", Action: status("Opened")},
+		&widgets.MenuItem{Label: "Open With", Mnemonic: 'w', Submenu: []*widgets.MenuItem{
+			{Label: "Editor", Action: status("Edited")},
+			{Label: "Pager", Action: status("Paged")},
+		}},
+		widgets.NewMenuSeparator(),
+		&widgets.MenuItem{Label: "Rename", Shortcut: "r", Action: status("Renamed")},
+		&widgets.MenuItem{Label: "Delete", Shortcut: "d", Action: status("Deleted")},
+		&widgets.MenuItem{Label: "Properties", Disabled: true},
+	)
+	menu.Bounds = image.Rect(0, 0, width, height)
+
+	render := func() {
+		ui.Render(files, p)
+	}
+	render()
+
+	for e := range ui.PollEvents() {
+		if menu.HandleKey(e.ID) {
+			render()
+			continue
+		}
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "j", "<Down>":
+			files.ScrollDown()
+		case "k", "<Up>":
+			files.ScrollUp()
+		case "m":
+			// open the menu beside the selected row
+			rect := files.GetRect()
+			menu.OpenAt(image.Pt(rect.Min.X+12, rect.Min.Y+1+files.SelectedRow))
+		default:
+			if e.ID == "<MouseRight>" {
+				files.HandleMouse(ui.Event{Type: e.Type, ID: "<MouseLeft>", Payload: e.Payload})
+			}
+			if !menu.HandleMouse(e, files.Inner) && !files.HandleMouse(e) {
+				continue
+			}
+		}
+		render()
+	}
+}
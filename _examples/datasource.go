@@ -0,0 +1,88 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+// Plots metrics of this program published with expvar, and shows the targets of a Prometheus
+// server when its address is given, like "http://localhost:9090".
+
+package main
+
+import (
+	"expvar"
+	"log"
+	"math/rand"
+	"os"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/datasource"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	// some work to measure
+	queue := expvar.NewInt("queue")
+	go func() {
+		garbage := [][]byte{}
+		for range time.Tick(20 * time.Millisecond) {
+			garbage = append(garbage, make([]byte, rand.Intn(1<<16)))
+			if len(garbage) > 200 {
+				garbage = garbage[100:]
+			}
+			queue.Set(int64(len(garbage) / 2))
+		}
+	}()
+
+	heap := widgets.NewPlot()
+	heap.Title = "Heap allocated, bytes (q quits)"
+	heap.SetRect(0, 0, 60, 12)
+
+	gauge := widgets.NewGauge()
+	gauge.Title = "Queue"
+	gauge.SetRect(0, 12, 60, 15)
+
+	poller := datasource.NewPoller(250 * time.Millisecond)
+	poller.Value(heap, 0, datasource.Expvar("memstats.HeapAlloc"))
+	poller.Value(gauge, 0, datasource.Expvar("queue"))
+
+	items := []ui.Drawable{heap, gauge}
+	if len(os.Args) > 1 {
+		targets := widgets.NewTable()
+		targets.Title = "Prometheus targets"
+		targets.Header = []string{"job", "instance", "up"}
+		targets.SetRect(0, 15, 60, 25)
+		items = append(items, targets)
+
+		errors := widgets.NewParagraph()
+		errors.Title = "Errors"
+		errors.SetRect(0, 25, 60, 28)
+		items = append(items, errors)
+		poller.OnError = func(err error) {
+			ui.Update(func() {
+				errors.Text = err.Error()
+			})
+			ui.Render(errors)
+		}
+
+		prometheus := datasource.NewPrometheus(os.Args[1])
+		poller.Rows(targets, prometheus.Rows("up", "%.0f", "job", "instance"))
+	}
+	ui.Render(items...)
+
+	poller.Start()
+	defer poller.Stop()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		}
+	}
+}
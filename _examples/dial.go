@@ -0,0 +1,63 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"math"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	cpu := widgets.NewDial()
+	cpu.Title = "CPU"
+	cpu.Label = "percent"
+	cpu.Zones = []widgets.DialZone{
+		{Min: 70, Max: 90, Color: ui.ColorYellow},
+		{Min: 90, Max: 100, Color: ui.ColorRed},
+	}
+	cpu.SetRect(0, 0, 30, 15)
+
+	rate := widgets.NewDial()
+	rate.Title = "Requests"
+	rate.Label = "req/s"
+	rate.MaxVal = 2000
+	rate.ArcWidth = 3
+	rate.ArcColor = ui.ColorGreen
+	rate.Zones = []widgets.DialZone{{Min: 1500, Max: 2000, Color: ui.ColorRed}}
+	rate.SetRect(30, 0, 60, 15)
+
+	update := func(t float64) {
+		cpu.Value = 55 + 40*math.Sin(t/3)
+		rate.Value = 1000 + 900*math.Sin(t/5)
+		ui.Render(cpu, rate)
+	}
+	start := time.Now()
+	update(0)
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	events := ui.PollEvents()
+	for {
+		select {
+		case e := <-events:
+			if e.ID == "q" || e.ID == "<C-c>" {
+				return
+			}
+		case now := <-ticker.C:
+			update(now.Sub(start).Seconds())
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/dialogs"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	p := widgets.NewParagraph()
+	p.Title = "Dialogs"
+	p.Text = "c: confirm, r: rename, m: message, q: quit"
+	p.SetRect(0, 0, 60, 8)
+
+	for e := range ui.PollEvents() {
+		if dialogs.HandleEvent(e) {
+			ui.Render(p)
+			continue
+		}
+		if e.Type != ui.KeyboardEvent {
+			continue
+		}
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "c":
+			dialogs.Confirm("Delete", "Delete the 3 selected files? This can't be undone.", func(ok bool) {
+				p.Text = fmt.Sprintf("Delete: %v", ok)
+			})
+		case "r":
+			dialogs.Prompt("Rename", "New name:", "report.txt", func(text string, ok bool) {
+				if ok {
+					p.Text = fmt.Sprintf("Renamed to %q", text)
+				} else {
+					p.Text = "Rename cancelled"
+				}
+			})
+		case "m":
+			dialogs.Message("Saved", "The report was saved.", nil)
+		}
+		ui.Render(p)
+	}
+}
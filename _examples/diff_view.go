@@ -0,0 +1,99 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+const before = `package main
+
+import "fmt"
+
+func main() {
+	names := []string{"Alice", "Bob"}
+	for _, name := range names {
+		fmt.Println("Hello", name)
+	}
+}
+
+func unused() {
+	return
+}
+`
+
+const after = `package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func main() {
+	names := []string{"Alice", "Bob", "Carol"}
+	for _, name := range names {
+		fmt.Println("Hello,", strings.ToUpper(name))
+	}
+}
+`
+
+const patch = `--- a/greet.go
++++ b/greet.go
+@@ -1,6 +1,6 @@
+ package greet
+ 
+-func Greet(name string) string {
+-	return "Hello " + name
++func Greet(name, greeting string) string {
++	return greeting + " " + name
+ }
+ 
+`
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	texts := widgets.NewDiffView()
+	texts.Title = "Texts (j/k scroll, n/p hunks, <Tab> switches, q quits)"
+	texts.LeftTitle, texts.RightTitle = "before.go", "after.go"
+	texts.SetTexts(before, after)
+	texts.SetRect(0, 0, 100, 18)
+
+	unified := widgets.NewDiffView()
+	unified.Title = "Unified diff"
+	if err := unified.SetUnifiedDiff(patch); err != nil {
+		log.Fatalf("failed to parse the diff: %v", err)
+	}
+	unified.SetRect(0, 18, 100, 28)
+
+	focused := texts
+	ui.Render(texts, unified)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "<Tab>":
+			if focused == texts {
+				focused = unified
+			} else {
+				focused = texts
+			}
+		default:
+			if !focused.HandleKey(e.ID) && !focused.HandleMouse(e) {
+				continue
+			}
+		}
+		ui.Render(texts, unified)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+type nodeValue string
+
+func (nv nodeValue) String() string {
+	return string(nv)
+}
+
+// items are dragged with the mouse between the list and the tree, and within each of them
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	inbox := widgets.NewList()
+	inbox.Title = "Inbox"
+	inbox.Rows = []string{"invoice.pdf", "notes.txt", "photo.jpg", "report.docx", "song.mp3"}
+	inbox.SetRect(0, 0, 25, 12)
+
+	folders := widgets.NewTree()
+	folders.Title = "Folders"
+	folders.SetNodes([]*widgets.TreeNode{
+		{Value: nodeValue("Documents"), Expanded: true, Nodes: []*widgets.TreeNode{
+			{Value: nodeValue("letter.txt")},
+		}},
+		{Value: nodeValue("Music"), Nodes: []*widgets.TreeNode{
+			{Value: nodeValue("album.flac")},
+		}},
+		{Value: nodeValue("Pictures"), Nodes: []*widgets.TreeNode{
+			{Value: nodeValue("cat.png")},
+		}},
+	})
+	folders.SetRect(25, 0, 55, 12)
+
+	// only files can be dropped into the inbox, not folders
+	inbox.OnDrop = func(item ui.DragItem, row int) bool {
+		node, ok := item.Value.(*widgets.TreeNode)
+		return !ok || len(node.Nodes) == 0
+	}
+
+	dnd := ui.NewDragDrop()
+	dnd.Sources = []ui.DragSource{inbox, folders}
+	dnd.Targets = []ui.DropTarget{inbox, folders}
+
+	ui.Render(inbox, folders)
+	for e := range ui.PollEvents() {
+		switch e.Type {
+		case ui.KeyboardEvent:
+			switch e.ID {
+			case "q", "<C-c>":
+				return
+			case "<Escape>":
+				dnd.Cancel()
+			}
+		case ui.MouseEvent:
+			if !dnd.HandleMouse(e) && e.ID == "<MouseLeft>" {
+				inbox.HandleMouse(e)
+			}
+		}
+		ui.Render(inbox, folders)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/export"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// Writes a snapshot of a small dashboard to dashboard.html and an animation of it to
+// dashboard_frames.html in the temporary directory, without needing a terminal.
+func main() {
+	gauge := widgets.NewGauge()
+	gauge.Title = "Build"
+	gauge.SetRect(0, 0, 50, 3)
+
+	plot := widgets.NewPlot()
+	plot.Title = "Requests"
+	plot.SetRect(0, 3, 50, 15)
+
+	table := widgets.NewTable()
+	table.Title = "Services"
+	table.Rows = [][]string{
+		{"Service", "Status"},
+		{"api", "[up](fg:green)"},
+		{"billing", "[degraded](fg:yellow)"},
+		{"search", "[down](fg:red)"},
+	}
+	table.SetRect(50, 0, 75, 15)
+
+	update := func(step int) {
+		gauge.Percent = step * 10
+		plot.Data = [][]float64{make([]float64, 60)}
+		for i := range plot.Data[0] {
+			plot.Data[0][i] = 1 + math.Sin(float64(i+step*4)/6)
+		}
+	}
+
+	html := export.NewHTML()
+	html.Title = "Dashboard"
+
+	update(7)
+	if err := writeFile("dashboard.html", func(f *os.File) error {
+		return html.Write(f, ui.RenderBuffer(gauge, plot, table))
+	}); err != nil {
+		log.Fatal(err)
+	}
+
+	frames := []*ui.Buffer{}
+	for step := 0; step <= 10; step++ {
+		update(step)
+		frames = append(frames, ui.RenderBuffer(gauge, plot, table))
+	}
+	if err := writeFile("dashboard_frames.html", func(f *os.File) error {
+		return html.WriteFrames(f, frames)
+	}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeFile(name string, write func(*os.File) error) error {
+	path := filepath.Join(os.TempDir(), name)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	log.Printf("wrote %s", path)
+	return f.Close()
+}
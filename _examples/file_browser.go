@@ -0,0 +1,56 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"strings"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	browser := widgets.NewFileBrowser(".")
+	browser.MultiSelect = true
+	browser.Title = browser.Dir
+	browser.SetRect(0, 0, 60, 20)
+
+	status := widgets.NewParagraph()
+	status.Text = "<Enter> opens, <Space> marks, . shows hidden files, s sorts, q quits"
+	status.SetRect(0, 20, 60, 23)
+
+	browser.OnChangeDir = func(dir string) {
+		browser.Title = dir
+	}
+	browser.OnSelect = func(paths []string) {
+		status.Text = "Selected " + strings.Join(paths, ", ")
+	}
+
+	ui.Render(browser, status)
+
+	for e := range ui.PollEvents() {
+		switch e.Type {
+		case ui.KeyboardEvent:
+			if e.ID == "<C-c>" || e.ID == "q" && !browser.Searching() {
+				return
+			}
+			browser.HandleKey(e.ID)
+			if err := browser.Err(); err != nil {
+				status.Text = err.Error()
+			}
+		case ui.MouseEvent:
+			browser.HandleMouse(e)
+		}
+		ui.Render(browser, status)
+	}
+}
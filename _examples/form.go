@@ -0,0 +1,81 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	name := widgets.NewTextInput()
+	email := widgets.NewTextInput()
+	email.Placeholder = "name@example.com"
+	port := widgets.NewTextInput()
+	port.SetText("8080")
+	protocol := widgets.NewSelect()
+	protocol.Options = []string{"http", "https", "grpc", "tcp", "udp"}
+	notes := widgets.NewTextArea()
+	terms := widgets.NewCheckbox("I accept the terms of service")
+
+	form := widgets.NewForm()
+	form.Title = "New service (<Tab> next field, <Enter> submit, <C-c> quit)"
+	form.SetRect(0, 0, 60, 27)
+	form.AddField("Name", "name", name, widgets.Required)
+	form.AddField("Email", "email", email, widgets.Required, func(value interface{}) error {
+		if !strings.Contains(value.(string), "@") {
+			return errors.New("not an email address")
+		}
+		return nil
+	})
+	form.AddField("Port", "port", port, func(value interface{}) error {
+		if n, err := strconv.Atoi(value.(string)); err != nil || n < 1 || n > 65535 {
+			return errors.New("must be a number from 1 to 65535")
+		}
+		return nil
+	})
+	form.AddField("Protocol", "protocol", protocol)
+	form.AddField("Notes", "notes", notes).Height = 6
+	form.AddField("", "terms", terms, widgets.Required)
+
+	result := widgets.NewParagraph()
+	result.Title = "Submitted"
+	result.SetRect(0, 27, 60, 33)
+
+	form.OnSubmit = func(values map[string]interface{}) {
+		result.Text = fmt.Sprintf("name: %s\nemail: %s\nport: %s/%s\nnotes: %q", values["name"], values["email"], values["port"], values["protocol"], values["notes"])
+	}
+
+	ui.Render(form, result)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "<C-c>":
+			return
+		case "<Paste>":
+			if !form.HandlePaste(e.Payload.(ui.Paste).Text) {
+				continue
+			}
+		default:
+			if !form.HandleKey(e.ID) && !form.HandleMouse(e) {
+				continue
+			}
+		}
+		ui.Render(form, result)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	today := time.Now()
+	day := func(days int) time.Time {
+		return today.AddDate(0, 0, days)
+	}
+	task := func(label string, start, end int, dependsOn ...*widgets.GanttTask) *widgets.GanttTask {
+		return &widgets.GanttTask{Label: label, Start: day(start), End: day(end), DependsOn: dependsOn}
+	}
+	design := task("Design", -12, -5)
+	api := task("API", -4, 6, design)
+	frontend := task("Frontend", -2, 10, design)
+	docs := task("Documentation", 3, 12, api)
+	testing := task("Testing", 11, 16, api, frontend)
+	release := task("Release", 17, 18, testing, docs)
+
+	gantt := widgets.NewGantt()
+	gantt.Title = "Release plan (<Up>/<Down> select, <Left>/<Right> scroll, t: today, q quits)"
+	gantt.Tasks = []*widgets.GanttTask{design, api, frontend, docs, testing, release}
+	gantt.SetRect(0, 0, 80, 10)
+
+	p := widgets.NewParagraph()
+	p.SetRect(0, 10, 80, 13)
+	gantt.OnSelect = func(task *widgets.GanttTask) {
+		p.Text = fmt.Sprintf("%s: %s to %s", task.Label, task.Start.Format("Jan 2"), task.End.Format("Jan 2"))
+	}
+
+	ui.Render(gantt, p)
+
+	for e := range ui.PollEvents() {
+		if e.ID == "q" || e.ID == "<C-c>" {
+			return
+		}
+		if gantt.HandleKey(e.ID) || gantt.HandleMouse(e) {
+			ui.Render(gantt, p)
+		}
+	}
+}
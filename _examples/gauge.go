@@ -24,7 +24,11 @@ func main() {
 	g0.Title = "Slim Gauge"
 	g0.SetRect(20, 20, 30, 30)
 	g0.Percent = 75
-	g0.BarColor = ui.ColorRed
+	g0.Thresholds = []widgets.GaugeThreshold{
+		{Percent: 0, Color: ui.ColorGreen},
+		{Percent: 70, Color: ui.ColorYellow},
+		{Percent: 90, Color: ui.ColorRed},
+	}
 	g0.BorderStyle.Fg = ui.ColorWhite
 	g0.TitleStyle.Fg = ui.ColorCyan
 
@@ -59,7 +63,17 @@ func main() {
 	g4.BarColor = ui.ColorGreen
 	g4.LabelStyle = ui.NewStyle(ui.ColorYellow)
 
-	ui.Render(g0, g1, g2, g3, g4)
+	g5 := widgets.NewGauge()
+	g5.Title = "Memory"
+	g5.SetRect(0, 17, 50, 21)
+	g5.Segments = []widgets.GaugeSegment{
+		{Label: "used", Percent: 45, Color: ui.ColorRed},
+		{Label: "cached", Percent: 20, Color: ui.ColorYellow},
+		{Label: "buffers", Percent: 5, Color: ui.ColorBlue},
+	}
+	g5.ShowLegend = true
+
+	ui.Render(g0, g1, g2, g3, g4, g5)
 
 	uiEvents := ui.PollEvents()
 	for {
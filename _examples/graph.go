@@ -0,0 +1,64 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	graph := widgets.NewGraph()
+	graph.Title = "Packages (arrows select, hjkl pan, +/- zoom, f switches layout, q quits)"
+	for _, id := range []string{"main", "server", "handlers", "store", "cache", "sql", "log", "config"} {
+		graph.AddNode(id, id)
+	}
+	graph.AddEdge("main", "server")
+	graph.AddEdge("main", "config")
+	graph.AddEdge("server", "handlers")
+	graph.AddEdge("server", "log")
+	graph.AddEdge("handlers", "store")
+	graph.AddEdge("handlers", "log")
+	graph.AddEdge("store", "cache")
+	graph.AddEdge("store", "sql")
+	graph.AddEdge("sql", "log")
+	graph.AddEdge("config", "log")
+
+	termWidth, termHeight := ui.TerminalDimensions()
+	graph.SetRect(0, 0, termWidth, termHeight)
+
+	ui.Render(graph)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "f":
+			if graph.Layout == widgets.GraphLayered {
+				graph.Layout = widgets.GraphForce
+			} else {
+				graph.Layout = widgets.GraphLayered
+			}
+			graph.ResetView()
+		case "<Resize>":
+			payload := e.Payload.(ui.Resize)
+			graph.SetRect(0, 0, payload.Width, payload.Height)
+		default:
+			if !graph.HandleKey(e.ID) && !graph.HandleMouse(e) {
+				continue
+			}
+		}
+		ui.Render(graph)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	latency := widgets.NewHeatGrid()
+	latency.Title = "p99 latency, ms (arrows move, q quits)"
+	latency.RowLabels = []string{"api", "auth", "billing", "search", "storage", "mail"}
+	latency.ColumnLabels = []string{"us-east", "us-west", "eu-west", "eu-north", "ap-south"}
+	latency.MinVal, latency.MaxVal = 0, 500
+	latency.ShowValues = true
+	latency.Focused = true
+	latency.NumFormatter = func(n float64) string { return fmt.Sprintf("%.0f", n) }
+	for range latency.RowLabels {
+		row := []float64{}
+		for range latency.ColumnLabels {
+			row = append(row, 20+rand.Float64()*rand.Float64()*480)
+		}
+		latency.Values = append(latency.Values, row)
+	}
+	// billing isn't deployed in eu-north
+	latency.Values[2][3] = math.NaN()
+	latency.SetRect(0, 0, 60, 10)
+
+	confusion := widgets.NewHeatGrid()
+	confusion.Title = "Confusion matrix"
+	confusion.RowLabels = []string{"cat", "dog", "bird"}
+	confusion.ColumnLabels = []string{"cat", "dog", "bird"}
+	confusion.Values = [][]float64{{42, 6, 2}, {5, 38, 7}, {1, 3, 46}}
+	confusion.Colors = []ui.Color{ui.NewRGBColor(0x10, 0x20, 0x40), ui.NewRGBColor(0x40, 0xa0, 0xff)}
+	confusion.ShowValues = true
+	confusion.SetRect(0, 10, 30, 16)
+
+	ui.Render(latency, confusion)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		}
+		if latency.HandleKey(e.ID) || latency.HandleMouse(e) {
+			ui.Render(latency)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	width, height := ui.TerminalDimensions()
+
+	files := widgets.NewList()
+	files.Title = "Files"
+	files.Rows = []string{"README.md", "main.go", "go.mod"}
+	files.SetRect(0, 0, width/2, height)
+
+	preview := widgets.NewParagraph()
+	preview.Title = "Preview"
+	preview.Text = "Press ? for help, <Tab> to switch panes"
+	preview.SetRect(width/2, 0, width, height)
+
+	help := widgets.NewHelp()
+	help.SetRect(width/2-25, height/2-8, width/2+25, height/2+8)
+
+	quit := false
+	focusFiles := true
+	keymap := ui.DefaultKeymap
+	keymap.Bind("Global", "Toggle this help", help.Toggle, "?")
+	keymap.Bind("Global", "Switch pane", func() {
+		focusFiles = !focusFiles
+		keymap.SetScopeEnabled("Files", focusFiles)
+		keymap.SetScopeEnabled("Preview", !focusFiles)
+	}, "<Tab>")
+	keymap.Bind("Global", "Quit", func() { quit = true }, "q", "<C-c>")
+	keymap.Bind("Files", "Next file", files.ScrollDown, "j", "<Down>")
+	keymap.Bind("Files", "Previous file", files.ScrollUp, "k", "<Up>")
+	keymap.Bind("Files", "Open file", func() {
+		preview.Text = "Opened " + files.Rows[files.SelectedRow]
+	}, "<Enter>")
+	keymap.Bind("Preview", "Toggle word wrap", func() { preview.WrapText = !preview.WrapText }, "w")
+	keymap.SetScopeEnabled("Preview", false)
+
+	render := func() {
+		files.BorderStyle, preview.BorderStyle = ui.NewStyle(ui.ColorWhite), ui.NewStyle(ui.ColorWhite)
+		if focusFiles {
+			files.BorderStyle = ui.NewStyle(ui.ColorYellow)
+		} else {
+			preview.BorderStyle = ui.NewStyle(ui.ColorYellow)
+		}
+		ui.Render(files, preview)
+	}
+	render()
+
+	for e := range ui.PollEvents() {
+		if help.HandleKey(e.ID) || help.HandleMouse(e) || keymap.Handle(e.ID) {
+			if quit {
+				return
+			}
+			render()
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	data, err := ioutil.ReadFile(os.Args[0])
+	if err != nil {
+		log.Fatalf("failed to read %s: %v", os.Args[0], err)
+	}
+	if len(data) > 1<<16 {
+		data = data[:1<<16]
+	}
+
+	hv := widgets.NewHexView()
+	hv.Title = "Hex View"
+	hv.Data = data
+	hv.Editable = true
+	hv.SetRect(0, 0, 80, 24)
+
+	p := widgets.NewParagraph()
+	p.Text = "Type hex digits to edit, <Tab> switches to the ASCII column, v selects, / searches (try 7f 45 4c 46), <C-c> quits"
+	p.SetRect(0, 24, 80, 28)
+
+	changes := 0
+	hv.OnChange = func(offset int, old, new []byte) {
+		changes++
+		p.Text = fmt.Sprintf("%d changes, last at %08x: % x -> % x", changes, offset, old, new)
+	}
+	hv.OnCursorChange = func(offset int) {
+		p.Title = fmt.Sprintf("Offset %08x (%d)", offset, offset)
+		if start, end, ok := hv.Selection(); ok {
+			p.Title += fmt.Sprintf(", %d bytes selected from %08x", end-start+1, start)
+		}
+	}
+
+	ui.Render(hv, p)
+
+	for e := range ui.PollEvents() {
+		if e.ID == "<C-c>" {
+			return
+		}
+		if e.Type == ui.MouseEvent {
+			hv.HandleMouse(e)
+		} else {
+			hv.HandleKey(e.ID)
+		}
+		ui.Render(hv, p)
+	}
+}
@@ -50,10 +50,15 @@ func main() {
 
 	img := widgets.NewImage(nil)
 	img.SetRect(0, 0, 100, 50)
+	// g switches between the terminal's graphics protocol, if it has one, and drawing with cells
+	graphics := img.Protocol
+	protocols := map[ui.GraphicsProtocol]string{ui.GraphicsSixel: "Sixel", ui.GraphicsKitty: "Kitty", ui.GraphicsITerm: "iTerm2"}
 	index := 0
 	render := func() {
 		img.Image = images[index]
-		if !img.Monochrome {
+		if img.Protocol != ui.GraphicsNone {
+			img.Title = fmt.Sprintf("%s %d/%d", protocols[img.Protocol], index+1, len(images))
+		} else if !img.Monochrome {
 			img.Title = fmt.Sprintf("Color %d/%d", index+1, len(images))
 		} else if !img.MonochromeInvert {
 			img.Title = fmt.Sprintf("Monochrome(%d) %d/%d", img.MonochromeThreshold, index+1, len(images))
@@ -82,6 +87,15 @@ func main() {
 			img.Monochrome = !img.Monochrome
 		case "<Tab>":
 			img.MonochromeInvert = !img.MonochromeInvert
+		case "d":
+			img.Dithering = !img.Dithering
+		case "g":
+			if img.Protocol == ui.GraphicsNone {
+				img.Protocol = graphics
+			} else {
+				img.Protocol = ui.GraphicsNone
+			}
+			ui.Clear()
 		}
 		render()
 	}
@@ -0,0 +1,103 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+const manifest = `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  labels:
+    app: web
+    tier: frontend
+spec:
+  containers:
+  - name: nginx
+    image: nginx:1.19
+    ports:
+    - containerPort: 80
+    resources:
+      limits: {"cpu": "500m", "memory": "128Mi"}
+  - name: log-shipper
+    image: fluent-bit:1.6
+    args: ["-c", "/fluent-bit/etc/fluent-bit.conf"]
+  restartPolicy: Always
+  hostNetwork: false
+  nodeName: ~
+`
+
+const status = `{
+  "phase": "Running",
+  "podIP": "10.1.2.3",
+  "startTime": "2020-06-01T12:00:00Z",
+  "containerStatuses": [
+    {"name": "nginx", "ready": true, "restartCount": 0},
+    {"name": "log-shipper", "ready": false, "restartCount": 3}
+  ]
+}`
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	spec := widgets.NewInspector()
+	spec.Title = "pod.yaml"
+	if err := spec.LoadYAML([]byte(manifest)); err != nil {
+		log.Fatalf("failed to load the manifest: %v", err)
+	}
+	spec.ExpandAll()
+	spec.SetRect(0, 0, 50, 24)
+
+	state := widgets.NewInspector()
+	state.Title = "status.json"
+	if err := state.LoadJSON([]byte(status)); err != nil {
+		log.Fatalf("failed to load the status: %v", err)
+	}
+	state.SetRect(50, 0, 100, 24)
+
+	p := widgets.NewParagraph()
+	p.Text = ": goes to a path like .spec.containers[0].image, / searches, y and Y copy the value and path, <Tab> switches, q quits"
+	p.SetRect(0, 24, 100, 28)
+	copied := func(text string, err error) {
+		if err != nil {
+			p.Text = fmt.Sprintf("Failed to copy: %v", err)
+		} else {
+			p.Text = fmt.Sprintf("Copied %q", text)
+		}
+	}
+	spec.OnCopy, state.OnCopy = copied, copied
+
+	focused := spec
+	ui.Render(spec, state, p)
+
+	for e := range ui.PollEvents() {
+		searching := focused.FindingPath() || focused.Searching()
+		if !searching && (e.ID == "q" || e.ID == "<C-c>") {
+			return
+		}
+		if !searching && e.ID == "<Tab>" {
+			if focused == spec {
+				focused = state
+			} else {
+				focused = spec
+			}
+		} else if !focused.HandleKey(e.ID) {
+			continue
+		}
+		p.Title = focused.SelectedPath()
+		ui.Render(spec, state, p)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// lists the keys pressed, repeated, and released, on terminals supporting the kitty keyboard
+// protocol like kitty, WezTerm, foot, and ghostty
+func main() {
+	ui.KittyKeyboard = true
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	l := widgets.NewList()
+	l.Title = "Keys (q to quit)"
+	l.SetRect(0, 0, 60, 20)
+	ui.Render(l)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		}
+		if e.Type != ui.KeyboardEvent {
+			continue
+		}
+		row := e.ID
+		if key, ok := e.Payload.(ui.Key); ok {
+			row = fmt.Sprintf("%-20s %+v", e.ID, key)
+		}
+		l.Rows = append(l.Rows, row)
+		l.ScrollBottom()
+		ui.Render(l)
+	}
+}
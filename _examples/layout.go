@@ -0,0 +1,120 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// dashboard is used unless a layout file is given as an argument.
+const dashboard = `
+rows:
+  - ratio: 0.25
+    columns:
+      - widget:
+          id: cpu
+          type: Gauge
+          title: CPU
+          options:
+            BarColor: green
+      - widget:
+          id: memory
+          type: Gauge
+          title: Memory
+          options:
+            BarColor: yellow
+  - columns:
+      - ratio: 0.6
+        widget:
+          id: load
+          type: Plot
+          title: Load
+          options:
+            LineColors: ["cyan"]
+      - widget:
+          id: log
+          type: Paragraph
+          title: Log (q quits)
+`
+
+func main() {
+	var layout *widgets.Layout
+	var err error
+	if len(os.Args) > 1 {
+		layout, err = widgets.LoadLayoutFile(os.Args[1])
+	} else {
+		layout, err = widgets.LoadLayout([]byte(dashboard))
+	}
+	if err != nil {
+		log.Fatalf("failed to load the layout: %v", err)
+	}
+
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	width, height := ui.TerminalDimensions()
+	layout.Grid.SetRect(0, 0, width, height)
+
+	// the layout may leave out any of the widgets
+	cpu, _ := layout.Widget("cpu").(*widgets.Gauge)
+	memory, _ := layout.Widget("memory").(*widgets.Gauge)
+	load, _ := layout.Widget("load").(*widgets.Plot)
+	messages, _ := layout.Widget("log").(*widgets.Paragraph)
+
+	samples := []float64{}
+	update := func(tick int) {
+		t := float64(tick) / 10
+		if cpu != nil {
+			cpu.Percent = int(50 + 40*math.Sin(t))
+		}
+		if memory != nil {
+			memory.Percent = 30 + tick%40
+		}
+		samples = append(samples, 2+math.Sin(t)+math.Sin(t*3)/3)
+		if len(samples) > 100 {
+			samples = samples[1:]
+		}
+		if load != nil && len(samples) > 1 {
+			load.Data = [][]float64{samples}
+		}
+		if messages != nil && tick%10 == 0 {
+			messages.Text = fmt.Sprintf("tick %d\n", tick) + messages.Text
+		}
+	}
+	update(0)
+	ui.Render(layout.Grid)
+
+	tick := 0
+	ticker := time.NewTicker(100 * time.Millisecond).C
+	uiEvents := ui.PollEvents()
+	for {
+		select {
+		case e := <-uiEvents:
+			switch e.ID {
+			case "q", "<C-c>":
+				return
+			case "<Resize>":
+				payload := e.Payload.(ui.Resize)
+				layout.Grid.SetRect(0, 0, payload.Width, payload.Height)
+				ui.Clear()
+			}
+		case <-ticker:
+			tick++
+			update(tick)
+		}
+		ui.Render(layout.Grid)
+	}
+}
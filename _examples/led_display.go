@@ -0,0 +1,66 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	clock := widgets.NewLEDDisplay()
+	clock.Title = "Time"
+	clock.TextAlignment = ui.AlignCenter
+	clock.SetRect(0, 0, 50, 9)
+
+	counter := widgets.NewLEDDisplay()
+	counter.Title = "Requests"
+	counter.OnColor = ui.ColorGreen
+	counter.OffColor = ui.NewRGBColor(0x10, 0x30, 0x10)
+	counter.TextAlignment = ui.AlignRight
+	counter.SetRect(0, 9, 50, 15)
+
+	temperature := widgets.NewLEDDisplay()
+	temperature.Title = "Probe"
+	temperature.OnColor = ui.ColorYellow
+	temperature.OffColor = ui.ColorClear
+	temperature.DigitHeight = 3
+	temperature.SetRect(0, 15, 50, 20)
+
+	requests := 0
+	draw := func(now time.Time) {
+		clock.Text = now.Format("15:04:05")
+		counter.Text = fmt.Sprintf("%8d", requests)
+		temperature.Text = fmt.Sprintf("%4.1f°C", 21.5+float64(now.Second()%10)/10)
+		ui.Render(clock, counter, temperature)
+	}
+	draw(time.Now())
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	events := ui.PollEvents()
+	for {
+		select {
+		case e := <-events:
+			if e.ID == "q" || e.ID == "<C-c>" {
+				return
+			}
+		case now := <-ticker.C:
+			requests += now.Nanosecond() % 37
+			draw(now)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// the feed of the log page runs only while the page is shown, and the spinner of the loading
+// page stops spinning while it is hidden; 1 and 2 switch pages, and q quits
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	events := widgets.NewList()
+	events.Title = "Lifecycle"
+
+	logs := widgets.NewList()
+	logs.Title = "Feed"
+	lines := make(chan string)
+	var stop chan struct{}
+	logs.Hooks = ui.LifecycleHooks{
+		OnMount: func() {
+			events.Rows = append(events.Rows, "feed mounted")
+		},
+		OnVisible: func(visible bool) {
+			events.Rows = append(events.Rows, fmt.Sprintf("feed visible: %v", visible))
+			if !visible {
+				close(stop)
+				return
+			}
+			stop = make(chan struct{})
+			go func(stop chan struct{}) {
+				ticker := time.NewTicker(500 * time.Millisecond)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-stop:
+						return
+					case now := <-ticker.C:
+						lines <- now.Format("15:04:05.000")
+					}
+				}
+			}(stop)
+		},
+	}
+
+	spinner := widgets.NewSpinner()
+	spinner.Label = "Loading"
+	spinner.Start()
+
+	pages := widgets.NewPages()
+	pages.AddPage("feed", logs)
+	pages.AddPage("loading", spinner)
+
+	termWidth, termHeight := ui.TerminalDimensions()
+	pages.SetRect(0, 0, termWidth/2, termHeight)
+	events.SetRect(termWidth/2, 0, termWidth, termHeight)
+
+	render := func() {
+		ui.Render(pages, events)
+	}
+	spinner.OnFrame = render
+
+	render()
+	uiEvents := ui.PollEvents()
+	for {
+		select {
+		case line := <-lines:
+			logs.Rows = append(logs.Rows, line)
+			logs.ScrollBottom()
+		case e := <-uiEvents:
+			switch e.ID {
+			case "q", "<C-c>":
+				return
+			default:
+				pages.HandleKey(e.ID)
+			}
+		}
+		render()
+	}
+}
@@ -7,7 +7,9 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"time"
 
 	ui "github.com/reaalkhalil/termui"
 	"github.com/reaalkhalil/termui/widgets"
@@ -35,17 +37,91 @@ func main() {
 	}
 	l.TextStyle = ui.NewStyle(ui.ColorYellow)
 	l.WrapText = false
+	l.FuzzySearch = true
+	l.MultiSelect = true
+	l.Sections = []widgets.ListSection{
+		{Title: "Packages", Start: 0},
+		{Title: "Files", Start: 4},
+		{Title: "Other", Start: 7},
+	}
 	l.SetRect(0, 0, 25, 8)
 
-	ui.Render(l)
+	// more rows are fetched in the background when the last row is selected
+	pages := make(chan []string)
+	l.OnReachEnd = func() {
+		go func(start int) {
+			time.Sleep(500 * time.Millisecond)
+			rows := []string{}
+			for i := start; i < start+10; i++ {
+				rows = append(rows, fmt.Sprintf("[%d] fetched", i))
+			}
+			pages <- rows
+		}(len(l.Rows))
+	}
+
+	running, failed := ui.NewStyle(ui.ColorGreen), ui.NewStyle(ui.ColorRed)
+	services := widgets.NewList()
+	services.Title = "Services"
+	services.Items = []widgets.ListItem{
+		{Icon: '●', Text: "api", Secondary: "up 3d", Style: &running},
+		{Icon: '●', Text: "worker", Secondary: "up 5h", Style: &running},
+		{Icon: '✗', Text: "scheduler", Secondary: "exit 1", Style: &failed},
+	}
+	services.SetRect(25, 0, 50, 8)
+
+	toolbar := widgets.NewList()
+	toolbar.Horizontal = true
+	toolbar.Rows = []string{"New", "Open", "Save", "Save As", "Export", "Print", "Close"}
+	toolbar.SelectedRowStyle = ui.NewStyle(ui.ColorBlack, ui.ColorWhite)
+	toolbar.SetRect(0, 8, 30, 11)
+
+	l.OnActivate = func(row int) {
+		l.Title = "List: " + l.Rows[row]
+	}
+
+	ui.Render(l, services, toolbar)
 
 	previousKey := ""
 	uiEvents := ui.PollEvents()
 	for {
-		e := <-uiEvents
+		var e ui.Event
+		select {
+		case e = <-uiEvents:
+		case rows := <-pages:
+			l.AppendRows(rows...)
+			if len(l.Rows) >= 50 {
+				l.OnReachEnd = nil
+			}
+			ui.Render(l)
+			continue
+		}
+		if l.HandleSearchKey(e.ID) || l.HandleReorderKey(e.ID) || l.HandleMouseDrag(e) {
+			ui.Render(l)
+			continue
+		}
+		if p, ok := ui.MousePoint(e); ok {
+			if list, ok := ui.HitTest(p, l, services, toolbar).(*widgets.List); ok && list.HandleMouse(e) {
+				ui.Render(l, services, toolbar)
+			}
+			continue
+		}
 		switch e.ID {
 		case "q", "<C-c>":
 			return
+		case "/":
+			l.StartSearch()
+		case "<Space>":
+			l.ToggleSelection()
+		case "a":
+			l.SelectAll()
+		case "n":
+			l.ClearSelection()
+		case "<Tab>":
+			l.ToggleSection(l.SelectedSection())
+		case "h", "<Left>":
+			toolbar.ScrollLeft()
+		case "l", "<Right>":
+			toolbar.ScrollRight()
 		case "j", "<Down>":
 			l.ScrollDown()
 		case "k", "<Up>":
@@ -74,6 +150,6 @@ func main() {
 			previousKey = e.ID
 		}
 
-		ui.Render(l)
+		ui.Render(l, toolbar)
 	}
 }
@@ -0,0 +1,52 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"math/rand"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	logs := widgets.NewLogView()
+	logs.Title = "1-4 toggle levels, t time, f follow, / search, n/N matches, q quits"
+	logs.Capacity = 500
+	logs.SetRect(0, 0, 80, 20)
+
+	messages := []string{"request served", "cache miss", "connection reset", "retrying", "slow query"}
+	ticker := time.NewTicker(200 * time.Millisecond).C
+
+	ui.Render(logs)
+	uiEvents := ui.PollEvents()
+	for {
+		select {
+		case e := <-uiEvents:
+			switch e.Type {
+			case ui.KeyboardEvent:
+				if e.ID == "<C-c>" || e.ID == "q" && !logs.Searching() {
+					return
+				}
+				logs.HandleKey(e.ID)
+			case ui.MouseEvent:
+				logs.HandleMouse(e)
+			}
+		case <-ticker:
+			logs.Log(widgets.LogLevel(rand.Intn(4)), messages[rand.Intn(len(messages))],
+				"latency", time.Duration(rand.Intn(900))*time.Millisecond, "status", 200+rand.Intn(4)*100)
+		}
+		ui.Render(logs)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+const document = `# termui
+
+termui is a cross-platform and fully-customizable terminal dashboard and widget library built on
+top of [termbox-go](https://github.com/nsf/termbox-go).
+
+## Installation
+
+Get the package with go get:
+
+` + "```" + `sh
+go get github.com/reaalkhalil/termui
+` + "```" + `
+
+## Usage
+
+### Hello World
+
+` + "```" + `go
+p := widgets.NewParagraph()
+p.Text = "Hello World!"
+p.SetRect(0, 0, 25, 5)
+ui.Render(p)
+` + "```" + `
+
+### Widgets
+
+Widgets are drawn with **Render** and laid out by hand or with a *Grid*:
+
+- Paragraph, List, Table, and Tree
+- BarChart, Plot, Sparkline, and PieChart
+- Forms, inputs, and dialogs
+
+> Every widget embeds a Block, which draws its border and title.
+
+## Links
+
+- [Documentation](https://godoc.org/github.com/reaalkhalil/termui)
+- [Examples](https://github.com/reaalkhalil/termui/tree/master/_examples)
+
+---
+
+j and k scroll, n and p go to the next and previous heading, <Tab> focuses a link and <Enter>
+follows it, t shows or hides the contents, and q quits.
+`
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	termWidth, termHeight := ui.TerminalDimensions()
+
+	viewer := widgets.NewMarkdownViewer()
+	viewer.Title = "README.md"
+	viewer.Text = document
+	viewer.SetRect(0, 0, termWidth, termHeight-1)
+
+	status := widgets.NewParagraph()
+	status.Border = false
+	status.SetRect(0, termHeight-1, termWidth, termHeight)
+	viewer.OnLink = func(url string) {
+		status.Text = "Followed " + url
+	}
+
+	ui.Render(viewer, status)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "<Resize>":
+			payload := e.Payload.(ui.Resize)
+			viewer.SetRect(0, 0, payload.Width, payload.Height-1)
+			status.SetRect(0, payload.Height-1, payload.Width, payload.Height)
+			ui.Clear()
+		default:
+			if !viewer.HandleKey(e.ID) && !viewer.HandleMouse(e) {
+				continue
+			}
+		}
+		ui.Render(viewer, status)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	width, height := ui.TerminalDimensions()
+
+	p := widgets.NewParagraph()
+	p.Title = "Document"
+	p.Text = "Open a menu with <F10>, <M-f>, <M-e>, or <M-v>, or by clicking it"
+	p.SetRect(0, 1, width, height)
+
+	quit := false
+	status := func(text string) func() {
+		return func() { p.Text = text }
+	}
+	wrap := &widgets.MenuItem{Label: "Word wrap: on"}
+	wrap.Action = func() {
+		p.WrapText = !p.WrapText
+		if p.WrapText {
+			wrap.Label = "Word wrap: on"
+		} else {
+			wrap.Label = "Word wrap: off"
+		}
+	}
+
+	bar := widgets.NewMenuBar(
+		&widgets.MenuItem{Label: "File", Submenu: []*widgets.MenuItem{
+			{Label: "New", Shortcut: "<C-n>", Action: status("Created a new document")},
+			{Label: "Open", Shortcut: "<C-o>", Action: status("Opened a document")},
+			{Label: "Open Recent", Mnemonic: 'r', Submenu: []*widgets.MenuItem{
+				{Label: "notes.md", Action: status("Opened notes.md")},
+				{Label: "todo.txt", Action: status("Opened todo.txt")},
+			}},
+			widgets.NewMenuSeparator(),
+			{Label: "Save", Shortcut: "<C-s>", Action: status("Saved")},
+			{Label: "Print", Disabled: true},
+			widgets.NewMenuSeparator(),
+			{Label: "Exit", Mnemonic: 'x', Action: func() { quit = true }},
+		}},
+		&widgets.MenuItem{Label: "Edit", Submenu: []*widgets.MenuItem{
+			{Label: "Undo", Shortcut: "<C-z>", Action: status("Undone")},
+			{Label: "Redo", Shortcut: "<C-y>", Disabled: true},
+			widgets.NewMenuSeparator(),
+			{Label: "Cut", Mnemonic: 't', Action: status("Cut")},
+			{Label: "Copy", Action: status("Copied")},
+			{Label: "Paste", Action: status("Pasted")},
+		}},
+		&widgets.MenuItem{Label: "View", Submenu: []*widgets.MenuItem{wrap}},
+	)
+	bar.SetRect(0, 0, width, 1)
+
+	ui.Render(bar, p)
+
+	for e := range ui.PollEvents() {
+		if e.ID == "<C-c>" || e.ID == "q" && !bar.IsOpen() {
+			return
+		}
+		if !bar.HandleKey(e.ID) && !bar.HandleMouse(e) {
+			continue
+		}
+		if quit {
+			return
+		}
+		ui.Render(bar, p)
+	}
+}
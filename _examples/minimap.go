@@ -0,0 +1,81 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	var source strings.Builder
+	for i := 0; i < 40; i++ {
+		fmt.Fprintf(&source, "// step%d runs step %d of the pipeline.\n", i, i)
+		fmt.Fprintf(&source, "func step%d(input []int) []int {\n", i)
+		fmt.Fprintf(&source, "\toutput := make([]int, 0, len(input))\n\tfor _, v := range input {\n")
+		fmt.Fprintf(&source, "\t\tif v%%%d != 0 {\n\t\t\toutput = append(output, v*%d)\n\t\t}\n\t}\n", i+2, i+1)
+		fmt.Fprintf(&source, "\treturn output\n}\n\n")
+	}
+
+	code := widgets.NewParagraph()
+	code.Title = "pipeline.go"
+	code.Language = "go"
+	code.WrapText = false
+	code.Text = source.String()
+	code.SetRect(0, 0, 60, 20)
+
+	codeMap := widgets.NewMinimap(code)
+	codeMap.SetRect(60, 0, 72, 20)
+
+	logs := widgets.NewLogView()
+	logs.Title = "Logs"
+	logs.SetRect(0, 20, 60, 34)
+
+	logMap := widgets.NewMinimap(logs)
+	logMap.SetRect(60, 20, 72, 34)
+
+	levels := []widgets.LogLevel{widgets.LogDebug, widgets.LogInfo, widgets.LogInfo, widgets.LogWarning, widgets.LogError}
+	render := func() {
+		ui.Render(code, codeMap, logs, logMap)
+	}
+	render()
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	events := ui.PollEvents()
+	for {
+		select {
+		case e := <-events:
+			switch {
+			case e.ID == "q" || e.ID == "<C-c>":
+				return
+			case e.Type == ui.MouseEvent:
+				if p, ok := ui.MousePoint(e); ok {
+					if minimap, ok := ui.HitTest(p, codeMap, logMap).(*widgets.Minimap); ok {
+						minimap.HandleMouse(e)
+					}
+				}
+			default:
+				code.HandleScrollKey(e.ID)
+			}
+		case <-ticker.C:
+			logs.Log(levels[rand.Intn(len(levels))], "request handled", "latency", rand.Intn(500))
+		}
+		render()
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	replicas := widgets.NewNumberInput()
+	replicas.Min, replicas.Max = 1, 20
+	replicas.SetValue(3)
+
+	cpu := widgets.NewNumberInput()
+	cpu.Format, cpu.Parse = widgets.FormatPercent, widgets.ParsePercent
+	cpu.Min, cpu.Max, cpu.Step = 0, 1, 0.05
+	cpu.SetValue(0.8)
+
+	memory := widgets.NewNumberInput()
+	memory.Format, memory.Parse = widgets.FormatBytes, widgets.ParseBytes
+	memory.Min, memory.Step = 0, 64<<20
+	memory.SetValue(512 << 20)
+
+	timeout := widgets.NewNumberInput()
+	timeout.Format, timeout.Parse = widgets.FormatSeconds, widgets.ParseSeconds
+	timeout.Min, timeout.Max, timeout.Step = 1, 3600, 15
+	timeout.SetValue(90)
+
+	form := widgets.NewForm()
+	form.Title = "Deployment (<Up>/<Down> step, <Tab> next field, <Enter> submit, <C-c> quit)"
+	form.SetRect(0, 0, 60, 18)
+	form.AddField("Replicas", "replicas", replicas, replicas.Validate)
+	form.AddField("CPU limit", "cpu", cpu, cpu.Validate)
+	form.AddField("Memory limit", "memory", memory, memory.Validate)
+	form.AddField("Timeout", "timeout", timeout, timeout.Validate)
+
+	result := widgets.NewParagraph()
+	result.Title = "Submitted"
+	result.SetRect(0, 18, 60, 24)
+
+	form.OnSubmit = func(values map[string]interface{}) {
+		result.Text = fmt.Sprintf("replicas: %v\ncpu: %v\nmemory: %v bytes\ntimeout: %vs",
+			values["replicas"], values["cpu"], values["memory"], values["timeout"])
+	}
+
+	ui.Render(form, result)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "<C-c>":
+			return
+		default:
+			if !form.HandleKey(e.ID) && !form.HandleMouse(e) {
+				continue
+			}
+		}
+		ui.Render(form, result)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	// the first page shows a plot and a gauge
+	plot := widgets.NewPlot()
+	plot.Title = "Sine"
+	plot.Data = [][]float64{make([]float64, 100)}
+	for i := range plot.Data[0] {
+		plot.Data[0][i] = 1 + math.Sin(float64(i)/5)
+	}
+	gauge := widgets.NewGauge()
+	gauge.Title = "Progress"
+	gauge.Percent = 60
+	overview := ui.NewGrid()
+	overview.Set(
+		ui.NewRow(.8, plot),
+		ui.NewRow(.2, gauge),
+	)
+
+	// the second page holds two lists; <Tab> moves the focus between them, remembered by the page
+	left := widgets.NewList()
+	left.Rows = []string{"one", "two", "three", "four"}
+	right := widgets.NewList()
+	right.Rows = []string{"uno", "dos", "tres", "cuatro"}
+	lists := ui.NewGrid()
+	lists.Set(ui.NewRow(1, ui.NewCol(.5, left), ui.NewCol(.5, right)))
+
+	help := widgets.NewParagraph()
+	help.Title = "Help"
+	help.Text = "1, 2, and 3 show a page, [ and ] the previous or next one.\n" +
+		"<Tab> moves the focus on the lists page, j and k scroll the focused list.\nq quits."
+
+	pages := widgets.NewPages()
+	pages.AddPage("overview", overview)
+	pages.AddPage("lists", lists)
+	pages.AddPage("help", help)
+
+	status := widgets.NewParagraph()
+	status.Border = false
+
+	termWidth, termHeight := ui.TerminalDimensions()
+	pages.SetRect(0, 0, termWidth, termHeight-1)
+	status.SetRect(0, termHeight-1, termWidth, termHeight)
+
+	render := func() {
+		page := pages.CurrentPage()
+		status.Text = fmt.Sprintf(" page %d/%d: %s", pages.Current()+1, len(pages.Items), page.Name)
+		ui.Render(pages, status)
+	}
+	focus := func(list *widgets.List) {
+		pages.SetFocus(list)
+		left.Title, right.Title = "Left", "Right"
+		list.Title += " (focused)"
+	}
+	pages.OnFrame = render
+	pages.ShowName("lists")
+	focus(left)
+	pages.ShowName("overview")
+	pages.Transition = 300 * time.Millisecond
+	render()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "<Tab>":
+			if pages.Focused() == left {
+				focus(right)
+			} else if pages.Focused() == right {
+				focus(left)
+			}
+		case "j", "k":
+			if list, ok := pages.Focused().(*widgets.List); ok {
+				if e.ID == "j" {
+					list.ScrollDown()
+				} else {
+					list.ScrollUp()
+				}
+			}
+		case "<Resize>":
+			payload := e.Payload.(ui.Resize)
+			pages.SetRect(0, 0, payload.Width, payload.Height-1)
+			status.SetRect(0, payload.Height-1, payload.Width, payload.Height)
+			ui.Clear()
+		default:
+			if !pages.HandleKey(e.ID) {
+				continue
+			}
+		}
+		render()
+	}
+}
@@ -8,6 +8,7 @@ package main
 
 import (
 	"log"
+	"time"
 
 	ui "github.com/reaalkhalil/termui"
 	"github.com/reaalkhalil/termui/widgets"
@@ -31,7 +32,7 @@ func main() {
 
 	p2 := widgets.NewParagraph()
 	p2.Title = "Multiline"
-	p2.Text = "Simple colored text\nwith label. It [can be](fg:red) multilined with \\n or [break automatically](fg:red,fg:bold)"
+	p2.Text = "[align:center]Simple colored text\nwith label. It [can be](fg:red) multilined with \\n or [break automatically](fg:red,fg:bold)"
 	p2.SetRect(0, 5, 35, 10)
 	p2.BorderStyle.Fg = ui.ColorYellow
 
@@ -41,19 +42,77 @@ func main() {
 	p3.SetRect(0, 10, 40, 15)
 
 	p4 := widgets.NewParagraph()
-	p4.Title = "Text Box with Wrapping"
-	p4.Text = "Press q to QUIT THE DEMO. [There](fg:blue,mod:bold) are other things [that](fg:red) are going to fit in here I think. What do you think? Now is the time for all good [men to](bg:blue) come to the aid of their country. [This is going to be one really really really long line](fg:green) that is going to go together and stuffs and things. Let's see how this thing renders out.\n    Here is a new paragraph and stuffs and things. There should be a tab indent at the beginning of the paragraph. Let's see if that worked as well."
-	p4.SetRect(40, 0, 70, 20)
+	p4.Title = "Scrolling Text Box"
+	p4.Text = "Press q to QUIT THE DEMO. [There](fg:blue,mod:bold) are other things [that](fg:red) are going to fit in here I think. What do you think? Now is the time for all good [men to](bg:blue) come to the aid of their country. [This is going to be one really really really long line](fg:green) that is going to go together and stuffs and things. Let's see how this thing renders out. Read more about [termui](link:https://github.com/reaalkhalil/termui).\n    Here is a new paragraph and stuffs and things. There should be a tab indent at the beginning of the paragraph. Let's see if that worked as well."
+	p4.SetRect(40, 0, 70, 12)
 	p4.BorderStyle.Fg = ui.ColorBlue
+	p4.TextAlignment = ui.AlignJustify
+	p4.OnLink = func(url string) {
+		p4.Title = url
+	}
+
+	p5 := widgets.NewParagraph()
+	p5.Title = "Markdown"
+	p5.Markdown = true
+	p5.Text = "# Help\n\nPress **q** to quit and *j*/*k* to scroll the `Scrolling Text Box`.\n\n" +
+		"- Markdown headings, lists, and quotes\n- [Links](https://github.com/reaalkhalil/termui) are underlined\n\n" +
+		"> Quotes are marked on the left.\n\n```\nfunc main() {}\n```"
+	p5.SetRect(0, 15, 40, 30)
 
-	ui.Render(p0, p1, p2, p3, p4)
+	p6 := widgets.NewParagraph()
+	p6.Title = "Code"
+	p6.Language = "go"
+	p6.WrapText = false
+	p6.Text = "// main says hello\nfunc main() {\n\tfmt.Println(\"hello\", 42)\n}"
+	p6.SetRect(40, 12, 70, 18)
+
+	p7 := widgets.NewParagraph()
+	p7.Title = "ANSI"
+	p7.ANSIParse = true
+	p7.Text = "\x1b[1;32mok\x1b[0m   build\n\x1b[31mFAIL\x1b[0m test \x1b[38;5;208m(2.1s)\x1b[0m"
+	p7.SetRect(40, 18, 70, 22)
+
+	p8 := widgets.NewParagraph()
+	p8.Title = "Log"
+	p8.Follow = true
+	p8.MaxLines = 100
+	p8.SetRect(70, 0, 100, 22)
+	logger := log.New(p8, "", log.Ltime)
+	go func() {
+		for i := 0; ; i++ {
+			logger.Printf("event %d", i)
+			ui.Render(p8)
+			time.Sleep(time.Second)
+		}
+	}()
+
+	ui.Render(p0, p1, p2, p3, p4, p5, p6, p7, p8)
 
 	uiEvents := ui.PollEvents()
 	for {
 		e := <-uiEvents
+		if p4.HandleSelectionKey(e.ID) || p4.HandleMouseLink(e) || p4.HandleMouseSelect(e) {
+			ui.Render(p4)
+			continue
+		}
 		switch e.ID {
 		case "q", "<C-c>":
 			return
+		case "v":
+			p4.StartSelection()
+		case "c":
+			p4.Copy()
+		case "<Tab>":
+			p4.NextLink()
+		case "<Enter>":
+			p4.ActivateLink()
+		case "j":
+			p4.ScrollDown()
+		case "k":
+			p4.ScrollUp()
+		default:
+			p4.HandleScrollKey(e.ID)
 		}
+		ui.Render(p4)
 	}
 }
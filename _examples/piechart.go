@@ -40,6 +40,19 @@ func main() {
 	pc.LabelFormatter = func(i int, v float64) string {
 		return fmt.Sprintf("%.02f", v)
 	}
+	pc.LabelsOutside = true
+
+	donut := widgets.NewPieChart()
+	donut.Title = "Donut Chart"
+	donut.SetRect(70, 5, 120, 30)
+	donut.Data = []float64{62, 38}
+	donut.InnerRadius = .6
+	donut.CenterText = "Disk\n62% used"
+	donut.Labels = []string{"used", "free"}
+	donut.LabelFormatter = func(i int, v float64) string {
+		return fmt.Sprintf("%.0f GB", v)
+	}
+	donut.ShowLegend = true
 
 	pause := func() {
 		run = !run
@@ -51,7 +64,7 @@ func main() {
 		ui.Render(pc)
 	}
 
-	ui.Render(pc)
+	ui.Render(pc, donut)
 
 	uiEvents := ui.PollEvents()
 	ticker := time.NewTicker(time.Second).C
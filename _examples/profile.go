@@ -0,0 +1,58 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"math"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// draws the same plot with each Profile, which TERM=dumb or TERM=vt100 also pick for the whole UI
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	data := []float64{}
+	for i := 0; i < 100; i++ {
+		data = append(data, math.Sin(float64(i)/8))
+	}
+	profiles := []struct {
+		name    string
+		profile ui.Profile
+	}{
+		{"Full", ui.ProfileFull},
+		{"Linux console", ui.ProfileLinuxConsole},
+		{"ASCII", ui.ProfileASCII},
+		{"Dumb", ui.ProfileDumb},
+	}
+	rows := []interface{}{}
+	for i := range profiles {
+		plot := widgets.NewPlot()
+		plot.Title = profiles[i].name
+		plot.Data = [][]float64{data}
+		plot.LineColors = []ui.Color{ui.ColorGreen}
+		plot.Profile = &profiles[i].profile
+		rows = append(rows, ui.NewRow(1.0/float64(len(profiles)), plot))
+	}
+
+	grid := ui.NewGrid()
+	termWidth, termHeight := ui.TerminalDimensions()
+	grid.SetRect(0, 0, termWidth, termHeight)
+	grid.Set(rows...)
+	ui.Render(grid)
+
+	for e := range ui.PollEvents() {
+		if e.Type == ui.KeyboardEvent {
+			break
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	progress := widgets.NewProgress()
+	progress.Title = "Installing (q to quit)"
+	progress.Unit = "files"
+	progress.SetRect(0, 0, 80, 7)
+
+	// each task is worked on by its own goroutine
+	work := func(task *widgets.ProgressTask, delay time.Duration, failAt int64) {
+		for !task.Finished() {
+			time.Sleep(delay + time.Duration(rand.Intn(int(delay))))
+			task.Add(1)
+			if done, _ := task.Progress(); done == failAt {
+				task.Fail(errors.New("checksum mismatch"))
+			}
+		}
+	}
+	go work(progress.AddTask("core", 120), 20*time.Millisecond, -1)
+	go work(progress.AddTask("plugins", 300), 15*time.Millisecond, -1)
+	go work(progress.AddTask("docs", 80), 60*time.Millisecond, -1)
+	go work(progress.AddTask("extras", 200), 30*time.Millisecond, 90)
+	go work(progress.AddTask("cache", 40), 10*time.Millisecond, -1)
+
+	ui.Render(progress)
+	ticker := time.NewTicker(100 * time.Millisecond).C
+	events := ui.PollEvents()
+	for {
+		select {
+		case e := <-events:
+			if e.ID == "q" || e.ID == "<C-c>" {
+				return
+			}
+		case <-ticker:
+			if progress.Finished() {
+				progress.Title = "Done (q to quit)"
+			}
+			ui.Render(progress)
+		}
+	}
+}
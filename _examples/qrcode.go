@@ -0,0 +1,64 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	texts := []string{
+		"https://github.com/reaalkhalil/termui",
+		"WIFI:T:WPA;S:termui-lab;P:correct horse battery staple;;",
+		"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq",
+	}
+	current := 0
+
+	qr := widgets.NewQRCode()
+	qr.Text = texts[current]
+	qr.SetRect(0, 0, 50, 27)
+
+	p := widgets.NewParagraph()
+	p.SetRect(0, 27, 50, 32)
+
+	levels := []string{"L", "M", "Q", "H"}
+	update := func() {
+		ui.Render(qr)
+		version, level := qr.Version()
+		p.Text = fmt.Sprintf("%s\nVersion %d-%s, at least %s. <Tab> switches, l raises the level, q quits",
+			qr.Text, version, levels[level], levels[qr.Level])
+		ui.Render(p)
+	}
+	update()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "<Tab>":
+			current = (current + 1) % len(texts)
+			qr.Text = texts[current]
+		case "l":
+			qr.Level = (qr.Level + 1) % 4
+		case "<Resize>":
+			payload := e.Payload.(ui.Resize)
+			qr.SetRect(0, 0, payload.Width, payload.Height-5)
+			p.SetRect(0, payload.Height-5, payload.Width, payload.Height)
+			ui.Clear()
+		}
+		update()
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	rc := widgets.NewRadarChart()
+	rc.Title = "Service scores"
+	rc.Labels = []string{"Latency", "Throughput", "Availability", "Cost", "Security", "Support"}
+	rc.Data = [][]float64{
+		{8, 6, 9, 4, 7, 6},
+		{5, 9, 7, 8, 6, 4},
+		{7, 4, 6, 9, 9, 8},
+	}
+	rc.SeriesLabels = []string{"Provider A", "Provider B", "Provider C"}
+	rc.MaxVal = 10
+	rc.Rings = 5
+	rc.SetRect(0, 0, 70, 24)
+
+	ui.Render(rc)
+
+	for e := range ui.PollEvents() {
+		if e.Type == ui.KeyboardEvent {
+			break
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	size := widgets.NewRadioGroup("Small", "Medium", "Large")
+	size.Title = "Size"
+	size.Selected = 1
+	size.SetRect(0, 0, 25, 5)
+
+	crust := widgets.NewRadioGroup("Thin", "Thick", "Stuffed")
+	crust.Title = "Crust"
+	crust.Horizontal = true
+	crust.Selected = -1
+	crust.SetRect(0, 5, 40, 8)
+
+	summary := widgets.NewParagraph()
+	summary.Border = false
+	summary.SetRect(0, 8, 40, 11)
+
+	update := func() {
+		summary.Text = fmt.Sprintf("Size: %v\nCrust: %v", size.FormValue(), crust.FormValue())
+	}
+	size.OnChange = func(int, string) { update() }
+	crust.OnChange = func(int, string) { update() }
+	update()
+
+	groups := []*widgets.RadioGroup{size, crust}
+	focus := 0
+	groups[focus].Focused = true
+	render := func() {
+		ui.Render(size, crust, summary)
+	}
+	render()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "<Tab>":
+			groups[focus].Focused = false
+			focus = (focus + 1) % len(groups)
+			groups[focus].Focused = true
+		default:
+			used := groups[focus].HandleKey(e.ID)
+			for _, group := range groups {
+				used = group.HandleMouse(e) || used
+			}
+			if !used {
+				continue
+			}
+		}
+		render()
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/export"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// Records the session to demo.cast in the temporary directory, which can be played back with
+// `asciinema play`.
+func main() {
+	path := filepath.Join(os.TempDir(), "demo.cast")
+	file, err := os.Create(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	recorder := export.NewRecorder(file, ui.NewTermboxBackend())
+	recorder.Title = "termui demo"
+	if err := ui.InitBackend(recorder); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	gauge := widgets.NewGauge()
+	gauge.Title = "Recording to " + path + " (q quits)"
+	gauge.SetRect(0, 0, 50, 3)
+	ui.Render(gauge)
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	events := ui.PollEvents()
+	for gauge.Percent < 100 {
+		select {
+		case e := <-events:
+			if e.ID == "q" || e.ID == "<C-c>" {
+				return
+			}
+		case <-ticker.C:
+			gauge.Percent++
+			ui.Render(gauge)
+		}
+	}
+	if err := recorder.Err(); err != nil {
+		log.Print(err)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	// a sparkline for each host, of which only one gets a new sample every tick
+	rows := []interface{}{}
+	groups := []*widgets.SparklineGroup{}
+	for i := 0; i < 8; i++ {
+		sparkline := widgets.NewSparkline()
+		sparkline.LineColor = ui.Color(i%6 + 1)
+		group := widgets.NewSparklineGroup(sparkline)
+		group.Title = fmt.Sprintf("host-%d (q quits)", i)
+		groups = append(groups, group)
+		rows = append(rows, ui.NewRow(1.0/8, group))
+	}
+
+	grid := ui.NewGrid()
+	width, height := ui.TerminalDimensions()
+	grid.SetRect(0, 0, width, height)
+	grid.Set(rows...)
+	ui.Render(grid)
+
+	ticker := time.NewTicker(50 * time.Millisecond).C
+	uiEvents := ui.PollEvents()
+	for {
+		select {
+		case e := <-uiEvents:
+			switch e.ID {
+			case "q", "<C-c>":
+				return
+			case "<Resize>":
+				payload := e.Payload.(ui.Resize)
+				grid.SetRect(0, 0, payload.Width, payload.Height)
+				ui.Clear()
+				ui.Render(grid)
+			}
+		case <-ticker:
+			group := groups[rand.Intn(len(groups))]
+			sparkline := group.Sparklines[0]
+			sparkline.Data = append(sparkline.Data, rand.Float64())
+			if len(sparkline.Data) > group.Inner.Dx() {
+				sparkline.Data = sparkline.Data[1:]
+			}
+			ui.MarkDirty(group)
+			// only the sparkline which changed is drawn
+			ui.RenderDirty()
+		}
+	}
+}
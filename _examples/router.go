@@ -0,0 +1,110 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+type contact struct {
+	name  string
+	email string
+}
+
+// a list of contacts opening a detail view opening an editor; <Escape> goes back and q quits
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	contacts := []*contact{
+		{"Ada Lovelace", "ada@example.com"},
+		{"Alan Turing", "alan@example.com"},
+		{"Grace Hopper", "grace@example.com"},
+	}
+
+	router := widgets.NewRouter()
+	// the key handler of the content of each view
+	keys := map[ui.Drawable]func(string) bool{}
+
+	router.AddView("contacts", func(params widgets.RouteParams) ui.Drawable {
+		list := widgets.NewList()
+		list.Title = "Contacts, <Enter> opens one"
+		for _, c := range contacts {
+			list.Rows = append(list.Rows, c.name)
+		}
+		keys[list] = func(id string) bool {
+			switch id {
+			case "j", "<Down>":
+				list.ScrollDown()
+			case "k", "<Up>":
+				list.ScrollUp()
+			case "<Enter>":
+				router.Push("detail", widgets.RouteParams{"contact": contacts[list.SelectedRow]})
+			default:
+				return false
+			}
+			return true
+		}
+		return list
+	})
+	router.AddView("detail", func(params widgets.RouteParams) ui.Drawable {
+		c := params["contact"].(*contact)
+		p := widgets.NewParagraph()
+		p.Title = c.name + ", e edits the email"
+		p.Text = "Email: " + c.email
+		keys[p] = func(id string) bool {
+			if id == "e" {
+				router.Push("edit", params)
+				return true
+			}
+			return false
+		}
+		return p
+	})
+	router.AddView("edit", func(params widgets.RouteParams) ui.Drawable {
+		c := params["contact"].(*contact)
+		input := widgets.NewTextInput()
+		input.Title = "Email, <Enter> saves"
+		input.Focused = true
+		input.SetText(c.email)
+		input.OnSubmit = func(text string) {
+			c.email = text
+			// the detail view is built again with the new email
+			router.Pop()
+			router.Replace("detail", params)
+		}
+		keys[input] = input.HandleKey
+		return input
+	})
+
+	breadcrumbs := widgets.NewBreadcrumbs()
+	breadcrumbs.Border = false
+	width, height := ui.TerminalDimensions()
+	breadcrumbs.SetRect(0, 0, width, 1)
+	router.SetRect(0, 1, width, height)
+
+	draw := func() {
+		breadcrumbs.Segments = router.Path()
+		ui.Render(breadcrumbs, router)
+	}
+	router.Push("contacts", nil)
+	draw()
+	for e := range ui.PollEvents() {
+		if e.ID == "<C-c>" || (e.ID == "q" && router.Current().Name != "edit") {
+			return
+		}
+		if handle := keys[router.Current().Content]; handle == nil || !handle(e.ID) {
+			router.HandleKey(e.ID)
+		}
+		draw()
+	}
+}
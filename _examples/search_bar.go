@@ -0,0 +1,63 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	table := widgets.NewTable()
+	table.Title = "Processes"
+	table.Header = []string{"PID", "Command", "CPU"}
+	table.Rows = [][]string{
+		{"1", "init", "0.0"},
+		{"212", "sshd", "0.1"},
+		{"845", "postgres", "3.2"},
+		{"846", "postgres: writer", "0.4"},
+		{"902", "nginx: master", "0.0"},
+		{"903", "nginx: worker", "1.7"},
+		{"1204", "node server.js", "12.5"},
+		{"1377", "redis-server", "0.9"},
+		{"2048", "bash", "0.0"},
+		{"2099", "top", "0.3"},
+	}
+	table.SetRect(0, 3, 50, 20)
+
+	search := widgets.NewSearchBar(table)
+	search.Title = "Filter (<Escape> clears, <C-c> quits)"
+	search.Focused = true
+	search.SetRect(0, 0, 50, 3)
+	// the search is applied after a pause in typing, outside the event loop
+	search.OnSearch = func(query string, matches int) {
+		go ui.Render(search, table)
+	}
+
+	ui.Render(search, table)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "<C-c>":
+			return
+		case "<Up>":
+			table.ScrollUp()
+		case "<Down>":
+			table.ScrollDown()
+		default:
+			search.HandleKey(e.ID)
+		}
+		ui.Render(search, table)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	region := widgets.NewSelect()
+	region.Title = "Region"
+	region.Options = []string{
+		"us-east-1", "us-east-2", "us-west-1", "us-west-2", "eu-west-1", "eu-west-2",
+		"eu-central-1", "ap-south-1", "ap-northeast-1", "ap-southeast-1", "sa-east-1",
+	}
+	region.Focused = true
+	region.SetRect(0, 0, 30, 3)
+
+	p := widgets.NewParagraph()
+	p.Text = "<Enter> or click to open, type to jump to a region, q to quit"
+	p.SetRect(0, 3, 30, 12)
+
+	region.OnChange = func(index int, option string) {
+		p.Text = fmt.Sprintf("Deploying to %s", option)
+	}
+
+	ui.Render(region, p)
+
+	for e := range ui.PollEvents() {
+		if e.ID == "q" && !region.IsOpen() || e.ID == "<C-c>" {
+			return
+		}
+		if region.HandleKey(e.ID) || region.HandleMouse(e) {
+			ui.Render(region, p)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	volume := widgets.NewSlider()
+	volume.Title = "Volume"
+	volume.Value = 50
+	volume.Step = 5
+	volume.TickInterval = 25
+	volume.SetRect(0, 0, 40, 4)
+
+	offset := widgets.NewSlider()
+	offset.Title = "Offset (s)"
+	offset.Min = -2
+	offset.Max = 2
+	offset.Step = 0.1
+	offset.Format = "%+.1f"
+	offset.TickInterval = 1
+	offset.SetRect(0, 4, 40, 8)
+
+	gauge := widgets.NewGauge()
+	gauge.Title = "Level"
+	gauge.SetRect(0, 8, 40, 11)
+	volume.OnChange = func(value float64) { gauge.Percent = int(value) }
+	gauge.Percent = int(volume.Value)
+
+	sliders := []*widgets.Slider{volume, offset}
+	focus := 0
+	sliders[focus].Focused = true
+	render := func() {
+		ui.Render(volume, offset, gauge)
+	}
+	render()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "<Tab>":
+			sliders[focus].Focused = false
+			focus = (focus + 1) % len(sliders)
+			sliders[focus].Focused = true
+		default:
+			used := sliders[focus].HandleKey(e.ID)
+			for _, slider := range sliders {
+				used = slider.HandleMouse(e) || used
+			}
+			if !used {
+				continue
+			}
+		}
+		render()
+	}
+}
@@ -34,11 +34,16 @@ func main() {
 	sl1.Title = "Sparkline 1"
 	sl1.Data = data
 	sl1.LineColor = ui.ColorRed
+	sl1.ShowValues = true
 
 	sl2 := widgets.NewSparkline()
 	sl2.Title = "Sparkline 2"
 	sl2.Data = data[5:]
-	sl2.LineColor = ui.ColorMagenta
+	sl2.LineColor = ui.ColorGreen
+	sl2.Thresholds = []widgets.SparklineThreshold{
+		{Value: 10, Color: ui.ColorYellow},
+		{Value: 14, Color: ui.ColorRed},
+	}
 
 	slg1 := widgets.NewSparklineGroup(sl0, sl1, sl2)
 	slg1.Title = "Group Sparklines"
@@ -48,13 +53,33 @@ func main() {
 	sl3.Title = "Enlarged Sparkline"
 	sl3.Data = data
 	sl3.LineColor = ui.ColorYellow
+	sl3.Marker = widgets.SparklineBraille
+	sl3.ShowValues = true
+	sl3.Baseline = 8
+	sl3.ShowBaseline = true
 
 	slg2 := widgets.NewSparklineGroup(sl3)
 	slg2.Title = "Tweeked Sparkline"
 	slg2.SetRect(20, 0, 50, 10)
 	slg2.BorderStyle.Fg = ui.ColorCyan
 
-	ui.Render(slg0, slg1, slg2)
+	// metric wall
+	wall := []*widgets.Sparkline{}
+	for i, title := range []string{"cpu", "mem", "disk", "net rx", "net tx", "load"} {
+		sl := widgets.NewSparkline()
+		sl.Title = title
+		sl.Data = append(data[i:], data[:i]...)
+		sl.LineColor = ui.ColorMagenta
+		wall = append(wall, sl)
+	}
+	slg3 := widgets.NewSparklineGroup(wall...)
+	slg3.Title = "Metric Wall"
+	slg3.SetRect(25, 10, 75, 25)
+	slg3.Columns = 2
+	slg3.SharedScale = true
+	slg3.ShowCurrent = true
+
+	ui.Render(slg0, slg1, slg2, slg3)
 
 	uiEvents := ui.PollEvents()
 	for {
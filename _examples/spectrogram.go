@@ -0,0 +1,66 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	spectrogram := widgets.NewSpectrogram()
+	spectrogram.Title = "Spectrum (d toggles density, q quits)"
+	spectrogram.MinVal, spectrogram.MaxVal = -60, 0
+	spectrogram.SetRect(0, 0, 80, 20)
+
+	// a tone sweeping up and down the band with a steady one and noise
+	const buckets = 256
+	sweep := 0.0
+	spectrum := func() []float64 {
+		row := make([]float64, buckets)
+		center := buckets/2 + math.Sin(sweep)*buckets/3
+		for i := range row {
+			noise := -50 + rand.Float64()*10
+			tone := -40 * math.Abs(float64(i)-center) / 8
+			steady := -40 * math.Abs(float64(i)-buckets/5) / 3
+			row[i] = math.Max(noise, math.Max(tone, steady))
+		}
+		sweep += 0.1
+		return row
+	}
+
+	ui.Render(spectrogram)
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	events := ui.PollEvents()
+	for {
+		select {
+		case e := <-events:
+			switch e.ID {
+			case "q", "<C-c>":
+				return
+			case "d":
+				spectrogram.Density = !spectrogram.Density
+				ui.Clear()
+			}
+		case <-ticker.C:
+			spectrogram.AppendRow(spectrum())
+			ui.Render(spectrogram)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	frames := []struct {
+		name   string
+		frames []string
+	}{
+		{"braille", ui.SPINNER_BRAILLE},
+		{"dots", ui.SPINNER_DOTS},
+		{"line", ui.SPINNER_LINE},
+		{"circle", ui.SPINNER_CIRCLE},
+		{"blocks", ui.SPINNER_BLOCKS},
+	}
+
+	spinners := []*widgets.Spinner{}
+	drawables := []ui.Drawable{}
+	render := func() {
+		ui.Render(drawables...)
+	}
+	for i, set := range frames {
+		spinner := widgets.NewSpinner()
+		spinner.Frames = set.frames
+		spinner.Label = "Loading " + set.name + "…"
+		spinner.Interval = time.Duration(80+40*i) * time.Millisecond
+		spinner.SetRect(0, 3*i, 30, 3*i+3)
+		spinner.OnFrame = render
+		spinner.Start()
+		spinners = append(spinners, spinner)
+		drawables = append(drawables, spinner)
+	}
+
+	p := widgets.NewParagraph()
+	p.Text = "<Space> pauses, q quits"
+	p.Border = false
+	p.SetRect(0, 3*len(frames), 30, 3*len(frames)+3)
+	drawables = append(drawables, p)
+	render()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "<Space>":
+			for _, spinner := range spinners {
+				if spinner.Running() {
+					spinner.Stop()
+				} else {
+					spinner.Start()
+				}
+			}
+		}
+	}
+}
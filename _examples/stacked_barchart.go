@@ -31,7 +31,15 @@ func main() {
 	sbc.SetRect(5, 5, 100, 30)
 	sbc.BarWidth = 5
 
-	ui.Render(sbc)
+	cashflow := widgets.NewStackedBarChart()
+	cashflow.Title = "Cash Flow (Income, Expenses)"
+	cashflow.Labels = []string{"Jan", "Feb", "Mar", "Apr"}
+	cashflow.Data = [][]float64{{12, -8}, {9, -11}, {14, -6}, {7, -9}}
+	cashflow.SetRect(100, 5, 140, 30)
+	cashflow.BarWidth = 5
+	cashflow.BarColors = []ui.Color{ui.ColorGreen, ui.ColorRed}
+
+	ui.Render(sbc, cashflow)
 
 	uiEvents := ui.PollEvents()
 	for {
@@ -0,0 +1,61 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// the selected row and the sort order are saved when quitting with q and restored on the next run;
+// j and k move the selection, and s sorts the table by its next column
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	l := widgets.NewList()
+	l.Title = "Planets"
+	l.Rows = []string{"Mercury", "Venus", "Earth", "Mars", "Jupiter", "Saturn", "Uranus", "Neptune"}
+	l.SetRect(0, 0, 25, 10)
+
+	table := widgets.NewTable()
+	table.Title = "Moons"
+	table.Rows = [][]string{{"Earth", "1"}, {"Mars", "2"}, {"Jupiter", "95"}, {"Saturn", "146"}}
+	table.SetRect(25, 0, 55, 10)
+
+	state := ui.NewStateManager(ui.StatePath("termui-state-example"))
+	if err := state.Load(); err != nil {
+		log.Printf("failed to load the state: %v", err)
+	}
+	state.Register("planets", l)
+	state.Register("moons", table)
+	defer func() {
+		if err := state.Save(); err != nil {
+			log.Printf("failed to save the state: %v", err)
+		}
+	}()
+
+	ui.Render(l, table)
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "j", "<Down>":
+			l.ScrollDown()
+		case "k", "<Up>":
+			l.ScrollUp()
+		case "s":
+			column, _ := table.SortColumn()
+			table.Sort((column+1)%2, true)
+		}
+		ui.Render(l, table)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	width, height := ui.TerminalDimensions()
+
+	p := widgets.NewParagraph()
+	p.Title = "main.go"
+	p.Text = "i: insert mode, <Escape>: normal mode, w: write, h/l: move, q: quit\nResize the terminal to see segments hidden by priority"
+	p.SetRect(0, 0, width, height-1)
+
+	file := widgets.NewStatusSegment("main.go", 10)
+	modified := widgets.NewStatusSegment("", 5)
+	encoding := widgets.NewStatusSegment("utf-8", 1)
+	filetype := widgets.NewStatusSegment("go", 2)
+	position := widgets.NewStatusSegment("", 8)
+	position.Style = ui.NewStyle(ui.ColorBlack, ui.ColorGreen)
+
+	bar := widgets.NewStatusBar()
+	bar.Mode = "NORMAL"
+	bar.ModeStyles = map[string]ui.Style{
+		"INSERT": ui.NewStyle(ui.ColorBlack, ui.ColorGreen, ui.ModifierBold),
+	}
+	bar.Left = []*widgets.StatusSegment{file, modified}
+	bar.Center = []*widgets.StatusSegment{encoding}
+	bar.Right = []*widgets.StatusSegment{filetype, position}
+	bar.SetRect(0, height-1, width, height)
+
+	column := 1
+	update := func() {
+		position.Text = fmt.Sprintf("1:%d", column)
+	}
+	update()
+	render := func() {
+		ui.Render(p, bar)
+	}
+	bar.OnFlashEnd = render
+	render()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "i":
+			bar.Mode = "INSERT"
+			modified.Text = "[+]"
+		case "<Escape>":
+			bar.Mode = "NORMAL"
+		case "w":
+			modified.Text = ""
+			bar.Flash(`"main.go" written`, 2*time.Second)
+		case "h":
+			column = ui.MaxInt(column-1, 1)
+		case "l":
+			column++
+		case "<Resize>":
+			payload := e.Payload.(ui.Resize)
+			p.SetRect(0, 0, payload.Width, payload.Height-1)
+			bar.SetRect(0, payload.Height-1, payload.Width, payload.Height)
+			ui.Clear()
+		default:
+			continue
+		}
+		update()
+		render()
+	}
+}
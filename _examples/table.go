@@ -8,6 +8,7 @@ package main
 
 import (
 	"log"
+	"strings"
 
 	ui "github.com/reaalkhalil/termui"
 	"github.com/reaalkhalil/termui/widgets"
@@ -24,17 +25,20 @@ func main() {
 		[]string{"header1", "header2", "header3"},
 		[]string{"你好吗", "Go-lang is so cool", "Im working on Ruby"},
 		[]string{"2016", "10", "11"},
+		[]string{"2017", "Long values wrap onto more lines", "11"},
 	}
 	table1.TextStyle = ui.NewStyle(ui.ColorWhite)
+	table1.AutoColumnWidths = true
+	table1.MaxColumnWidth = 20
+	table1.WrapCells = true
 	table1.SetRect(0, 0, 60, 10)
 
 	ui.Render(table1)
 
 	table2 := widgets.NewTable()
-	table2.Rows = [][]string{
-		[]string{"header1", "header2", "header3"},
-		[]string{"Foundations", "Go-lang is so cool", "Im working on Ruby"},
-		[]string{"2016", "11", "11"},
+	csv := "header1,header2,header3\nFoundations,Go-lang is so cool,Im working on Ruby\n2016,11,11\n"
+	if err := table2.LoadCSV(strings.NewReader(csv)); err != nil {
+		log.Fatalf("failed to load csv: %v", err)
 	}
 	table2.TextStyle = ui.NewStyle(ui.ColorWhite)
 	table2.TextAlignment = ui.AlignCenter
@@ -44,29 +48,73 @@ func main() {
 	ui.Render(table2)
 
 	table3 := widgets.NewTable()
+	table3.Header = []string{"header1", "header2", "count"}
 	table3.Rows = [][]string{
-		[]string{"header1", "header2", "header3"},
-		[]string{"AAA", "BBB", "CCC"},
-		[]string{"DDD", "EEE", "FFF"},
-		[]string{"GGG", "HHH", "III"},
+		[]string{"AAA", "BBB", "10"},
+		[]string{"DDD", "EEE", "9"},
+		[]string{"GGG", "HHH", "100"},
+	}
+	table3.Comparators[2] = widgets.CompareNumbers
+	table3.CellStyle = func(row, col int, value string) ui.Style {
+		if col == 2 && value == "100" {
+			return ui.NewStyle(ui.ColorGreen)
+		}
+		return table3.TextStyle
 	}
+	table3.ColumnWidths = []int{30, 30, 30}
+	table3.FrozenColumns = 1
+	table3.Selectable = true
+	table3.Editable = true
+	table3.MultiSelect = true
 	table3.TextStyle = ui.NewStyle(ui.ColorWhite)
 	table3.RowSeparator = true
 	table3.BorderStyle = ui.NewStyle(ui.ColorGreen)
 	table3.SetRect(0, 30, 70, 20)
 	table3.FillRow = true
-	table3.RowStyles[0] = ui.NewStyle(ui.ColorWhite, ui.ColorBlack, ui.ModifierBold)
-	table3.RowStyles[2] = ui.NewStyle(ui.ColorWhite, ui.ColorRed, ui.ModifierBold)
-	table3.RowStyles[3] = ui.NewStyle(ui.ColorYellow)
+	table3.RowStyles[1] = ui.NewStyle(ui.ColorWhite, ui.ColorRed, ui.ModifierBold)
+	table3.RowStyles[2] = ui.NewStyle(ui.ColorYellow)
+	// a double-clicked row is edited
+	table3.OnActivate = func(row int) {
+		table3.StartEdit()
+	}
 
 	ui.Render(table3)
 
 	uiEvents := ui.PollEvents()
 	for {
 		e := <-uiEvents
+		if table3.HandleEditKey(e.ID) || table3.HandleSearchKey(e.ID) || table3.HandleMouseResize(e) || table3.HandleMouse(e) {
+			ui.Render(table3)
+			continue
+		}
 		switch e.ID {
 		case "q", "<C-c>":
 			return
+		case "<Enter>":
+			table3.StartEdit()
+		case "/":
+			table3.StartSearch()
+		case "<Tab>":
+			table3.SelectNextColumn()
+		case "+":
+			table3.ResizeColumn(table3.SelectedColumn, 1)
+		case "-":
+			table3.ResizeColumn(table3.SelectedColumn, -1)
+		case "1", "2", "3":
+			column := int(e.ID[0] - '1')
+			sorted, ascending := table3.SortColumn()
+			table3.Sort(column, sorted != column || !ascending)
+		case "j", "<Down>":
+			table3.ScrollDown()
+		case "k", "<Up>":
+			table3.ScrollUp()
+		case "<Space>":
+			table3.ToggleSelection()
+		case "h", "<Left>":
+			table3.ScrollLeft()
+		case "l", "<Right>":
+			table3.ScrollRight()
 		}
+		ui.Render(table3)
 	}
 }
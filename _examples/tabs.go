@@ -20,37 +20,44 @@ func main() {
 	defer ui.Close()
 
 	header := widgets.NewParagraph()
-	header.Text = "Press q to quit, Press h or l to switch tabs"
+	header.Text = "Press q to quit, Press h, l, or <Tab> to switch tabs"
 	header.SetRect(0, 0, 50, 1)
 	header.Border = false
 	header.TextStyle.Bg = ui.ColorBlue
 
 	p2 := widgets.NewParagraph()
-	p2.Text = "Press q to quit\nPress h or l to switch tabs\n"
+	p2.Text = "Press q to quit\nPress h or l to switch tabs\nClick a tab's × to close it\n"
 	p2.Title = "Keys"
-	p2.SetRect(5, 5, 40, 15)
 	p2.BorderStyle.Fg = ui.ColorYellow
 
 	bc := widgets.NewBarChart()
 	bc.Title = "Bar Chart"
 	bc.Data = []float64{3, 2, 5, 3, 9, 5, 3, 2, 5, 8, 3, 2, 4, 5, 3, 2, 5, 7, 5, 3, 2, 6, 7, 4, 6, 3, 6, 7, 8, 3, 6, 4, 5, 3, 2, 4, 6, 4, 8, 5, 9, 4, 3, 6, 5, 3, 6}
-	bc.SetRect(5, 5, 35, 10)
 	bc.Labels = []string{"S0", "S1", "S2", "S3", "S4", "S5"}
 
-	tabpane := widgets.NewTabPane("pierwszy", "drugi", "trzeci", "żółw", "four", "five")
-	tabpane.SetRect(0, 1, 50, 4)
-	tabpane.Border = true
+	// the third tab holds two lists; f moves the focus between them and j or k scroll the focused one
+	left := widgets.NewList()
+	left.Title = "Left (focused)"
+	left.Rows = []string{"one", "two", "three", "four"}
+	right := widgets.NewList()
+	right.Title = "Right"
+	right.Rows = []string{"uno", "dos", "tres", "cuatro"}
+	grid := ui.NewGrid()
+	grid.Set(ui.NewRow(1, ui.NewCol(.5, left), ui.NewCol(.5, right)))
 
-	renderTab := func() {
-		switch tabpane.ActiveTabIndex {
-		case 0:
-			ui.Render(p2)
-		case 1:
-			ui.Render(bc)
-		}
+	tabpane := widgets.NewTabPane()
+	tabpane.SetRect(0, 1, 50, 16)
+	tabpane.Border = true
+	tabpane.Closable = true
+	tabpane.AddTab("pierwszy", p2)
+	tabpane.AddTab("drugi", bc)
+	tabpane.AddTab("trzeci", grid)
+	tabpane.SetFocus(left)
+	for _, name := range []string{"żółw", "four", "five"} {
+		tabpane.AddTab(name, nil)
 	}
 
-	ui.Render(header, tabpane, p2)
+	ui.Render(header, tabpane)
 
 	uiEvents := ui.PollEvents()
 
@@ -61,14 +68,34 @@ func main() {
 			return
 		case "h":
 			tabpane.FocusLeft()
-			ui.Clear()
-			ui.Render(header, tabpane)
-			renderTab()
 		case "l":
 			tabpane.FocusRight()
-			ui.Clear()
-			ui.Render(header, tabpane)
-			renderTab()
+		case "<Tab>":
+			tabpane.NextTab()
+		case "f":
+			if tabpane.ActiveContent() == grid {
+				if tabpane.Focused() == left {
+					tabpane.SetFocus(right)
+					left.Title, right.Title = "Left", "Right (focused)"
+				} else {
+					tabpane.SetFocus(left)
+					left.Title, right.Title = "Left (focused)", "Right"
+				}
+			}
+		case "j", "k":
+			if list, ok := tabpane.Focused().(*widgets.List); ok {
+				if e.ID == "j" {
+					list.ScrollDown()
+				} else {
+					list.ScrollUp()
+				}
+			}
+		default:
+			if !tabpane.HandleMouse(e) {
+				continue
+			}
 		}
+		ui.Clear()
+		ui.Render(header, tabpane)
 	}
 }
@@ -0,0 +1,54 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"strings"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	labels := widgets.NewTagInput()
+	labels.Title = "Labels"
+	labels.Placeholder = "type a label"
+	labels.KnownTags = []string{"bug", "enhancement", "documentation", "question", "help wanted", "good first issue", "wontfix"}
+	labels.Tags = []string{"bug"}
+	labels.Focused = true
+	labels.SetRect(0, 0, 60, 3)
+
+	p := widgets.NewParagraph()
+	p.Title = "Filter"
+	p.Text = "<Enter> or , adds a label, <Backspace> removes the last one, <C-c> quits"
+	p.SetRect(0, 3, 60, 8)
+
+	labels.OnTagsChange = func(tags []string) {
+		p.Text = "label:" + strings.Join(tags, " label:")
+	}
+
+	ui.Render(labels, p)
+
+	for e := range ui.PollEvents() {
+		switch e.Type {
+		case ui.KeyboardEvent:
+			if e.ID == "<C-c>" {
+				return
+			}
+			labels.HandleKey(e.ID)
+		case ui.MouseEvent:
+			labels.HandleMouse(e)
+		}
+		ui.Render(labels, p)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	ta := widgets.NewTextArea()
+	ta.Title = "Commit message"
+	ta.SetText("Fix the column widths of wrapped tables\n\nRows taller than the table were cut off when WrapCells was set.")
+	ta.Focused = true
+	ta.SetRect(0, 0, 50, 12)
+
+	status := widgets.NewParagraph()
+	status.Border = false
+	status.SetRect(0, 12, 50, 14)
+
+	render := func() {
+		line, column := ta.Cursor()
+		summary := strings.SplitN(ta.Text(), "\n", 2)[0]
+		status.Text = fmt.Sprintf("Ln %d, Col %d | summary %d/50 | <C-c> to quit", line+1, column+1, len([]rune(summary)))
+		ui.Render(ta, status)
+	}
+	render()
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "<C-c>":
+			return
+		case "<Resize>":
+			ui.Clear()
+		case "<Paste>":
+			ta.HandlePaste(e.Payload.(ui.Paste).Text)
+		default:
+			if !ta.HandleKey(e.ID) && !ta.HandleMouse(e) {
+				continue
+			}
+		}
+		render()
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	input := widgets.NewTextInput()
+	input.Title = "Name"
+	input.Placeholder = "type your name and press Enter"
+	input.Focused = true
+	input.SetRect(0, 0, 40, 3)
+
+	password := widgets.NewTextInput()
+	password.Title = "Password (<C-r> to reveal)"
+	password.Masked = true
+	password.SetRect(0, 3, 40, 6)
+
+	p := widgets.NewParagraph()
+	p.Title = "Greeting"
+	p.Text = "Press <Tab> to switch fields, <C-c> to quit"
+	p.SetRect(0, 6, 40, 9)
+
+	input.OnChange = func(text string) {
+		p.Title = fmt.Sprintf("Greeting (%d runes typed)", len([]rune(text)))
+	}
+	input.OnSubmit = func(text string) {
+		p.Text = fmt.Sprintf("Hello, %s!", text)
+		input.SetText("")
+	}
+	password.OnSubmit = func(text string) {
+		p.Text = fmt.Sprintf("Your password has %d runes", len([]rune(text)))
+		password.Wipe()
+	}
+
+	ui.Render(input, password, p)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "<C-c>":
+			return
+		case "<Tab>":
+			input.Focused, password.Focused = password.Focused, input.Focused
+		case "<C-r>":
+			password.ToggleReveal()
+		case "<Paste>":
+			text := e.Payload.(ui.Paste).Text
+			if !input.HandlePaste(text) && !password.HandlePaste(text) {
+				continue
+			}
+		default:
+			if !input.HandleKey(e.ID) && !password.HandleKey(e.ID) && !input.HandleMouse(e) && !password.HandleMouse(e) {
+				continue
+			}
+		}
+		ui.Render(input, password, p)
+	}
+}
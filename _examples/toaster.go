@@ -0,0 +1,64 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"image"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	width, height := ui.TerminalDimensions()
+
+	p := widgets.NewParagraph()
+	p.Title = "Toasts"
+	p.Text = "i: info, s: success, w: warning, e: error, c: change corner, d: dismiss all, q: quit\nClick a toast to dismiss it"
+	p.SetRect(0, 0, width, height)
+
+	toaster := widgets.NewToaster(image.Rect(1, 1, width-1, height-1))
+	render := func() {
+		ui.Render(p)
+	}
+	toaster.OnChange = render
+
+	count := 0
+	for e := range ui.PollEvents() {
+		count++
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "i":
+			toaster.Info(fmt.Sprintf("Background sync #%d started", count))
+		case "s":
+			toaster.Success("All 128 files were uploaded")
+		case "w":
+			toaster.Warning("Disk usage is above 90%, consider cleaning up old snapshots")
+		case "e":
+			toaster.Error("Connection to the database was lost")
+		case "c":
+			toaster.DismissAll()
+			toaster.Corner = (toaster.Corner + 1) % 4
+			toaster.Info("Toasts now stack from this corner")
+		case "d":
+			toaster.DismissAll()
+		default:
+			if !toaster.HandleMouse(e) {
+				continue
+			}
+		}
+		render()
+	}
+}
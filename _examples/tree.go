@@ -3,7 +3,9 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"time"
 
 	ui "github.com/reaalkhalil/termui"
 	"github.com/reaalkhalil/termui/widgets"
@@ -65,13 +67,52 @@ func main() {
 			Value: nodeValue("Key 3"),
 			Nodes: nil,
 		},
+		{
+			Value: nodeValue("Remote (loaded when expanded)"),
+			Lazy:  true,
+		},
 	}
 
 	l := widgets.NewTree()
 	l.TextStyle = ui.NewStyle(ui.ColorYellow)
 	l.WrapText = false
+	l.Checkboxes = true
+	l.Title = "Tree (<Space> to check)"
+	l.NodeRenderer = func(node *widgets.TreeNode, selected bool) []ui.Cell {
+		style := l.TextStyle
+		if selected {
+			style = ui.NewStyle(ui.ColorBlack, ui.ColorYellow)
+		}
+		cells := ui.RunesToStyledCells([]rune(node.Value.String()), style)
+		if len(node.Nodes) > 0 {
+			badge := fmt.Sprintf(" [%d]", len(node.Nodes))
+			cells = append(cells, ui.RunesToStyledCells([]rune(badge), ui.NewStyle(ui.ColorCyan))...)
+		}
+		return cells
+	}
 	l.SetNodes(nodes)
 
+	// children of lazy nodes are fetched in the background and set from the event loop
+	type loadedNodes struct {
+		node  *widgets.TreeNode
+		nodes []*widgets.TreeNode
+	}
+	loaded := make(chan loadedNodes)
+	l.OnExpand = func(node *widgets.TreeNode) []*widgets.TreeNode {
+		go func() {
+			time.Sleep(time.Second)
+			nodes := []*widgets.TreeNode{}
+			for i := 1; i <= 3; i++ {
+				nodes = append(nodes, &widgets.TreeNode{
+					Value: nodeValue(fmt.Sprintf("%v.%d", node.Value, i)),
+					Lazy:  true,
+				})
+			}
+			loaded <- loadedNodes{node, nodes}
+		}()
+		return nil
+	}
+
 	x, y := ui.TerminalDimensions()
 
 	l.SetRect(0, 0, x, y)
@@ -81,7 +122,18 @@ func main() {
 	previousKey := ""
 	uiEvents := ui.PollEvents()
 	for {
-		e := <-uiEvents
+		var e ui.Event
+		select {
+		case e = <-uiEvents:
+		case result := <-loaded:
+			l.SetChildren(result.node, result.nodes)
+			ui.Render(l)
+			continue
+		}
+		if l.HandleSearchKey(e.ID) {
+			ui.Render(l)
+			continue
+		}
 		switch e.ID {
 		case "q", "<C-c>":
 			return
@@ -105,6 +157,11 @@ func main() {
 			l.ScrollTop()
 		case "<Enter>":
 			l.ToggleExpand()
+		case "/":
+			l.StartSearch()
+		case "<Space>":
+			l.ToggleChecked()
+			l.Title = fmt.Sprintf("Tree (%d checked)", len(l.CheckedNodes()))
 		case "G", "<End>":
 			l.ScrollBottom()
 		case "E":
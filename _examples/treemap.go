@@ -0,0 +1,67 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func dir(label string, nodes ...*widgets.TreemapNode) *widgets.TreemapNode {
+	return &widgets.TreemapNode{Label: label, Nodes: nodes}
+}
+
+func file(label string, size float64) *widgets.TreemapNode {
+	return &widgets.TreemapNode{Label: label, Value: size}
+}
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	root := dir("/",
+		dir("usr",
+			dir("lib", file("go", 480<<20), file("python3", 210<<20), file("x86_64-linux-gnu", 1<<30)),
+			dir("share", file("doc", 120<<20), file("icons", 90<<20), file("fonts", 60<<20)),
+			file("bin", 350<<20),
+		),
+		dir("home",
+			dir("ann", file("videos", 3<<30), file("photos", 2<<30), file("code", 400<<20)),
+			dir("bob", file("music", 1<<30), file("documents", 200<<20)),
+		),
+		dir("var", file("log", 300<<20), file("cache", 700<<20), file("lib", 500<<20)),
+		file("opt", 600<<20),
+		file("etc", 20<<20),
+	)
+
+	treemap := widgets.NewTreemap()
+	treemap.Title = "Disk usage (arrows select, <Enter> opens, <Backspace> goes back, q quits)"
+	treemap.NumFormatter = widgets.FormatBytes
+	treemap.SetRoot(root)
+	termWidth, termHeight := ui.TerminalDimensions()
+	treemap.SetRect(0, 0, termWidth, termHeight)
+
+	ui.Render(treemap)
+
+	for e := range ui.PollEvents() {
+		switch e.ID {
+		case "q", "<C-c>":
+			return
+		case "<Resize>":
+			payload := e.Payload.(ui.Resize)
+			treemap.SetRect(0, 0, payload.Width, payload.Height)
+			ui.Clear()
+		}
+		if treemap.HandleKey(e.ID) || treemap.HandleMouse(e) || e.ID == "<Resize>" {
+			ui.Render(treemap)
+		}
+	}
+}
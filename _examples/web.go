@@ -0,0 +1,74 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"math/rand"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/web"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// Serves a dashboard at http://localhost:8080 instead of drawing it in the terminal.
+func main() {
+	backend := web.NewBackend(":8080")
+	backend.Title = "termui dashboard"
+	if err := ui.InitBackend(backend); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+	log.Println("serving on http://localhost:8080, press q in the page to quit")
+
+	sparkline := widgets.NewSparkline()
+	sparkline.Data = []float64{}
+	sparkline.LineColor = ui.ColorGreen
+	load := widgets.NewSparklineGroup(sparkline)
+	load.Title = "Load"
+
+	list := widgets.NewList()
+	list.Title = "Hosts (arrows move)"
+	list.Rows = []string{"web-1", "web-2", "db-1", "cache-1", "queue-1"}
+
+	grid := ui.NewGrid()
+	resize := func() {
+		width, height := ui.TerminalDimensions()
+		grid.SetRect(0, 0, width, height)
+	}
+	grid.Set(
+		ui.NewRow(1.0/2, ui.NewCol(1.0, load)),
+		ui.NewRow(1.0/2, ui.NewCol(1.0, list)),
+	)
+	resize()
+	ui.Render(grid)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	events := ui.PollEvents()
+	for {
+		select {
+		case e := <-events:
+			switch e.ID {
+			case "q", "<C-c>":
+				return
+			case "<Up>", "k":
+				list.ScrollUp()
+			case "<Down>", "j":
+				list.ScrollDown()
+			case "<Resize>":
+				resize()
+				ui.Clear()
+			}
+			ui.Render(grid)
+		case <-ticker.C:
+			sparkline.Data = append(sparkline.Data, rand.Float64()*10)
+			ui.Render(grid)
+		}
+	}
+}
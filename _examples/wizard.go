@@ -0,0 +1,81 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	name := widgets.NewTextInput()
+	email := widgets.NewTextInput()
+	account := widgets.NewForm()
+	account.Border = false
+	account.AddField("Name", "name", name, widgets.Required)
+	account.AddField("Email", "email", email, widgets.Required)
+
+	plan := widgets.NewRadioGroup("Free", "Pro ($8/month)", "Team ($20/month)")
+	plan.Title = "<Space> chooses a plan, <Enter> continues"
+	plan.Focused = true
+
+	summary := widgets.NewParagraph()
+	summary.Title = "Confirm"
+
+	wizard := widgets.NewWizard()
+	wizard.Title = "Sign up (<C-c> quits)"
+	wizard.SetRect(0, 0, 60, 16)
+	wizard.AddStep("Account", account)
+	wizard.AddStep("Plan", plan).Validate = func() error {
+		if plan.Selected < 0 {
+			return errors.New("choose a plan")
+		}
+		return nil
+	}
+	wizard.AddStep("Confirm", summary)
+
+	wizard.OnStepChange = func(step int) {
+		summary.Text = fmt.Sprintf("Name: %s\nEmail: %s\nPlan: %s\n\n<Enter> finishes, <Backspace> goes back",
+			name.Text(), email.Text(), plan.Options[plan.Selected])
+	}
+	done := false
+	wizard.OnFinish = func() {
+		done = true
+	}
+
+	ui.Render(wizard)
+
+	for e := range ui.PollEvents() {
+		switch e.Type {
+		case ui.KeyboardEvent:
+			if e.ID == "<C-c>" {
+				return
+			}
+			// the radio group selects with <Space>, leaving <Enter> to the wizard
+			if e.ID == "<Enter>" && wizard.Current() == 1 {
+				wizard.Next()
+			} else {
+				wizard.HandleKey(e.ID)
+			}
+		case ui.MouseEvent:
+			wizard.HandleMouse(e)
+		}
+		if done {
+			return
+		}
+		ui.Render(wizard)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// AnnouncementKind is what an Announcement is about.
+type AnnouncementKind uint
+
+const (
+	// AnnounceFocus is a widget getting the focus.
+	AnnounceFocus AnnouncementKind = iota
+	// AnnounceSelection is a change of what is selected in a widget, like the row of a List.
+	AnnounceSelection
+	// AnnounceAlert is a message needing attention, like a toast or an error.
+	AnnounceAlert
+)
+
+func (self AnnouncementKind) String() string {
+	switch self {
+	case AnnounceSelection:
+		return "selection"
+	case AnnounceAlert:
+		return "alert"
+	}
+	return "focus"
+}
+
+// Announcement is a change of the UI told to users who can't see it, like the users of screen readers.
+type Announcement struct {
+	Kind AnnouncementKind
+	Text string
+}
+
+// Announcer receives Announcements, to speak them or write them where a screen reader reads them.
+type Announcer interface {
+	Announce(Announcement)
+}
+
+// AnnouncerFunc is a function used as an Announcer.
+type AnnouncerFunc func(Announcement)
+
+func (self AnnouncerFunc) Announce(announcement Announcement) {
+	self(announcement)
+}
+
+// DefaultAnnouncer receives the Announcements of Render and Announce. Nothing is announced while
+// it is nil, which it is by default.
+var DefaultAnnouncer Announcer
+
+// Announce sends an Announcement to DefaultAnnouncer, for the changes widgets don't announce,
+// like an error shown by the application.
+func Announce(kind AnnouncementKind, text string) {
+	if DefaultAnnouncer != nil && text != "" {
+		DefaultAnnouncer.Announce(Announcement{kind, text})
+	}
+}
+
+// Accessible is implemented by the widgets which tell what has the focus and what is selected.
+// Render, including the Render of the items of a Grid, announces the changes of Accessible
+// widgets to DefaultAnnouncer after drawing them: a widget getting the focus is announced with
+// its name and selection, and a new selection of a widget is announced by itself. What was last
+// announced is kept in the Block of the widget, so widgets without one aren't announced.
+type Accessible interface {
+	// Accessibility returns the name of the widget, like its title, whether it has the focus,
+	// and the text of what is selected in it, which is "" when nothing is.
+	Accessibility() (name string, focused bool, selection string)
+}
+
+// accessibleState is what an Accessible widget had focused and selected when it was last drawn.
+type accessibleState struct {
+	seen      bool
+	focused   bool
+	selection string
+}
+
+// accessibleDrawable is implemented by Block and therefore by every widget embedding it, which
+// keeps what was last announced of it, so that it is dropped along with the widget.
+type accessibleDrawable interface {
+	Accessible
+	announceState() *accessibleState
+}
+
+// announceLock guards the accessibleState of every widget.
+var announceLock sync.Mutex
+
+// announceItem announces the changes of an item drawn since it was last drawn when it is Accessible.
+// The first selection of a widget isn't announced unless the widget has the focus.
+func announceItem(item Drawable) {
+	if DefaultAnnouncer == nil {
+		return
+	}
+	accessible, ok := item.(accessibleDrawable)
+	if !ok {
+		return
+	}
+	item.Lock()
+	name, focused, selection := accessible.Accessibility()
+	item.Unlock()
+
+	announceLock.Lock()
+	previous := *accessible.announceState()
+	*accessible.announceState() = accessibleState{seen: true, focused: focused, selection: selection}
+	announceLock.Unlock()
+
+	switch {
+	case focused && !previous.focused:
+		text := name
+		if selection != "" {
+			text = strings.TrimPrefix(text+", "+selection, ", ")
+		}
+		Announce(AnnounceFocus, text)
+	case previous.seen && selection != previous.selection:
+		Announce(AnnounceSelection, selection)
+	}
+}
+
+func (self *Block) announceState() *accessibleState {
+	return &self.announced
+}
+
+// NewWriterAnnouncer returns an Announcer writing every Announcement on a line of w, after its
+// kind, like "focus: Files". Writing to os.Stderr while the UI is drawn on the terminal requires
+// redirecting stderr, to a file or to the terminal of a screen reader.
+func NewWriterAnnouncer(w io.Writer) Announcer {
+	lock := sync.Mutex{}
+	return AnnouncerFunc(func(announcement Announcement) {
+		lock.Lock()
+		defer lock.Unlock()
+		fmt.Fprintf(w, "%v: %s\n", announcement.Kind, announcement.Text)
+	})
+}
+
+// NewLogAnnouncer returns an Announcer printing every Announcement with logger.
+func NewLogAnnouncer(logger *log.Logger) Announcer {
+	return AnnouncerFunc(func(announcement Announcement) {
+		logger.Printf("%v: %s", announcement.Kind, announcement.Text)
+	})
+}
+
+// NewSpeechDispatcherAnnouncer returns an Announcer speaking every Announcement with spd-say,
+// the client of Speech Dispatcher, which is used by the Orca screen reader on Linux. A focus or
+// selection interrupts the one still being spoken, while alerts are spoken with priority.
+// It returns an error when spd-say isn't installed.
+func NewSpeechDispatcherAnnouncer() (Announcer, error) {
+	path, err := exec.LookPath("spd-say")
+	if err != nil {
+		return nil, err
+	}
+	return AnnouncerFunc(func(announcement Announcement) {
+		priority := "text"
+		if announcement.Kind == AnnounceAlert {
+			priority = "important"
+		}
+		// text starting with - would be read as an option
+		cmd := exec.Command(path, "-P", priority, " "+announcement.Text)
+		if cmd.Start() == nil {
+			go cmd.Wait()
+		}
+	}), nil
+}
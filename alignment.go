@@ -6,4 +6,7 @@ const (
 	AlignLeft Alignment = iota
 	AlignCenter
 	AlignRight
+	// AlignJustify stretches the spaces between words so that lines fill their width.
+	// Widgets that don't justify text treat it like AlignLeft.
+	AlignJustify
 )
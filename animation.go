@@ -0,0 +1,77 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sync"
+	"time"
+)
+
+// Animation calls Step every Interval from its own goroutine while it is running, for widgets
+// that change over time such as spinners and clocks. Step runs while holding the render lock,
+// like Update, so it must not call Render or Clear; OnFrame is called after it, without the lock,
+// so that the application can Render the new frame.
+type Animation struct {
+	Interval time.Duration
+	Step     func(now time.Time)
+	OnFrame  func()
+
+	lock sync.Mutex
+	stop chan struct{}
+}
+
+func NewAnimation(interval time.Duration, step func(now time.Time)) *Animation {
+	return &Animation{
+		Interval: interval,
+		Step:     step,
+	}
+}
+
+// Start runs the animation if it isn't already running.
+func (self *Animation) Start() {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if self.stop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	self.stop = stop
+	ticker := time.NewTicker(self.Interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				Update(func() {
+					if self.Step != nil {
+						self.Step(now)
+					}
+				})
+				if self.OnFrame != nil {
+					self.OnFrame()
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops the animation. A frame being drawn when it is called may still reach OnFrame.
+func (self *Animation) Stop() {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if self.stop != nil {
+		close(self.stop)
+		self.stop = nil
+	}
+}
+
+// Running reports whether the animation has been started and not stopped.
+func (self *Animation) Running() bool {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	return self.stop != nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
+)
+
+// ParseANSI converts text holding ANSI escape sequences, like the output of `ls --color`,
+// into []Cell. SGR sequences set the Style of the cells that follow, starting from defaultStyle;
+// other escape sequences and carriage returns are removed.
+// Supported are bold, underline, reverse, the 16 basic colors, and 256 and 24-bit colors.
+func ParseANSI(text string, defaultStyle Style) []Cell {
+	cells := []Cell{}
+	style := defaultStyle
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\x1b' && i+1 < len(runes) && runes[i+1] == '[':
+			// CSI: parameters and intermediate bytes end with a final byte in @-~
+			end := i + 2
+			for end < len(runes) && (runes[end] < '@' || runes[end] > '~') {
+				end++
+			}
+			if end < len(runes) && runes[end] == 'm' {
+				style = applySGR(string(runes[i+2:end]), style, defaultStyle)
+			}
+			i = end
+		case r == '\x1b' && i+1 < len(runes) && runes[i+1] == ']':
+			// OSC: ends with BEL or ESC \
+			end := i + 2
+			for end < len(runes) && runes[end] != '\a' && !(runes[end] == '\x1b' && end+1 < len(runes) && runes[end+1] == '\\') {
+				end++
+			}
+			if end < len(runes) && runes[end] == '\x1b' {
+				end++
+			}
+			i = end
+		case r == '\x1b' && i+2 < len(runes) && (runes[i+1] == '(' || runes[i+1] == ')'):
+			// character set selection
+			i += 2
+		case r == '\x1b':
+			// two-byte escape sequences
+			i++
+		case r == '\r':
+		default:
+			cells = append(cells, Cell{r, style})
+		}
+	}
+	return cells
+}
+
+// applySGR returns style changed by the parameters of a Select Graphic Rendition sequence.
+func applySGR(params string, style Style, defaultStyle Style) Style {
+	codes := []int{}
+	for _, param := range strings.FieldsFunc(params, func(r rune) bool { return r == ';' || r == ':' }) {
+		code, err := strconv.Atoi(param)
+		if err != nil {
+			code = 0
+		}
+		codes = append(codes, code)
+	}
+	if len(codes) == 0 {
+		return defaultStyle
+	}
+
+	// extendedColor reads the 256 or 24-bit color following codes[i], returning it and the index of its last code
+	extendedColor := func(i int) (Color, int, bool) {
+		switch {
+		case i+2 < len(codes) && codes[i+1] == 5:
+			return Color(codes[i+2]), i + 2, true
+		case i+4 < len(codes) && codes[i+1] == 2:
+			return NewRGBColor(uint8(codes[i+2]), uint8(codes[i+3]), uint8(codes[i+4])), i + 4, true
+		}
+		return 0, len(codes), false
+	}
+
+	for i := 0; i < len(codes); i++ {
+		switch code := codes[i]; {
+		case code == 0:
+			style = defaultStyle
+		case code == 1:
+			style.Modifier |= ModifierBold
+		case code == 4:
+			style.Modifier |= ModifierUnderline
+		case code == 7:
+			style.Modifier |= ModifierReverse
+		case code == 22:
+			style.Modifier &^= ModifierBold
+		case code == 24:
+			style.Modifier &^= ModifierUnderline
+		case code == 27:
+			style.Modifier &^= ModifierReverse
+		case code >= 30 && code <= 37:
+			style.Fg = Color(code - 30)
+		case code == 38:
+			color, last, ok := extendedColor(i)
+			if ok {
+				style.Fg = color
+			}
+			i = last
+		case code == 39:
+			style.Fg = defaultStyle.Fg
+		case code >= 40 && code <= 47:
+			style.Bg = Color(code - 40)
+		case code == 48:
+			color, last, ok := extendedColor(i)
+			if ok {
+				style.Bg = color
+			}
+			i = last
+		case code == 49:
+			style.Bg = defaultStyle.Bg
+		case code >= 90 && code <= 97:
+			style.Fg = Color(code - 90 + 8)
+		case code >= 100 && code <= 107:
+			style.Bg = Color(code - 100 + 8)
+		}
+	}
+	return style
+}
+
+// FormatANSI returns the escape sequences drawing the cells of next which differ from those of
+// prev on a terminal, at their position on the screen, or clearing the screen and drawing every
+// cell when prev is nil. It is the reverse of ParseANSI, for sending frames to terminals other
+// than the one of the Backend, like recordings or remote screens.
+func FormatANSI(prev, next *Buffer) string {
+	out := strings.Builder{}
+	if prev == nil {
+		out.WriteString("\x1b[0m\x1b[2J")
+	}
+	// the cursor is moved only where it isn't after the last cell written
+	cursor, style := image.Pt(-1, -1), Style{-3, -3, 0}
+	for y := next.Min.Y; y < next.Max.Y; y++ {
+		for x := next.Min.X; x < next.Max.X; x++ {
+			p := image.Pt(x, y)
+			cell := next.GetCell(p)
+			if cell.Rune == 0 {
+				cell = CellClear
+			}
+			width := MaxInt(rw.RuneWidth(cell.Rune), 1)
+			if prev != nil {
+				if p.In(prev.Rectangle) && prev.GetCell(p) == cell {
+					x += width - 1
+					continue
+				}
+			}
+			if p != cursor {
+				fmt.Fprintf(&out, "\x1b[%d;%dH", y+1, x+1)
+			}
+			if cell.Style != style {
+				out.WriteString(formatSGR(cell.Style))
+				style = cell.Style
+			}
+			out.WriteRune(cell.Rune)
+			// a wide rune covers the next cell
+			x += width - 1
+			cursor = image.Pt(x+1, y)
+		}
+	}
+	if style.Fg != -3 {
+		out.WriteString("\x1b[0m")
+	}
+	return out.String()
+}
+
+// formatSGR returns the Select Graphic Rendition sequence of a style.
+func formatSGR(style Style) string {
+	codes := []string{"0"}
+	if style.Modifier&ModifierBold != 0 {
+		codes = append(codes, "1")
+	}
+	if style.Modifier&ModifierUnderline != 0 {
+		codes = append(codes, "4")
+	}
+	if style.Modifier&ModifierReverse != 0 {
+		codes = append(codes, "7")
+	}
+	color := func(color Color, base int) {
+		switch {
+		case color < 0:
+		case color&colorRGB != 0:
+			r, g, b := color.RGB()
+			codes = append(codes, fmt.Sprintf("%d;2;%d;%d;%d", base+8, r, g, b))
+		case color < 8:
+			codes = append(codes, strconv.Itoa(base+int(color)))
+		case color < 16:
+			codes = append(codes, strconv.Itoa(base+60+int(color)-8))
+		default:
+			codes = append(codes, fmt.Sprintf("%d;5;%d", base+8, color))
+		}
+	}
+	color(style.Fg, 30)
+	color(style.Bg, 40)
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
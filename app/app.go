@@ -0,0 +1,230 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// Package app runs applications in the Elm architecture: the state of the application is held
+// by a Model, whose Update returns the model changed by every message, like an event or the result
+// of some work, and whose View returns the widgets drawn for it. Work like requests and timers is
+// done by the commands Update returns and by the subscriptions of the model, which report back with
+// messages, so that widgets are only changed by Update on the goroutine of the Program instead of
+// by goroutines of their own.
+package app
+
+import (
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+)
+
+// Msg is a message telling a Model what happened: a ui.Event, the result of a Cmd, or a value
+// received by a Sub.
+type Msg interface{}
+
+// Cmd is work done on its own goroutine, like a request, returning the message reporting its result,
+// or nil for none.
+type Cmd func() Msg
+
+// Model is the state of an application.
+type Model interface {
+	// Update returns the model changed by msg, and a Cmd to run, or nil.
+	Update(msg Msg) (Model, Cmd)
+	// View returns the items drawn for the model, in the order given to ui.Render.
+	View() []ui.Drawable
+}
+
+// Initializer is implemented by the models with work to start when the Program starts.
+type Initializer interface {
+	// Init returns the Cmd run when the Program starts, or nil.
+	Init() Cmd
+}
+
+// Subscriber is implemented by the models with subscriptions, like tickers and channels.
+type Subscriber interface {
+	// Subscriptions returns the subscriptions wanted by the model. It is called after every
+	// Update: the subscriptions whose Key wasn't there before are started, and those missing
+	// from the ones returned are stopped.
+	Subscriptions() []Sub
+}
+
+// Sub is a subscription sending messages to the Program from its own goroutine until it is stopped.
+type Sub struct {
+	// Key identifies the subscription across updates, so that it keeps running while the
+	// model keeps it.
+	Key string
+	// Run sends messages with send until stop is closed.
+	Run func(send func(Msg), stop <-chan struct{})
+}
+
+// Every returns a Sub sending the message returned by fn with the time every interval.
+func Every(key string, interval time.Duration, fn func(now time.Time) Msg) Sub {
+	return Sub{Key: key, Run: func(send func(Msg), stop <-chan struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				send(fn(now))
+			}
+		}
+	}}
+}
+
+// Channel returns a Sub sending the messages received from ch until it is closed.
+func Channel(key string, ch <-chan Msg) Sub {
+	return Sub{Key: key, Run: func(send func(Msg), stop <-chan struct{}) {
+		for {
+			select {
+			case <-stop:
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				send(msg)
+			}
+		}
+	}}
+}
+
+// quitMsg ends the Program.
+type quitMsg struct{}
+
+// Quit is a Cmd ending the Program once the Update returning it is done.
+func Quit() Msg {
+	return quitMsg{}
+}
+
+// batchMsg holds Cmds to run at once.
+type batchMsg []Cmd
+
+// Batch returns a Cmd running cmds at once, each on its own goroutine. nil Cmds are left out.
+func Batch(cmds ...Cmd) Cmd {
+	return func() Msg {
+		return batchMsg(cmds)
+	}
+}
+
+// Tick returns a Cmd sending the message returned by fn with the time after d, once.
+func Tick(d time.Duration, fn func(now time.Time) Msg) Cmd {
+	return func() Msg {
+		return fn(<-time.After(d))
+	}
+}
+
+// Program runs a Model: it sends the events of ui.PollEvents, the results of Cmds, and the
+// messages of subscriptions to Update one at a time, and renders the View after each of them.
+//
+//	if err := ui.Init(); err != nil {
+//		log.Fatalf("failed to initialize termui: %v", err)
+//	}
+//	defer ui.Close()
+//	model := app.NewProgram(counter{}).Run()
+type Program struct {
+	model Model
+	msgs  chan Msg
+	quit  chan struct{}
+	subs  map[string]chan struct{}
+}
+
+func NewProgram(model Model) *Program {
+	return &Program{
+		model: model,
+		msgs:  make(chan Msg),
+		quit:  make(chan struct{}),
+		subs:  map[string]chan struct{}{},
+	}
+}
+
+// Send sends a message to Update from any goroutine. It is dropped once the Program has ended.
+func (self *Program) Send(msg Msg) {
+	select {
+	case self.msgs <- msg:
+	case <-self.quit:
+	}
+}
+
+// Run runs the Program on the UI initialized by ui.Init or ui.InitBackend until a Cmd returns
+// Quit, and returns the last model. The Program is run only once.
+func (self *Program) Run() Model {
+	defer close(self.quit)
+	defer self.subscribe(nil)
+
+	if initializer, ok := self.model.(Initializer); ok {
+		self.run(initializer.Init())
+	}
+	self.sync()
+	self.render()
+
+	events := ui.PollEvents()
+	for {
+		var msg Msg
+		select {
+		case e := <-events:
+			msg = e
+		case msg = <-self.msgs:
+		}
+		switch msg := msg.(type) {
+		case quitMsg:
+			return self.model
+		case batchMsg:
+			for _, cmd := range msg {
+				self.run(cmd)
+			}
+			continue
+		}
+		var cmd Cmd
+		self.model, cmd = self.model.Update(msg)
+		self.run(cmd)
+		self.sync()
+		self.render()
+	}
+}
+
+// run runs a Cmd on its own goroutine, sending its message to Update.
+func (self *Program) run(cmd Cmd) {
+	if cmd == nil {
+		return
+	}
+	go func() {
+		if msg := cmd(); msg != nil {
+			self.Send(msg)
+		}
+	}()
+}
+
+// sync starts and stops the subscriptions to match those of the model.
+func (self *Program) sync() {
+	if subscriber, ok := self.model.(Subscriber); ok {
+		self.subscribe(subscriber.Subscriptions())
+	} else {
+		self.subscribe(nil)
+	}
+}
+
+// subscribe starts the subscriptions which aren't running and stops those left out of subs.
+func (self *Program) subscribe(subs []Sub) {
+	wanted := map[string]bool{}
+	for _, sub := range subs {
+		wanted[sub.Key] = true
+		if _, ok := self.subs[sub.Key]; ok {
+			continue
+		}
+		stop := make(chan struct{})
+		self.subs[sub.Key] = stop
+		go sub.Run(self.Send, stop)
+	}
+	for key, stop := range self.subs {
+		if !wanted[key] {
+			close(stop)
+			delete(self.subs, key)
+		}
+	}
+}
+
+func (self *Program) render() {
+	if items := self.model.View(); len(items) > 0 {
+		ui.Render(items...)
+	}
+}
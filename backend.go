@@ -5,31 +5,85 @@
 package termui
 
 import (
-	tb "github.com/nsf/termbox-go"
+	"image"
 )
 
-// Init initializes termbox-go and is required to render anything.
+// Backend is the screen termui draws on and the source of its events. Init uses a terminal
+// through termbox-go, and InitBackend can use another Backend, such as one serving the UI to
+// browsers.
+type Backend interface {
+	Init() error
+	Close()
+	// Size returns the number of columns and rows of the screen.
+	Size() (int, int)
+	// SetCell sets a cell of the back buffer, which is drawn on the screen by Flush.
+	SetCell(p image.Point, cell Cell)
+	// Cell returns a cell of the back buffer.
+	Cell(p image.Point) Cell
+	// Clear fills the back buffer with blank cells of a style.
+	Clear(style Style)
+	// Flush draws the cells of the back buffer changed since the last Flush, and Sync draws all of
+	// them.
+	Flush()
+	Sync()
+	// PollEvent waits for the next event.
+	PollEvent() Event
+	// ColorDepth and Graphics are the colors and the graphics protocol of the screen, which set
+	// TerminalColorDepth and TerminalGraphics.
+	ColorDepth() ColorDepth
+	Graphics() GraphicsProtocol
+}
+
+// backend is the Backend set by Init or InitBackend.
+var backend Backend = &termboxBackend{}
+
+// Init initializes termbox-go and is required to render anything, and sets TerminalProfile from
+// DetectProfile. On Windows, the console is drawn with virtual terminal sequences when it supports
+// them, which Windows 10 and later do, and with termbox-go and the legacy console API otherwise.
 // After initialization, the library must be finalized with `Close`.
 func Init() error {
-	if err := tb.Init(); err != nil {
+	TerminalProfile = DetectProfile()
+	if b := newPlatformBackend(); b != nil {
+		if err := InitBackend(b); err == nil {
+			return nil
+		}
+	}
+	return InitBackend(NewTermboxBackend())
+}
+
+// InitBackend initializes a Backend, which Render, Clear, and PollEvents use instead of
+// termbox-go. After initialization, the library must be finalized with `Close`.
+func InitBackend(b Backend) error {
+	if err := b.Init(); err != nil {
 		return err
 	}
-	tb.SetInputMode(tb.InputEsc | tb.InputMouse)
-	tb.SetOutputMode(tb.Output256)
+	backend = b
+	TerminalColorDepth = b.ColorDepth()
+	TerminalGraphics = b.Graphics()
+	TerminalSynchronizedOutput = false
+	if b, ok := b.(synchronizedBackend); ok {
+		TerminalSynchronizedOutput = b.SynchronizedOutput()
+	}
 	return nil
 }
 
-// Close closes termbox-go.
+// Close removes any graphics left on the screen and closes the Backend.
 func Close() {
-	tb.Close()
+	renderLock.Lock()
+	deleteGraphics()
+	renderLock.Unlock()
+	backend.Close()
 }
 
 func TerminalDimensions() (int, int) {
-	tb.Sync()
-	width, height := tb.Size()
+	backend.Sync()
+	width, height := backend.Size()
 	return width, height
 }
 
 func Clear() {
-	tb.Clear(tb.ColorDefault, tb.Attribute(Theme.Default.Bg+1))
+	renderLock.Lock()
+	defer renderLock.Unlock()
+	backend.Clear(NewStyle(ColorClear, Theme.Default.Bg))
+	graphicsStale = len(shownGraphics) > 0
 }
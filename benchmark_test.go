@@ -0,0 +1,183 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui_test
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// nullBackend is a 256 color screen of 200 by 60 cells, which discards them after downgrading
+// their styles like terminals do.
+type nullBackend struct {
+	cell ui.Cell
+}
+
+func (self *nullBackend) Init() error      { return nil }
+func (self *nullBackend) Close()           {}
+func (self *nullBackend) Size() (int, int) { return 200, 60 }
+func (self *nullBackend) SetCell(p image.Point, cell ui.Cell) {
+	cell.Style = cell.Style.Downgrade(ui.TerminalColorDepth)
+	self.cell = cell
+}
+func (self *nullBackend) Cell(image.Point) ui.Cell      { return ui.CellClear }
+func (self *nullBackend) Clear(ui.Style)                {}
+func (self *nullBackend) Flush()                        {}
+func (self *nullBackend) Sync()                         {}
+func (self *nullBackend) PollEvent() ui.Event           { select {} }
+func (self *nullBackend) ColorDepth() ui.ColorDepth     { return ui.ColorDepth256 }
+func (self *nullBackend) Graphics() ui.GraphicsProtocol { return ui.GraphicsNone }
+
+var initOnce sync.Once
+
+// initNullBackend initializes termui with a nullBackend for the benchmarks rendering.
+func initNullBackend(b *testing.B) {
+	initOnce.Do(func() {
+		if err := ui.InitBackend(&nullBackend{}); err != nil {
+			b.Fatal(err)
+		}
+	})
+}
+
+func BenchmarkNewBuffer(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ui.NewBuffer(image.Rect(0, 0, 200, 60))
+	}
+}
+
+func BenchmarkBufferSetString(b *testing.B) {
+	buf := ui.NewBuffer(image.Rect(0, 0, 200, 60))
+	line := strings.Repeat("termui ", 28)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for y := 0; y < 60; y++ {
+			buf.SetString(line, ui.StyleClear, image.Pt(0, y))
+		}
+	}
+}
+
+func BenchmarkBufferComposite(b *testing.B) {
+	buf := ui.NewBuffer(image.Rect(0, 0, 200, 60))
+	overlay := ui.NewTransparentBuffer(image.Rect(50, 10, 150, 50))
+	overlay.Fill(ui.NewCell('x', ui.NewStyle(ui.ColorRed, ui.ColorTransparent)), image.Rect(60, 20, 140, 40))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Composite(overlay)
+	}
+}
+
+func BenchmarkParseStyles(b *testing.B) {
+	text := strings.Repeat("some [styled](fg:red,mod:bold) text ", 5)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ui.ParseStyles(text, ui.StyleClear)
+	}
+}
+
+func BenchmarkParseStylesPlain(b *testing.B) {
+	text := strings.Repeat("some plain text ", 10)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ui.ParseStyles(text, ui.StyleClear)
+	}
+}
+
+func BenchmarkCanvas(b *testing.B) {
+	canvas := ui.NewCanvas()
+	canvas.SetRect(0, 0, 200, 60)
+	points := []image.Point{}
+	for x := 0; x < 400; x++ {
+		points = append(points, image.Pt(x, 120+int(100*math.Sin(float64(x)/20))))
+	}
+	canvas.SetPolyline(points, ui.ColorGreen)
+	canvas.SetCircle(image.Pt(200, 120), 80, ui.ColorRed)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		canvas.Draw(ui.NewBuffer(canvas.GetRect()))
+	}
+}
+
+func newTable(rows int) *widgets.Table {
+	table := widgets.NewTable()
+	table.Header = []string{"id", "name", "status", "cpu", "memory", "uptime"}
+	for i := 0; i < rows; i++ {
+		table.Rows = append(table.Rows, []string{
+			fmt.Sprint(i), fmt.Sprintf("process-%d", i), "[running](fg:green)",
+			fmt.Sprintf("%.1f%%", math.Mod(float64(i)*7.3, 100)), fmt.Sprintf("%d MB", i%512), "3d 4h",
+		})
+	}
+	table.SetRect(0, 0, 200, 60)
+	return table
+}
+
+// dashboard returns a Grid of a Table, a Plot, and a Paragraph.
+func dashboard() *ui.Grid {
+	plot := widgets.NewPlot()
+	data := []float64{}
+	for x := 0; x < 300; x++ {
+		data = append(data, math.Sin(float64(x)/15))
+	}
+	plot.Data = [][]float64{data}
+	paragraph := widgets.NewParagraph()
+	paragraph.Text = strings.Repeat("[log](fg:cyan) line\n", 60)
+
+	grid := ui.NewGrid()
+	grid.SetRect(0, 0, 200, 60)
+	grid.Set(
+		ui.NewRow(0.5, ui.NewCol(0.5, newTable(1000)), ui.NewCol(0.5, plot)),
+		ui.NewRow(0.5, paragraph),
+	)
+	return grid
+}
+
+func BenchmarkRender(b *testing.B) {
+	initNullBackend(b)
+	grid := dashboard()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ui.Render(grid)
+	}
+}
+
+// BenchmarkRenderParallel renders the dashboard with the widgets drawn concurrently.
+func BenchmarkRenderParallel(b *testing.B) {
+	initNullBackend(b)
+	grid := dashboard()
+	grid.Parallel = true
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ui.Render(grid)
+	}
+}
+
+// BenchmarkRenderRGB renders 24-bit colors, which are downgraded to the 256 colors of the
+// backend.
+func BenchmarkRenderRGB(b *testing.B) {
+	initNullBackend(b)
+	canvas := ui.NewCanvas()
+	canvas.SetRect(0, 0, 200, 60)
+	for y := 0; y < 240; y += 2 {
+		color := ui.NewRGBColor(uint8(y), uint8(255-y), 128)
+		canvas.SetLine(image.Pt(0, y), image.Pt(399, y), color)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ui.Render(canvas)
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"unicode"
+)
+
+// Direction is the base direction of a line of text, which orders the runs of left-to-right and
+// right-to-left text it mixes, like Hebrew or Arabic words in English text, and the side it starts on.
+type Direction uint
+
+const (
+	// DirectionAuto takes the direction of the first letter of a paragraph, left-to-right when it has none.
+	DirectionAuto Direction = iota
+	DirectionLTR
+	DirectionRTL
+)
+
+// bidiClass is the bidirectional character type of a rune, from the Unicode bidirectional algorithm.
+type bidiClass uint8
+
+const (
+	bidiL   bidiClass = iota // left-to-right letter
+	bidiR                    // right-to-left letter, like Hebrew
+	bidiAL                   // Arabic letter
+	bidiEN                   // European number
+	bidiAN                   // Arabic number
+	bidiES                   // number separator, like + and -
+	bidiET                   // number terminator, like % and $
+	bidiCS                   // common separator, like , and .
+	bidiNSM                  // nonspacing mark, taking the class of the rune it marks
+	bidiWS                   // whitespace
+	bidiON                   // other neutral
+)
+
+// classOf returns the bidiClass of a rune. The right-to-left scripts are Hebrew, Arabic, Syriac,
+// Thaana, NKo, and Samaritan; the other letters are left-to-right.
+func classOf(r rune) bidiClass {
+	switch {
+	case r >= '0' && r <= '9', r >= 0x06f0 && r <= 0x06f9:
+		return bidiEN
+	case r < 0x80:
+		switch {
+		case r == ' ' || r == '\t':
+			return bidiWS
+		case r == '+' || r == '-':
+			return bidiES
+		case r == '#' || r == '$' || r == '%':
+			return bidiET
+		case r == ',' || r == '.' || r == ':' || r == '/':
+			return bidiCS
+		case unicode.IsLetter(r):
+			return bidiL
+		}
+		return bidiON
+	case r >= 0x0660 && r <= 0x0669, r == 0x066b || r == 0x066c:
+		return bidiAN
+	case r == 0xa0:
+		return bidiCS
+	case r == 0xa2 || r == 0xa3 || r == 0xa5 || r == 0xb0 || r == 0x20ac:
+		return bidiET
+	case unicode.Is(unicode.Mn, r):
+		return bidiNSM
+	case r >= 0x0590 && r <= 0x05ff, r >= 0x07c0 && r <= 0x085f, r >= 0xfb1d && r <= 0xfb4f:
+		return bidiR
+	case r >= 0x0600 && r <= 0x074f, r >= 0x0780 && r <= 0x07bf, r >= 0x0860 && r <= 0x08ff,
+		r >= 0xfb50 && r <= 0xfdff, r >= 0xfe70 && r <= 0xfefe:
+		return bidiAL
+	case r == 0x200f:
+		return bidiR
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || r == 0x200e:
+		return bidiL
+	case unicode.IsSpace(r):
+		return bidiWS
+	}
+	return bidiON
+}
+
+// ResolveDirection returns direction, or for DirectionAuto the direction of the first letter
+// of cells: DirectionRTL for a right-to-left letter and otherwise DirectionLTR.
+func ResolveDirection(cells []Cell, direction Direction) Direction {
+	if direction != DirectionAuto {
+		return direction
+	}
+	for _, cell := range cells {
+		switch classOf(cell.Rune) {
+		case bidiL:
+			return DirectionLTR
+		case bidiR, bidiAL:
+			return DirectionRTL
+		}
+	}
+	return DirectionLTR
+}
+
+// ReorderCells returns a line of cells, in the order they are typed in, in the order they are
+// drawn in from left to right, following the Unicode bidirectional algorithm without explicit
+// embeddings, with direction as the base direction of the line. Brackets and other mirrored
+// runes in right-to-left runs are mirrored. It also returns the index in cells of every cell
+// returned, or nil when the cells are drawn in the order they are in, which is always the case
+// for left-to-right lines without right-to-left letters or Arabic numbers.
+// Lines are reordered after being wrapped. Arabic letters aren't shaped into their joined forms,
+// which is left to the terminal.
+func ReorderCells(cells []Cell, direction Direction) ([]Cell, []int) {
+	direction = ResolveDirection(cells, direction)
+	classes := make([]bidiClass, len(cells))
+	reorder := direction == DirectionRTL
+	for i, cell := range cells {
+		classes[i] = classOf(cell.Rune)
+		if classes[i] == bidiR || classes[i] == bidiAL || classes[i] == bidiAN {
+			reorder = true
+		}
+	}
+	if !reorder || len(cells) == 0 {
+		return cells, nil
+	}
+
+	levels := bidiLevels(classes, direction)
+
+	// L1: trailing whitespace goes back to the base level
+	base := 0
+	if direction == DirectionRTL {
+		base = 1
+	}
+	for i := len(cells) - 1; i >= 0 && classOf(cells[i].Rune) == bidiWS; i-- {
+		levels[i] = base
+	}
+
+	// L2: from the highest level down to the lowest odd one, every run at that level or higher is reversed
+	index := make([]int, len(cells))
+	highest, lowest := 0, levels[0]
+	for i, level := range levels {
+		index[i] = i
+		highest, lowest = MaxInt(highest, level), MinInt(lowest, level)
+	}
+	lowestOdd := lowest | 1
+	for level := highest; level >= lowestOdd; level-- {
+		for i := 0; i < len(index); {
+			if levels[index[i]] < level {
+				i++
+				continue
+			}
+			end := i
+			for end < len(index) && levels[index[end]] >= level {
+				end++
+			}
+			for a, b := i, end-1; a < b; a, b = a+1, b-1 {
+				index[a], index[b] = index[b], index[a]
+			}
+			i = end
+		}
+	}
+
+	reordered := make([]Cell, len(cells))
+	for i, k := range index {
+		reordered[i] = cells[k]
+		if levels[k]%2 == 1 {
+			if mirrored, ok := mirroredRunes[cells[k].Rune]; ok {
+				reordered[i].Rune = mirrored
+			}
+		}
+	}
+	return reordered, index
+}
+
+// bidiLevels resolves the embedding level of every rune of a line from its class, with the weak
+// (W1-W7), neutral (N1-N2), and implicit (I1-I2) rules of the bidirectional algorithm.
+func bidiLevels(classes []bidiClass, direction Direction) []int {
+	types := make([]bidiClass, len(classes))
+	copy(types, classes)
+	sos := bidiL
+	if direction == DirectionRTL {
+		sos = bidiR
+	}
+
+	// W1: marks take the class of the rune before them
+	// W2: European numbers after an Arabic letter are Arabic numbers
+	// W3: Arabic letters are right-to-left letters
+	previous, strong := sos, sos
+	for i, t := range types {
+		if t == bidiNSM {
+			t = previous
+		}
+		switch t {
+		case bidiL, bidiR, bidiAL:
+			strong = t
+		case bidiEN:
+			if strong == bidiAL {
+				t = bidiAN
+			}
+		}
+		previous = t
+		if t == bidiAL {
+			t = bidiR
+		}
+		types[i] = t
+	}
+
+	// W4: a single separator between two numbers of the same kind joins them
+	for i := 1; i < len(types)-1; i++ {
+		before, after := types[i-1], types[i+1]
+		switch {
+		case types[i] == bidiES && before == bidiEN && after == bidiEN:
+			types[i] = bidiEN
+		case types[i] == bidiCS && before == after && (before == bidiEN || before == bidiAN):
+			types[i] = before
+		}
+	}
+
+	// W5: terminators next to European numbers are part of them
+	for i := 0; i < len(types); {
+		if types[i] != bidiET {
+			i++
+			continue
+		}
+		end := i
+		for end < len(types) && types[end] == bidiET {
+			end++
+		}
+		if (i > 0 && types[i-1] == bidiEN) || (end < len(types) && types[end] == bidiEN) {
+			for k := i; k < end; k++ {
+				types[k] = bidiEN
+			}
+		}
+		i = end
+	}
+
+	// W6: the remaining separators and terminators are neutral
+	// W7: European numbers in left-to-right text are left-to-right
+	strong = sos
+	for i, t := range types {
+		switch t {
+		case bidiES, bidiET, bidiCS:
+			types[i] = bidiON
+		case bidiL, bidiR:
+			strong = t
+		case bidiEN:
+			if strong == bidiL {
+				types[i] = bidiL
+			}
+		}
+	}
+
+	// N1: neutrals between runes of the same direction take it, numbers counting as right-to-left
+	// N2: the other neutrals take the base direction
+	strongOf := func(t bidiClass) bidiClass {
+		if t == bidiL {
+			return bidiL
+		}
+		return bidiR
+	}
+	for i := 0; i < len(types); {
+		if types[i] != bidiWS && types[i] != bidiON {
+			i++
+			continue
+		}
+		end := i
+		for end < len(types) && (types[end] == bidiWS || types[end] == bidiON) {
+			end++
+		}
+		before, after := sos, sos
+		if i > 0 {
+			before = strongOf(types[i-1])
+		}
+		if end < len(types) {
+			after = strongOf(types[end])
+		}
+		resolved := sos
+		if before == after {
+			resolved = before
+		}
+		for k := i; k < end; k++ {
+			types[k] = resolved
+		}
+		i = end
+	}
+
+	// I1, I2: levels are raised for the runes going against the base direction, and numbers
+	levels := make([]int, len(types))
+	for i, t := range types {
+		if direction == DirectionRTL {
+			levels[i] = 1
+			if t != bidiR {
+				levels[i] = 2
+			}
+		} else {
+			switch t {
+			case bidiR:
+				levels[i] = 1
+			case bidiEN, bidiAN:
+				levels[i] = 2
+			}
+		}
+	}
+	return levels
+}
+
+// mirroredRunes are the runes drawn mirrored in right-to-left text.
+var mirroredRunes = map[rune]rune{
+	'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{', '<': '>', '>': '<',
+	'«': '»', '»': '«', '‹': '›', '›': '‹', '≤': '≥', '≥': '≤',
+}
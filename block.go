@@ -6,7 +6,28 @@ package termui
 
 import (
 	"image"
+	"strings"
 	"sync"
+
+	rw "github.com/mattn/go-runewidth"
+)
+
+// BorderSet holds the runes a Block draws its border with.
+type BorderSet struct {
+	Top, Bottom, Left, Right                   rune
+	TopLeft, TopRight, BottomLeft, BottomRight rune
+}
+
+var (
+	// BorderSingle is the default border of thin lines, drawn with ASCII on Windows.
+	BorderSingle = BorderSet{
+		HORIZONTAL_LINE, HORIZONTAL_LINE, VERTICAL_LINE, VERTICAL_LINE,
+		TOP_LEFT, TOP_RIGHT, BOTTOM_LEFT, BOTTOM_RIGHT,
+	}
+	BorderRounded = BorderSet{'─', '─', '│', '│', '╭', '╮', '╰', '╯'}
+	BorderDouble  = BorderSet{'═', '═', '║', '║', '╔', '╗', '╚', '╝'}
+	BorderThick   = BorderSet{'━', '━', '┃', '┃', '┏', '┓', '┗', '┛'}
+	BorderASCII   = BorderSet{'-', '-', '|', '|', '+', '+', '+', '+'}
 )
 
 // Block is the base struct inherited by most widgets.
@@ -16,16 +37,72 @@ import (
 type Block struct {
 	Border      bool
 	BorderStyle Style
+	// BorderSet is the runes of the border, like BorderRounded or BorderDouble.
+	BorderSet BorderSet
 
+	// BorderLeft, BorderRight, BorderTop, and BorderBottom draw each side of the border, so that
+	// panes placed side by side can share a line by leaving out one of theirs.
 	BorderLeft, BorderRight, BorderTop, BorderBottom bool
+	// BorderLeftStyle, BorderRightStyle, BorderTopStyle, and BorderBottomStyle are used for their
+	// side in place of BorderStyle when not nil. Corners take the style of the top or bottom side.
+	BorderLeftStyle, BorderRightStyle, BorderTopStyle, BorderBottomStyle *Style
 
+	// PaddingLeft, PaddingRight, PaddingTop, and PaddingBottom keep the content off the border, by
+	// the number of cells of their side between the border and Inner.
 	PaddingLeft, PaddingRight, PaddingTop, PaddingBottom int
+	// MarginLeft, MarginRight, MarginTop, and MarginBottom keep the widget off the sides of the area
+	// given to SetRect, leaving gaps between the widgets of a Grid. The Rectangle of the widget is
+	// the area without them.
+	MarginLeft, MarginRight, MarginTop, MarginBottom int
 
 	image.Rectangle
 	Inner image.Rectangle
 
 	Title      string
 	TitleStyle Style
+	// TitleAlignment places the Title on the top border. BottomTitle is drawn on the bottom border,
+	// placed by BottomTitleAlignment.
+	TitleAlignment       Alignment
+	BottomTitle          string
+	BottomTitleStyle     Style
+	BottomTitleAlignment Alignment
+
+	// Badge is drawn at the right of the top border, like a count or a status glyph, with the Title
+	// trimmed to leave room for it.
+	Badge      string
+	BadgeStyle Style
+
+	// Background fills Inner beneath the content of the widget when its Rune isn't 0, like a space
+	// with a background color for a solid fill, or a shade of SHADED_BLOCKS for a pattern.
+	// Watermark is drawn faintly in the middle of it, like "NO DATA" for an empty state.
+	Background     Cell
+	Watermark      string
+	WatermarkStyle Style
+
+	// Shadow dims the cells below and right of the widget, like the shadow of a popup or a dialog
+	// floating over the content beneath it, keeping their runes and drawing them with the colors
+	// of ShadowStyle.
+	Shadow      bool
+	ShadowStyle Style
+
+	// Transparent makes the cells the widget leaves untouched show the content
+	// already on screen instead of being cleared when rendered.
+	Transparent bool
+
+	// Profile is what the widget is drawn with in place of TerminalProfile when it is set, like
+	// ProfileASCII for a widget drawn with ASCII on any terminal.
+	Profile *Profile
+
+	// Hooks are called by the Lifecycle of the widget, as it is mounted, focused, and shown or hidden.
+	Hooks LifecycleHooks
+	// lifecycle is the state of the Lifecycle of the widget, guarded by mountLock.
+	lifecycle lifecycleState
+	// announced is what was last announced of the widget when it is Accessible, guarded by
+	// announceLock.
+	announced accessibleState
+
+	// children are the widgets hosted in the regions of the Block with Attach.
+	children []blockChild
 
 	sync.Mutex
 }
@@ -34,69 +111,186 @@ func NewBlock() *Block {
 	return &Block{
 		Border:       true,
 		BorderStyle:  Theme.Block.Border,
+		BorderSet:    Theme.Block.BorderSet,
 		BorderLeft:   true,
 		BorderRight:  true,
 		BorderTop:    true,
 		BorderBottom: true,
 
-		TitleStyle: Theme.Block.Title,
+		TitleStyle:       Theme.Block.Title,
+		BottomTitleStyle: Theme.Block.Title,
+		BadgeStyle:       Theme.Block.Badge,
+		ShadowStyle:      Theme.Block.Shadow,
+		WatermarkStyle:   Theme.Block.Watermark,
+	}
+}
+
+// sideStyle returns the style of a side of the border.
+func (self *Block) sideStyle(style *Style) Style {
+	if style != nil {
+		return *style
 	}
+	return self.BorderStyle
 }
 
 func (self *Block) drawBorder(buf *Buffer) {
-	verticalCell := Cell{VERTICAL_LINE, self.BorderStyle}
-	horizontalCell := Cell{HORIZONTAL_LINE, self.BorderStyle}
+	set := self.BorderSet
+	if set == (BorderSet{}) {
+		set = BorderSingle
+	}
+	top, bottom := self.sideStyle(self.BorderTopStyle), self.sideStyle(self.BorderBottomStyle)
+	left, right := self.sideStyle(self.BorderLeftStyle), self.sideStyle(self.BorderRightStyle)
 
 	// draw lines
 	if self.BorderTop {
-		buf.Fill(horizontalCell, image.Rect(self.Min.X, self.Min.Y, self.Max.X, self.Min.Y+1))
+		buf.Fill(Cell{set.Top, top}, image.Rect(self.Min.X, self.Min.Y, self.Max.X, self.Min.Y+1))
 	}
 	if self.BorderBottom {
-		buf.Fill(horizontalCell, image.Rect(self.Min.X, self.Max.Y-1, self.Max.X, self.Max.Y))
+		buf.Fill(Cell{set.Bottom, bottom}, image.Rect(self.Min.X, self.Max.Y-1, self.Max.X, self.Max.Y))
 	}
 	if self.BorderLeft {
-		buf.Fill(verticalCell, image.Rect(self.Min.X, self.Min.Y, self.Min.X+1, self.Max.Y))
+		buf.Fill(Cell{set.Left, left}, image.Rect(self.Min.X, self.Min.Y, self.Min.X+1, self.Max.Y))
 	}
 	if self.BorderRight {
-		buf.Fill(verticalCell, image.Rect(self.Max.X-1, self.Min.Y, self.Max.X, self.Max.Y))
+		buf.Fill(Cell{set.Right, right}, image.Rect(self.Max.X-1, self.Min.Y, self.Max.X, self.Max.Y))
 	}
 
 	// draw corners
 	if self.BorderTop && self.BorderLeft {
-		buf.SetCell(Cell{TOP_LEFT, self.BorderStyle}, self.Min)
+		buf.SetCell(Cell{set.TopLeft, top}, self.Min)
 	}
 	if self.BorderTop && self.BorderRight {
-		buf.SetCell(Cell{TOP_RIGHT, self.BorderStyle}, image.Pt(self.Max.X-1, self.Min.Y))
+		buf.SetCell(Cell{set.TopRight, top}, image.Pt(self.Max.X-1, self.Min.Y))
 	}
 	if self.BorderBottom && self.BorderLeft {
-		buf.SetCell(Cell{BOTTOM_LEFT, self.BorderStyle}, image.Pt(self.Min.X, self.Max.Y-1))
+		buf.SetCell(Cell{set.BottomLeft, bottom}, image.Pt(self.Min.X, self.Max.Y-1))
 	}
 	if self.BorderBottom && self.BorderRight {
-		buf.SetCell(Cell{BOTTOM_RIGHT, self.BorderStyle}, self.Max.Sub(image.Pt(1, 1)))
+		buf.SetCell(Cell{set.BottomRight, bottom}, self.Max.Sub(image.Pt(1, 1)))
 	}
 }
 
 // Draw implements the Drawable interface.
 func (self *Block) Draw(buf *Buffer) {
+	if self.Background.Rune != 0 {
+		buf.Fill(self.Background, self.Inner)
+	}
+	if self.Watermark != "" {
+		self.drawWatermark(buf)
+	}
 	if self.Border {
 		self.drawBorder(buf)
 	}
-	buf.SetString(
-		self.Title,
-		self.TitleStyle,
-		image.Pt(self.Min.X+2, self.Min.Y),
-	)
+	// the titles are kept off the corners, and the Title off the Badge
+	end := self.Max.X - 2
+	if self.Badge != "" {
+		badge := TrimString(self.Badge, end-self.Min.X-2)
+		end -= rw.StringWidth(badge)
+		buf.SetString(badge, self.BadgeStyle, image.Pt(end, self.Min.Y))
+		end--
+	}
+	self.drawTitle(buf, self.Title, self.TitleStyle, self.TitleAlignment, end, self.Min.Y)
+	if self.BottomTitle != "" {
+		self.drawTitle(buf, self.BottomTitle, self.BottomTitleStyle, self.BottomTitleAlignment, self.Max.X-2, self.Max.Y-1)
+	}
+	self.drawChildren(buf)
+}
+
+// drawWatermark draws the lines of the Watermark centered in Inner, over the Background.
+func (self *Block) drawWatermark(buf *Buffer) {
+	lines := strings.Split(self.Watermark, "\n")
+	y := self.Inner.Min.Y + (self.Inner.Dy()-len(lines))/2
+	for _, line := range lines {
+		if y >= self.Inner.Min.Y && y < self.Inner.Max.Y {
+			line = TrimString(line, self.Inner.Dx())
+			x := self.Inner.Min.X + (self.Inner.Dx()-rw.StringWidth(line))/2
+			style := self.WatermarkStyle
+			if self.Background.Rune != 0 && style.Bg == ColorClear {
+				style.Bg = self.Background.Style.Bg
+			}
+			buf.SetString(line, style, image.Pt(x, y))
+		}
+		y++
+	}
+}
+
+// drawTitle draws a title aligned on the line y, between the left corner and end.
+func (self *Block) drawTitle(buf *Buffer, title string, style Style, align Alignment, end, y int) {
+	start := self.Min.X + 2
+	title = TrimString(title, end-start)
+	x := start
+	switch align {
+	case AlignCenter:
+		x += (end - start - rw.StringWidth(title)) / 2
+	case AlignRight:
+		x = end - rw.StringWidth(title)
+	}
+	buf.SetString(title, style, image.Pt(x, y))
 }
 
 // SetRect implements the Drawable interface.
 func (self *Block) SetRect(x1, y1, x2, y2 int) {
-	self.Rectangle = image.Rect(x1, y1, x2, y2)
+	area := image.Rect(x1, y1, x2, y2)
+	self.Rectangle = image.Rect(
+		area.Min.X+self.MarginLeft,
+		area.Min.Y+self.MarginTop,
+		MaxInt(area.Max.X-self.MarginRight, area.Min.X+self.MarginLeft),
+		MaxInt(area.Max.Y-self.MarginBottom, area.Min.Y+self.MarginTop),
+	)
 	self.Inner = image.Rect(
 		self.Min.X+1+self.PaddingLeft,
 		self.Min.Y+1+self.PaddingTop,
 		self.Max.X-1-self.PaddingRight,
 		self.Max.Y-1-self.PaddingBottom,
 	)
+	self.layoutChildren()
+}
+
+// shadowOffset is how far the shadow of a Block is cast, two columns for each row so that it
+// looks as deep to the right as below.
+var shadowOffset = image.Pt(2, 1)
+
+// shadowRect returns the area of the shadow cast by the Block, and false if it casts none. The
+// cells of the Rectangle itself aren't dimmed.
+func (self *Block) shadowRect() (image.Rectangle, Style, bool) {
+	if !self.Shadow || self.Rectangle.Empty() {
+		return image.Rectangle{}, Style{}, false
+	}
+	return self.Rectangle.Add(shadowOffset), self.ShadowStyle, true
+}
+
+func (self *Block) isTransparent() bool {
+	return self.Transparent
+}
+
+func (self *Block) profile() *Profile {
+	return self.Profile
+}
+
+// SetPadding sets the padding of every side, like CSS: from one value for all of them, two for
+// the top and bottom then the left and right, or four for the top, right, bottom, and left.
+// It applies from the next SetRect.
+func (self *Block) SetPadding(sides ...int) {
+	self.PaddingTop, self.PaddingRight, self.PaddingBottom, self.PaddingLeft = expandSides(sides)
+}
+
+// SetMargin sets the margin of every side from one, two, or four values, like SetPadding.
+func (self *Block) SetMargin(sides ...int) {
+	self.MarginTop, self.MarginRight, self.MarginBottom, self.MarginLeft = expandSides(sides)
+}
+
+// expandSides returns the top, right, bottom, and left values of one, two, or four values,
+// which are 0 for any other number of them.
+func expandSides(sides []int) (int, int, int, int) {
+	switch len(sides) {
+	case 1:
+		return sides[0], sides[0], sides[0], sides[0]
+	case 2:
+		return sides[0], sides[1], sides[0], sides[1]
+	case 4:
+		return sides[0], sides[1], sides[2], sides[3]
+	}
+	return 0, 0, 0, 0
 }
 
 // GetRect implements the Drawable interface.
@@ -21,6 +21,12 @@ var CellClear = Cell{
 	Style: StyleClear,
 }
 
+// CellTransparent lets any content beneath it show through when composited.
+var CellTransparent = Cell{
+	Rune:  0,
+	Style: Style{ColorTransparent, ColorTransparent, ModifierClear},
+}
+
 // NewCell takes 1 to 2 arguments
 // 1st argument = rune
 // 2nd argument = optional style
@@ -35,41 +41,172 @@ func NewCell(rune rune, args ...interface{}) Cell {
 	}
 }
 
+// IsTransparent reports whether any part of the Cell shows through to the content beneath it.
+func (self Cell) IsTransparent() bool {
+	return self.Rune == 0 || self.Style.Fg == ColorTransparent || self.Style.Bg == ColorTransparent
+}
+
+// Composite returns the result of drawing the Cell over the given underlying Cell.
+// A zero Rune keeps the underlying rune along with its modifiers,
+// and a ColorTransparent Fg or Bg keeps the underlying color.
+func (self Cell) Composite(under Cell) Cell {
+	if self.Rune == 0 {
+		self.Rune = under.Rune
+		self.Style.Modifier |= under.Style.Modifier
+	}
+	if self.Style.Fg == ColorTransparent {
+		self.Style.Fg = under.Style.Fg
+	}
+	if self.Style.Bg == ColorTransparent {
+		self.Style.Bg = under.Style.Bg
+	}
+	return self
+}
+
 // Buffer represents a section of a terminal and is a renderable rectangle of cells.
 type Buffer struct {
 	image.Rectangle
-	CellMap map[image.Point]Cell
+	// Cells holds the cells of the Rectangle row by row. Cells set outside of it are dropped.
+	Cells []Cell
+	// Graphics are written to the terminal after the cells.
+	Graphics []Graphic
+	// deferred collects the functions given to Defer while the Buffer is drawn by a Grid with
+	// Parallel on another goroutine, for the Grid to call once its items are drawn.
+	deferred *[]func()
 }
 
 func NewBuffer(r image.Rectangle) *Buffer {
-	buf := &Buffer{
-		Rectangle: r,
-		CellMap:   make(map[image.Point]Cell),
+	buf := &Buffer{}
+	buf.Reset(r, CellClear) // clears out area
+	return buf
+}
+
+// NewTransparentBuffer returns a Buffer whose cells all let the content beneath them show through.
+// It is useful for drawing overlays that are later composited over another Buffer.
+func NewTransparentBuffer(r image.Rectangle) *Buffer {
+	buf := &Buffer{}
+	buf.Reset(r, CellTransparent)
+	return buf
+}
+
+// Reset makes the Buffer cover another Rectangle filled with a Cell, and removes its Graphics.
+// The storage of the cells is kept when it is large enough, so that a Buffer can be reused
+// for every frame.
+func (self *Buffer) Reset(r image.Rectangle, c Cell) {
+	r = r.Canon()
+	self.Rectangle = r
+	if size := r.Dx() * r.Dy(); cap(self.Cells) >= size {
+		self.Cells = self.Cells[:size]
+	} else {
+		self.Cells = make([]Cell, size)
+	}
+	for i := range self.Cells {
+		self.Cells[i] = c
+	}
+	self.Graphics = nil
+}
+
+// Clone returns a copy of the Buffer.
+func (self *Buffer) Clone() *Buffer {
+	return &Buffer{
+		Rectangle: self.Rectangle,
+		Cells:     append([]Cell{}, self.Cells...),
+		Graphics:  append([]Graphic{}, self.Graphics...),
+	}
+}
+
+// Defer calls f, which runs the Lifecycle callbacks or announcements of an item drawn into the
+// Buffer, like Show for the content of a layout drawing it itself. When the Buffer is drawn on
+// another goroutine by a Grid with Parallel, f is called on the goroutine of the Grid once its
+// items are drawn instead.
+func (self *Buffer) Defer(f func()) {
+	if self.deferred != nil {
+		*self.deferred = append(*self.deferred, f)
+		return
 	}
-	buf.Fill(CellClear, r) // clears out area
+	f()
+}
+
+// Derive returns a new Buffer covering r whose functions given to Defer are deferred like those of
+// the Buffer, for widgets drawing their content into Buffers of their own.
+func (self *Buffer) Derive(r image.Rectangle) *Buffer {
+	buf := NewBuffer(r)
+	buf.deferred = self.deferred
 	return buf
 }
 
+// index returns the index of the cell at a point in Cells, and whether the point is in the
+// Rectangle.
+func (self *Buffer) index(p image.Point) (int, bool) {
+	if !p.In(self.Rectangle) {
+		return 0, false
+	}
+	return (p.Y-self.Min.Y)*self.Dx() + p.X - self.Min.X, true
+}
+
+// GetCell returns the cell at a point, or an empty Cell outside of the Rectangle.
 func (self *Buffer) GetCell(p image.Point) Cell {
-	return self.CellMap[p]
+	if i, ok := self.index(p); ok {
+		return self.Cells[i]
+	}
+	return Cell{}
 }
 
 func (self *Buffer) SetCell(c Cell, p image.Point) {
-	self.CellMap[p] = c
+	if i, ok := self.index(p); ok {
+		self.Cells[i] = c
+	}
+}
+
+// SetGraphic adds a Graphic to be drawn after the cells.
+// It is drawn over whatever cells lie beneath it, so they are usually left blank.
+func (self *Buffer) SetGraphic(graphic Graphic) {
+	self.Graphics = append(self.Graphics, graphic)
+}
+
+// CompositeCell draws the Cell over the Cell currently at the given point, honoring transparency.
+func (self *Buffer) CompositeCell(c Cell, p image.Point) {
+	if i, ok := self.index(p); ok {
+		self.Cells[i] = c.Composite(self.Cells[i])
+	}
+}
+
+// Composite draws every cell of the given Buffer that lies within this Buffer on top of it,
+// letting transparent cells show the existing content through.
+func (self *Buffer) Composite(other *Buffer) {
+	area := self.Intersect(other.Rectangle)
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		i, _ := self.index(image.Pt(area.Min.X, y))
+		j, _ := other.index(image.Pt(area.Min.X, y))
+		for x := area.Min.X; x < area.Max.X; x, i, j = x+1, i+1, j+1 {
+			if cell := other.Cells[j]; cell.IsTransparent() {
+				self.Cells[i] = cell.Composite(self.Cells[i])
+			} else {
+				self.Cells[i] = cell
+			}
+		}
+	}
+	for _, graphic := range other.Graphics {
+		if graphic.Point.In(self.Rectangle) {
+			self.Graphics = append(self.Graphics, graphic)
+		}
+	}
 }
 
 func (self *Buffer) Fill(c Cell, rect image.Rectangle) {
-	for x := rect.Min.X; x < rect.Max.X; x++ {
-		for y := rect.Min.Y; y < rect.Max.Y; y++ {
-			self.SetCell(c, image.Pt(x, y))
+	rect = self.Intersect(rect)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		i, _ := self.index(image.Pt(rect.Min.X, y))
+		row := self.Cells[i : i+rect.Dx()]
+		for x := range row {
+			row[x] = c
 		}
 	}
 }
 
 func (self *Buffer) SetString(s string, style Style, p image.Point) {
-	runes := []rune(s)
 	x := 0
-	for _, char := range runes {
+	for _, char := range s {
 		self.SetCell(Cell{char, style}, image.Pt(p.X+x, p.Y))
 		x += rw.RuneWidth(char)
 	}
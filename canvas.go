@@ -3,12 +3,33 @@ package termui
 import (
 	"image"
 
+	rw "github.com/mattn/go-runewidth"
+
 	"github.com/reaalkhalil/termui/drawille"
 )
 
+// Canvas modes which trade resolution for font support.
+// Point coordinates passed to the drawing methods are in units of the selected mode's resolution.
+const (
+	CanvasBraille   = drawille.ModeBraille
+	CanvasSextant   = drawille.ModeSextant
+	CanvasQuadrant  = drawille.ModeQuadrant
+	CanvasHalfBlock = drawille.ModeHalfBlock
+)
+
 type Canvas struct {
 	Block
 	drawille.Canvas
+
+	labels []canvasLabel
+}
+
+// canvasLabel is text anchored to a point in canvas coordinates.
+type canvasLabel struct {
+	point     image.Point
+	text      string
+	style     Style
+	alignment Alignment
 }
 
 func NewCanvas() *Canvas {
@@ -26,6 +47,77 @@ func (self *Canvas) SetLine(p0, p1 image.Point, color Color) {
 	self.Canvas.SetLine(p0, p1, drawille.Color(color))
 }
 
+// SetPolyline draws connected line segments through the given braille coordinates.
+func (self *Canvas) SetPolyline(points []image.Point, color Color) {
+	self.Canvas.SetPolyline(points, drawille.Color(color))
+}
+
+// SetQuadBezier draws a quadratic Bezier curve from p0 to p2 bent towards the control point p1.
+func (self *Canvas) SetQuadBezier(p0, p1, p2 image.Point, color Color) {
+	self.Canvas.SetQuadBezier(p0, p1, p2, drawille.Color(color))
+}
+
+// SetCubicBezier draws a cubic Bezier curve from p0 to p3 bent towards the control points p1 and p2.
+func (self *Canvas) SetCubicBezier(p0, p1, p2, p3 image.Point, color Color) {
+	self.Canvas.SetCubicBezier(p0, p1, p2, p3, drawille.Color(color))
+}
+
+// SetCircle draws a circle outline in braille coordinates.
+func (self *Canvas) SetCircle(center image.Point, radius int, color Color) {
+	self.Canvas.SetCircle(center, radius, drawille.Color(color))
+}
+
+// SetEllipse draws an ellipse outline in braille coordinates with horizontal radius rx and vertical radius ry.
+func (self *Canvas) SetEllipse(center image.Point, rx, ry int, color Color) {
+	self.Canvas.SetEllipse(center, rx, ry, drawille.Color(color))
+}
+
+// SetArc draws part of a circle outline between two angles, in radians measured clockwise from 3 o'clock.
+func (self *Canvas) SetArc(center image.Point, radius int, start, end float64, color Color) {
+	self.Canvas.SetArc(center, radius, start, end, drawille.Color(color))
+}
+
+// SetEllipseArc draws part of an ellipse outline between two angles, in radians measured clockwise from 3 o'clock.
+func (self *Canvas) SetEllipseArc(center image.Point, rx, ry int, start, end float64, color Color) {
+	self.Canvas.SetEllipseArc(center, rx, ry, start, end, drawille.Color(color))
+}
+
+// SetText draws text starting at the cell containing the given point in canvas coordinates.
+// Text is drawn above any points sharing its cells.
+func (self *Canvas) SetText(p image.Point, s string, style Style) {
+	self.SetAlignedText(p, s, style, AlignLeft)
+}
+
+// SetAlignedText draws text relative to the cell containing the given point in canvas coordinates:
+// starting at it with AlignLeft, centered on it with AlignCenter, or ending at it with AlignRight.
+func (self *Canvas) SetAlignedText(p image.Point, s string, style Style, alignment Alignment) {
+	self.labels = append(self.labels, canvasLabel{p, s, style, alignment})
+}
+
+// ClearText removes all text added with SetText and SetAlignedText.
+func (self *Canvas) ClearText() {
+	self.labels = nil
+}
+
+func (self *Canvas) drawLabels(buf *Buffer) {
+	res := self.Mode.Resolution()
+	for _, label := range self.labels {
+		point := image.Pt(label.point.X/res.X, label.point.Y/res.Y)
+		switch label.alignment {
+		case AlignCenter:
+			point.X -= rw.StringWidth(label.text) / 2
+		case AlignRight:
+			point.X -= rw.StringWidth(label.text) - 1
+		}
+		for _, char := range label.text {
+			if point.In(self.Rectangle) {
+				buf.SetCell(Cell{char, label.style}, point)
+			}
+			point.X += rw.RuneWidth(char)
+		}
+	}
+}
+
 func (self *Canvas) Draw(buf *Buffer) {
 	for point, cell := range self.Canvas.GetCells() {
 		if point.In(self.Rectangle) {
@@ -40,4 +132,6 @@ func (self *Canvas) Draw(buf *Buffer) {
 			buf.SetCell(convertedCell, point)
 		}
 	}
+
+	self.drawLabels(buf)
 }
@@ -0,0 +1,23 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ClipboardWriter receives the escape sequences written by CopyToClipboard.
+var ClipboardWriter io.Writer = os.Stdout
+
+// CopyToClipboard puts text on the system clipboard with the OSC 52 escape sequence. The terminal
+// sets the clipboard itself, so it also works over SSH, but some terminals ignore the sequence
+// or need it enabled in their settings.
+func CopyToClipboard(text string) error {
+	_, err := fmt.Fprintf(ClipboardWriter, "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	return err
+}
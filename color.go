@@ -0,0 +1,192 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ColorDepth is the number of colors a terminal is able to display.
+type ColorDepth uint
+
+const (
+	ColorDepthMono ColorDepth = iota
+	ColorDepth8
+	ColorDepth16
+	ColorDepth256
+	ColorDepthTrueColor
+)
+
+// TerminalColorDepth is set by Init from DetectColorDepth.
+// Every Style is downgraded to its nearest colors at this depth when rendered,
+// so it can be lowered after Init to preview a theme on a more limited terminal.
+var TerminalColorDepth = ColorDepth256
+
+// colorRGB marks a Color as a 24-bit value created with NewRGBColor.
+const colorRGB Color = 1 << 24
+
+// NewRGBColor returns a 24-bit Color.
+// termbox output is limited to 256 colors, so it is always drawn as its nearest palette color.
+func NewRGBColor(r, g, b uint8) Color {
+	return colorRGB | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// DetectColorDepth guesses the color depth of the terminal from $NO_COLOR, $COLORTERM, and $TERM.
+func DetectColorDepth() ColorDepth {
+	return ColorDepthFromEnv(os.LookupEnv)
+}
+
+// ColorDepthFromEnv guesses the color depth of a terminal like DetectColorDepth, from the
+// environment variables returned by lookupEnv, like those sent by a remote terminal.
+func ColorDepthFromEnv(lookupEnv func(key string) (string, bool)) ColorDepth {
+	if _, ok := lookupEnv("NO_COLOR"); ok {
+		return ColorDepthMono
+	}
+	colorterm, _ := lookupEnv("COLORTERM")
+	colorterm = strings.ToLower(colorterm)
+	if colorterm == "truecolor" || colorterm == "24bit" {
+		return ColorDepthTrueColor
+	}
+	term, _ := lookupEnv("TERM")
+	term = strings.ToLower(term)
+	switch {
+	case term == "dumb" || strings.HasSuffix(term, "-mono") || strings.HasSuffix(term, "-m"):
+		return ColorDepthMono
+	case strings.Contains(term, "truecolor") || strings.Contains(term, "direct"):
+		return ColorDepthTrueColor
+	case strings.Contains(term, "256"):
+		return ColorDepth256
+	case strings.Contains(term, "16color"):
+		return ColorDepth16
+	}
+	return ColorDepth8
+}
+
+// Downgrade returns the nearest Color that can be displayed at the given depth.
+// ColorClear and ColorTransparent are returned unchanged, and every color becomes ColorClear in mono.
+func (self Color) Downgrade(depth ColorDepth) Color {
+	if self < 0 {
+		return self
+	}
+	if depth == ColorDepthMono {
+		return ColorClear
+	}
+	if self&colorRGB == 0 {
+		switch {
+		case depth >= ColorDepth256 || self < 8:
+			return self
+		case depth == ColorDepth16 && self < 16:
+			return self
+		}
+	}
+	key := downgradeKey{self, depth}
+	if color, ok := downgradeCache.Load(key); ok {
+		return color.(Color)
+	}
+	r, g, b := self.RGB()
+	var color Color
+	switch depth {
+	case ColorDepth8:
+		color = nearestColor(r, g, b, 0, 8)
+	case ColorDepth16:
+		color = nearestColor(r, g, b, 0, 16)
+	default:
+		color = nearestColor(r, g, b, 16, 256)
+	}
+	downgradeCache.Store(key, color)
+	return color
+}
+
+type downgradeKey struct {
+	color Color
+	depth ColorDepth
+}
+
+// downgradeCache holds the colors found by nearestColor, since searching the palette for every
+// cell of every frame is slow.
+var downgradeCache sync.Map
+
+// UnmarshalJSON reads a Color from its number, a name of StyleParserColorMap, or "#rrggbb", so
+// that colors can be written by name in JSON, like in the layouts of widgets.LoadLayout.
+func (self *Color) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var n int
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("color must be a number or a name: %s", data)
+		}
+		*self = Color(n)
+		return nil
+	}
+	if color, ok := StyleParserColorMap[strings.ToLower(name)]; ok {
+		*self = color
+		return nil
+	}
+	if len(name) == 7 && name[0] == '#' {
+		if rgb, err := strconv.ParseUint(name[1:], 16, 32); err == nil {
+			*self = NewRGBColor(uint8(rgb>>16), uint8(rgb>>8), uint8(rgb))
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown color %q", name)
+}
+
+// RGB returns the components of a 24-bit color, or the standard xterm values of a palette color.
+func (self Color) RGB() (uint8, uint8, uint8) {
+	switch {
+	case self < 0:
+		return 0, 0, 0
+	case self&colorRGB != 0:
+		return uint8(self >> 16), uint8(self >> 8), uint8(self)
+	case self < 16:
+		c := ansiColors[self]
+		return c[0], c[1], c[2]
+	case self < 232:
+		levels := [6]uint8{0, 95, 135, 175, 215, 255}
+		i := int(self) - 16
+		return levels[i/36], levels[(i/6)%6], levels[i%6]
+	default:
+		gray := uint8(8 + (int(self)-232)*10)
+		return gray, gray, gray
+	}
+}
+
+var ansiColors = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// nearestColor returns the palette color in [from, to) closest to the given components.
+func nearestColor(r, g, b uint8, from, to int) Color {
+	best := Color(from)
+	bestDistance := -1
+	for i := from; i < to; i++ {
+		pr, pg, pb := Color(i).RGB()
+		dr, dg, db := int(r)-int(pr), int(g)-int(pg), int(b)-int(pb)
+		distance := 2*dr*dr + 4*dg*dg + 3*db*db
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = Color(i), distance
+		}
+	}
+	return best
+}
+
+// Downgrade returns the Style with its colors downgraded to the given depth.
+// In mono, a Style with a background color is reversed so highlighted text stays visible.
+func (self Style) Downgrade(depth ColorDepth) Style {
+	if depth == ColorDepthMono && self.Bg >= 0 && self.Bg != ColorBlack {
+		self.Modifier |= ModifierReverse
+	}
+	self.Fg = self.Fg.Downgrade(depth)
+	self.Bg = self.Bg.Downgrade(depth)
+	return self
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+)
+
+// Region is the part of a Block a child widget attached with Attach is placed in.
+type Region uint
+
+const (
+	// RegionBody is what is left of Inner once the other regions are taken out of it.
+	RegionBody Region = iota
+	// RegionHeader and RegionFooter are lines across the top and the bottom of Inner.
+	RegionHeader
+	RegionFooter
+	// RegionSidebar and RegionSidebarRight are columns along the left and the right of Inner,
+	// between the headers and the footers.
+	RegionSidebar
+	RegionSidebarRight
+)
+
+// blockChild is a widget attached to a Block.
+type blockChild struct {
+	name   string
+	region Region
+	size   int
+	item   Drawable
+}
+
+// composedDrawable is implemented by Block and therefore by every widget embedding it.
+type composedDrawable interface {
+	childItems() []Drawable
+}
+
+// Attach hosts item in a region of the Block under name, so that compound widgets, like a Plot with
+// a toolbar in its header and a legend in a sidebar, are built from existing widgets. size is the
+// height of a header or footer, or the width of a sidebar, and isn't used for the body.
+//
+// The regions are taken out of Inner on SetRect, which sets the rectangles of the children and
+// leaves Inner to the body, where the content of the widget is drawn. Several headers are stacked
+// from the top down, footers from the bottom up, and sidebars from the sides in. The children are
+// drawn by the Draw of the Block in the order they were attached, before the content of the
+// widget, with a child attached again under its name keeping its place. The Block must be given
+// its rectangle again with SetRect for them to be laid out.
+func (self *Block) Attach(name string, region Region, size int, item Drawable) {
+	child := blockChild{name, region, MaxInt(size, 0), item}
+	for i, other := range self.children {
+		if other.name == name {
+			if other.item != item {
+				Unmount(other.item)
+			}
+			self.children[i] = child
+			return
+		}
+	}
+	self.children = append(self.children, child)
+}
+
+// Detach removes the child named name from the Block and unmounts it, and reports whether there was
+// one. The body keeps its size until the next SetRect.
+func (self *Block) Detach(name string) bool {
+	for i, child := range self.children {
+		if child.name == name {
+			self.children = append(self.children[:i], self.children[i+1:]...)
+			Unmount(child.item)
+			return true
+		}
+	}
+	return false
+}
+
+// Child returns the child attached under name, or nil if there is none.
+func (self *Block) Child(name string) Drawable {
+	for _, child := range self.children {
+		if child.name == name {
+			return child.item
+		}
+	}
+	return nil
+}
+
+// ChildAt returns the name of the child drawn last at p and the child, for passing mouse events on
+// to it, and false if there is none.
+func (self *Block) ChildAt(p image.Point) (string, Drawable, bool) {
+	for i := len(self.children) - 1; i >= 0; i-- {
+		if child := self.children[i]; p.In(child.item.GetRect()) {
+			return child.name, child.item, true
+		}
+	}
+	return "", nil, false
+}
+
+func (self *Block) childItems() []Drawable {
+	items := make([]Drawable, len(self.children))
+	for i, child := range self.children {
+		items[i] = child.item
+	}
+	return items
+}
+
+// layoutChildren takes the regions of the children out of Inner, setting their rectangles, and
+// leaves Inner to the body.
+func (self *Block) layoutChildren() {
+	if len(self.children) == 0 {
+		return
+	}
+	area := self.Inner
+	rects := make([]image.Rectangle, len(self.children))
+	// the headers and footers span the width of Inner, and the sidebars the height left between them
+	for _, regions := range [][]Region{{RegionHeader, RegionFooter}, {RegionSidebar, RegionSidebarRight}} {
+		for i, child := range self.children {
+			if child.region != regions[0] && child.region != regions[1] {
+				continue
+			}
+			rect := area
+			switch child.region {
+			case RegionHeader:
+				rect.Max.Y = MinInt(area.Min.Y+child.size, area.Max.Y)
+				area.Min.Y = rect.Max.Y
+			case RegionFooter:
+				rect.Min.Y = MaxInt(area.Max.Y-child.size, area.Min.Y)
+				area.Max.Y = rect.Min.Y
+			case RegionSidebar:
+				rect.Max.X = MinInt(area.Min.X+child.size, area.Max.X)
+				area.Min.X = rect.Max.X
+			case RegionSidebarRight:
+				rect.Min.X = MaxInt(area.Max.X-child.size, area.Min.X)
+				area.Max.X = rect.Min.X
+			}
+			rects[i] = rect
+		}
+	}
+	for i, child := range self.children {
+		if child.region == RegionBody {
+			rects[i] = area
+		}
+		child.item.SetRect(rects[i].Min.X, rects[i].Min.Y, rects[i].Max.X, rects[i].Max.Y)
+	}
+	self.Inner = area
+}
+
+// drawChildren draws the children in the order they were attached, like a Grid draws its items.
+func (self *Block) drawChildren(buf *Buffer) {
+	for _, child := range self.children {
+		child.item.Lock()
+		child.item.Draw(buf)
+		child.item.Unlock()
+		applyProfile(child.item, buf, child.item.GetRect())
+		notifyDrawn(buf, child.item)
+	}
+}
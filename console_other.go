@@ -0,0 +1,12 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build !windows
+
+package termui
+
+// newPlatformBackend returns nil since termbox-go is used for every other terminal.
+func newPlatformBackend() Backend {
+	return nil
+}
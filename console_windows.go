@@ -0,0 +1,405 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build windows
+
+package termui
+
+import (
+	"errors"
+	"image"
+	"os"
+	"sync"
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+)
+
+var (
+	kernel32                       = syscall.NewLazyDLL("kernel32.dll")
+	procSetConsoleMode             = kernel32.NewProc("SetConsoleMode")
+	procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+	procReadConsoleInputW          = kernel32.NewProc("ReadConsoleInputW")
+)
+
+const (
+	enableVirtualTerminalProcessing = 0x0004
+	disableNewlineAutoReturn        = 0x0008
+	enableWindowInput               = 0x0008
+	enableMouseInput                = 0x0010
+	// enableExtendedFlags without enableQuickEditMode lets the mouse be used by the UI instead of
+	// selecting text
+	enableExtendedFlags = 0x0080
+
+	keyEvent              = 0x0001
+	mouseEvent            = 0x0002
+	windowBufferSizeEvent = 0x0004
+
+	rightAltPressed  = 0x0001
+	leftAltPressed   = 0x0002
+	rightCtrlPressed = 0x0004
+	leftCtrlPressed  = 0x0008
+
+	fromLeft1stButtonPressed = 0x0001
+	rightmostButtonPressed   = 0x0002
+	fromLeft2ndButtonPressed = 0x0004
+
+	mouseMoved   = 0x0001
+	mouseWheeled = 0x0004
+)
+
+type consoleCoord struct {
+	X, Y int16
+}
+
+type consoleScreenBufferInfo struct {
+	Size              consoleCoord
+	CursorPosition    consoleCoord
+	Attributes        uint16
+	Window            struct{ Left, Top, Right, Bottom int16 }
+	MaximumWindowSize consoleCoord
+}
+
+type inputRecord struct {
+	EventType uint16
+	_         uint16
+	Event     [16]byte
+}
+
+type keyEventRecord struct {
+	KeyDown         int32
+	RepeatCount     uint16
+	VirtualKeyCode  uint16
+	VirtualScanCode uint16
+	UnicodeChar     uint16
+	ControlKeyState uint32
+}
+
+type mouseEventRecord struct {
+	MousePosition   consoleCoord
+	ButtonState     uint32
+	ControlKeyState uint32
+	EventFlags      uint32
+}
+
+// consoleKeys are the keys without a character, by their virtual key code.
+var consoleKeys = map[uint16]string{
+	0x08: "<Backspace>",
+	0x09: "<Tab>",
+	0x0d: "<Enter>",
+	0x1b: "<Escape>",
+	0x21: "<PageUp>",
+	0x22: "<PageDown>",
+	0x23: "<End>",
+	0x24: "<Home>",
+	0x25: "<Left>",
+	0x26: "<Up>",
+	0x27: "<Right>",
+	0x28: "<Down>",
+	0x2d: "<Insert>",
+	0x2e: "<Delete>",
+	0x70: "<F1>",
+	0x71: "<F2>",
+	0x72: "<F3>",
+	0x73: "<F4>",
+	0x74: "<F5>",
+	0x75: "<F6>",
+	0x76: "<F7>",
+	0x77: "<F8>",
+	0x78: "<F9>",
+	0x79: "<F10>",
+	0x7a: "<F11>",
+	0x7b: "<F12>",
+}
+
+var errNoVirtualTerminal = errors.New("the console doesn't support virtual terminal sequences")
+
+// consoleBackend draws on the Windows console with virtual terminal sequences, which Windows 10
+// and later support, and reads its input records. Init falls back to termbox-go, which uses the
+// legacy console API, when they aren't supported.
+type consoleBackend struct {
+	input, output         syscall.Handle
+	inputMode, outputMode uint32
+
+	mutex sync.Mutex
+	// screen is the back buffer and shown the screen last drawn, or nil when it has to be drawn
+	// again whole.
+	screen *Buffer
+	shown  *Buffer
+
+	// events read with the records before them
+	pending []Event
+	// buttons held, and the high surrogate of a character sent in two records
+	buttons   uint32
+	surrogate uint16
+}
+
+func newPlatformBackend() Backend {
+	return &consoleBackend{}
+}
+
+func (self *consoleBackend) Init() error {
+	var err error
+	if self.input, err = syscall.GetStdHandle(syscall.STD_INPUT_HANDLE); err != nil {
+		return err
+	}
+	if self.output, err = syscall.GetStdHandle(syscall.STD_OUTPUT_HANDLE); err != nil {
+		return err
+	}
+	if err := syscall.GetConsoleMode(self.input, &self.inputMode); err != nil {
+		return err
+	}
+	if err := syscall.GetConsoleMode(self.output, &self.outputMode); err != nil {
+		return err
+	}
+	if setConsoleMode(self.output, self.outputMode|enableVirtualTerminalProcessing|disableNewlineAutoReturn) != nil {
+		return errNoVirtualTerminal
+	}
+	if err := setConsoleMode(self.input, enableWindowInput|enableMouseInput|enableExtendedFlags); err != nil {
+		setConsoleMode(self.output, self.outputMode)
+		return err
+	}
+
+	width, height := self.windowSize()
+	self.screen, self.shown = NewBuffer(image.Rect(0, 0, width, height)), nil
+	// the alternate screen, without the cursor
+	os.Stdout.WriteString("\x1b[?1049h\x1b[?25l")
+	return nil
+}
+
+func (self *consoleBackend) Close() {
+	os.Stdout.WriteString("\x1b[0m\x1b[?25h\x1b[?1049l")
+	setConsoleMode(self.input, self.inputMode)
+	setConsoleMode(self.output, self.outputMode)
+}
+
+// windowSize returns the size of the visible part of the console.
+func (self *consoleBackend) windowSize() (int, int) {
+	info := consoleScreenBufferInfo{}
+	ok, _, _ := procGetConsoleScreenBufferInfo.Call(uintptr(self.output), uintptr(unsafe.Pointer(&info)))
+	if ok == 0 {
+		return 80, 25
+	}
+	return int(info.Window.Right-info.Window.Left) + 1, int(info.Window.Bottom-info.Window.Top) + 1
+}
+
+func (self *consoleBackend) Size() (int, int) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.screen.Dx(), self.screen.Dy()
+}
+
+func (self *consoleBackend) SetCell(p image.Point, cell Cell) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	cell.Style = cell.Style.Downgrade(TerminalColorDepth)
+	self.screen.SetCell(cell, p)
+}
+
+func (self *consoleBackend) Cell(p image.Point) Cell {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if p.In(self.screen.Rectangle) {
+		return self.screen.GetCell(p)
+	}
+	return CellClear
+}
+
+func (self *consoleBackend) Clear(style Style) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.screen.Fill(NewCell(' ', style), self.screen.Rectangle)
+}
+
+func (self *consoleBackend) Flush() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if frame := FormatANSI(self.shown, self.screen); frame != "" {
+		os.Stdout.WriteString(frame)
+	}
+	self.shown = self.screen.Clone()
+}
+
+func (self *consoleBackend) Sync() {
+	self.mutex.Lock()
+	self.resize()
+	self.shown = nil
+	self.mutex.Unlock()
+	self.Flush()
+}
+
+// resize resizes the back buffer to the console, keeping the cells which still fit.
+func (self *consoleBackend) resize() {
+	width, height := self.windowSize()
+	if width == self.screen.Dx() && height == self.screen.Dy() {
+		return
+	}
+	screen := NewBuffer(image.Rect(0, 0, width, height))
+	kept := screen.Intersect(self.screen.Rectangle)
+	for y := kept.Min.Y; y < kept.Max.Y; y++ {
+		for x := kept.Min.X; x < kept.Max.X; x++ {
+			screen.SetCell(self.screen.GetCell(image.Pt(x, y)), image.Pt(x, y))
+		}
+	}
+	self.screen, self.shown = screen, nil
+}
+
+func (self *consoleBackend) PollEvent() Event {
+	for len(self.pending) == 0 {
+		record, read := inputRecord{}, uint32(0)
+		ok, _, err := procReadConsoleInputW.Call(uintptr(self.input), uintptr(unsafe.Pointer(&record)), 1, uintptr(unsafe.Pointer(&read)))
+		if ok == 0 {
+			panic(err)
+		}
+		if read == 1 {
+			self.pending = self.convertRecord(record)
+		}
+	}
+	e := self.pending[0]
+	self.pending = self.pending[1:]
+	return e
+}
+
+// convertRecord converts an input record to the events it stands for.
+func (self *consoleBackend) convertRecord(record inputRecord) []Event {
+	switch record.EventType {
+	case keyEvent:
+		key := (*keyEventRecord)(unsafe.Pointer(&record.Event[0]))
+		if key.KeyDown == 0 {
+			return nil
+		}
+		if e, ok := self.convertKey(key); ok {
+			events := []Event{}
+			for i := 0; i < MaxInt(int(key.RepeatCount), 1); i++ {
+				events = append(events, e)
+			}
+			return events
+		}
+	case mouseEvent:
+		if e, ok := self.convertMouse((*mouseEventRecord)(unsafe.Pointer(&record.Event[0]))); ok {
+			return []Event{e}
+		}
+	case windowBufferSizeEvent:
+		self.mutex.Lock()
+		self.resize()
+		width, height := self.screen.Dx(), self.screen.Dy()
+		self.mutex.Unlock()
+		return []Event{{
+			Type:    ResizeEvent,
+			ID:      "<Resize>",
+			Payload: Resize{Width: width, Height: height},
+		}}
+	}
+	return nil
+}
+
+// convertKey converts a key record to an event named like convertTermboxKeyboardEvent names them.
+func (self *consoleBackend) convertKey(key *keyEventRecord) (Event, bool) {
+	ctrl := key.ControlKeyState&(leftCtrlPressed|rightCtrlPressed) != 0
+	// AltGr is sent as Ctrl and Alt, for the characters it types
+	alt := key.ControlKeyState&(leftAltPressed|rightAltPressed) != 0 && !ctrl
+	char := rune(key.UnicodeChar)
+
+	if utf16.IsSurrogate(char) {
+		if self.surrogate == 0 {
+			self.surrogate = key.UnicodeChar
+			return Event{}, false
+		}
+		char = utf16.DecodeRune(rune(self.surrogate), char)
+	}
+	self.surrogate = 0
+
+	id := ""
+	switch {
+	case key.VirtualKeyCode == 0x08 && ctrl:
+		id = "<C-<Backspace>>"
+	case key.VirtualKeyCode == 0x20 && ctrl:
+		id = "<C-<Space>>"
+	case consoleKeys[key.VirtualKeyCode] != "":
+		id = consoleKeys[key.VirtualKeyCode]
+	case char == ' ':
+		id = "<Space>"
+	case ctrl && char >= 1 && char <= 26:
+		id = "<C-" + string('a'+char-1) + ">"
+	case char >= ' ':
+		id = string(char)
+	default:
+		// modifiers held alone
+		return Event{}, false
+	}
+	if alt {
+		id = "<M-" + id + ">"
+	}
+	return Event{Type: KeyboardEvent, ID: id}, true
+}
+
+// convertMouse converts a mouse record to an event named like convertTermboxMouseEvent names
+// them.
+func (self *consoleBackend) convertMouse(mouse *mouseEventRecord) (Event, bool) {
+	held := self.buttons
+	self.buttons = mouse.ButtonState & (fromLeft1stButtonPressed | rightmostButtonPressed | fromLeft2ndButtonPressed)
+
+	id, drag := "", false
+	switch {
+	case mouse.EventFlags&mouseWheeled != 0:
+		// the high word is the signed distance, away from the user being up
+		if int16(mouse.ButtonState>>16) > 0 {
+			id = "<MouseWheelUp>"
+		} else {
+			id = "<MouseWheelDown>"
+		}
+	case self.buttons&fromLeft1stButtonPressed != 0:
+		id = "<MouseLeft>"
+	case self.buttons&rightmostButtonPressed != 0:
+		id = "<MouseRight>"
+	case self.buttons&fromLeft2ndButtonPressed != 0:
+		id = "<MouseMiddle>"
+	case held != 0:
+		id = "<MouseRelease>"
+	default:
+		// motion without a button held
+		return Event{}, false
+	}
+	if mouse.EventFlags&mouseMoved != 0 && self.buttons != 0 {
+		drag = true
+	}
+
+	// the position is in the screen buffer, of which the window shows a part
+	info := consoleScreenBufferInfo{}
+	procGetConsoleScreenBufferInfo.Call(uintptr(self.output), uintptr(unsafe.Pointer(&info)))
+	return Event{
+		Type: MouseEvent,
+		ID:   id,
+		Payload: Mouse{
+			X:    int(mouse.MousePosition.X - info.Window.Left),
+			Y:    int(mouse.MousePosition.Y - info.Window.Top),
+			Drag: drag,
+		},
+	}, true
+}
+
+// ColorDepth is 24 bits, which consoles supporting virtual terminal sequences draw, unless
+// $NO_COLOR is set.
+func (self *consoleBackend) ColorDepth() ColorDepth {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return ColorDepthMono
+	}
+	return ColorDepthTrueColor
+}
+
+func (self *consoleBackend) Graphics() GraphicsProtocol {
+	return GraphicsNone
+}
+
+func (self *consoleBackend) SynchronizedOutput() bool {
+	return DetectSynchronizedOutput()
+}
+
+func setConsoleMode(handle syscall.Handle, mode uint32) error {
+	ok, _, err := procSetConsoleMode.Call(uintptr(handle), uintptr(mode))
+	if ok == 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package datasource
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Expvar returns a fetch function for a Poller, reading a variable published with the expvar
+// package by this program. Fields of maps and structs are named after a dot, like
+// "memstats.HeapAlloc".
+func Expvar(name string) func() (float64, error) {
+	return func() (float64, error) {
+		parts := strings.SplitN(name, ".", 2)
+		variable := expvar.Get(parts[0])
+		if variable == nil {
+			return 0, fmt.Errorf("expvar: no variable %q", parts[0])
+		}
+		value := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(fmt.Sprintf(`{%q: %s}`, parts[0], variable.String())), &value); err != nil {
+			return 0, err
+		}
+		return expvarNumber(value, name)
+	}
+}
+
+// RemoteExpvar reads the variables another program publishes with the expvar package, usually at
+// "/debug/vars".
+type RemoteExpvar struct {
+	// URL is the address of the variables, like "http://localhost:8080/debug/vars".
+	URL    string
+	Client *http.Client
+}
+
+func NewRemoteExpvar(url string) *RemoteExpvar {
+	return &RemoteExpvar{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Vars returns all of the variables.
+func (self *RemoteExpvar) Vars() (map[string]interface{}, error) {
+	client := self.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(self.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("expvar: %s", resp.Status)
+	}
+	vars := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&vars); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// Value returns a fetch function for a Poller, reading a variable named like Expvar names them.
+func (self *RemoteExpvar) Value(name string) func() (float64, error) {
+	return func() (float64, error) {
+		vars, err := self.Vars()
+		if err != nil {
+			return 0, err
+		}
+		return expvarNumber(vars, name)
+	}
+}
+
+// expvarNumber returns the number at a dotted name in decoded variables.
+func expvarNumber(vars map[string]interface{}, name string) (float64, error) {
+	var value interface{} = vars
+	for _, part := range strings.Split(name, ".") {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return 0, fmt.Errorf("expvar: %q is not a map", name)
+		}
+		if value, ok = fields[part]; !ok {
+			return 0, fmt.Errorf("expvar: no variable %q", name)
+		}
+	}
+	switch value := value.(type) {
+	case float64:
+		return value, nil
+	case bool:
+		if value {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("expvar: %q is not a number", name)
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// Package datasource polls metrics, from Prometheus or expvar, and feeds them into widgets with
+// the bindings of the widgets package.
+package datasource
+
+import (
+	"sync"
+	"time"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// Poller runs fetch functions every Interval and feeds their results into widgets:
+//
+//	prometheus := datasource.NewPrometheus("http://localhost:9090")
+//	poller := datasource.NewPoller(5 * time.Second)
+//	poller.Value(gauge, 0, prometheus.Value(`100 * avg(rate(node_cpu_seconds_total{mode!="idle"}[1m]))`))
+//	poller.Series(plot, 0, prometheus.Range(`sum(rate(http_requests_total[1m]))`, time.Hour, time.Minute))
+//	poller.Rows(table, prometheus.Rows(`up`, "%.0f", "job", "instance"))
+//	poller.Start()
+//	defer poller.Stop()
+//
+// Each fetch function runs in its own goroutine, so that a slow one doesn't hold up the others.
+type Poller struct {
+	Interval time.Duration
+	// OnError is called with the errors of fetch functions, whose results are skipped.
+	OnError func(error)
+
+	mutex    sync.Mutex
+	polls    []func()
+	bindings []*widgets.Binding
+	started  bool
+	stop     chan struct{}
+}
+
+func NewPoller(interval time.Duration) *Poller {
+	return &Poller{
+		Interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Value polls a value and feeds it into a series of a widget with widgets.Bind.
+func (self *Poller) Value(widget ui.Drawable, series int, fetch func() (float64, error)) *widgets.Binding {
+	ch := make(chan float64)
+	binding := widgets.Bind(widget, series, ch)
+	self.add(binding, func() {
+		if value, err := fetch(); err != nil {
+			self.fail(err)
+		} else {
+			select {
+			case ch <- value:
+			case <-self.stop:
+			}
+		}
+	})
+	return binding
+}
+
+// Series polls values and replaces a series of a widget with them with widgets.BindSeries.
+func (self *Poller) Series(widget ui.Drawable, series int, fetch func() ([]float64, error)) *widgets.Binding {
+	ch := make(chan []float64)
+	binding := widgets.BindSeries(widget, series, ch)
+	self.add(binding, func() {
+		if values, err := fetch(); err != nil {
+			self.fail(err)
+		} else {
+			select {
+			case ch <- values:
+			case <-self.stop:
+			}
+		}
+	})
+	return binding
+}
+
+// Rows polls rows and replaces the Rows of a Table with them with widgets.BindRows.
+func (self *Poller) Rows(table *widgets.Table, fetch func() ([][]string, error)) *widgets.Binding {
+	ch := make(chan [][]string)
+	binding := widgets.BindRows(table, ch)
+	self.add(binding, func() {
+		if rows, err := fetch(); err != nil {
+			self.fail(err)
+		} else {
+			select {
+			case ch <- rows:
+			case <-self.stop:
+			}
+		}
+	})
+	return binding
+}
+
+// Start starts polling, at once and then every Interval. Polls added later start when added.
+func (self *Poller) Start() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if self.started {
+		return
+	}
+	self.started = true
+	for _, poll := range self.polls {
+		go self.run(poll)
+	}
+}
+
+// Stop stops polling and the bindings of the Poller. It can't be started again.
+func (self *Poller) Stop() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	select {
+	case <-self.stop:
+		return
+	default:
+	}
+	close(self.stop)
+	for _, binding := range self.bindings {
+		binding.Stop()
+	}
+}
+
+func (self *Poller) add(binding *widgets.Binding, poll func()) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.polls = append(self.polls, poll)
+	self.bindings = append(self.bindings, binding)
+	if self.started {
+		go self.run(poll)
+	}
+}
+
+func (self *Poller) run(poll func()) {
+	ticker := time.NewTicker(self.Interval)
+	defer ticker.Stop()
+	for {
+		poll()
+		select {
+		case <-ticker.C:
+		case <-self.stop:
+			return
+		}
+	}
+}
+
+func (self *Poller) fail(err error) {
+	if self.OnError != nil {
+		self.OnError(err)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package datasource
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Prometheus runs queries on the HTTP API of a Prometheus server.
+type Prometheus struct {
+	// URL is the address of the server, like "http://localhost:9090".
+	URL    string
+	Client *http.Client
+}
+
+// Sample is a value of an instant query, with the labels of its series.
+type Sample struct {
+	Labels map[string]string
+	Time   time.Time
+	Value  float64
+}
+
+// Series is the values of a series returned by a range query, in time order.
+type Series struct {
+	Labels map[string]string
+	Times  []time.Time
+	Values []float64
+}
+
+func NewPrometheus(url string) *Prometheus {
+	return &Prometheus{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// prometheusResponse is the body of the responses of the query API.
+type prometheusResponse struct {
+	Status string
+	Error  string
+	Data   struct {
+		ResultType string
+		Result     json.RawMessage
+	}
+}
+
+// prometheusResult is a series of a vector or matrix result.
+type prometheusResult struct {
+	Metric map[string]string
+	Value  []interface{}
+	Values [][]interface{}
+}
+
+// Query runs an instant query evaluated at a time, or now when it is zero. A scalar result is
+// returned as one Sample without labels.
+func (self *Prometheus) Query(query string, t time.Time) ([]Sample, error) {
+	params := url.Values{"query": {query}}
+	if !t.IsZero() {
+		params.Set("time", formatPrometheusTime(t))
+	}
+	response, err := self.get("/api/v1/query", params)
+	if err != nil {
+		return nil, err
+	}
+	switch response.Data.ResultType {
+	case "scalar":
+		pair := []interface{}{}
+		if err := json.Unmarshal(response.Data.Result, &pair); err != nil {
+			return nil, err
+		}
+		t, value, err := parsePrometheusValue(pair)
+		if err != nil {
+			return nil, err
+		}
+		return []Sample{{Labels: map[string]string{}, Time: t, Value: value}}, nil
+	case "vector":
+		results := []prometheusResult{}
+		if err := json.Unmarshal(response.Data.Result, &results); err != nil {
+			return nil, err
+		}
+		samples := []Sample{}
+		for _, result := range results {
+			t, value, err := parsePrometheusValue(result.Value)
+			if err != nil {
+				return nil, err
+			}
+			samples = append(samples, Sample{Labels: result.Metric, Time: t, Value: value})
+		}
+		return samples, nil
+	}
+	return nil, fmt.Errorf("unsupported result type %q", response.Data.ResultType)
+}
+
+// QueryRange runs a range query evaluated every step from start to end.
+func (self *Prometheus) QueryRange(query string, start, end time.Time, step time.Duration) ([]Series, error) {
+	params := url.Values{
+		"query": {query},
+		"start": {formatPrometheusTime(start)},
+		"end":   {formatPrometheusTime(end)},
+		"step":  {strconv.FormatFloat(step.Seconds(), 'f', -1, 64)},
+	}
+	response, err := self.get("/api/v1/query_range", params)
+	if err != nil {
+		return nil, err
+	}
+	if response.Data.ResultType != "matrix" {
+		return nil, fmt.Errorf("unsupported result type %q", response.Data.ResultType)
+	}
+	results := []prometheusResult{}
+	if err := json.Unmarshal(response.Data.Result, &results); err != nil {
+		return nil, err
+	}
+	series := []Series{}
+	for _, result := range results {
+		s := Series{Labels: result.Metric}
+		for _, pair := range result.Values {
+			t, value, err := parsePrometheusValue(pair)
+			if err != nil {
+				return nil, err
+			}
+			s.Times = append(s.Times, t)
+			s.Values = append(s.Values, value)
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+// Value returns a fetch function for a Poller, running an instant query which must return one
+// sample, like a sum or a scalar.
+func (self *Prometheus) Value(query string) func() (float64, error) {
+	return func() (float64, error) {
+		samples, err := self.Query(query, time.Time{})
+		if err != nil {
+			return 0, err
+		}
+		if len(samples) != 1 {
+			return 0, fmt.Errorf("query %q returned %d samples instead of one", query, len(samples))
+		}
+		return samples[0].Value, nil
+	}
+}
+
+// Range returns a fetch function for a Poller, running a range query over the last duration,
+// evaluated every step, which must return one series.
+func (self *Prometheus) Range(query string, duration, step time.Duration) func() ([]float64, error) {
+	return func() ([]float64, error) {
+		end := time.Now()
+		series, err := self.QueryRange(query, end.Add(-duration), end, step)
+		if err != nil {
+			return nil, err
+		}
+		switch len(series) {
+		case 0:
+			return []float64{}, nil
+		case 1:
+			return series[0].Values, nil
+		}
+		return nil, fmt.Errorf("query %q returned %d series instead of one", query, len(series))
+	}
+}
+
+// Rows returns a fetch function for a Poller, running an instant query and returning a row for
+// each sample, with the values of labels followed by the value of the sample formatted by
+// format, like "%.2f". The rows are sorted.
+func (self *Prometheus) Rows(query string, format string, labels ...string) func() ([][]string, error) {
+	return func() ([][]string, error) {
+		samples, err := self.Query(query, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		rows := [][]string{}
+		for _, sample := range samples {
+			row := []string{}
+			for _, label := range labels {
+				row = append(row, sample.Labels[label])
+			}
+			rows = append(rows, append(row, fmt.Sprintf(format, sample.Value)))
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			return strings.Join(rows[i], "\x00") < strings.Join(rows[j], "\x00")
+		})
+		return rows, nil
+	}
+}
+
+// get requests an endpoint of the API and returns its response, or the error it reports.
+func (self *Prometheus) get(path string, params url.Values) (*prometheusResponse, error) {
+	client := self.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(strings.TrimRight(self.URL, "/") + path + "?" + params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	response := &prometheusResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, fmt.Errorf("prometheus: %s: %v", resp.Status, err)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus: %s", response.Error)
+	}
+	return response, nil
+}
+
+// parsePrometheusValue parses a [time, "value"] pair of a result.
+func parsePrometheusValue(pair []interface{}) (time.Time, float64, error) {
+	if len(pair) != 2 {
+		return time.Time{}, 0, fmt.Errorf("invalid sample %v", pair)
+	}
+	seconds, ok := pair[0].(float64)
+	text, isText := pair[1].(string)
+	if !ok || !isText {
+		return time.Time{}, 0, fmt.Errorf("invalid sample %v", pair)
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	whole, fraction := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(fraction*1e9)), value, nil
+}
+
+func formatPrometheusTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', 3, 64)
+}
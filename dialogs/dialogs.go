@@ -0,0 +1,326 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// Package dialogs shows modal confirmations, prompts, and messages as overlays in one call.
+// The result of a dialog is passed to its callback and sent on the channel it returns once it is
+// closed. While a dialog is open, the event loop sends events to HandleEvent first:
+//
+//	for e := range ui.PollEvents() {
+//		if dialogs.HandleEvent(e) {
+//			ui.Render(grid)
+//			continue
+//		}
+//		...
+//	}
+package dialogs
+
+import (
+	"image"
+	"strings"
+	"sync"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+var (
+	// Bounds is the area dialogs are centered in, the whole terminal when it is empty.
+	Bounds image.Rectangle
+	// Width is the width of the dialogs, limited to the width of Bounds.
+	Width = 50
+)
+
+var (
+	openLock sync.Mutex
+	// open holds the dialogs shown, the topmost last.
+	open []*Dialog
+)
+
+// Dialog is a modal box with a Message, an optional Input, and a row of Buttons, shown as an overlay.
+// <Tab>, <Left>, and <Right> move the focus, <Enter> chooses the focused button, or the first one
+// from the Input, and <Escape> chooses the Cancel button.
+type Dialog struct {
+	Block
+	Message   string
+	TextStyle Style
+	Input     *widgets.TextInput
+	Buttons   []*widgets.Button
+	// Cancel is the index of the button chosen with <Escape>.
+	Cancel int
+
+	// focus is the index of the focused button, or -1 for the Input.
+	focus   int
+	onClose func(button int)
+}
+
+// New returns a Dialog with a button for each label, for dialogs other than those of Confirm,
+// Prompt, and Message.
+func New(title, message string, labels ...string) *Dialog {
+	self := &Dialog{
+		Block:     *NewBlock(),
+		Message:   message,
+		TextStyle: Theme.Dialog.Text,
+		Cancel:    len(labels) - 1,
+	}
+	self.Title = title
+	self.BorderStyle = Theme.Dialog.Border
+	for i, label := range labels {
+		button := widgets.NewButton(label)
+		button.Border = false
+		i := i
+		button.OnClick = func() {
+			self.Close(i)
+		}
+		self.Buttons = append(self.Buttons, button)
+	}
+	return self
+}
+
+// Open shows the Dialog, centered in Bounds, on top of the other dialogs. onClose is called with
+// the index of the chosen button when it is closed.
+func (self *Dialog) Open(onClose func(button int)) {
+	self.onClose = onClose
+	self.focus = 0
+	if self.Input != nil {
+		self.focus = -1
+	}
+	self.layout()
+	openLock.Lock()
+	open = append(open, self)
+	openLock.Unlock()
+	ShowOverlay(self)
+}
+
+// Close hides the Dialog and calls the callback given to Open with the index of the chosen button.
+func (self *Dialog) Close(button int) {
+	openLock.Lock()
+	for i, dialog := range open {
+		if dialog == self {
+			open = append(open[:i], open[i+1:]...)
+			break
+		}
+	}
+	openLock.Unlock()
+	HideOverlay(self)
+	if self.onClose != nil {
+		onClose := self.onClose
+		self.onClose = nil
+		onClose(button)
+	}
+}
+
+// IsOpen reports whether the Dialog is shown.
+func (self *Dialog) IsOpen() bool {
+	return IsOverlay(self)
+}
+
+// Active returns the topmost dialog shown, or nil if there is none.
+func Active() *Dialog {
+	openLock.Lock()
+	defer openLock.Unlock()
+	if len(open) == 0 {
+		return nil
+	}
+	return open[len(open)-1]
+}
+
+// HandleEvent sends a keyboard or mouse event to the topmost dialog and reports whether a dialog is
+// shown, in which case the event shouldn't be handled by anything else.
+func HandleEvent(e Event) bool {
+	dialog := Active()
+	if dialog == nil {
+		return false
+	}
+	switch e.Type {
+	case KeyboardEvent:
+		dialog.HandleKey(e.ID)
+	case MouseEvent:
+		dialog.HandleMouse(e)
+	}
+	return true
+}
+
+// lines returns the Message wrapped to the width of the Dialog.
+func (self *Dialog) lines(width int) []string {
+	return strings.Split(WrapString(self.Message, uint(MaxInt(width, 1))), "\n")
+}
+
+// layout sizes the Dialog to its content and centers it in Bounds.
+func (self *Dialog) layout() {
+	bounds := Bounds
+	if bounds.Empty() {
+		width, height := TerminalDimensions()
+		bounds = image.Rect(0, 0, width, height)
+	}
+	width := MinInt(Width, bounds.Dx())
+	// the message is inset by the border and a column on both sides, and followed by a blank row
+	// and the buttons
+	height := len(self.lines(width-4)) + 4
+	if self.Input != nil {
+		height += 3
+	}
+	x := bounds.Min.X + (bounds.Dx()-width)/2
+	y := bounds.Min.Y + MaxInt((bounds.Dy()-height)/2, 0)
+	self.SetRect(x, y, x+width, y+height)
+}
+
+// setFocus focuses the Input for -1 or the button at index.
+func (self *Dialog) setFocus(index int) {
+	self.focus = index
+	if self.Input != nil {
+		self.Input.Focused = index < 0
+	}
+	for i, button := range self.Buttons {
+		button.Focused = i == index
+	}
+}
+
+// HandleKey moves the focus, edits the Input, and chooses buttons. It reports whether the key was
+// used, which is always the case while the Dialog is open.
+func (self *Dialog) HandleKey(id string) bool {
+	if !self.IsOpen() {
+		return false
+	}
+	first := 0
+	if self.Input != nil {
+		first = -1
+	}
+	switch id {
+	case "<Tab>":
+		next := self.focus + 1
+		if next >= len(self.Buttons) {
+			next = first
+		}
+		self.setFocus(next)
+	case "<Right>":
+		if self.focus >= 0 {
+			self.setFocus(MinInt(self.focus+1, len(self.Buttons)-1))
+		} else {
+			self.Input.HandleKey(id)
+		}
+	case "<Left>":
+		if self.focus > 0 {
+			self.setFocus(self.focus - 1)
+		} else if self.focus < 0 {
+			self.Input.HandleKey(id)
+		}
+	case "<Enter>":
+		self.Close(MaxInt(self.focus, 0))
+	case "<Escape>":
+		self.Close(self.Cancel)
+	default:
+		if self.focus >= 0 {
+			self.Buttons[self.focus].HandleKey(id)
+		} else {
+			self.Input.HandleKey(id)
+		}
+	}
+	return true
+}
+
+// HandleMouse clicks the buttons and moves the cursor of the Input. It reports whether the event
+// was used, which is always the case while the Dialog is open.
+func (self *Dialog) HandleMouse(e Event) bool {
+	if !self.IsOpen() {
+		return false
+	}
+	p, ok := MousePoint(e)
+	if !ok {
+		return true
+	}
+	for _, button := range self.Buttons {
+		// the rect of a button is larger than the label it is pressed on
+		if e.ID == "<MouseLeft>" && !p.In(button.Inner) {
+			continue
+		}
+		if button.HandleMouse(e) {
+			return true
+		}
+	}
+	if self.Input != nil && self.Input.HandleMouse(e) {
+		self.setFocus(-1)
+	}
+	return true
+}
+
+func (self *Dialog) Draw(buf *Buffer) {
+	buf.Fill(NewCell(' ', NewStyle(ColorClear)), self.Rectangle)
+	self.Block.Draw(buf)
+	self.setFocus(self.focus)
+
+	x, y := self.Inner.Min.X+1, self.Inner.Min.Y
+	for _, line := range self.lines(self.Inner.Dx() - 2) {
+		buf.SetString(TrimString(line, self.Inner.Dx()-2), self.TextStyle, image.Pt(x, y))
+		y++
+	}
+	if self.Input != nil {
+		self.Input.SetRect(x, y, self.Inner.Max.X-1, y+3)
+		self.Input.Draw(buf)
+		y += 3
+	}
+
+	// center the buttons on the last row
+	width := -2
+	for _, button := range self.Buttons {
+		width += rw.StringWidth(button.Label) + 4 + 2
+	}
+	x = self.Inner.Min.X + MaxInt((self.Inner.Dx()-width)/2, 0)
+	y = self.Inner.Max.Y - 1
+	for _, button := range self.Buttons {
+		w := rw.StringWidth(button.Label) + 4
+		// buttons without a border draw their label in their Inner, one cell in from their rect
+		button.SetRect(x-1, y-1, x+w+1, y+2)
+		button.Draw(buf)
+		x += w + 2
+	}
+}
+
+// Confirm shows a dialog with OK and Cancel buttons and reports whether OK was chosen.
+func Confirm(title, message string, callback func(ok bool)) <-chan bool {
+	result := make(chan bool, 1)
+	New(title, message, "OK", "Cancel").Open(func(button int) {
+		ok := button == 0
+		if callback != nil {
+			callback(ok)
+		}
+		result <- ok
+	})
+	return result
+}
+
+// PromptResult is the text entered in a Prompt and whether it was confirmed with OK.
+type PromptResult struct {
+	Text string
+	OK   bool
+}
+
+// Prompt shows a dialog with a TextInput holding value, and OK and Cancel buttons.
+func Prompt(title, message, value string, callback func(text string, ok bool)) <-chan PromptResult {
+	result := make(chan PromptResult, 1)
+	dialog := New(title, message, "OK", "Cancel")
+	dialog.Input = widgets.NewTextInput()
+	dialog.Input.SetText(value)
+	dialog.Open(func(button int) {
+		text, ok := dialog.Input.Text(), button == 0
+		if callback != nil {
+			callback(text, ok)
+		}
+		result <- PromptResult{text, ok}
+	})
+	return result
+}
+
+// Message shows a dialog with an OK button.
+func Message(title, message string, callback func()) <-chan struct{} {
+	result := make(chan struct{}, 1)
+	New(title, message, "OK").Open(func(int) {
+		if callback != nil {
+			callback()
+		}
+		result <- struct{}{}
+	})
+	return result
+}
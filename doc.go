@@ -4,5 +4,13 @@
 
 /*
 Package termui is a library for creating terminal user interfaces (TUIs) using widgets.
+
+Widgets are not safe for concurrent use by themselves. When widget data is updated from
+goroutines other than the one calling Render, either wrap the mutation in Update, which
+serializes it with Render, or hold the widget's own lock while changing its fields:
+
+	ui.Update(func() {
+		gauge.Percent = percent
+	})
 */
 package termui
@@ -0,0 +1,181 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+
+	rw "github.com/mattn/go-runewidth"
+)
+
+// DragItem is an item dragged with the mouse from a DragSource to a DropTarget.
+type DragItem struct {
+	// Source is the widget the item is dragged out of.
+	Source DragSource
+	// Text is drawn by the ghost following the pointer, and stands for the item in the widgets
+	// which don't know the type of its Value.
+	Text string
+	// Value is the item itself, like a ListItem or a *TreeNode.
+	Value interface{}
+}
+
+// DragSource is a widget whose items can be dragged out with a DragDrop.
+type DragSource interface {
+	Drawable
+	// DragStart returns the item at p, where the left button was pressed, and false if there is none.
+	DragStart(p image.Point) (DragItem, bool)
+	// DragEnd is called once the item is released, with the DropTarget which took it, or nil if it
+	// wasn't dropped. Sources remove the items dropped into other widgets, which moves them there.
+	DragEnd(item DragItem, target DropTarget)
+}
+
+// DropTarget is a widget items can be dropped into with a DragDrop.
+type DropTarget interface {
+	Drawable
+	// Drop adds the item where it was released at p and reports whether it was taken.
+	Drop(item DragItem, p image.Point) bool
+}
+
+// DragDrop moves items between widgets with the mouse. Pressing the left button over an item of
+// one of its Sources and dragging it shows a ghost of the item following the pointer on the
+// overlay layer, and releasing the button over one of its Targets drops the item there:
+//
+//	dnd := ui.NewDragDrop()
+//	dnd.Sources = []ui.DragSource{list, tree}
+//	dnd.Targets = []ui.DropTarget{list, tree}
+//	...
+//	case ui.MouseEvent:
+//		if !dnd.HandleMouse(e) {
+//			list.HandleMouse(e)
+//		}
+type DragDrop struct {
+	Sources []DragSource
+	Targets []DropTarget
+
+	// GhostStyle is used for the text of the dragged item drawn next to the pointer.
+	GhostStyle Style
+
+	source   DragSource
+	press    image.Point
+	dragging bool
+	item     DragItem
+	ghost    *dragGhost
+}
+
+// dragGhost is the overlay drawing the text of the dragged item.
+type dragGhost struct {
+	Block
+	text  string
+	style Style
+}
+
+func (self *dragGhost) Draw(buf *Buffer) {
+	buf.Fill(NewCell(' ', self.style), self.Rectangle)
+	buf.SetString(TrimString(self.text, self.Dx()), self.style, self.Min)
+}
+
+func NewDragDrop() *DragDrop {
+	ghost := &dragGhost{Block: *NewBlock()}
+	ghost.Border = false
+	return &DragDrop{
+		GhostStyle: Theme.DragDrop.Ghost,
+		ghost:      ghost,
+	}
+}
+
+// Dragging reports whether an item is being dragged.
+func (self *DragDrop) Dragging() bool {
+	return self.dragging
+}
+
+// Cancel ends the drag without dropping the item, hiding its ghost.
+func (self *DragDrop) Cancel() {
+	if self.dragging {
+		self.end(nil)
+	}
+	self.source = nil
+}
+
+// HandleMouse follows a drag with a mouse event and reports whether the event was used by it, in
+// which case it isn't meant for the widgets under the pointer. Pressing the button isn't reported
+// as used so that it can still select the item pressed.
+func (self *DragDrop) HandleMouse(e Event) bool {
+	m, ok := e.Payload.(Mouse)
+	if !ok {
+		return false
+	}
+	p := image.Pt(m.X, m.Y)
+	switch {
+	case e.ID == "<MouseRelease>":
+		self.source = nil
+		if !self.dragging {
+			return false
+		}
+		self.drop(p)
+		return true
+	case e.ID != "<MouseLeft>":
+		return false
+	case !m.Drag:
+		self.Cancel()
+		self.source, _ = HitTest(p, self.sources()...).(DragSource)
+		self.press = p
+		return false
+	}
+	if !self.dragging {
+		if self.source == nil || p == self.press {
+			return false
+		}
+		item, ok := self.source.DragStart(self.press)
+		if !ok {
+			self.source = nil
+			return false
+		}
+		item.Source = self.source
+		self.item, self.dragging = item, true
+	}
+	self.moveGhost(p)
+	return true
+}
+
+// moveGhost shows the ghost of the dragged item right of p.
+func (self *DragDrop) moveGhost(p image.Point) {
+	HideOverlay(self.ghost)
+	self.ghost.text, self.ghost.style = self.item.Text, self.GhostStyle
+	self.ghost.SetRect(p.X+1, p.Y, p.X+1+MaxInt(rw.StringWidth(self.item.Text), 1), p.Y+1)
+	ShowOverlay(self.ghost)
+}
+
+// drop drops the dragged item into the target at p, if there is one taking it.
+func (self *DragDrop) drop(p image.Point) {
+	target, _ := HitTest(p, self.targets()...).(DropTarget)
+	if target != nil && !target.Drop(self.item, p) {
+		target = nil
+	}
+	self.end(target)
+}
+
+// end hides the ghost and tells the source where the item went.
+func (self *DragDrop) end(target DropTarget) {
+	HideOverlay(self.ghost)
+	item := self.item
+	self.item, self.dragging = DragItem{}, false
+	item.Source.DragEnd(item, target)
+}
+
+func (self *DragDrop) sources() []Drawable {
+	items := make([]Drawable, len(self.Sources))
+	for i, source := range self.Sources {
+		items[i] = source
+	}
+	return items
+}
+
+func (self *DragDrop) targets() []Drawable {
+	items := make([]Drawable, len(self.Targets))
+	for i, target := range self.Targets {
+		items[i] = target
+	}
+	return items
+}
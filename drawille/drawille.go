@@ -2,6 +2,7 @@ package drawille
 
 import (
 	"image"
+	"math"
 )
 
 const BRAILLE_OFFSET = '\u2800'
@@ -13,6 +14,79 @@ var BRAILLE = [4][2]rune{
 	{'\u0040', '\u0080'},
 }
 
+// HALF_BLOCKS and QUADRANT_BLOCKS are indexed by the bit mask of the points set in a cell.
+var HALF_BLOCKS = [4]rune{' ', '▀', '▄', '█'}
+
+var QUADRANT_BLOCKS = [16]rune{
+	' ', '▘', '▝', '▀', '▖', '▌', '▞', '▛',
+	'▗', '▚', '▐', '▜', '▄', '▙', '▟', '█',
+}
+
+// Mode selects the characters used to draw points and therefore the canvas resolution.
+type Mode uint
+
+const (
+	ModeBraille   Mode = iota // 2x4 points per cell
+	ModeSextant               // 2x3 points per cell, needs a font with Unicode 13 sextants
+	ModeQuadrant              // 2x2 points per cell
+	ModeHalfBlock             // 1x2 points per cell
+)
+
+// Resolution returns the number of points per cell horizontally and vertically.
+func (self Mode) Resolution() image.Point {
+	switch self {
+	case ModeSextant:
+		return image.Pt(2, 3)
+	case ModeQuadrant:
+		return image.Pt(2, 2)
+	case ModeHalfBlock:
+		return image.Pt(1, 2)
+	}
+	return image.Pt(2, 4)
+}
+
+// bit returns the bit of a cell's mask which represents the point at (x, y) within that cell.
+func (self Mode) bit(x, y int) rune {
+	switch self {
+	case ModeBraille:
+		return BRAILLE[y][x]
+	case ModeHalfBlock:
+		return 1 << uint(y)
+	}
+	return 1 << uint(y*2+x)
+}
+
+// glyph converts a cell's bit mask to the character drawn for it.
+func (self Mode) glyph(mask rune) rune {
+	switch self {
+	case ModeSextant:
+		switch mask {
+		case 0:
+			return ' '
+		case 21:
+			return QUADRANT_BLOCKS[5]
+		case 42:
+			return QUADRANT_BLOCKS[10]
+		case 63:
+			return QUADRANT_BLOCKS[15]
+		}
+		// the sextant block skips the masks which already exist as half and full blocks
+		r := '\U0001FB00' + mask - 1
+		if mask > 21 {
+			r--
+		}
+		if mask > 42 {
+			r--
+		}
+		return r
+	case ModeQuadrant:
+		return QUADRANT_BLOCKS[mask]
+	case ModeHalfBlock:
+		return HALF_BLOCKS[mask]
+	}
+	return mask + BRAILLE_OFFSET
+}
+
 type Color int
 
 type Cell struct {
@@ -22,32 +96,101 @@ type Cell struct {
 
 type Canvas struct {
 	CellMap map[image.Point]Cell
+
+	// LineWidth is the stroke width in points used by lines, polylines, curves, and outlines.
+	LineWidth int
+
+	// Mode should be set before drawing since it changes how points map to cells.
+	Mode Mode
 }
 
 func NewCanvas() *Canvas {
 	return &Canvas{
-		CellMap: make(map[image.Point]Cell),
+		CellMap:   make(map[image.Point]Cell),
+		LineWidth: 1,
 	}
 }
 
 func (self *Canvas) SetPoint(p image.Point, color Color) {
-	point := image.Pt(p.X/2, p.Y/4)
+	if p.X < 0 || p.Y < 0 {
+		return
+	}
+	res := self.Mode.Resolution()
+	point := image.Pt(p.X/res.X, p.Y/res.Y)
 	self.CellMap[point] = Cell{
-		self.CellMap[point].Rune | BRAILLE[p.Y%4][p.X%2],
+		self.CellMap[point].Rune | self.Mode.bit(p.X%res.X, p.Y%res.Y),
 		color,
 	}
 }
 
+// setStroke sets the square of LineWidth dots centered on p.
+func (self *Canvas) setStroke(p image.Point, color Color) {
+	if self.LineWidth <= 1 {
+		self.SetPoint(p, color)
+		return
+	}
+	for dx := -(self.LineWidth - 1) / 2; dx <= self.LineWidth/2; dx++ {
+		for dy := -(self.LineWidth - 1) / 2; dy <= self.LineWidth/2; dy++ {
+			self.SetPoint(p.Add(image.Pt(dx, dy)), color)
+		}
+	}
+}
+
 func (self *Canvas) SetLine(p0, p1 image.Point, color Color) {
 	for _, p := range line(p0, p1) {
-		self.SetPoint(p, color)
+		self.setStroke(p, color)
 	}
 }
 
+// SetPolyline draws connected line segments through the given points in order.
+func (self *Canvas) SetPolyline(points []image.Point, color Color) {
+	if len(points) == 1 {
+		self.setStroke(points[0], color)
+	}
+	for i := 1; i < len(points); i++ {
+		for _, p := range segment(points[i-1], points[i]) {
+			self.setStroke(p, color)
+		}
+	}
+}
+
+// SetQuadBezier draws a quadratic Bezier curve from p0 to p2 with control point p1.
+func (self *Canvas) SetQuadBezier(p0, p1, p2 image.Point, color Color) {
+	self.SetPolyline(bezier([]image.Point{p0, p1, p2}), color)
+}
+
+// SetCubicBezier draws a cubic Bezier curve from p0 to p3 with control points p1 and p2.
+func (self *Canvas) SetCubicBezier(p0, p1, p2, p3 image.Point, color Color) {
+	self.SetPolyline(bezier([]image.Point{p0, p1, p2, p3}), color)
+}
+
+// SetEllipseArc draws the part of the ellipse centered at c with radii rx and ry
+// between the start and end angles, given in radians and measured clockwise from the positive x axis.
+func (self *Canvas) SetEllipseArc(c image.Point, rx, ry int, start, end float64, color Color) {
+	for _, p := range ellipseArc(c, rx, ry, start, end) {
+		self.setStroke(p, color)
+	}
+}
+
+// SetEllipse draws the outline of the ellipse centered at c with radii rx and ry.
+func (self *Canvas) SetEllipse(c image.Point, rx, ry int, color Color) {
+	self.SetEllipseArc(c, rx, ry, 0, 2*math.Pi, color)
+}
+
+// SetCircle draws the outline of the circle centered at c with radius r.
+func (self *Canvas) SetCircle(c image.Point, r int, color Color) {
+	self.SetEllipse(c, r, r, color)
+}
+
+// SetArc draws the part of the circle centered at c with radius r between the start and end angles.
+func (self *Canvas) SetArc(c image.Point, r int, start, end float64, color Color) {
+	self.SetEllipseArc(c, r, r, start, end, color)
+}
+
 func (self *Canvas) GetCells() map[image.Point]Cell {
 	cellMap := make(map[image.Point]Cell)
 	for point, cell := range self.CellMap {
-		cellMap[point] = Cell{cell.Rune + BRAILLE_OFFSET, cell.Color}
+		cellMap[point] = Cell{self.Mode.glyph(cell.Rune), cell.Color}
 	}
 	return cellMap
 }
@@ -82,6 +225,109 @@ func line(p0, p1 image.Point) []image.Point {
 	return points
 }
 
+// segment returns every point on the line between p0 and p1, both inclusive.
+func segment(p0, p1 image.Point) []image.Point {
+	points := []image.Point{}
+
+	dx := absInt(p1.X - p0.X)
+	dy := -absInt(p1.Y - p0.Y)
+	sx, sy := 1, 1
+	if p0.X > p1.X {
+		sx = -1
+	}
+	if p0.Y > p1.Y {
+		sy = -1
+	}
+
+	err := dx + dy
+	for p := p0; ; {
+		points = append(points, p)
+		if p == p1 {
+			break
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			p.X += sx
+		}
+		if e2 <= dx {
+			err += dx
+			p.Y += sy
+		}
+	}
+
+	return points
+}
+
+func ellipseArc(c image.Point, rx, ry int, start, end float64) []image.Point {
+	if end < start {
+		start, end = end, start
+	}
+	// sample roughly one point per dot along the perimeter and join the samples
+	steps := int(math.Ceil((end - start) * float64(maxInt(maxInt(rx, ry), 1))))
+	if steps < 1 {
+		steps = 1
+	}
+
+	at := func(phi float64) image.Point {
+		return image.Pt(
+			c.X+int(math.Round(float64(rx)*math.Cos(phi))),
+			c.Y+int(math.Round(float64(ry)*math.Sin(phi))),
+		)
+	}
+
+	points := []image.Point{}
+	previous := at(start)
+	for i := 1; i <= steps; i++ {
+		current := at(start + (end-start)*float64(i)/float64(steps))
+		points = append(points, segment(previous, current)...)
+		previous = current
+	}
+
+	return points
+}
+
+// bezier samples the Bezier curve defined by the given control points
+// densely enough for consecutive samples to be joined by short segments.
+func bezier(controls []image.Point) []image.Point {
+	length := 0.0
+	for i := 1; i < len(controls); i++ {
+		d := controls[i].Sub(controls[i-1])
+		length += math.Hypot(float64(d.X), float64(d.Y))
+	}
+	steps := int(math.Ceil(length / 2))
+	if steps < 1 {
+		steps = 1
+	}
+
+	points := make([]image.Point, 0, steps+1)
+	xs := make([]float64, len(controls))
+	ys := make([]float64, len(controls))
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		// de Casteljau's algorithm
+		for j, c := range controls {
+			xs[j], ys[j] = float64(c.X), float64(c.Y)
+		}
+		for n := len(controls) - 1; n > 0; n-- {
+			for j := 0; j < n; j++ {
+				xs[j] += (xs[j+1] - xs[j]) * t
+				ys[j] += (ys[j+1] - ys[j]) * t
+			}
+		}
+		points = append(points, image.Pt(int(math.Round(xs[0])), int(math.Round(ys[0]))))
+	}
+
+	return points
+}
+
+func maxInt(x, y int) int {
+	if x > y {
+		return x
+	}
+	return y
+}
+
 func absInt(x int) int {
 	if x >= 0 {
 		return x
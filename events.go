@@ -23,8 +23,14 @@ List of events:
 		<Insert> <Delete> <Home> <End> <Previous> <Next>
 		<Backspace> <Tab> <Enter> <Escape> <Space>
 		<C-<Space>> etc
+	keyboard events with KittyKeyboard:
+		<C-i> <C-m> <C-h> etc
+		<S-<Enter>> <S-<Tab>> <C-<Up>> etc
+		<Release-j> <Release-<C-d>> etc
 	terminal events:
         <Resize>
+	paste events, with BracketedPaste:
+		<Paste>
 
     keyboard events that do not work:
         <C-->
@@ -45,6 +51,7 @@ const (
 	KeyboardEvent EventType = iota
 	MouseEvent
 	ResizeEvent
+	PasteEvent
 )
 
 type Event struct {
@@ -58,6 +65,13 @@ type Mouse struct {
 	Drag bool
 	X    int
 	Y    int
+	// Clicks is the number of presses of the button in a row at the same point, each within
+	// DoubleClickInterval of the last, as counted by PollEvents: 1 for a click, 2 for a
+	// double-click, and so on. It is 0 for releases, drags, and the wheel.
+	Clicks int
+	// Steps is the number of wheel events coalesced into a <MouseWheelUp> or <MouseWheelDown> event
+	// by PollEvents, which WheelSteps returns.
+	Steps int
 }
 
 // Resize payload.
@@ -66,12 +80,37 @@ type Resize struct {
 	Height int
 }
 
-// PollEvents gets events from termbox, converts them, then sends them to each of its channels.
+// Paste payload, with the lines of the text separated by "\n".
+type Paste struct {
+	Text string
+}
+
+// PollEvents gets events from the Backend, counts the clicks of mouse events, then sends them to
+// each of its channels. Events received while the last one is being handled are queued, with
+// consecutive drags of a button coalesced into the last, and consecutive wheel events of the same
+// direction into one with the sum of their Steps, so that the UI doesn't lag behind the mouse.
+// The channel keeps reading the Backend initialized when it was created, even after another is.
 func PollEvents() <-chan Event {
 	ch := make(chan Event)
+	b := backend
 	go func() {
+		events := make(chan Event)
+		go func() {
+			for {
+				events <- countClicks(b.PollEvent())
+			}
+		}()
+		pending := []Event{}
 		for {
-			ch <- convertTermboxEvent(tb.PollEvent())
+			if len(pending) == 0 {
+				pending = append(pending, <-events)
+			}
+			select {
+			case e := <-events:
+				pending = coalesceEvent(pending, e)
+			case ch <- pending[0]:
+				pending = pending[1:]
+			}
 		}
 	}()
 	return ch
@@ -0,0 +1,147 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// Recorder is a Backend recording the frames drawn on another Backend to an asciicast v2 file,
+// which asciinema plays back. It is initialized in place of the Backend it wraps:
+//
+//	recorder := export.NewRecorder(file, ui.NewTermboxBackend())
+//	if err := ui.InitBackend(recorder); err != nil {
+//		log.Fatal(err)
+//	}
+//	defer ui.Close()
+//
+// Each Flush is written as an output event with the escape sequences drawing the cells changed
+// since the last one, and changes to the size of the screen as resize events.
+type Recorder struct {
+	Backend
+	Title string
+
+	mutex sync.Mutex
+	w     io.Writer
+	start time.Time
+	// screen is the back buffer and shown the screen last recorded, or nil when it has to be
+	// recorded again whole.
+	screen *Buffer
+	shown  *Buffer
+	err    error
+}
+
+func NewRecorder(w io.Writer, backend Backend) *Recorder {
+	return &Recorder{
+		Backend: backend,
+		w:       w,
+	}
+}
+
+// Init initializes the wrapped Backend and writes the header of the recording.
+func (self *Recorder) Init() error {
+	if err := self.Backend.Init(); err != nil {
+		return err
+	}
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.start = time.Now()
+	width, height := self.Backend.Size()
+	self.screen, self.shown = NewBuffer(image.Rect(0, 0, width, height)), nil
+	header := map[string]interface{}{
+		"version":   2,
+		"width":     width,
+		"height":    height,
+		"timestamp": self.start.Unix(),
+		"env":       map[string]string{"TERM": os.Getenv("TERM")},
+	}
+	if self.Title != "" {
+		header["title"] = self.Title
+	}
+	self.writeLine(header)
+	return self.err
+}
+
+// Err returns the first error writing the recording, after which nothing more is written.
+func (self *Recorder) Err() error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.err
+}
+
+// writeLine writes a line of JSON unless writing failed before.
+func (self *Recorder) writeLine(value interface{}) {
+	if self.err != nil {
+		return
+	}
+	line, err := json.Marshal(value)
+	if err == nil {
+		_, err = self.w.Write(append(line, '\n'))
+	}
+	self.err = err
+}
+
+// writeEvent writes an event of a type, "o" for output or "r" for resize, with its time.
+func (self *Recorder) writeEvent(kind, data string) {
+	elapsed := time.Since(self.start).Seconds()
+	self.writeLine([]interface{}{json.Number(fmt.Sprintf("%.6f", elapsed)), kind, data})
+}
+
+func (self *Recorder) SetCell(p image.Point, cell Cell) {
+	self.Backend.SetCell(p, cell)
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if p.In(self.screen.Rectangle) {
+		cell.Style = cell.Style.Downgrade(TerminalColorDepth)
+		self.screen.SetCell(cell, p)
+	}
+}
+
+func (self *Recorder) Clear(style Style) {
+	self.Backend.Clear(style)
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.screen.Fill(NewCell(' ', style), self.screen.Rectangle)
+}
+
+func (self *Recorder) Flush() {
+	self.Backend.Flush()
+	self.record()
+}
+
+func (self *Recorder) Sync() {
+	self.Backend.Sync()
+	self.record()
+}
+
+// record writes the cells changed since the last frame recorded, after a resize event when the
+// size of the screen changed.
+func (self *Recorder) record() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if width, height := self.Backend.Size(); width != self.screen.Dx() || height != self.screen.Dy() {
+		screen := NewBuffer(image.Rect(0, 0, width, height))
+		kept := screen.Intersect(self.screen.Rectangle)
+		for y := kept.Min.Y; y < kept.Max.Y; y++ {
+			for x := kept.Min.X; x < kept.Max.X; x++ {
+				screen.SetCell(self.screen.GetCell(image.Pt(x, y)), image.Pt(x, y))
+			}
+		}
+		self.screen, self.shown = screen, nil
+		self.writeEvent("r", fmt.Sprintf("%dx%d", width, height))
+	}
+	if frame := FormatANSI(self.shown, self.screen); frame != "" {
+		self.writeEvent("o", frame)
+	}
+	self.shown = self.screen.Clone()
+}
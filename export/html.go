@@ -0,0 +1,163 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// Package export converts rendered Buffers to other formats, such as standalone HTML pages for
+// embedding dashboard snapshots in documentation and reports. Widgets don't need a terminal to
+// be drawn, so snapshots can be taken with RenderBuffer without calling Init:
+//
+//	err := export.NewHTML().Write(file, ui.RenderBuffer(gauge, plot))
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image"
+	"io"
+	"strings"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// HTML writes Buffers as standalone HTML pages with inline CSS colors. Graphics held by the
+// Buffers, such as images, are not written.
+type HTML struct {
+	Title string
+	// Foreground and Background are the colors of cells with ColorClear, and of the page.
+	Foreground Color
+	Background Color
+	FontFamily string
+	// FrameInterval is the time each frame written by WriteFrames is shown.
+	FrameInterval time.Duration
+}
+
+func NewHTML() *HTML {
+	return &HTML{
+		Title:         "termui",
+		Foreground:    ColorWhite,
+		Background:    ColorBlack,
+		FontFamily:    "Menlo, Consolas, \"DejaVu Sans Mono\", monospace",
+		FrameInterval: time.Second,
+	}
+}
+
+// Write writes a page showing buf.
+func (self *HTML) Write(w io.Writer, buf *Buffer) error {
+	return self.WriteFrames(w, []*Buffer{buf})
+}
+
+// WriteFrames writes a page showing frames in turn, each for FrameInterval, looping back to the
+// first after the last. Only the first frame is shown when scripts are disabled.
+func (self *HTML) WriteFrames(w io.Writer, frames []*Buffer) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(self.Title))
+	fmt.Fprintf(out, "<style>\n"+
+		"body { margin: 0; background: %s; color: %s; }\n"+
+		"pre { margin: 1em; font-family: %s; line-height: 1.15; }\n"+
+		"pre.frame { display: none; }\n"+
+		"pre.frame.shown { display: block; }\n"+
+		"</style>\n</head>\n<body>\n",
+		cssColor(self.Background), cssColor(self.Foreground), strings.Replace(self.FontFamily, "<", "", -1),
+	)
+	for i, frame := range frames {
+		class := "frame"
+		if i == 0 {
+			class += " shown"
+		}
+		fmt.Fprintf(out, "<pre class=\"%s\">", class)
+		self.writeCells(out, frame)
+		out.WriteString("</pre>\n")
+	}
+	if len(frames) > 1 {
+		fmt.Fprintf(out, "<script>\n"+
+			"var frames = document.querySelectorAll(\"pre.frame\"), shown = 0;\n"+
+			"setInterval(function() {\n"+
+			"\tframes[shown].classList.remove(\"shown\");\n"+
+			"\tshown = (shown + 1) %% frames.length;\n"+
+			"\tframes[shown].classList.add(\"shown\");\n"+
+			"}, %d);\n"+
+			"</script>\n",
+			self.FrameInterval.Milliseconds(),
+		)
+	}
+	out.WriteString("</body>\n</html>\n")
+	return out.Flush()
+}
+
+// writeCells writes the rows of buf, with a span for each run of cells of the same style.
+func (self *HTML) writeCells(out *bufio.Writer, buf *Buffer) {
+	for y := buf.Min.Y; y < buf.Max.Y; y++ {
+		if y > buf.Min.Y {
+			out.WriteString("\n")
+		}
+		style, text := StyleClear, strings.Builder{}
+		flush := func() {
+			if text.Len() == 0 {
+				return
+			}
+			if css := self.css(style); css != "" {
+				fmt.Fprintf(out, "<span style=\"%s\">%s</span>", css, html.EscapeString(text.String()))
+			} else {
+				out.WriteString(html.EscapeString(text.String()))
+			}
+			text.Reset()
+		}
+		for x := buf.Min.X; x < buf.Max.X; x++ {
+			cell := buf.GetCell(image.Pt(x, y))
+			if cell.Rune == 0 {
+				cell = CellClear
+			}
+			if cell.Style != style {
+				flush()
+				style = cell.Style
+			}
+			text.WriteRune(cell.Rune)
+			// a wide rune covers the next cell
+			x += MaxInt(rw.RuneWidth(cell.Rune), 1) - 1
+		}
+		flush()
+	}
+}
+
+// css returns the inline CSS of a style.
+func (self *HTML) css(style Style) string {
+	fg, bg := style.Fg, style.Bg
+	if fg < 0 {
+		fg = ColorClear
+	}
+	if bg < 0 {
+		bg = ColorClear
+	}
+	if style.Modifier&ModifierReverse != 0 {
+		if fg == ColorClear {
+			fg = self.Foreground
+		}
+		if bg == ColorClear {
+			bg = self.Background
+		}
+		fg, bg = bg, fg
+	}
+	css := []string{}
+	if fg != ColorClear {
+		css = append(css, "color: "+cssColor(fg))
+	}
+	if bg != ColorClear {
+		css = append(css, "background: "+cssColor(bg))
+	}
+	if style.Modifier&ModifierBold != 0 {
+		css = append(css, "font-weight: bold")
+	}
+	if style.Modifier&ModifierUnderline != 0 {
+		css = append(css, "text-decoration: underline")
+	}
+	return strings.Join(css, "; ")
+}
+
+func cssColor(color Color) string {
+	r, g, b := color.RGB()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
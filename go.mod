@@ -4,6 +4,5 @@ go 1.14
 
 require (
 	github.com/mattn/go-runewidth v0.0.2
-	github.com/mitchellh/go-wordwrap v0.0.0-20150314170334-ad45545899c7
 	github.com/nsf/termbox-go v0.0.0-20190121233118-02980233997d
 )
@@ -0,0 +1,121 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"strings"
+)
+
+// GraphicsProtocol is a family of escape sequences for drawing images with the pixels of the
+// terminal instead of its cells.
+type GraphicsProtocol uint
+
+const (
+	GraphicsNone GraphicsProtocol = iota
+	GraphicsSixel
+	GraphicsKitty
+	GraphicsITerm
+)
+
+// TerminalGraphics is set by Init from DetectGraphics.
+// It can be changed after Init when the guess is wrong.
+var TerminalGraphics = GraphicsNone
+
+// GraphicsWriter receives the escape sequences of the Graphics drawn by Render.
+var GraphicsWriter io.Writer = os.Stdout
+
+// DefaultCellSize is the size in pixels returned by CellSize when the terminal doesn't report it.
+var DefaultCellSize = image.Pt(10, 20)
+
+// Graphic is an escape sequence drawing an image with its top left corner at Point.
+type Graphic struct {
+	Point    image.Point
+	Sequence []byte
+	// Delete is written to remove the image when it is replaced, the screen is cleared, or on Close,
+	// for protocols whose images aren't erased along with the cells beneath them.
+	Delete []byte
+}
+
+// DetectGraphics guesses the graphics protocol of the terminal from $TERM, $TERM_PROGRAM,
+// $LC_TERMINAL, $KITTY_WINDOW_ID, and $XTERM_VERSION.
+func DetectGraphics() GraphicsProtocol {
+	term := strings.ToLower(os.Getenv("TERM"))
+	program := strings.ToLower(os.Getenv("TERM_PROGRAM"))
+	switch {
+	case strings.Contains(term, "kitty"), os.Getenv("KITTY_WINDOW_ID") != "", program == "wezterm":
+		return GraphicsKitty
+	case program == "iterm.app", os.Getenv("LC_TERMINAL") == "iTerm2":
+		return GraphicsITerm
+	case strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "mlterm"), strings.HasPrefix(term, "contour"),
+		strings.Contains(term, "sixel"), program == "mlterm":
+		return GraphicsSixel
+	case os.Getenv("XTERM_VERSION") != "":
+		// xterm ignores sixels unless started as a VT340, e.g. with `xterm -ti vt340`
+		return GraphicsSixel
+	}
+	return GraphicsNone
+}
+
+// CellSize returns the size of a terminal cell in pixels, or DefaultCellSize if the terminal
+// doesn't report it.
+func CellSize() image.Point {
+	if width, height, columns, rows, ok := terminalPixels(); ok && columns > 0 && rows > 0 && width > 0 && height > 0 {
+		return image.Pt(width/columns, height/rows)
+	}
+	return DefaultCellSize
+}
+
+var (
+	// shownGraphics holds the Graphics last written at each point.
+	shownGraphics = map[image.Point]Graphic{}
+	// graphicsStale is set by Clear since clearing the back buffer doesn't remove graphics from the screen.
+	graphicsStale bool
+)
+
+// flushGraphics flushes the Backend and then writes the graphics that changed since the last frame.
+// The whole screen is redrawn first if a graphic was replaced or the screen was cleared, so that
+// no pixels are left behind. With TerminalSynchronizedOutput, all of it is one synchronized update.
+func flushGraphics(graphics []Graphic) {
+	if TerminalSynchronizedOutput {
+		io.WriteString(GraphicsWriter, beginSynchronizedUpdate)
+		defer io.WriteString(GraphicsWriter, endSynchronizedUpdate)
+	}
+
+	sync := graphicsStale
+	for _, graphic := range graphics {
+		if shown, ok := shownGraphics[graphic.Point]; ok && !bytes.Equal(shown.Sequence, graphic.Sequence) {
+			sync = true
+		}
+	}
+	if sync {
+		deleteGraphics()
+		graphicsStale = false
+		backend.Sync()
+	} else {
+		backend.Flush()
+	}
+
+	for _, graphic := range graphics {
+		if bytes.Equal(shownGraphics[graphic.Point].Sequence, graphic.Sequence) {
+			continue
+		}
+		// the cursor is saved and restored around the sequence since termbox keeps track of its position
+		fmt.Fprintf(GraphicsWriter, "\x1b7\x1b[%d;%dH%s\x1b8", graphic.Point.Y+1, graphic.Point.X+1, graphic.Sequence)
+		shownGraphics[graphic.Point] = graphic
+	}
+}
+
+// deleteGraphics writes the Delete sequences of the Graphics on the screen and forgets them.
+func deleteGraphics() {
+	for _, graphic := range shownGraphics {
+		GraphicsWriter.Write(graphic.Delete)
+	}
+	shownGraphics = map[image.Point]Graphic{}
+}
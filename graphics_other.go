@@ -0,0 +1,12 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package termui
+
+// terminalPixels always fails since the size of the terminal in pixels isn't known here.
+func terminalPixels() (width, height, columns, rows int, ok bool) {
+	return 0, 0, 0, 0, false
+}
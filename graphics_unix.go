@@ -0,0 +1,25 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package termui
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// terminalPixels returns the size of the terminal in pixels and cells as reported by TIOCGWINSZ.
+func terminalPixels() (width, height, columns, rows int, ok bool) {
+	var size struct {
+		Rows, Columns, Width, Height uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, os.Stdout.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0, 0, 0, 0, false
+	}
+	return int(size.Width), int(size.Height), int(size.Columns), int(size.Rows), true
+}
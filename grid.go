@@ -4,6 +4,10 @@
 
 package termui
 
+import (
+	"sync"
+)
+
 type gridItemType uint
 
 const (
@@ -14,6 +18,14 @@ const (
 type Grid struct {
 	Block
 	Items []*GridItem
+
+	// Parallel draws the items on their own goroutines, into their own Buffers which are then
+	// drawn in order, so that dashboards of many heavy widgets are drawn faster on several cores.
+	// Items must then not share state which their Draw changes. Transparent items are drawn in
+	// place, like without Parallel, to show the items beneath them. The Lifecycle callbacks and
+	// announcements of the items, and those their Draw gives to Buffer.Defer, are run on the
+	// goroutine of the Grid.
+	Parallel bool
 }
 
 // GridItem represents either a Row or Column in a grid.
@@ -133,9 +145,79 @@ func (self *Grid) setHelper(item GridItem, parentWidthRatio, parentHeightRatio f
 }
 
 func (self *Grid) Draw(buf *Buffer) {
+	entries := self.layout()
+	if !self.Parallel {
+		for _, entry := range entries {
+			entry.Lock()
+			entry.Draw(buf)
+			entry.Unlock()
+			applyProfile(entry, buf, entry.GetRect())
+			notifyDrawn(buf, entry)
+		}
+		return
+	}
+
+	// the Buffers of the opaque items, drawn concurrently
+	bufs := make([]*Buffer, len(entries))
+	// the functions given to Defer by each of them, called here once they are drawn
+	deferred := make([][]func(), len(entries))
+	wait := sync.WaitGroup{}
+	for i, entry := range entries {
+		if t, ok := entry.(transparentDrawable); ok && t.isTransparent() {
+			continue
+		}
+		wait.Add(1)
+		go func(i int, entry Drawable) {
+			defer wait.Done()
+			bufs[i] = itemBuffer(entry, &deferred[i])
+		}(i, entry)
+	}
+	wait.Wait()
+	for i, entry := range entries {
+		if bufs[i] == nil {
+			entry.Lock()
+			entry.Draw(buf)
+			entry.Unlock()
+			applyProfile(entry, buf, entry.GetRect())
+			continue
+		}
+		buf.Composite(bufs[i])
+		bufferPool.Put(bufs[i])
+	}
+	for i, entry := range entries {
+		for _, f := range deferred[i] {
+			buf.Defer(f)
+		}
+		notifyDrawn(buf, entry)
+	}
+}
+
+// notifyDrawn announces an item drawn into a Buffer and calls its Lifecycle callbacks, once the
+// functions given to its Defer are called.
+func notifyDrawn(buf *Buffer, item Drawable) {
+	buf.Defer(func() {
+		announceItem(item)
+		Show(item)
+	})
+}
+
+// entries returns the items of the Grid.
+func (self *Grid) entries() []Drawable {
+	entries := []Drawable{}
+	for _, item := range self.Items {
+		if entry, ok := item.Entry.(Drawable); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// layout sets the rectangles of the items from their ratios, and returns them.
+func (self *Grid) layout() []Drawable {
 	width := float64(self.Dx()) + 1
 	height := float64(self.Dy()) + 1
 
+	entries := []Drawable{}
 	for _, item := range self.Items {
 		entry, _ := item.Entry.(Drawable)
 
@@ -152,9 +234,7 @@ func (self *Grid) Draw(buf *Buffer) {
 		}
 
 		entry.SetRect(x, y, x+w, y+h)
-
-		entry.Lock()
-		entry.Draw(buf)
-		entry.Unlock()
+		entries = append(entries, entry)
 	}
+	return entries
 }
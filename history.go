@@ -0,0 +1,127 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"time"
+)
+
+// Edit is a change recorded in a History, reverted by Undo and made again by Redo.
+type Edit interface {
+	Undo()
+	Redo()
+}
+
+// MergeableEdit is an Edit which can stand for the edits grouped after it, like an edit restoring
+// a snapshot of the text taken before it, which undoes a whole group of keystrokes. Merge reports
+// whether it absorbed next; otherwise next is kept in the group.
+type MergeableEdit interface {
+	Edit
+	Merge(next Edit) bool
+}
+
+// History is the undo and redo stack of an editing widget, shared by TextInput, TextArea, and
+// Table, and usable by custom widgets. Consecutive edits of the same group, like the keystrokes
+// typing a word, are undone in one step while they are made within GroupInterval of each other.
+type History struct {
+	// Limit is the number of undo steps kept, or unlimited when it is 0.
+	Limit int
+	// GroupInterval is the longest pause between two edits of a group, or unlimited when it is 0.
+	GroupInterval time.Duration
+
+	undo  [][]Edit
+	redo  [][]Edit
+	group string
+	last  time.Time
+}
+
+// NewHistory returns a History keeping 100 steps, grouping the edits made within a second of each other.
+func NewHistory() *History {
+	return &History{
+		Limit:         100,
+		GroupInterval: time.Second,
+	}
+}
+
+// Push records an edit as part of the last undo step when it has the same group, which isn't "",
+// and is made within GroupInterval of the last edit, and otherwise as a new step. Edits of the
+// group "" are never grouped. Push clears the steps undone, which can't be redone anymore.
+func (self *History) Push(edit Edit, group string) {
+	now := time.Now()
+	continued := group != "" && group == self.group && len(self.undo) > 0 &&
+		(self.GroupInterval <= 0 || now.Sub(self.last) <= self.GroupInterval)
+	self.redo = nil
+	self.group, self.last = group, now
+	if !continued {
+		self.undo = append(self.undo, []Edit{edit})
+		if self.Limit > 0 && len(self.undo) > self.Limit {
+			self.undo = self.undo[len(self.undo)-self.Limit:]
+		}
+		return
+	}
+	step := self.undo[len(self.undo)-1]
+	if first, ok := step[0].(MergeableEdit); ok && first.Merge(edit) {
+		return
+	}
+	self.undo[len(self.undo)-1] = append(step, edit)
+}
+
+// Continue makes the next edits of group part of the last undo step, like the text typed over a
+// selection, which is undone along with the deletion of the selection.
+func (self *History) Continue(group string) {
+	self.group, self.last = group, time.Now()
+}
+
+// Break ends the group of the last edits, so the next edit starts a new undo step, like after the
+// cursor is moved.
+func (self *History) Break() {
+	self.group = ""
+}
+
+// Undo reverts the last undo step and reports whether there was one.
+func (self *History) Undo() bool {
+	if len(self.undo) == 0 {
+		return false
+	}
+	step := self.undo[len(self.undo)-1]
+	self.undo = self.undo[:len(self.undo)-1]
+	for i := len(step) - 1; i >= 0; i-- {
+		step[i].Undo()
+	}
+	self.redo = append(self.redo, step)
+	self.Break()
+	return true
+}
+
+// Redo makes the last step reverted by Undo again and reports whether there was one.
+func (self *History) Redo() bool {
+	if len(self.redo) == 0 {
+		return false
+	}
+	step := self.redo[len(self.redo)-1]
+	self.redo = self.redo[:len(self.redo)-1]
+	for _, edit := range step {
+		edit.Redo()
+	}
+	self.undo = append(self.undo, step)
+	self.Break()
+	return true
+}
+
+// CanUndo reports whether there is a step to undo.
+func (self *History) CanUndo() bool {
+	return len(self.undo) > 0
+}
+
+// CanRedo reports whether there is a step to redo.
+func (self *History) CanRedo() bool {
+	return len(self.redo) > 0
+}
+
+// Clear forgets every step.
+func (self *History) Clear() {
+	self.undo, self.redo = nil, nil
+	self.Break()
+}
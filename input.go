@@ -0,0 +1,180 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strconv"
+	"strings"
+)
+
+// csiInputKeys are the keys sent as CSI sequences, by their final byte or their number before '~'.
+var csiInputKeys = map[string]string{
+	"A":   "<Up>",
+	"B":   "<Down>",
+	"C":   "<Right>",
+	"D":   "<Left>",
+	"H":   "<Home>",
+	"F":   "<End>",
+	"1~":  "<Home>",
+	"2~":  "<Insert>",
+	"3~":  "<Delete>",
+	"4~":  "<End>",
+	"5~":  "<PageUp>",
+	"6~":  "<PageDown>",
+	"15~": "<F5>",
+	"17~": "<F6>",
+	"18~": "<F7>",
+	"19~": "<F8>",
+	"20~": "<F9>",
+	"21~": "<F10>",
+	"23~": "<F11>",
+	"24~": "<F12>",
+}
+
+// ss3InputKeys are the keys sent as SS3 sequences, by their final byte.
+var ss3InputKeys = map[byte]string{
+	'A': "<Up>",
+	'B': "<Down>",
+	'C': "<Right>",
+	'D': "<Left>",
+	'H': "<Home>",
+	'F': "<End>",
+	'P': "<F1>",
+	'Q': "<F2>",
+	'R': "<F3>",
+	'S': "<F4>",
+}
+
+// controlInputKeys are the keys sent as control characters, named like termbox names them.
+var controlInputKeys = map[rune]string{
+	0x00: "<C-<Space>>",
+	0x08: "<C-<Backspace>>",
+	0x09: "<Tab>",
+	0x0d: "<Enter>",
+	0x1b: "<Escape>",
+	0x1c: "<C-4>",
+	0x1d: "<C-5>",
+	0x1e: "<C-6>",
+	0x1f: "<C-7>",
+	0x20: "<Space>",
+	0x7f: "<Backspace>",
+}
+
+func inputKeyEvent(id string) Event {
+	return Event{Type: KeyboardEvent, ID: id}
+}
+
+// ParseInput converts the input of a terminal, keys, pastes, and SGR mouse reports, into events,
+// for Backends reading terminals other than the one of Init, like remote ones. Sequences cut at
+// the end of the input are dropped.
+func ParseInput(input string) []Event {
+	events := []Event{}
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\x1b' {
+			rest := string(runes[i:])
+			if e, n, ok := ParsePaste([]byte(rest)); ok {
+				// a paste is sent in one message, so an incomplete one is dropped
+				if n > 0 {
+					events = append(events, e)
+					i += len([]rune(rest[:n])) - 1
+					continue
+				}
+				return events
+			}
+		}
+		switch {
+		case r == '\x1b' && i+1 < len(runes) && runes[i+1] == '[':
+			// CSI: parameters end with a final byte in @-~
+			end := i + 2
+			for end < len(runes) && (runes[end] < '@' || runes[end] > '~') {
+				end++
+			}
+			if end == len(runes) {
+				return events
+			}
+			params, final := string(runes[i+2:end]), runes[end]
+			if strings.HasPrefix(params, "<") && (final == 'M' || final == 'm') {
+				if e, ok := parseSGRMouse(params[1:], final == 'm'); ok {
+					events = append(events, e)
+				}
+			} else if id, ok := csiInputKeys[params+string(final)]; ok {
+				events = append(events, inputKeyEvent(id))
+			} else if id, ok := csiInputKeys[string(final)]; ok && strings.HasPrefix(params, "1;") {
+				// keys with modifiers, like "\x1b[1;5A", are sent without them
+				events = append(events, inputKeyEvent(id))
+			}
+			i = end
+		case r == '\x1b' && i+2 < len(runes) && runes[i+1] == 'O':
+			if id, ok := ss3InputKeys[byte(runes[i+2])]; ok {
+				events = append(events, inputKeyEvent(id))
+			}
+			i += 2
+		case r == '\x1b' && i+1 < len(runes):
+			// Alt sends the key after an escape
+			key := ParseInput(string(runes[i+1]))
+			if len(key) == 1 {
+				id := key[0].ID
+				if strings.HasPrefix(id, "<") && strings.HasSuffix(id, ">") {
+					id = id[1 : len(id)-1]
+				}
+				events = append(events, inputKeyEvent("<M-"+id+">"))
+			}
+			i++
+		case controlInputKeys[r] != "":
+			events = append(events, inputKeyEvent(controlInputKeys[r]))
+		case r < 0x20:
+			events = append(events, inputKeyEvent("<C-"+string('a'+r-1)+">"))
+		default:
+			events = append(events, inputKeyEvent(string(r)))
+		}
+	}
+	return events
+}
+
+// parseSGRMouse converts the parameters of an SGR mouse report, "button;x;y", into an event.
+func parseSGRMouse(params string, release bool) (Event, bool) {
+	fields := strings.Split(params, ";")
+	if len(fields) != 3 {
+		return Event{}, false
+	}
+	numbers := make([]int, 3)
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return Event{}, false
+		}
+		numbers[i] = n
+	}
+	button := numbers[0]
+	id := ""
+	switch {
+	case release:
+		id = "<MouseRelease>"
+	case button&64 != 0 && button&1 == 0:
+		id = "<MouseWheelUp>"
+	case button&64 != 0:
+		id = "<MouseWheelDown>"
+	case button&3 == 0:
+		id = "<MouseLeft>"
+	case button&3 == 1:
+		id = "<MouseMiddle>"
+	case button&3 == 2:
+		id = "<MouseRight>"
+	default:
+		// motion without a button held
+		return Event{}, false
+	}
+	return Event{
+		Type: MouseEvent,
+		ID:   id,
+		Payload: Mouse{
+			X:    numbers[1] - 1,
+			Y:    numbers[2] - 1,
+			Drag: button&32 != 0,
+		},
+	}, true
+}
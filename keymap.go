@@ -0,0 +1,145 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sync"
+)
+
+// KeyBinding is an action bound to keyboard event IDs, described so that it can be listed in help
+// screens and run by name from a command palette. A KeyBinding without Keys is a command only run
+// by name.
+type KeyBinding struct {
+	Keys        []string
+	Description string
+	// Scope groups related bindings, such as "Global" or "Editor".
+	Scope  string
+	Action func()
+}
+
+// Keymap is a registry of KeyBindings. Handle runs the action bound to an event ID, and the
+// bindings can be listed to show the keys of an application or to search its commands.
+type Keymap struct {
+	sync.Mutex
+	bindings []*KeyBinding
+	disabled map[string]bool
+}
+
+func NewKeymap() *Keymap {
+	return &Keymap{
+		disabled: map[string]bool{},
+	}
+}
+
+// DefaultKeymap is the Keymap used by widgets listing bindings unless they are given another.
+var DefaultKeymap = NewKeymap()
+
+// Bind registers an action under a scope and description, bound to the given event IDs, and
+// returns the binding. Keys bound more than once run the binding registered first.
+func (self *Keymap) Bind(scope, description string, action func(), keys ...string) *KeyBinding {
+	self.Lock()
+	defer self.Unlock()
+	binding := &KeyBinding{
+		Keys:        keys,
+		Description: description,
+		Scope:       scope,
+		Action:      action,
+	}
+	self.bindings = append(self.bindings, binding)
+	return binding
+}
+
+// Unbind removes a binding.
+func (self *Keymap) Unbind(binding *KeyBinding) {
+	self.Lock()
+	defer self.Unlock()
+	for i, b := range self.bindings {
+		if b == binding {
+			self.bindings = append(self.bindings[:i:i], self.bindings[i+1:]...)
+			return
+		}
+	}
+}
+
+// Bindings returns the bindings in the order they were registered.
+func (self *Keymap) Bindings() []*KeyBinding {
+	self.Lock()
+	defer self.Unlock()
+	return append([]*KeyBinding{}, self.bindings...)
+}
+
+// SetScopeEnabled enables or disables the bindings of a scope, such as those of a pane which
+// isn't focused. Disabled bindings aren't run by Handle nor listed by ActiveBindings.
+func (self *Keymap) SetScopeEnabled(scope string, enabled bool) {
+	self.Lock()
+	defer self.Unlock()
+	if enabled {
+		delete(self.disabled, scope)
+	} else {
+		self.disabled[scope] = true
+	}
+}
+
+// ScopeEnabled reports whether the bindings of a scope are enabled, which they are by default.
+func (self *Keymap) ScopeEnabled(scope string) bool {
+	self.Lock()
+	defer self.Unlock()
+	return !self.disabled[scope]
+}
+
+// ActiveBindings returns the bindings of the enabled scopes in the order they were registered.
+func (self *Keymap) ActiveBindings() []*KeyBinding {
+	self.Lock()
+	defer self.Unlock()
+	active := []*KeyBinding{}
+	for _, binding := range self.bindings {
+		if !self.disabled[binding.Scope] {
+			active = append(active, binding)
+		}
+	}
+	return active
+}
+
+// Scopes returns the scopes of the bindings in the order they were first used.
+func (self *Keymap) Scopes() []string {
+	self.Lock()
+	defer self.Unlock()
+	scopes := []string{}
+	seen := map[string]bool{}
+	for _, binding := range self.bindings {
+		if !seen[binding.Scope] {
+			seen[binding.Scope] = true
+			scopes = append(scopes, binding.Scope)
+		}
+	}
+	return scopes
+}
+
+// Lookup returns the enabled binding of an event ID, or nil if it isn't bound.
+func (self *Keymap) Lookup(id string) *KeyBinding {
+	self.Lock()
+	defer self.Unlock()
+	for _, binding := range self.bindings {
+		if self.disabled[binding.Scope] {
+			continue
+		}
+		for _, key := range binding.Keys {
+			if key == id {
+				return binding
+			}
+		}
+	}
+	return nil
+}
+
+// Handle runs the action bound to an event ID and reports whether there was one.
+func (self *Keymap) Handle(id string) bool {
+	binding := self.Lookup(id)
+	if binding == nil || binding.Action == nil {
+		return false
+	}
+	binding.Action()
+	return true
+}
@@ -0,0 +1,227 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// KittyKeyboard makes Init ask the terminal for the kitty keyboard protocol, which reports every
+// key as an escape sequence with its modifiers, and its repeats and release. Keys then have a Key
+// payload, keys legacy input can't tell apart get their own IDs, like <C-i> and <S-<Enter>>, and
+// releases are sent as <Release-...> keyboard events, like <Release-a> or <Release-<C-a>>.
+// Terminals without the protocol keep sending legacy input, which is read like without it.
+// It must be set before Init and is only supported by the termbox-go Backend, outside Windows.
+var KittyKeyboard = false
+
+// The kitty keyboard protocol is pushed with the flags to disambiguate escape codes (1), report
+// event types (2), report alternate keys (4), and report all keys as escape codes (8), and popped.
+const (
+	pushKittyKeyboard = "\x1b[>15u"
+	popKittyKeyboard  = "\x1b[<u"
+)
+
+// Key payload of the keyboard events read with KittyKeyboard.
+type Key struct {
+	Shift   bool
+	Alt     bool
+	Ctrl    bool
+	Super   bool
+	Repeat  bool
+	Release bool
+}
+
+// kittyKeys are the keys without a character sent as CSI u, by their key code.
+var kittyKeys = map[int]string{
+	9:     "<Tab>",
+	13:    "<Enter>",
+	27:    "<Escape>",
+	32:    "<Space>",
+	127:   "<Backspace>",
+	57414: "<Enter>", // keypad enter
+	57417: "<Left>",
+	57418: "<Right>",
+	57419: "<Up>",
+	57420: "<Down>",
+	57421: "<PageUp>",
+	57422: "<PageDown>",
+	57423: "<Home>",
+	57424: "<End>",
+	57425: "<Insert>",
+	57426: "<Delete>",
+}
+
+// kittyKeypad are the characters of the keypad keys, by their key code.
+var kittyKeypad = map[int]rune{
+	57399: '0', 57400: '1', 57401: '2', 57402: '3', 57403: '4',
+	57404: '5', 57405: '6', 57406: '7', 57407: '8', 57408: '9',
+	57409: '.', 57410: '/', 57411: '*', 57412: '-', 57413: '+', 57415: '=',
+}
+
+// kittyLegacyKeys are the keys sent with the legacy CSI sequences, by their final byte or their
+// number before '~'.
+var kittyLegacyKeys = map[string]string{
+	"A":   "<Up>",
+	"B":   "<Down>",
+	"C":   "<Right>",
+	"D":   "<Left>",
+	"H":   "<Home>",
+	"F":   "<End>",
+	"P":   "<F1>",
+	"Q":   "<F2>",
+	"S":   "<F4>",
+	"2~":  "<Insert>",
+	"3~":  "<Delete>",
+	"5~":  "<PageUp>",
+	"6~":  "<PageDown>",
+	"7~":  "<Home>",
+	"8~":  "<End>",
+	"11~": "<F1>",
+	"12~": "<F2>",
+	"13~": "<F3>",
+	"14~": "<F4>",
+	"15~": "<F5>",
+	"17~": "<F6>",
+	"18~": "<F7>",
+	"19~": "<F8>",
+	"20~": "<F9>",
+	"21~": "<F10>",
+	"23~": "<F11>",
+	"24~": "<F12>",
+}
+
+// kitty modifier bits, one less than the number sent
+const (
+	kittyShift    = 1
+	kittyAlt      = 2
+	kittyCtrl     = 4
+	kittySuper    = 8
+	kittyCapsLock = 64
+)
+
+// parseKittyKey converts the parameters and final byte of a CSI sequence sent with the kitty
+// keyboard protocol into a keyboard event. It reports false for sequences which aren't keys, and
+// an event without an ID for keys which are ignored, like modifiers pressed alone.
+func parseKittyKey(params string, final byte) (Event, bool) {
+	fields := strings.Split(params, ";")
+	codes := strings.Split(fields[0], ":")
+	modifiers, eventType := 0, 1
+	if len(fields) > 1 {
+		parts := strings.Split(fields[1], ":")
+		if n, err := strconv.Atoi(parts[0]); err == nil && n > 0 {
+			modifiers = n - 1
+		}
+		if len(parts) > 1 {
+			if n, err := strconv.Atoi(parts[1]); err == nil {
+				eventType = n
+			}
+		}
+	}
+
+	key := Key{
+		Shift:   modifiers&kittyShift != 0,
+		Alt:     modifiers&kittyAlt != 0,
+		Ctrl:    modifiers&kittyCtrl != 0,
+		Super:   modifiers&kittySuper != 0,
+		Repeat:  eventType == 2,
+		Release: eventType == 3,
+	}
+	name, char := "", rune(0)
+	switch final {
+	case 'u':
+		code, err := strconv.Atoi(codes[0])
+		if err != nil {
+			return Event{}, false
+		}
+		switch {
+		case kittyKeys[code] != "":
+			name = kittyKeys[code]
+		case kittyKeypad[code] != 0:
+			char = kittyKeypad[code]
+		case code >= 57376 && code <= 57398:
+			name = "<F" + strconv.Itoa(code-57376+13) + ">"
+		case code >= 57344 || code < 32:
+			// modifiers, locks, and media keys
+			return Event{Type: KeyboardEvent, Payload: key}, true
+		default:
+			char = rune(code)
+			// the shifted key is the second code, sent when Shift is held
+			if len(codes) > 1 && codes[1] != "" && key.Shift {
+				if shifted, err := strconv.Atoi(codes[1]); err == nil {
+					char = rune(shifted)
+				}
+			}
+			if modifiers&kittyCapsLock != 0 && unicode.IsLetter(char) {
+				if unicode.IsUpper(char) {
+					char = unicode.ToLower(char)
+				} else {
+					char = unicode.ToUpper(char)
+				}
+			}
+		}
+	default:
+		number := ""
+		if final == '~' {
+			number = codes[0]
+		}
+		var ok bool
+		if name, ok = kittyLegacyKeys[number+string(final)]; !ok {
+			return Event{}, false
+		}
+	}
+
+	id := ""
+	if char != 0 {
+		// Shift is part of the character
+		id = string(char)
+		if key.Ctrl {
+			id = "<C-" + id + ">"
+		}
+	} else {
+		id = name
+		if key.Shift {
+			id = "<S-" + id + ">"
+		}
+		if key.Ctrl {
+			id = "<C-" + id + ">"
+		}
+	}
+	if key.Alt {
+		id = "<M-" + id + ">"
+	}
+	if key.Super {
+		id = "<Super-" + id + ">"
+	}
+	if key.Release {
+		id = "<Release-" + id + ">"
+	}
+	return Event{Type: KeyboardEvent, ID: id, Payload: key}, true
+}
+
+// parseKittyInput converts the first event of the input read with the kitty keyboard protocol, and
+// returns the number of bytes it took. It returns 0 bytes when the input ends with an incomplete
+// sequence, and false for input parsed by termbox-go, like mouse reports and legacy keys.
+func parseKittyInput(input []byte) (Event, int, bool) {
+	if len(input) < 2 || input[0] != '\x1b' || input[1] != '[' {
+		return Event{}, 0, false
+	}
+	// CSI: parameters end with a final byte in @-~
+	end := 2
+	for end < len(input) && (input[end] < '@' || input[end] > '~') {
+		end++
+	}
+	if end == len(input) {
+		return Event{}, 0, true
+	}
+	params := string(input[2:end])
+	if strings.HasPrefix(params, "<") || strings.HasPrefix(params, "?") || input[end] == 'M' {
+		// mouse reports and replies
+		return Event{}, 0, false
+	}
+	e, ok := parseKittyKey(params, input[end])
+	return e, end + 1, ok
+}
@@ -0,0 +1,215 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sync"
+)
+
+// Lifecycle is implemented by Block, and therefore by every widget embedding it, to be told when
+// the widget is added to the UI, removed from it, focused, and shown or hidden, so that it can
+// start and stop its data feeds and timers while it is shown:
+//
+//   - OnMount is called when the widget is first drawn by Render, a Grid, or another layout, and
+//     OnUnmount when a layout discards it, like a Router closing a view or HideOverlay removing an
+//     overlay, or with Unmount.
+//   - OnVisible is called with true after OnMount and when the widget is drawn again after being
+//     hidden, and with false when a layout stops showing it, like Pages showing another page, or
+//     with Hide.
+//   - OnFocus and OnBlur are called when an Accessible widget drawn reports getting or losing the
+//     focus, and OnBlur when a focused widget is hidden.
+//
+// The callbacks are called while rendering, so like Animation.Step they must not call Render or
+// Update. Widgets overriding them call those of their Block, which call its Hooks. The state of the
+// Lifecycle is kept in the Block, so widgets without one aren't told about it.
+type Lifecycle interface {
+	OnMount()
+	OnUnmount()
+	OnFocus()
+	OnBlur()
+	OnVisible(visible bool)
+}
+
+// LifecycleHooks are the callbacks of the Lifecycle of a Block, each called when it is set.
+type LifecycleHooks struct {
+	OnMount   func()
+	OnUnmount func()
+	OnFocus   func()
+	OnBlur    func()
+	OnVisible func(visible bool)
+}
+
+// lifecycleState is whether a widget is mounted, and what it was when it was last shown or hidden.
+type lifecycleState struct {
+	mounted bool
+	visible bool
+	focused bool
+}
+
+// lifecycleDrawable is implemented by Block and therefore by every widget embedding it, which keeps
+// the state of its Lifecycle, so that it is dropped along with the widget.
+type lifecycleDrawable interface {
+	Lifecycle
+	mountState() *lifecycleState
+}
+
+// mountLock guards the lifecycleState of every widget.
+var mountLock sync.Mutex
+
+// Show calls the Lifecycle callbacks of items being drawn: OnMount and OnVisible the first time,
+// OnVisible when they were hidden, and OnFocus or OnBlur when the focus of Accessible items changed.
+// Render and Grid call it for the items they draw, and layouts drawing widgets themselves, like
+// Pages and Router, for their content, through Buffer.Defer so that it is called on the goroutine
+// rendering.
+func Show(items ...Drawable) {
+	for _, item := range items {
+		lifecycle, ok := item.(lifecycleDrawable)
+		if !ok {
+			continue
+		}
+		focused := false
+		if accessible, ok := item.(Accessible); ok {
+			item.Lock()
+			_, focused, _ = accessible.Accessibility()
+			item.Unlock()
+		}
+
+		mountLock.Lock()
+		state := *lifecycle.mountState()
+		*lifecycle.mountState() = lifecycleState{mounted: true, visible: true, focused: focused}
+		mountLock.Unlock()
+
+		if !state.mounted {
+			lifecycle.OnMount()
+		}
+		if !state.visible {
+			lifecycle.OnVisible(true)
+		}
+		switch {
+		case focused && !state.focused:
+			lifecycle.OnFocus()
+		case !focused && state.focused:
+			lifecycle.OnBlur()
+		}
+	}
+}
+
+// Hide calls OnBlur for the focused items among those shown, and OnVisible(false) for them, when a
+// layout stops showing them. The widgets of a Grid, and the children attached to a Block, are hidden
+// along with it.
+func Hide(items ...Drawable) {
+	for _, item := range items {
+		lifecycle, ok := item.(lifecycleDrawable)
+		if !ok {
+			continue
+		}
+		mountLock.Lock()
+		state := *lifecycle.mountState()
+		lifecycle.mountState().visible = false
+		lifecycle.mountState().focused = false
+		mountLock.Unlock()
+
+		if state.focused {
+			lifecycle.OnBlur()
+		}
+		if state.visible {
+			lifecycle.OnVisible(false)
+		}
+		if grid, ok := item.(*Grid); ok {
+			Hide(grid.entries()...)
+		}
+		if host, ok := item.(composedDrawable); ok {
+			Hide(host.childItems()...)
+		}
+	}
+}
+
+// Unmount hides the items and calls OnUnmount for the mounted ones, when they are removed from the
+// UI. They are mounted again if they are drawn again. The widgets of a Grid, and the children
+// attached to a Block, are unmounted along with it.
+func Unmount(items ...Drawable) {
+	for _, item := range items {
+		lifecycle, ok := item.(lifecycleDrawable)
+		if !ok {
+			continue
+		}
+		Hide(item)
+		mountLock.Lock()
+		state := *lifecycle.mountState()
+		*lifecycle.mountState() = lifecycleState{}
+		mountLock.Unlock()
+
+		if state.mounted {
+			lifecycle.OnUnmount()
+		}
+		if grid, ok := item.(*Grid); ok {
+			Unmount(grid.entries()...)
+		}
+		if host, ok := item.(composedDrawable); ok {
+			Unmount(host.childItems()...)
+		}
+	}
+}
+
+// IsMounted reports whether an item has been drawn and not unmounted since.
+func IsMounted(item Drawable) bool {
+	lifecycle, ok := item.(lifecycleDrawable)
+	if !ok {
+		return false
+	}
+	mountLock.Lock()
+	defer mountLock.Unlock()
+	return lifecycle.mountState().mounted
+}
+
+// IsVisible reports whether an item is mounted and hasn't been hidden since it was last drawn.
+func IsVisible(item Drawable) bool {
+	lifecycle, ok := item.(lifecycleDrawable)
+	if !ok {
+		return false
+	}
+	mountLock.Lock()
+	defer mountLock.Unlock()
+	return lifecycle.mountState().visible
+}
+
+func (self *Block) mountState() *lifecycleState {
+	return &self.lifecycle
+}
+
+// OnMount implements Lifecycle, calling Hooks.OnMount.
+func (self *Block) OnMount() {
+	if self.Hooks.OnMount != nil {
+		self.Hooks.OnMount()
+	}
+}
+
+// OnUnmount implements Lifecycle, calling Hooks.OnUnmount.
+func (self *Block) OnUnmount() {
+	if self.Hooks.OnUnmount != nil {
+		self.Hooks.OnUnmount()
+	}
+}
+
+// OnFocus implements Lifecycle, calling Hooks.OnFocus.
+func (self *Block) OnFocus() {
+	if self.Hooks.OnFocus != nil {
+		self.Hooks.OnFocus()
+	}
+}
+
+// OnBlur implements Lifecycle, calling Hooks.OnBlur.
+func (self *Block) OnBlur() {
+	if self.Hooks.OnBlur != nil {
+		self.Hooks.OnBlur()
+	}
+}
+
+// OnVisible implements Lifecycle, calling Hooks.OnVisible.
+func (self *Block) OnVisible(visible bool) {
+	if self.Hooks.OnVisible != nil {
+		self.Hooks.OnVisible(visible)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"time"
+)
+
+// DoubleClickInterval is the longest time between the clicks counted together in Mouse.Clicks,
+// like the two of a double-click.
+var DoubleClickInterval = 500 * time.Millisecond
+
+// lastClick is the last press counted by countClicks.
+var lastClick struct {
+	id    string
+	point image.Point
+	time  time.Time
+	count int
+}
+
+// countClicks sets the Clicks of a mouse button press, counting it along with the last one if it
+// was of the same button at the same point within DoubleClickInterval.
+func countClicks(e Event) Event {
+	m, ok := e.Payload.(Mouse)
+	if !ok || m.Drag {
+		return e
+	}
+	switch e.ID {
+	case "<MouseLeft>", "<MouseRight>", "<MouseMiddle>":
+	default:
+		return e
+	}
+	now, p := time.Now(), image.Pt(m.X, m.Y)
+	if e.ID == lastClick.id && p == lastClick.point && now.Sub(lastClick.time) <= DoubleClickInterval {
+		lastClick.count++
+	} else {
+		lastClick.id, lastClick.point, lastClick.count = e.ID, p, 1
+	}
+	lastClick.time = now
+	m.Clicks = lastClick.count
+	e.Payload = m
+	return e
+}
+
+// WheelSteps returns the number of wheel events of a <MouseWheelUp> or <MouseWheelDown> event, which
+// is more than 1 when PollEvents coalesced them.
+func WheelSteps(e Event) int {
+	if m, ok := e.Payload.(Mouse); ok && m.Steps > 1 {
+		return m.Steps
+	}
+	return 1
+}
+
+// coalesceEvent adds an event to the pending ones, merging it into the last if both are drags of
+// the same button, keeping the latest position, or wheel events of the same direction, summing their
+// Steps.
+func coalesceEvent(pending []Event, e Event) []Event {
+	m, ok := e.Payload.(Mouse)
+	if !ok || len(pending) == 0 {
+		return append(pending, e)
+	}
+	last := &pending[len(pending)-1]
+	lastMouse, ok := last.Payload.(Mouse)
+	if !ok || last.ID != e.ID {
+		return append(pending, e)
+	}
+	switch {
+	case m.Drag && lastMouse.Drag:
+		last.Payload = m
+	case e.ID == "<MouseWheelUp>" || e.ID == "<MouseWheelDown>":
+		m.Steps = WheelSteps(*last) + WheelSteps(e)
+		last.Payload = m
+	default:
+		return append(pending, e)
+	}
+	return pending
+}
+
+// MousePoint returns the position of a mouse event, and false if e isn't a mouse event.
+func MousePoint(e Event) (image.Point, bool) {
+	m, ok := e.Payload.(Mouse)
+	if !ok {
+		return image.Point{}, false
+	}
+	return image.Pt(m.X, m.Y), true
+}
+
+// HitTest returns the last of items whose area holds p, which is the item on top when the items
+// are rendered in the same order, or nil if there is none.
+// It is used to send a mouse event to the widget under the pointer:
+//
+//	if p, ok := ui.MousePoint(e); ok {
+//		if list, ok := ui.HitTest(p, list1, list2).(*widgets.List); ok {
+//			list.HandleMouse(e)
+//		}
+//	}
+func HitTest(p image.Point, items ...Drawable) Drawable {
+	for i := len(items) - 1; i >= 0; i-- {
+		if p.In(items[i].GetRect()) {
+			return items[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"sync"
+)
+
+var (
+	overlayLock sync.Mutex
+	overlays    []Drawable
+	// hiddenOverlays holds the areas of the overlays hidden since the last Render.
+	hiddenOverlays []image.Rectangle
+)
+
+// ShowOverlay adds an item that every Render draws over the items given to it, for popups such
+// as menus and dialogs floating above the layout. Overlays are drawn in the order they were shown,
+// and showing an overlay again brings it to the top.
+func ShowOverlay(item Drawable) {
+	overlayLock.Lock()
+	defer overlayLock.Unlock()
+	overlays = append(removeOverlay(overlays, item), item)
+}
+
+// HideOverlay removes an overlay, and unmounts it. The area it covered, along with its shadow, is cleared by the next
+// Render, which is expected to redraw the items beneath it.
+func HideOverlay(item Drawable) {
+	overlayLock.Lock()
+	shown := false
+	for _, overlay := range overlays {
+		if overlay == item {
+			hiddenOverlays = append(hiddenOverlays, drawnArea(item))
+			shown = true
+		}
+	}
+	overlays = removeOverlay(overlays, item)
+	overlayLock.Unlock()
+	if shown {
+		Unmount(item)
+	}
+}
+
+// Overlays returns the overlays being shown, the topmost last. Mouse events can be sent to the
+// overlay under the pointer with HitTest(p, Overlays()...).
+func Overlays() []Drawable {
+	overlayLock.Lock()
+	defer overlayLock.Unlock()
+	return append([]Drawable{}, overlays...)
+}
+
+// IsOverlay reports whether an item is being shown as an overlay.
+func IsOverlay(item Drawable) bool {
+	overlayLock.Lock()
+	defer overlayLock.Unlock()
+	for _, overlay := range overlays {
+		if overlay == item {
+			return true
+		}
+	}
+	return false
+}
+
+func removeOverlay(items []Drawable, item Drawable) []Drawable {
+	kept := []Drawable{}
+	for _, overlay := range items {
+		if overlay != item {
+			kept = append(kept, overlay)
+		}
+	}
+	return kept
+}
+
+// takeOverlays returns the overlays to draw and the areas of the hidden ones to clear.
+func takeOverlays() ([]Drawable, []image.Rectangle) {
+	overlayLock.Lock()
+	defer overlayLock.Unlock()
+	hidden := hiddenOverlays
+	hiddenOverlays = nil
+	return append([]Drawable{}, overlays...), hidden
+}
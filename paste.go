@@ -0,0 +1,49 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bytes"
+	"strings"
+)
+
+// BracketedPaste makes Init enable the bracketed paste mode of the terminal, which marks the start
+// and end of pasted text so that it is sent as a single <Paste> event with a Paste payload instead
+// of a keyboard event for each of its characters. It must be set before Init, and is supported by
+// the termbox-go Backend outside Windows and the web backend. The Windows console doesn't emit
+// <Paste> events, pasted text is read as keyboard events there.
+var BracketedPaste = true
+
+const (
+	enableBracketedPaste  = "\x1b[?2004h"
+	disableBracketedPaste = "\x1b[?2004l"
+	// the pasted text is sent between these
+	pasteStart = "\x1b[200~"
+	pasteEnd   = "\x1b[201~"
+)
+
+// ParsePaste converts pasted text sent in bracketed paste mode at the start of the input into a
+// <Paste> event, and returns the number of bytes it took. It returns false when the input doesn't
+// start with a paste, and 0 bytes when the end of the paste wasn't read yet.
+func ParsePaste(input []byte) (Event, int, bool) {
+	if len(input) >= 2 && len(input) < len(pasteStart) && strings.HasPrefix(pasteStart, string(input)) {
+		return Event{}, 0, true
+	}
+	if !bytes.HasPrefix(input, []byte(pasteStart)) {
+		return Event{}, 0, false
+	}
+	end := bytes.Index(input, []byte(pasteEnd))
+	if end < 0 {
+		return Event{}, 0, true
+	}
+	// terminals send the lines of the text separated by carriage returns
+	text := string(input[len(pasteStart):end])
+	text = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(text)
+	return Event{
+		Type:    PasteEvent,
+		ID:      "<Paste>",
+		Payload: Paste{Text: text},
+	}, end + len(pasteEnd), true
+}
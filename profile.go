@@ -0,0 +1,171 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Profile is what a terminal is able to draw. The cells of widgets are substituted with what it
+// can draw when they are rendered, so that widgets degrade gracefully on limited terminals.
+type Profile struct {
+	// ASCII draws box drawing characters, blocks, and other symbols with ASCII look-alikes, like
+	// '+', '-', and '|' for borders.
+	ASCII bool
+	// NoBraille draws the braille patterns of canvases, plots, and spinners as dots.
+	NoBraille bool
+	// Monochrome draws without colors, reversing the styles which have a background color.
+	Monochrome bool
+}
+
+var (
+	// ProfileFull draws everything as it is.
+	ProfileFull = Profile{}
+	// ProfileLinuxConsole is the Linux console, whose fonts have box drawing but no braille.
+	ProfileLinuxConsole = Profile{NoBraille: true}
+	// ProfileASCII is a terminal without Unicode, like a VT100 or a terminal without a UTF-8 locale.
+	ProfileASCII = Profile{ASCII: true, NoBraille: true}
+	// ProfileDumb is a dumb terminal, without Unicode or colors.
+	ProfileDumb = Profile{ASCII: true, NoBraille: true, Monochrome: true}
+)
+
+// TerminalProfile is set by Init from DetectProfile and used for the widgets without a Profile of
+// their own. It can be changed after Init when the guess is wrong, or to preview a limited terminal.
+var TerminalProfile = ProfileFull
+
+// DetectProfile guesses what the terminal can draw from $TERM and the locale in $LC_ALL,
+// $LC_CTYPE, or $LANG.
+func DetectProfile() Profile {
+	if runtime.GOOS == "windows" {
+		// consoles don't set $TERM, and symbols_windows.go already draws ASCII borders
+		return ProfileFull
+	}
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case term == "" || term == "dumb":
+		return ProfileDumb
+	case term == "linux" || strings.HasPrefix(term, "linux-"):
+		return ProfileLinuxConsole
+	case term == "vt52", term == "vt100", term == "vt102", term == "vt220", term == "ansi", term == "cons25":
+		return ProfileASCII
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToLower(os.Getenv(name)); locale != "" {
+			if !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8") {
+				return ProfileASCII
+			}
+			break
+		}
+	}
+	return ProfileFull
+}
+
+// profiledDrawable is implemented by Block and therefore by every widget embedding it.
+type profiledDrawable interface {
+	profile() *Profile
+}
+
+// applyProfile substitutes the cells an item was drawn in, in area, with what its Profile, or
+// otherwise TerminalProfile, can draw. The items of a Grid are substituted by the Grid.
+func applyProfile(item Drawable, buf *Buffer, area image.Rectangle) {
+	if _, ok := item.(*Grid); ok {
+		return
+	}
+	profile := TerminalProfile
+	if p, ok := item.(profiledDrawable); ok && p.profile() != nil {
+		profile = *p.profile()
+	}
+	if profile != ProfileFull {
+		profile.Apply(buf, area)
+	}
+}
+
+// Apply substitutes the cells of a Buffer in an area with what the Profile can draw.
+func (self Profile) Apply(buf *Buffer, area image.Rectangle) {
+	area = buf.Intersect(area)
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		i, _ := buf.index(image.Pt(area.Min.X, y))
+		for x := area.Min.X; x < area.Max.X; x, i = x+1, i+1 {
+			buf.Cells[i] = self.Cell(buf.Cells[i])
+		}
+	}
+}
+
+// Cell returns what the Profile draws in place of a Cell.
+func (self Profile) Cell(cell Cell) Cell {
+	if self.Monochrome {
+		cell.Style = cell.Style.Downgrade(ColorDepthMono)
+	}
+	r := cell.Rune
+	switch {
+	case r < 0x80:
+	case r >= BRAILLE_OFFSET && r <= BRAILLE_OFFSET+0xff:
+		if self.NoBraille || self.ASCII {
+			cell.Rune = self.brailleDot(r - BRAILLE_OFFSET)
+		}
+	case self.ASCII:
+		cell.Rune = asciiRune(r)
+	}
+	return cell
+}
+
+// brailleDot returns the dot drawn in place of a braille pattern: ASCII draws ' for the top dots,
+// . for the bottom ones, and : for both.
+func (self Profile) brailleDot(dots rune) rune {
+	top, bottom := dots&0x1b != 0, dots&0xe4 != 0
+	switch {
+	case !top && !bottom:
+		return ' '
+	case !self.ASCII:
+		return DOT
+	case top && bottom:
+		return ':'
+	case top:
+		return '\''
+	}
+	return '.'
+}
+
+// asciiRunes are the ASCII look-alikes of the symbols drawn by widgets.
+var asciiRunes = map[rune]rune{
+	'•': '*', '…': '.', '«': '<', '»': '>',
+	'▲': '^', '▼': 'v', '▶': '>', '▸': '>', '◀': '<', '◂': '<',
+	'−': '-', '×': 'x', '✓': 'v', '✗': 'x',
+	'☑': 'x', '☐': '_', '▣': '~', '◉': '*', '○': 'o', '●': 'O',
+	'■': '#', '□': 'o', '◐': 'o', '◓': 'o', '◑': 'o', '◒': 'o',
+	'▁': '_', '▂': '_', '▃': '_', '▄': '=', '▅': '=', '▆': '=', '▇': '#', '█': '#',
+	'▏': '|', '▎': '|', '▍': '|', '▌': '#', '▋': '#', '▊': '#', '▉': '#',
+	'░': '.', '▒': ':', '▓': '#', '▀': '"', '▐': '#',
+	'▘': '\'', '▝': '\'', '▖': '.', '▗': '.', '▞': '/', '▚': '\\',
+	'▛': '#', '▜': '#', '▙': '#', '▟': '#',
+	'‘': '\'', '’': '\'', '“': '"', '”': '"', '–': '-', '—': '-',
+}
+
+// asciiRune returns the ASCII look-alike of a rune, or the rune itself when it has none.
+func asciiRune(r rune) rune {
+	if ascii, ok := asciiRunes[r]; ok {
+		return ascii
+	}
+	if r < 0x2500 || r > 0x257f {
+		return r
+	}
+	// box drawing: lines and dashes, then corners and junctions
+	switch r {
+	case '─', '━', '┄', '┅', '┈', '┉', '╌', '╍', '═', '╴', '╶', '╸', '╺':
+		return '-'
+	case '│', '┃', '┆', '┇', '┊', '┋', '╎', '╏', '║', '╵', '╷', '╹', '╻':
+		return '|'
+	case '╱':
+		return '/'
+	case '╲':
+		return '\\'
+	case '╳':
+		return 'X'
+	}
+	return '+'
+}
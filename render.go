@@ -7,8 +7,6 @@ package termui
 import (
 	"image"
 	"sync"
-
-	tb "github.com/nsf/termbox-go"
 )
 
 type Drawable interface {
@@ -18,21 +16,178 @@ type Drawable interface {
 	sync.Locker
 }
 
+// shadowedDrawable is implemented by Block and therefore by every widget embedding it.
+type shadowedDrawable interface {
+	shadowRect() (image.Rectangle, Style, bool)
+}
+
+// drawnArea returns the area an item covers when drawn, along with its shadow.
+func drawnArea(item Drawable) image.Rectangle {
+	if s, ok := item.(shadowedDrawable); ok {
+		if shadow, _, ok := s.shadowRect(); ok {
+			return item.GetRect().Union(shadow)
+		}
+	}
+	return item.GetRect()
+}
+
+// drawShadow dims the cells of the Backend in the shadow of an item, if it casts one.
+func drawShadow(item Drawable) {
+	s, ok := item.(shadowedDrawable)
+	if !ok {
+		return
+	}
+	shadow, style, ok := s.shadowRect()
+	if !ok {
+		return
+	}
+	rect := item.GetRect()
+	// the rune of the cell is kept, with the colors of the shadow
+	cell := Cell{0, Style{style.Fg, style.Bg, ModifierClear}}
+	for y := shadow.Min.Y; y < shadow.Max.Y; y++ {
+		for x := shadow.Min.X; x < shadow.Max.X; x++ {
+			if point := image.Pt(x, y); !point.In(rect) {
+				backend.SetCell(point, cell.Composite(backend.Cell(point)))
+			}
+		}
+	}
+}
+
+// transparentDrawable is implemented by Block and therefore by every widget embedding it.
+type transparentDrawable interface {
+	isTransparent() bool
+}
+
+// renderLock serializes frames with the widget mutations made through Update.
+var renderLock sync.Mutex
+
+// Update runs fn while holding the render lock so that widget state changed from other
+// goroutines is never drawn half-updated by a concurrent Render.
+// fn must not call Render or Clear since they acquire the same lock.
+func Update(fn func()) {
+	renderLock.Lock()
+	defer renderLock.Unlock()
+	fn()
+}
+
+// Render draws the items in order, followed by the overlays added with ShowOverlay.
 func Render(items ...Drawable) {
+	renderLock.Lock()
+	defer renderLock.Unlock()
+
+	overlays, hidden := takeOverlays()
+	blank := NewCell(' ', NewStyle(ColorClear, Theme.Default.Bg))
+	for _, rect := range hidden {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			for y := rect.Min.Y; y < rect.Max.Y; y++ {
+				backend.SetCell(image.Pt(x, y), blank)
+			}
+		}
+	}
+
+	graphics := []Graphic{}
 	for _, item := range items {
-		buf := NewBuffer(item.GetRect())
-		item.Lock()
-		item.Draw(buf)
-		item.Unlock()
-		for point, cell := range buf.CellMap {
-			if point.In(buf.Rectangle) {
-				tb.SetCell(
-					point.X, point.Y,
-					cell.Rune,
-					tb.Attribute(cell.Style.Fg+1)|tb.Attribute(cell.Style.Modifier), tb.Attribute(cell.Style.Bg+1),
-				)
+		graphics = append(graphics, drawItem(item)...)
+	}
+	for _, overlay := range overlays {
+		graphics = append(graphics, drawItem(overlay)...)
+	}
+	flushGraphics(graphics)
+}
+
+// dirty holds the items marked by MarkDirty, in the order they were first marked.
+var dirty = struct {
+	sync.Mutex
+	items []Drawable
+}{}
+
+// MarkDirty marks items whose data changed, to be drawn by the next RenderDirty.
+func MarkDirty(items ...Drawable) {
+	dirty.Lock()
+	defer dirty.Unlock()
+next:
+	for _, item := range items {
+		for _, marked := range dirty.items {
+			if marked == item {
+				continue next
+			}
+		}
+		dirty.items = append(dirty.items, item)
+	}
+}
+
+// RenderDirty renders the items marked by MarkDirty since the last RenderDirty, in the order they
+// were marked, so that only the widgets which changed are drawn instead of a whole layout.
+// Nothing is drawn when none were marked.
+func RenderDirty() {
+	dirty.Lock()
+	items := dirty.items
+	dirty.items = nil
+	dirty.Unlock()
+	if len(items) > 0 {
+		Render(items...)
+	}
+}
+
+// RenderBuffer draws the items in order into a Buffer covering all of them, like Render draws
+// them on the screen, so that they can be exported without a terminal.
+func RenderBuffer(items ...Drawable) *Buffer {
+	area := image.Rectangle{}
+	for _, item := range items {
+		area = area.Union(item.GetRect())
+	}
+	buf := NewBuffer(area)
+	for _, item := range items {
+		itemBuf := itemBuffer(item, nil)
+		buf.Composite(itemBuf)
+		bufferPool.Put(itemBuf)
+	}
+	return buf
+}
+
+// bufferPool holds the Buffers of itemBuffer, which are reused for the next frames instead of
+// allocating new ones.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return &Buffer{}
+	},
+}
+
+// itemBuffer returns a Buffer of bufferPool the item is drawn in. The functions given to Defer
+// while it is drawn are added to deferred when it isn't nil, and called right away otherwise.
+func itemBuffer(item Drawable, deferred *[]func()) *Buffer {
+	buf := bufferPool.Get().(*Buffer)
+	buf.deferred = deferred
+	if t, ok := item.(transparentDrawable); ok && t.isTransparent() {
+		buf.Reset(item.GetRect(), CellTransparent)
+	} else {
+		buf.Reset(item.GetRect(), CellClear)
+	}
+	item.Lock()
+	item.Draw(buf)
+	item.Unlock()
+	applyProfile(item, buf, buf.Rectangle)
+	return buf
+}
+
+// drawItem draws an item into the back buffer of the Backend and returns its graphics.
+func drawItem(item Drawable) []Graphic {
+	buf := itemBuffer(item, nil)
+	announceItem(item)
+	Show(item)
+	i := 0
+	for y := buf.Min.Y; y < buf.Max.Y; y++ {
+		for x := buf.Min.X; x < buf.Max.X; x, i = x+1, i+1 {
+			point, cell := image.Pt(x, y), buf.Cells[i]
+			if cell.IsTransparent() {
+				cell = cell.Composite(backend.Cell(point))
 			}
+			backend.SetCell(point, cell)
 		}
 	}
-	tb.Flush()
+	drawShadow(item)
+	// the Graphics aren't reused by Reset
+	graphics := buf.Graphics
+	bufferPool.Put(buf)
+	return graphics
 }
@@ -0,0 +1,179 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Persistent is implemented by the widgets whose UI state, like their scroll position, selected
+// row, or sort order, can be saved and restored on the next launch by a StateManager.
+type Persistent interface {
+	// SaveState returns the state of the widget, encoded as JSON.
+	SaveState() ([]byte, error)
+	// RestoreState restores a state returned by SaveState. The state may be from an older version
+	// of the application, whose widgets had other rows or columns, and is applied as far as it fits.
+	RestoreState(data []byte) error
+}
+
+// StateManager saves the state of Persistent widgets to a JSON file, keyed by names given to the
+// widgets, and restores it on the next launch:
+//
+//	state := ui.NewStateManager(ui.StatePath("myapp"))
+//	state.Load()
+//	state.Register("files", list)
+//	defer state.Save()
+//
+// The states of widgets which aren't registered, like those of views not opened in a run, are kept
+// in the file.
+type StateManager struct {
+	sync.Mutex
+	Path string
+
+	widgets map[string]Persistent
+	saved   map[string]json.RawMessage
+}
+
+func NewStateManager(path string) *StateManager {
+	return &StateManager{
+		Path:    path,
+		widgets: map[string]Persistent{},
+		saved:   map[string]json.RawMessage{},
+	}
+}
+
+// StatePath returns the path of the state file of an application named app, in $XDG_STATE_HOME,
+// or else in the configuration directory of the user.
+func StatePath(app string) string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		if config, err := os.UserConfigDir(); err == nil {
+			dir = config
+		}
+	}
+	return filepath.Join(dir, app, "state.json")
+}
+
+// Load reads the state file and restores the widgets registered so far. A missing file isn't an error.
+func (self *StateManager) Load() error {
+	self.Lock()
+	defer self.Unlock()
+	data, err := ioutil.ReadFile(self.Path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	saved := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	self.saved = saved
+	for _, name := range self.names() {
+		if err := self.restore(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Register adds a widget saved under name, and restores its state if it was loaded.
+func (self *StateManager) Register(name string, widget Persistent) error {
+	self.Lock()
+	defer self.Unlock()
+	self.widgets[name] = widget
+	return self.restore(name)
+}
+
+// Unregister removes the widget saved under name, keeping its last saved state in the file.
+func (self *StateManager) Unregister(name string) {
+	self.Lock()
+	defer self.Unlock()
+	if _, ok := self.widgets[name]; !ok {
+		return
+	}
+	if err := self.snapshot(name); err == nil {
+		delete(self.widgets, name)
+	}
+}
+
+// restore restores the widget registered under name from its loaded state.
+func (self *StateManager) restore(name string) error {
+	data, ok := self.saved[name]
+	widget := self.widgets[name]
+	if !ok || widget == nil {
+		return nil
+	}
+	if locker, ok := widget.(sync.Locker); ok {
+		locker.Lock()
+		defer locker.Unlock()
+	}
+	return widget.RestoreState(data)
+}
+
+// snapshot records the state of the widget registered under name.
+func (self *StateManager) snapshot(name string) error {
+	widget := self.widgets[name]
+	if locker, ok := widget.(sync.Locker); ok {
+		locker.Lock()
+		defer locker.Unlock()
+	}
+	data, err := widget.SaveState()
+	if err != nil {
+		return err
+	}
+	self.saved[name] = data
+	return nil
+}
+
+// names returns the names of the registered widgets, sorted.
+func (self *StateManager) names() []string {
+	names := make([]string, 0, len(self.widgets))
+	for name := range self.widgets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Save writes the state of the registered widgets, along with the states loaded for the others,
+// to the state file, creating its directory. The file is replaced at once, so a crash while saving
+// leaves the previous state.
+func (self *StateManager) Save() error {
+	self.Lock()
+	defer self.Unlock()
+	for _, name := range self.names() {
+		if err := self.snapshot(name); err != nil {
+			return err
+		}
+	}
+	data, err := json.MarshalIndent(self.saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(self.Path), 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(self.Path), filepath.Base(self.Path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), self.Path)
+}
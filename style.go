@@ -1,6 +1,7 @@
 package termui
 
-// Color is an integer from -1 to 255
+// Color is an integer from -2 to 255 or a 24-bit color created with NewRGBColor
+// -2 = ColorTransparent
 // -1 = ColorClear
 // 0-255 = Xterm colors
 type Color int
@@ -8,6 +9,9 @@ type Color int
 // ColorClear clears the Fg or Bg color of a Style
 const ColorClear Color = -1
 
+// ColorTransparent keeps the color of whatever lies beneath a cell when it is composited
+const ColorTransparent Color = -2
+
 // Basic terminal colors
 const (
 	ColorBlack   Color = 0
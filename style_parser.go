@@ -75,8 +75,16 @@ func readStyle(runes []rune, defaultStyle Style) Style {
 // Syntax is of the form [text](fg:<color>,mod:<attribute>,bg:<color>).
 // Ordering does not matter. All fields are optional.
 func ParseStyles(s string, defaultStyle Style) []Cell {
-	cells := []Cell{}
+	if !strings.ContainsRune(s, tokenBeginStyledText) {
+		// no styles to parse
+		cells := make([]Cell, 0, len(s))
+		for _, _rune := range s {
+			cells = append(cells, Cell{_rune, defaultStyle})
+		}
+		return cells
+	}
 	runes := []rune(s)
+	cells := make([]Cell, 0, len(runes))
 	state := parserStateDefault
 	styledText := []rune{}
 	styleItems := []rune{}
@@ -4,18 +4,51 @@ const (
 	DOT      = '•'
 	ELLIPSES = '…'
 
-	UP_ARROW   = '▲'
-	DOWN_ARROW = '▼'
+	UP_ARROW    = '▲'
+	DOWN_ARROW  = '▼'
+	RIGHT_ARROW = '▶'
 
 	COLLAPSED = '+'
 	EXPANDED  = '−'
+
+	CHECKED           = '☑'
+	UNCHECKED         = '☐'
+	PARTIALLY_CHECKED = '▣'
+
+	SELECTED   = '◉'
+	UNSELECTED = '○'
+
+	SLIDER_HANDLE = '●'
+	TICK          = '╵'
+
+	SUBMENU = '▸'
+
+	DECREMENT = '−'
+	INCREMENT = '+'
+
+	DIRECTORY = '■'
+	FILE      = '□'
+
+	CLOSE = '×'
+	CHECK = '✓'
+	ERROR = '✗'
 )
 
 var (
 	BARS = [...]rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
 
+	HORIZONTAL_BARS = [...]rune{' ', '▏', '▎', '▍', '▌', '▋', '▊', '▉', '█'}
+
 	SHADED_BLOCKS = [...]rune{' ', '░', '▒', '▓', '█'}
 
+	UPPER_HALF_BLOCK = '▀'
+
+	SPINNER_BRAILLE = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	SPINNER_DOTS    = []string{"   ", ".  ", ".. ", "..."}
+	SPINNER_LINE    = []string{"-", "\\", "|", "/"}
+	SPINNER_CIRCLE  = []string{"◐", "◓", "◑", "◒"}
+	SPINNER_BLOCKS  = []string{"▖", "▘", "▝", "▗"}
+
 	IRREGULAR_BLOCKS = [...]rune{
 		' ', '▘', '▝', '▀', '▖', '▌', '▞', '▛',
 		'▗', '▚', '▐', '▜', '▄', '▙', '▟', '█',
@@ -0,0 +1,50 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"os"
+	"strings"
+)
+
+// The synchronized output sequences (DEC private mode 2026) make the terminal hold back drawing
+// what is written between them until the end, so that a frame is never shown half drawn.
+const (
+	beginSynchronizedUpdate = "\x1b[?2026h"
+	endSynchronizedUpdate   = "\x1b[?2026l"
+)
+
+// TerminalSynchronizedOutput is set by Init from DetectSynchronizedOutput, and makes Render write
+// each frame, along with its graphics, to the terminal as a synchronized update.
+// It can be changed after Init when the guess is wrong.
+var TerminalSynchronizedOutput = false
+
+// synchronizedBackend is implemented by the Backends drawing on a terminal which may support
+// synchronized output. The markers are written to GraphicsWriter, so it must be the output of the
+// Backend.
+type synchronizedBackend interface {
+	SynchronizedOutput() bool
+}
+
+// DetectSynchronizedOutput guesses whether the terminal supports synchronized output from $TERM,
+// $TERM_PROGRAM, $KITTY_WINDOW_ID, and $WT_SESSION. Terminals ignore the sequences when they don't,
+// but some older ones print them.
+func DetectSynchronizedOutput() bool {
+	term := strings.ToLower(os.Getenv("TERM"))
+	program := strings.ToLower(os.Getenv("TERM_PROGRAM"))
+	switch {
+	case strings.Contains(term, "kitty"), os.Getenv("KITTY_WINDOW_ID") != "":
+		return true
+	case program == "wezterm", program == "iterm.app", program == "ghostty", program == "contour":
+		return true
+	case strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "contour"), strings.HasPrefix(term, "alacritty"),
+		strings.Contains(term, "ghostty"):
+		return true
+	case os.Getenv("WT_SESSION") != "":
+		// Windows Terminal
+		return true
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"os"
+
+	tb "github.com/nsf/termbox-go"
+)
+
+// termboxBackend is the Backend of Init, drawing on the terminal with termbox-go.
+type termboxBackend struct {
+	colorDepth ColorDepth
+	// kitty and paste are set when the kitty keyboard protocol or bracketed paste mode were enabled
+	// by Init, and input then holds what was read but not yet converted.
+	kitty bool
+	paste bool
+	input []byte
+}
+
+// NewTermboxBackend returns the Backend used by Init, for Backends wrapping it.
+func NewTermboxBackend() Backend {
+	return &termboxBackend{}
+}
+
+func (self *termboxBackend) Init() error {
+	if err := tb.Init(); err != nil {
+		return err
+	}
+	tb.SetInputMode(tb.InputEsc | tb.InputMouse)
+	self.colorDepth = DetectColorDepth()
+	if self.colorDepth >= ColorDepth256 {
+		tb.SetOutputMode(tb.Output256)
+	} else {
+		tb.SetOutputMode(tb.OutputNormal)
+	}
+	self.kitty = KittyKeyboard && termboxRawInput
+	if self.kitty {
+		os.Stdout.WriteString(pushKittyKeyboard)
+	}
+	self.paste = BracketedPaste && termboxRawInput
+	if self.paste {
+		os.Stdout.WriteString(enableBracketedPaste)
+	}
+	return nil
+}
+
+func (self *termboxBackend) Close() {
+	if self.kitty {
+		os.Stdout.WriteString(popKittyKeyboard)
+	}
+	if self.paste {
+		os.Stdout.WriteString(disableBracketedPaste)
+	}
+	tb.Close()
+}
+
+func (self *termboxBackend) Size() (int, int) {
+	return tb.Size()
+}
+
+func (self *termboxBackend) SetCell(p image.Point, cell Cell) {
+	fg, bg := termboxAttributes(cell.Style)
+	tb.SetCell(p.X, p.Y, cell.Rune, fg, bg)
+}
+
+// Cell returns the Cell currently held in termbox's back buffer at the given point.
+func (self *termboxBackend) Cell(p image.Point) Cell {
+	width, height := tb.Size()
+	if p.X < 0 || p.Y < 0 || p.X >= width || p.Y >= height {
+		return CellClear
+	}
+	c := tb.CellBuffer()[p.Y*width+p.X]
+	modifiers := Modifier(ModifierBold | ModifierUnderline | ModifierReverse)
+	r := c.Ch
+	if r == 0 {
+		r = ' '
+	}
+	return Cell{
+		Rune: r,
+		Style: Style{
+			Fg:       Color(c.Fg&^tb.Attribute(modifiers)) - 1,
+			Bg:       Color(c.Bg&^tb.Attribute(modifiers)) - 1,
+			Modifier: Modifier(c.Fg) & modifiers,
+		},
+	}
+}
+
+func (self *termboxBackend) Clear(style Style) {
+	fg, bg := termboxAttributes(style)
+	tb.Clear(fg, bg)
+}
+
+func (self *termboxBackend) Flush() {
+	tb.Flush()
+}
+
+func (self *termboxBackend) Sync() {
+	tb.Sync()
+}
+
+func (self *termboxBackend) PollEvent() Event {
+	if self.kitty || self.paste {
+		return self.pollRawEvent()
+	}
+	return convertTermboxEvent(tb.PollEvent())
+}
+
+func (self *termboxBackend) ColorDepth() ColorDepth {
+	return self.colorDepth
+}
+
+func (self *termboxBackend) Graphics() GraphicsProtocol {
+	return DetectGraphics()
+}
+
+func (self *termboxBackend) SynchronizedOutput() bool {
+	return DetectSynchronizedOutput()
+}
+
+// termboxAttributes converts a Style to termbox attributes for the current TerminalColorDepth.
+func termboxAttributes(style Style) (tb.Attribute, tb.Attribute) {
+	style = style.Downgrade(TerminalColorDepth)
+	if TerminalColorDepth < ColorDepth256 {
+		// termbox's normal output mode only has the 8 basic colors, bright ones are drawn bold
+		if style.Fg >= 8 {
+			style.Fg -= 8
+			style.Modifier |= ModifierBold
+		}
+		if style.Bg >= 8 {
+			style.Bg -= 8
+		}
+	}
+	return tb.Attribute(style.Fg+1) | tb.Attribute(style.Modifier), tb.Attribute(style.Bg + 1)
+}
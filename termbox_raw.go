@@ -0,0 +1,56 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build !windows
+
+package termui
+
+import (
+	tb "github.com/nsf/termbox-go"
+)
+
+// termboxRawInput reports whether termbox-go hands over the raw input, which the kitty keyboard
+// protocol and bracketed paste need.
+const termboxRawInput = true
+
+// pollRawEvent reads the input itself to convert pastes and the keys sent with the kitty keyboard
+// protocol, and leaves the rest to termbox-go.
+func (self *termboxBackend) pollRawEvent() Event {
+	data := make([]byte, 256)
+	for {
+		for len(self.input) > 0 {
+			e, n, ok := Event{}, 0, false
+			if self.paste {
+				e, n, ok = ParsePaste(self.input)
+			}
+			if !ok && self.kitty {
+				e, n, ok = parseKittyInput(self.input)
+			}
+			if !ok {
+				parsed := tb.ParseEvent(self.input)
+				e, n = convertTermboxEvent(parsed), parsed.N
+				if n == 0 && len(self.input) >= 4 {
+					// not even a character, since they are up to 4 bytes
+					n = 1
+				}
+			}
+			if n == 0 {
+				// incomplete
+				break
+			}
+			self.input = self.input[n:]
+			if e.ID != "" {
+				return e
+			}
+		}
+
+		raw := tb.PollRawEvent(data)
+		switch raw.Type {
+		case tb.EventRaw:
+			self.input = append(self.input, data[:raw.N]...)
+		case tb.EventResize, tb.EventError:
+			return convertTermboxEvent(raw)
+		}
+	}
+}
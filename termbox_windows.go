@@ -0,0 +1,21 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build windows
+
+package termui
+
+import (
+	tb "github.com/nsf/termbox-go"
+)
+
+// termboxRawInput is false since termbox-go reads console input records on Windows, so the raw
+// input the kitty keyboard protocol and bracketed paste need isn't available. Neither is enabled,
+// and pastes are read as keyboard events.
+const termboxRawInput = false
+
+// pollRawEvent leaves the input to termbox-go.
+func (self *termboxBackend) pollRawEvent() Event {
+	return convertTermboxEvent(tb.PollEvent())
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuitest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
+)
+
+// UpdateGolden writes the golden files of AssertGolden instead of comparing them. It is set by
+// running the tests with the environment variable TERMUITEST_UPDATE set to 1.
+var UpdateGolden = os.Getenv("TERMUITEST_UPDATE") == "1"
+
+// GoldenDir is the directory of the golden files, relative to the package being tested.
+var GoldenDir = "testdata"
+
+// TestingT is the part of testing.TB used by AssertGolden.
+type TestingT interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// AssertGolden compares got with the golden file GoldenDir/name.golden, and fails the test with
+// a Diff of them when they differ.
+func AssertGolden(t TestingT, name string, got string) {
+	t.Helper()
+	path := filepath.Join(GoldenDir, name+".golden")
+	if UpdateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("creating the golden file directory: %v", err)
+			return
+		}
+		if err := ioutil.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("writing the golden file: %v", err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("no golden file %s, run the tests with TERMUITEST_UPDATE=1 to write it", path)
+		return
+	} else if err != nil {
+		t.Fatalf("reading the golden file: %v", err)
+		return
+	}
+	if diff := Diff(string(want), got); diff != "" {
+		t.Errorf("%s differs from the golden file %s:\n%s", name, path, diff)
+	}
+}
+
+// Diff returns the lines of want and got which differ, with the columns where they do marked, or
+// "" when they are the same:
+//
+//	line 2:
+//	- │    42%   │
+//	+ │    43%   │
+//	        ^
+func Diff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	wantLines := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	gotLines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	diff := strings.Builder{}
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		switch {
+		case i >= len(gotLines):
+			fmt.Fprintf(&diff, "line %d:\n- %s\n", i+1, wantLines[i])
+		case i >= len(wantLines):
+			fmt.Fprintf(&diff, "line %d:\n+ %s\n", i+1, gotLines[i])
+		case wantLines[i] != gotLines[i]:
+			fmt.Fprintf(&diff, "line %d:\n- %s\n+ %s\n  %s\n", i+1, wantLines[i], gotLines[i], diffMarks(wantLines[i], gotLines[i]))
+		}
+	}
+	if diff.Len() == 0 {
+		// only the final newlines differ
+		return fmt.Sprintf("want %q\n got %q\n", want, got)
+	}
+	return diff.String()
+}
+
+// diffMarks returns a line with a '^' under the columns where two lines differ.
+func diffMarks(a, b string) string {
+	ra, rb := []rune(a), []rune(b)
+	marks := strings.Builder{}
+	for i := 0; i < len(ra) || i < len(rb); i++ {
+		mark, width := " ", 1
+		switch {
+		case i >= len(ra):
+			mark, width = "^", rw.RuneWidth(rb[i])
+		case i >= len(rb):
+			mark, width = "^", rw.RuneWidth(ra[i])
+		default:
+			if ra[i] != rb[i] {
+				mark = "^"
+			}
+			width = rw.RuneWidth(rb[i])
+		}
+		marks.WriteString(mark + strings.Repeat(" ", width-1))
+	}
+	return strings.TrimRight(marks.String(), " ")
+}
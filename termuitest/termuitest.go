@@ -0,0 +1,155 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// Package termuitest renders widgets to text for tests, and compares them with golden files:
+//
+//	func TestGauge(t *testing.T) {
+//		gauge := widgets.NewGauge()
+//		gauge.Title = "CPU"
+//		gauge.Percent = 42
+//		termuitest.AssertGolden(t, "gauge", termuitest.RenderStyled(gauge, 12, 3))
+//	}
+//
+// Golden files are written by running the tests with TERMUITEST_UPDATE=1.
+package termuitest
+
+import (
+	"fmt"
+	"image"
+	"sort"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
+
+	ui "github.com/reaalkhalil/termui"
+)
+
+// styleKeys are the characters standing for the styles of a RenderStyled snapshot, in the order
+// they first appear. StyleClear is '.'.
+const styleKeys = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// RenderToString draws a widget sized width by height and returns its runes, a line for each row.
+func RenderToString(widget ui.Drawable, width, height int) string {
+	lines, _, _ := render(widget, width, height)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// RenderStyled draws a widget sized width by height like RenderToString, followed by the styles
+// of its cells, each drawn as a character, and what the characters stand for:
+//
+//	┌─CPU──────┐
+//	│    42%   │
+//	└──────────┘
+//	-- styles --
+//	aaaaaaaaaaaa
+//	abbbbaaa...a
+//	aaaaaaaaaaaa
+//	-- legend --
+//	a fg:white
+//	b bg:white
+//
+// so that changes of style show up in diffs like changes of text.
+func RenderStyled(widget ui.Drawable, width, height int) string {
+	lines, styles, legend := render(widget, width, height)
+	text := strings.Builder{}
+	for _, line := range lines {
+		text.WriteString(line + "\n")
+	}
+	text.WriteString("-- styles --\n")
+	for _, line := range styles {
+		text.WriteString(line + "\n")
+	}
+	text.WriteString("-- legend --\n")
+	for _, line := range legend {
+		text.WriteString(line + "\n")
+	}
+	return text.String()
+}
+
+// render returns the lines of runes and styles of a widget, and the legend of the styles.
+func render(widget ui.Drawable, width, height int) ([]string, []string, []string) {
+	widget.SetRect(0, 0, width, height)
+	buf := ui.RenderBuffer(widget)
+
+	keys := map[ui.Style]byte{ui.StyleClear: '.'}
+	legend := []string{}
+	lines, styles := []string{}, []string{}
+	for y := 0; y < height; y++ {
+		line, style := strings.Builder{}, strings.Builder{}
+		for x := 0; x < width; x++ {
+			cell := buf.GetCell(image.Pt(x, y))
+			key, ok := keys[cell.Style]
+			if !ok {
+				key = '?'
+				if len(legend) < len(styleKeys) {
+					key = styleKeys[len(legend)]
+					keys[cell.Style] = key
+					legend = append(legend, string(key)+" "+FormatStyle(cell.Style))
+				}
+			}
+			line.WriteRune(cell.Rune)
+			style.WriteByte(key)
+			// a wide rune covers the next cell
+			if w := rw.RuneWidth(cell.Rune); w > 1 && x+1 < width {
+				style.WriteByte(key)
+				x++
+			}
+		}
+		lines, styles = append(lines, line.String()), append(styles, style.String())
+	}
+	return lines, styles, legend
+}
+
+// FormatStyle formats a style like the style parser reads them, such as "fg:red,mod:bold", with
+// colors without a name as numbers or "#rrggbb". Clear colors and modifiers are left out.
+func FormatStyle(style ui.Style) string {
+	parts := []string{}
+	if style.Fg != ui.ColorClear {
+		parts = append(parts, "fg:"+formatColor(style.Fg))
+	}
+	if style.Bg != ui.ColorClear {
+		parts = append(parts, "bg:"+formatColor(style.Bg))
+	}
+	modifiers := []string{}
+	for _, modifier := range []struct {
+		name     string
+		modifier ui.Modifier
+	}{
+		{"bold", ui.ModifierBold},
+		{"underline", ui.ModifierUnderline},
+		{"reverse", ui.ModifierReverse},
+	} {
+		if style.Modifier&modifier.modifier != 0 {
+			modifiers = append(modifiers, modifier.name)
+		}
+	}
+	if len(modifiers) > 0 {
+		parts = append(parts, "mod:"+strings.Join(modifiers, "+"))
+	}
+	if len(parts) == 0 {
+		return "clear"
+	}
+	return strings.Join(parts, ",")
+}
+
+func formatColor(color ui.Color) string {
+	names := []string{}
+	for name, named := range ui.StyleParserColorMap {
+		if named == color {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		return names[0]
+	}
+	if color == ui.ColorTransparent {
+		return "transparent"
+	}
+	if color >= 0 && color < 256 {
+		return fmt.Sprint(int(color))
+	}
+	r, g, b := color.RGB()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
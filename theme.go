@@ -40,17 +40,66 @@ type RootTheme struct {
 	StackedBarChart StackedBarChartTheme
 	Tab             TabTheme
 	Table           TableTheme
+	TextInput       TextInputTheme
+	TextArea        TextAreaTheme
+	Form            FormTheme
+	Select          SelectTheme
+	Checkbox        CheckboxTheme
+	RadioGroup      RadioGroupTheme
+	Button          ButtonTheme
+	Slider          SliderTheme
+	Calendar        CalendarTheme
+	ColorPicker     ColorPickerTheme
+	CommandPalette  CommandPaletteTheme
+	Menu            MenuTheme
+	StatusBar       StatusBarTheme
+	Toast           ToastTheme
+	Help            HelpTheme
+	Progress        ProgressTheme
+	Spinner         SpinnerTheme
+	FileBrowser     FileBrowserTheme
+	LogView         LogViewTheme
+	Breadcrumbs     BreadcrumbsTheme
+	NumberInput     NumberInputTheme
+	TagInput        TagInputTheme
+	Wizard          WizardTheme
+	SearchBar       SearchBarTheme
+	Dialog          DialogTheme
+	CalendarHeatmap CalendarHeatmapTheme
+	Treemap         TreemapTheme
+	Gantt           GanttTheme
+	Graph           GraphTheme
+	BoxPlot         BoxPlotTheme
+	RadarChart      RadarChartTheme
+	MarkdownViewer  MarkdownViewerTheme
+	DiffView        DiffViewTheme
+	Inspector       InspectorTheme
+	HexView         HexViewTheme
+	Minimap         MinimapTheme
+	QRCode          QRCodeTheme
+	BigText         BigTextTheme
+	Clock           ClockTheme
+	Dial            DialTheme
+	LEDDisplay      LEDDisplayTheme
+	HeatGrid        HeatGridTheme
+	Spectrogram     SpectrogramTheme
+	DragDrop        DragDropTheme
 }
 
 type BlockTheme struct {
-	Title  Style
-	Border Style
+	Title     Style
+	Border    Style
+	BorderSet BorderSet
+	Badge     Style
+	Shadow    Style
+	Watermark Style
 }
 
 type BarChartTheme struct {
-	Bars   []Color
-	Nums   []Style
-	Labels []Style
+	Bars     []Color
+	Nums     []Style
+	Labels   []Style
+	Baseline Style
 }
 
 type GaugeTheme struct {
@@ -64,81 +113,479 @@ type PlotTheme struct {
 }
 
 type ListTheme struct {
-	Text Style
+	Text      Style
+	Match     Style
+	Checked   rune
+	Unchecked rune
+	Section   Style
+	Secondary Style
+	Collapsed rune
+	Expanded  rune
+	Scrollbar Style
 }
 
 type TreeTheme struct {
 	Text      Style
 	Collapsed rune
 	Expanded  rune
+	Checked   rune
+	Unchecked rune
+	Partial   rune
+	Match     Style
+	Scrollbar Style
 }
 
 type ParagraphTheme struct {
-	Text Style
+	Text        Style
+	Scrollbar   Style
+	Selection   Style
+	Link        Style
+	FocusedLink Style
+	Markdown    MarkdownTheme
+	Syntax      SyntaxTheme
+}
+
+type SyntaxTheme struct {
+	Keyword Style
+	Type    Style
+	String  Style
+	Number  Style
+	Comment Style
+}
+
+type MarkdownTheme struct {
+	Heading  Style
+	Strong   Style
+	Emphasis Style
+	Code     Style
+	Quote    Style
+	Bullet   Style
+	Link     Style
 }
 
 type PieChartTheme struct {
-	Slices []Color
+	Slices     []Color
+	CenterText Style
+	Legend     Style
 }
 
 type SparklineTheme struct {
-	Title Style
-	Line  Color
+	Title    Style
+	Line     Color
+	Values   Style
+	Baseline Style
+	Current  Style
 }
 
 type StackedBarChartTheme struct {
-	Bars   []Color
-	Nums   []Style
-	Labels []Style
+	Bars     []Color
+	Nums     []Style
+	Labels   []Style
+	Baseline Style
 }
 
 type TabTheme struct {
 	Active   Style
 	Inactive Style
+	Close    rune
 }
 
 type TableTheme struct {
+	Text         Style
+	Header       Style
+	SelectedRow  Style
+	SelectedCell Style
+	MarkedRow    Style
+	Match        Style
+	Scrollbar    Style
+}
+
+type TextInputTheme struct {
+	Text        Style
+	Placeholder Style
+	Mask        rune
+}
+
+type TextAreaTheme struct {
+	Text      Style
+	Selection Style
+	Scrollbar Style
+}
+
+type FormTheme struct {
+	Label        Style
+	FocusedLabel Style
+	Error        Style
+}
+
+type SelectTheme struct {
+	Text        Style
+	Focused     Style
+	Highlighted Style
+}
+
+type CheckboxTheme struct {
+	Text      Style
+	Focused   Style
+	Checked   rune
+	Unchecked rune
+	Partial   rune
+}
+
+type RadioGroupTheme struct {
+	Text       Style
+	Focused    Style
+	Selected   rune
+	Unselected rune
+}
+
+type ButtonTheme struct {
+	Text    Style
+	Focused Style
+	Pressed Style
+}
+
+type SliderTheme struct {
+	Track      Style
+	Filled     Style
+	Handle     Style
+	Focused    Style
+	Tick       Style
+	Value      Style
+	HandleRune rune
+	TickRune   rune
+}
+
+type CalendarTheme struct {
+	Text        Style
+	Header      Style
+	Weekday     Style
+	Today       Style
+	Highlighted Style
+	Selected    Style
+	Cursor      Style
+}
+
+type ColorPickerTheme struct {
+	Text Style
+}
+
+type CommandPaletteTheme struct {
+	Text      Style
+	Key       Style
+	Match     Style
+	Highlight Style
+}
+
+type MenuTheme struct {
+	Bar         Style
+	Border      Style
+	Item        Style
+	Selected    Style
+	Disabled    Style
+	Shortcut    Style
+	SubmenuRune rune
+}
+
+type StatusBarTheme struct {
+	Text  Style
+	Mode  Style
+	Flash Style
+}
+
+type ToastTheme struct {
+	Text    Style
+	Info    Style
+	Success Style
+	Warning Style
+	Error   Style
+}
+
+type HelpTheme struct {
+	Scope Style
+	Key   Style
+	Text  Style
+}
+
+type ProgressTheme struct {
+	Label    Style
+	Bar      Style
+	Empty    Style
+	Finished Style
+	Error    Style
+	Stats    Style
+}
+
+type SpinnerTheme struct {
+	Spinner Style
+	Text    Style
+}
+
+type LogViewTheme struct {
+	Text    Style
+	Time    Style
+	Field   Style
+	Match   Style
+	Debug   Style
+	Info    Style
+	Warning Style
+	Error   Style
+}
+
+type BreadcrumbsTheme struct {
+	Text      Style
+	Current   Style
+	Separator Style
+	Focused   Style
+}
+
+type NumberInputTheme struct {
+	Button        Style
+	Error         Style
+	DecrementRune rune
+	IncrementRune rune
+}
+
+type TagInputTheme struct {
+	Chip  Style
+	Close rune
+}
+
+type WizardTheme struct {
+	Text    Style
+	Current Style
+	Done    Style
+	Error   Style
+	Button  Style
+}
+
+type SearchBarTheme struct {
+	Count Style
+}
+
+type DialogTheme struct {
+	Text   Style
+	Border Style
+}
+
+type CalendarHeatmapTheme struct {
+	Colors []Color
+	Rune   rune
+	Text   Style
+	Cursor Style
+}
+
+type TreemapTheme struct {
+	Colors   []Color
+	Text     Style
+	Selected Style
+}
+
+type GanttTheme struct {
+	Bars       []Color
+	Text       Style
+	Selected   Style
+	Axis       Style
+	Today      Style
+	Dependency Style
+}
+
+type GraphTheme struct {
+	Edge         Color
+	SelectedEdge Color
+	Node         Style
+	Selected     Style
+}
+
+type BoxPlotTheme struct {
+	Boxes    []Color
+	Median   Color
+	Labels   Style
+	Whiskers Style
+	Axis     Style
+}
+
+type RadarChartTheme struct {
+	Lines  []Color
+	Axes   Color
+	Labels Style
+}
+
+type MarkdownViewerTheme struct {
+	Text        Style
+	TOC         Style
+	TOCCurrent  Style
+	CodeBlock   Style
+	FocusedLink Style
+	Scrollbar   Style
+}
+
+type DiffViewTheme struct {
+	Text             Style
+	Removed          Style
+	Added            Style
+	RemovedHighlight Style
+	AddedHighlight   Style
+	LineNumber       Style
+	Hunk             Style
+	Title            Style
+	Scrollbar        Style
+}
+
+type InspectorTheme struct {
+	Key      Style
+	String   Style
+	Number   Style
+	Bool     Style
+	Null     Style
+	Size     Style
+	Selected Style
+}
+
+type HexViewTheme struct {
+	Offset    Style
+	Text      Style
+	Cursor    Style
+	Selection Style
+	Match     Style
+}
+
+type MinimapTheme struct {
+	Text     Style
+	Viewport Style
+}
+
+type QRCodeTheme struct {
+	Dark  Color
+	Light Color
+	Text  Style
+}
+
+type BigTextTheme struct {
 	Text Style
 }
 
+type ClockTheme struct {
+	Text       Style
+	Face       Color
+	Hand       Color
+	SecondHand Color
+}
+
+type DialTheme struct {
+	Arc    Color
+	Needle Color
+	Value  Style
+	Label  Style
+}
+
+type LEDDisplayTheme struct {
+	On  Color
+	Off Color
+}
+
+type HeatGridTheme struct {
+	Colors []Color
+	Text   Style
+	Cursor Style
+}
+
+type SpectrogramTheme struct {
+	Colors []Color
+	Dot    Color
+	Text   Style
+}
+
+type DragDropTheme struct {
+	Ghost Style
+}
+
+type FileBrowserTheme struct {
+	File          Style
+	Directory     Style
+	FileRune      rune
+	DirectoryRune rune
+}
+
 // Theme holds the default Styles and Colors for all widgets.
 // You can set default widget Styles by modifying the Theme before creating the widgets.
 var Theme = RootTheme{
 	Default: NewStyle(ColorWhite),
 
 	Block: BlockTheme{
-		Title:  NewStyle(ColorWhite),
-		Border: NewStyle(ColorWhite),
+		Title:     NewStyle(ColorWhite),
+		Border:    NewStyle(ColorWhite),
+		BorderSet: BorderSingle,
+		Badge:     NewStyle(ColorYellow),
+		Shadow:    NewStyle(Color(8), ColorBlack),
+		Watermark: NewStyle(Color(8)),
 	},
 
 	BarChart: BarChartTheme{
-		Bars:   StandardColors,
-		Nums:   StandardStyles,
-		Labels: StandardStyles,
+		Bars:     StandardColors,
+		Nums:     StandardStyles,
+		Labels:   StandardStyles,
+		Baseline: NewStyle(ColorWhite),
 	},
 
 	Paragraph: ParagraphTheme{
-		Text: NewStyle(ColorWhite),
+		Text:        NewStyle(ColorWhite),
+		Scrollbar:   NewStyle(ColorWhite),
+		Selection:   NewStyle(ColorBlack, ColorWhite),
+		Link:        NewStyle(ColorBlue, ColorClear, ModifierUnderline),
+		FocusedLink: NewStyle(ColorBlue, ColorClear, ModifierUnderline|ModifierReverse),
+		Markdown: MarkdownTheme{
+			Heading:  NewStyle(ColorYellow, ColorClear, ModifierBold),
+			Strong:   NewStyle(ColorWhite, ColorClear, ModifierBold),
+			Emphasis: NewStyle(ColorWhite, ColorClear, ModifierUnderline),
+			Code:     NewStyle(ColorCyan),
+			Quote:    NewStyle(ColorGreen),
+			Bullet:   NewStyle(ColorYellow),
+			Link:     NewStyle(ColorBlue, ColorClear, ModifierUnderline),
+		},
+		Syntax: SyntaxTheme{
+			Keyword: NewStyle(ColorMagenta, ColorClear, ModifierBold),
+			Type:    NewStyle(ColorCyan),
+			String:  NewStyle(ColorGreen),
+			Number:  NewStyle(ColorYellow),
+			Comment: NewStyle(ColorBlue),
+		},
 	},
 
 	PieChart: PieChartTheme{
-		Slices: StandardColors,
+		Slices:     StandardColors,
+		CenterText: NewStyle(ColorWhite, ColorClear, ModifierBold),
+		Legend:     NewStyle(ColorWhite),
 	},
 
 	List: ListTheme{
-		Text: NewStyle(ColorWhite),
+		Text:      NewStyle(ColorWhite),
+		Match:     NewStyle(ColorBlack, ColorYellow),
+		Checked:   CHECKED,
+		Unchecked: UNCHECKED,
+		Section:   NewStyle(ColorCyan, ColorClear, ModifierBold),
+		Secondary: NewStyle(ColorCyan),
+		Collapsed: COLLAPSED,
+		Expanded:  EXPANDED,
+		Scrollbar: NewStyle(ColorWhite),
 	},
 
 	Tree: TreeTheme{
 		Text:      NewStyle(ColorWhite),
 		Collapsed: COLLAPSED,
 		Expanded:  EXPANDED,
+		Checked:   CHECKED,
+		Unchecked: UNCHECKED,
+		Partial:   PARTIALLY_CHECKED,
+		Match:     NewStyle(ColorBlack, ColorYellow),
+		Scrollbar: NewStyle(ColorWhite),
 	},
 
 	StackedBarChart: StackedBarChartTheme{
-		Bars:   StandardColors,
-		Nums:   StandardStyles,
-		Labels: StandardStyles,
+		Bars:     StandardColors,
+		Nums:     StandardStyles,
+		Labels:   StandardStyles,
+		Baseline: NewStyle(ColorWhite),
 	},
 
 	Gauge: GaugeTheme{
@@ -147,8 +594,11 @@ var Theme = RootTheme{
 	},
 
 	Sparkline: SparklineTheme{
-		Title: NewStyle(ColorWhite),
-		Line:  ColorWhite,
+		Title:    NewStyle(ColorWhite),
+		Line:     ColorWhite,
+		Values:   NewStyle(ColorCyan),
+		Baseline: NewStyle(ColorBlue),
+		Current:  NewStyle(ColorWhite, ColorClear, ModifierBold),
 	},
 
 	Plot: PlotTheme{
@@ -157,11 +607,342 @@ var Theme = RootTheme{
 	},
 
 	Table: TableTheme{
-		Text: NewStyle(ColorWhite),
+		Text:         NewStyle(ColorWhite),
+		Header:       NewStyle(ColorWhite, ColorClear, ModifierBold),
+		SelectedRow:  NewStyle(ColorWhite, ColorClear, ModifierReverse),
+		SelectedCell: NewStyle(ColorBlack, ColorYellow),
+		MarkedRow:    NewStyle(ColorYellow),
+		Match:        NewStyle(ColorBlack, ColorYellow),
+		Scrollbar:    NewStyle(ColorWhite),
 	},
 
 	Tab: TabTheme{
 		Active:   NewStyle(ColorRed),
 		Inactive: NewStyle(ColorWhite),
+		Close:    CLOSE,
+	},
+
+	TextInput: TextInputTheme{
+		Text:        NewStyle(ColorWhite),
+		Placeholder: NewStyle(ColorBlue),
+		Mask:        DOT,
+	},
+
+	TextArea: TextAreaTheme{
+		Text:      NewStyle(ColorWhite),
+		Selection: NewStyle(ColorBlack, ColorWhite),
+		Scrollbar: NewStyle(ColorWhite),
+	},
+
+	Form: FormTheme{
+		Label:        NewStyle(ColorWhite),
+		FocusedLabel: NewStyle(ColorYellow, ColorClear, ModifierBold),
+		Error:        NewStyle(ColorRed),
+	},
+
+	Select: SelectTheme{
+		Text:        NewStyle(ColorWhite),
+		Focused:     NewStyle(ColorYellow),
+		Highlighted: NewStyle(ColorBlack, ColorYellow),
+	},
+
+	Checkbox: CheckboxTheme{
+		Text:      NewStyle(ColorWhite),
+		Focused:   NewStyle(ColorYellow),
+		Checked:   CHECKED,
+		Unchecked: UNCHECKED,
+		Partial:   PARTIALLY_CHECKED,
+	},
+
+	RadioGroup: RadioGroupTheme{
+		Text:       NewStyle(ColorWhite),
+		Focused:    NewStyle(ColorYellow),
+		Selected:   SELECTED,
+		Unselected: UNSELECTED,
+	},
+
+	Button: ButtonTheme{
+		Text:    NewStyle(ColorWhite),
+		Focused: NewStyle(ColorYellow, ColorClear, ModifierBold),
+		Pressed: NewStyle(ColorBlack, ColorYellow),
+	},
+
+	Slider: SliderTheme{
+		Track:      NewStyle(ColorWhite),
+		Filled:     NewStyle(ColorCyan),
+		Handle:     NewStyle(ColorWhite),
+		Focused:    NewStyle(ColorYellow),
+		Tick:       NewStyle(ColorBlue),
+		Value:      NewStyle(ColorWhite),
+		HandleRune: SLIDER_HANDLE,
+		TickRune:   TICK,
+	},
+
+	Calendar: CalendarTheme{
+		Text:        NewStyle(ColorWhite),
+		Header:      NewStyle(ColorWhite, ColorClear, ModifierBold),
+		Weekday:     NewStyle(ColorBlue),
+		Today:       NewStyle(ColorWhite, ColorClear, ModifierUnderline),
+		Highlighted: NewStyle(ColorMagenta, ColorClear, ModifierBold),
+		Selected:    NewStyle(ColorBlack, ColorCyan),
+		Cursor:      NewStyle(ColorBlack, ColorYellow),
+	},
+
+	ColorPicker: ColorPickerTheme{
+		Text: NewStyle(ColorWhite),
+	},
+
+	CommandPalette: CommandPaletteTheme{
+		Text:      NewStyle(ColorWhite),
+		Key:       NewStyle(ColorBlue),
+		Match:     NewStyle(ColorYellow, ColorClear, ModifierBold),
+		Highlight: NewStyle(ColorBlack, ColorWhite),
+	},
+
+	Menu: MenuTheme{
+		Bar:         NewStyle(ColorBlack, ColorWhite),
+		Border:      NewStyle(ColorWhite),
+		Item:        NewStyle(ColorWhite),
+		Selected:    NewStyle(ColorBlack, ColorCyan),
+		Disabled:    NewStyle(ColorBlue),
+		Shortcut:    NewStyle(ColorBlue),
+		SubmenuRune: SUBMENU,
+	},
+
+	StatusBar: StatusBarTheme{
+		Text:  NewStyle(ColorBlack, ColorWhite),
+		Mode:  NewStyle(ColorBlack, ColorCyan, ModifierBold),
+		Flash: NewStyle(ColorBlack, ColorYellow),
+	},
+
+	Toast: ToastTheme{
+		Text:    NewStyle(ColorWhite),
+		Info:    NewStyle(ColorBlue, ColorClear, ModifierBold),
+		Success: NewStyle(ColorGreen, ColorClear, ModifierBold),
+		Warning: NewStyle(ColorYellow, ColorClear, ModifierBold),
+		Error:   NewStyle(ColorRed, ColorClear, ModifierBold),
+	},
+
+	Help: HelpTheme{
+		Scope: NewStyle(ColorYellow, ColorClear, ModifierBold),
+		Key:   NewStyle(ColorCyan),
+		Text:  NewStyle(ColorWhite),
+	},
+
+	Progress: ProgressTheme{
+		Label:    NewStyle(ColorWhite),
+		Bar:      NewStyle(ColorCyan),
+		Empty:    NewStyle(ColorBlue),
+		Finished: NewStyle(ColorGreen),
+		Error:    NewStyle(ColorRed),
+		Stats:    NewStyle(ColorWhite),
+	},
+
+	Spinner: SpinnerTheme{
+		Spinner: NewStyle(ColorCyan),
+		Text:    NewStyle(ColorWhite),
+	},
+
+	FileBrowser: FileBrowserTheme{
+		File:          NewStyle(ColorWhite),
+		Directory:     NewStyle(ColorBlue, ColorClear, ModifierBold),
+		FileRune:      FILE,
+		DirectoryRune: DIRECTORY,
+	},
+
+	LogView: LogViewTheme{
+		Text:    NewStyle(ColorWhite),
+		Time:    NewStyle(ColorBlue),
+		Field:   NewStyle(ColorCyan),
+		Match:   NewStyle(ColorBlack, ColorYellow),
+		Debug:   NewStyle(ColorMagenta),
+		Info:    NewStyle(ColorGreen),
+		Warning: NewStyle(ColorYellow, ColorClear, ModifierBold),
+		Error:   NewStyle(ColorRed, ColorClear, ModifierBold),
+	},
+
+	Breadcrumbs: BreadcrumbsTheme{
+		Text:      NewStyle(ColorWhite),
+		Current:   NewStyle(ColorCyan, ColorClear, ModifierBold),
+		Separator: NewStyle(ColorBlue),
+		Focused:   NewStyle(ColorBlack, ColorYellow),
+	},
+
+	NumberInput: NumberInputTheme{
+		Button:        NewStyle(ColorCyan, ColorClear, ModifierBold),
+		Error:         NewStyle(ColorRed),
+		DecrementRune: DECREMENT,
+		IncrementRune: INCREMENT,
+	},
+
+	TagInput: TagInputTheme{
+		Chip:  NewStyle(ColorBlack, ColorCyan),
+		Close: CLOSE,
+	},
+
+	Wizard: WizardTheme{
+		Text:    NewStyle(ColorWhite),
+		Current: NewStyle(ColorCyan, ColorClear, ModifierBold),
+		Done:    NewStyle(ColorGreen),
+		Error:   NewStyle(ColorRed),
+		Button:  NewStyle(ColorBlack, ColorWhite),
+	},
+
+	SearchBar: SearchBarTheme{
+		Count: NewStyle(ColorBlue),
+	},
+
+	Dialog: DialogTheme{
+		Text:   NewStyle(ColorWhite),
+		Border: NewStyle(ColorCyan),
+	},
+
+	CalendarHeatmap: CalendarHeatmapTheme{
+		Colors: []Color{
+			NewRGBColor(0x2d, 0x33, 0x3b),
+			NewRGBColor(0x0e, 0x44, 0x29),
+			NewRGBColor(0x00, 0x6d, 0x32),
+			NewRGBColor(0x26, 0xa6, 0x41),
+			NewRGBColor(0x39, 0xd3, 0x53),
+		},
+		Rune:   '■',
+		Text:   NewStyle(ColorWhite),
+		Cursor: NewStyle(ColorClear, ColorWhite),
+	},
+
+	Treemap: TreemapTheme{
+		Colors:   []Color{ColorBlue, ColorGreen, ColorYellow, ColorMagenta, ColorCyan, ColorRed},
+		Text:     NewStyle(ColorBlack),
+		Selected: NewStyle(ColorWhite, ColorBlack, ModifierBold),
+	},
+
+	Gantt: GanttTheme{
+		Bars:       StandardColors,
+		Text:       NewStyle(ColorWhite),
+		Selected:   NewStyle(ColorBlack, ColorCyan),
+		Axis:       NewStyle(ColorBlue),
+		Today:      NewStyle(ColorRed),
+		Dependency: NewStyle(ColorWhite),
+	},
+
+	Graph: GraphTheme{
+		Edge:         ColorBlue,
+		SelectedEdge: ColorYellow,
+		Node:         NewStyle(ColorWhite),
+		Selected:     NewStyle(ColorBlack, ColorYellow),
+	},
+
+	BoxPlot: BoxPlotTheme{
+		Boxes:    StandardColors,
+		Median:   ColorBlack,
+		Labels:   NewStyle(ColorWhite),
+		Whiskers: NewStyle(ColorWhite),
+		Axis:     NewStyle(ColorBlue),
+	},
+
+	RadarChart: RadarChartTheme{
+		Lines:  StandardColors,
+		Axes:   ColorBlue,
+		Labels: NewStyle(ColorWhite),
+	},
+
+	MarkdownViewer: MarkdownViewerTheme{
+		Text:        NewStyle(ColorWhite),
+		TOC:         NewStyle(ColorWhite),
+		TOCCurrent:  NewStyle(ColorBlack, ColorYellow),
+		CodeBlock:   NewStyle(ColorClear, NewRGBColor(0x30, 0x30, 0x30)),
+		FocusedLink: NewStyle(ColorBlue, ColorClear, ModifierUnderline|ModifierReverse),
+		Scrollbar:   NewStyle(ColorWhite),
+	},
+
+	DiffView: DiffViewTheme{
+		Text:             NewStyle(ColorWhite),
+		Removed:          NewStyle(ColorRed),
+		Added:            NewStyle(ColorGreen),
+		RemovedHighlight: NewStyle(ColorBlack, ColorRed),
+		AddedHighlight:   NewStyle(ColorBlack, ColorGreen),
+		LineNumber:       NewStyle(ColorBlue),
+		Hunk:             NewStyle(ColorCyan),
+		Title:            NewStyle(ColorWhite, ColorClear, ModifierBold),
+		Scrollbar:        NewStyle(ColorWhite),
+	},
+
+	Inspector: InspectorTheme{
+		Key:      NewStyle(ColorCyan),
+		String:   NewStyle(ColorGreen),
+		Number:   NewStyle(ColorYellow),
+		Bool:     NewStyle(ColorMagenta),
+		Null:     NewStyle(ColorRed),
+		Size:     NewStyle(ColorWhite),
+		Selected: NewStyle(ColorClear, ColorClear, ModifierReverse),
+	},
+
+	HexView: HexViewTheme{
+		Offset:    NewStyle(ColorBlue),
+		Text:      NewStyle(ColorWhite),
+		Cursor:    NewStyle(ColorBlack, ColorYellow),
+		Selection: NewStyle(ColorClear, ColorClear, ModifierReverse),
+		Match:     NewStyle(ColorBlack, ColorGreen),
+	},
+
+	Minimap: MinimapTheme{
+		Text:     NewStyle(ColorWhite),
+		Viewport: NewStyle(ColorClear, NewRGBColor(0x3a, 0x3a, 0x3a)),
+	},
+
+	QRCode: QRCodeTheme{
+		Dark:  ColorBlack,
+		Light: ColorWhite,
+		Text:  NewStyle(ColorWhite),
+	},
+
+	BigText: BigTextTheme{
+		Text: NewStyle(ColorWhite),
+	},
+
+	Clock: ClockTheme{
+		Text:       NewStyle(ColorWhite),
+		Face:       ColorBlue,
+		Hand:       ColorWhite,
+		SecondHand: ColorRed,
+	},
+
+	Dial: DialTheme{
+		Arc:    ColorBlue,
+		Needle: ColorWhite,
+		Value:  NewStyle(ColorWhite, ColorClear, ModifierBold),
+		Label:  NewStyle(ColorWhite),
+	},
+
+	LEDDisplay: LEDDisplayTheme{
+		On:  ColorRed,
+		Off: NewRGBColor(0x3a, 0x10, 0x10),
+	},
+
+	HeatGrid: HeatGridTheme{
+		Colors: []Color{
+			NewRGBColor(0x1a, 0x98, 0x50),
+			NewRGBColor(0xfe, 0xe0, 0x8b),
+			NewRGBColor(0xd7, 0x30, 0x27),
+		},
+		Text:   NewStyle(ColorWhite),
+		Cursor: NewStyle(ColorBlack, ColorWhite),
+	},
+
+	Spectrogram: SpectrogramTheme{
+		Colors: []Color{
+			NewRGBColor(0x00, 0x00, 0x04),
+			NewRGBColor(0x42, 0x0a, 0x68),
+			NewRGBColor(0x93, 0x26, 0x67),
+			NewRGBColor(0xdd, 0x51, 0x3a),
+			NewRGBColor(0xfc, 0xa5, 0x0a),
+			NewRGBColor(0xfc, 0xff, 0xa4),
+		},
+		Dot:  ColorGreen,
+		Text: NewStyle(ColorWhite),
+	},
+
+	DragDrop: DragDropTheme{
+		Ghost: NewStyle(ColorBlack, ColorYellow),
 	},
 }
@@ -8,9 +8,10 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strings"
+	"unicode"
 
 	rw "github.com/mattn/go-runewidth"
-	"github.com/mitchellh/go-wordwrap"
 )
 
 // InterfaceSlice takes an []interface{} represented as an interface{} and converts it
@@ -171,7 +172,7 @@ func MinInt(x, y int) int {
 // WrapCells takes []Cell and inserts Cells containing '\n' wherever a linebreak should go.
 func WrapCells(cells []Cell, width uint) []Cell {
 	str := CellsToString(cells)
-	wrapped := wordwrap.WrapString(str, width)
+	wrapped := WrapString(str, width)
 	wrappedCells := []Cell{}
 	i := 0
 	for _, _rune := range wrapped {
@@ -185,10 +186,71 @@ func WrapCells(cells []Cell, width uint) []Cell {
 	return wrappedCells
 }
 
+// WrapString breaks s into lines of at most width columns at spaces, dropping the spaces a line is
+// broken at. Words wider than width are left whole. Widths are counted in columns, so lines of
+// Hebrew, Arabic, or CJK text and emoji fill the width like others.
+func WrapString(s string, width uint) string {
+	var wrapped, word, space strings.Builder
+	lineWidth, wordWidth, spaceWidth := 0, 0, 0
+	lim := int(width)
+	flushSpace := func() {
+		wrapped.WriteString(space.String())
+		lineWidth += spaceWidth
+		space.Reset()
+		spaceWidth = 0
+	}
+	flushWord := func() {
+		wrapped.WriteString(word.String())
+		lineWidth += wordWidth
+		word.Reset()
+		wordWidth = 0
+	}
+
+	for _, r := range s {
+		switch {
+		case r == '\n':
+			if word.Len() == 0 && lineWidth+spaceWidth > lim {
+				space.Reset()
+				spaceWidth = 0
+			}
+			flushSpace()
+			flushWord()
+			wrapped.WriteRune(r)
+			lineWidth = 0
+		case unicode.IsSpace(r):
+			if space.Len() == 0 || word.Len() > 0 {
+				flushSpace()
+				flushWord()
+			}
+			space.WriteRune(r)
+			spaceWidth += MaxInt(rw.RuneWidth(r), 1)
+		default:
+			word.WriteRune(r)
+			wordWidth += rw.RuneWidth(r)
+			if lineWidth+spaceWidth+wordWidth > lim && wordWidth < lim {
+				wrapped.WriteRune('\n')
+				lineWidth = 0
+				space.Reset()
+				spaceWidth = 0
+			}
+		}
+	}
+
+	if word.Len() == 0 {
+		if lineWidth+spaceWidth <= lim {
+			flushSpace()
+		}
+	} else {
+		flushSpace()
+		flushWord()
+	}
+	return wrapped.String()
+}
+
 func RunesToStyledCells(runes []rune, style Style) []Cell {
-	cells := []Cell{}
-	for _, _rune := range runes {
-		cells = append(cells, Cell{_rune, style})
+	cells := make([]Cell, len(runes))
+	for i, _rune := range runes {
+		cells[i] = Cell{_rune, style}
 	}
 	return cells
 }
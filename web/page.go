@@ -0,0 +1,74 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package web
+
+import (
+	"html/template"
+)
+
+// page shows the screen with the terminal of terminalScript, or xterm.js and its fit addon when
+// AssetsURL is set, connecting to the socket next to it.
+// Messages from the page are JSON: {"type": "input", "data": ...} with the input of the terminal
+// and {"type": "resize", "cols": ..., "rows": ...} with its size.
+var page = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+{{if .AssetsURL}}
+<link rel="stylesheet" href="{{.AssetsURL}}/xterm@5.3.0/css/xterm.css">
+<script src="{{.AssetsURL}}/xterm@5.3.0/lib/xterm.js"></script>
+<script src="{{.AssetsURL}}/xterm-addon-fit@0.8.0/lib/xterm-addon-fit.js"></script>
+{{else}}
+<script src="terminal.js"></script>
+{{end}}
+<style>
+html, body { margin: 0; height: 100%; background: #000; overflow: hidden; }
+#terminal { height: 100%; }
+</style>
+</head>
+<body>
+<div id="terminal"></div>
+<script>
+{{if .AssetsURL}}
+var term = new Terminal({fontFamily: {{.FontFamily}}});
+var fit = new FitAddon.FitAddon();
+term.loadAddon(fit);
+{{else}}
+var term = new TermuiTerminal({fontFamily: {{.FontFamily}}});
+var fit = term;
+{{end}}
+term.open(document.getElementById("terminal"));
+
+var socket = new WebSocket(location.href.replace(/^http/, "ws").replace(/[?#].*$/, "").replace(/[^\/]*$/, "") + "socket");
+function send(message) {
+	if (socket.readyState === WebSocket.OPEN) {
+		socket.send(JSON.stringify(message));
+	}
+}
+socket.onopen = function() {
+	fit.fit();
+	send({type: "resize", cols: term.cols, rows: term.rows});
+};
+socket.onmessage = function(e) {
+	term.write(e.data);
+};
+socket.onclose = function() {
+	term.write("\x1b[0m\r\n[disconnected]");
+};
+term.onData(function(data) {
+	send({type: "input", data: data});
+});
+term.onResize(function(size) {
+	send({type: "resize", cols: size.cols, rows: size.rows});
+});
+window.addEventListener("resize", function() {
+	fit.fit();
+});
+term.focus();
+</script>
+</body>
+</html>
+`))
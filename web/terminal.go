@@ -0,0 +1,428 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package web
+
+// terminalScript is the terminal the page shows the screen with when AssetsURL is empty, served
+// by the Backend as "terminal.js" next to the page. It draws what FormatANSI sends, cursor moves,
+// clears, and colors, and sends keys, pastes, and SGR mouse reports like a terminal, with the same
+// methods as the ones of xterm.js the page uses.
+const terminalScript = `(function() {
+"use strict";
+
+function rgb(r, g, b) {
+	return "rgb(" + r + "," + g + "," + b + ")";
+}
+
+// the 16 colors of xterm, the 6x6x6 color cube, and the grays
+var palette = [
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff"
+];
+var levels = [0, 95, 135, 175, 215, 255];
+for (var n = 0; n < 216; n++) {
+	palette.push(rgb(levels[Math.floor(n / 36)], levels[Math.floor(n / 6) % 6], levels[n % 6]));
+}
+for (var n = 0; n < 24; n++) {
+	palette.push(rgb(8 + n * 10, 8 + n * 10, 8 + n * 10));
+}
+
+var defaultStyle = {fg: null, bg: null, bold: false, underline: false, reverse: false};
+
+// wide reports whether a code point takes two cells, for the CJK ranges and emoji.
+function wide(code) {
+	return (code >= 0x1100 && code <= 0x115f) || (code >= 0x2e80 && code <= 0xa4cf) ||
+		(code >= 0xac00 && code <= 0xd7a3) || (code >= 0xf900 && code <= 0xfaff) ||
+		(code >= 0xfe30 && code <= 0xfe4f) || (code >= 0xff00 && code <= 0xff60) ||
+		(code >= 0xffe0 && code <= 0xffe6) || (code >= 0x1f300 && code <= 0x1f64f) ||
+		(code >= 0x1f900 && code <= 0x1f9ff) || (code >= 0x20000 && code <= 0x3fffd);
+}
+
+var keys = {
+	Enter: "\r", Backspace: "\x7f", Tab: "\t", Escape: "\x1b",
+	ArrowUp: "\x1b[A", ArrowDown: "\x1b[B", ArrowRight: "\x1b[C", ArrowLeft: "\x1b[D",
+	Home: "\x1b[H", End: "\x1b[F", Insert: "\x1b[2~", Delete: "\x1b[3~",
+	PageUp: "\x1b[5~", PageDown: "\x1b[6~",
+	F1: "\x1bOP", F2: "\x1bOQ", F3: "\x1bOR", F4: "\x1bOS",
+	F5: "\x1b[15~", F6: "\x1b[17~", F7: "\x1b[18~", F8: "\x1b[19~",
+	F9: "\x1b[20~", F10: "\x1b[21~", F11: "\x1b[23~", F12: "\x1b[24~"
+};
+
+function TermuiTerminal(options) {
+	this.fontFamily = (options && options.fontFamily) || "monospace";
+	this.cols = 0;
+	this.rows = 0;
+	this.cells = [];
+	this.dirty = [];
+	this.lines = [];
+	this.x = 0;
+	this.y = 0;
+	this.style = defaultStyle;
+	this.pending = "";
+	this.bracketedPaste = false;
+	this.button = -1;
+	this.dataListeners = [];
+	this.resizeListeners = [];
+}
+
+TermuiTerminal.prototype.onData = function(listener) {
+	this.dataListeners.push(listener);
+};
+
+TermuiTerminal.prototype.onResize = function(listener) {
+	this.resizeListeners.push(listener);
+};
+
+TermuiTerminal.prototype.send = function(data) {
+	for (var i = 0; i < this.dataListeners.length; i++) {
+		this.dataListeners[i](data);
+	}
+};
+
+TermuiTerminal.prototype.open = function(element) {
+	var self = this;
+	this.element = element;
+	element.tabIndex = 0;
+	element.style.fontFamily = this.fontFamily;
+	element.style.whiteSpace = "pre";
+	element.style.lineHeight = "normal";
+	element.style.color = palette[7];
+	element.style.background = palette[0];
+	element.style.outline = "none";
+	element.style.cursor = "default";
+	element.style.userSelect = "none";
+
+	element.addEventListener("keydown", function(e) { self.key(e); });
+	element.addEventListener("paste", function(e) {
+		var text = e.clipboardData.getData("text").replace(/\r?\n/g, "\r");
+		if (self.bracketedPaste) {
+			text = "\x1b[200~" + text + "\x1b[201~";
+		}
+		self.send(text);
+		e.preventDefault();
+	});
+	element.addEventListener("mousedown", function(e) {
+		if (e.button > 2) {
+			return;
+		}
+		self.button = e.button;
+		self.mouse(e, self.button, "M");
+		element.focus();
+		e.preventDefault();
+	});
+	element.addEventListener("mousemove", function(e) {
+		if (self.button >= 0) {
+			self.mouse(e, self.button + 32, "M");
+		}
+	});
+	window.addEventListener("mouseup", function(e) {
+		if (self.button >= 0) {
+			self.mouse(e, self.button, "m");
+			self.button = -1;
+		}
+	});
+	element.addEventListener("wheel", function(e) {
+		self.mouse(e, e.deltaY < 0 ? 64 : 65, "M");
+		e.preventDefault();
+	});
+	element.addEventListener("contextmenu", function(e) {
+		e.preventDefault();
+	});
+	this.fit();
+};
+
+TermuiTerminal.prototype.focus = function() {
+	this.element.focus();
+};
+
+// cellSize measures a cell of the font.
+TermuiTerminal.prototype.cellSize = function() {
+	var probe = document.createElement("span");
+	probe.textContent = "W";
+	this.element.appendChild(probe);
+	var rect = probe.getBoundingClientRect();
+	this.element.removeChild(probe);
+	return {width: rect.width || 8, height: rect.height || 16};
+};
+
+// fit resizes the terminal to fill its element.
+TermuiTerminal.prototype.fit = function() {
+	var cell = this.cellSize();
+	this.cellWidth = cell.width;
+	this.cellHeight = cell.height;
+	var cols = Math.max(1, Math.floor(this.element.clientWidth / cell.width));
+	var rows = Math.max(1, Math.floor(this.element.clientHeight / cell.height));
+	if (cols === this.cols && rows === this.rows) {
+		return;
+	}
+	this.resize(cols, rows);
+	for (var i = 0; i < this.resizeListeners.length; i++) {
+		this.resizeListeners[i]({cols: cols, rows: rows});
+	}
+};
+
+TermuiTerminal.prototype.resize = function(cols, rows) {
+	var cells = [];
+	for (var y = 0; y < rows; y++) {
+		var row = [];
+		for (var x = 0; x < cols; x++) {
+			var old = this.cells[y] && this.cells[y][x];
+			row.push(old === undefined ? {ch: " ", style: defaultStyle} : old);
+		}
+		cells.push(row);
+	}
+	this.cells = cells;
+	this.cols = cols;
+	this.rows = rows;
+	while (this.lines.length < rows) {
+		var line = document.createElement("div");
+		this.element.appendChild(line);
+		this.lines.push(line);
+	}
+	while (this.lines.length > rows) {
+		this.element.removeChild(this.lines.pop());
+	}
+	this.dirty = [];
+	for (var y = 0; y < rows; y++) {
+		this.dirty.push(true);
+	}
+	this.schedule();
+};
+
+TermuiTerminal.prototype.clear = function() {
+	for (var y = 0; y < this.rows; y++) {
+		for (var x = 0; x < this.cols; x++) {
+			this.cells[y][x] = {ch: " ", style: defaultStyle};
+		}
+		this.dirty[y] = true;
+	}
+};
+
+TermuiTerminal.prototype.put = function(ch, width) {
+	if (this.y >= 0 && this.y < this.rows && this.x >= 0 && this.x < this.cols) {
+		this.cells[this.y][this.x] = {ch: ch, style: this.style};
+		if (width === 2 && this.x + 1 < this.cols) {
+			// covered by the wide character
+			this.cells[this.y][this.x + 1] = null;
+		}
+		this.dirty[this.y] = true;
+	}
+	this.x += width;
+};
+
+TermuiTerminal.prototype.write = function(data) {
+	data = this.pending + data;
+	this.pending = "";
+	var i = 0;
+	while (i < data.length) {
+		var c = data.charAt(i);
+		if (c === "\x1b") {
+			if (i + 1 >= data.length) {
+				this.pending = data.slice(i);
+				break;
+			}
+			if (data.charAt(i + 1) !== "[") {
+				i += 2;
+				continue;
+			}
+			var end = i + 2;
+			while (end < data.length && (data.charCodeAt(end) < 0x40 || data.charCodeAt(end) > 0x7e)) {
+				end++;
+			}
+			if (end >= data.length) {
+				this.pending = data.slice(i);
+				break;
+			}
+			this.csi(data.slice(i + 2, end), data.charAt(end));
+			i = end + 1;
+			continue;
+		}
+		if (c === "\r") {
+			this.x = 0;
+			i++;
+			continue;
+		}
+		if (c === "\n") {
+			this.y++;
+			i++;
+			continue;
+		}
+		var code = data.codePointAt(i);
+		var ch = String.fromCodePoint(code);
+		this.put(ch, wide(code) ? 2 : 1);
+		i += ch.length;
+	}
+	this.schedule();
+};
+
+TermuiTerminal.prototype.csi = function(params, final) {
+	if (params.charAt(0) === "?") {
+		if (params === "?2004") {
+			this.bracketedPaste = final === "h";
+		}
+		return;
+	}
+	var args = params === "" ? [] : params.split(";").map(function(n) { return parseInt(n, 10) || 0; });
+	switch (final) {
+	case "H":
+		this.y = (args[0] || 1) - 1;
+		this.x = (args[1] || 1) - 1;
+		break;
+	case "J":
+		if (args[0] === 2) {
+			this.clear();
+		}
+		break;
+	case "m":
+		this.sgr(args.length === 0 ? [0] : args);
+		break;
+	}
+};
+
+TermuiTerminal.prototype.sgr = function(args) {
+	var style = {};
+	for (var key in this.style) {
+		style[key] = this.style[key];
+	}
+	for (var i = 0; i < args.length; i++) {
+		var code = args[i];
+		if (code === 0) {
+			style = {fg: null, bg: null, bold: false, underline: false, reverse: false};
+		} else if (code === 1) {
+			style.bold = true;
+		} else if (code === 4) {
+			style.underline = true;
+		} else if (code === 7) {
+			style.reverse = true;
+		} else if (code === 22) {
+			style.bold = false;
+		} else if (code === 24) {
+			style.underline = false;
+		} else if (code === 27) {
+			style.reverse = false;
+		} else if (code >= 30 && code <= 37) {
+			style.fg = palette[code - 30];
+		} else if (code >= 90 && code <= 97) {
+			style.fg = palette[code - 90 + 8];
+		} else if (code >= 40 && code <= 47) {
+			style.bg = palette[code - 40];
+		} else if (code >= 100 && code <= 107) {
+			style.bg = palette[code - 100 + 8];
+		} else if (code === 39) {
+			style.fg = null;
+		} else if (code === 49) {
+			style.bg = null;
+		} else if (code === 38 || code === 48) {
+			var color = null;
+			if (args[i + 1] === 5) {
+				color = palette[args[i + 2]] || null;
+				i += 2;
+			} else if (args[i + 1] === 2) {
+				color = rgb(args[i + 2] || 0, args[i + 3] || 0, args[i + 4] || 0);
+				i += 4;
+			}
+			if (code === 38) {
+				style.fg = color;
+			} else {
+				style.bg = color;
+			}
+		}
+	}
+	this.style = style;
+};
+
+TermuiTerminal.prototype.schedule = function() {
+	var self = this;
+	if (this.scheduled) {
+		return;
+	}
+	this.scheduled = true;
+	window.requestAnimationFrame(function() {
+		self.scheduled = false;
+		self.draw();
+	});
+};
+
+// draw redraws the changed lines, with a span for each run of cells of the same style.
+TermuiTerminal.prototype.draw = function() {
+	for (var y = 0; y < this.rows; y++) {
+		if (!this.dirty[y]) {
+			continue;
+		}
+		this.dirty[y] = false;
+		var line = this.lines[y];
+		while (line.firstChild) {
+			line.removeChild(line.firstChild);
+		}
+		var row = this.cells[y];
+		var x = 0;
+		while (x < this.cols) {
+			var style = row[x] ? row[x].style : defaultStyle;
+			var text = "";
+			while (x < this.cols && (row[x] === null || row[x].style === style)) {
+				if (row[x] !== null) {
+					text += row[x].ch;
+				}
+				x++;
+			}
+			var span = document.createElement("span");
+			span.textContent = text;
+			var fg = style.fg || palette[7];
+			var bg = style.bg || palette[0];
+			if (style.reverse) {
+				span.style.color = bg;
+				span.style.background = fg;
+			} else {
+				span.style.color = fg;
+				span.style.background = bg;
+			}
+			if (style.bold) {
+				span.style.fontWeight = "bold";
+			}
+			if (style.underline) {
+				span.style.textDecoration = "underline";
+			}
+			line.appendChild(span);
+		}
+	}
+};
+
+TermuiTerminal.prototype.key = function(e) {
+	if (e.metaKey || (e.ctrlKey && e.key.toLowerCase() === "v")) {
+		// left to the browser, like pasting
+		return;
+	}
+	var data = keys[e.key];
+	if (data === undefined && Array.from(e.key).length === 1) {
+		data = e.key;
+		if (e.ctrlKey) {
+			var code = e.key.toLowerCase().charCodeAt(0);
+			if (code >= 97 && code <= 122) {
+				data = String.fromCharCode(code - 96);
+			} else if (e.key === " ") {
+				data = "\x00";
+			}
+		}
+	}
+	if (data === undefined) {
+		return;
+	}
+	if (e.altKey) {
+		data = "\x1b" + data;
+	}
+	this.send(data);
+	e.preventDefault();
+};
+
+// mouse sends an SGR mouse report of the cell under the pointer.
+TermuiTerminal.prototype.mouse = function(e, button, final) {
+	var rect = this.element.getBoundingClientRect();
+	var x = Math.min(Math.max(Math.floor((e.clientX - rect.left) / this.cellWidth), 0), this.cols - 1);
+	var y = Math.min(Math.max(Math.floor((e.clientY - rect.top) / this.cellHeight), 0), this.rows - 1);
+	this.send("\x1b[<" + button + ";" + (x + 1) + ";" + (y + 1) + final);
+};
+
+window.TermuiTerminal = TermuiTerminal;
+})();
+`
@@ -0,0 +1,309 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// Package web serves a termui application to browsers instead of the terminal. Its Backend
+// serves a page showing the screen with a terminal bundled with it, or xterm.js, sends it the
+// cells changed by each Render over a WebSocket, and turns the keys and mouse events of the page
+// into events:
+//
+//	if err := ui.InitBackend(web.NewBackend(":8080")); err != nil {
+//		log.Fatal(err)
+//	}
+//	defer ui.Close()
+//
+// Every browser connected sees and controls the same screen, whose size is that of the browser
+// which last connected or was resized.
+package web
+
+import (
+	"encoding/json"
+	"image"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	ui "github.com/reaalkhalil/termui"
+)
+
+// clientBuffer is the number of frames queued for a browser before it's disconnected as too slow.
+const clientBuffer = 64
+
+// maxSize is the largest number of columns and rows a browser may resize the screen to.
+const maxSize = 1000
+
+// Backend is a termui Backend drawing on the pages of browsers. It is also an http.Handler
+// serving the page and, under "socket" next to it, its WebSocket, for applications serving it
+// themselves.
+type Backend struct {
+	// Addr is the address Init listens on, like ":8080". When empty, Init doesn't listen.
+	Addr  string
+	Title string
+	// AssetsURL is the URL xterm.js and its fit addon are loaded from, laid out like the npm
+	// packages of the jsDelivr CDN, like "https://cdn.jsdelivr.net/npm". When empty, which it is
+	// by default, the page uses the terminal served by the Backend itself.
+	AssetsURL  string
+	FontFamily string
+	// Width and Height are the size of the screen until a browser reports its own.
+	Width  int
+	Height int
+	// CheckOrigin reports whether a browser may connect from the page a request comes from. When
+	// nil, only pages served from the same host may.
+	CheckOrigin func(r *http.Request) bool
+
+	mutex sync.Mutex
+	// screen is the back buffer and shown the screen last sent to browsers, or nil when it has to
+	// be sent again whole.
+	screen  *ui.Buffer
+	shown   *ui.Buffer
+	clients map[*client]bool
+	events  chan ui.Event
+	server  *http.Server
+}
+
+var _ ui.Backend = &Backend{}
+
+// client is a connected browser, with the frames waiting to be sent to it.
+type client struct {
+	conn   *wsConn
+	frames chan string
+}
+
+func NewBackend(addr string) *Backend {
+	return &Backend{
+		Addr:       addr,
+		Title:      "termui",
+		FontFamily: "Menlo, Consolas, \"DejaVu Sans Mono\", monospace",
+		Width:      80,
+		Height:     24,
+		clients:    map[*client]bool{},
+		events:     make(chan ui.Event, 64),
+	}
+}
+
+func (self *Backend) Init() error {
+	self.mutex.Lock()
+	self.screen = ui.NewBuffer(image.Rect(0, 0, self.Width, self.Height))
+	self.shown = nil
+	self.mutex.Unlock()
+	if self.Addr == "" {
+		return nil
+	}
+	listener, err := net.Listen("tcp", self.Addr)
+	if err != nil {
+		return err
+	}
+	self.server = &http.Server{Handler: self}
+	go self.server.Serve(listener)
+	return nil
+}
+
+// Close stops the server started by Init and disconnects the browsers.
+func (self *Backend) Close() {
+	if self.server != nil {
+		self.server.Close()
+	}
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	for c := range self.clients {
+		self.disconnect(c)
+	}
+}
+
+func (self *Backend) Size() (int, int) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.screen.Dx(), self.screen.Dy()
+}
+
+func (self *Backend) SetCell(p image.Point, cell ui.Cell) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if p.In(self.screen.Rectangle) {
+		cell.Style = cell.Style.Downgrade(ui.TerminalColorDepth)
+		self.screen.SetCell(cell, p)
+	}
+}
+
+func (self *Backend) Cell(p image.Point) ui.Cell {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if p.In(self.screen.Rectangle) {
+		return self.screen.GetCell(p)
+	}
+	return ui.CellClear
+}
+
+func (self *Backend) Clear(style ui.Style) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.screen.Fill(ui.NewCell(' ', style), self.screen.Rectangle)
+}
+
+func (self *Backend) Flush() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	frame := ui.FormatANSI(self.shown, self.screen)
+	self.shown = self.screen.Clone()
+	if frame == "" {
+		return
+	}
+	for c := range self.clients {
+		select {
+		case c.frames <- frame:
+		default:
+			self.disconnect(c)
+		}
+	}
+}
+
+func (self *Backend) Sync() {
+	self.mutex.Lock()
+	self.shown = nil
+	self.mutex.Unlock()
+	self.Flush()
+}
+
+func (self *Backend) PollEvent() ui.Event {
+	return <-self.events
+}
+
+func (self *Backend) ColorDepth() ui.ColorDepth {
+	return ui.ColorDepthTrueColor
+}
+
+func (self *Backend) Graphics() ui.GraphicsProtocol {
+	return ui.GraphicsNone
+}
+
+// sameOrigin reports whether the page of a request was served from the host it is sent to.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// ServeHTTP serves the page, its terminal for paths ending in "/terminal.js", or its WebSocket for
+// paths ending in "/socket".
+func (self *Backend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/terminal.js") {
+		w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+		io.WriteString(w, terminalScript)
+		return
+	}
+	if !strings.HasSuffix(r.URL.Path, "/socket") {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		page.Execute(w, self)
+		return
+	}
+	checkOrigin := self.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = sameOrigin
+	}
+	if !checkOrigin(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	c := &client{conn: conn, frames: make(chan string, clientBuffer)}
+	self.mutex.Lock()
+	// hide the cursor and report mouse buttons and drags in SGR format, then send the screen
+	c.frames <- "\x1b[?25l\x1b[?1002h\x1b[?1006h"
+	if ui.BracketedPaste {
+		c.frames <- "\x1b[?2004h"
+	}
+	if self.shown != nil {
+		c.frames <- ui.FormatANSI(nil, self.shown)
+	}
+	self.clients[c] = true
+	self.mutex.Unlock()
+
+	go func() {
+		// a failed write closes the connection too, which ends read
+		defer c.conn.Close()
+		for frame := range c.frames {
+			if err := c.conn.WriteText(frame); err != nil {
+				return
+			}
+		}
+	}()
+	self.read(c)
+}
+
+// read sends the events of the messages of a browser until it disconnects.
+func (self *Backend) read(c *client) {
+	defer func() {
+		self.mutex.Lock()
+		self.disconnect(c)
+		self.mutex.Unlock()
+	}()
+	for {
+		data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		message := struct {
+			Type string
+			Data string
+			Cols int
+			Rows int
+		}{}
+		if err := json.Unmarshal(data, &message); err != nil {
+			continue
+		}
+		switch message.Type {
+		case "input":
+			for _, e := range ui.ParseInput(message.Data) {
+				self.events <- e
+			}
+		case "resize":
+			if message.Cols <= 0 || message.Rows <= 0 || message.Cols > maxSize || message.Rows > maxSize {
+				continue
+			}
+			if self.resize(message.Cols, message.Rows) {
+				self.events <- ui.Event{
+					Type:    ui.ResizeEvent,
+					ID:      "<Resize>",
+					Payload: ui.Resize{Width: message.Cols, Height: message.Rows},
+				}
+			}
+		}
+	}
+}
+
+// resize sets the size of the screen, and reports whether it changed. The screen is sent whole
+// on the next Flush.
+func (self *Backend) resize(width, height int) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if width == self.screen.Dx() && height == self.screen.Dy() {
+		return false
+	}
+	screen := ui.NewBuffer(image.Rect(0, 0, width, height))
+	kept := screen.Intersect(self.screen.Rectangle)
+	for y := kept.Min.Y; y < kept.Max.Y; y++ {
+		for x := kept.Min.X; x < kept.Max.X; x++ {
+			screen.SetCell(self.screen.GetCell(image.Pt(x, y)), image.Pt(x, y))
+		}
+	}
+	self.screen, self.shown = screen, nil
+	return true
+}
+
+// disconnect forgets a browser, whose connection is closed once its frames are sent. It is called
+// with the mutex held.
+func (self *Backend) disconnect(c *client) {
+	if self.clients[c] {
+		delete(self.clients, c)
+		close(c.frames)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"strings"
+	"sync/atomic"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// Autocomplete is a TextInput which looks up suggestions for its text from Source as it is edited
+// and shows them in a popup list, drawn as an overlay with ShowOverlay so that it floats over the
+// widgets below the input.
+type Autocomplete struct {
+	TextInput
+
+	// Source returns the suggestions for the text.
+	Source func(text string) []string
+	// Async calls Source in its own goroutine, for sources that are slow or remote. Suggestions
+	// for text which has since been edited are dropped, and OnSuggest is called once the others
+	// arrive so that the application can Render them.
+	Async bool
+	// MinLength is the number of runes typed before Source is called.
+	MinLength int
+	// MaxVisible is the number of suggestions shown by the popup before it scrolls.
+	MaxVisible int
+
+	// OnSuggest is called with the suggestions shown by the popup when they change.
+	OnSuggest func(suggestions []string)
+	// OnAccept is called when a suggestion is accepted, after the text is replaced with it.
+	OnAccept func(suggestion string)
+
+	popup *List
+	// generation counts the lookups, to drop async suggestions for outdated text.
+	generation uint64
+}
+
+func NewAutocomplete() *Autocomplete {
+	popup := NewList()
+	popup.SelectedRowStyle = Theme.Select.Highlighted
+	popup.TextStyle = Theme.Select.Text
+	return &Autocomplete{
+		TextInput:  *NewTextInput(),
+		MinLength:  1,
+		MaxVisible: 8,
+		popup:      popup,
+	}
+}
+
+// StaticSuggestions returns a Source suggesting the options containing the text, ignoring case,
+// with the options starting with it first.
+func StaticSuggestions(options []string) func(text string) []string {
+	return func(text string) []string {
+		text = strings.ToLower(text)
+		prefixed, contained := []string{}, []string{}
+		for _, option := range options {
+			lower := strings.ToLower(option)
+			if strings.HasPrefix(lower, text) {
+				prefixed = append(prefixed, option)
+			} else if strings.Contains(lower, text) {
+				contained = append(contained, option)
+			}
+		}
+		return append(prefixed, contained...)
+	}
+}
+
+// IsOpen reports whether the popup is shown.
+func (self *Autocomplete) IsOpen() bool {
+	return IsOverlay(self.popup)
+}
+
+// Close hides the popup until the text is edited again.
+func (self *Autocomplete) Close() {
+	atomic.AddUint64(&self.generation, 1)
+	HideOverlay(self.popup)
+}
+
+// Suggestions returns the suggestions shown by the popup.
+func (self *Autocomplete) Suggestions() []string {
+	self.popup.Lock()
+	defer self.popup.Unlock()
+	return append([]string{}, self.popup.Rows...)
+}
+
+// Suggest looks up the suggestions for the current text, as editing it does.
+func (self *Autocomplete) Suggest() {
+	generation := atomic.AddUint64(&self.generation, 1)
+	text := self.Text()
+	if self.Source == nil || len([]rune(text)) < self.MinLength {
+		self.setSuggestions(nil)
+		return
+	}
+	if !self.Async {
+		self.setSuggestions(self.Source(text))
+		return
+	}
+	go func() {
+		suggestions := self.Source(text)
+		if atomic.LoadUint64(&self.generation) == generation {
+			self.setSuggestions(suggestions)
+		}
+	}()
+}
+
+// setSuggestions shows suggestions in the popup, or hides it if there are none.
+func (self *Autocomplete) setSuggestions(suggestions []string) {
+	self.popup.Lock()
+	self.popup.Rows = suggestions
+	self.popup.SelectedRow = 0
+	self.placePopup()
+	self.popup.Unlock()
+
+	if len(suggestions) > 0 && self.Focused {
+		ShowOverlay(self.popup)
+	} else {
+		HideOverlay(self.popup)
+	}
+	if self.OnSuggest != nil {
+		self.OnSuggest(suggestions)
+	}
+}
+
+// Accept replaces the text with the suggestion highlighted in the popup and closes it.
+func (self *Autocomplete) Accept() {
+	self.popup.Lock()
+	suggestion, ok := "", self.popup.SelectedRow < len(self.popup.Rows)
+	if ok {
+		suggestion = self.popup.Rows[self.popup.SelectedRow]
+	}
+	self.popup.Unlock()
+	self.Close()
+	if !ok {
+		return
+	}
+	self.SetText(suggestion)
+	if self.OnChange != nil {
+		self.OnChange(suggestion)
+	}
+	if self.OnAccept != nil {
+		self.OnAccept(suggestion)
+	}
+}
+
+func (self *Autocomplete) placePopup() {
+	height := MinInt(len(self.popup.Rows), MaxInt(self.MaxVisible, 1)) + 2
+	self.popup.SetRect(self.Min.X, self.Max.Y-1, self.Max.X, self.Max.Y-1+height)
+}
+
+func (self *Autocomplete) SetFocused(focused bool) {
+	self.Focused = focused
+	if !focused {
+		self.Close()
+	}
+}
+
+// HandleKey edits the text like a TextInput while the Autocomplete is Focused, looking up
+// suggestions after every edit. While the popup is open, <Up> and <Down> move through the
+// suggestions, <Tab> or <Enter> accepts one, and <Escape> closes it. It reports whether the key
+// was used.
+func (self *Autocomplete) HandleKey(id string) bool {
+	if !self.Focused {
+		return false
+	}
+	if self.IsOpen() {
+		used := true
+		self.popup.Lock()
+		switch id {
+		case "<Up>", "<C-p>":
+			self.popup.ScrollUp()
+		case "<Down>", "<C-n>":
+			self.popup.ScrollDown()
+		case "<PageUp>":
+			self.popup.ScrollPageUp()
+		case "<PageDown>":
+			self.popup.ScrollPageDown()
+		default:
+			used = false
+		}
+		self.popup.Unlock()
+		if used {
+			return true
+		}
+		switch id {
+		case "<Tab>", "<Enter>":
+			self.Accept()
+			return true
+		case "<Escape>":
+			self.Close()
+			return true
+		}
+	} else if id == "<Down>" || id == "<C-n>" {
+		self.Suggest()
+		return true
+	}
+
+	before := self.Text()
+	if !self.TextInput.HandleKey(id) {
+		return false
+	}
+	if self.Text() != before {
+		self.Suggest()
+	}
+	return true
+}
+
+// HandleMouse accepts a clicked suggestion, closes the popup on a click anywhere else, and moves
+// the cursor like a TextInput. It reports whether the event was used.
+func (self *Autocomplete) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok {
+		return false
+	}
+	if self.IsOpen() && p.In(self.popup.GetRect()) {
+		self.popup.Lock()
+		used := self.popup.HandleMouse(e)
+		self.popup.Unlock()
+		if e.ID == "<MouseLeft>" && !e.Payload.(Mouse).Drag && p.In(self.popup.Inner) {
+			self.Accept()
+		}
+		return used
+	}
+	if e.ID == "<MouseLeft>" && !e.Payload.(Mouse).Drag && !p.In(self.Rectangle) && self.IsOpen() {
+		self.Close()
+	}
+	return self.TextInput.HandleMouse(e)
+}
+
+func (self *Autocomplete) Draw(buf *Buffer) {
+	self.TextInput.Draw(buf)
+	if self.IsOpen() {
+		self.popup.Lock()
+		self.placePopup()
+		self.popup.Unlock()
+	}
+}
@@ -7,6 +7,7 @@ package widgets
 import (
 	"fmt"
 	"image"
+	"strconv"
 
 	rw "github.com/mattn/go-runewidth"
 
@@ -15,75 +16,414 @@ import (
 
 type BarChart struct {
 	Block
-	BarColors    []Color
-	LabelStyles  []Style
-	NumStyles    []Style // only Fg and Modifier are used
+	BarColors   []Color
+	LabelStyles []Style
+	NumStyles   []Style // only Fg and Modifier are used
+	// NumFormatter formats the value drawn on each bar. Values formatted as "" are not drawn.
 	NumFormatter func(float64) string
 	Data         []float64
 	Labels       []string
 	BarWidth     int
 	BarGap       int
 	MaxVal       float64
+	// MinVal is the lowest value of the scale. Negative values are drawn as bars growing down, or
+	// left when Horizontal, from a zero baseline. When zero, it's taken from the Data.
+	MinVal        float64
+	BaselineStyle Style
+	// Horizontal draws the bars growing from left to right with the labels in a gutter on the left.
+	// BarWidth and BarGap are then counted in lines.
+	Horizontal bool
+	// Series holds several sets of Data drawn as groups of bars side by side, one group per label.
+	// Each series is colored with its own BarColor. Data is ignored when there are Series.
+	Series       [][]float64
+	SeriesLabels []string
+	// ShowLegend draws the SeriesLabels with their colors on the first line of the chart.
+	ShowLegend bool
+	// LabelRotation draws long labels vertically or diagonally below the bars instead of
+	// trimming them to the width of their bars. It is not used when Horizontal.
+	LabelRotation LabelRotation
+
+	// offset is the first group of bars drawn, and shown the number of groups fitting in the chart.
+	offset int
+	shown  int
 }
 
+type LabelRotation uint
+
+const (
+	LabelHorizontal LabelRotation = iota
+	LabelVertical
+	LabelDiagonal
+)
+
 func NewBarChart() *BarChart {
 	return &BarChart{
-		Block:        *NewBlock(),
-		BarColors:    Theme.BarChart.Bars,
-		NumStyles:    Theme.BarChart.Nums,
-		LabelStyles:  Theme.BarChart.Labels,
-		NumFormatter: func(n float64) string { return fmt.Sprint(n) },
-		BarGap:       1,
-		BarWidth:     3,
+		Block:         *NewBlock(),
+		BarColors:     Theme.BarChart.Bars,
+		NumStyles:     Theme.BarChart.Nums,
+		LabelStyles:   Theme.BarChart.Labels,
+		NumFormatter:  func(n float64) string { return fmt.Sprint(n) },
+		BarGap:        1,
+		BarWidth:      3,
+		BaselineStyle: Theme.BarChart.Baseline,
 	}
 }
 
 func (self *BarChart) Draw(buf *Buffer) {
 	self.Block.Draw(buf)
 
-	maxVal := self.MaxVal
-	if maxVal == 0 {
-		maxVal, _ = GetMaxFloat64FromSlice(self.Data)
+	groups := self.groups()
+	if len(groups) == 0 {
+		return
+	}
+	maxVal, minVal := self.MaxVal, self.MinVal
+	for _, group := range groups {
+		for _, data := range group {
+			if self.MaxVal == 0 {
+				maxVal = MaxFloat64(maxVal, data)
+			}
+			if self.MinVal == 0 {
+				minVal = MinFloat64(minVal, data)
+			}
+		}
+	}
+
+	area := self.Inner
+	if self.ShowLegend && len(self.Series) > 0 && area.Dy() > 1 {
+		colors := make([]Color, len(self.Series))
+		for s := range self.Series {
+			colors[s] = SelectColor(self.BarColors, s)
+		}
+		drawLegend(buf, area.Min, area.Dx(), self.SeriesLabels, colors, SelectStyle(self.LabelStyles, 0))
+		area.Min.Y++
+	}
+
+	length := area.Dx()
+	if self.Horizontal {
+		length = area.Dy()
 	}
+	self.shown = MaxInt((length+self.BarGap)/MaxInt(len(groups[0])*self.BarWidth+self.BarGap, 1), 1)
+	self.offset = MaxInt(MinInt(self.offset, len(groups)-self.shown), 0)
+	shown := groups[self.offset:MinInt(self.offset+self.shown, len(groups))]
 
-	barXCoordinate := self.Inner.Min.X
+	if self.Horizontal {
+		self.drawHorizontal(buf, area, shown, maxVal, minVal)
+	} else {
+		self.drawVertical(buf, area, shown, maxVal, minVal)
+	}
+	self.drawScrollIndicators(buf, area, len(groups))
+}
+
+// drawScrollIndicators marks the ends of the chart when groups of bars are scrolled out of view.
+func (self *BarChart) drawScrollIndicators(buf *Buffer, area image.Rectangle, count int) {
+	before, after := QUOTA_LEFT, QUOTA_RIGHT
+	beforePoint := image.Pt(area.Min.X, area.Max.Y-1)
+	afterPoint := image.Pt(area.Max.X-1, area.Max.Y-1)
+	if self.Horizontal {
+		before, after = UP_ARROW, DOWN_ARROW
+		beforePoint = image.Pt(area.Max.X-1, area.Min.Y)
+	}
+	if self.offset > 0 {
+		buf.SetCell(NewCell(before, NewStyle(ColorWhite)), beforePoint)
+	}
+	if self.offset+self.shown < count {
+		buf.SetCell(NewCell(after, NewStyle(ColorWhite)), afterPoint)
+	}
+}
 
-	for i, data := range self.Data {
-		// draw bar
-		height := int((data / maxVal) * float64(self.Inner.Dy()-1))
-		for x := barXCoordinate; x < MinInt(barXCoordinate+self.BarWidth, self.Inner.Max.X); x++ {
-			for y := self.Inner.Max.Y - 2; y > (self.Inner.Max.Y-2)-height; y-- {
-				c := NewCell(' ', NewStyle(ColorClear, SelectColor(self.BarColors, i)))
-				buf.SetCell(c, image.Pt(x, y))
+// groups returns the values drawn for each label, one for each series.
+func (self *BarChart) groups() [][]float64 {
+	if len(self.Series) == 0 {
+		groups := make([][]float64, len(self.Data))
+		for i, data := range self.Data {
+			groups[i] = []float64{data}
+		}
+		return groups
+	}
+	count := 0
+	for _, series := range self.Series {
+		count = MaxInt(count, len(series))
+	}
+	groups := make([][]float64, count)
+	for i := range groups {
+		groups[i] = make([]float64, len(self.Series))
+		for s, series := range self.Series {
+			if i < len(series) {
+				groups[i][s] = series[i]
 			}
 		}
+	}
+	return groups
+}
+
+// barIndex returns the index of the colors and styles of bar s of group i.
+func (self *BarChart) barIndex(i, s int) int {
+	if len(self.Series) > 0 {
+		return s
+	}
+	return i
+}
+
+func (self *BarChart) numStyle(i, s int) Style {
+	index := self.barIndex(i, s)
+	return NewStyle(
+		SelectStyle(self.NumStyles, index+1).Fg,
+		SelectColor(self.BarColors, index),
+		SelectStyle(self.NumStyles, index+1).Modifier,
+	)
+}
+
+// barScale returns the number of cells per unit of a scale from minVal to maxVal drawn over
+// length cells, leaving one cell for the zero baseline if there are negative values.
+func barScale(length int, maxVal, minVal float64) float64 {
+	if minVal < 0 {
+		length--
+	}
+	if maxVal-minVal <= 0 {
+		return 0
+	}
+	return float64(length) / (maxVal - MinFloat64(minVal, 0))
+}
+
+// NewNumFormatter returns a NumFormatter writing values with precision decimals followed by unit.
+// If hideZeros is set, zero values are not drawn.
+func NewNumFormatter(precision int, unit string, hideZeros bool) func(float64) string {
+	return func(n float64) string {
+		if hideZeros && n == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(n, 'f', precision, 64) + unit
+	}
+}
+
+// labelHeight returns the number of lines taken by the labels below vertical bars.
+func (self *BarChart) labelHeight(area image.Rectangle) int {
+	if self.LabelRotation == LabelHorizontal {
+		return 1
+	}
+	height := 1
+	for _, label := range self.Labels {
+		height = MaxInt(height, rw.StringWidth(label))
+	}
+	return MaxInt(MinInt(height, area.Dy()/2), 1)
+}
+
+func (self *BarChart) drawVertical(buf *Buffer, area image.Rectangle, groups [][]float64, maxVal, minVal float64) {
+	labelHeight := self.labelHeight(area)
+	labelYCoordinate := area.Max.Y - labelHeight
+	area.Max.Y = labelYCoordinate + 1
+
+	// bars grow up from the line above zeroYCoordinate and down from the line below it
+	scale := barScale(area.Dy()-1, maxVal, minVal)
+	zeroYCoordinate := area.Max.Y - 1
+	if minVal < 0 {
+		zeroYCoordinate = area.Min.Y + int(MaxFloat64(maxVal, 0)*scale)
+		buf.Fill(
+			NewCell(HORIZONTAL_LINE, self.BaselineStyle),
+			image.Rect(area.Min.X, zeroYCoordinate, area.Max.X, zeroYCoordinate+1),
+		)
+	}
+
+	barXCoordinate := area.Min.X
+
+	for k, group := range groups {
+		i := self.offset + k
+		groupXCoordinate := barXCoordinate
+
+		for s, data := range group {
+			// draw bar
+			height := int(data * scale)
+			bar := image.Rect(barXCoordinate, zeroYCoordinate-height, barXCoordinate+self.BarWidth, zeroYCoordinate)
+			numberYCoordinate := zeroYCoordinate - 1
+			if data < 0 {
+				bar = image.Rect(barXCoordinate, zeroYCoordinate+1, barXCoordinate+self.BarWidth, zeroYCoordinate+1-height)
+				numberYCoordinate = zeroYCoordinate + 1
+			}
+			buf.Fill(
+				NewCell(' ', NewStyle(ColorClear, SelectColor(self.BarColors, self.barIndex(i, s)))),
+				bar.Intersect(area),
+			)
+
+			// draw number
+			numberXCoordinate := barXCoordinate + int((float64(self.BarWidth) / 2))
+			if numberXCoordinate <= area.Max.X {
+				buf.SetString(
+					self.NumFormatter(data),
+					self.numStyle(i, s),
+					image.Pt(numberXCoordinate, numberYCoordinate),
+				)
+			}
+
+			barXCoordinate += self.BarWidth
+		}
 
 		// draw label
 		if i < len(self.Labels) {
-			labelXCoordinate := barXCoordinate +
-				int((float64(self.BarWidth) / 2)) -
-				int((float64(rw.StringWidth(self.Labels[i])) / 2))
+			label := image.Rect(groupXCoordinate, labelYCoordinate, barXCoordinate, labelYCoordinate+labelHeight)
+			self.drawLabel(buf, i, label)
+		}
+
+		barXCoordinate += self.BarGap
+	}
+}
+
+// drawLabel draws label i in rect, below the group of bars as wide as rect.
+func (self *BarChart) drawLabel(buf *Buffer, i int, rect image.Rectangle) {
+	style := SelectStyle(self.LabelStyles, i)
+	if self.LabelRotation == LabelHorizontal {
+		label := TrimString(self.Labels[i], rect.Dx()+self.BarGap)
+		labelXCoordinate := rect.Min.X +
+			int((float64(rect.Dx()) / 2)) -
+			int((float64(rw.StringWidth(label)) / 2))
+		buf.SetString(label, style, image.Pt(MaxInt(labelXCoordinate, rect.Min.X), rect.Min.Y))
+		return
+	}
+	for j, r := range []rune(TrimString(self.Labels[i], rect.Dy())) {
+		point := image.Pt(rect.Min.X+rect.Dx()/2, rect.Min.Y+j)
+		if self.LabelRotation == LabelDiagonal {
+			point = image.Pt(rect.Min.X+j, rect.Min.Y+j)
+		}
+		if point.X < self.Inner.Max.X {
+			buf.SetCell(NewCell(r, style), point)
+		}
+	}
+}
+
+func (self *BarChart) drawHorizontal(buf *Buffer, area image.Rectangle, groups [][]float64, maxVal, minVal float64) {
+	gutter := 0
+	for _, label := range self.Labels {
+		gutter = MaxInt(gutter, rw.StringWidth(label)+1)
+	}
+	gutter = MinInt(gutter, area.Dx()/2)
+	barXCoordinate := area.Min.X + gutter
+	barYCoordinate := area.Min.Y
+
+	// bars grow right from the column after zeroXCoordinate and left from the column before it
+	scale := barScale(area.Max.X-barXCoordinate, maxVal, minVal)
+	zeroXCoordinate := barXCoordinate - 1
+	if minVal < 0 {
+		zeroXCoordinate = barXCoordinate + int(-minVal*scale)
+		buf.Fill(
+			NewCell(VERTICAL_LINE, self.BaselineStyle),
+			image.Rect(zeroXCoordinate, area.Min.Y, zeroXCoordinate+1, area.Max.Y),
+		)
+	}
+
+	for k, group := range groups {
+		i := self.offset + k
+		groupYCoordinate := barYCoordinate
+
+		for s, data := range group {
+			if barYCoordinate >= area.Max.Y {
+				break
+			}
+			middle := barYCoordinate + (MinInt(self.BarWidth, area.Max.Y-barYCoordinate)-1)/2
+
+			// draw bar
+			length := int(data * scale)
+			bar := image.Rect(zeroXCoordinate+1, barYCoordinate, zeroXCoordinate+1+length, barYCoordinate+self.BarWidth)
+			if data < 0 {
+				bar = image.Rect(zeroXCoordinate+length, barYCoordinate, zeroXCoordinate, barYCoordinate+self.BarWidth)
+			}
+			buf.Fill(
+				NewCell(' ', NewStyle(ColorClear, SelectColor(self.BarColors, self.barIndex(i, s)))),
+				bar.Intersect(area),
+			)
+
+			// draw number, ending at the baseline for negative values
+			number := self.NumFormatter(data)
+			numberXCoordinate := zeroXCoordinate + 1
+			if data < 0 {
+				numberXCoordinate = MaxInt(zeroXCoordinate-rw.StringWidth(number), barXCoordinate)
+			}
 			buf.SetString(
-				self.Labels[i],
-				SelectStyle(self.LabelStyles, i),
-				image.Pt(labelXCoordinate, self.Inner.Max.Y-1),
+				TrimString(number, area.Max.X-numberXCoordinate),
+				self.numStyle(i, s),
+				image.Pt(numberXCoordinate, middle),
 			)
+
+			barYCoordinate += self.BarWidth
 		}
 
-		// draw number
-		numberXCoordinate := barXCoordinate + int((float64(self.BarWidth) / 2))
-		if numberXCoordinate <= self.Inner.Max.X {
+		// draw label
+		if i < len(self.Labels) && gutter > 1 && groupYCoordinate < area.Max.Y {
+			groupHeight := MinInt(barYCoordinate, area.Max.Y) - groupYCoordinate
 			buf.SetString(
-				self.NumFormatter(data),
-				NewStyle(
-					SelectStyle(self.NumStyles, i+1).Fg,
-					SelectColor(self.BarColors, i),
-					SelectStyle(self.NumStyles, i+1).Modifier,
-				),
-				image.Pt(numberXCoordinate, self.Inner.Max.Y-2),
+				TrimString(self.Labels[i], gutter-1),
+				SelectStyle(self.LabelStyles, i),
+				image.Pt(area.Min.X, groupYCoordinate+(groupHeight-1)/2),
 			)
 		}
 
-		barXCoordinate += (self.BarWidth + self.BarGap)
+		barYCoordinate += self.BarGap
+	}
+}
+
+// ScrollAmount scrolls by amount groups of bars. If amount is < 0, then scroll toward the first group.
+// The offset is limited to the number of groups on the next Draw.
+func (self *BarChart) ScrollAmount(amount int) {
+	self.offset = MaxInt(self.offset+amount, 0)
+}
+
+func (self *BarChart) ScrollLeft() {
+	self.ScrollAmount(-1)
+}
+
+func (self *BarChart) ScrollRight() {
+	self.ScrollAmount(1)
+}
+
+func (self *BarChart) ScrollPageLeft() {
+	self.ScrollAmount(-MaxInt(self.shown, 1))
+}
+
+func (self *BarChart) ScrollPageRight() {
+	self.ScrollAmount(MaxInt(self.shown, 1))
+}
+
+func (self *BarChart) ScrollStart() {
+	self.offset = 0
+}
+
+func (self *BarChart) ScrollEnd() {
+	self.offset = len(self.groups())
+}
+
+// HandleScrollKey scrolls for <Left>, <Right>, <Up>, <Down>, <PageUp>, <PageDown>, <Home>, and <End>
+// and reports whether the keyboard event ID was used.
+func (self *BarChart) HandleScrollKey(id string) bool {
+	switch id {
+	case "<Left>", "<Up>":
+		self.ScrollLeft()
+	case "<Right>", "<Down>":
+		self.ScrollRight()
+	case "<PageUp>":
+		self.ScrollPageLeft()
+	case "<PageDown>":
+		self.ScrollPageRight()
+	case "<Home>":
+		self.ScrollStart()
+	case "<End>":
+		self.ScrollEnd()
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse scrolls with the mouse wheel over the chart and reports whether the event was used.
+func (self *BarChart) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Rectangle) {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.ScrollAmount(-WheelSteps(e))
+	case "<MouseWheelDown>":
+		self.ScrollAmount(WheelSteps(e))
+	default:
+		return false
 	}
+	return true
 }
@@ -0,0 +1,106 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	ui "github.com/reaalkhalil/termui"
+	"github.com/reaalkhalil/termui/widgets"
+)
+
+// draw draws a widget into a new Buffer b.N times, like Render does.
+func draw(b *testing.B, widget ui.Drawable) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		widget.Draw(ui.NewBuffer(widget.GetRect()))
+	}
+}
+
+func newTable(rows int) *widgets.Table {
+	table := widgets.NewTable()
+	table.Header = []string{"id", "name", "status", "cpu", "memory", "uptime"}
+	for i := 0; i < rows; i++ {
+		table.Rows = append(table.Rows, []string{
+			fmt.Sprint(i), fmt.Sprintf("process-%d", i), "[running](fg:green)",
+			fmt.Sprintf("%.1f%%", math.Mod(float64(i)*7.3, 100)), fmt.Sprintf("%d MB", i%512), "3d 4h",
+		})
+	}
+	table.SetRect(0, 0, 200, 60)
+	return table
+}
+
+func BenchmarkTable(b *testing.B) {
+	draw(b, newTable(10000))
+}
+
+func BenchmarkTableAutoWidths(b *testing.B) {
+	table := newTable(10000)
+	table.AutoColumnWidths = true
+	draw(b, table)
+}
+
+func BenchmarkPlot(b *testing.B) {
+	plot := widgets.NewPlot()
+	for series := 0; series < 4; series++ {
+		data := []float64{}
+		for x := 0; x < 500; x++ {
+			data = append(data, math.Sin(float64(x+series*20)/15)+float64(series))
+		}
+		plot.Data = append(plot.Data, data)
+	}
+	plot.SetRect(0, 0, 200, 60)
+	draw(b, plot)
+}
+
+func BenchmarkParagraph(b *testing.B) {
+	paragraph := widgets.NewParagraph()
+	paragraph.Text = strings.Repeat("Lorem ipsum [dolor](fg:yellow) sit amet, consectetur adipiscing elit. ", 100)
+	paragraph.SetRect(0, 0, 200, 60)
+	draw(b, paragraph)
+}
+
+func BenchmarkList(b *testing.B) {
+	list := widgets.NewList()
+	for i := 0; i < 10000; i++ {
+		list.Rows = append(list.Rows, fmt.Sprintf("[%d] item number [%d](fg:blue)", i, i))
+	}
+	list.SetRect(0, 0, 200, 60)
+	draw(b, list)
+}
+
+func BenchmarkBarChart(b *testing.B) {
+	chart := widgets.NewBarChart()
+	for i := 0; i < 40; i++ {
+		chart.Data = append(chart.Data, float64(i%13))
+		chart.Labels = append(chart.Labels, fmt.Sprint(i))
+	}
+	chart.SetRect(0, 0, 200, 60)
+	draw(b, chart)
+}
+
+func BenchmarkSparkline(b *testing.B) {
+	sparklines := []*widgets.Sparkline{}
+	for i := 0; i < 4; i++ {
+		sparkline := widgets.NewSparkline()
+		for x := 0; x < 200; x++ {
+			sparkline.Data = append(sparkline.Data, 1+math.Sin(float64(x+i*10)/8))
+		}
+		sparklines = append(sparklines, sparkline)
+	}
+	group := widgets.NewSparklineGroup(sparklines...)
+	group.SetRect(0, 0, 200, 60)
+	draw(b, group)
+}
+
+func BenchmarkGauge(b *testing.B) {
+	gauge := widgets.NewGauge()
+	gauge.Percent = 42
+	gauge.SetRect(0, 0, 200, 3)
+	draw(b, gauge)
+}
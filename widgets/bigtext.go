@@ -0,0 +1,282 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"strings"
+	"unicode"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// BigTextFont is a font of glyphs drawn with pixels, as rows of the same length where '#' marks
+// the pixels set. Runes without a glyph are drawn with the glyph of '?', and letters are looked up
+// in upper case when they have no glyph of their own.
+type BigTextFont struct {
+	Height int
+	Glyphs map[rune][]string
+}
+
+// BigTextBlockFont is the font of BigText, five pixels high, with glyphs for letters, digits,
+// and common punctuation.
+var BigTextBlockFont = &BigTextFont{
+	Height: 5,
+	Glyphs: map[rune][]string{
+		'A': {" ### ", "#   #", "#####", "#   #", "#   #"},
+		'B': {"#### ", "#   #", "#### ", "#   #", "#### "},
+		'C': {" ####", "#    ", "#    ", "#    ", " ####"},
+		'D': {"#### ", "#   #", "#   #", "#   #", "#### "},
+		'E': {"#####", "#    ", "#### ", "#    ", "#####"},
+		'F': {"#####", "#    ", "#### ", "#    ", "#    "},
+		'G': {" ####", "#    ", "#  ##", "#   #", " ####"},
+		'H': {"#   #", "#   #", "#####", "#   #", "#   #"},
+		'I': {"###", " # ", " # ", " # ", "###"},
+		'J': {"  ###", "   # ", "   # ", "#  # ", " ##  "},
+		'K': {"#   #", "#  # ", "###  ", "#  # ", "#   #"},
+		'L': {"#    ", "#    ", "#    ", "#    ", "#####"},
+		'M': {"#   #", "## ##", "# # #", "#   #", "#   #"},
+		'N': {"#   #", "##  #", "# # #", "#  ##", "#   #"},
+		'O': {" ### ", "#   #", "#   #", "#   #", " ### "},
+		'P': {"#### ", "#   #", "#### ", "#    ", "#    "},
+		'Q': {" ### ", "#   #", "# # #", "#  # ", " ## #"},
+		'R': {"#### ", "#   #", "#### ", "#  # ", "#   #"},
+		'S': {" ####", "#    ", " ### ", "    #", "#### "},
+		'T': {"#####", "  #  ", "  #  ", "  #  ", "  #  "},
+		'U': {"#   #", "#   #", "#   #", "#   #", " ### "},
+		'V': {"#   #", "#   #", "#   #", " # # ", "  #  "},
+		'W': {"#   #", "#   #", "# # #", "## ##", "#   #"},
+		'X': {"#   #", " # # ", "  #  ", " # # ", "#   #"},
+		'Y': {"#   #", " # # ", "  #  ", "  #  ", "  #  "},
+		'Z': {"#####", "   # ", "  #  ", " #   ", "#####"},
+
+		'0': {" ### ", "#  ##", "# # #", "##  #", " ### "},
+		'1': {"  #  ", " ##  ", "  #  ", "  #  ", " ### "},
+		'2': {" ### ", "#   #", "  ## ", " #   ", "#####"},
+		'3': {"#### ", "    #", " ### ", "    #", "#### "},
+		'4': {"#   #", "#   #", "#####", "    #", "    #"},
+		'5': {"#####", "#    ", "#### ", "    #", "#### "},
+		'6': {" ### ", "#    ", "#### ", "#   #", " ### "},
+		'7': {"#####", "    #", "   # ", "  #  ", "  #  "},
+		'8': {" ### ", "#   #", " ### ", "#   #", " ### "},
+		'9': {" ### ", "#   #", " ####", "    #", " ### "},
+
+		' ':  {"   ", "   ", "   ", "   ", "   "},
+		':':  {" ", "#", " ", "#", " "},
+		'.':  {" ", " ", " ", " ", "#"},
+		',':  {"  ", "  ", "  ", " #", "# "},
+		'\'': {"#", "#", " ", " ", " "},
+		'!':  {"#", "#", "#", " ", "#"},
+		'?':  {"### ", "   #", " ## ", "    ", " #  "},
+		'-':  {"    ", "    ", "####", "    ", "    "},
+		'+':  {"     ", "  #  ", "#####", "  #  ", "     "},
+		'=':  {"    ", "####", "    ", "####", "    "},
+		'_':  {"    ", "    ", "    ", "    ", "####"},
+		'/':  {"    #", "   # ", "  #  ", " #   ", "#    "},
+		'%':  {"#   #", "   # ", "  #  ", " #   ", "#   #"},
+		'(':  {" #", "# ", "# ", "# ", " #"},
+		')':  {"# ", " #", " #", " #", "# "},
+		'°':  {"###", "# #", "###", "   ", "   "},
+	},
+}
+
+// glyph returns the rows of the glyph of r.
+func (self *BigTextFont) glyph(r rune) []string {
+	if glyph, ok := self.Glyphs[r]; ok {
+		return glyph
+	}
+	if glyph, ok := self.Glyphs[unicode.ToUpper(r)]; ok {
+		return glyph
+	}
+	return self.Glyphs['?']
+}
+
+// BigTextSize is the size of the pixels of a BigText.
+type BigTextSize int
+
+const (
+	// BigTextSmall draws two pixels per cell with half blocks, for square pixels.
+	BigTextSmall BigTextSize = iota
+	// BigTextMedium draws a pixel per cell.
+	BigTextMedium
+	// BigTextLarge draws a pixel as two cells across and two down.
+	BigTextLarge
+)
+
+// BigText draws Text with the pixels of Font, separated by a pixel, in lines split at newlines.
+// The letters of Text are colored with Colors in turn, or, with Gradient, the text is colored
+// with Colors blended from left to right. Without Colors, the foreground of TextStyle is used.
+type BigText struct {
+	Block
+	Text          string
+	Font          *BigTextFont
+	Size          BigTextSize
+	TextStyle     Style
+	TextAlignment Alignment
+	Colors        []Color
+	Gradient      bool
+}
+
+func NewBigText() *BigText {
+	return &BigText{
+		Block:     *NewBlock(),
+		Font:      BigTextBlockFont,
+		Size:      BigTextMedium,
+		TextStyle: Theme.BigText.Text,
+	}
+}
+
+// pixelWidth returns the width of a line in pixels.
+func (self *BigText) pixelWidth(line string) int {
+	width := 0
+	for i, r := range []rune(line) {
+		if i > 0 {
+			width++
+		}
+		width += len([]rune(self.font().glyph(r)[0]))
+	}
+	return width
+}
+
+func (self *BigText) font() *BigTextFont {
+	if self.Font == nil {
+		return BigTextBlockFont
+	}
+	return self.Font
+}
+
+// cellSize returns the number of cells across and down pixels.
+func (self *BigText) cellSize(width, height int) (int, int) {
+	switch self.Size {
+	case BigTextSmall:
+		return width, (height + 1) / 2
+	case BigTextLarge:
+		return width * 2, height * 2
+	}
+	return width, height
+}
+
+// TextSize returns the number of cells across and down the text drawn, to size the BigText to fit
+// it.
+func (self *BigText) TextSize() image.Point {
+	lines := strings.Split(self.Text, "\n")
+	width := 0
+	for _, line := range lines {
+		width = MaxInt(width, self.pixelWidth(line))
+	}
+	width, height := self.cellSize(width, self.font().Height)
+	return image.Pt(width, height*len(lines)+len(lines)-1)
+}
+
+// letterColor returns the color of the letter with an index drawn in column x of a line of a
+// width.
+func (self *BigText) letterColor(index, x, width int) Color {
+	switch {
+	case len(self.Colors) == 0:
+		return self.TextStyle.Fg
+	case self.Gradient && width > 1:
+		return gradientColor(self.Colors, float64(x)/float64(width-1))
+	case self.Gradient:
+		return self.Colors[0]
+	}
+	return self.Colors[index%len(self.Colors)]
+}
+
+func (self *BigText) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+
+	font := self.font()
+	_, lineHeight := self.cellSize(0, font.Height)
+	y := self.Inner.Min.Y
+	letter := 0
+	for _, line := range strings.Split(self.Text, "\n") {
+		// the pixels of the line and their colors
+		width := self.pixelWidth(line)
+		pixels := make([][]bool, font.Height)
+		colors := make([]Color, width)
+		for i := range pixels {
+			pixels[i] = make([]bool, width)
+		}
+		x := 0
+		for _, r := range line {
+			glyph := font.glyph(r)
+			glyphWidth := len([]rune(glyph[0]))
+			for row, text := range glyph {
+				for column, pixel := range []rune(text) {
+					pixels[row][x+column] = pixel == '#'
+				}
+			}
+			for column := x; column < x+glyphWidth; column++ {
+				colors[column] = self.letterColor(letter, column, width)
+			}
+			if r != ' ' {
+				letter++
+			}
+			x += glyphWidth + 1
+		}
+
+		cellWidth, _ := self.cellSize(width, font.Height)
+		left := self.Inner.Min.X
+		switch self.TextAlignment {
+		case AlignCenter:
+			left += (self.Inner.Dx() - cellWidth) / 2
+		case AlignRight:
+			left += self.Inner.Dx() - cellWidth
+		}
+		for row := 0; row < lineHeight; row++ {
+			for column := 0; column < cellWidth; column++ {
+				point := image.Pt(left+column, y+row)
+				if !point.In(self.Inner) {
+					continue
+				}
+				style := self.TextStyle
+				switch self.Size {
+				case BigTextSmall:
+					upper := pixels[2*row][column]
+					lower := 2*row+1 < font.Height && pixels[2*row+1][column]
+					style.Fg = colors[column]
+					switch {
+					case upper && lower:
+						buf.SetCell(NewCell(BARS[8], style), point)
+					case upper:
+						buf.SetCell(NewCell(UPPER_HALF_BLOCK, style), point)
+					case lower:
+						buf.SetCell(NewCell(BARS[4], style), point)
+					}
+				case BigTextLarge:
+					if pixels[row/2][column/2] {
+						style.Fg = colors[column/2]
+						buf.SetCell(NewCell(BARS[8], style), point)
+					}
+				default:
+					if pixels[row][column] {
+						style.Fg = colors[column]
+						buf.SetCell(NewCell(BARS[8], style), point)
+					}
+				}
+			}
+		}
+		y += lineHeight + 1
+	}
+}
+
+// gradientColor returns the color at t, from 0 to 1, of a gradient through colors.
+func gradientColor(colors []Color, t float64) Color {
+	if len(colors) == 1 {
+		return colors[0]
+	}
+	position := MaxFloat64(MinFloat64(t, 1), 0) * float64(len(colors)-1)
+	i := int(position)
+	if i >= len(colors)-1 {
+		return colors[len(colors)-1]
+	}
+	f := position - float64(i)
+	r1, g1, b1 := colors[i].RGB()
+	r2, g2, b2 := colors[i+1].RGB()
+	blend := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*f + 0.5)
+	}
+	return NewRGBColor(blend(r1, r2), blend(g1, g2), blend(b1, b2))
+}
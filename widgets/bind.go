@@ -0,0 +1,291 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// BindRenderInterval is the shortest time between two renders of the widgets changed by bindings,
+// so that values received faster than the screen can show them are drawn together.
+var BindRenderInterval = 50 * time.Millisecond
+
+// Bindable is implemented by widgets other than the ones of this package to be fed by Bind.
+type Bindable interface {
+	Drawable
+	// BindValue adds or sets a value of a series while holding the render lock.
+	BindValue(series int, value float64)
+}
+
+// Binding feeds the values received on a channel into a series of a widget.
+type Binding struct {
+	widget Drawable
+	window int
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// Bind feeds the values received on ch into a series of a widget until ch is closed or the
+// Binding is stopped, and renders the widget after they change it, so that applications don't
+// need loops updating their widgets:
+//
+//	cpu := widgets.NewPlot()
+//	widgets.Bind(cpu, 0, cpuSamples)
+//
+// Values are appended to Data[series] of a Plot, BarChart Data[series] is set, a Gauge shows the
+// value as its Percent, and a SparklineGroup appends it to Sparklines[series].
+// Widgets implementing Bindable are given the values. Bind panics for any other widget.
+//
+// Values are read as soon as they are sent, so that senders aren't held up by rendering, and
+// those received together are drawn in one frame. The widget is changed with Update, so it
+// can be rendered from other goroutines too, and marked with MarkDirty to be drawn by
+// RenderDirty.
+func Bind(widget Drawable, series int, ch <-chan float64) *Binding {
+	apply := bindFunc(widget, series)
+	self := newBinding(widget)
+	go self.run(ch, apply)
+	return self
+}
+
+// BindSeries replaces a series of a widget with the values received on ch, like the results of a
+// range query: Data[series] of a Plot, or the Data of Sparklines[series] of a SparklineGroup. Only
+// the last values of the Binding window are kept, and series received faster than they are drawn
+// are skipped.
+func BindSeries(widget Drawable, series int, ch <-chan []float64) *Binding {
+	var apply func(values []float64, window int)
+	switch widget := widget.(type) {
+	case *Plot:
+		apply = func(values []float64, window int) {
+			for len(widget.Data) <= series {
+				widget.Data = append(widget.Data, []float64{})
+			}
+			widget.Data[series] = lastValues(values, window)
+		}
+	case *SparklineGroup:
+		if series < 0 || series >= len(widget.Sparklines) {
+			panic(fmt.Sprintf("BindSeries() given series %d of %d Sparklines", series, len(widget.Sparklines)))
+		}
+		apply = func(values []float64, window int) {
+			widget.Sparklines[series].Data = lastValues(values, window)
+		}
+	default:
+		panic(fmt.Sprintf("BindSeries() given an unsupported widget %T", widget))
+	}
+	self := newBinding(widget)
+	go func() {
+		for {
+			select {
+			case <-self.stop:
+				return
+			case values, ok := <-ch:
+				if !ok {
+					return
+				}
+			drain:
+				for {
+					select {
+					case latest, ok := <-ch:
+						if !ok {
+							break drain
+						}
+						values = latest
+					default:
+						break drain
+					}
+				}
+				self.change(func(window int) {
+					apply(values, window)
+				})
+			}
+		}
+	}()
+	return self
+}
+
+// BindRows replaces the Rows of a Table with the ones received on ch. Rows received faster than
+// they are drawn are skipped.
+func BindRows(table *Table, ch <-chan [][]string) *Binding {
+	self := newBinding(table)
+	go func() {
+		for {
+			select {
+			case <-self.stop:
+				return
+			case rows, ok := <-ch:
+				if !ok {
+					return
+				}
+			drain:
+				for {
+					select {
+					case latest, ok := <-ch:
+						if !ok {
+							break drain
+						}
+						rows = latest
+					default:
+						break drain
+					}
+				}
+				self.change(func(int) {
+					table.Rows = rows
+				})
+			}
+		}
+	}()
+	return self
+}
+
+func newBinding(widget Drawable) *Binding {
+	return &Binding{
+		widget: widget,
+		stop:   make(chan struct{}),
+	}
+}
+
+// SetWindow sets the number of values kept in a series of a Plot or Sparkline, the oldest being
+// dropped. Zero, the default, keeps as many as the width of the widget.
+func (self *Binding) SetWindow(window int) *Binding {
+	Update(func() {
+		self.window = window
+	})
+	return self
+}
+
+// Stop stops reading the channel. Values already read are still drawn.
+func (self *Binding) Stop() {
+	self.once.Do(func() { close(self.stop) })
+}
+
+// change applies a change to the widget, with the number of values kept in a series, and
+// renders it.
+func (self *Binding) change(apply func(window int)) {
+	Update(func() {
+		window := self.window
+		if window <= 0 {
+			window = MaxInt(self.widget.GetRect().Dx()-2, 1)
+		}
+		apply(window)
+	})
+	scheduleBindRender(self.widget)
+}
+
+func (self *Binding) run(ch <-chan float64, apply func(value float64, window int)) {
+	for {
+		select {
+		case <-self.stop:
+			return
+		case value, ok := <-ch:
+			if !ok {
+				return
+			}
+			values := []float64{value}
+			// take what else was sent meanwhile, to change the widget once
+		drain:
+			for {
+				select {
+				case value, ok := <-ch:
+					if !ok {
+						break drain
+					}
+					values = append(values, value)
+				default:
+					break drain
+				}
+			}
+			self.change(func(window int) {
+				for _, value := range values {
+					apply(value, window)
+				}
+			})
+		}
+	}
+}
+
+// bindFunc returns the function setting a value of a series of a widget.
+func bindFunc(widget Drawable, series int) func(float64, int) {
+	if series < 0 {
+		panic(fmt.Sprintf("Bind() given a negative series %d", series))
+	}
+	switch widget := widget.(type) {
+	case *Plot:
+		return func(value float64, window int) {
+			for len(widget.Data) <= series {
+				widget.Data = append(widget.Data, []float64{})
+			}
+			widget.Data[series] = appendWindow(widget.Data[series], value, window)
+		}
+	case *SparklineGroup:
+		if series >= len(widget.Sparklines) {
+			panic(fmt.Sprintf("Bind() given series %d of %d Sparklines", series, len(widget.Sparklines)))
+		}
+		return func(value float64, window int) {
+			sparkline := widget.Sparklines[series]
+			sparkline.Data = appendWindow(sparkline.Data, value, window)
+		}
+	case *BarChart:
+		return func(value float64, window int) {
+			for len(widget.Data) <= series {
+				widget.Data = append(widget.Data, 0)
+			}
+			widget.Data[series] = value
+		}
+	case *Gauge:
+		return func(value float64, window int) {
+			widget.Percent = int(value)
+		}
+	case Bindable:
+		return func(value float64, window int) {
+			widget.BindValue(series, value)
+		}
+	}
+	panic(fmt.Sprintf("Bind() given an unsupported widget %T", widget))
+}
+
+// appendWindow appends a value to data, dropping the oldest ones past window.
+func appendWindow(data []float64, value float64, window int) []float64 {
+	data = append(data, value)
+	return lastValues(data, window)
+}
+
+// lastValues returns the last window values of data.
+func lastValues(data []float64, window int) []float64 {
+	if len(data) > window {
+		return data[len(data)-window:]
+	}
+	return data
+}
+
+var bindRender = struct {
+	sync.Mutex
+	timer *time.Timer
+	last  time.Time
+}{}
+
+// scheduleBindRender marks a widget changed by a binding dirty, and renders it with RenderDirty
+// with the others changed within BindRenderInterval.
+func scheduleBindRender(widget Drawable) {
+	MarkDirty(widget)
+	bindRender.Lock()
+	defer bindRender.Unlock()
+	if bindRender.timer == nil {
+		wait := BindRenderInterval - time.Since(bindRender.last)
+		if wait < 0 {
+			wait = 0
+		}
+		bindRender.timer = time.AfterFunc(wait, renderBound)
+	}
+}
+
+func renderBound() {
+	bindRender.Lock()
+	bindRender.timer, bindRender.last = nil, time.Now()
+	bindRender.Unlock()
+	RenderDirty()
+}
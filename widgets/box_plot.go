@@ -0,0 +1,206 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// BoxPlotStats summarizes a sample set: the whiskers reach from Min to Max, the box from Q1 to Q3
+// with a line at the Median, and the Outliers are drawn as dots beyond the whiskers.
+type BoxPlotStats struct {
+	Min      float64
+	Q1       float64
+	Median   float64
+	Q3       float64
+	Max      float64
+	Outliers []float64
+}
+
+// NewBoxPlotStats returns the quartiles of samples, with whiskers reaching the farthest samples
+// within 1.5 times the interquartile range of the box. The samples beyond them are Outliers.
+func NewBoxPlotStats(samples []float64) BoxPlotStats {
+	if len(samples) == 0 {
+		return BoxPlotStats{}
+	}
+	sorted := append([]float64{}, samples...)
+	sort.Float64s(sorted)
+	quantile := func(q float64) float64 {
+		position := q * float64(len(sorted)-1)
+		i := int(position)
+		if i+1 >= len(sorted) {
+			return sorted[i]
+		}
+		return sorted[i] + (sorted[i+1]-sorted[i])*(position-float64(i))
+	}
+	stats := BoxPlotStats{Q1: quantile(0.25), Median: quantile(0.5), Q3: quantile(0.75)}
+	low := stats.Q1 - 1.5*(stats.Q3-stats.Q1)
+	high := stats.Q3 + 1.5*(stats.Q3-stats.Q1)
+	stats.Min, stats.Max = stats.Q1, stats.Q3
+	for _, sample := range sorted {
+		if sample < low || sample > high {
+			stats.Outliers = append(stats.Outliers, sample)
+			continue
+		}
+		stats.Min, stats.Max = math.Min(stats.Min, sample), math.Max(stats.Max, sample)
+	}
+	return stats
+}
+
+// BoxPlot draws a box and whiskers per category on a row, with their Labels on the left and a value
+// axis shared by all of them below.
+type BoxPlot struct {
+	Block
+	Labels []string
+	// Samples holds the sample set of each category, summarized with NewBoxPlotStats.
+	Samples [][]float64
+	// Stats holds precomputed statistics of each category, drawn instead of the Samples when set.
+	Stats []BoxPlotStats
+	// MinVal and MaxVal are the ends of the value axis. When both are zero, they're taken from
+	// the statistics.
+	MinVal float64
+	MaxVal float64
+
+	BoxColors    []Color
+	MedianColor  Color
+	LabelStyle   Style
+	WhiskerStyle Style
+	AxisStyle    Style
+	// NumFormatter formats the values of the axis.
+	NumFormatter func(float64) string
+	// BoxGap is the number of rows between the boxes.
+	BoxGap int
+}
+
+func NewBoxPlot() *BoxPlot {
+	return &BoxPlot{
+		Block:        *NewBlock(),
+		BoxColors:    Theme.BoxPlot.Boxes,
+		MedianColor:  Theme.BoxPlot.Median,
+		LabelStyle:   Theme.BoxPlot.Labels,
+		WhiskerStyle: Theme.BoxPlot.Whiskers,
+		AxisStyle:    Theme.BoxPlot.Axis,
+		NumFormatter: FormatNumber,
+		BoxGap:       1,
+	}
+}
+
+// stats returns the Stats, or the statistics of the Samples when there are none.
+func (self *BoxPlot) stats() []BoxPlotStats {
+	if len(self.Stats) > 0 {
+		return self.Stats
+	}
+	stats := make([]BoxPlotStats, len(self.Samples))
+	for i, samples := range self.Samples {
+		stats[i] = NewBoxPlotStats(samples)
+	}
+	return stats
+}
+
+func (self *BoxPlot) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	stats := self.stats()
+	if len(stats) == 0 || self.Inner.Dy() < 3 {
+		return
+	}
+
+	minVal, maxVal := self.MinVal, self.MaxVal
+	if minVal == 0 && maxVal == 0 {
+		minVal, maxVal = math.Inf(1), math.Inf(-1)
+		for _, s := range stats {
+			minVal, maxVal = math.Min(minVal, s.Min), math.Max(maxVal, s.Max)
+			for _, outlier := range s.Outliers {
+				minVal, maxVal = math.Min(minVal, outlier), math.Max(maxVal, outlier)
+			}
+		}
+	}
+	if maxVal <= minVal {
+		maxVal = minVal + 1
+	}
+
+	gutter := 0
+	for _, label := range self.Labels {
+		gutter = MaxInt(gutter, rw.StringWidth(label)+1)
+	}
+	gutter = MinInt(gutter, self.Inner.Dx()/3)
+	// the axis and its labels take the last two rows
+	area := image.Rect(self.Inner.Min.X+gutter, self.Inner.Min.Y, self.Inner.Max.X, self.Inner.Max.Y-2)
+	if area.Dx() < 2 {
+		return
+	}
+	column := func(value float64) int {
+		x := area.Min.X + int(math.Round((value-minVal)/(maxVal-minVal)*float64(area.Dx()-1)))
+		return MaxInt(MinInt(x, area.Max.X-1), area.Min.X)
+	}
+
+	for i, s := range stats {
+		y := area.Min.Y + i*(1+self.BoxGap)
+		if y >= area.Max.Y {
+			break
+		}
+		if i < len(self.Labels) {
+			buf.SetString(TrimString(self.Labels[i], gutter-1), self.LabelStyle, image.Pt(self.Inner.Min.X, y))
+		}
+		color := SelectColor(self.BoxColors, i)
+
+		low, q1, median, q3, high := column(s.Min), column(s.Q1), column(s.Median), column(s.Q3), column(s.Max)
+		buf.Fill(NewCell(HORIZONTAL_LINE, self.WhiskerStyle), image.Rect(low, y, high+1, y+1))
+		buf.SetCell(NewCell(VERTICAL_RIGHT, self.WhiskerStyle), image.Pt(low, y))
+		buf.SetCell(NewCell(VERTICAL_LEFT, self.WhiskerStyle), image.Pt(high, y))
+		buf.Fill(NewCell(' ', NewStyle(ColorClear, color)), image.Rect(q1, y, q3+1, y+1))
+		buf.SetCell(NewCell(VERTICAL_LINE, NewStyle(self.MedianColor, color)), image.Pt(median, y))
+		for _, outlier := range s.Outliers {
+			buf.SetCell(NewCell(DOT, NewStyle(color)), image.Pt(column(outlier), y))
+		}
+	}
+
+	self.drawAxis(buf, area, column, minVal, maxVal)
+}
+
+// niceStep returns the step of 1, 2, or 5 times a power of ten nearest above span.
+func niceStep(span float64) float64 {
+	power := math.Pow(10, math.Floor(math.Log10(span)))
+	for _, step := range []float64{1, 2, 5} {
+		if step*power >= span {
+			return step * power
+		}
+	}
+	return 10 * power
+}
+
+// drawAxis draws a line with ticks below area on round values, and the values of the ticks below
+// it, as many as fit with some space between them.
+func (self *BoxPlot) drawAxis(buf *Buffer, area image.Rectangle, column func(float64) int, minVal, maxVal float64) {
+	y := self.Inner.Max.Y - 2
+	buf.Fill(NewCell(HORIZONTAL_LINE, self.AxisStyle), image.Rect(area.Min.X, y, area.Max.X, y+1))
+
+	width := MaxInt(rw.StringWidth(self.NumFormatter(minVal)), rw.StringWidth(self.NumFormatter(maxVal)))
+	step := niceStep((maxVal - minVal) / float64(MaxInt(area.Dx()/(width+2), 1)))
+	labelEnd := self.Inner.Min.X
+	for n := math.Ceil(minVal / step); n*step <= maxVal; n++ {
+		// dividing by the inverse of a fractional step keeps values like 0.3 exact
+		value := n * step
+		if step < 1 {
+			value = n / math.Round(1/step)
+		}
+		x := column(value)
+		buf.SetCell(NewCell(HORIZONTAL_DOWN, self.AxisStyle), image.Pt(x, y))
+
+		// center the value on its tick, keeping it inside the widget
+		label := self.NumFormatter(value)
+		labelX := MinInt(x-rw.StringWidth(label)/2, self.Inner.Max.X-rw.StringWidth(label))
+		if labelX < labelEnd {
+			continue
+		}
+		buf.SetString(label, self.AxisStyle, image.Pt(labelX, y+1))
+		labelEnd = labelX + rw.StringWidth(label) + 1
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// Breadcrumbs shows a path of Segments, such as the directories of a file path, on the first row of
+// its rect when it has no border. When the path doesn't fit, the segments in the middle are replaced
+// by an ellipsis, keeping the first and the last ones. Selecting an ancestor, by clicking it or with
+// the arrow keys and <Enter>, drops the segments after it and calls OnChange.
+type Breadcrumbs struct {
+	Block
+	Segments  []string
+	Separator string
+
+	TextStyle      Style
+	CurrentStyle   Style
+	SeparatorStyle Style
+
+	// Focused draws the segment under the cursor with FocusedStyle. Keys are only handled while it is set.
+	Focused      bool
+	FocusedStyle Style
+
+	// OnChange is called with the remaining segments after an ancestor is selected.
+	OnChange func(segments []string)
+
+	cursor int
+	// spans holds the area of each segment drawn by the last Draw, empty for the hidden ones.
+	spans []image.Rectangle
+}
+
+func NewBreadcrumbs(segments ...string) *Breadcrumbs {
+	self := &Breadcrumbs{
+		Block:          *NewBlock(),
+		Segments:       segments,
+		Separator:      " › ",
+		TextStyle:      Theme.Breadcrumbs.Text,
+		CurrentStyle:   Theme.Breadcrumbs.Current,
+		SeparatorStyle: Theme.Breadcrumbs.Separator,
+		FocusedStyle:   Theme.Breadcrumbs.Focused,
+		cursor:         len(segments) - 1,
+	}
+	self.Border = false
+	return self
+}
+
+// SetPath sets the Segments to the elements of path split at sep, leaving out empty ones,
+// and moves the cursor to the last one.
+func (self *Breadcrumbs) SetPath(path string, sep string) {
+	self.Segments = self.Segments[:0]
+	for _, segment := range strings.Split(path, sep) {
+		if segment != "" {
+			self.Segments = append(self.Segments, segment)
+		}
+	}
+	self.cursor = len(self.Segments) - 1
+}
+
+// Push adds a segment to the end of the path and moves the cursor to it.
+func (self *Breadcrumbs) Push(segment string) {
+	self.Segments = append(self.Segments, segment)
+	self.cursor = len(self.Segments) - 1
+}
+
+// Pop removes the last segment and returns it, or "" if there is none.
+func (self *Breadcrumbs) Pop() string {
+	if len(self.Segments) == 0 {
+		return ""
+	}
+	last := self.Segments[len(self.Segments)-1]
+	self.Segments = self.Segments[:len(self.Segments)-1]
+	self.cursor = len(self.Segments) - 1
+	return last
+}
+
+// Select drops the segments after the one at index and calls OnChange.
+func (self *Breadcrumbs) Select(index int) {
+	if index < 0 || index >= len(self.Segments) {
+		return
+	}
+	self.cursor = index
+	if index == len(self.Segments)-1 {
+		return
+	}
+	self.Segments = self.Segments[:index+1]
+	if self.OnChange != nil {
+		self.OnChange(self.Segments)
+	}
+}
+
+// Cursor returns the index of the segment under the cursor.
+func (self *Breadcrumbs) Cursor() int {
+	return MinInt(self.cursor, len(self.Segments)-1)
+}
+
+func (self *Breadcrumbs) SetFocused(focused bool) {
+	self.Focused = focused
+}
+
+// FormValue returns the Segments for a Form.
+func (self *Breadcrumbs) FormValue() interface{} {
+	return self.Segments
+}
+
+// HandleKey moves the cursor with <Left> and <Right>, <Home> and <End>, and selects the segment
+// under it with <Enter>. <Backspace> selects the parent of the last segment.
+// It reports whether the key was used.
+func (self *Breadcrumbs) HandleKey(id string) bool {
+	if !self.Focused || len(self.Segments) == 0 {
+		return false
+	}
+	cursor := self.Cursor()
+	switch id {
+	case "<Left>", "h":
+		self.cursor = MaxInt(cursor-1, 0)
+	case "<Right>", "l":
+		self.cursor = MinInt(cursor+1, len(self.Segments)-1)
+	case "<Home>":
+		self.cursor = 0
+	case "<End>":
+		self.cursor = len(self.Segments) - 1
+	case "<Enter>", "<Space>":
+		self.Select(cursor)
+	case "<Backspace>":
+		self.Select(len(self.Segments) - 2)
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse selects a clicked segment and reports whether the event was used.
+func (self *Breadcrumbs) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag {
+		return false
+	}
+	for i, span := range self.spans {
+		if p.In(span) {
+			self.Select(i)
+			return true
+		}
+	}
+	return false
+}
+
+// row returns the area the segments are drawn in.
+func (self *Breadcrumbs) row() image.Rectangle {
+	area := self.Rectangle
+	if self.Border {
+		area = self.Inner
+	}
+	return image.Rect(area.Min.X, area.Min.Y, area.Max.X, area.Min.Y+1)
+}
+
+// shownSegments returns whether each segment is drawn, hiding segments from the middle outward
+// until the path fits in width. The first and last segments and the cursor are always drawn.
+func (self *Breadcrumbs) shownSegments(width int) []bool {
+	shown := make([]bool, len(self.Segments))
+	for i := range shown {
+		shown[i] = true
+	}
+	// hide the segments nearest the middle first, preferring those nearer the start
+	for self.pathWidth(shown) > width {
+		best := -1
+		for i := 1; i < len(self.Segments)-1; i++ {
+			if !shown[i] || i == self.Cursor() {
+				continue
+			}
+			if best < 0 || AbsInt(2*i-len(self.Segments)+1) < AbsInt(2*best-len(self.Segments)+1) {
+				best = i
+			}
+		}
+		if best < 0 {
+			break
+		}
+		shown[best] = false
+	}
+	return shown
+}
+
+// pathWidth returns the width of the path drawn with an ellipsis for every run of hidden segments.
+func (self *Breadcrumbs) pathWidth(shown []bool) int {
+	width := 0
+	for i, segment := range self.Segments {
+		if i > 0 {
+			width += rw.StringWidth(self.Separator)
+		}
+		if shown[i] {
+			width += rw.StringWidth(segment)
+		} else if shown[i-1] {
+			width += rw.RuneWidth(ELLIPSES)
+		} else {
+			// the separator belongs to the ellipsis
+			width -= rw.StringWidth(self.Separator)
+		}
+	}
+	return width
+}
+
+func (self *Breadcrumbs) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	row := self.row()
+	self.spans = make([]image.Rectangle, len(self.Segments))
+
+	x := row.Min.X
+	put := func(s string, style Style) image.Rectangle {
+		start := x
+		for _, r := range s {
+			if x+rw.RuneWidth(r) > row.Max.X {
+				break
+			}
+			buf.SetCell(NewCell(r, style), image.Pt(x, row.Min.Y))
+			x += rw.RuneWidth(r)
+		}
+		return image.Rect(start, row.Min.Y, x, row.Max.Y)
+	}
+
+	shown := self.shownSegments(row.Dx())
+	for i, segment := range self.Segments {
+		if !shown[i] {
+			if shown[i-1] {
+				put(string(ELLIPSES), self.TextStyle)
+				put(self.Separator, self.SeparatorStyle)
+			}
+			continue
+		}
+		style := self.TextStyle
+		if i == len(self.Segments)-1 {
+			style = self.CurrentStyle
+		}
+		if self.Focused && i == self.Cursor() {
+			style = self.FocusedStyle
+		}
+		self.spans[i] = put(segment, style)
+		if i < len(self.Segments)-1 {
+			put(self.Separator, self.SeparatorStyle)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// Button is a labelled button activated with <Enter> or <Space> while it is Focused, or by clicking
+// it. A click activates it when the mouse button is released over it, and it is drawn with
+// PressedStyle while the mouse button is held down.
+type Button struct {
+	Block
+	Label     string
+	TextStyle Style
+
+	// Focused draws the Button with FocusedStyle. Keys are only handled while it is set.
+	Focused      bool
+	FocusedStyle Style
+	PressedStyle Style
+
+	// OnClick is called when the Button is activated.
+	OnClick func()
+
+	pressed bool
+}
+
+func NewButton(label string) *Button {
+	return &Button{
+		Block:        *NewBlock(),
+		Label:        label,
+		TextStyle:    Theme.Button.Text,
+		FocusedStyle: Theme.Button.Focused,
+		PressedStyle: Theme.Button.Pressed,
+	}
+}
+
+// Click activates the Button, calling OnClick.
+func (self *Button) Click() {
+	if self.OnClick != nil {
+		self.OnClick()
+	}
+}
+
+// Pressed reports whether the mouse button is held down over the Button.
+func (self *Button) Pressed() bool {
+	return self.pressed
+}
+
+func (self *Button) SetFocused(focused bool) {
+	self.Focused = focused
+}
+
+// HandleKey activates the Button on <Enter> or <Space> while it is Focused and reports whether the
+// key was used.
+func (self *Button) HandleKey(id string) bool {
+	if !self.Focused || (id != "<Enter>" && id != "<Space>") {
+		return false
+	}
+	self.Click()
+	return true
+}
+
+// Accessibility implements Accessible with the Label and Focused.
+func (self *Button) Accessibility() (string, bool, string) {
+	return self.Label, self.Focused, ""
+}
+
+// HandleMouse presses the Button when it is clicked and activates it when the mouse button is
+// released over it, reporting whether the event was used.
+func (self *Button) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok {
+		return false
+	}
+	switch e.ID {
+	case "<MouseLeft>":
+		if e.Payload.(Mouse).Drag {
+			return false
+		}
+		if p.In(self.Rectangle) {
+			self.pressed = true
+			return true
+		}
+	case "<MouseRelease>":
+		if self.pressed {
+			self.pressed = false
+			if p.In(self.Rectangle) {
+				self.Click()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (self *Button) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	if self.Inner.Empty() {
+		return
+	}
+
+	style := self.TextStyle
+	switch {
+	case self.pressed:
+		style = self.PressedStyle
+	case self.Focused:
+		style = self.FocusedStyle
+	}
+	buf.Fill(NewCell(' ', style), self.Inner)
+
+	label := TrimString(self.Label, self.Inner.Dx())
+	x := self.Inner.Min.X + (self.Inner.Dx()-rw.StringWidth(label))/2
+	y := self.Inner.Min.Y + (self.Inner.Dy()-1)/2
+	buf.SetString(label, style, image.Pt(x, y))
+}
@@ -0,0 +1,403 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+const (
+	// calendarWidth is the width of a week of two-column days separated by spaces.
+	calendarWidth = 7*3 - 1
+	// calendarHeight is the height of the header, the weekday names, and six weeks.
+	calendarHeight = 8
+)
+
+// Calendar shows a month of days, with the month under the Cursor. The arrow keys move the Cursor
+// while Focused and <Enter> or <Space> selects the date under it. When Range is set, two dates are
+// picked to select the range between them.
+type Calendar struct {
+	Block
+	// Cursor is the date under the cursor, whose month is shown.
+	Cursor time.Time
+	// Start and End are the first and last selected dates, the same date unless Range is set.
+	// End is zero while the second date of a range is being picked.
+	Start time.Time
+	End   time.Time
+	Range bool
+	// Highlighted dates, such as those with events, are drawn with HighlightedStyle.
+	Highlighted  []time.Time
+	FirstWeekday time.Weekday
+
+	TextStyle        Style
+	HeaderStyle      Style
+	WeekdayStyle     Style
+	TodayStyle       Style
+	HighlightedStyle Style
+	SelectedStyle    Style
+	CursorStyle      Style
+
+	// Focused draws the Cursor. Keys are only handled while it is set.
+	Focused bool
+
+	// OnSelect is called when a date, or both ends of a range, are selected.
+	OnSelect func(start, end time.Time)
+
+	// grid is the area of the days drawn by the last Draw, and header the area of the month name.
+	grid   image.Rectangle
+	header image.Rectangle
+}
+
+func NewCalendar() *Calendar {
+	return &Calendar{
+		Block:            *NewBlock(),
+		Cursor:           truncateDay(time.Now()),
+		TextStyle:        Theme.Calendar.Text,
+		HeaderStyle:      Theme.Calendar.Header,
+		WeekdayStyle:     Theme.Calendar.Weekday,
+		TodayStyle:       Theme.Calendar.Today,
+		HighlightedStyle: Theme.Calendar.Highlighted,
+		SelectedStyle:    Theme.Calendar.Selected,
+		CursorStyle:      Theme.Calendar.Cursor,
+	}
+}
+
+// truncateDay returns midnight of the day of t.
+func truncateDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// addMonths adds months to t, keeping the day within the resulting month instead of overflowing
+// into the next one.
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, MinInt(day, last)-1)
+}
+
+// Select selects date, or one end of a range when Range is set, and calls OnSelect once the
+// selection is complete.
+func (self *Calendar) Select(date time.Time) {
+	date = truncateDay(date)
+	self.Cursor = date
+	if !self.Range {
+		self.Start, self.End = date, date
+	} else if self.Start.IsZero() || !self.End.IsZero() {
+		self.Start, self.End = date, time.Time{}
+		return
+	} else {
+		self.End = date
+		if self.End.Before(self.Start) {
+			self.Start, self.End = self.End, self.Start
+		}
+	}
+	if self.OnSelect != nil {
+		self.OnSelect(self.Start, self.End)
+	}
+}
+
+// IsSelected reports whether date is within the selection.
+func (self *Calendar) IsSelected(date time.Time) bool {
+	if self.Start.IsZero() {
+		return false
+	}
+	if self.End.IsZero() {
+		return sameDay(date, self.Start)
+	}
+	date = truncateDay(date)
+	return !date.Before(truncateDay(self.Start)) && !date.After(truncateDay(self.End))
+}
+
+func (self *Calendar) isHighlighted(date time.Time) bool {
+	for _, highlighted := range self.Highlighted {
+		if sameDay(date, highlighted) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstShown returns the date in the first column of the first week of the month of the Cursor.
+func (self *Calendar) firstShown() time.Time {
+	year, month, _ := self.Cursor.Date()
+	first := time.Date(year, month, 1, 0, 0, 0, 0, self.Cursor.Location())
+	offset := (int(first.Weekday()) - int(self.FirstWeekday) + 7) % 7
+	return first.AddDate(0, 0, -offset)
+}
+
+func (self *Calendar) SetFocused(focused bool) {
+	self.Focused = focused
+}
+
+// FormValue returns the first selected date for a Form, or the zero time if there is none.
+func (self *Calendar) FormValue() interface{} {
+	return self.Start
+}
+
+// HandleKey moves the Cursor a day with <Left> and <Right>, a week with <Up> and <Down>, a month
+// with <PageUp> and <PageDown>, and to the start or end of the month with <Home> and <End>, and
+// selects the date under it with <Enter> or <Space>, while the Calendar is Focused.
+// It reports whether the key was used.
+func (self *Calendar) HandleKey(id string) bool {
+	if !self.Focused {
+		return false
+	}
+	switch id {
+	case "<Left>", "h":
+		self.Cursor = self.Cursor.AddDate(0, 0, -1)
+	case "<Right>", "l":
+		self.Cursor = self.Cursor.AddDate(0, 0, 1)
+	case "<Up>", "k":
+		self.Cursor = self.Cursor.AddDate(0, 0, -7)
+	case "<Down>", "j":
+		self.Cursor = self.Cursor.AddDate(0, 0, 7)
+	case "<PageUp>":
+		self.Cursor = addMonths(self.Cursor, -1)
+	case "<PageDown>":
+		self.Cursor = addMonths(self.Cursor, 1)
+	case "<Home>":
+		self.Cursor = self.Cursor.AddDate(0, 0, 1-self.Cursor.Day())
+	case "<End>":
+		self.Cursor = addMonths(self.Cursor.AddDate(0, 0, 1-self.Cursor.Day()), 1).AddDate(0, 0, -1)
+	case "<Enter>", "<Space>":
+		self.Select(self.Cursor)
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse selects a clicked date, or shows the previous or next month when the arrows beside
+// the month name are clicked, and reports whether the event was used.
+func (self *Calendar) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag {
+		return false
+	}
+	if p.In(self.header) {
+		switch p.X {
+		case self.header.Min.X:
+			self.Cursor = addMonths(self.Cursor, -1)
+		case self.header.Max.X - 1:
+			self.Cursor = addMonths(self.Cursor, 1)
+		}
+		return true
+	}
+	if !p.In(self.grid) || (p.X-self.grid.Min.X)%3 == 2 {
+		return p.In(self.Rectangle)
+	}
+	date := self.firstShown().AddDate(0, 0, (p.Y-self.grid.Min.Y)*7+(p.X-self.grid.Min.X)/3)
+	if date.Month() == self.Cursor.Month() {
+		self.Select(date)
+	}
+	return true
+}
+
+func (self *Calendar) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	self.grid, self.header = image.Rectangle{}, image.Rectangle{}
+	if self.Inner.Dx() < calendarWidth || self.Inner.Dy() < calendarHeight {
+		return
+	}
+
+	origin := image.Pt(self.Inner.Min.X+(self.Inner.Dx()-calendarWidth)/2, self.Inner.Min.Y)
+	self.header = image.Rect(origin.X, origin.Y, origin.X+calendarWidth, origin.Y+1)
+	title := self.Cursor.Format("January 2006")
+	buf.SetString(title, self.HeaderStyle, image.Pt(origin.X+(calendarWidth-rw.StringWidth(title))/2, origin.Y))
+	buf.SetCell(NewCell('<', self.HeaderStyle), self.header.Min)
+	buf.SetCell(NewCell('>', self.HeaderStyle), image.Pt(self.header.Max.X-1, origin.Y))
+
+	for i := 0; i < 7; i++ {
+		weekday := time.Weekday((int(self.FirstWeekday) + i) % 7).String()[:2]
+		buf.SetString(weekday, self.WeekdayStyle, image.Pt(origin.X+3*i, origin.Y+1))
+	}
+
+	self.grid = image.Rect(origin.X, origin.Y+2, origin.X+calendarWidth, origin.Y+calendarHeight)
+	today := time.Now()
+	date := self.firstShown()
+	for week := 0; week < 6; week++ {
+		for day := 0; day < 7; day++ {
+			if date.Month() == self.Cursor.Month() {
+				style := self.TextStyle
+				switch {
+				case self.Focused && sameDay(date, self.Cursor):
+					style = self.CursorStyle
+				case self.IsSelected(date):
+					style = self.SelectedStyle
+				case self.isHighlighted(date):
+					style = self.HighlightedStyle
+				case sameDay(date, today):
+					style = self.TodayStyle
+				}
+				point := image.Pt(self.grid.Min.X+3*day, self.grid.Min.Y+week)
+				buf.SetString(fmt.Sprintf("%2d", date.Day()), style, point)
+			}
+			date = date.AddDate(0, 0, 1)
+		}
+	}
+}
+
+// DatePicker shows a date and opens a Calendar to pick it from, drawn as an overlay with
+// ShowOverlay so that it floats over the widgets below the DatePicker.
+type DatePicker struct {
+	Block
+	// Date is the picked date, or the zero time if none has been.
+	Date time.Time
+	// Layout is the time.Format layout the Date is shown with.
+	Layout    string
+	TextStyle Style
+
+	// Focused draws the DatePicker with FocusedStyle. Keys are only handled while it is set.
+	Focused      bool
+	FocusedStyle Style
+
+	// OnChange is called when another date is picked.
+	OnChange func(date time.Time)
+
+	// Calendar is the popup, which can be styled or given Highlighted dates.
+	Calendar *Calendar
+}
+
+func NewDatePicker() *DatePicker {
+	self := &DatePicker{
+		Block:        *NewBlock(),
+		Layout:       "2006-01-02",
+		TextStyle:    Theme.Select.Text,
+		FocusedStyle: Theme.Select.Focused,
+		Calendar:     NewCalendar(),
+	}
+	self.Calendar.Focused = true
+	self.Calendar.OnSelect = func(start, end time.Time) {
+		self.Close()
+		if !sameDay(start, self.Date) || self.Date.IsZero() {
+			self.Date = start
+			if self.OnChange != nil {
+				self.OnChange(start)
+			}
+		}
+	}
+	return self
+}
+
+// IsOpen reports whether the Calendar is shown.
+func (self *DatePicker) IsOpen() bool {
+	return IsOverlay(self.Calendar)
+}
+
+// Open shows the Calendar below the DatePicker with the cursor on the picked date, or on today.
+func (self *DatePicker) Open() {
+	self.Calendar.Cursor = truncateDay(time.Now())
+	self.Calendar.Start, self.Calendar.End = time.Time{}, time.Time{}
+	if !self.Date.IsZero() {
+		self.Calendar.Cursor = truncateDay(self.Date)
+		self.Calendar.Start, self.Calendar.End = self.Calendar.Cursor, self.Calendar.Cursor
+	}
+	self.placeCalendar()
+	ShowOverlay(self.Calendar)
+}
+
+// Close hides the Calendar without changing the Date.
+func (self *DatePicker) Close() {
+	HideOverlay(self.Calendar)
+}
+
+func (self *DatePicker) placeCalendar() {
+	self.Calendar.SetRect(self.Min.X, self.Max.Y-1, self.Min.X+calendarWidth+2, self.Max.Y-1+calendarHeight+2)
+}
+
+func (self *DatePicker) SetFocused(focused bool) {
+	self.Focused = focused
+	if !focused {
+		self.Close()
+	}
+}
+
+// FormValue returns the Date for a Form.
+func (self *DatePicker) FormValue() interface{} {
+	return self.Date
+}
+
+// HandleKey opens the Calendar with <Enter>, <Space>, or <Down> while the DatePicker is Focused.
+// Keys are then sent to the Calendar until a date is picked or <Escape> closes it.
+// It reports whether the key was used.
+func (self *DatePicker) HandleKey(id string) bool {
+	if !self.Focused {
+		return false
+	}
+	if !self.IsOpen() {
+		switch id {
+		case "<Enter>", "<Space>", "<Down>":
+			self.Open()
+			return true
+		}
+		return false
+	}
+	if id == "<Escape>" {
+		self.Close()
+		return true
+	}
+	return self.Calendar.HandleKey(id)
+}
+
+// HandleMouse opens or closes the Calendar when the DatePicker is clicked, sends clicks on the
+// Calendar to it, and closes it on a click anywhere else. It reports whether the event was used.
+func (self *DatePicker) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok {
+		return false
+	}
+	if self.IsOpen() && p.In(self.Calendar.GetRect()) {
+		return self.Calendar.HandleMouse(e)
+	}
+	if e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag {
+		return false
+	}
+	if p.In(self.Rectangle) {
+		if self.IsOpen() {
+			self.Close()
+		} else {
+			self.Open()
+		}
+		return true
+	}
+	if self.IsOpen() {
+		self.Close()
+	}
+	return false
+}
+
+func (self *DatePicker) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	if self.Inner.Empty() {
+		return
+	}
+	if self.IsOpen() {
+		self.placeCalendar()
+	}
+
+	style := self.TextStyle
+	if self.Focused {
+		style = self.FocusedStyle
+	}
+	buf.Fill(NewCell(' ', style), image.Rect(self.Inner.Min.X, self.Inner.Min.Y, self.Inner.Max.X, self.Inner.Min.Y+1))
+	if !self.Date.IsZero() {
+		buf.SetString(TrimString(self.Date.Format(self.Layout), self.Inner.Dx()-2), style, self.Inner.Min)
+	}
+	buf.SetCell(NewCell(DOWN_ARROW, style), image.Pt(self.Inner.Max.X-1, self.Inner.Min.Y))
+}
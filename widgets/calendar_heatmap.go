@@ -0,0 +1,284 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+const (
+	// heatmapLabelWidth is the width of the weekday labels on the left of the days.
+	heatmapLabelWidth = 4
+	// heatmapHeight is the height of the month labels and a week of days.
+	heatmapHeight = 8
+)
+
+// CalendarHeatmap shows a value per day as a colored cell, with a column per week under the month
+// labels, like a contribution graph. Values are drawn with the Colors of their level, from the
+// first for days without a value to the last for MaxVal. A legend of the Colors is drawn below the
+// days, after the date and value under the Cursor while Focused. <Left> and <Right> move the
+// Cursor a week and <Up> and <Down> a day, scrolling the weeks when they don't all fit.
+type CalendarHeatmap struct {
+	Block
+	// Start is the date of Values[0]. Each following value is the value of the next day.
+	Start  time.Time
+	Values []float64
+	// MaxVal is the value drawn with the last of the Colors. When zero, it's taken from the Values.
+	MaxVal float64
+	// Colors are the levels the values are drawn with, the first for zero and missing values.
+	Colors       []Color
+	Rune         rune
+	FirstWeekday time.Weekday
+	// NumFormatter formats the value under the Cursor.
+	NumFormatter func(float64) string
+
+	TextStyle Style
+	// CursorStyle is the style of the day under the Cursor. Only its Bg is used, the Fg keeping the
+	// color of the value.
+	CursorStyle Style
+
+	// Cursor is the day whose value is shown while Focused. It's the last day when zero.
+	Cursor time.Time
+	// Focused draws the Cursor. Keys are only handled while it is set.
+	Focused bool
+
+	// OnCursorChange is called with the date and value under the Cursor after it moves.
+	OnCursorChange func(date time.Time, value float64)
+
+	// grid is the area of the days drawn by the last Draw, and offset the first week drawn.
+	grid   image.Rectangle
+	offset int
+}
+
+func NewCalendarHeatmap() *CalendarHeatmap {
+	return &CalendarHeatmap{
+		Block:        *NewBlock(),
+		Start:        truncateDay(time.Now()).AddDate(-1, 0, 1),
+		Colors:       Theme.CalendarHeatmap.Colors,
+		Rune:         Theme.CalendarHeatmap.Rune,
+		NumFormatter: func(n float64) string { return fmt.Sprint(n) },
+		TextStyle:    Theme.CalendarHeatmap.Text,
+		CursorStyle:  Theme.CalendarHeatmap.Cursor,
+	}
+}
+
+// daysBetween returns the number of days from the day of a to the day of b.
+func daysBetween(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	from := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	to := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// End returns the date of the last of the Values.
+func (self *CalendarHeatmap) End() time.Time {
+	return truncateDay(self.Start).AddDate(0, 0, MaxInt(len(self.Values)-1, 0))
+}
+
+// Value returns the value of date, or 0 if it has none.
+func (self *CalendarHeatmap) Value(date time.Time) float64 {
+	i := daysBetween(self.Start, date)
+	if i < 0 || i >= len(self.Values) {
+		return 0
+	}
+	return self.Values[i]
+}
+
+// SetValue sets the value of date, moving the Start or adding Values when it is out of the period.
+func (self *CalendarHeatmap) SetValue(date time.Time, value float64) {
+	i := daysBetween(self.Start, date)
+	if i < 0 {
+		self.Values = append(make([]float64, -i), self.Values...)
+		self.Start = truncateDay(date)
+		i = 0
+	}
+	for i >= len(self.Values) {
+		self.Values = append(self.Values, 0)
+	}
+	self.Values[i] = value
+}
+
+// level returns the index of the color a value is drawn with.
+func (self *CalendarHeatmap) level(value, maxVal float64) int {
+	levels := len(self.Colors) - 1
+	if value <= 0 || maxVal <= 0 || levels < 1 {
+		return 0
+	}
+	return MaxInt(MinInt(int(math.Ceil(value/maxVal*float64(levels))), levels), 1)
+}
+
+// cursor returns the Cursor within the period.
+func (self *CalendarHeatmap) cursor() time.Time {
+	switch {
+	case self.Cursor.IsZero() || self.Cursor.After(self.End()):
+		return self.End()
+	case self.Cursor.Before(self.Start):
+		return truncateDay(self.Start)
+	}
+	return truncateDay(self.Cursor)
+}
+
+// moveCursor moves the Cursor by days, staying within the period.
+func (self *CalendarHeatmap) moveCursor(days int) {
+	before := self.cursor()
+	self.Cursor = before.AddDate(0, 0, days)
+	self.Cursor = self.cursor()
+	if !sameDay(self.Cursor, before) && self.OnCursorChange != nil {
+		self.OnCursorChange(self.Cursor, self.Value(self.Cursor))
+	}
+}
+
+// firstShown returns the date in the first row of the week of the Start.
+func (self *CalendarHeatmap) firstShown() time.Time {
+	start := truncateDay(self.Start)
+	offset := (int(start.Weekday()) - int(self.FirstWeekday) + 7) % 7
+	return start.AddDate(0, 0, -offset)
+}
+
+// HandleKey moves the Cursor a week with <Left> and <Right>, a day with <Up> and <Down>, and to the
+// first or last day with <Home> and <End>, while the CalendarHeatmap is Focused.
+// It reports whether the key was used.
+func (self *CalendarHeatmap) HandleKey(id string) bool {
+	if !self.Focused || len(self.Values) == 0 {
+		return false
+	}
+	switch id {
+	case "<Left>", "h":
+		self.moveCursor(-7)
+	case "<Right>", "l":
+		self.moveCursor(7)
+	case "<Up>", "k":
+		self.moveCursor(-1)
+	case "<Down>", "j":
+		self.moveCursor(1)
+	case "<Home>":
+		self.moveCursor(daysBetween(self.cursor(), self.Start))
+	case "<End>":
+		self.moveCursor(daysBetween(self.cursor(), self.End()))
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse moves the Cursor to a clicked day, and reports whether the event was used.
+func (self *CalendarHeatmap) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag || !p.In(self.grid) {
+		return false
+	}
+	if (p.X-self.grid.Min.X)%2 == 1 {
+		return true
+	}
+	week := self.offset + (p.X-self.grid.Min.X)/2
+	date := self.firstShown().AddDate(0, 0, week*7+p.Y-self.grid.Min.Y)
+	if i := daysBetween(self.Start, date); i >= 0 && i < len(self.Values) {
+		self.moveCursor(daysBetween(self.cursor(), date))
+	}
+	return true
+}
+
+func (self *CalendarHeatmap) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	self.grid = image.Rectangle{}
+	if len(self.Values) == 0 || len(self.Colors) == 0 || self.Inner.Dy() < heatmapHeight {
+		return
+	}
+	// a column per week, with a space between them
+	shown := (self.Inner.Dx() - heatmapLabelWidth + 1) / 2
+	if shown < 1 {
+		return
+	}
+
+	maxVal := self.MaxVal
+	if maxVal == 0 {
+		maxVal, _ = GetMaxFloat64FromSlice(self.Values)
+	}
+	first := self.firstShown()
+	cursor := self.cursor()
+	weeks := daysBetween(first, self.End())/7 + 1
+	week := daysBetween(first, cursor) / 7
+
+	// scroll the week of the Cursor into view
+	if week < self.offset {
+		self.offset = week
+	} else if week >= self.offset+shown {
+		self.offset = week - shown + 1
+	}
+	self.offset = MaxInt(MinInt(self.offset, weeks-shown), 0)
+	shown = MinInt(shown, weeks-self.offset)
+
+	self.grid = image.Rect(
+		self.Inner.Min.X+heatmapLabelWidth, self.Inner.Min.Y+1,
+		self.Inner.Min.X+heatmapLabelWidth+2*shown-1, self.Inner.Min.Y+heatmapHeight,
+	)
+
+	for i := 1; i < 7; i += 2 {
+		weekday := time.Weekday((int(self.FirstWeekday) + i) % 7).String()[:3]
+		buf.SetString(weekday, self.TextStyle, image.Pt(self.Inner.Min.X, self.grid.Min.Y+i))
+	}
+
+	// label the month of a week starting one, and of the first week if there is room before the next
+	labelEnd := 0
+	for w := 0; w < shown; w++ {
+		weekEnd := first.AddDate(0, 0, (self.offset+w)*7+6)
+		x := self.grid.Min.X + 2*w
+		if x < labelEnd || (weekEnd.Day() > 7 && (w > 0 || weekEnd.AddDate(0, 0, 7).Day() <= 7)) {
+			continue
+		}
+		month := TrimString(weekEnd.Format("Jan"), self.Inner.Max.X-x)
+		buf.SetString(month, self.TextStyle, image.Pt(x, self.Inner.Min.Y))
+		labelEnd = x + rw.StringWidth(month) + 1
+	}
+
+	for w := 0; w < shown; w++ {
+		for d := 0; d < 7; d++ {
+			date := first.AddDate(0, 0, (self.offset+w)*7+d)
+			i := daysBetween(self.Start, date)
+			if i < 0 || i >= len(self.Values) {
+				continue
+			}
+			style := NewStyle(self.Colors[self.level(self.Values[i], maxVal)])
+			if self.Focused && sameDay(date, cursor) {
+				style.Bg = self.CursorStyle.Bg
+			}
+			buf.SetCell(NewCell(self.Rune, style), image.Pt(self.grid.Min.X+2*w, self.grid.Min.Y+d))
+		}
+	}
+
+	if self.Inner.Dy() > heatmapHeight {
+		self.drawLegend(buf, cursor, self.Inner.Min.Y+heatmapHeight)
+	}
+}
+
+// drawLegend draws the date and value under the Cursor while Focused on the left of row, and the
+// Colors between "Less" and "More" on its right.
+func (self *CalendarHeatmap) drawLegend(buf *Buffer, cursor time.Time, y int) {
+	cells := RunesToStyledCells([]rune("Less "), self.TextStyle)
+	for _, color := range self.Colors {
+		cells = append(cells, NewCell(self.Rune, NewStyle(color)), NewCell(' ', self.TextStyle))
+	}
+	cells = append(cells, RunesToStyledCells([]rune("More"), self.TextStyle)...)
+	x := MaxInt(self.grid.Max.X-len(cells), self.Inner.Min.X)
+	if self.Focused {
+		value := fmt.Sprintf("%s: %s", cursor.Format("Mon Jan 2, 2006"), self.NumFormatter(self.Value(cursor)))
+		if x-self.Inner.Min.X < rw.StringWidth(value)+2 {
+			// keep the value rather than the legend when both don't fit
+			x = self.Inner.Max.X
+		}
+		buf.SetString(TrimString(value, self.Inner.Dx()), self.TextStyle, image.Pt(self.Inner.Min.X, y))
+	}
+	for i, cell := range TrimCells(cells, self.Inner.Max.X-x) {
+		buf.SetCell(cell, image.Pt(x+i, y))
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+type CheckboxState uint
+
+const (
+	CheckboxUnchecked CheckboxState = iota
+	CheckboxChecked
+	// CheckboxPartial is the third state of a TriState Checkbox, for a setting only applying to some items.
+	CheckboxPartial
+)
+
+// Checkbox is a labelled box toggled with <Space> or <Enter> or by clicking it.
+type Checkbox struct {
+	Block
+	Label     string
+	TextStyle Style
+	State     CheckboxState
+	// TriState makes toggling go through CheckboxPartial after CheckboxChecked.
+	TriState bool
+
+	// Focused draws the Checkbox with FocusedStyle. Keys are only handled while it is set.
+	Focused      bool
+	FocusedStyle Style
+
+	// OnChange is called with the new state when the Checkbox is toggled.
+	OnChange func(state CheckboxState)
+}
+
+func NewCheckbox(label string) *Checkbox {
+	return &Checkbox{
+		Block:        *NewBlock(),
+		Label:        label,
+		TextStyle:    Theme.Checkbox.Text,
+		FocusedStyle: Theme.Checkbox.Focused,
+	}
+}
+
+// Checked reports whether the Checkbox is checked.
+func (self *Checkbox) Checked() bool {
+	return self.State == CheckboxChecked
+}
+
+// Toggle moves to the next state and calls OnChange.
+func (self *Checkbox) Toggle() {
+	switch {
+	case self.State == CheckboxUnchecked:
+		self.State = CheckboxChecked
+	case self.State == CheckboxChecked && self.TriState:
+		self.State = CheckboxPartial
+	default:
+		self.State = CheckboxUnchecked
+	}
+	if self.OnChange != nil {
+		self.OnChange(self.State)
+	}
+}
+
+func (self *Checkbox) SetFocused(focused bool) {
+	self.Focused = focused
+}
+
+// FormValue returns whether the Checkbox is checked for a Form, or its CheckboxState if it is TriState.
+func (self *Checkbox) FormValue() interface{} {
+	if self.TriState {
+		return self.State
+	}
+	return self.Checked()
+}
+
+// Accessibility implements Accessible with the Label, Focused, and the State.
+func (self *Checkbox) Accessibility() (string, bool, string) {
+	switch self.State {
+	case CheckboxChecked:
+		return self.Label, self.Focused, "checked"
+	case CheckboxPartial:
+		return self.Label, self.Focused, "partially checked"
+	}
+	return self.Label, self.Focused, "not checked"
+}
+
+// HandleKey toggles the Checkbox on <Space> or <Enter> while it is Focused and reports whether the
+// key was used.
+func (self *Checkbox) HandleKey(id string) bool {
+	if !self.Focused || (id != "<Space>" && id != "<Enter>") {
+		return false
+	}
+	self.Toggle()
+	return true
+}
+
+// HandleMouse toggles the Checkbox when it is clicked and reports whether the event was used.
+func (self *Checkbox) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag || !p.In(self.Rectangle) {
+		return false
+	}
+	self.Toggle()
+	return true
+}
+
+func (self *Checkbox) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	if self.Inner.Empty() {
+		return
+	}
+	box := Theme.Checkbox.Unchecked
+	switch self.State {
+	case CheckboxChecked:
+		box = Theme.Checkbox.Checked
+	case CheckboxPartial:
+		box = Theme.Checkbox.Partial
+	}
+	style := self.TextStyle
+	if self.Focused {
+		style = self.FocusedStyle
+	}
+	buf.SetString(TrimString(string(box)+" "+self.Label, self.Inner.Dx()), style, image.Pt(self.Inner.Min.X, self.Inner.Min.Y))
+}
@@ -0,0 +1,298 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// ClockMode is what a Clock shows.
+type ClockMode int
+
+const (
+	// ClockTime shows the time of day in the Location of the Clock.
+	ClockTime ClockMode = iota
+	// ClockCountdown shows the time left of Duration, counting down while the Clock is started.
+	ClockCountdown
+	// ClockStopwatch shows the time elapsed while the Clock is started.
+	ClockStopwatch
+)
+
+// Clock shows the time of day, a countdown, or a stopwatch in BigText digits, or with the hands
+// of an analog face when Analog is set. Start animates it every Interval, which also runs the
+// countdown or stopwatch, and Stop pauses it.
+type Clock struct {
+	Block
+	Mode   ClockMode
+	Analog bool
+	// Location is the time zone of the time of day, or the local one when nil.
+	Location *time.Location
+	// Format is the layout of the digital time of day.
+	Format string
+	// Duration is the length of a countdown.
+	Duration time.Duration
+	// ShowTenths adds tenths of a second to a digital countdown or stopwatch, which needs an
+	// Interval of 100ms or less.
+	ShowTenths bool
+	// ShowDate draws the date and time zone under the time of day.
+	ShowDate bool
+	Interval time.Duration
+
+	// Size, Colors, and Gradient are those of the BigText digits.
+	Size     BigTextSize
+	Colors   []Color
+	Gradient bool
+
+	TextStyle       Style
+	FaceColor       Color
+	HandColor       Color
+	SecondHandColor Color
+
+	// OnFrame is called from the goroutine of the animation after every frame, so that the
+	// application can Render it, and OnDone after the frame a countdown ends in.
+	OnFrame func()
+	OnDone  func()
+
+	now time.Time
+	// elapsed is the time counted before started, the time the count was last started or zero
+	// when it's paused.
+	elapsed time.Duration
+	started time.Time
+	done    bool
+
+	digits    *BigText
+	animation *Animation
+	// paused is set while the animation is stopped by the Clock being hidden.
+	paused bool
+}
+
+func NewClock() *Clock {
+	self := &Clock{
+		Block:           *NewBlock(),
+		Format:          "15:04:05",
+		Interval:        200 * time.Millisecond,
+		Size:            BigTextMedium,
+		TextStyle:       Theme.Clock.Text,
+		FaceColor:       Theme.Clock.Face,
+		HandColor:       Theme.Clock.Hand,
+		SecondHandColor: Theme.Clock.SecondHand,
+		digits:          NewBigText(),
+	}
+	self.digits.Border = false
+	self.digits.TextAlignment = AlignCenter
+	self.animation = NewAnimation(self.Interval, self.Step)
+	self.animation.OnFrame = func() {
+		if self.OnFrame != nil {
+			self.OnFrame()
+		}
+		if self.done {
+			self.done = false
+			if self.OnDone != nil {
+				self.OnDone()
+			}
+		}
+	}
+	return self
+}
+
+// Start animates the Clock, picking up any change to its Interval, and starts or resumes a
+// countdown or stopwatch.
+func (self *Clock) Start() {
+	if self.started.IsZero() && (self.Mode == ClockStopwatch || self.Remaining() > 0) {
+		self.started = time.Now()
+	}
+	self.animation.Stop()
+	self.animation.Interval = self.Interval
+	self.animation.Start()
+}
+
+// Stop stops animating the Clock and pauses a countdown or stopwatch.
+func (self *Clock) Stop() {
+	self.animation.Stop()
+	self.pause(time.Now())
+	self.paused = false
+}
+
+// OnVisible implements Lifecycle, stopping the animation while the Clock is hidden. A countdown
+// or stopwatch keeps running.
+func (self *Clock) OnVisible(visible bool) {
+	if !visible && self.animation.Running() {
+		self.animation.Stop()
+		self.paused = true
+	} else if visible && self.paused {
+		self.animation.Start()
+		self.paused = false
+	}
+	self.Block.OnVisible(visible)
+}
+
+func (self *Clock) pause(now time.Time) {
+	if !self.started.IsZero() {
+		self.elapsed += now.Sub(self.started)
+		self.started = time.Time{}
+	}
+}
+
+func (self *Clock) Running() bool {
+	return self.animation.Running()
+}
+
+// Reset sets a countdown back to its Duration and a stopwatch back to zero, keeping them running
+// if they are.
+func (self *Clock) Reset() {
+	self.elapsed = 0
+	if !self.started.IsZero() {
+		self.started = time.Now()
+	}
+}
+
+// Elapsed returns the time counted by a countdown or stopwatch.
+func (self *Clock) Elapsed() time.Duration {
+	if self.started.IsZero() {
+		return self.elapsed
+	}
+	return self.elapsed + time.Since(self.started)
+}
+
+// Remaining returns the time left of a countdown.
+func (self *Clock) Remaining() time.Duration {
+	if remaining := self.Duration - self.Elapsed(); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// Step moves the Clock to the time now, ending a countdown which has run out. It is called by the
+// animation while the Clock is started.
+func (self *Clock) Step(now time.Time) {
+	self.now = now
+	if self.Mode == ClockCountdown && !self.started.IsZero() && self.Remaining() == 0 {
+		self.pause(now)
+		self.elapsed = self.Duration
+		self.done = true
+	}
+}
+
+// shown returns the time shown: the time of day, or the duration as a time from midnight.
+func (self *Clock) shown() time.Time {
+	switch self.Mode {
+	case ClockCountdown:
+		return time.Time{}.Add(self.Remaining())
+	case ClockStopwatch:
+		return time.Time{}.Add(self.Elapsed())
+	}
+	now := self.now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if self.Location != nil {
+		return now.In(self.Location)
+	}
+	return now.Local()
+}
+
+// text returns the digital time shown.
+func (self *Clock) text() string {
+	if self.Mode == ClockTime {
+		return self.shown().Format(self.Format)
+	}
+	d := self.Elapsed()
+	if self.Mode == ClockCountdown {
+		// round up, so that a countdown shows zero only once it's over
+		d = self.Remaining()
+		if !self.ShowTenths {
+			d += time.Second - 1
+		} else {
+			d += time.Second/10 - 1
+		}
+	}
+	hours, minutes, seconds := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	text := fmt.Sprintf("%02d:%02d", minutes, seconds)
+	if hours > 0 {
+		text = fmt.Sprintf("%d:%s", hours, text)
+	}
+	if self.ShowTenths {
+		text += fmt.Sprintf(".%d", int(d/(time.Second/10))%10)
+	}
+	return text
+}
+
+func (self *Clock) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	area := self.Inner
+	if self.ShowDate && self.Mode == ClockTime && area.Dy() > 1 {
+		date := TrimString(self.shown().Format("Mon Jan 2 2006 MST"), area.Dx())
+		x := area.Min.X + (area.Dx()-rw.StringWidth(date))/2
+		buf.SetString(date, self.TextStyle, image.Pt(x, area.Max.Y-1))
+		area.Max.Y -= 2
+	}
+	if area.Dx() <= 0 || area.Dy() <= 0 {
+		return
+	}
+	if self.Analog {
+		self.drawFace(buf, area)
+		return
+	}
+
+	self.digits.Text = self.text()
+	self.digits.Size, self.digits.Colors, self.digits.Gradient = self.Size, self.Colors, self.Gradient
+	self.digits.TextStyle = self.TextStyle
+	// center the digits vertically
+	size := self.digits.TextSize()
+	area.Min.Y += MaxInt(area.Dy()-size.Y, 0) / 2
+	self.digits.Rectangle, self.digits.Inner = area, area
+	self.digits.Draw(buf)
+}
+
+// drawFace draws an analog clock face in area with ticks for the hours and hands for the hours,
+// minutes, and seconds.
+func (self *Clock) drawFace(buf *Buffer, area image.Rectangle) {
+	canvas := NewCanvas()
+	canvas.Rectangle = area
+	res := canvas.Mode.Resolution()
+	radius := MinInt(area.Dx()*res.X, area.Dy()*res.Y)/2 - 1
+	if radius < 4 {
+		return
+	}
+	center := image.Pt(
+		(area.Min.X+area.Max.X)*res.X/2,
+		(area.Min.Y+area.Max.Y)*res.Y/2,
+	)
+	// point returns the point at a distance from the center in the direction of a fraction of a
+	// turn from 12 o'clock
+	point := func(turn, distance float64) image.Point {
+		angle := 2*math.Pi*turn - math.Pi/2
+		return image.Pt(
+			center.X+int(math.Round(distance*math.Cos(angle))),
+			center.Y+int(math.Round(distance*math.Sin(angle))),
+		)
+	}
+
+	r := float64(radius)
+	canvas.SetCircle(center, radius, self.FaceColor)
+	for hour := 0; hour < 12; hour++ {
+		inner := 0.85
+		if hour%3 == 0 {
+			inner = 0.75
+		}
+		canvas.SetLine(point(float64(hour)/12, inner*r), point(float64(hour)/12, r), self.FaceColor)
+	}
+
+	t := self.shown()
+	seconds := float64(t.Second()) + float64(t.Nanosecond())/1e9
+	minutes := float64(t.Minute()) + seconds/60
+	hours := float64(t.Hour()%12) + minutes/60
+	canvas.SetLine(center, point(hours/12, 0.5*r), self.HandColor)
+	canvas.SetLine(center, point(minutes/60, 0.75*r), self.HandColor)
+	canvas.SetLine(center, point(float64(t.Second())/60, 0.9*r), self.SecondHandColor)
+	canvas.Draw(buf)
+}
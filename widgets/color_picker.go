@@ -0,0 +1,264 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// ColorPalette is the set of colors offered by a ColorPicker.
+type ColorPalette uint
+
+const (
+	// Palette16 is the 16 standard and bright terminal colors.
+	Palette16 ColorPalette = iota
+	// Palette256 is the xterm palette: the 16 terminal colors, a 6x6x6 color cube, and 24 grays.
+	Palette256
+	// PaletteTrueColor is a grid of 24-bit colors of 18 hues in 9 lightnesses, and a row of grays.
+	PaletteTrueColor
+)
+
+// swatchWidth is the number of columns of each color in the grid.
+const swatchWidth = 2
+
+// ColorPicker shows a Palette as a grid of swatches with a preview of the color under the cursor.
+// The arrow keys move the cursor while Focused, and <Enter> or <Space> selects the color under it.
+type ColorPicker struct {
+	Block
+	Palette   ColorPalette
+	TextStyle Style
+	// Selected is the picked color.
+	Selected Color
+
+	// Focused draws the cursor. Keys are only handled while it is set.
+	Focused bool
+
+	// OnSelect is called with the picked color.
+	OnSelect func(color Color)
+
+	row, column int
+	top         int
+	// grid is the area of the swatches drawn by the last Draw.
+	grid image.Rectangle
+}
+
+func NewColorPicker() *ColorPicker {
+	return &ColorPicker{
+		Block:     *NewBlock(),
+		Palette:   Palette256,
+		TextStyle: Theme.ColorPicker.Text,
+	}
+}
+
+// swatches returns the colors of the Palette in rows.
+func (self *ColorPicker) swatches() [][]Color {
+	rows := [][]Color{}
+	span := func(from, to, width int) {
+		for i := from; i < to; i += width {
+			row := []Color{}
+			for j := i; j < MinInt(i+width, to); j++ {
+				row = append(row, Color(j))
+			}
+			rows = append(rows, row)
+		}
+	}
+	switch self.Palette {
+	case Palette16:
+		span(0, 16, 8)
+	case Palette256:
+		span(0, 16, 8)
+		span(16, 232, 18)
+		span(232, 256, 12)
+	default:
+		for i := 1; i <= 9; i++ {
+			row := []Color{}
+			for hue := 0; hue < 360; hue += 20 {
+				row = append(row, hslColor(float64(hue), 1, 1-float64(i)/10))
+			}
+			rows = append(rows, row)
+		}
+		grays := []Color{}
+		for i := 0; i < 18; i++ {
+			gray := uint8(math.Round(float64(i) * 255 / 17))
+			grays = append(grays, NewRGBColor(gray, gray, gray))
+		}
+		rows = append(rows, grays)
+	}
+	return rows
+}
+
+// hslColor returns the 24-bit Color of a hue in degrees, saturation, and lightness.
+func hslColor(h, s, l float64) Color {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	component := func(v float64) uint8 {
+		return uint8(math.Round((v + m) * 255))
+	}
+	return NewRGBColor(component(r), component(g), component(b))
+}
+
+// Hovered returns the color under the cursor.
+func (self *ColorPicker) Hovered() Color {
+	rows := self.swatches()
+	self.clampCursor(rows)
+	return rows[self.row][self.column]
+}
+
+func (self *ColorPicker) clampCursor(rows [][]Color) {
+	self.row = MaxInt(MinInt(self.row, len(rows)-1), 0)
+	self.column = MaxInt(MinInt(self.column, len(rows[self.row])-1), 0)
+}
+
+// Select makes the color under the cursor the Selected one and calls OnSelect.
+func (self *ColorPicker) Select() {
+	self.Selected = self.Hovered()
+	if self.OnSelect != nil {
+		self.OnSelect(self.Selected)
+	}
+}
+
+func (self *ColorPicker) SetFocused(focused bool) {
+	self.Focused = focused
+}
+
+// FormValue returns the Selected color for a Form.
+func (self *ColorPicker) FormValue() interface{} {
+	return self.Selected
+}
+
+// HandleKey moves the cursor with the arrow keys, or h, j, k, and l, and selects the color under
+// it with <Enter> or <Space> while the ColorPicker is Focused. It reports whether the key was used.
+func (self *ColorPicker) HandleKey(id string) bool {
+	if !self.Focused {
+		return false
+	}
+	switch id {
+	case "<Left>", "h":
+		self.column--
+	case "<Right>", "l":
+		self.column++
+	case "<Up>", "k":
+		self.row--
+	case "<Down>", "j":
+		self.row++
+	case "<Home>":
+		self.row, self.column = 0, 0
+	case "<End>":
+		self.row, self.column = math.MaxInt32, math.MaxInt32
+	case "<Enter>", "<Space>":
+		self.Select()
+	default:
+		return false
+	}
+	self.clampCursor(self.swatches())
+	return true
+}
+
+// HandleMouse selects a clicked color and reports whether the event was used.
+func (self *ColorPicker) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag || !p.In(self.grid) {
+		return false
+	}
+	rows := self.swatches()
+	row, column := self.top+p.Y-self.grid.Min.Y, (p.X-self.grid.Min.X)/swatchWidth
+	if row >= len(rows) || column >= len(rows[row]) {
+		return true
+	}
+	self.row, self.column = row, column
+	self.Select()
+	return true
+}
+
+// describeColor returns the palette index and hex components of a color.
+func describeColor(color Color) string {
+	r, g, b := color.RGB()
+	if color >= 0 && color < 256 {
+		return fmt.Sprintf("%d #%02x%02x%02x", color, r, g, b)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// contrastColor returns black or white, whichever is more readable over color.
+func contrastColor(color Color) Color {
+	r, g, b := color.RGB()
+	if 299*int(r)+587*int(g)+114*int(b) > 128000 {
+		return ColorBlack
+	}
+	return ColorWhite
+}
+
+func (self *ColorPicker) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	self.grid = image.Rectangle{}
+	if self.Inner.Empty() {
+		return
+	}
+
+	rows := self.swatches()
+	self.clampCursor(rows)
+
+	// the last two rows hold the preview when there is room for it
+	height := len(rows)
+	if self.Inner.Dy() > 2 {
+		height = MinInt(height, self.Inner.Dy()-2)
+	} else {
+		height = MinInt(height, self.Inner.Dy())
+	}
+	if self.row < self.top {
+		self.top = self.row
+	} else if self.row >= self.top+height {
+		self.top = self.row - height + 1
+	}
+	self.top = MaxInt(MinInt(self.top, len(rows)-height), 0)
+
+	width := 0
+	for _, row := range rows {
+		width = MaxInt(width, len(row)*swatchWidth)
+	}
+	self.grid = image.Rect(self.Inner.Min.X, self.Inner.Min.Y, self.Inner.Min.X+MinInt(width, self.Inner.Dx()), self.Inner.Min.Y+height)
+
+	for y := 0; y < height; y++ {
+		row := rows[self.top+y]
+		for x, color := range row {
+			point := image.Pt(self.grid.Min.X+x*swatchWidth, self.grid.Min.Y+y)
+			if point.X+swatchWidth > self.Inner.Max.X {
+				break
+			}
+			swatch := "  "
+			if self.Focused && self.top+y == self.row && x == self.column {
+				swatch = "[]"
+			}
+			buf.SetString(swatch, NewStyle(contrastColor(color), color), point)
+		}
+	}
+
+	if self.Inner.Dy() > height+1 {
+		hovered := rows[self.row][self.column]
+		point := image.Pt(self.Inner.Min.X, self.Inner.Min.Y+height+1)
+		buf.Fill(NewCell(' ', NewStyle(ColorClear, hovered)), image.Rect(point.X, point.Y, MinInt(point.X+4, self.Inner.Max.X), point.Y+1))
+		buf.SetString(TrimString(describeColor(hovered), self.Inner.Dx()-5), self.TextStyle, image.Pt(point.X+5, point.Y))
+	}
+}
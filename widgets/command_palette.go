@@ -0,0 +1,252 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"sort"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// CommandPalette is a modal search over the active bindings of a Keymap, run by their description.
+// Open shows it as an overlay with ShowOverlay; while it is open every key should be sent to
+// HandleKey. Typing filters the commands with a fuzzy match, the commands run most recently are
+// listed first, and <Enter> runs the highlighted one.
+type CommandPalette struct {
+	Block
+	Keymap *Keymap
+
+	TextStyle      Style
+	KeyStyle       Style
+	MatchStyle     Style
+	HighlightStyle Style
+
+	// OnExecute is called with a command after its Action has run.
+	OnExecute func(binding *KeyBinding)
+
+	editor   lineEditor
+	matches  []*KeyBinding
+	selected int
+	top      int
+	// recent holds the commands run, the most recent first.
+	recent []*KeyBinding
+}
+
+func NewCommandPalette() *CommandPalette {
+	self := &CommandPalette{
+		Block:          *NewBlock(),
+		Keymap:         DefaultKeymap,
+		TextStyle:      Theme.CommandPalette.Text,
+		KeyStyle:       Theme.CommandPalette.Key,
+		MatchStyle:     Theme.CommandPalette.Match,
+		HighlightStyle: Theme.CommandPalette.Highlight,
+	}
+	self.Title = "Commands"
+	return self
+}
+
+// IsOpen reports whether the CommandPalette is shown.
+func (self *CommandPalette) IsOpen() bool {
+	return IsOverlay(self)
+}
+
+// Open clears the query and shows the CommandPalette as an overlay at its rect.
+func (self *CommandPalette) Open() {
+	self.editor.setText("")
+	self.filter()
+	ShowOverlay(self)
+}
+
+// Close hides the CommandPalette without running a command.
+func (self *CommandPalette) Close() {
+	HideOverlay(self)
+}
+
+// Query returns the text typed to search the commands.
+func (self *CommandPalette) Query() string {
+	return self.editor.text()
+}
+
+// Matches returns the commands matching the query in the order they are listed.
+func (self *CommandPalette) Matches() []*KeyBinding {
+	return append([]*KeyBinding{}, self.matches...)
+}
+
+// filter lists the commands matching the query, the recently run ones first in the order they
+// were run and the others by how closely they match.
+func (self *CommandPalette) filter() {
+	query := self.Query()
+	recency := map[*KeyBinding]int{}
+	for i, binding := range self.recent {
+		recency[binding] = i + 1
+	}
+	// end is the position of the last matching rune, which is lower for tighter and earlier matches
+	end := map[*KeyBinding]int{}
+	self.matches = self.matches[:0]
+	for _, binding := range self.Keymap.ActiveBindings() {
+		if binding.Action == nil {
+			continue
+		}
+		positions := matchRunes(binding.Description, query, true)
+		if positions == nil {
+			continue
+		}
+		if len(positions) > 0 {
+			end[binding] = positions[len(positions)-1]
+		}
+		self.matches = append(self.matches, binding)
+	}
+	sort.SliceStable(self.matches, func(i, j int) bool {
+		a, b := self.matches[i], self.matches[j]
+		switch {
+		case recency[a] > 0 && recency[b] > 0:
+			return recency[a] < recency[b]
+		case recency[a] > 0 || recency[b] > 0:
+			return recency[a] > 0
+		}
+		return end[a] < end[b]
+	})
+	self.selected, self.top = 0, 0
+}
+
+// Execute closes the CommandPalette and runs the highlighted command.
+func (self *CommandPalette) Execute() {
+	if self.selected >= len(self.matches) {
+		return
+	}
+	binding := self.matches[self.selected]
+	self.Close()
+	recent := []*KeyBinding{binding}
+	for _, b := range self.recent {
+		if b != binding {
+			recent = append(recent, b)
+		}
+	}
+	self.recent = recent
+	binding.Action()
+	if self.OnExecute != nil {
+		self.OnExecute(binding)
+	}
+}
+
+// HandleKey edits the query while the CommandPalette is open. <Up> and <Down> move through the
+// matching commands, <Enter> runs the highlighted one, and <Escape> closes the CommandPalette.
+// It reports whether the key was used, which is always the case while it is open.
+func (self *CommandPalette) HandleKey(id string) bool {
+	if !self.IsOpen() {
+		return false
+	}
+	switch id {
+	case "<Up>", "<C-p>":
+		self.selected = MaxInt(self.selected-1, 0)
+	case "<Down>", "<C-n>":
+		self.selected = MaxInt(MinInt(self.selected+1, len(self.matches)-1), 0)
+	case "<Enter>":
+		self.Execute()
+	case "<Escape>", "<C-c>":
+		self.Close()
+	default:
+		before := self.Query()
+		self.editor.handleKey(id)
+		if self.Query() != before {
+			self.filter()
+		}
+	}
+	return true
+}
+
+// HandleMouse runs a clicked command or closes the CommandPalette on a click outside of it, and
+// reports whether the event was used.
+func (self *CommandPalette) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !self.IsOpen() {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.selected = MaxInt(self.selected-WheelSteps(e), 0)
+	case "<MouseWheelDown>":
+		self.selected = MaxInt(MinInt(self.selected+WheelSteps(e), len(self.matches)-1), 0)
+	case "<MouseLeft>":
+		if e.Payload.(Mouse).Drag {
+			return true
+		}
+		if !p.In(self.Rectangle) {
+			self.Close()
+			return true
+		}
+		row := self.top + p.Y - self.Inner.Min.Y - 1
+		if p.In(self.Inner) && row >= self.top && row < len(self.matches) {
+			self.selected = row
+			self.Execute()
+		}
+	}
+	return true
+}
+
+// keyLabel returns the keys of a binding separated by spaces.
+func keyLabel(binding *KeyBinding) string {
+	return strings.Join(binding.Keys, " ")
+}
+
+func (self *CommandPalette) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	if self.Inner.Empty() {
+		return
+	}
+
+	prompt := "> "
+	buf.SetString(prompt, self.KeyStyle, self.Inner.Min)
+	width := rw.StringWidth(prompt)
+	self.editor.draw(buf, image.Pt(self.Inner.Min.X+width, self.Inner.Min.Y), self.Inner.Dx()-width, self.TextStyle, true)
+
+	height := self.Inner.Dy() - 1
+	if self.selected < self.top {
+		self.top = self.selected
+	} else if self.selected >= self.top+height {
+		self.top = self.selected - height + 1
+	}
+
+	query := self.Query()
+	for i := self.top; i < len(self.matches) && i-self.top < height; i++ {
+		binding := self.matches[i]
+		y := self.Inner.Min.Y + 1 + i - self.top
+		style, keyStyle, matchStyle := self.TextStyle, self.KeyStyle, self.MatchStyle
+		if i == self.selected {
+			style, keyStyle = self.HighlightStyle, self.HighlightStyle
+			matchStyle.Bg = self.HighlightStyle.Bg
+			buf.Fill(NewCell(' ', style), image.Rect(self.Inner.Min.X, y, self.Inner.Max.X, y+1))
+		}
+
+		keys := keyLabel(binding)
+		keysWidth := rw.StringWidth(keys)
+		textWidth := self.Inner.Dx() - keysWidth - 1
+		if textWidth < self.Inner.Dx()/2 {
+			keys, textWidth = "", self.Inner.Dx()
+		}
+		cells := RunesToStyledCells([]rune(binding.Description), style)
+		for _, k := range matchRunes(binding.Description, query, true) {
+			if k < len(cells) {
+				cells[k].Style = matchStyle
+			}
+		}
+		x := self.Inner.Min.X
+		for _, cell := range cells {
+			w := rw.RuneWidth(cell.Rune)
+			if x+w > self.Inner.Min.X+textWidth {
+				break
+			}
+			buf.SetCell(cell, image.Pt(x, y))
+			x += w
+		}
+		if keys != "" {
+			buf.SetString(keys, keyStyle, image.Pt(self.Inner.Max.X-keysWidth, y))
+		}
+	}
+}
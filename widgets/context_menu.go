@@ -0,0 +1,84 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// ContextMenu is a menu opened at a point, such as where the mouse was right-clicked or beside
+// the selection of a widget, and drawn as an overlay with ShowOverlay. It is closed by <Escape>, by
+// a click outside of it, or once an item is activated.
+type ContextMenu struct {
+	Items []*MenuItem
+	// Bounds is the area the menu and its submenus are kept within, usually the terminal, when it
+	// isn't empty.
+	Bounds image.Rectangle
+
+	stack menuStack
+}
+
+func NewContextMenu(items ...*MenuItem) *ContextMenu {
+	return &ContextMenu{
+		Items: items,
+	}
+}
+
+// IsOpen reports whether the menu is open.
+func (self *ContextMenu) IsOpen() bool {
+	return self.stack.isOpen()
+}
+
+// OpenAt opens the menu with its top left corner at p, closing it first if it is already open.
+func (self *ContextMenu) OpenAt(p image.Point) {
+	self.Close()
+	if len(self.Items) == 0 {
+		return
+	}
+	self.stack.bounds = self.Bounds
+	self.stack.push(self.Items, p.X, p.Y)
+}
+
+// Close closes the menu and its submenus.
+func (self *ContextMenu) Close() {
+	self.stack.closeAll()
+}
+
+// HandleKey moves through the open menu with <Up> and <Down>, activates an item with <Enter> or
+// its mnemonic, opens and closes submenus with <Right> and <Left>, and closes the menu with
+// <Escape>. It reports whether the key was used, which is always the case while the menu is open.
+func (self *ContextMenu) HandleKey(id string) bool {
+	if !self.IsOpen() {
+		return false
+	}
+	if !self.stack.handleKey(id) && id == "<Escape>" {
+		self.Close()
+	}
+	return true
+}
+
+// HandleMouse opens the menu where the mouse is right-clicked within area, activates clicked
+// items, and closes the menu on a click outside of it. It reports whether the event was used.
+// An empty area opens the menu on a right-click anywhere.
+func (self *ContextMenu) HandleMouse(e Event, area image.Rectangle) bool {
+	p, ok := MousePoint(e)
+	if !ok {
+		return false
+	}
+	if self.stack.handleMouse(e) {
+		return true
+	}
+	if e.ID == "<MouseRight>" && (area.Empty() || p.In(area)) {
+		self.OpenAt(p)
+		return true
+	}
+	if self.IsOpen() && (e.ID == "<MouseLeft>" || e.ID == "<MouseRight>" || e.ID == "<MouseMiddle>") {
+		self.Close()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// DialZone colors the part of the arc of a Dial from Min to Max.
+type DialZone struct {
+	Min   float64
+	Max   float64
+	Color Color
+}
+
+// Dial draws Value as a needle on an arc of three quarters of a circle from MinVal, at the bottom
+// left, to MaxVal, at the bottom right, drawn with braille dots. Zones color parts of the arc,
+// the rest of which is drawn with ArcColor, and the value formatted with Format is drawn under the
+// center, followed by the Label.
+type Dial struct {
+	Block
+	Value  float64
+	MinVal float64
+	MaxVal float64
+	Format string
+	Label  string
+	Zones  []DialZone
+	// ArcWidth is the width of the arc in dots.
+	ArcWidth int
+
+	ArcColor    Color
+	NeedleColor Color
+	ValueStyle  Style
+	LabelStyle  Style
+}
+
+func NewDial() *Dial {
+	return &Dial{
+		Block:       *NewBlock(),
+		MaxVal:      100,
+		Format:      "%.0f",
+		ArcWidth:    2,
+		ArcColor:    Theme.Dial.Arc,
+		NeedleColor: Theme.Dial.Needle,
+		ValueStyle:  Theme.Dial.Value,
+		LabelStyle:  Theme.Dial.Label,
+	}
+}
+
+// angle returns the angle of a value on the arc, in radians clockwise from 3 o'clock.
+func (self *Dial) angle(value float64) float64 {
+	fraction := 0.0
+	if self.MaxVal > self.MinVal {
+		fraction = math.Max(math.Min((value-self.MinVal)/(self.MaxVal-self.MinVal), 1), 0)
+	}
+	return 3*math.Pi/4 + fraction*3*math.Pi/2
+}
+
+func (self *Dial) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+
+	canvas := NewCanvas()
+	canvas.Rectangle = self.Inner
+	res := canvas.Mode.Resolution()
+	// the arc is as wide as its diameter and reaches down sin(45°) of its radius under the center,
+	// with a row left for the labels of the ends
+	radius := int(math.Min(
+		float64(self.Inner.Dx()*res.X)/2,
+		float64((self.Inner.Dy()-1)*res.Y)/(1+math.Sqrt2/2),
+	)) - self.ArcWidth
+	if radius < 4 {
+		return
+	}
+	center := image.Pt(
+		(self.Inner.Min.X+self.Inner.Max.X)*res.X/2,
+		self.Inner.Min.Y*res.Y+radius+self.ArcWidth,
+	)
+	point := func(angle, distance float64) image.Point {
+		return image.Pt(
+			center.X+int(math.Round(distance*math.Cos(angle))),
+			center.Y+int(math.Round(distance*math.Sin(angle))),
+		)
+	}
+
+	canvas.LineWidth = MaxInt(self.ArcWidth, 1)
+	canvas.SetArc(center, radius, self.angle(self.MinVal), self.angle(self.MaxVal), self.ArcColor)
+	for _, zone := range self.Zones {
+		canvas.SetArc(center, radius, self.angle(zone.Min), self.angle(zone.Max), zone.Color)
+	}
+	canvas.LineWidth = 1
+	canvas.SetLine(center, point(self.angle(self.Value), float64(radius-self.ArcWidth-1)), self.NeedleColor)
+
+	// the value and label under the center, and the limits under the ends of the arc
+	y := center.Y + res.Y
+	canvas.SetAlignedText(image.Pt(center.X, y), fmt.Sprintf(self.Format, self.Value), self.ValueStyle, AlignCenter)
+	if self.Label != "" {
+		canvas.SetAlignedText(image.Pt(center.X, y+res.Y), self.Label, self.LabelStyle, AlignCenter)
+	}
+	for _, limit := range []float64{self.MinVal, self.MaxVal} {
+		p := point(self.angle(limit), float64(radius))
+		canvas.SetAlignedText(image.Pt(p.X, p.Y+res.Y), fmt.Sprintf(self.Format, limit), self.LabelStyle, AlignCenter)
+	}
+	canvas.Draw(buf)
+}
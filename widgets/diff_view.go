@@ -0,0 +1,475 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"regexp"
+	"strconv"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+type diffKind uint
+
+const (
+	diffEqual diffKind = iota
+	diffRemoved
+	diffAdded
+	diffChanged
+	// diffHunk is the header of a hunk of a unified diff, drawn across both sides.
+	diffHunk
+)
+
+// diffLine is a side of a row of a DiffView, with the cells changed within the line highlighted.
+// Number is 0 on the side of a line added or removed on the other side.
+type diffLine struct {
+	number    int
+	text      []rune
+	highlight []bool
+}
+
+type diffRow struct {
+	kind        diffKind
+	left, right diffLine
+}
+
+// DiffView shows the changes between two texts side by side, the old text on the left and the new
+// one on the right, scrolled together. Removed and added lines are drawn with RemovedStyle and
+// AddedStyle, and a removed line facing an added one is drawn as changed, with the changed parts
+// highlighted. n and p scroll to the next and previous hunk of changes.
+type DiffView struct {
+	Block
+	// LeftTitle and RightTitle are drawn above the sides, such as the names of the files compared.
+	LeftTitle  string
+	RightTitle string
+	TabWidth   int
+
+	TextStyle             Style
+	RemovedStyle          Style
+	AddedStyle            Style
+	RemovedHighlightStyle Style
+	AddedHighlightStyle   Style
+	LineNumberStyle       Style
+	HunkStyle             Style
+	TitleStyle            Style
+	ScrollbarStyle        Style
+
+	rows []diffRow
+	// hunks holds the first row of every run of changed rows.
+	hunks   []int
+	topRow  int
+	offset  int
+	visible int
+}
+
+func NewDiffView() *DiffView {
+	return &DiffView{
+		Block:                 *NewBlock(),
+		TabWidth:              4,
+		TextStyle:             Theme.DiffView.Text,
+		RemovedStyle:          Theme.DiffView.Removed,
+		AddedStyle:            Theme.DiffView.Added,
+		RemovedHighlightStyle: Theme.DiffView.RemovedHighlight,
+		AddedHighlightStyle:   Theme.DiffView.AddedHighlight,
+		LineNumberStyle:       Theme.DiffView.LineNumber,
+		HunkStyle:             Theme.DiffView.Hunk,
+		TitleStyle:            Theme.DiffView.Title,
+		ScrollbarStyle:        Theme.DiffView.Scrollbar,
+	}
+}
+
+// SetTexts compares the lines of the old and new texts and shows the differences.
+func (self *DiffView) SetTexts(old, new string) {
+	a, b := self.splitLines(old), self.splitLines(new)
+	self.rows = self.rows[:0]
+	removed, added := []diffLine{}, []diffLine{}
+	flush := func() {
+		self.addChanges(removed, added)
+		removed, added = removed[:0], added[:0]
+	}
+	for _, op := range diffLines(a, b) {
+		switch op.kind {
+		case diffRemoved:
+			removed = append(removed, diffLine{number: op.a + 1, text: []rune(a[op.a])})
+		case diffAdded:
+			added = append(added, diffLine{number: op.b + 1, text: []rune(b[op.b])})
+		default:
+			flush()
+			self.rows = append(self.rows, diffRow{
+				diffEqual,
+				diffLine{number: op.a + 1, text: []rune(a[op.a])},
+				diffLine{number: op.b + 1, text: []rune(b[op.b])},
+			})
+		}
+	}
+	flush()
+	self.findHunks()
+}
+
+var unifiedHunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// SetUnifiedDiff shows the hunks of a diff in the unified format, such as the output of diff -u
+// or git diff, with the file names of its --- and +++ lines as the titles when they aren't set.
+// Only the first file of a diff of several files is shown.
+func (self *DiffView) SetUnifiedDiff(diff string) error {
+	self.rows = self.rows[:0]
+	removed, added := []diffLine{}, []diffLine{}
+	flush := func() {
+		self.addChanges(removed, added)
+		removed, added = removed[:0], added[:0]
+	}
+	left, right := 0, 0
+	inHunk := false
+	for i, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		switch {
+		case !inHunk && strings.HasPrefix(line, "--- "):
+			if self.LeftTitle == "" {
+				self.LeftTitle = diffFileName(line)
+			}
+		case !inHunk && strings.HasPrefix(line, "+++ "):
+			if self.RightTitle == "" {
+				self.RightTitle = diffFileName(line)
+			}
+		case strings.HasPrefix(line, "@@"):
+			match := unifiedHunkHeader.FindStringSubmatch(line)
+			if match == nil {
+				return fmt.Errorf("line %d: invalid hunk header %q", i+1, line)
+			}
+			flush()
+			left, _ = strconv.Atoi(match[1])
+			right, _ = strconv.Atoi(match[2])
+			inHunk = true
+			self.rows = append(self.rows, diffRow{kind: diffHunk, left: diffLine{text: []rune(line)}})
+		case !inHunk:
+			// the lines before the first hunk, such as the command or index of git diff
+		case strings.HasPrefix(line, "diff "):
+			// the next file
+			flush()
+			self.findHunks()
+			return nil
+		case strings.HasPrefix(line, "-"):
+			removed = append(removed, diffLine{number: left, text: []rune(self.expand(line[1:]))})
+			left++
+		case strings.HasPrefix(line, "+"):
+			added = append(added, diffLine{number: right, text: []rune(self.expand(line[1:]))})
+			right++
+		case strings.HasPrefix(line, " ") || line == "":
+			flush()
+			text := []rune(self.expand(strings.TrimPrefix(line, " ")))
+			self.rows = append(self.rows, diffRow{diffEqual, diffLine{number: left, text: text}, diffLine{number: right, text: text}})
+			left++
+			right++
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file"
+		default:
+			return fmt.Errorf("line %d: invalid diff line %q", i+1, line)
+		}
+	}
+	flush()
+	self.findHunks()
+	return nil
+}
+
+// diffFileName returns the name of a --- or +++ line of a unified diff, without the timestamp of
+// diff -u and the a/ and b/ prefixes of git diff.
+func diffFileName(line string) string {
+	name := strings.SplitN(line[4:], "\t", 2)[0]
+	if strings.HasPrefix(name, "a/") || strings.HasPrefix(name, "b/") {
+		name = name[2:]
+	}
+	return name
+}
+
+func (self *DiffView) expand(line string) string {
+	return expandTabs(line, self.TabWidth)
+}
+
+func (self *DiffView) splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(self.expand(text), "\n"), "\n")
+}
+
+// addChanges adds rows facing the removed lines with the added ones, highlighting the changes
+// within the lines facing each other.
+func (self *DiffView) addChanges(removed, added []diffLine) {
+	for i := 0; i < len(removed) || i < len(added); i++ {
+		row := diffRow{}
+		switch {
+		case i >= len(added):
+			row.kind, row.left = diffRemoved, removed[i]
+		case i >= len(removed):
+			row.kind, row.right = diffAdded, added[i]
+		default:
+			row.kind, row.left, row.right = diffChanged, removed[i], added[i]
+			a, b := row.left.text, row.right.text
+			row.left.highlight = make([]bool, len(a))
+			row.right.highlight = make([]bool, len(b))
+			for _, op := range diffSequences(len(a), len(b), func(i, j int) bool { return a[i] == b[j] }) {
+				switch op.kind {
+				case diffRemoved:
+					row.left.highlight[op.a] = true
+				case diffAdded:
+					row.right.highlight[op.b] = true
+				}
+			}
+		}
+		self.rows = append(self.rows, row)
+	}
+}
+
+func (self *DiffView) findHunks() {
+	self.hunks = self.hunks[:0]
+	for i, row := range self.rows {
+		if row.kind != diffEqual && row.kind != diffHunk && (i == 0 || self.rows[i-1].kind == diffEqual || self.rows[i-1].kind == diffHunk) {
+			self.hunks = append(self.hunks, i)
+		}
+	}
+	self.topRow, self.offset = 0, 0
+}
+
+// diffOp is an edit turning a sequence into another: the element a of the first sequence is kept
+// as the element b of the second one, removed, or b is added.
+type diffOp struct {
+	kind diffKind
+	a, b int
+}
+
+func diffLines(a, b []string) []diffOp {
+	return diffSequences(len(a), len(b), func(i, j int) bool { return a[i] == b[j] })
+}
+
+// diffSequences returns the edits turning a sequence of n elements into one of m elements keeping
+// their longest common subsequence, the elements at i and j being equal when equal(i, j).
+// The common prefix and suffix are kept without comparing the rest against them.
+func diffSequences(n, m int, equal func(i, j int) bool) []diffOp {
+	prefix := 0
+	for prefix < n && prefix < m && equal(prefix, prefix) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < n-prefix && suffix < m-prefix && equal(n-1-suffix, m-1-suffix) {
+		suffix++
+	}
+
+	// lengths[i][j] is the length of the longest common subsequence of the elements from i and j
+	rows, cols := n-prefix-suffix, m-prefix-suffix
+	lengths := make([][]int32, rows+1)
+	for i := range lengths {
+		lengths[i] = make([]int32, cols+1)
+	}
+	for i := rows - 1; i >= 0; i-- {
+		for j := cols - 1; j >= 0; j-- {
+			if equal(prefix+i, prefix+j) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, diffOp{diffEqual, i, i})
+	}
+	i, j := 0, 0
+	for i < rows || j < cols {
+		switch {
+		case i < rows && j < cols && equal(prefix+i, prefix+j):
+			ops = append(ops, diffOp{diffEqual, prefix + i, prefix + j})
+			i++
+			j++
+		case j >= cols || (i < rows && lengths[i+1][j] >= lengths[i][j+1]):
+			ops = append(ops, diffOp{diffRemoved, prefix + i, prefix + j})
+			i++
+		default:
+			ops = append(ops, diffOp{diffAdded, prefix + i, prefix + j})
+			j++
+		}
+	}
+	for k := 0; k < suffix; k++ {
+		ops = append(ops, diffOp{diffEqual, n - suffix + k, m - suffix + k})
+	}
+	return ops
+}
+
+// Hunks returns the number of runs of changed lines.
+func (self *DiffView) Hunks() int {
+	return len(self.hunks)
+}
+
+// hunkContext is the number of rows kept above a hunk scrolled to.
+const hunkContext = 2
+
+// NextHunk scrolls to the first hunk below the top of the view.
+func (self *DiffView) NextHunk() {
+	for _, hunk := range self.hunks {
+		if hunk-hunkContext > self.topRow {
+			self.topRow = hunk - hunkContext
+			return
+		}
+	}
+}
+
+// PreviousHunk scrolls to the last hunk above the top of the view.
+func (self *DiffView) PreviousHunk() {
+	for i := len(self.hunks) - 1; i >= 0; i-- {
+		if top := MaxInt(self.hunks[i]-hunkContext, 0); top < self.topRow {
+			self.topRow = top
+			return
+		}
+	}
+}
+
+// ScrollAmount scrolls both sides by amount rows. If amount is < 0, then scroll up.
+func (self *DiffView) ScrollAmount(amount int) {
+	self.topRow = MaxInt(self.topRow+amount, 0)
+}
+
+// ScrollLeft scrolls both sides by columns, right for negative ones.
+func (self *DiffView) ScrollLeft(columns int) {
+	self.offset = MaxInt(self.offset-columns, 0)
+}
+
+// HandleKey scrolls with the arrow keys and hjkl, <PageUp>, <PageDown>, <Home>, and <End>, and moves
+// to the next and previous hunk with n and p. It reports whether the key was used.
+func (self *DiffView) HandleKey(id string) bool {
+	switch id {
+	case "<Up>", "k":
+		self.ScrollAmount(-1)
+	case "<Down>", "j":
+		self.ScrollAmount(1)
+	case "<PageUp>":
+		self.ScrollAmount(-MaxInt(self.visible-1, 1))
+	case "<PageDown>", "<Space>":
+		self.ScrollAmount(MaxInt(self.visible-1, 1))
+	case "<Home>", "g":
+		self.topRow = 0
+	case "<End>", "G":
+		self.topRow = len(self.rows)
+	case "<Left>", "h":
+		self.ScrollLeft(4)
+	case "<Right>", "l":
+		self.ScrollLeft(-4)
+	case "n":
+		self.NextHunk()
+	case "p":
+		self.PreviousHunk()
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse scrolls with the mouse wheel and reports whether the event was used.
+func (self *DiffView) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Inner) {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.ScrollAmount(-3 * WheelSteps(e))
+	case "<MouseWheelDown>":
+		self.ScrollAmount(3 * WheelSteps(e))
+	default:
+		return false
+	}
+	return true
+}
+
+func (self *DiffView) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	area := self.Inner
+	if self.LeftTitle != "" || self.RightTitle != "" {
+		area.Min.Y++
+	}
+	self.visible = area.Dy()
+	if area.Dy() <= 0 || area.Dx() < 3 {
+		return
+	}
+	self.topRow = MaxInt(MinInt(self.topRow, len(self.rows)-area.Dy()), 0)
+
+	// leave the last column to the scrollbar when the rows don't fit
+	scrollbar := len(self.rows) > area.Dy()
+	width := area.Dx()
+	if scrollbar {
+		width--
+	}
+	lastNumber := 0
+	for _, row := range self.rows {
+		lastNumber = MaxInt(lastNumber, MaxInt(row.left.number, row.right.number))
+	}
+	gutter := len(strconv.Itoa(lastNumber)) + 1
+	sideWidth := (width - 1) / 2
+	left := image.Rect(area.Min.X, area.Min.Y, area.Min.X+sideWidth, area.Max.Y)
+	right := image.Rect(left.Max.X+1, area.Min.Y, area.Min.X+width, area.Max.Y)
+
+	if area.Min.Y > self.Inner.Min.Y {
+		buf.SetString(TrimString(self.LeftTitle, left.Dx()), self.TitleStyle, image.Pt(left.Min.X, self.Inner.Min.Y))
+		buf.SetString(TrimString(self.RightTitle, right.Dx()), self.TitleStyle, image.Pt(right.Min.X, self.Inner.Min.Y))
+	}
+
+	for y := area.Min.Y; y < area.Max.Y && self.topRow+y-area.Min.Y < len(self.rows); y++ {
+		row := self.rows[self.topRow+y-area.Min.Y]
+		if row.kind == diffHunk {
+			buf.SetString(TrimString(string(row.left.text), width), self.HunkStyle, image.Pt(area.Min.X, y))
+			continue
+		}
+		buf.SetCell(NewCell(VERTICAL_LINE, self.LineNumberStyle), image.Pt(left.Max.X, y))
+
+		leftStyle, leftHighlight := self.TextStyle, self.TextStyle
+		rightStyle, rightHighlight := self.TextStyle, self.TextStyle
+		if row.kind == diffRemoved || row.kind == diffChanged {
+			leftStyle, leftHighlight = self.RemovedStyle, self.RemovedHighlightStyle
+		}
+		if row.kind == diffAdded || row.kind == diffChanged {
+			rightStyle, rightHighlight = self.AddedStyle, self.AddedHighlightStyle
+		}
+		self.drawLine(buf, row.left, image.Rect(left.Min.X, y, left.Max.X, y+1), gutter, leftStyle, leftHighlight)
+		self.drawLine(buf, row.right, image.Rect(right.Min.X, y, right.Max.X, y+1), gutter, rightStyle, rightHighlight)
+	}
+
+	if scrollbar {
+		drawScrollbar(buf, area.Max.X-1, area.Min.Y, area.Max.Y, self.topRow, area.Dy(), len(self.rows), self.ScrollbarStyle)
+	}
+}
+
+// drawLine draws a side of a row in rect, its line number in the gutter followed by its text
+// scrolled by the offset, filling the rest of rect with the background of style.
+func (self *DiffView) drawLine(buf *Buffer, line diffLine, rect image.Rectangle, gutter int, style, highlight Style) {
+	if line.number == 0 {
+		return
+	}
+	number := strconv.Itoa(line.number)
+	buf.SetString(TrimString(number, rect.Dx()), self.LineNumberStyle, image.Pt(rect.Min.X+gutter-1-len(number), rect.Min.Y))
+	rect.Min.X += gutter
+	buf.Fill(NewCell(' ', style), rect)
+
+	x := rect.Min.X - self.offset
+	for i, r := range line.text {
+		w := rw.RuneWidth(r)
+		if x >= rect.Min.X && x+w <= rect.Max.X {
+			cellStyle := style
+			if line.highlight != nil && line.highlight[i] {
+				cellStyle = highlight
+			}
+			buf.SetCell(NewCell(r, cellStyle), image.Pt(x, rect.Min.Y))
+		}
+		x += w
+		if x >= rect.Max.X {
+			break
+		}
+	}
+}
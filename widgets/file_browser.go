@@ -0,0 +1,376 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// FileSort is the order the entries of a FileBrowser are listed in. Directories always come first.
+type FileSort uint
+
+const (
+	FileSortName FileSort = iota
+	FileSortSize
+	FileSortModified
+)
+
+func (self FileSort) String() string {
+	switch self {
+	case FileSortSize:
+		return "size"
+	case FileSortModified:
+		return "modified"
+	}
+	return "name"
+}
+
+// FileBrowser is a List of the entries of Dir for picking files to open or save. <Enter> opens the
+// directory under the cursor or selects the file, <Backspace> goes to the parent directory, "."
+// toggles hidden files, "s" cycles the Sort and "S" reverses it. With MultiSelect set, <Space> marks
+// entries and <Enter> selects all the marked ones.
+type FileBrowser struct {
+	List
+	// Dir is the absolute path of the directory listed. Change it with SetDir.
+	Dir        string
+	ShowHidden bool
+	Sort       FileSort
+	Reverse    bool
+	// Pattern is a glob, such as "*.go", the names of the files listed must match.
+	// Directories are listed whatever their name.
+	Pattern string
+
+	FileStyle      Style
+	DirectoryStyle Style
+
+	// OnSelect is called with the paths of the selected files.
+	OnSelect func(paths []string)
+	// OnChangeDir is called after Dir changes.
+	OnChangeDir func(dir string)
+
+	// entries holds the entries listed, in the order of Items, after the ".." entry if there is one.
+	entries []os.FileInfo
+	parent  bool
+	err     error
+}
+
+// NewFileBrowser returns a FileBrowser listing dir. Err reports if it couldn't be read.
+func NewFileBrowser(dir string) *FileBrowser {
+	self := &FileBrowser{
+		List:           *NewList(),
+		FileStyle:      Theme.FileBrowser.File,
+		DirectoryStyle: Theme.FileBrowser.Directory,
+	}
+	self.OnActivate = func(row int) {
+		self.SelectedRow = row
+		self.Open()
+	}
+	self.err = self.SetDir(dir)
+	return self
+}
+
+// Err returns the error from the last read of Dir, if any.
+func (self *FileBrowser) Err() error {
+	return self.err
+}
+
+// SetDir lists dir, relative to Dir if it isn't absolute. Dir is left unchanged if dir can't be read.
+func (self *FileBrowser) SetDir(dir string) error {
+	if !filepath.IsAbs(dir) && self.Dir != "" {
+		dir = filepath.Join(self.Dir, dir)
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	previous := self.Dir
+	self.Dir = dir
+	if err := self.Refresh(); err != nil {
+		self.Dir = previous
+		if previous != "" {
+			self.Refresh()
+		}
+		self.err = err
+		return err
+	}
+	self.SelectedRow = 0
+	// put the cursor on the directory we came up from
+	if filepath.Dir(previous) == dir {
+		self.selectName(filepath.Base(previous))
+	}
+	self.ClearSearch()
+	if self.OnChangeDir != nil {
+		self.OnChangeDir(dir)
+	}
+	return nil
+}
+
+// Up lists the parent of Dir.
+func (self *FileBrowser) Up() error {
+	return self.SetDir(filepath.Dir(self.Dir))
+}
+
+// Refresh reads Dir again, keeping the cursor on the same entry when it's still there.
+// Marked entries are unmarked.
+func (self *FileBrowser) Refresh() error {
+	infos, err := ioutil.ReadDir(self.Dir)
+	self.err = err
+	if err != nil {
+		return err
+	}
+	current := self.CurrentPath()
+
+	entries := infos[:0]
+	for _, info := range infos {
+		if !self.ShowHidden && strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		if !info.IsDir() && self.Pattern != "" {
+			if ok, _ := filepath.Match(self.Pattern, info.Name()); !ok {
+				continue
+			}
+		}
+		entries = append(entries, info)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.IsDir() != b.IsDir() {
+			return a.IsDir()
+		}
+		if self.Reverse {
+			a, b = b, a
+		}
+		switch self.Sort {
+		case FileSortSize:
+			if a.Size() != b.Size() {
+				return a.Size() < b.Size()
+			}
+		case FileSortModified:
+			if !a.ModTime().Equal(b.ModTime()) {
+				return a.ModTime().Before(b.ModTime())
+			}
+		}
+		return strings.ToLower(a.Name()) < strings.ToLower(b.Name())
+	})
+	self.entries = entries
+	self.parent = filepath.Dir(self.Dir) != self.Dir
+
+	items := make([]ListItem, 0, len(entries)+1)
+	directoryStyle, fileStyle := self.DirectoryStyle, self.FileStyle
+	if self.parent {
+		items = append(items, ListItem{
+			Icon:  Theme.FileBrowser.DirectoryRune,
+			Text:  "..",
+			Style: &directoryStyle,
+		})
+	}
+	for _, info := range entries {
+		if info.IsDir() {
+			items = append(items, ListItem{
+				Icon:  Theme.FileBrowser.DirectoryRune,
+				Text:  info.Name() + string(filepath.Separator),
+				Style: &directoryStyle,
+			})
+		} else {
+			items = append(items, ListItem{
+				Icon:      Theme.FileBrowser.FileRune,
+				Text:      info.Name(),
+				Secondary: formatSize(info.Size()),
+				Style:     &fileStyle,
+			})
+		}
+	}
+	self.Items = items
+	self.ClearSelection()
+	if filepath.Dir(current) != self.Dir || !self.selectName(filepath.Base(current)) {
+		if self.SelectedRow >= len(items) {
+			self.SelectedRow = len(items) - 1
+		}
+	}
+	if self.SelectedRow < 0 {
+		self.SelectedRow = 0
+	}
+	return nil
+}
+
+// selectName moves the cursor to the entry with the given name and reports whether there is one.
+func (self *FileBrowser) selectName(name string) bool {
+	for i, info := range self.entries {
+		if info.Name() == name {
+			self.SelectedRow = self.row(i)
+			return true
+		}
+	}
+	return false
+}
+
+// row returns the row of Items showing the entry at index i.
+func (self *FileBrowser) row(i int) int {
+	if self.parent {
+		return i + 1
+	}
+	return i
+}
+
+// entry returns the entry shown on a row, or nil for the ".." row.
+func (self *FileBrowser) entry(row int) os.FileInfo {
+	if self.parent {
+		row--
+	}
+	if row < 0 || row >= len(self.entries) {
+		return nil
+	}
+	return self.entries[row]
+}
+
+// CurrentPath returns the path of the entry under the cursor, or Dir if there is none.
+func (self *FileBrowser) CurrentPath() string {
+	if self.parent && self.SelectedRow == 0 {
+		return filepath.Dir(self.Dir)
+	}
+	if info := self.entry(self.SelectedRow); info != nil {
+		return filepath.Join(self.Dir, info.Name())
+	}
+	return self.Dir
+}
+
+// SelectedPaths returns the paths of the marked entries, or of the file under the cursor when
+// nothing is marked.
+func (self *FileBrowser) SelectedPaths() []string {
+	paths := []string{}
+	for _, row := range self.Selected() {
+		if info := self.entry(row); info != nil {
+			paths = append(paths, filepath.Join(self.Dir, info.Name()))
+		}
+	}
+	if len(paths) == 0 {
+		if info := self.entry(self.SelectedRow); info != nil && !info.IsDir() {
+			paths = append(paths, filepath.Join(self.Dir, info.Name()))
+		}
+	}
+	return paths
+}
+
+// Open lists the directory under the cursor, or calls OnSelect with the SelectedPaths when the
+// cursor is on a file or entries are marked.
+func (self *FileBrowser) Open() error {
+	if len(self.Selected()) == 0 {
+		if self.parent && self.SelectedRow == 0 {
+			return self.Up()
+		}
+		info := self.entry(self.SelectedRow)
+		if info == nil {
+			return nil
+		}
+		if info.IsDir() {
+			return self.SetDir(filepath.Join(self.Dir, info.Name()))
+		}
+	}
+	if paths := self.SelectedPaths(); len(paths) > 0 && self.OnSelect != nil {
+		self.OnSelect(paths)
+	}
+	return nil
+}
+
+// ToggleHidden shows or hides the entries whose name starts with a dot.
+func (self *FileBrowser) ToggleHidden() error {
+	self.ShowHidden = !self.ShowHidden
+	return self.Refresh()
+}
+
+// SetSort lists the entries in another order.
+func (self *FileBrowser) SetSort(order FileSort, reverse bool) error {
+	self.Sort, self.Reverse = order, reverse
+	return self.Refresh()
+}
+
+// SetPattern filters the files listed with a glob, or shows them all when pattern is empty.
+func (self *FileBrowser) SetPattern(pattern string) error {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return err
+	}
+	self.Pattern = pattern
+	return self.Refresh()
+}
+
+// HandleKey applies a keyboard event ID to the FileBrowser and reports whether it was used.
+// "/" searches the entries listed.
+func (self *FileBrowser) HandleKey(id string) bool {
+	if self.Searching() {
+		return self.HandleSearchKey(id)
+	}
+	var err error
+	switch id {
+	case "<Enter>", "l", "<Right>":
+		err = self.Open()
+	case "<Backspace>", "h", "<Left>":
+		err = self.Up()
+	case ".":
+		err = self.ToggleHidden()
+	case "s":
+		err = self.SetSort((self.Sort+1)%(FileSortModified+1), self.Reverse)
+	case "S":
+		err = self.SetSort(self.Sort, !self.Reverse)
+	case "<Space>":
+		if !self.MultiSelect {
+			return false
+		}
+		// the ".." entry can't be marked
+		if self.entry(self.SelectedRow) != nil {
+			self.ToggleSelection()
+		}
+		self.ScrollDown()
+	case "/":
+		self.StartSearch()
+	case "<Escape>":
+		if len(self.Selected()) == 0 && self.SearchQuery() == "" {
+			return false
+		}
+		self.ClearSelection()
+		self.ClearSearch()
+	case "j", "<Down>":
+		self.ScrollDown()
+	case "k", "<Up>":
+		self.ScrollUp()
+	case "<PageDown>", "<C-f>":
+		self.ScrollPageDown()
+	case "<PageUp>", "<C-b>":
+		self.ScrollPageUp()
+	case "<Home>", "g":
+		self.ScrollTop()
+	case "<End>", "G":
+		self.ScrollBottom()
+	default:
+		return false
+	}
+	if err != nil {
+		self.err = err
+	}
+	return true
+}
+
+// formatSize formats a number of bytes with a binary unit, like 1.5K.
+func formatSize(size int64) string {
+	const units = "KMGTPE"
+	if size < 1024 {
+		return fmt.Sprintf("%dB", size)
+	}
+	value, unit := float64(size)/1024, 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if value < 10 {
+		return fmt.Sprintf("%.1f%c", value, units[unit])
+	}
+	return fmt.Sprintf("%.0f%c", value, units[unit])
+}
@@ -0,0 +1,295 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// FormInput is a widget that can be used as a field of a Form.
+type FormInput interface {
+	Drawable
+	// HandleKey handles a keyboard event ID sent while the input is focused and reports whether it was used.
+	HandleKey(id string) bool
+	SetFocused(focused bool)
+	// FormValue returns the value of the input put in the values of the Form.
+	FormValue() interface{}
+}
+
+// FormValidator checks the value of a field, returning an error describing why it is invalid.
+type FormValidator func(value interface{}) error
+
+// Required is a FormValidator rejecting empty strings, false, and nil.
+func Required(value interface{}) error {
+	switch value {
+	case nil, "", false:
+		return errors.New("required")
+	}
+	return nil
+}
+
+// FormField is a labelled input of a Form.
+type FormField struct {
+	Label string
+	// Name is the key of the value of the field in the values of the Form.
+	Name  string
+	Input FormInput
+	// Height is the number of rows of the Input, 3 by default to fit its border.
+	Height     int
+	Validators []FormValidator
+
+	// Error is set by Validate to the first error returned by the Validators, and drawn below the Input.
+	Error error
+}
+
+// Form lays out labelled fields in a column and moves the focus between them with <Tab>.
+// Validate runs the validators of every field, and Submit calls OnSubmit with the values of the
+// fields once they are all valid.
+type Form struct {
+	Block
+	Fields []*FormField
+
+	LabelStyle        Style
+	FocusedLabelStyle Style
+	ErrorStyle        Style
+
+	OnSubmit func(values map[string]interface{})
+
+	focus int
+	top   int
+}
+
+func NewForm() *Form {
+	return &Form{
+		Block:             *NewBlock(),
+		LabelStyle:        Theme.Form.Label,
+		FocusedLabelStyle: Theme.Form.FocusedLabel,
+		ErrorStyle:        Theme.Form.Error,
+	}
+}
+
+// AddField appends a field and returns it. The first field added is focused.
+func (self *Form) AddField(label, name string, input FormInput, validators ...FormValidator) *FormField {
+	field := &FormField{
+		Label:      label,
+		Name:       name,
+		Input:      input,
+		Height:     3,
+		Validators: validators,
+	}
+	self.Fields = append(self.Fields, field)
+	self.Focus(self.focus)
+	return field
+}
+
+// Focused returns the index of the focused field.
+func (self *Form) Focused() int {
+	return self.focus
+}
+
+// Focus moves the focus to the field at index i.
+func (self *Form) Focus(i int) {
+	if len(self.Fields) == 0 {
+		return
+	}
+	self.focus = MaxInt(MinInt(i, len(self.Fields)-1), 0)
+	for j, field := range self.Fields {
+		field.Input.SetFocused(j == self.focus)
+	}
+}
+
+// FocusNext moves the focus to the next field, wrapping around to the first.
+func (self *Form) FocusNext() {
+	if len(self.Fields) > 0 {
+		self.Focus((self.focus + 1) % len(self.Fields))
+	}
+}
+
+// FocusPrevious moves the focus to the previous field, wrapping around to the last.
+func (self *Form) FocusPrevious() {
+	if len(self.Fields) > 0 {
+		self.Focus((self.focus + len(self.Fields) - 1) % len(self.Fields))
+	}
+}
+
+// Accessibility implements Accessible with the Title and the label of the focused field, whose
+// changes are announced as the focus moves between fields.
+func (self *Form) Accessibility() (string, bool, string) {
+	if len(self.Fields) == 0 {
+		return self.Title, false, ""
+	}
+	return self.Title, false, self.Fields[self.focus].Label
+}
+
+// Values returns the values of the fields by Name.
+func (self *Form) Values() map[string]interface{} {
+	values := make(map[string]interface{}, len(self.Fields))
+	for _, field := range self.Fields {
+		values[field.Name] = field.Input.FormValue()
+	}
+	return values
+}
+
+// Validate runs the validators of every field, setting their Error, and reports whether all of
+// them are valid. The focus moves to the first invalid field.
+func (self *Form) Validate() bool {
+	invalid := -1
+	for i, field := range self.Fields {
+		field.Error = nil
+		value := field.Input.FormValue()
+		for _, validator := range field.Validators {
+			if err := validator(value); err != nil {
+				field.Error = err
+				break
+			}
+		}
+		if field.Error != nil && invalid < 0 {
+			invalid = i
+		}
+	}
+	if invalid >= 0 {
+		self.Focus(invalid)
+		return false
+	}
+	return true
+}
+
+// Submit validates the fields and calls OnSubmit with their values if they are valid,
+// reporting whether they were.
+func (self *Form) Submit() bool {
+	if !self.Validate() {
+		return false
+	}
+	if self.OnSubmit != nil {
+		self.OnSubmit(self.Values())
+	}
+	return true
+}
+
+// HandleKey sends a keyboard event ID to the focused input and reports whether it was used.
+// <Tab> always moves the focus to the next field. <Down> and <Up> move it, and <Enter> submits the
+// form, when the input doesn't use them.
+func (self *Form) HandleKey(id string) bool {
+	if len(self.Fields) == 0 {
+		return false
+	}
+	if id == "<Tab>" {
+		self.FocusNext()
+		return true
+	}
+	if self.Fields[self.focus].Input.HandleKey(id) {
+		return true
+	}
+	switch id {
+	case "<Down>":
+		self.FocusNext()
+	case "<Up>":
+		self.FocusPrevious()
+	case "<Enter>":
+		self.Submit()
+	default:
+		return false
+	}
+	return true
+}
+
+// HandlePaste sends the text of a <Paste> event to the focused input, if it handles pastes, and
+// reports whether it was used.
+func (self *Form) HandlePaste(text string) bool {
+	if len(self.Fields) == 0 {
+		return false
+	}
+	if input, ok := self.Fields[self.focus].Input.(interface{ HandlePaste(string) bool }); ok {
+		return input.HandlePaste(text)
+	}
+	return false
+}
+
+// HandleMouse focuses a clicked field, passing the event on to inputs that handle the mouse,
+// and reports whether it was used.
+func (self *Form) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Inner) {
+		return false
+	}
+	for i, field := range self.Fields {
+		if !p.In(field.Input.GetRect()) {
+			continue
+		}
+		if e.ID == "<MouseLeft>" {
+			self.Focus(i)
+		}
+		if input, ok := field.Input.(interface{ HandleMouse(Event) bool }); ok {
+			input.HandleMouse(e)
+		}
+		return true
+	}
+	return false
+}
+
+// rowHeight returns the number of rows taken by a field and its error.
+func (self *FormField) rowHeight() int {
+	if self.Error != nil {
+		return self.Height + 1
+	}
+	return self.Height
+}
+
+func (self *Form) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+
+	labelWidth := 0
+	for _, field := range self.Fields {
+		labelWidth = MaxInt(labelWidth, rw.StringWidth(field.Label))
+	}
+	labelWidth = MinInt(labelWidth, self.Inner.Dx()/3)
+
+	// scroll so that the focused field is in view
+	if self.focus < self.top {
+		self.top = self.focus
+	}
+	for self.top < self.focus {
+		height := 0
+		for _, field := range self.Fields[self.top : self.focus+1] {
+			height += field.rowHeight()
+		}
+		if height <= self.Inner.Dy() {
+			break
+		}
+		self.top++
+	}
+
+	y := self.Inner.Min.Y
+	for i := self.top; i < len(self.Fields); i++ {
+		field := self.Fields[i]
+		if y+field.Height > self.Inner.Max.Y {
+			break
+		}
+		labelStyle := self.LabelStyle
+		if i == self.focus {
+			labelStyle = self.FocusedLabelStyle
+		}
+		buf.SetString(TrimString(field.Label, labelWidth), labelStyle, image.Pt(self.Inner.Min.X, y+field.Height/2))
+
+		input := field.Input
+		input.SetRect(self.Inner.Min.X+labelWidth+1, y, self.Inner.Max.X, y+field.Height)
+		input.Lock()
+		input.Draw(buf)
+		input.Unlock()
+		y += field.Height
+
+		if field.Error != nil && y < self.Inner.Max.Y {
+			message := fmt.Sprintf("%c %v", ERROR, field.Error)
+			buf.SetString(TrimString(message, self.Inner.Dx()-labelWidth-2), self.ErrorStyle, image.Pt(self.Inner.Min.X+labelWidth+2, y))
+			y++
+		}
+	}
+}
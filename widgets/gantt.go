@@ -0,0 +1,326 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"math"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// GanttTask is a row of a Gantt, drawn as a bar from Start to End.
+type GanttTask struct {
+	Label string
+	Start time.Time
+	End   time.Time
+	// DependsOn holds the tasks which must end before this one starts, each drawn as an arrow from
+	// its end to the start of this one.
+	DependsOn []*GanttTask
+}
+
+// Gantt shows Tasks as bars on a time axis, a column for every Scale, with their labels on the left
+// and a line marking Today. <Up> and <Down> select a task, <Left> and <Right> scroll the time axis,
+// <Enter> scrolls to the selected task, and t scrolls to Today.
+type Gantt struct {
+	Block
+	Tasks []*GanttTask
+	// Scale is the time of a column.
+	Scale time.Duration
+	// TimeFormat is the time.Format layout of the labels of the time axis.
+	TimeFormat string
+	// LabelWidth is the width of the labels, fit to the longest one, up to a third of the width,
+	// when zero.
+	LabelWidth int
+	// Today is marked with a line, the current time when it is zero.
+	Today time.Time
+
+	BarColors       []Color
+	TextStyle       Style
+	SelectedStyle   Style
+	AxisStyle       Style
+	TodayStyle      Style
+	DependencyStyle Style
+
+	SelectedRow int
+	// OnSelect is called with the selected task after the selection changes.
+	OnSelect func(task *GanttTask)
+
+	// offset is the first column drawn, counted from the start of the first task, and topRow the
+	// first task drawn.
+	offset int
+	topRow int
+	// chart is the area of the bars drawn by the last Draw.
+	chart image.Rectangle
+}
+
+func NewGantt() *Gantt {
+	return &Gantt{
+		Block:           *NewBlock(),
+		Scale:           24 * time.Hour,
+		TimeFormat:      "Jan 2",
+		BarColors:       Theme.Gantt.Bars,
+		TextStyle:       Theme.Gantt.Text,
+		SelectedStyle:   Theme.Gantt.Selected,
+		AxisStyle:       Theme.Gantt.Axis,
+		TodayStyle:      Theme.Gantt.Today,
+		DependencyStyle: Theme.Gantt.Dependency,
+	}
+}
+
+// Selected returns the selected task, or nil if there are no Tasks.
+func (self *Gantt) Selected() *GanttTask {
+	if len(self.Tasks) == 0 {
+		return nil
+	}
+	return self.Tasks[MaxInt(MinInt(self.SelectedRow, len(self.Tasks)-1), 0)]
+}
+
+// Select selects the task at row and calls OnSelect.
+func (self *Gantt) Select(row int) {
+	row = MaxInt(MinInt(row, len(self.Tasks)-1), 0)
+	if row == self.SelectedRow || len(self.Tasks) == 0 {
+		return
+	}
+	self.SelectedRow = row
+	if self.OnSelect != nil {
+		self.OnSelect(self.Tasks[row])
+	}
+}
+
+// origin returns the time of the column 0, the start of the first task.
+func (self *Gantt) origin() time.Time {
+	origin := time.Time{}
+	for _, task := range self.Tasks {
+		if origin.IsZero() || task.Start.Before(origin) {
+			origin = task.Start
+		}
+	}
+	if self.Scale >= 24*time.Hour {
+		return truncateDay(origin)
+	}
+	return origin.Truncate(self.Scale)
+}
+
+// column returns the column of t, counted from the origin, rounding up when ceil is set.
+func (self *Gantt) column(origin, t time.Time, ceil bool) int {
+	columns := float64(t.Sub(origin)) / float64(self.Scale)
+	if ceil {
+		return int(math.Ceil(columns))
+	}
+	return int(math.Floor(columns))
+}
+
+func (self *Gantt) today() time.Time {
+	if self.Today.IsZero() {
+		return time.Now()
+	}
+	return self.Today
+}
+
+// ScrollLeft scrolls the time axis by columns, right for negative ones.
+func (self *Gantt) ScrollLeft(columns int) {
+	self.offset -= columns
+}
+
+// ScrollTo scrolls the time axis to start a couple of columns before t.
+func (self *Gantt) ScrollTo(t time.Time) {
+	if self.Scale > 0 {
+		self.offset = self.column(self.origin(), t, false) - 2
+	}
+}
+
+// HandleKey selects a task with <Up> and <Down>, <Home> and <End>, and scrolls the time axis with
+// <Left> and <Right>, to the selected task with <Enter>, and to Today with t.
+// It reports whether the key was used.
+func (self *Gantt) HandleKey(id string) bool {
+	switch id {
+	case "<Up>", "k":
+		self.Select(self.SelectedRow - 1)
+	case "<Down>", "j":
+		self.Select(self.SelectedRow + 1)
+	case "<Home>", "g":
+		self.Select(0)
+	case "<End>", "G":
+		self.Select(len(self.Tasks) - 1)
+	case "<Left>", "h":
+		self.ScrollLeft(1)
+	case "<Right>", "l":
+		self.ScrollLeft(-1)
+	case "<Enter>":
+		if task := self.Selected(); task != nil {
+			self.ScrollTo(task.Start)
+		}
+	case "t":
+		self.ScrollTo(self.today())
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse selects a clicked task, or the next or previous one with the mouse wheel.
+// It reports whether the event was used.
+func (self *Gantt) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Inner) {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.Select(self.SelectedRow - WheelSteps(e))
+	case "<MouseWheelDown>":
+		self.Select(self.SelectedRow + WheelSteps(e))
+	case "<MouseLeft>":
+		row := self.topRow + p.Y - self.chart.Min.Y
+		if e.Payload.(Mouse).Drag || p.Y < self.chart.Min.Y || row >= len(self.Tasks) {
+			return false
+		}
+		self.Select(row)
+	default:
+		return false
+	}
+	return true
+}
+
+// labelWidth returns the width of the labels, fit to the longest one when LabelWidth is zero.
+func (self *Gantt) labelWidth() int {
+	if self.LabelWidth > 0 {
+		return MinInt(self.LabelWidth, self.Inner.Dx()-1)
+	}
+	width := 0
+	for _, task := range self.Tasks {
+		width = MaxInt(width, rw.StringWidth(task.Label))
+	}
+	return MinInt(width, self.Inner.Dx()/3)
+}
+
+func (self *Gantt) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	self.chart = image.Rectangle{}
+	if len(self.Tasks) == 0 || self.Scale <= 0 || self.Inner.Dy() < 2 {
+		return
+	}
+
+	labelWidth := self.labelWidth()
+	self.chart = image.Rect(self.Inner.Min.X+labelWidth+1, self.Inner.Min.Y+1, self.Inner.Max.X, self.Inner.Max.Y)
+	if self.chart.Empty() {
+		return
+	}
+	origin := self.origin()
+	rows := make(map[*GanttTask]int, len(self.Tasks))
+	for i, task := range self.Tasks {
+		rows[task] = i
+	}
+
+	// scroll the selected task into view
+	self.SelectedRow = MaxInt(MinInt(self.SelectedRow, len(self.Tasks)-1), 0)
+	if self.SelectedRow < self.topRow {
+		self.topRow = self.SelectedRow
+	} else if self.SelectedRow >= self.topRow+self.chart.Dy() {
+		self.topRow = self.SelectedRow - self.chart.Dy() + 1
+	}
+	self.topRow = MaxInt(MinInt(self.topRow, len(self.Tasks)-self.chart.Dy()), 0)
+
+	// point returns the cell of a column of the task at row, and whether it is in the chart
+	point := func(column, row int) (image.Point, bool) {
+		p := image.Pt(self.chart.Min.X+column-self.offset, self.chart.Min.Y+row-self.topRow)
+		return p, p.In(self.chart)
+	}
+	set := func(r rune, style Style, column, row int) {
+		if p, ok := point(column, row); ok {
+			buf.SetCell(NewCell(r, style), p)
+		}
+	}
+
+	self.drawAxis(buf, origin)
+	buf.Fill(NewCell(VERTICAL_LINE, self.AxisStyle), image.Rect(self.chart.Min.X-1, self.Inner.Min.Y, self.chart.Min.X, self.Inner.Max.Y))
+
+	today := self.column(origin, self.today(), false)
+	for row := self.topRow; row < self.topRow+self.chart.Dy(); row++ {
+		set(VERTICAL_LINE, self.TodayStyle, today, row)
+	}
+
+	// draw the arrows from the end of a dependency to the start of the task below the bars, their
+	// vertical lines first so that the turns of arrows sharing one join it
+	for _, turns := range []bool{false, true} {
+		for row, task := range self.Tasks {
+			start := self.column(origin, task.Start, false)
+			for _, dependency := range task.DependsOn {
+				from, ok := rows[dependency]
+				if !ok || from == row {
+					continue
+				}
+				x := self.column(origin, dependency.End, true)
+				step, corner, turn := 1, TOP_RIGHT, BOTTOM_LEFT
+				if from > row {
+					step, corner, turn = -1, BOTTOM_RIGHT, TOP_LEFT
+				}
+				if !turns {
+					set(corner, self.DependencyStyle, x, from)
+					for y := from + step; y != row; y += step {
+						set(VERTICAL_LINE, self.DependencyStyle, x, y)
+					}
+					continue
+				}
+				if start <= x {
+					continue
+				}
+				if p, ok := point(x, row); ok && buf.GetCell(p) == NewCell(VERTICAL_LINE, self.DependencyStyle) {
+					turn = VERTICAL_RIGHT
+				}
+				set(turn, self.DependencyStyle, x, row)
+				for column := x + 1; column < start-1; column++ {
+					set(HORIZONTAL_LINE, self.DependencyStyle, column, row)
+				}
+				if start-1 > x {
+					set(RIGHT_ARROW, self.DependencyStyle, start-1, row)
+				}
+			}
+		}
+	}
+
+	for row := self.topRow; row < len(self.Tasks) && row-self.topRow < self.chart.Dy(); row++ {
+		task := self.Tasks[row]
+		y := self.chart.Min.Y + row - self.topRow
+		style := self.TextStyle
+		if row == self.SelectedRow {
+			style = self.SelectedStyle
+			buf.Fill(NewCell(' ', style), image.Rect(self.Inner.Min.X, y, self.chart.Min.X-1, y+1))
+		}
+		buf.SetString(TrimString(task.Label, labelWidth), style, image.Pt(self.Inner.Min.X, y))
+
+		start := self.column(origin, task.Start, false)
+		end := MaxInt(self.column(origin, task.End, true), start+1)
+		barStyle := NewStyle(SelectColor(self.BarColors, row))
+		for column := start; column < end; column++ {
+			set(BARS[len(BARS)-1], barStyle, column, row)
+		}
+	}
+}
+
+// drawAxis draws the time of every few columns on the first row, far enough apart to fit, leaving
+// out those cut by the left edge.
+func (self *Gantt) drawAxis(buf *Buffer, origin time.Time) {
+	step := rw.StringWidth(origin.Format(self.TimeFormat)) + 2
+	// keep the labels on the same columns while scrolling
+	first := self.offset - ((self.offset%step)+step)%step
+	for column := first; column < self.offset+self.chart.Dx(); column += step {
+		x := self.chart.Min.X + column - self.offset
+		if x < self.chart.Min.X {
+			continue
+		}
+		label := origin.Add(time.Duration(column) * self.Scale).Format(self.TimeFormat)
+		cells := RunesToStyledCells([]rune(label), self.AxisStyle)
+		for i, cell := range cells {
+			if x+i < self.chart.Max.X {
+				buf.SetCell(cell, image.Pt(x+i, self.Inner.Min.Y))
+			}
+		}
+	}
+}
@@ -17,6 +17,27 @@ type Gauge struct {
 	BarColor   Color
 	Label      string
 	LabelStyle Style
+	// Thresholds change the color of the bar with Percent. The bar takes the Color of the
+	// highest threshold Percent has reached, or BarColor below all of them.
+	Thresholds []GaugeThreshold
+	// Segments split the bar into labelled portions drawn one after another, like used and cached
+	// memory. Percent and Thresholds are ignored when there are Segments.
+	Segments []GaugeSegment
+	// ShowLegend draws the Label, Percent, and Color of each segment on the last line of the Gauge.
+	ShowLegend bool
+}
+
+// GaugeThreshold is a Percent from which a Gauge bar is drawn in Color.
+type GaugeThreshold struct {
+	Percent int
+	Color   Color
+}
+
+// GaugeSegment is one portion of a segmented Gauge.
+type GaugeSegment struct {
+	Label   string
+	Percent int
+	Color   Color
 }
 
 func NewGauge() *Gauge {
@@ -30,28 +51,115 @@ func NewGauge() *Gauge {
 func (self *Gauge) Draw(buf *Buffer) {
 	self.Block.Draw(buf)
 
+	percent := self.Percent
+	if len(self.Segments) > 0 {
+		percent = 0
+		for _, segment := range self.Segments {
+			percent += segment.Percent
+		}
+	}
 	label := self.Label
 	if label == "" {
-		label = fmt.Sprintf("%d%%", self.Percent)
+		label = fmt.Sprintf("%d%%", percent)
+	}
+
+	bar := self.Inner
+	if self.ShowLegend && len(self.Segments) > 0 && bar.Dy() > 1 {
+		bar.Max.Y--
+		labels := make([]string, len(self.Segments))
+		colors := make([]Color, len(self.Segments))
+		for i, segment := range self.Segments {
+			labels[i] = fmt.Sprintf("%s %d%%", segment.Label, segment.Percent)
+			colors[i] = segment.Color
+		}
+		drawLegend(buf, image.Pt(bar.Min.X, bar.Max.Y), bar.Dx(), labels, colors, self.LabelStyle)
 	}
 
 	// plot bar
-	barWidth := int((float64(self.Percent) / 100) * float64(self.Inner.Dx()))
-	buf.Fill(
-		NewCell(' ', NewStyle(ColorClear, self.BarColor)),
-		image.Rect(self.Inner.Min.X, self.Inner.Min.Y, self.Inner.Min.X+barWidth, self.Inner.Max.Y),
-	)
+	columns := self.barColumns(bar.Dx())
+	for i, column := range columns {
+		cell := NewCell(' ', NewStyle(ColorClear, column.color))
+		if column.eighths < 8 {
+			cell = NewCell(HORIZONTAL_BARS[column.eighths], NewStyle(column.color, column.rest))
+		}
+		buf.Fill(cell, image.Rect(bar.Min.X+i, bar.Min.Y, bar.Min.X+i+1, bar.Max.Y))
+	}
 
 	// plot label
-	labelXCoordinate := self.Inner.Min.X + (self.Inner.Dx() / 2) - int(float64(len(label))/2)
-	labelYCoordinate := self.Inner.Min.Y + ((self.Inner.Dy() - 1) / 2)
-	if labelYCoordinate < self.Inner.Max.Y {
+	labelXCoordinate := bar.Min.X + (bar.Dx() / 2) - int(float64(len(label))/2)
+	labelYCoordinate := bar.Min.Y + ((bar.Dy() - 1) / 2)
+	if labelYCoordinate < bar.Max.Y {
 		for i, char := range label {
 			style := self.LabelStyle
-			if labelXCoordinate+i+1 <= self.Inner.Min.X+barWidth {
-				style = NewStyle(self.BarColor, ColorClear, ModifierReverse)
+			if column := labelXCoordinate + i - bar.Min.X; column >= 0 && column < len(columns) {
+				if color := columns[column].labelColor(); color != ColorClear {
+					style = NewStyle(color, ColorClear, ModifierReverse)
+				}
 			}
 			buf.SetCell(NewCell(char, style), image.Pt(labelXCoordinate+i, labelYCoordinate))
 		}
 	}
 }
+
+// gaugeColumn is a filled cell of a Gauge bar. The last filled cell of a bar or segment can be
+// partly filled, in eighths of a cell, with the rest taken by the next segment or left empty.
+type gaugeColumn struct {
+	color   Color
+	eighths int
+	rest    Color
+}
+
+// labelColor returns the color of the bar behind a label drawn over the column.
+func (self gaugeColumn) labelColor() Color {
+	if self.eighths >= 4 {
+		return self.color
+	}
+	return self.rest
+}
+
+// barColumns returns the filled columns of a bar width cells wide.
+func (self *Gauge) barColumns(width int) []gaugeColumn {
+	columns := []gaugeColumn{}
+	filled := 0
+	fill := func(percent int, color Color) {
+		end := MinInt(int((float64(percent)/100)*float64(width*8)), width*8)
+		for filled < end {
+			i := filled / 8
+			if i == len(columns) {
+				columns = append(columns, gaugeColumn{color, 0, ColorClear})
+			}
+			column := &columns[i]
+			if column.eighths > 0 && column.color != color {
+				// the rest of a cell shared with the previous segment is drawn as the background
+				column.rest = color
+				filled = (i + 1) * 8
+				continue
+			}
+			eighths := MinInt(8-column.eighths, end-filled)
+			column.eighths += eighths
+			filled += eighths
+		}
+	}
+	if len(self.Segments) == 0 {
+		fill(self.Percent, self.barColor())
+		return columns
+	}
+	// segments are filled to their running totals so rounding doesn't add up across them
+	total := 0
+	for _, segment := range self.Segments {
+		total += segment.Percent
+		fill(total, segment.Color)
+	}
+	return columns
+}
+
+// barColor returns the color of the threshold reached by Percent.
+func (self *Gauge) barColor() Color {
+	color, reached := self.BarColor, -1
+	for _, threshold := range self.Thresholds {
+		if self.Percent >= threshold.Percent && threshold.Percent > reached {
+			color, reached = threshold.Color, threshold.Percent
+		}
+	}
+	return color
+}
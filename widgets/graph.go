@@ -0,0 +1,506 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// GraphNode is a node of a Graph, drawn as its Label, or its ID when the Label is empty.
+type GraphNode struct {
+	ID    string
+	Label string
+}
+
+func (self *GraphNode) label() string {
+	if self.Label == "" {
+		return self.ID
+	}
+	return self.Label
+}
+
+// GraphEdge links the nodes of a Graph with the IDs From and To.
+type GraphEdge struct {
+	From string
+	To   string
+}
+
+type GraphLayout uint
+
+const (
+	// GraphLayered places the nodes in rows, every edge going down to a later row, like a
+	// dependency graph.
+	GraphLayered GraphLayout = iota
+	// GraphForce places linked nodes near each other and the others apart, like a network.
+	GraphForce
+)
+
+// Graph draws Nodes linked by Edges, placed by the Layout, with braille lines between their labels.
+// The arrow keys select the nearest node in their direction and <Tab> the next one. h, j, k, and l
+// or dragging the mouse pan the view, + and - or the mouse wheel zoom it, <Enter> centers it on the
+// selected node, and 0 resets it. The layout is computed again when the Nodes or Edges are added or
+// removed, or by Relayout after they are otherwise changed.
+type Graph struct {
+	Block
+	Nodes  []*GraphNode
+	Edges  []GraphEdge
+	Layout GraphLayout
+
+	EdgeColor Color
+	// SelectedEdgeColor is the color of the edges of the selected node.
+	SelectedEdgeColor Color
+	NodeStyle         Style
+	SelectedStyle     Style
+
+	// Zoom scales the view, 1 fitting the whole graph in the widget, and Pan moves it by a number
+	// of cells.
+	Zoom float64
+	Pan  image.Point
+
+	SelectedNode int
+	// OnSelect is called with the selected node after the selection changes.
+	OnSelect func(node *GraphNode)
+
+	// positions holds the place of each node computed by the layout, from 0 to 1 on both axes.
+	positions []graphPoint
+	// laidOut records the counts of nodes and edges and the layout the positions were computed for.
+	laidOut [3]int
+	// cells holds the cell of each node for the view last drawn.
+	cells []image.Point
+	// dragging is where the mouse was last while dragging the view.
+	dragging *image.Point
+}
+
+type graphPoint struct {
+	x, y float64
+}
+
+func NewGraph() *Graph {
+	return &Graph{
+		Block:             *NewBlock(),
+		EdgeColor:         Theme.Graph.Edge,
+		SelectedEdgeColor: Theme.Graph.SelectedEdge,
+		NodeStyle:         Theme.Graph.Node,
+		SelectedStyle:     Theme.Graph.Selected,
+		Zoom:              1,
+	}
+}
+
+// AddNode adds a node and returns it.
+func (self *Graph) AddNode(id, label string) *GraphNode {
+	node := &GraphNode{ID: id, Label: label}
+	self.Nodes = append(self.Nodes, node)
+	return node
+}
+
+// AddEdge links the nodes with the IDs from and to.
+func (self *Graph) AddEdge(from, to string) {
+	self.Edges = append(self.Edges, GraphEdge{from, to})
+}
+
+// Selected returns the selected node, or nil if there are no Nodes.
+func (self *Graph) Selected() *GraphNode {
+	if len(self.Nodes) == 0 {
+		return nil
+	}
+	return self.Nodes[MaxInt(MinInt(self.SelectedNode, len(self.Nodes)-1), 0)]
+}
+
+// Select selects the node at index and calls OnSelect.
+func (self *Graph) Select(index int) {
+	if index < 0 || index >= len(self.Nodes) || index == self.SelectedNode {
+		return
+	}
+	self.SelectedNode = index
+	if self.OnSelect != nil {
+		self.OnSelect(self.Nodes[index])
+	}
+}
+
+// Relayout computes the positions of the nodes again.
+func (self *Graph) Relayout() {
+	self.laidOut = [3]int{}
+}
+
+// ResetView fits the whole graph in the widget.
+func (self *Graph) ResetView() {
+	self.Zoom, self.Pan = 1, image.Point{}
+}
+
+// ZoomBy multiplies the Zoom by factor, between 1/4 and 16, keeping the center of the view.
+func (self *Graph) ZoomBy(factor float64) {
+	zoom := math.Max(math.Min(self.Zoom*factor, 16), 0.25)
+	self.Pan.X = int(math.Round(float64(self.Pan.X) * zoom / self.Zoom))
+	self.Pan.Y = int(math.Round(float64(self.Pan.Y) * zoom / self.Zoom))
+	self.Zoom = zoom
+}
+
+// CenterSelected pans the view to put the selected node in its center.
+func (self *Graph) CenterSelected() {
+	if len(self.Nodes) == 0 {
+		return
+	}
+	self.layout()
+	self.place()
+	center := self.Inner.Min.Add(self.Inner.Max).Div(2)
+	self.Pan = self.Pan.Add(center.Sub(self.cells[MinInt(self.SelectedNode, len(self.cells)-1)]))
+}
+
+// place sets the cell of every node from its position and the view.
+func (self *Graph) place() {
+	// keep room for half the widest label on the sides and a row above and below
+	margin := 0
+	for _, node := range self.Nodes {
+		margin = MaxInt(margin, (rw.StringWidth(node.label())+1)/2)
+	}
+	margin = MinInt(margin, self.Inner.Dx()/4)
+	width := float64(self.Inner.Dx()-1-2*margin) * self.Zoom
+	height := float64(self.Inner.Dy()-3) * self.Zoom
+	center := self.Inner.Min.Add(self.Inner.Max).Div(2).Add(self.Pan)
+	self.cells = self.cells[:0]
+	for _, p := range self.positions {
+		self.cells = append(self.cells, image.Pt(
+			center.X+int(math.Round((p.x-0.5)*width)),
+			center.Y+int(math.Round((p.y-0.5)*height)),
+		))
+	}
+}
+
+// edgeIndexes returns the indexes of the nodes linked by each edge, leaving out edges to unknown
+// nodes.
+func (self *Graph) edgeIndexes() [][2]int {
+	indexes := make(map[string]int, len(self.Nodes))
+	for i, node := range self.Nodes {
+		indexes[node.ID] = i
+	}
+	edges := [][2]int{}
+	for _, edge := range self.Edges {
+		from, ok := indexes[edge.From]
+		to, ok2 := indexes[edge.To]
+		if ok && ok2 {
+			edges = append(edges, [2]int{from, to})
+		}
+	}
+	return edges
+}
+
+// layout computes the positions of the nodes if they changed since they were last computed.
+func (self *Graph) layout() {
+	laidOut := [3]int{len(self.Nodes), len(self.Edges), int(self.Layout) + 1}
+	if laidOut == self.laidOut {
+		return
+	}
+	self.laidOut = laidOut
+	edges := self.edgeIndexes()
+	switch self.Layout {
+	case GraphForce:
+		self.positions = forceLayout(len(self.Nodes), edges)
+	default:
+		self.positions = layeredLayout(len(self.Nodes), edges)
+	}
+}
+
+// layeredLayout puts every node one row below the lowest of the nodes linked to it, then orders
+// the rows to bring linked nodes closer, sweeping down and up a few times.
+func layeredLayout(count int, edges [][2]int) []graphPoint {
+	layers := make([]int, count)
+	// a cycle stops pushing nodes down once they reach the last possible row
+	for changed, pass := true, 0; changed && pass < count; pass++ {
+		changed = false
+		for _, edge := range edges {
+			if edge[0] != edge[1] && layers[edge[1]] < layers[edge[0]]+1 && layers[edge[0]]+1 < count {
+				layers[edge[1]] = layers[edge[0]] + 1
+				changed = true
+			}
+		}
+	}
+	rows := [][]int{}
+	for i, layer := range layers {
+		for len(rows) <= layer {
+			rows = append(rows, nil)
+		}
+		rows[layer] = append(rows[layer], i)
+	}
+
+	order := make([]float64, count)
+	reorder := func(row []int, linked func(edge [2]int) (int, int)) {
+		for _, i := range row {
+			sum, links := 0.0, 0
+			for _, edge := range edges {
+				if node, other := linked(edge); node == i && layers[other] != layers[i] {
+					sum, links = sum+order[other], links+1
+				}
+			}
+			if links > 0 {
+				order[i] = sum / float64(links)
+			}
+		}
+		sort.SliceStable(row, func(a, b int) bool {
+			return order[row[a]] < order[row[b]]
+		})
+		for position, i := range row {
+			order[i] = float64(position)
+		}
+	}
+	for _, row := range rows {
+		for position, i := range row {
+			order[i] = float64(position)
+		}
+	}
+	for sweep := 0; sweep < 4; sweep++ {
+		for _, row := range rows[1:] {
+			reorder(row, func(edge [2]int) (int, int) { return edge[1], edge[0] })
+		}
+		for r := len(rows) - 2; r >= 0; r-- {
+			reorder(rows[r], func(edge [2]int) (int, int) { return edge[0], edge[1] })
+		}
+	}
+
+	positions := make([]graphPoint, count)
+	for r, row := range rows {
+		for position, i := range row {
+			positions[i] = graphPoint{
+				(float64(position) + 0.5) / float64(len(row)),
+				(float64(r) + 0.5) / float64(len(rows)),
+			}
+		}
+	}
+	return positions
+}
+
+// forceLayout starts with the nodes on a circle and moves them as if they pushed each other away
+// while the edges pulled them together, cooling down over a fixed number of steps.
+func forceLayout(count int, edges [][2]int) []graphPoint {
+	positions := make([]graphPoint, count)
+	if count == 0 {
+		return positions
+	}
+	for i := range positions {
+		angle := 2 * math.Pi * float64(i) / float64(count)
+		positions[i] = graphPoint{0.5 + 0.4*math.Cos(angle), 0.5 + 0.4*math.Sin(angle)}
+	}
+	k := math.Sqrt(1 / float64(count))
+	const steps = 200
+	moves := make([]graphPoint, count)
+	for step := 0; step < steps; step++ {
+		for i := range moves {
+			moves[i] = graphPoint{}
+		}
+		push := func(i, j int, force func(distance float64) float64) {
+			dx, dy := positions[i].x-positions[j].x, positions[i].y-positions[j].y
+			distance := math.Max(math.Hypot(dx, dy), 0.001)
+			f := force(distance) / distance
+			moves[i].x, moves[i].y = moves[i].x+dx*f, moves[i].y+dy*f
+			moves[j].x, moves[j].y = moves[j].x-dx*f, moves[j].y-dy*f
+		}
+		for i := 0; i < count; i++ {
+			for j := i + 1; j < count; j++ {
+				push(i, j, func(d float64) float64 { return k * k / d })
+			}
+		}
+		for _, edge := range edges {
+			if edge[0] != edge[1] {
+				push(edge[0], edge[1], func(d float64) float64 { return -d * d / k })
+			}
+		}
+		temperature := 0.1 * (1 - float64(step)/steps)
+		for i, move := range moves {
+			length := math.Max(math.Hypot(move.x, move.y), 0.001)
+			scale := math.Min(length, temperature) / length
+			positions[i].x += move.x * scale
+			positions[i].y += move.y * scale
+		}
+	}
+
+	// stretch the nodes over the whole area
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range positions {
+		minX, minY = math.Min(minX, p.x), math.Min(minY, p.y)
+		maxX, maxY = math.Max(maxX, p.x), math.Max(maxY, p.y)
+	}
+	for i, p := range positions {
+		positions[i] = graphPoint{0.5, 0.5}
+		if maxX > minX {
+			positions[i].x = (p.x - minX) / (maxX - minX)
+		}
+		if maxY > minY {
+			positions[i].y = (p.y - minY) / (maxY - minY)
+		}
+	}
+	return positions
+}
+
+// move selects the nearest node in the direction (dx, dy) from the selected one.
+func (self *Graph) move(dx, dy int) {
+	if self.SelectedNode >= len(self.cells) {
+		return
+	}
+	from := self.cells[self.SelectedNode]
+	best, bestDistance := -1, 0
+	for i, cell := range self.cells {
+		d := cell.Sub(from)
+		// the node must be more in the direction than across it
+		along, across := d.X*dx+d.Y*dy, AbsInt(d.X*dy)+AbsInt(d.Y*dx)
+		if dy != 0 {
+			// cells are about twice as tall as they are wide
+			along *= 2
+		} else {
+			across *= 2
+		}
+		if i == self.SelectedNode || along <= 0 || across > 2*along {
+			continue
+		}
+		if distance := along + across; best < 0 || distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	if best >= 0 {
+		self.Select(best)
+	}
+}
+
+// HandleKey selects a node with the arrow keys and <Tab>, pans the view with h, j, k, and l, zooms
+// it with + and -, centers it on the selected node with <Enter>, and resets it with 0.
+// It reports whether the key was used.
+func (self *Graph) HandleKey(id string) bool {
+	if len(self.Nodes) == 0 {
+		return false
+	}
+	switch id {
+	case "<Left>":
+		self.move(-1, 0)
+	case "<Right>":
+		self.move(1, 0)
+	case "<Up>":
+		self.move(0, -1)
+	case "<Down>":
+		self.move(0, 1)
+	case "<Tab>":
+		self.Select((self.SelectedNode + 1) % len(self.Nodes))
+	case "h":
+		self.Pan.X += 2
+	case "l":
+		self.Pan.X -= 2
+	case "k":
+		self.Pan.Y++
+	case "j":
+		self.Pan.Y--
+	case "+", "=":
+		self.ZoomBy(1.25)
+	case "-":
+		self.ZoomBy(0.8)
+	case "0":
+		self.ResetView()
+	case "<Enter>":
+		self.CenterSelected()
+	default:
+		return false
+	}
+	return true
+}
+
+// nodeAt returns the index of the node whose label is drawn over p, or -1 if there is none.
+func (self *Graph) nodeAt(p image.Point) int {
+	for i := len(self.cells) - 1; i >= 0; i-- {
+		width := rw.StringWidth(self.Nodes[i].label())
+		x := self.cells[i].X - width/2
+		if p.Y == self.cells[i].Y && p.X >= x && p.X < x+MaxInt(width, 1) {
+			return i
+		}
+	}
+	return -1
+}
+
+// HandleMouse selects a clicked node, pans the view while the mouse is dragged elsewhere, and
+// zooms it with the mouse wheel. It reports whether the event was used.
+func (self *Graph) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok {
+		return false
+	}
+	switch e.ID {
+	case "<MouseLeft>":
+		if self.dragging != nil {
+			self.Pan = self.Pan.Add(p.Sub(*self.dragging))
+			self.dragging = &p
+			return true
+		}
+		if !p.In(self.Inner) {
+			return false
+		}
+		if i := self.nodeAt(p); i >= 0 && !e.Payload.(Mouse).Drag {
+			self.Select(i)
+			return true
+		}
+		self.dragging = &p
+	case "<MouseRelease>":
+		if self.dragging == nil {
+			return false
+		}
+		self.dragging = nil
+	case "<MouseWheelUp>", "<MouseWheelDown>":
+		if !p.In(self.Inner) {
+			return false
+		}
+		steps := float64(WheelSteps(e))
+		if e.ID == "<MouseWheelUp>" {
+			self.ZoomBy(math.Pow(1.25, steps))
+		} else {
+			self.ZoomBy(math.Pow(0.8, steps))
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func (self *Graph) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	self.cells = self.cells[:0]
+	if len(self.Nodes) == 0 || self.Inner.Empty() {
+		return
+	}
+	self.layout()
+	self.SelectedNode = MaxInt(MinInt(self.SelectedNode, len(self.Nodes)-1), 0)
+	self.place()
+
+	canvas := NewCanvas()
+	canvas.Rectangle = self.Inner
+	res := canvas.Mode.Resolution()
+	pixel := func(cell image.Point) image.Point {
+		return image.Pt(cell.X*res.X+res.X/2, cell.Y*res.Y+res.Y/2)
+	}
+	// draw the edges of the selected node last, over the others
+	edges := self.edgeIndexes()
+	sort.SliceStable(edges, func(a, b int) bool {
+		return !self.touches(edges[a]) && self.touches(edges[b])
+	})
+	for _, edge := range edges {
+		color := self.EdgeColor
+		if self.touches(edge) {
+			color = self.SelectedEdgeColor
+		}
+		canvas.SetLine(pixel(self.cells[edge[0]]), pixel(self.cells[edge[1]]), color)
+	}
+	for i, node := range self.Nodes {
+		style := self.NodeStyle
+		if i == self.SelectedNode {
+			style = self.SelectedStyle
+		}
+		canvas.SetAlignedText(pixel(self.cells[i]), node.label(), style, AlignCenter)
+	}
+	canvas.Draw(buf)
+}
+
+// touches reports whether an edge links the selected node.
+func (self *Graph) touches(edge [2]int) bool {
+	return edge[0] == self.SelectedNode || edge[1] == self.SelectedNode
+}
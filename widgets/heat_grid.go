@@ -0,0 +1,327 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// HeatGrid shows a matrix of values as colored cells under ColumnLabels and after RowLabels, such
+// as the status of services by region or a confusion matrix. Values are drawn with the Colors
+// blended from MinVal to MaxVal, and a legend of the scale is drawn below the cells, after the
+// labels and value of the cell under the cursor while Focused. The arrow keys move the cursor,
+// scrolling the cells when they don't all fit.
+type HeatGrid struct {
+	Block
+	RowLabels    []string
+	ColumnLabels []string
+	// Values holds the values of each row, from the first column. Missing and NaN values are drawn
+	// as empty cells.
+	Values [][]float64
+	// MinVal and MaxVal are the values drawn with the first and last of the Colors. When both are
+	// zero, they're taken from the Values.
+	MinVal float64
+	MaxVal float64
+	Colors []Color
+	// CellWidth is the width of the cells, or, when zero, that of the widest column label or value.
+	CellWidth int
+	// ShowValues draws the values in the cells, formatted with NumFormatter.
+	ShowValues   bool
+	NumFormatter func(float64) string
+
+	TextStyle Style
+	// CursorStyle is the style of the labels of the row and column of the cursor, whose cell is
+	// drawn between brackets.
+	CursorStyle Style
+
+	// CursorRow and CursorColumn are the cell of the cursor, drawn and moved while Focused.
+	CursorRow    int
+	CursorColumn int
+	Focused      bool
+
+	// OnCursorChange is called with the cell under the cursor and its value after it moves.
+	OnCursorChange func(row, column int, value float64)
+
+	// grid is the area of the cells drawn by the last Draw, the first of which is at rowOffset and
+	// columnOffset, cellWidth wide.
+	grid         image.Rectangle
+	rowOffset    int
+	columnOffset int
+	cellWidth    int
+}
+
+func NewHeatGrid() *HeatGrid {
+	return &HeatGrid{
+		Block:        *NewBlock(),
+		Colors:       Theme.HeatGrid.Colors,
+		NumFormatter: func(n float64) string { return fmt.Sprintf("%.3g", n) },
+		TextStyle:    Theme.HeatGrid.Text,
+		CursorStyle:  Theme.HeatGrid.Cursor,
+	}
+}
+
+// Size returns the number of rows and columns, the larger of the labels and Values.
+func (self *HeatGrid) Size() (int, int) {
+	columns := len(self.ColumnLabels)
+	for _, row := range self.Values {
+		columns = MaxInt(columns, len(row))
+	}
+	return MaxInt(len(self.RowLabels), len(self.Values)), columns
+}
+
+// Value returns the value of a cell, and false if it has none.
+func (self *HeatGrid) Value(row, column int) (float64, bool) {
+	if row < 0 || row >= len(self.Values) || column < 0 || column >= len(self.Values[row]) {
+		return 0, false
+	}
+	value := self.Values[row][column]
+	return value, !math.IsNaN(value)
+}
+
+// heatGridLabel returns the label at i of labels, or i+1 when it has none.
+func heatGridLabel(labels []string, i int) string {
+	if i < len(labels) {
+		return labels[i]
+	}
+	return fmt.Sprint(i + 1)
+}
+
+// scale returns MinVal and MaxVal, or the smallest and largest of the Values when both are zero.
+func (self *HeatGrid) scale() (float64, float64) {
+	if self.MinVal != 0 || self.MaxVal != 0 {
+		return self.MinVal, self.MaxVal
+	}
+	minVal, maxVal := math.Inf(1), math.Inf(-1)
+	for _, row := range self.Values {
+		for _, value := range row {
+			if !math.IsNaN(value) {
+				minVal, maxVal = math.Min(minVal, value), math.Max(maxVal, value)
+			}
+		}
+	}
+	if minVal > maxVal {
+		return 0, 0
+	}
+	return minVal, maxVal
+}
+
+// color returns the color of a value on the scale from minVal to maxVal.
+func (self *HeatGrid) color(value, minVal, maxVal float64) Color {
+	if maxVal <= minVal {
+		return self.Colors[len(self.Colors)-1]
+	}
+	return gradientColor(self.Colors, (value-minVal)/(maxVal-minVal))
+}
+
+// SetCursor moves the cursor to a cell, within the grid.
+func (self *HeatGrid) SetCursor(row, column int) {
+	rows, columns := self.Size()
+	row, column = MaxInt(MinInt(row, rows-1), 0), MaxInt(MinInt(column, columns-1), 0)
+	if row == self.CursorRow && column == self.CursorColumn {
+		return
+	}
+	self.CursorRow, self.CursorColumn = row, column
+	if self.OnCursorChange != nil {
+		value, _ := self.Value(row, column)
+		self.OnCursorChange(row, column, value)
+	}
+}
+
+// HandleKey moves the cursor a cell with the arrow keys, to the first or last column with <Home>
+// and <End>, and a page of rows with <PageUp> and <PageDown>, while the HeatGrid is Focused.
+// It reports whether the key was used.
+func (self *HeatGrid) HandleKey(id string) bool {
+	if rows, columns := self.Size(); !self.Focused || rows == 0 || columns == 0 {
+		return false
+	}
+	page := MaxInt(self.grid.Dy(), 1)
+	switch id {
+	case "<Left>", "h":
+		self.SetCursor(self.CursorRow, self.CursorColumn-1)
+	case "<Right>", "l":
+		self.SetCursor(self.CursorRow, self.CursorColumn+1)
+	case "<Up>", "k":
+		self.SetCursor(self.CursorRow-1, self.CursorColumn)
+	case "<Down>", "j":
+		self.SetCursor(self.CursorRow+1, self.CursorColumn)
+	case "<Home>":
+		self.SetCursor(self.CursorRow, 0)
+	case "<End>":
+		_, columns := self.Size()
+		self.SetCursor(self.CursorRow, columns-1)
+	case "<PageUp>":
+		self.SetCursor(self.CursorRow-page, self.CursorColumn)
+	case "<PageDown>":
+		self.SetCursor(self.CursorRow+page, self.CursorColumn)
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse moves the cursor to a clicked cell, and reports whether the event was used.
+func (self *HeatGrid) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag || !p.In(self.grid) {
+		return false
+	}
+	if (p.X-self.grid.Min.X)%(self.cellWidth+1) == self.cellWidth {
+		return true
+	}
+	self.SetCursor(self.rowOffset+p.Y-self.grid.Min.Y, self.columnOffset+(p.X-self.grid.Min.X)/(self.cellWidth+1))
+	return true
+}
+
+// scrollOffset returns the first of a number of items shown from offset, moved to show cursor.
+func scrollOffset(offset, cursor, shown, total int) int {
+	if cursor < offset {
+		offset = cursor
+	} else if cursor >= offset+shown {
+		offset = cursor - shown + 1
+	}
+	return MaxInt(MinInt(offset, total-shown), 0)
+}
+
+func (self *HeatGrid) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	self.grid = image.Rectangle{}
+	rows, columns := self.Size()
+	if rows == 0 || columns == 0 || len(self.Colors) == 0 || self.Inner.Dy() < 2 {
+		return
+	}
+	minVal, maxVal := self.scale()
+
+	labelWidth := 0
+	for row := 0; row < rows; row++ {
+		labelWidth = MaxInt(labelWidth, rw.StringWidth(heatGridLabel(self.RowLabels, row)))
+	}
+	labelWidth = MinInt(labelWidth, self.Inner.Dx()/3)
+	self.cellWidth = self.CellWidth
+	if self.cellWidth <= 0 {
+		self.cellWidth = 3
+		for column := 0; column < columns; column++ {
+			self.cellWidth = MaxInt(self.cellWidth, rw.StringWidth(heatGridLabel(self.ColumnLabels, column)))
+		}
+		if self.ShowValues {
+			for _, row := range self.Values {
+				for _, value := range row {
+					if !math.IsNaN(value) {
+						self.cellWidth = MaxInt(self.cellWidth, rw.StringWidth(self.NumFormatter(value))+2)
+					}
+				}
+			}
+		}
+	}
+
+	// the column labels on the first row, the cells under them, and the legend on the last row
+	// when there is room
+	shownRows := self.Inner.Dy() - 1
+	if shownRows > 1 {
+		shownRows--
+	}
+	shownColumns := (self.Inner.Dx() - labelWidth) / (self.cellWidth + 1)
+	if shownColumns < 1 {
+		return
+	}
+	self.CursorRow, self.CursorColumn = MaxInt(MinInt(self.CursorRow, rows-1), 0), MaxInt(MinInt(self.CursorColumn, columns-1), 0)
+	self.rowOffset = scrollOffset(self.rowOffset, self.CursorRow, shownRows, rows)
+	self.columnOffset = scrollOffset(self.columnOffset, self.CursorColumn, shownColumns, columns)
+	shownRows, shownColumns = MinInt(shownRows, rows-self.rowOffset), MinInt(shownColumns, columns-self.columnOffset)
+
+	self.grid = image.Rect(
+		self.Inner.Min.X+labelWidth+1, self.Inner.Min.Y+1,
+		self.Inner.Min.X+labelWidth+1+shownColumns*(self.cellWidth+1)-1, self.Inner.Min.Y+1+shownRows,
+	)
+
+	labelStyle := func(cursor bool) Style {
+		if cursor && self.Focused {
+			return self.CursorStyle
+		}
+		return self.TextStyle
+	}
+	for c := 0; c < shownColumns; c++ {
+		column := self.columnOffset + c
+		label := TrimString(heatGridLabel(self.ColumnLabels, column), self.cellWidth)
+		x := self.grid.Min.X + c*(self.cellWidth+1) + (self.cellWidth-rw.StringWidth(label))/2
+		buf.SetString(label, labelStyle(column == self.CursorColumn), image.Pt(x, self.Inner.Min.Y))
+	}
+	for r := 0; r < shownRows; r++ {
+		row := self.rowOffset + r
+		y := self.grid.Min.Y + r
+		label := TrimString(heatGridLabel(self.RowLabels, row), labelWidth)
+		buf.SetString(label, labelStyle(row == self.CursorRow), image.Pt(self.Inner.Min.X, y))
+
+		for c := 0; c < shownColumns; c++ {
+			column := self.columnOffset + c
+			value, ok := self.Value(row, column)
+			if !ok {
+				continue
+			}
+			color := self.color(value, minVal, maxVal)
+			style := NewStyle(contrastColor(color), color)
+			cells := make([]Cell, self.cellWidth)
+			for i := range cells {
+				cells[i] = NewCell(' ', style)
+			}
+			if self.ShowValues {
+				text := TrimString(self.NumFormatter(value), self.cellWidth)
+				x := (self.cellWidth - rw.StringWidth(text)) / 2
+				for i, cell := range RunesToStyledCells([]rune(text), style) {
+					if x+i < len(cells) {
+						cells[x+i] = cell
+					}
+				}
+			}
+			if self.Focused && row == self.CursorRow && column == self.CursorColumn {
+				bracketStyle := NewStyle(style.Fg, style.Bg, ModifierBold)
+				cells[0], cells[len(cells)-1] = NewCell('[', bracketStyle), NewCell(']', bracketStyle)
+			}
+			x := self.grid.Min.X + c*(self.cellWidth+1)
+			for i, cell := range cells {
+				buf.SetCell(cell, image.Pt(x+i, y))
+			}
+		}
+	}
+
+	if self.grid.Max.Y < self.Inner.Max.Y {
+		self.drawLegend(buf, minVal, maxVal, self.grid.Max.Y)
+	}
+}
+
+// drawLegend draws the labels and value of the cell under the cursor while Focused on the left of
+// row, and the scale of the Colors between MinVal and MaxVal on its right.
+func (self *HeatGrid) drawLegend(buf *Buffer, minVal, maxVal float64, y int) {
+	cells := RunesToStyledCells([]rune(self.NumFormatter(minVal)+" "), self.TextStyle)
+	const steps = 10
+	for i := 0; i < steps; i++ {
+		color := self.color(minVal+(maxVal-minVal)*float64(i)/(steps-1), minVal, maxVal)
+		cells = append(cells, NewCell(' ', NewStyle(ColorClear, color)))
+	}
+	cells = append(cells, RunesToStyledCells([]rune(" "+self.NumFormatter(maxVal)), self.TextStyle)...)
+	x := MaxInt(self.Inner.Max.X-len(cells), self.Inner.Min.X)
+	if self.Focused {
+		readout := fmt.Sprintf("%s / %s: ",
+			heatGridLabel(self.RowLabels, self.CursorRow), heatGridLabel(self.ColumnLabels, self.CursorColumn))
+		if value, ok := self.Value(self.CursorRow, self.CursorColumn); ok {
+			readout += self.NumFormatter(value)
+		} else {
+			readout += "-"
+		}
+		if x-self.Inner.Min.X < rw.StringWidth(readout)+2 {
+			// keep the readout rather than the legend when both don't fit
+			x = self.Inner.Max.X
+		}
+		buf.SetString(TrimString(readout, self.Inner.Dx()), self.TextStyle, image.Pt(self.Inner.Min.X, y))
+	}
+	for i, cell := range TrimCells(cells, self.Inner.Max.X-x) {
+		buf.SetCell(cell, image.Pt(x+i, y))
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// Help is a modal cheat-sheet of the active bindings of a Keymap, grouped by scope. It is shown
+// as an overlay with ShowOverlay, and ToggleKey opens and closes it. Bindings without keys are
+// only listed by the CommandPalette.
+type Help struct {
+	Block
+	Keymap *Keymap
+	// ToggleKey is the keyboard event ID opening and closing the Help.
+	ToggleKey string
+
+	ScopeStyle Style
+	KeyStyle   Style
+	TextStyle  Style
+
+	top int
+}
+
+func NewHelp() *Help {
+	self := &Help{
+		Block:      *NewBlock(),
+		Keymap:     DefaultKeymap,
+		ToggleKey:  "?",
+		ScopeStyle: Theme.Help.Scope,
+		KeyStyle:   Theme.Help.Key,
+		TextStyle:  Theme.Help.Text,
+	}
+	self.Title = "Keys"
+	return self
+}
+
+// IsOpen reports whether the Help is shown.
+func (self *Help) IsOpen() bool {
+	return IsOverlay(self)
+}
+
+// Open shows the Help as an overlay at its rect, scrolled to the top.
+func (self *Help) Open() {
+	self.top = 0
+	ShowOverlay(self)
+}
+
+func (self *Help) Close() {
+	HideOverlay(self)
+}
+
+// Toggle opens the Help if it is closed and closes it otherwise.
+func (self *Help) Toggle() {
+	if self.IsOpen() {
+		self.Close()
+	} else {
+		self.Open()
+	}
+}
+
+// HandleKey opens and closes the Help with ToggleKey. While it is open, <Escape> and q close it
+// and the arrow keys scroll it. It reports whether the key was used, which is always the case
+// while the Help is open.
+func (self *Help) HandleKey(id string) bool {
+	if id == self.ToggleKey {
+		self.Toggle()
+		return true
+	}
+	if !self.IsOpen() {
+		return false
+	}
+	switch id {
+	case "<Escape>", "q":
+		self.Close()
+	case "<Up>", "k":
+		self.top--
+	case "<Down>", "j":
+		self.top++
+	case "<PageUp>":
+		self.top -= self.Inner.Dy()
+	case "<PageDown>":
+		self.top += self.Inner.Dy()
+	}
+	return true
+}
+
+// HandleMouse scrolls the Help with the mouse wheel and closes it on a click outside of it. It
+// reports whether the event was used, which is always the case while the Help is open.
+func (self *Help) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !self.IsOpen() {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.top -= WheelSteps(e)
+	case "<MouseWheelDown>":
+		self.top += WheelSteps(e)
+	case "<MouseLeft>":
+		if !p.In(self.Rectangle) && !e.Payload.(Mouse).Drag {
+			self.Close()
+		}
+	}
+	return true
+}
+
+// helpLine is a line of the Help: a scope heading when keys is empty, or a binding.
+type helpLine struct {
+	keys        string
+	description string
+}
+
+// lines lists the bindings with keys by scope, in the order the scopes were first used.
+func (self *Help) lines() ([]helpLine, int) {
+	bindings := self.Keymap.ActiveBindings()
+	lines := []helpLine{}
+	keysWidth := 0
+	for _, scope := range self.Keymap.Scopes() {
+		heading := false
+		for _, binding := range bindings {
+			if binding.Scope != scope || len(binding.Keys) == 0 {
+				continue
+			}
+			if !heading {
+				if len(lines) > 0 {
+					lines = append(lines, helpLine{})
+				}
+				lines = append(lines, helpLine{description: scope})
+				heading = true
+			}
+			keys := keyLabel(binding)
+			keysWidth = MaxInt(keysWidth, rw.StringWidth(keys))
+			lines = append(lines, helpLine{keys, binding.Description})
+		}
+	}
+	return lines, keysWidth
+}
+
+func (self *Help) Draw(buf *Buffer) {
+	buf.Fill(NewCell(' ', self.TextStyle), self.Rectangle)
+	self.Block.Draw(buf)
+	if self.Inner.Empty() {
+		return
+	}
+
+	lines, keysWidth := self.lines()
+	keysWidth = MinInt(keysWidth, self.Inner.Dx()/2)
+	self.top = MaxInt(MinInt(self.top, len(lines)-self.Inner.Dy()), 0)
+	for i := self.top; i < len(lines) && i-self.top < self.Inner.Dy(); i++ {
+		line := lines[i]
+		y := self.Inner.Min.Y + i - self.top
+		if line.keys == "" {
+			buf.SetString(TrimString(line.description, self.Inner.Dx()), self.ScopeStyle, image.Pt(self.Inner.Min.X, y))
+			continue
+		}
+		buf.SetString(TrimString(line.keys, keysWidth), self.KeyStyle, image.Pt(self.Inner.Min.X+1, y))
+		x := self.Inner.Min.X + keysWidth + 3
+		buf.SetString(TrimString(line.description, self.Inner.Max.X-x), self.TextStyle, image.Pt(x, y))
+	}
+	if self.top > 0 {
+		buf.SetCell(NewCell(UP_ARROW, self.BorderStyle), image.Pt(self.Inner.Max.X-1, self.Inner.Min.Y))
+	}
+	if self.top+self.Inner.Dy() < len(lines) {
+		buf.SetCell(NewCell(DOWN_ARROW, self.BorderStyle), image.Pt(self.Inner.Max.X-1, self.Inner.Max.Y-1))
+	}
+}
@@ -0,0 +1,445 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// HexView draws Data as rows of an offset, the bytes in hex, and the bytes as ASCII text, with a
+// cursor on a byte. v starts selecting bytes from the cursor, / opens a search bar looking for hex
+// bytes such as "de ad be ef", or for text when quoted or not hex, and n and N go to the next and
+// previous match. When Editable, hex digits typed overwrite the byte under the cursor, or text
+// typed after <Tab> moves the cursor to the ASCII column, and OnChange is called with every change.
+type HexView struct {
+	Block
+	Data []byte
+	// BytesPerRow is the number of bytes of a row, a multiple of 8 fitting the width when zero.
+	BytesPerRow int
+	Editable    bool
+
+	OffsetStyle    Style
+	TextStyle      Style
+	CursorStyle    Style
+	SelectionStyle Style
+	MatchStyle     Style
+
+	Cursor int
+	// OnCursorChange is called with the offset of the cursor after it moves.
+	OnCursorChange func(offset int)
+	// OnChange is called after the bytes at offset are changed with SetBytes or by typing, with
+	// their old and new values.
+	OnChange func(offset int, old, new []byte)
+
+	topRow  int
+	columns int
+	// selecting is set while the bytes from anchor to the Cursor are selected.
+	selecting bool
+	anchor    int
+	// ascii is set when the cursor is in the ASCII column, and nibble after the first hex digit of
+	// a byte is typed.
+	ascii  bool
+	nibble bool
+
+	searchBar
+	pattern []byte
+}
+
+func NewHexView() *HexView {
+	return &HexView{
+		Block:          *NewBlock(),
+		OffsetStyle:    Theme.HexView.Offset,
+		TextStyle:      Theme.HexView.Text,
+		CursorStyle:    Theme.HexView.Cursor,
+		SelectionStyle: Theme.HexView.Selection,
+		MatchStyle:     Theme.HexView.Match,
+	}
+}
+
+// SetCursor moves the cursor to the byte at offset, extending the selection if one is being made,
+// and calls OnCursorChange.
+func (self *HexView) SetCursor(offset int) {
+	offset = MaxInt(MinInt(offset, len(self.Data)-1), 0)
+	self.nibble = false
+	if offset == self.Cursor {
+		return
+	}
+	self.Cursor = offset
+	if self.OnCursorChange != nil {
+		self.OnCursorChange(offset)
+	}
+}
+
+// StartSelection starts selecting the bytes from the cursor on, or ends the selection when one is
+// being made.
+func (self *HexView) StartSelection() {
+	self.selecting = !self.selecting
+	self.anchor = self.Cursor
+}
+
+// ClearSelection removes the selection.
+func (self *HexView) ClearSelection() {
+	self.selecting = false
+}
+
+// Selection returns the offsets of the first and last bytes selected, and false if there's no
+// selection.
+func (self *HexView) Selection() (int, int, bool) {
+	if !self.selecting {
+		return 0, 0, false
+	}
+	if self.anchor < self.Cursor {
+		return self.anchor, self.Cursor, true
+	}
+	return self.Cursor, self.anchor, true
+}
+
+// SelectedBytes returns the bytes selected, or nil if there's no selection.
+func (self *HexView) SelectedBytes() []byte {
+	start, end, ok := self.Selection()
+	if !ok || end >= len(self.Data) {
+		return nil
+	}
+	return self.Data[start : end+1]
+}
+
+// SetBytes overwrites the bytes from offset with b, leaving out those beyond the end of Data, and
+// calls OnChange.
+func (self *HexView) SetBytes(offset int, b []byte) {
+	if offset < 0 || offset >= len(self.Data) {
+		return
+	}
+	b = b[:MinInt(len(b), len(self.Data)-offset)]
+	old := append([]byte{}, self.Data[offset:offset+len(b)]...)
+	copy(self.Data[offset:], b)
+	if self.OnChange != nil && !bytes.Equal(old, b) {
+		self.OnChange(offset, old, append([]byte{}, b...))
+	}
+}
+
+// parseHexPattern returns the bytes searched for by a query: the text between quotes, the bytes
+// of hex digits, which may be separated by spaces and start with 0x, or else the text.
+func parseHexPattern(query string) []byte {
+	if len(query) >= 2 && query[0] == '"' && query[len(query)-1] == '"' {
+		return []byte(query[1 : len(query)-1])
+	}
+	digits := strings.Replace(strings.TrimPrefix(query, "0x"), " ", "", -1)
+	if b, err := hex.DecodeString(digits); err == nil && len(b) > 0 {
+		return b
+	}
+	return []byte(query)
+}
+
+// Find moves the cursor to the next bytes matching query, as parsed for the search bar, after the
+// cursor and wrapping around to the start, selects them, and reports whether there is a match.
+func (self *HexView) Find(query string) bool {
+	self.pattern = parseHexPattern(query)
+	return self.findMatch(1)
+}
+
+// NextMatch goes to the next bytes matching the last search.
+func (self *HexView) NextMatch() bool {
+	return self.findMatch(1)
+}
+
+// PreviousMatch goes to the previous bytes matching the last search.
+func (self *HexView) PreviousMatch() bool {
+	return self.findMatch(-1)
+}
+
+func (self *HexView) findMatch(direction int) bool {
+	if len(self.pattern) == 0 {
+		return false
+	}
+	var i int
+	if direction > 0 {
+		if i = bytes.Index(self.Data[MinInt(self.Cursor+1, len(self.Data)):], self.pattern); i >= 0 {
+			i += self.Cursor + 1
+		} else {
+			i = bytes.Index(self.Data, self.pattern)
+		}
+	} else {
+		if i = bytes.LastIndex(self.Data[:self.Cursor], self.pattern); i < 0 {
+			i = bytes.LastIndex(self.Data, self.pattern)
+		}
+	}
+	if i < 0 {
+		return false
+	}
+	self.SetCursor(i + len(self.pattern) - 1)
+	self.selecting, self.anchor = true, i
+	return true
+}
+
+// StartSearch opens the search bar on the bottom line. HandleKey then edits the query, searching
+// for it with <Enter>.
+func (self *HexView) StartSearch() {
+	self.clearSearch()
+	self.openSearch()
+}
+
+// HandleKey moves the cursor with the arrow keys and hjkl, <PageUp> and <PageDown>, <Home> and
+// <End> of the row, and g and G to the first and last bytes, and selects, searches, and edits the
+// bytes as described for HexView. It reports whether the key was used.
+func (self *HexView) HandleKey(id string) bool {
+	if self.searching {
+		if id == "<Enter>" {
+			query := self.SearchQuery()
+			self.clearSearch()
+			self.Find(query)
+			return true
+		}
+		return self.handleSearchKey(id, func() {})
+	}
+
+	if self.Editable && len(self.Data) > 0 {
+		if self.ascii {
+			if runes := []rune(id); len(runes) == 1 && runes[0] < 0x80 || id == "<Space>" {
+				b := byte(' ')
+				if id != "<Space>" {
+					b = byte(runes[0])
+				}
+				self.SetBytes(self.Cursor, []byte{b})
+				self.SetCursor(self.Cursor + 1)
+				return true
+			}
+		} else if digit, err := strconv.ParseUint(id, 16, 8); err == nil && len(id) == 1 {
+			old := self.Data[self.Cursor]
+			if !self.nibble {
+				self.SetBytes(self.Cursor, []byte{byte(digit)<<4 | old&0x0f})
+				self.nibble = true
+			} else {
+				self.SetBytes(self.Cursor, []byte{old&0xf0 | byte(digit)})
+				self.SetCursor(self.Cursor + 1)
+			}
+			return true
+		}
+	}
+
+	columns := MaxInt(self.columns, 1)
+	switch id {
+	case "<Left>", "h":
+		self.SetCursor(self.Cursor - 1)
+	case "<Right>", "l":
+		self.SetCursor(self.Cursor + 1)
+	case "<Up>", "k":
+		self.SetCursor(self.Cursor - columns)
+	case "<Down>", "j":
+		self.SetCursor(self.Cursor + columns)
+	case "<PageUp>":
+		self.SetCursor(self.Cursor - columns*MaxInt(self.Inner.Dy()-1, 1))
+	case "<PageDown>":
+		self.SetCursor(self.Cursor + columns*MaxInt(self.Inner.Dy()-1, 1))
+	case "<Home>":
+		self.SetCursor(self.Cursor - self.Cursor%columns)
+	case "<End>":
+		self.SetCursor(self.Cursor - self.Cursor%columns + columns - 1)
+	case "g":
+		self.SetCursor(0)
+	case "G":
+		self.SetCursor(len(self.Data) - 1)
+	case "<Tab>":
+		self.ascii = !self.ascii
+		self.nibble = false
+	case "v":
+		self.StartSelection()
+	case "<Escape>":
+		self.ClearSelection()
+	case "/":
+		self.StartSearch()
+	case "n":
+		self.NextMatch()
+	case "N":
+		self.PreviousMatch()
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse moves the cursor to a clicked byte, selects the bytes the mouse is dragged over, and
+// scrolls with the mouse wheel. It reports whether the event was used.
+func (self *HexView) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Inner) {
+		return false
+	}
+	columns := MaxInt(self.columns, 1)
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.topRow = MaxInt(self.topRow-3*WheelSteps(e), 0)
+		self.SetCursor(self.Cursor - 3*WheelSteps(e)*columns)
+	case "<MouseWheelDown>":
+		self.topRow += 3 * WheelSteps(e)
+		self.SetCursor(self.Cursor + 3*WheelSteps(e)*columns)
+	case "<MouseLeft>":
+		offset, ascii, ok := self.byteAt(p)
+		if !ok {
+			return false
+		}
+		if e.Payload.(Mouse).Drag {
+			if !self.selecting {
+				self.selecting, self.anchor = true, self.Cursor
+			}
+		} else {
+			self.selecting = false
+			self.ascii = ascii
+		}
+		self.SetCursor(offset)
+	default:
+		return false
+	}
+	return true
+}
+
+// offsetWidth returns the number of hex digits of the offsets.
+func (self *HexView) offsetWidth() int {
+	return MaxInt(len(strconv.FormatInt(int64(len(self.Data)), 16)), 8)
+}
+
+// hexX returns the column of the hex digits of byte i of a row, counted from the left of the
+// Inner area, and asciiX the column of its character for rows of columns bytes.
+func (self *HexView) hexX(i int) int {
+	return self.offsetWidth() + 2 + 3*i + i/8
+}
+
+func (self *HexView) asciiX(i, columns int) int {
+	return self.hexX(columns-1) + 5 + i
+}
+
+// fitColumns returns the BytesPerRow, or the largest multiple of 8 bytes fitting the width.
+func (self *HexView) fitColumns() int {
+	if self.BytesPerRow > 0 {
+		return self.BytesPerRow
+	}
+	width := self.Inner.Dx()
+	columns := 8
+	for self.asciiX(columns+8, columns+8) <= width {
+		columns += 8
+	}
+	for columns > 1 && self.asciiX(columns, columns) > width {
+		columns--
+	}
+	return columns
+}
+
+// byteAt returns the offset of the byte drawn at p by the last Draw, and whether p is in the ASCII
+// column.
+func (self *HexView) byteAt(p image.Point) (int, bool, bool) {
+	columns := MaxInt(self.columns, 1)
+	row := self.topRow + p.Y - self.Inner.Min.Y
+	x := p.X - self.Inner.Min.X
+	for i := 0; i < columns; i++ {
+		offset := row*columns + i
+		if offset >= len(self.Data) {
+			break
+		}
+		if x >= self.hexX(i) && x < self.hexX(i)+2 {
+			return offset, false, true
+		}
+		if x == self.asciiX(i, columns) {
+			return offset, true, true
+		}
+	}
+	return 0, false, false
+}
+
+func (self *HexView) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	height := self.Inner.Dy()
+	if self.searching {
+		height--
+	}
+	if height <= 0 {
+		return
+	}
+	self.columns = self.fitColumns()
+	columns := self.columns
+	self.Cursor = MaxInt(MinInt(self.Cursor, len(self.Data)-1), 0)
+
+	// keep the cursor in view
+	rows := (len(self.Data) + columns - 1) / columns
+	cursorRow := self.Cursor / columns
+	if cursorRow < self.topRow {
+		self.topRow = cursorRow
+	} else if cursorRow >= self.topRow+height {
+		self.topRow = cursorRow - height + 1
+	}
+	self.topRow = MaxInt(MinInt(self.topRow, rows-height), 0)
+
+	// the matches of the last search starting before the rows in view or in them
+	first, last := self.topRow*columns, MinInt((self.topRow+height)*columns, len(self.Data))
+	matched := map[int]bool{}
+	if len(self.pattern) > 0 {
+		window := self.Data[MaxInt(first-len(self.pattern)+1, 0):last]
+		base := MaxInt(first-len(self.pattern)+1, 0)
+		for i := 0; i+len(self.pattern) <= len(window); {
+			j := bytes.Index(window[i:], self.pattern)
+			if j < 0 {
+				break
+			}
+			for k := 0; k < len(self.pattern); k++ {
+				matched[base+i+j+k] = true
+			}
+			i += j + 1
+		}
+	}
+	start, end, selected := self.Selection()
+
+	for y := 0; y < height && self.topRow+y < rows; y++ {
+		row := self.topRow + y
+		point := image.Pt(self.Inner.Min.X, self.Inner.Min.Y+y)
+		offset := fmt.Sprintf("%0*x", self.offsetWidth(), row*columns)
+		buf.SetString(TrimString(offset, self.Inner.Dx()), self.OffsetStyle, point)
+		if x := self.Inner.Min.X + self.asciiX(0, columns) - 2; x < self.Inner.Max.X {
+			buf.SetCell(NewCell(VERTICAL_LINE, self.OffsetStyle), image.Pt(x, point.Y))
+		}
+
+		for i := 0; i < columns && row*columns+i < len(self.Data); i++ {
+			offset := row*columns + i
+			b := self.Data[offset]
+			style := self.TextStyle
+			switch {
+			case selected && offset >= start && offset <= end:
+				style = self.SelectionStyle
+			case matched[offset]:
+				style = self.MatchStyle
+			}
+			hexStyle, asciiStyle := style, style
+			if offset == self.Cursor {
+				// the cursor is drawn in the column being typed in, and underlined in the other
+				hexStyle, asciiStyle = self.CursorStyle, style
+				asciiStyle.Modifier |= ModifierUnderline
+				if self.ascii {
+					hexStyle, asciiStyle = asciiStyle, self.CursorStyle
+				}
+			}
+			text := fmt.Sprintf("%02x", b)
+			for k, r := range text {
+				if x := self.Inner.Min.X + self.hexX(i) + k; x < self.Inner.Max.X {
+					buf.SetCell(NewCell(r, hexStyle), image.Pt(x, point.Y))
+				}
+			}
+			char := rune(b)
+			if b < 0x20 || b >= 0x7f {
+				char = '.'
+			}
+			if x := self.Inner.Min.X + self.asciiX(i, columns); x < self.Inner.Max.X {
+				buf.SetCell(NewCell(char, asciiStyle), image.Pt(x, point.Y))
+			}
+		}
+	}
+
+	if self.searching {
+		self.drawSearchBar(buf, image.Pt(self.Inner.Min.X, self.Inner.Max.Y-1), self.Inner.Dx()-1, self.TextStyle)
+	}
+}
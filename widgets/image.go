@@ -5,8 +5,13 @@
 package widgets
 
 import (
+	"bytes"
+	"encoding/base64"
 	"image"
 	"image/color"
+	"image/draw"
+	"image/png"
+	"reflect"
 
 	. "github.com/reaalkhalil/termui"
 )
@@ -17,6 +22,24 @@ type Image struct {
 	Monochrome          bool
 	MonochromeThreshold uint8
 	MonochromeInvert    bool
+	// Dithering diffuses the error of mapping the image to the terminal's colors when it is drawn
+	// with cells, which smooths gradients at the cost of some noise.
+	Dithering bool
+	// Protocol draws the image with the pixels of the terminal, scaled to fit the widget, when it
+	// isn't GraphicsNone. NewImage sets it to TerminalGraphics.
+	Protocol GraphicsProtocol
+
+	// graphic caches the last encoded image, so a modified Image needs to be set again as a new value.
+	graphic imageGraphic
+	// id names the image in terminals keeping their own copy of it.
+	id uint32
+}
+
+type imageGraphic struct {
+	source   image.Image
+	size     image.Point
+	protocol GraphicsProtocol
+	sequence []byte
 }
 
 func NewImage(img image.Image) *Image {
@@ -24,6 +47,8 @@ func NewImage(img image.Image) *Image {
 		Block:               *NewBlock(),
 		MonochromeThreshold: 128,
 		Image:               img,
+		Dithering:           true,
+		Protocol:            TerminalGraphics,
 	}
 }
 
@@ -33,6 +58,10 @@ func (self *Image) Draw(buf *Buffer) {
 	if self.Image == nil {
 		return
 	}
+	if self.Protocol != GraphicsNone {
+		self.drawGraphic(buf)
+		return
+	}
 
 	bufWidth := self.Inner.Dx()
 	bufHeight := self.Inner.Dy()
@@ -79,27 +108,138 @@ func (self *Image) Draw(buf *Buffer) {
 			}
 		}
 	} else {
-		if bufWidth > imageWidth {
-			bufWidth = imageWidth
+		self.drawHalfBlocks(buf)
+	}
+}
+
+// drawHalfBlocks draws two pixels in each cell with an upper half block colored by the top pixel
+// over a background of the bottom one. The image is mapped to the colors of TerminalColorDepth.
+func (self *Image) drawHalfBlocks(buf *Buffer) {
+	bounds := self.Image.Bounds()
+	width := MinInt(self.Inner.Dx(), bounds.Dx())
+	height := MinInt(self.Inner.Dy()*2, bounds.Dy())
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	colors, first := terminalPalette()
+	scaled := scaleImage(self.Image, width, height)
+	paletted := image.NewPaletted(scaled.Bounds(), colors)
+	if self.Dithering {
+		draw.FloydSteinberg.Draw(paletted, paletted.Bounds(), scaled, image.ZP)
+	} else {
+		draw.Draw(paletted, paletted.Bounds(), scaled, image.ZP, draw.Src)
+	}
+
+	for y := 0; y < height; y += 2 {
+		for x := 0; x < width; x++ {
+			top := first + Color(paletted.ColorIndexAt(x, y))
+			bottom := ColorClear
+			if y+1 < height {
+				bottom = first + Color(paletted.ColorIndexAt(x, y+1))
+			}
+			buf.SetCell(
+				NewCell(UPPER_HALF_BLOCK, NewStyle(top, bottom)),
+				image.Pt(self.Inner.Min.X+x, self.Inner.Min.Y+y/2),
+			)
 		}
-		if bufHeight > imageHeight {
-			bufHeight = imageHeight
+	}
+}
+
+// terminalPalette returns the colors displayable at TerminalColorDepth along with the Color of the first.
+// The 16 basic colors are left out at 256 colors since terminals often change them.
+func terminalPalette() (color.Palette, Color) {
+	first, last := 16, 256
+	switch {
+	case TerminalColorDepth == ColorDepth16:
+		first, last = 0, 16
+	case TerminalColorDepth < ColorDepth16:
+		first, last = 0, 8
+	}
+	colors := make(color.Palette, 0, last-first)
+	for i := first; i < last; i++ {
+		r, g, b := Color(i).RGB()
+		colors = append(colors, color.RGBA{r, g, b, 255})
+	}
+	return colors, Color(first)
+}
+
+// drawGraphic draws the image as a graphics escape sequence over the blank inner cells,
+// scaled to the largest size fitting them that keeps its aspect ratio.
+func (self *Image) drawGraphic(buf *Buffer) {
+	cell := CellSize()
+	bounds := self.Image.Bounds()
+	if bounds.Empty() || self.Inner.Empty() {
+		return
+	}
+	width, height := self.Inner.Dx()*cell.X, self.Inner.Dy()*cell.Y
+	if width*bounds.Dy() > height*bounds.Dx() {
+		width = MaxInt(height*bounds.Dx()/bounds.Dy(), 1)
+	} else {
+		height = MaxInt(width*bounds.Dy()/bounds.Dx(), 1)
+	}
+	size := image.Pt(width, height)
+
+	cached := self.graphic
+	if cached.sequence == nil || cached.size != size || cached.protocol != self.Protocol || !sameImage(cached.source, self.Image) {
+		var sequence []byte
+		columns := MinInt((width+cell.X-1)/cell.X, self.Inner.Dx())
+		rows := MinInt((height+cell.Y-1)/cell.Y, self.Inner.Dy())
+		switch self.Protocol {
+		case GraphicsSixel:
+			sequence = encodeSixel(self.Image, width, height)
+		case GraphicsKitty:
+			if self.id == 0 {
+				self.id = nextKittyImageID()
+			}
+			sequence = encodeKitty(self.Image, width, height, columns, rows, self.id)
+		case GraphicsITerm:
+			sequence = encodeITerm(self.Image, width, height, columns, rows)
 		}
-		for bx := 0; bx < bufWidth; bx++ {
-			for by := 0; by < bufHeight; by++ {
-				c := self.colorAverage(
-					bx*imageWidth/bufWidth,
-					(bx+1)*imageWidth/bufWidth,
-					by*imageHeight/bufHeight,
-					(by+1)*imageHeight/bufHeight,
-				)
-				buf.SetCell(
-					NewCell(c.ch(), NewStyle(c.fgColor(), ColorBlack)),
-					image.Pt(self.Inner.Min.X+bx, self.Inner.Min.Y+by),
-				)
+		self.graphic = imageGraphic{self.Image, size, self.Protocol, sequence}
+	}
+	if self.graphic.sequence == nil {
+		return
+	}
+	graphic := Graphic{Point: self.Inner.Min, Sequence: self.graphic.sequence}
+	if self.Protocol == GraphicsKitty {
+		graphic.Delete = deleteKitty(self.id)
+	}
+	buf.SetGraphic(graphic)
+}
+
+// sameImage reports whether a and b are the same image without panicking on images that can't be compared.
+func sameImage(a, b image.Image) bool {
+	return reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() && a == b
+}
+
+// scaleImage returns img resized to width by height pixels, averaging the pixels each new pixel covers.
+func scaleImage(img image.Image, width, height int) *image.RGBA {
+	bounds := img.Bounds()
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * bounds.Dy() / height
+		y1 := MaxInt((y+1)*bounds.Dy()/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := x * bounds.Dx() / width
+			x1 := MaxInt((x+1)*bounds.Dx()/width, x0+1)
+			var c colorAverager
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c = c.add(img.At(sx+bounds.Min.X, sy+bounds.Min.Y))
+				}
 			}
+			scaled.Set(x, y, c)
 		}
 	}
+	return scaled
+}
+
+// encodePNG returns img scaled to width by height pixels as a base64 encoded PNG.
+func encodePNG(img image.Image, width, height int) string {
+	var data bytes.Buffer
+	png.Encode(&data, scaleImage(img, width, height))
+	return base64.StdEncoding.EncodeToString(data.Bytes())
 }
 
 func (self *Image) colorAverage(x0, x1, y0, y1 int) colorAverager {
@@ -142,50 +282,10 @@ func (self colorAverager) RGBA() (uint32, uint32, uint32, uint32) {
 		uint32(self.asum/self.count) & 0xffff
 }
 
-func (self colorAverager) fgColor() Color {
-	return palette.Convert(self).(paletteColor).attribute
-}
-
-func (self colorAverager) ch() rune {
-	gray := color.GrayModel.Convert(self).(color.Gray).Y
-	switch {
-	case gray < 51:
-		return SHADED_BLOCKS[0]
-	case gray < 102:
-		return SHADED_BLOCKS[1]
-	case gray < 153:
-		return SHADED_BLOCKS[2]
-	case gray < 204:
-		return SHADED_BLOCKS[3]
-	default:
-		return SHADED_BLOCKS[4]
-	}
-}
-
 func (self colorAverager) monochrome(threshold uint8, invert bool) bool {
 	return self.count != 0 && (color.GrayModel.Convert(self).(color.Gray).Y < threshold != invert)
 }
 
-type paletteColor struct {
-	rgba      color.RGBA
-	attribute Color
-}
-
-func (self paletteColor) RGBA() (uint32, uint32, uint32, uint32) {
-	return self.rgba.RGBA()
-}
-
-var palette = color.Palette([]color.Color{
-	paletteColor{color.RGBA{0, 0, 0, 255}, ColorBlack},
-	paletteColor{color.RGBA{255, 0, 0, 255}, ColorRed},
-	paletteColor{color.RGBA{0, 255, 0, 255}, ColorGreen},
-	paletteColor{color.RGBA{255, 255, 0, 255}, ColorYellow},
-	paletteColor{color.RGBA{0, 0, 255, 255}, ColorBlue},
-	paletteColor{color.RGBA{255, 0, 255, 255}, ColorMagenta},
-	paletteColor{color.RGBA{0, 255, 255, 255}, ColorCyan},
-	paletteColor{color.RGBA{255, 255, 255, 255}, ColorWhite},
-})
-
 func blocksChar(ul, ur, ll, lr colorAverager, threshold uint8, invert bool) rune {
 	index := 0
 	if ul.monochrome(threshold, invert) {
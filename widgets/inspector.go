@@ -0,0 +1,535 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// inspectorMember is a key and value of an inspectorObject.
+type inspectorMember struct {
+	key   string
+	value interface{}
+}
+
+// inspectorObject is a JSON object or YAML mapping keeping the order of its keys.
+type inspectorObject []inspectorMember
+
+func (self inspectorObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, member := range self {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(member.key)
+		value, err := json.Marshal(member.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeJSON decodes a JSON value, with its objects as inspectorObjects and its numbers as
+// json.Numbers.
+func decodeJSON(text string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	value, err := decodeJSONValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the value at offset %d", decoder.InputOffset())
+	}
+	return value, nil
+}
+
+func decodeJSONValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := inspectorObject{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, inspectorMember{key.(string), value})
+		}
+		_, err = decoder.Token()
+		return object, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err = decoder.Token()
+		return array, err
+	}
+	return token, nil
+}
+
+// inspectorValue is the Value of a TreeNode of an Inspector: a Key of an object or an index of an
+// array, written like [0], with the value it holds.
+type inspectorValue struct {
+	key   string
+	path  string
+	value interface{}
+}
+
+// summary returns the value as drawn: strings quoted, and objects and arrays as the number of
+// their elements.
+func (self *inspectorValue) summary() string {
+	switch value := self.value.(type) {
+	case inspectorObject:
+		return fmt.Sprintf("{%d}", len(value))
+	case []interface{}:
+		return fmt.Sprintf("[%d]", len(value))
+	case string:
+		return strconv.Quote(value)
+	case nil:
+		return "null"
+	}
+	return fmt.Sprint(self.value)
+}
+
+// separator returns what is drawn between the key and the summary.
+func (self *inspectorValue) separator() string {
+	switch self.value.(type) {
+	case inspectorObject, []interface{}:
+		return " "
+	}
+	return ": "
+}
+
+func (self *inspectorValue) String() string {
+	if self.key == "" {
+		return self.summary()
+	}
+	return self.key + self.separator() + self.summary()
+}
+
+var inspectorIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// inspectorPath returns the path of the member key of the value at path.
+func inspectorPath(path, key string) string {
+	if inspectorIdentifier.MatchString(key) {
+		return path + "." + key
+	}
+	return path + "[" + strconv.Quote(key) + "]"
+}
+
+// Inspector is a Tree showing structured data, such as a JSON or YAML document, with the keys and
+// values of its objects and arrays colored by type. Every value has a path like
+// .spec.containers[0].name, which Find selects and which can be typed after : to go to it. y copies
+// the selected value, Y its path, and / searches the keys and values like the Tree search.
+type Inspector struct {
+	Tree
+	KeyStyle    Style
+	StringStyle Style
+	NumberStyle Style
+	BoolStyle   Style
+	NullStyle   Style
+	// SizeStyle is used for the number of elements drawn after the key of an object or array.
+	SizeStyle Style
+
+	// OnCopy is called with the text copied by CopyValue or CopyPath, and the error of
+	// CopyToClipboard if it failed.
+	OnCopy func(text string, err error)
+
+	// findingPath is set while a path is typed in the path bar.
+	findingPath bool
+	path        lineEditor
+}
+
+func NewInspector() *Inspector {
+	self := &Inspector{
+		Tree:        *NewTree(),
+		KeyStyle:    Theme.Inspector.Key,
+		StringStyle: Theme.Inspector.String,
+		NumberStyle: Theme.Inspector.Number,
+		BoolStyle:   Theme.Inspector.Bool,
+		NullStyle:   Theme.Inspector.Null,
+		SizeStyle:   Theme.Inspector.Size,
+	}
+	self.WrapText = false
+	self.SelectedRowStyle = Theme.Inspector.Selected
+	self.NodeRenderer = self.renderNode
+	return self
+}
+
+// LoadJSON shows a JSON document, keeping the order of the keys of its objects.
+func (self *Inspector) LoadJSON(data []byte) error {
+	value, err := decodeJSON(string(data))
+	if err != nil {
+		return err
+	}
+	self.setValue(value)
+	return nil
+}
+
+// LoadYAML shows a YAML document written in block style, keeping the order of the keys of its
+// mappings. Flow collections are read as JSON. Anchors, tags, and multiple documents aren't
+// supported; such documents can be decoded by a YAML package and shown with SetData.
+func (self *Inspector) LoadYAML(data []byte) error {
+	value, err := parseYAML(string(data))
+	if err != nil {
+		return err
+	}
+	self.setValue(value)
+	return nil
+}
+
+// SetData shows decoded data made of maps, slices, and values such as strings, numbers, and bools,
+// like those decoded by encoding/json or a YAML package into an interface{}. The keys of maps are
+// sorted.
+func (self *Inspector) SetData(data interface{}) {
+	self.setValue(inspectorData(reflect.ValueOf(data)))
+}
+
+// inspectorData converts maps into inspectorObjects and slices into []interface{}.
+func inspectorData(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return inspectorData(v.Elem())
+	case reflect.Map:
+		object := inspectorObject{}
+		for _, key := range v.MapKeys() {
+			object = append(object, inspectorMember{fmt.Sprint(key.Interface()), inspectorData(v.MapIndex(key))})
+		}
+		sort.Slice(object, func(i, j int) bool { return object[i].key < object[j].key })
+		return object
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		array := make([]interface{}, v.Len())
+		for i := range array {
+			array[i] = inspectorData(v.Index(i))
+		}
+		return array
+	}
+	return v.Interface()
+}
+
+func (self *Inspector) setValue(value interface{}) {
+	switch value.(type) {
+	case inspectorObject, []interface{}:
+		self.SetNodes(inspectorNodes("", value))
+	default:
+		self.SetNodes([]*TreeNode{{Value: &inspectorValue{path: ".", value: value}}})
+	}
+	self.SelectedRow = 0
+}
+
+// inspectorNodes returns the nodes of the members of an object or the elements of an array at path.
+func inspectorNodes(path string, value interface{}) []*TreeNode {
+	nodes := []*TreeNode{}
+	add := func(key, path string, value interface{}) {
+		node := &TreeNode{Value: &inspectorValue{key, path, value}}
+		node.Nodes = inspectorNodes(path, value)
+		nodes = append(nodes, node)
+	}
+	switch value := value.(type) {
+	case inspectorObject:
+		for _, member := range value {
+			add(member.key, inspectorPath(path, member.key), member.value)
+		}
+	case []interface{}:
+		for i, element := range value {
+			index := "[" + strconv.Itoa(i) + "]"
+			add(index, path+index, element)
+		}
+	}
+	return nodes
+}
+
+// renderNode draws the key of a node and its value in the style of its type.
+func (self *Inspector) renderNode(node *TreeNode, selected bool) []Cell {
+	v := node.Value.(*inspectorValue)
+	style := self.SizeStyle
+	switch v.value.(type) {
+	case inspectorObject, []interface{}:
+	case json.Number, float64, float32, int, int64, int32, uint, uint64, uint32:
+		style = self.NumberStyle
+	case bool:
+		style = self.BoolStyle
+	case nil:
+		style = self.NullStyle
+	default:
+		style = self.StringStyle
+	}
+	cells := []Cell{}
+	if v.key != "" {
+		cells = append(RunesToStyledCells([]rune(v.key), self.KeyStyle), RunesToStyledCells([]rune(v.separator()), self.TextStyle)...)
+	}
+	cells = append(cells, RunesToStyledCells([]rune(v.summary()), style)...)
+	if selected {
+		for i := range cells {
+			cells[i].Style.Bg = self.SelectedRowStyle.Bg
+			cells[i].Style.Modifier |= self.SelectedRowStyle.Modifier
+		}
+	}
+	return cells
+}
+
+// selectedValue returns the value of the selected node, or nil if there is none.
+func (self *Inspector) selectedValue() *inspectorValue {
+	if node := self.SelectedNode(); node != nil {
+		if v, ok := node.Value.(*inspectorValue); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// SelectedPath returns the path of the selected value, or "" if there is none.
+func (self *Inspector) SelectedPath() string {
+	if v := self.selectedValue(); v != nil {
+		return v.path
+	}
+	return ""
+}
+
+// SelectedValue returns the selected value as text: strings as they are, and other values as
+// JSON, objects and arrays indented.
+func (self *Inspector) SelectedValue() string {
+	v := self.selectedValue()
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.value.(string); ok {
+		return s
+	}
+	text, err := json.MarshalIndent(v.value, "", "  ")
+	if err != nil {
+		return fmt.Sprint(v.value)
+	}
+	return string(text)
+}
+
+// CopyValue puts the SelectedValue on the clipboard and calls OnCopy.
+func (self *Inspector) CopyValue() error {
+	return self.copy(self.SelectedValue())
+}
+
+// CopyPath puts the SelectedPath on the clipboard and calls OnCopy.
+func (self *Inspector) CopyPath() error {
+	return self.copy(self.SelectedPath())
+}
+
+func (self *Inspector) copy(text string) error {
+	err := CopyToClipboard(text)
+	if self.OnCopy != nil {
+		self.OnCopy(text, err)
+	}
+	return err
+}
+
+// parseInspectorPath splits a path like .spec.containers[0] or ["a key"] into its keys and
+// indexes, indexes written like [0], and reports whether it is valid. The leading dot is optional.
+func parseInspectorPath(path string) ([]string, bool) {
+	keys := []string{}
+	for i := 0; i < len(path); {
+		switch {
+		case path[i] == '.':
+			end := i + 1
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+			if end > i+1 {
+				keys = append(keys, path[i+1:end])
+			}
+			i = end
+		case strings.HasPrefix(path[i:], `["`):
+			end := closingQuote(path[i+1:])
+			if end < 0 || !strings.HasPrefix(path[i+end+2:], "]") {
+				return nil, false
+			}
+			key, err := strconv.Unquote(path[i+1 : i+end+2])
+			if err != nil {
+				return nil, false
+			}
+			keys = append(keys, key)
+			i += end + 3
+		case path[i] == '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, false
+			}
+			if _, err := strconv.Atoi(path[i+1 : i+end]); err != nil {
+				return nil, false
+			}
+			keys = append(keys, path[i:i+end+1])
+			i += end + 1
+		default:
+			// a first key without a dot
+			if i > 0 {
+				return nil, false
+			}
+			path = "." + path
+		}
+	}
+	return keys, true
+}
+
+// Find selects the value at path, like .spec.containers[0], expanding the values above it, and
+// reports whether there is one. When there isn't, the deepest value found along the path is
+// selected.
+func (self *Inspector) Find(path string) bool {
+	keys, ok := parseInspectorPath(path)
+	if !ok {
+		return false
+	}
+	var found *TreeNode
+	depth := 0
+	nodes := self.nodes
+	for _, key := range keys {
+		var next *TreeNode
+		for _, node := range nodes {
+			if node.Value.(*inspectorValue).key == key {
+				next = node
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		if found != nil {
+			found.Expanded = true
+		}
+		found, nodes = next, next.Nodes
+		depth++
+	}
+	if found == nil {
+		return false
+	}
+	self.prepareNodes()
+	for row, node := range self.rows {
+		if node == found {
+			self.SelectedRow = row
+		}
+	}
+	return depth == len(keys)
+}
+
+// StartFind opens the path bar on the bottom line, filled with the path of the selected value.
+// While it is open, HandleKey edits the path, selecting the value found at it as it is typed.
+func (self *Inspector) StartFind() {
+	self.findingPath = true
+	self.path.setText(self.SelectedPath())
+}
+
+// FindingPath reports whether the path bar is accepting input.
+func (self *Inspector) FindingPath() bool {
+	return self.findingPath
+}
+
+// HandleKey moves through the values with the arrow keys and hjkl, expands and collapses them with
+// <Enter>, <Right>, and <Left>, opens the path bar with : and the search bar with /, and copies the
+// selected value with y and its path with Y. It reports whether the key was used.
+func (self *Inspector) HandleKey(id string) bool {
+	if self.findingPath {
+		switch id {
+		case "<Enter>", "<Escape>":
+			self.findingPath = false
+		default:
+			if !self.path.handleKey(id) {
+				return false
+			}
+			self.Find(self.path.text())
+		}
+		return true
+	}
+	if self.HandleSearchKey(id) {
+		return true
+	}
+	if len(self.rows) == 0 {
+		return false
+	}
+	switch id {
+	case "<Up>", "k":
+		self.ScrollUp()
+	case "<Down>", "j":
+		self.ScrollDown()
+	case "<PageUp>":
+		self.ScrollPageUp()
+	case "<PageDown>":
+		self.ScrollPageDown()
+	case "<Home>", "g":
+		self.ScrollTop()
+	case "<End>", "G":
+		self.ScrollBottom()
+	case "<Enter>", "<Space>":
+		self.ToggleExpand()
+	case "<Right>", "l":
+		self.Expand()
+	case "<Left>", "h":
+		self.Collapse()
+	case "E":
+		self.ExpandAll()
+	case "C":
+		self.CollapseAll()
+	case "/":
+		self.StartSearch()
+	case ":":
+		self.StartFind()
+	case "y":
+		self.CopyValue()
+	case "Y":
+		self.CopyPath()
+	default:
+		return false
+	}
+	return true
+}
+
+func (self *Inspector) Draw(buf *Buffer) {
+	if !self.findingPath {
+		self.Tree.Draw(buf)
+		return
+	}
+	// draw the path bar on the bottom line, below the tree
+	inner := self.Inner
+	self.Inner.Max.Y--
+	self.Tree.Draw(buf)
+	self.Inner = inner
+	y := self.Inner.Max.Y - 1
+	buf.Fill(NewCell(' ', self.TextStyle), image.Rect(self.Inner.Min.X, y, self.Inner.Max.X, y+1))
+	buf.SetCell(NewCell(':', self.TextStyle), image.Pt(self.Inner.Min.X, y))
+	self.path.draw(buf, image.Pt(self.Inner.Min.X+1, y), self.Inner.Dx()-1, self.TextStyle, true)
+}
@@ -0,0 +1,22 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// encodeITerm returns the iTerm2 inline image sequence drawing img as a PNG scaled to width by
+// height pixels and stretched over columns by rows cells.
+func encodeITerm(img image.Image, width, height, columns, rows int) []byte {
+	payload := encodePNG(img, width, height)
+	size := len(payload)/4*3 - strings.Count(payload[MaxInt(len(payload)-2, 0):], "=")
+	return []byte(fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1;doNotMoveCursor=1:%s\a",
+		size, columns, rows, payload))
+}
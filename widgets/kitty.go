@@ -0,0 +1,50 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"sync/atomic"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// kittyChunkSize is the largest payload the kitty graphics protocol accepts in one escape sequence.
+const kittyChunkSize = 4096
+
+// kittyImageIDs hands out the ids naming the images of each Image widget in the terminal.
+var kittyImageIDs uint32
+
+func nextKittyImageID() uint32 {
+	return atomic.AddUint32(&kittyImageIDs, 1)
+}
+
+// encodeKitty returns the kitty graphics sequence transmitting img as a PNG scaled to width by
+// height pixels and placing it over columns by rows cells without moving the cursor.
+// Transmitting another image with the same id replaces the previous one.
+func encodeKitty(img image.Image, width, height, columns, rows int, id uint32) []byte {
+	payload := encodePNG(img, width, height)
+	var b bytes.Buffer
+	for start := 0; start < len(payload); start += kittyChunkSize {
+		end := MinInt(start+kittyChunkSize, len(payload))
+		more := 0
+		if end < len(payload) {
+			more = 1
+		}
+		if start == 0 {
+			fmt.Fprintf(&b, "\x1b_Ga=T,f=100,q=2,C=1,i=%d,c=%d,r=%d,m=%d;%s\x1b\\", id, columns, rows, more, payload[start:end])
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, payload[start:end])
+		}
+	}
+	return b.Bytes()
+}
+
+// deleteKitty returns the kitty graphics sequence deleting the image with the given id and its data.
+func deleteKitty(id uint32) []byte {
+	return []byte(fmt.Sprintf("\x1b_Ga=d,d=I,q=2,i=%d\x1b\\", id))
+}
@@ -0,0 +1,238 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// LayoutTypes holds the widgets a layout can create, by the name of their type, which is matched
+// ignoring case. Applications can add their own widgets.
+var LayoutTypes = map[string]func() Drawable{
+	"BarChart":        func() Drawable { return NewBarChart() },
+	"BigText":         func() Drawable { return NewBigText() },
+	"BoxPlot":         func() Drawable { return NewBoxPlot() },
+	"Calendar":        func() Drawable { return NewCalendar() },
+	"CalendarHeatmap": func() Drawable { return NewCalendarHeatmap() },
+	"Clock":           func() Drawable { return NewClock() },
+	"ColorPicker":     func() Drawable { return NewColorPicker() },
+	"DatePicker":      func() Drawable { return NewDatePicker() },
+	"Dial":            func() Drawable { return NewDial() },
+	"DiffView":        func() Drawable { return NewDiffView() },
+	"Gantt":           func() Drawable { return NewGantt() },
+	"Gauge":           func() Drawable { return NewGauge() },
+	"Graph":           func() Drawable { return NewGraph() },
+	"HeatGrid":        func() Drawable { return NewHeatGrid() },
+	"HexView":         func() Drawable { return NewHexView() },
+	"Inspector":       func() Drawable { return NewInspector() },
+	"LEDDisplay":      func() Drawable { return NewLEDDisplay() },
+	"List":            func() Drawable { return NewList() },
+	"LogView":         func() Drawable { return NewLogView() },
+	"MarkdownViewer":  func() Drawable { return NewMarkdownViewer() },
+	"NumberInput":     func() Drawable { return NewNumberInput() },
+	"Paragraph":       func() Drawable { return NewParagraph() },
+	"PieChart":        func() Drawable { return NewPieChart() },
+	"Plot":            func() Drawable { return NewPlot() },
+	"Progress":        func() Drawable { return NewProgress() },
+	"QRCode":          func() Drawable { return NewQRCode() },
+	"RadarChart":      func() Drawable { return NewRadarChart() },
+	"Select":          func() Drawable { return NewSelect() },
+	"Slider":          func() Drawable { return NewSlider() },
+	"Spectrogram":     func() Drawable { return NewSpectrogram() },
+	"Spinner":         func() Drawable { return NewSpinner() },
+	"StackedBarChart": func() Drawable { return NewStackedBarChart() },
+	"StatusBar":       func() Drawable { return NewStatusBar() },
+	"Table":           func() Drawable { return NewTable() },
+	"TagInput":        func() Drawable { return NewTagInput() },
+	"TextArea":        func() Drawable { return NewTextArea() },
+	"TextInput":       func() Drawable { return NewTextInput() },
+	"Tree":            func() Drawable { return NewTree() },
+	"Treemap":         func() Drawable { return NewTreemap() },
+}
+
+// Layout is a Grid of widgets loaded from a description by LoadLayout.
+type Layout struct {
+	Grid *Grid
+	// Widgets holds the widgets with an id, for the application to fill them with data.
+	Widgets map[string]Drawable
+}
+
+// Widget returns the widget with an id, or nil when the layout has none.
+func (self *Layout) Widget(id string) Drawable {
+	return self.Widgets[id]
+}
+
+// layoutNode is a row or column of a layout, holding rows, columns, or a widget.
+type layoutNode struct {
+	// Ratio is the part of its parent taken, the parts not given being split evenly.
+	Ratio   float64
+	Rows    []layoutNode
+	Columns []layoutNode
+	Widget  *layoutWidget
+}
+
+type layoutWidget struct {
+	ID    string
+	Type  string
+	Title string
+	// Options are set on the exported fields of the widget.
+	Options json.RawMessage
+}
+
+// LoadLayout builds a Grid of widgets from a JSON or YAML description, so that a dashboard can be
+// changed without recompiling it. The description holds the rows or columns of the Grid, each
+// with a ratio of its parent and either rows, columns, or a widget:
+//
+//	rows:
+//	  - ratio: 0.3
+//	    columns:
+//	      - widget:
+//	          id: cpu
+//	          type: Gauge
+//	          title: CPU
+//	          options:
+//	            BarColor: green
+//	      - widget:
+//	          id: memory
+//	          type: Gauge
+//	          title: Memory
+//	  - widget:
+//	      id: log
+//	      type: LogView
+//	      title: Log
+//
+// Widgets are created from LayoutTypes, and their options set their exported fields like
+// encoding/json does, with colors by number or name. Rows and columns without a ratio share what
+// the others leave. The Grid is sized by the application with SetRect.
+func LoadLayout(data []byte) (*Layout, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		value, err := parseYAML(string(data))
+		if err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	}
+	root := layoutNode{}
+	if err := decodeLayoutJSON(data, &root); err != nil {
+		return nil, err
+	}
+	layout := &Layout{Grid: NewGrid(), Widgets: map[string]Drawable{}}
+	if root.Widget != nil || len(root.Rows) > 0 == (len(root.Columns) > 0) {
+		return nil, fmt.Errorf("layout must hold either rows or columns")
+	}
+	items, err := layout.items(root, "")
+	if err != nil {
+		return nil, err
+	}
+	layout.Grid.Set(items...)
+	return layout, nil
+}
+
+// LoadLayoutFile loads a layout from a JSON or YAML file with LoadLayout.
+func LoadLayoutFile(path string) (*Layout, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadLayout(data)
+}
+
+// decodeLayoutJSON decodes data into value, rejecting unknown fields to catch misspellings.
+func decodeLayoutJSON(data []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(value)
+}
+
+// items returns the grid items of the rows or columns of a node at path.
+func (self *Layout) items(node layoutNode, path string) ([]interface{}, error) {
+	children, name, newItem := node.Rows, "rows", NewRow
+	if len(node.Columns) > 0 {
+		children, name, newItem = node.Columns, "columns", NewCol
+	}
+
+	// the rows or columns without a ratio share what the others leave
+	given, missing := 0.0, 0
+	for _, child := range children {
+		if child.Ratio > 0 {
+			given += child.Ratio
+		} else {
+			missing++
+		}
+	}
+	shared := 0.0
+	if missing > 0 {
+		shared = (1 - given) / float64(missing)
+	}
+
+	items := []interface{}{}
+	for i, child := range children {
+		childPath := fmt.Sprintf("%s%s[%d]", path, name, i)
+		ratio := child.Ratio
+		if ratio <= 0 {
+			ratio = shared
+		}
+		switch {
+		case child.Widget != nil && (len(child.Rows) > 0 || len(child.Columns) > 0),
+			len(child.Rows) > 0 && len(child.Columns) > 0:
+			return nil, fmt.Errorf("%s: must hold either rows, columns, or a widget", childPath)
+		case child.Widget != nil:
+			widget, err := self.widget(child.Widget, childPath)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, newItem(ratio, widget))
+		case len(child.Rows) > 0 || len(child.Columns) > 0:
+			grandchildren, err := self.items(child, childPath+".")
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, newItem(ratio, grandchildren...))
+		default:
+			return nil, fmt.Errorf("%s: is empty", childPath)
+		}
+	}
+	return items, nil
+}
+
+// widget creates the widget of a description at path.
+func (self *Layout) widget(description *layoutWidget, path string) (Drawable, error) {
+	var create func() Drawable
+	for name, constructor := range LayoutTypes {
+		if strings.EqualFold(name, description.Type) {
+			create = constructor
+		}
+	}
+	if create == nil {
+		return nil, fmt.Errorf("%s: unknown widget type %q", path, description.Type)
+	}
+	widget := create()
+	if description.Title != "" {
+		title, _ := json.Marshal(map[string]string{"Title": description.Title})
+		if err := json.Unmarshal(title, widget); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	if len(description.Options) > 0 {
+		if err := decodeLayoutJSON(description.Options, widget); err != nil {
+			return nil, fmt.Errorf("%s: options: %v", path, err)
+		}
+	}
+	if description.ID != "" {
+		if _, ok := self.Widgets[description.ID]; ok {
+			return nil, fmt.Errorf("%s: duplicate id %q", path, description.ID)
+		}
+		self.Widgets[description.ID] = widget
+	}
+	return widget, nil
+}
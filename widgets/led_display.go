@@ -0,0 +1,217 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"unicode"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// The segments of a seven-segment digit: top, top right, bottom right, bottom, bottom left,
+// top left, and middle.
+const (
+	segmentA = 1 << iota
+	segmentB
+	segmentC
+	segmentD
+	segmentE
+	segmentF
+	segmentG
+)
+
+// ledSegments holds the segments lit for the characters an LEDDisplay can show. Letters without
+// a form of their own are looked up in the other case.
+var ledSegments = map[rune]int{
+	'0': segmentA | segmentB | segmentC | segmentD | segmentE | segmentF,
+	'1': segmentB | segmentC,
+	'2': segmentA | segmentB | segmentD | segmentE | segmentG,
+	'3': segmentA | segmentB | segmentC | segmentD | segmentG,
+	'4': segmentB | segmentC | segmentF | segmentG,
+	'5': segmentA | segmentC | segmentD | segmentF | segmentG,
+	'6': segmentA | segmentC | segmentD | segmentE | segmentF | segmentG,
+	'7': segmentA | segmentB | segmentC,
+	'8': segmentA | segmentB | segmentC | segmentD | segmentE | segmentF | segmentG,
+	'9': segmentA | segmentB | segmentC | segmentD | segmentF | segmentG,
+
+	'A': segmentA | segmentB | segmentC | segmentE | segmentF | segmentG,
+	'b': segmentC | segmentD | segmentE | segmentF | segmentG,
+	'C': segmentA | segmentD | segmentE | segmentF,
+	'c': segmentD | segmentE | segmentG,
+	'd': segmentB | segmentC | segmentD | segmentE | segmentG,
+	'E': segmentA | segmentD | segmentE | segmentF | segmentG,
+	'F': segmentA | segmentE | segmentF | segmentG,
+	'G': segmentA | segmentC | segmentD | segmentE | segmentF,
+	'H': segmentB | segmentC | segmentE | segmentF | segmentG,
+	'h': segmentC | segmentE | segmentF | segmentG,
+	'I': segmentE | segmentF,
+	'J': segmentB | segmentC | segmentD | segmentE,
+	'L': segmentD | segmentE | segmentF,
+	'n': segmentC | segmentE | segmentG,
+	'o': segmentC | segmentD | segmentE | segmentG,
+	'P': segmentA | segmentB | segmentE | segmentF | segmentG,
+	'q': segmentA | segmentB | segmentC | segmentF | segmentG,
+	'r': segmentE | segmentG,
+	'S': segmentA | segmentC | segmentD | segmentF | segmentG,
+	't': segmentD | segmentE | segmentF | segmentG,
+	'U': segmentB | segmentC | segmentD | segmentE | segmentF,
+	'u': segmentC | segmentD | segmentE,
+	'y': segmentB | segmentC | segmentD | segmentF | segmentG,
+
+	' ': 0,
+	'-': segmentG,
+	'_': segmentD,
+	'=': segmentD | segmentG,
+	'°': segmentA | segmentB | segmentF | segmentG,
+}
+
+// LEDDisplay draws Text as seven-segment digits with half blocks, with the segments lit drawn
+// with OnColor and the others with OffColor, or left out when it's ColorClear. Text can hold
+// digits, the letters seven segments can show, such as those of hex numbers, "-_=°", and '.' and
+// ':' drawn between digits; other characters are drawn with every segment off. Digits are as tall
+// as the LEDDisplay, or DigitHeight rows when it's greater than 0.
+type LEDDisplay struct {
+	Block
+	Text          string
+	DigitHeight   int
+	TextAlignment Alignment
+	OnColor       Color
+	OffColor      Color
+}
+
+func NewLEDDisplay() *LEDDisplay {
+	return &LEDDisplay{
+		Block:    *NewBlock(),
+		OnColor:  Theme.LEDDisplay.On,
+		OffColor: Theme.LEDDisplay.Off,
+	}
+}
+
+// ledColumn is a column of pixels of the display, ColorClear where nothing is drawn.
+type ledColumn []Color
+
+// digitColumns returns the columns of pixels of a digit w pixels wide and h high, h being odd.
+func (self *LEDDisplay) digitColumns(segments, w, h int) []ledColumn {
+	middle := (h - 1) / 2
+	columns := make([]ledColumn, w)
+	for x := range columns {
+		columns[x] = make(ledColumn, h)
+		for y := range columns[x] {
+			// the segments the pixel is part of, which share the corners
+			owners := 0
+			switch y {
+			case 0:
+				owners = segmentA
+			case middle:
+				owners = segmentG
+			case h - 1:
+				owners = segmentD
+			}
+			if x == 0 || x == w-1 {
+				upper, lower := segmentF, segmentE
+				if x == w-1 {
+					upper, lower = segmentB, segmentC
+				}
+				if y <= middle {
+					owners |= upper
+				}
+				if y >= middle {
+					owners |= lower
+				}
+			}
+			switch {
+			case owners == 0:
+				columns[x][y] = ColorClear
+			case segments&owners != 0:
+				columns[x][y] = self.OnColor
+			default:
+				columns[x][y] = self.OffColor
+			}
+		}
+	}
+	return columns
+}
+
+// columns returns the columns of pixels of Text, with digits w pixels wide and h high.
+func (self *LEDDisplay) columns(w, h int) []ledColumn {
+	columns := []ledColumn{}
+	gap := func() {
+		columns = append(columns, make(ledColumn, h))
+		for y := range columns[len(columns)-1] {
+			columns[len(columns)-1][y] = ColorClear
+		}
+	}
+	for i, r := range []rune(self.Text) {
+		if i > 0 {
+			gap()
+		}
+		switch r {
+		case '.', ':':
+			gap()
+			dots := columns[len(columns)-1]
+			if r == '.' {
+				dots[h-1] = self.OnColor
+			} else {
+				dots[(h-1)/4], dots[h-1-(h-1)/4] = self.OnColor, self.OnColor
+			}
+			continue
+		}
+		segments, ok := ledSegments[r]
+		if !ok {
+			if segments, ok = ledSegments[unicode.ToUpper(r)]; !ok {
+				segments = ledSegments[unicode.ToLower(r)]
+			}
+		}
+		columns = append(columns, self.digitColumns(segments, w, h)...)
+	}
+	return columns
+}
+
+func (self *LEDDisplay) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+
+	rows := self.Inner.Dy()
+	if self.DigitHeight > 0 {
+		rows = MinInt(self.DigitHeight, rows)
+	}
+	// an odd number of pixels leaves a middle row, and digits are a little over half as wide as
+	// they are high
+	h := rows*2 - 1
+	if h < 5 {
+		return
+	}
+	w := (h + 1) / 2
+	columns := self.columns(w, h)
+
+	left := self.Inner.Min.X
+	switch self.TextAlignment {
+	case AlignCenter:
+		left += (self.Inner.Dx() - len(columns)) / 2
+	case AlignRight:
+		left += self.Inner.Dx() - len(columns)
+	}
+	top := self.Inner.Min.Y + (self.Inner.Dy()-rows)/2
+	for x, column := range columns {
+		for y := 0; y < rows; y++ {
+			point := image.Pt(left+x, top+y)
+			if !point.In(self.Inner) {
+				continue
+			}
+			upper, lower := column[2*y], ColorClear
+			if 2*y+1 < h {
+				lower = column[2*y+1]
+			}
+			switch {
+			case upper != ColorClear && lower != ColorClear:
+				buf.SetCell(NewCell(UPPER_HALF_BLOCK, NewStyle(upper, lower)), point)
+			case upper != ColorClear:
+				buf.SetCell(NewCell(UPPER_HALF_BLOCK, NewStyle(upper)), point)
+			case lower != ColorClear:
+				buf.SetCell(NewCell(BARS[4], NewStyle(lower)), point)
+			}
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// drawLegend draws a line of labels, each after a block in its color, starting at point and
+// trimmed to width.
+func drawLegend(buf *Buffer, point image.Point, width int, labels []string, colors []Color, style Style) {
+	cells := []Cell{}
+	for i, label := range labels {
+		if i > 0 {
+			cells = append(cells, NewCell(' ', style), NewCell(' ', style))
+		}
+		cells = append(cells, NewCell(BARS[len(BARS)-1], NewStyle(SelectColor(colors, i))))
+		cells = append(cells, RunesToStyledCells([]rune(" "+label), style)...)
+	}
+	for _, cx := range BuildCellWithXArray(TrimCells(cells, width)) {
+		buf.SetCell(cx.Cell, image.Pt(point.X+cx.X, point.Y))
+	}
+}
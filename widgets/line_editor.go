@@ -0,0 +1,456 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// lineEditor holds a single line of text being edited and the position of its cursor.
+type lineEditor struct {
+	runes  []rune
+	cursor int
+	// offset is the first rune drawn, kept between draws so the text only scrolls when the cursor leaves the view.
+	offset int
+	// mask is drawn in place of every rune when it isn't 0.
+	mask rune
+	// direction is the base direction the text is drawn with, reordered when it has right-to-left
+	// text. column is then the first column drawn, and columns holds the rune drawn in every column.
+	direction Direction
+	column    int
+	columns   []int
+	// history records the edits undone with <C-z>, or none when it is nil.
+	history *History
+}
+
+// the groups of the edits whose consecutive keystrokes are undone in one step
+const (
+	editTyping   = "typing"
+	editDeleting = "deleting"
+)
+
+// lineEditorSnapshot is an undo step restoring the text and cursor of a lineEditor from before it.
+type lineEditorSnapshot struct {
+	editor                    *lineEditor
+	before, after             []rune
+	beforeCursor, afterCursor int
+}
+
+func (self *lineEditorSnapshot) Undo() {
+	self.after = append([]rune{}, self.editor.runes...)
+	self.afterCursor = self.editor.cursor
+	self.editor.runes = append([]rune{}, self.before...)
+	self.editor.cursor = self.beforeCursor
+}
+
+func (self *lineEditorSnapshot) Redo() {
+	self.editor.runes = append([]rune{}, self.after...)
+	self.editor.cursor = self.afterCursor
+}
+
+// Merge absorbs the later snapshots of the same lineEditor, which Undo reverts along with it.
+func (self *lineEditorSnapshot) Merge(next Edit) bool {
+	snapshot, ok := next.(*lineEditorSnapshot)
+	return ok && snapshot.editor == self.editor
+}
+
+// edit records the text before an edit of a group in the history.
+func (self *lineEditor) edit(group string) {
+	if self.history != nil {
+		self.history.Push(&lineEditorSnapshot{
+			editor:       self,
+			before:       append([]rune{}, self.runes...),
+			beforeCursor: self.cursor,
+		}, group)
+	}
+}
+
+// moved ends the group of the last edits, after the cursor is moved.
+func (self *lineEditor) moved() {
+	if self.history != nil {
+		self.history.Break()
+	}
+}
+
+// setText replaces the text, moving the cursor to its end, and clears the history.
+func (self *lineEditor) setText(s string) {
+	self.runes = []rune(s)
+	self.cursor = len(self.runes)
+	if self.history != nil {
+		self.history.Clear()
+	}
+}
+
+func (self *lineEditor) text() string {
+	return string(self.runes)
+}
+
+// insert types r at the cursor, recording it in the history.
+func (self *lineEditor) insert(r rune) {
+	group := editTyping
+	if r == ' ' {
+		group = ""
+	}
+	self.edit(group)
+	self.insertRune(r)
+}
+
+// insertText pastes s at the cursor, recording it as one edit.
+func (self *lineEditor) insertText(s string) {
+	self.edit("")
+	for _, r := range s {
+		self.insertRune(r)
+	}
+}
+
+func (self *lineEditor) insertRune(r rune) {
+	self.runes = append(self.runes, 0)
+	copy(self.runes[self.cursor+1:], self.runes[self.cursor:])
+	self.runes[self.cursor] = r
+	self.cursor++
+}
+
+func (self *lineEditor) backspace() {
+	if self.cursor > 0 {
+		self.edit(editDeleting)
+		self.runes = append(self.runes[:self.cursor-1], self.runes[self.cursor:]...)
+		self.cursor--
+	}
+}
+
+func (self *lineEditor) delete() {
+	if self.cursor < len(self.runes) {
+		self.edit(editDeleting)
+		self.runes = append(self.runes[:self.cursor], self.runes[self.cursor+1:]...)
+	}
+}
+
+func (self *lineEditor) left() {
+	self.cursor = MaxInt(self.cursor-1, 0)
+	self.moved()
+}
+
+func (self *lineEditor) right() {
+	self.cursor = MinInt(self.cursor+1, len(self.runes))
+	self.moved()
+}
+
+// deleteWord deletes the word before the cursor along with the spaces following it.
+func (self *lineEditor) deleteWord() {
+	start := self.cursor
+	for start > 0 && self.runes[start-1] == ' ' {
+		start--
+	}
+	for start > 0 && self.runes[start-1] != ' ' {
+		start--
+	}
+	self.edit("")
+	self.runes = append(self.runes[:start], self.runes[self.cursor:]...)
+	self.cursor = start
+}
+
+func (self *lineEditor) deleteToStart() {
+	self.edit("")
+	self.runes = self.runes[self.cursor:]
+	self.cursor = 0
+}
+
+func (self *lineEditor) deleteToEnd() {
+	self.edit("")
+	self.runes = self.runes[:self.cursor]
+}
+
+func (self *lineEditor) home() {
+	self.cursor = 0
+	self.moved()
+}
+
+func (self *lineEditor) end() {
+	self.cursor = len(self.runes)
+	self.moved()
+}
+
+// handleKey applies the editing action for a keyboard event ID and reports whether it was one.
+// <C-z> and <C-y> undo and redo edits when the editor has a history.
+func (self *lineEditor) handleKey(id string) bool {
+	switch id {
+	case "<C-z>", "<C-y>":
+		if self.history == nil {
+			return false
+		}
+		if id == "<C-z>" {
+			self.history.Undo()
+		} else {
+			self.history.Redo()
+		}
+		return true
+	case "<Left>":
+		// the arrows move the cursor the way they point, which is backwards in right-to-left text
+		if self.rtl() {
+			self.right()
+		} else {
+			self.left()
+		}
+	case "<Right>":
+		if self.rtl() {
+			self.left()
+		} else {
+			self.right()
+		}
+	case "<C-b>":
+		self.left()
+	case "<C-f>":
+		self.right()
+	case "<Home>", "<C-a>":
+		self.home()
+	case "<End>", "<C-e>":
+		self.end()
+	case "<Backspace>", "<C-<Backspace>>":
+		self.backspace()
+	case "<Delete>", "<C-d>":
+		self.delete()
+	case "<C-w>":
+		self.deleteWord()
+	case "<C-u>":
+		self.deleteToStart()
+	case "<C-k>":
+		self.deleteToEnd()
+	case "<Space>":
+		self.insert(' ')
+	default:
+		runes := []rune(id)
+		if len(runes) != 1 {
+			return false
+		}
+		self.insert(runes[0])
+	}
+	return true
+}
+
+// shown returns the rune drawn for the rune at i.
+func (self *lineEditor) shown(i int) rune {
+	if self.mask != 0 {
+		return self.mask
+	}
+	return self.runes[i]
+}
+
+// cells returns the runes drawn as cells with style.
+func (self *lineEditor) cells(style Style) []Cell {
+	cells := make([]Cell, len(self.runes))
+	for i := range self.runes {
+		cells[i] = NewCell(self.shown(i), style)
+	}
+	return cells
+}
+
+// rtl reports whether the text is drawn right to left.
+func (self *lineEditor) rtl() bool {
+	return ResolveDirection(self.cells(StyleClear), self.direction) == DirectionRTL
+}
+
+// width returns the number of columns taken by the runes from start to end.
+func (self *lineEditor) width(start, end int) int {
+	w := 0
+	for i := start; i < end; i++ {
+		w += rw.RuneWidth(self.shown(i))
+	}
+	return w
+}
+
+// scroll moves the offset so that the cursor fits in the width.
+func (self *lineEditor) scroll(width int) {
+	self.offset = MinInt(self.offset, self.cursor)
+	for self.offset < self.cursor && self.width(self.offset, self.cursor)+1 > width {
+		self.offset++
+	}
+}
+
+// runeAt returns the index of the rune drawn at column x of the last draw, for placing the cursor with the mouse.
+func (self *lineEditor) runeAt(x int) int {
+	if self.columns != nil {
+		if x < 0 || x >= len(self.columns) || self.columns[x] < 0 {
+			return len(self.runes)
+		}
+		return self.columns[x]
+	}
+	for i := self.offset; i < len(self.runes); i++ {
+		x -= rw.RuneWidth(self.shown(i))
+		if x < 0 {
+			return i
+		}
+	}
+	return len(self.runes)
+}
+
+// draw draws the text within the given width starting at p, scrolled so the cursor stays visible.
+// The cell under the cursor is drawn reversed when showCursor is set.
+func (self *lineEditor) draw(buf *Buffer, p image.Point, width int, style Style, showCursor bool) {
+	if width <= 0 {
+		return
+	}
+	self.cursor = MinInt(self.cursor, len(self.runes))
+	if cells, order := ReorderCells(self.cells(style), self.direction); order != nil {
+		self.drawReordered(buf, p, width, style, showCursor, cells, order)
+		return
+	}
+	self.columns = nil
+	self.scroll(width)
+
+	buf.Fill(NewCell(' ', style), image.Rect(p.X, p.Y, p.X+width, p.Y+1))
+	x := 0
+	for i := self.offset; i < len(self.runes); i++ {
+		w := rw.RuneWidth(self.shown(i))
+		if x+w > width {
+			break
+		}
+		cellStyle := style
+		if showCursor && i == self.cursor {
+			cellStyle.Modifier |= ModifierReverse
+		}
+		buf.SetCell(NewCell(self.shown(i), cellStyle), image.Pt(p.X+x, p.Y))
+		x += w
+	}
+	if showCursor && self.cursor == len(self.runes) && x < width {
+		cursorStyle := style
+		cursorStyle.Modifier |= ModifierReverse
+		buf.SetCell(NewCell(' ', cursorStyle), image.Pt(p.X+x, p.Y))
+	}
+}
+
+// drawReordered draws text with right-to-left runes in the order given by ReorderCells, aligned
+// right when its direction is right-to-left. The cursor is drawn on the rune it is before, or on
+// the column past the end of the text, which is on its left in right-to-left text.
+func (self *lineEditor) drawReordered(buf *Buffer, p image.Point, width int, style Style, showCursor bool, cells []Cell, order []int) {
+	// the runes drawn, with the column past the end
+	runes := append([]int{}, order...)
+	cells = append([]Cell{}, cells...)
+	end := NewCell(' ', style)
+	if self.rtl() {
+		runes = append([]int{len(self.runes)}, runes...)
+		cells = append([]Cell{end}, cells...)
+	} else {
+		runes = append(runes, len(self.runes))
+		cells = append(cells, end)
+	}
+
+	xs := make([]int, len(cells))
+	total, cursorX := 0, 0
+	for i, cell := range cells {
+		xs[i] = total
+		if runes[i] == self.cursor {
+			cursorX = total
+		}
+		total += rw.RuneWidth(cell.Rune)
+	}
+
+	// scroll by columns to keep the cursor in view, aligning short right-to-left text right
+	pad := 0
+	if total <= width {
+		self.column = 0
+		if self.rtl() {
+			pad = width - total
+		}
+	} else {
+		self.column = MaxInt(MinInt(self.column, cursorX), cursorX-width+1)
+		self.column = MaxInt(MinInt(self.column, total-width), 0)
+	}
+
+	buf.Fill(NewCell(' ', style), image.Rect(p.X, p.Y, p.X+width, p.Y+1))
+	self.columns = make([]int, width)
+	for x := range self.columns {
+		self.columns[x] = -1
+	}
+	for i, cell := range cells {
+		x := xs[i] - self.column + pad
+		w := rw.RuneWidth(cell.Rune)
+		if x < 0 || x+w > width {
+			continue
+		}
+		if showCursor && runes[i] == self.cursor {
+			cell.Style.Modifier |= ModifierReverse
+		}
+		buf.SetCell(cell, image.Pt(p.X+x, p.Y))
+		for k := x; k < x+w; k++ {
+			self.columns[k] = runes[i]
+		}
+	}
+}
+
+// searchBar is the query typed on the bottom line of the widgets embedding it, which match it
+// against their own items.
+type searchBar struct {
+	searching bool
+	query     lineEditor
+	// linked is set when the query comes from SetSearchQuery, which doesn't show the search bar.
+	linked bool
+}
+
+// Searching reports whether the search bar is accepting input.
+func (self *searchBar) Searching() bool {
+	return self.searching
+}
+
+// SearchQuery returns the query typed in the search bar.
+func (self *searchBar) SearchQuery() string {
+	return self.query.text()
+}
+
+// openSearch opens the search bar with the query it had.
+func (self *searchBar) openSearch() {
+	self.searching = true
+	self.linked = false
+}
+
+// clearSearch closes the search bar and clears its query.
+func (self *searchBar) clearSearch() {
+	self.searching = false
+	self.linked = false
+	self.query.setText("")
+}
+
+// linkSearch applies query without showing the search bar, and calls match.
+func (self *searchBar) linkSearch(query string, match func()) {
+	self.searching = false
+	self.linked = true
+	self.query.setText(query)
+	match()
+}
+
+// searchBarShown reports whether the search bar is drawn on the bottom line.
+func (self *searchBar) searchBarShown() bool {
+	return self.searching || (self.query.text() != "" && !self.linked)
+}
+
+// handleSearchKey applies a keyboard event ID to the search bar while it is open and reports
+// whether it was used. <Enter> closes the bar keeping the query, <Escape> clears it, and other
+// keys edit the query, after which match is called.
+func (self *searchBar) handleSearchKey(id string, match func()) bool {
+	if !self.searching {
+		return false
+	}
+	switch id {
+	case "<Enter>":
+		self.searching = false
+	case "<Escape>":
+		self.clearSearch()
+	default:
+		if !self.query.handleKey(id) {
+			return false
+		}
+		match()
+	}
+	return true
+}
+
+// drawSearchBar draws a slash at p followed by the query, which is given width columns.
+func (self *searchBar) drawSearchBar(buf *Buffer, p image.Point, width int, style Style) {
+	buf.SetCell(NewCell('/', style), p)
+	self.query.draw(buf, p.Add(image.Pt(1, 0)), width, style, self.searching)
+}
@@ -5,7 +5,11 @@
 package widgets
 
 import (
+	"encoding/json"
 	"image"
+	"sort"
+	"strings"
+	"unicode"
 
 	rw "github.com/mattn/go-runewidth"
 
@@ -20,6 +24,93 @@ type List struct {
 	SelectedRow      int
 	topRow           int
 	SelectedRowStyle Style
+
+	// Items, when not nil, are drawn in place of Rows. SecondaryStyle is used for their Secondary text.
+	Items          []ListItem
+	SecondaryStyle Style
+
+	// ScrollbarStyle is used for the scrollbar drawn on the right edge when the rows don't fit.
+	ScrollbarStyle Style
+
+	// FuzzySearch matches the search query against rows as a subsequence, like fzf,
+	// instead of as a substring.
+	FuzzySearch bool
+	// MatchStyle is used for the runes of a row matching the search query.
+	MatchStyle Style
+
+	// OnReachEnd is called when the selection moves onto the last row, to fetch more rows and add them
+	// with AppendRows. Loading is set before the call and shows LoadingText below the rows until
+	// AppendRows is called. Set OnReachEnd to nil once there are no more rows.
+	OnReachEnd  func()
+	Loading     bool
+	LoadingText string
+
+	// Sections group the Rows under headers that can't be selected. A section holds the Rows from its
+	// Start up to the Start of the next section, so Sections are ordered by Start.
+	Sections     []ListSection
+	SectionStyle Style
+
+	// Horizontal lays the rows out left to right on the first line, with arrows marking rows scrolled
+	// out of view. Sections aren't drawn in this mode; ScrollLeft and ScrollRight move the selection.
+	Horizontal bool
+
+	// MultiSelect draws a check box before every row for ToggleSelection to mark it.
+	MultiSelect bool
+
+	// Direction is the base direction of the rows, which are drawn in the order of the Unicode
+	// bidirectional algorithm. Right-to-left rows are aligned right. With DirectionAuto, every
+	// row takes the direction of its first letter.
+	Direction Direction
+
+	// OnReorder is called after rows are moved with MoveUp, MoveDown, or HandleMouseDrag.
+	// order holds, for every position, the index the row there had before the move.
+	OnReorder func(order []int)
+
+	// OnActivate is called with the row double-clicked in HandleMouse.
+	OnActivate func(row int)
+
+	// OnDrop, when set, is called before an item dragged from another widget with a DragDrop is
+	// inserted at row, and rejects it by returning false. OnDragOut is called after the row dragged
+	// into another widget is removed.
+	OnDrop    func(item DragItem, row int) bool
+	OnDragOut func(row int)
+
+	searchBar
+	marked    map[int]bool
+	spans     []listSpan
+	dragRow   int
+	dragOrder []int
+	// dragged is the row dragged out with a DragDrop.
+	dragged   int
+	scrollbar Scrollbar
+}
+
+// listSpan is the area a row or section header was drawn in by the last Draw.
+type listSpan struct {
+	listEntry
+	rect image.Rectangle
+}
+
+// ListItem is a row of a List with an optional leading Icon and Secondary text drawn right-aligned.
+type ListItem struct {
+	Icon      rune
+	Text      string
+	Secondary string
+	// Style is used for the item in place of TextStyle when not nil.
+	Style *Style
+}
+
+// ListSection is a header drawn above the Rows from Start. The Rows of a Collapsed section are hidden.
+type ListSection struct {
+	Title     string
+	Start     int
+	Collapsed bool
+}
+
+// listEntry is a line of the List: a row, or the header of a section when row is -1.
+type listEntry struct {
+	row     int
+	section int
 }
 
 func NewList() *List {
@@ -27,36 +118,387 @@ func NewList() *List {
 		Block:            *NewBlock(),
 		TextStyle:        Theme.List.Text,
 		SelectedRowStyle: Theme.List.Text,
+		MatchStyle:       Theme.List.Match,
+		SectionStyle:     Theme.List.Section,
+		SecondaryStyle:   Theme.List.Secondary,
+		ScrollbarStyle:   Theme.List.Scrollbar,
+		marked:           make(map[int]bool),
+		dragRow:          -1,
+		LoadingText:      "Loading" + string(ELLIPSES),
+	}
+}
+
+// ToggleSection collapses or expands the section at the given index of Sections.
+func (self *List) ToggleSection(section int) {
+	if section >= 0 && section < len(self.Sections) {
+		self.Sections[section].Collapsed = !self.Sections[section].Collapsed
+	}
+}
+
+// CollapseAllSections hides the Rows of every section.
+func (self *List) CollapseAllSections() {
+	for i := range self.Sections {
+		self.Sections[i].Collapsed = true
+	}
+}
+
+// ExpandAllSections shows the Rows of every section.
+func (self *List) ExpandAllSections() {
+	for i := range self.Sections {
+		self.Sections[i].Collapsed = false
+	}
+}
+
+// SelectedSection returns the index of the section holding SelectedRow, or -1 if it isn't in one.
+func (self *List) SelectedSection() int {
+	return self.sectionOf(self.SelectedRow)
+}
+
+// sectionOf returns the index of the section holding row, or -1 if it isn't in one.
+func (self *List) sectionOf(row int) int {
+	section := -1
+	for i, s := range self.Sections {
+		if s.Start <= row {
+			section = i
+		}
+	}
+	return section
+}
+
+// AppendRows adds rows to the end of the List and clears Loading.
+// Rows fetched in another goroutine should be appended inside termui.Update.
+func (self *List) AppendRows(rows ...string) {
+	if self.Items != nil {
+		for _, row := range rows {
+			self.Items = append(self.Items, ListItem{Text: row})
+		}
+	} else {
+		self.Rows = append(self.Rows, rows...)
+	}
+	self.Loading = false
+}
+
+// AppendItems adds items to the end of Items and clears Loading.
+func (self *List) AppendItems(items ...ListItem) {
+	self.Items = append(self.Items, items...)
+	self.Loading = false
+}
+
+// rowCount returns the number of Items, or of Rows when there are no Items.
+func (self *List) rowCount() int {
+	if self.Items != nil {
+		return len(self.Items)
+	}
+	return len(self.Rows)
+}
+
+// item returns the row at the given index as a ListItem.
+func (self *List) item(i int) ListItem {
+	if self.Items != nil {
+		return self.Items[i]
+	}
+	return ListItem{Text: self.Rows[i]}
+}
+
+// itemStyle returns the Style of the text of an item.
+func (self *List) itemStyle(item ListItem) Style {
+	if item.Style != nil {
+		return *item.Style
+	}
+	return self.TextStyle
+}
+
+// checkEnd calls OnReachEnd when the last row is selected and no rows are being loaded.
+// Rows hidden by the search don't count as the end of the List.
+func (self *List) checkEnd() {
+	if self.OnReachEnd == nil || self.Loading || self.query.text() != "" {
+		return
+	}
+	if self.rowCount() == 0 || self.SelectedRow >= self.rowCount()-1 {
+		self.Loading = true
+		self.OnReachEnd()
+	}
+}
+
+// ToggleSelection marks or unmarks SelectedRow.
+func (self *List) ToggleSelection() {
+	if self.SelectedRow < 0 || self.SelectedRow >= self.rowCount() {
+		return
+	}
+	if self.marked[self.SelectedRow] {
+		delete(self.marked, self.SelectedRow)
+	} else {
+		self.marked[self.SelectedRow] = true
+	}
+}
+
+// SelectAll marks every row shown, leaving out rows hidden by the search.
+func (self *List) SelectAll() {
+	for _, row := range self.visibleRows() {
+		self.marked[row] = true
+	}
+}
+
+// ClearSelection unmarks every row.
+func (self *List) ClearSelection() {
+	self.marked = make(map[int]bool)
+}
+
+// Selected returns the indexes of the marked Rows in ascending order.
+func (self *List) Selected() []int {
+	rows := []int{}
+	for row, marked := range self.marked {
+		if marked && row < self.rowCount() {
+			rows = append(rows, row)
+		}
+	}
+	sort.Ints(rows)
+	return rows
+}
+
+// StartSearch opens the search bar on the bottom line. While it is open, HandleSearchKey edits the query,
+// and only rows matching the query are shown. SelectedRow keeps indexing Rows while they are filtered.
+func (self *List) StartSearch() {
+	self.openSearch()
+}
+
+// ClearSearch closes the search bar and shows the rows it was hiding.
+func (self *List) ClearSearch() {
+	self.clearSearch()
+}
+
+// SetSearchQuery applies query like one typed in the search bar, without showing the bar, for a
+// SearchBar bound to the List.
+func (self *List) SetSearchQuery(query string) {
+	self.linkSearch(query, self.selectFirstMatch)
+}
+
+// SearchMatches returns the number of rows matching the search query.
+func (self *List) SearchMatches() int {
+	return len(self.visibleRows())
+}
+
+// HandleSearchKey applies a keyboard event ID to the search bar and reports whether it was used.
+// <Enter> closes the bar keeping the query applied, <Escape> clears it.
+// <Up> and <Down> move the selection while typing.
+func (self *List) HandleSearchKey(id string) bool {
+	if !self.searching {
+		return false
+	}
+	switch id {
+	case "<Up>", "<C-p>":
+		self.ScrollUp()
+	case "<Down>", "<C-n>":
+		self.ScrollDown()
+	default:
+		return self.handleSearchKey(id, self.selectFirstMatch)
+	}
+	return true
+}
+
+// selectFirstMatch selects the best match as the query changes.
+func (self *List) selectFirstMatch() {
+	if rows := self.visibleRows(); len(rows) > 0 {
+		self.SelectedRow = rows[0]
+	}
+	self.topRow = 0
+}
+
+// entries returns the lines of the List in order: the rows matching the search and the headers
+// of their sections. Rows of collapsed sections are left out, and so are the headers of sections
+// without matches while searching.
+func (self *List) entries() []listEntry {
+	entries := make([]listEntry, 0, self.rowCount()+len(self.Sections))
+	query := self.query.text()
+	section := -1
+	for i := 0; i < self.rowCount(); i++ {
+		item := self.item(i)
+		for section+1 < len(self.Sections) && self.Sections[section+1].Start <= i {
+			section++
+			if query == "" {
+				entries = append(entries, listEntry{-1, section})
+			}
+		}
+		if section >= 0 && self.Sections[section].Collapsed {
+			continue
+		}
+		if query == "" || matchRunes(CellsToString(ParseStyles(item.Text, self.TextStyle)), query, self.FuzzySearch) != nil {
+			if query != "" && section >= 0 && (len(entries) == 0 || entries[len(entries)-1].section != section) {
+				entries = append(entries, listEntry{-1, section})
+			}
+			entries = append(entries, listEntry{i, section})
+		}
+	}
+	// headers of sections starting after the last row
+	for section+1 < len(self.Sections) && query == "" {
+		section++
+		entries = append(entries, listEntry{-1, section})
+	}
+	return entries
+}
+
+// visibleRows returns the indexes of the Rows drawn, in order, leaving out rows that don't match the search
+// and rows of collapsed sections.
+func (self *List) visibleRows() []int {
+	rows := []int{}
+	for _, entry := range self.entries() {
+		if entry.row >= 0 {
+			rows = append(rows, entry.row)
+		}
+	}
+	return rows
+}
+
+// position returns the position of SelectedRow in rows,
+// or of the nearest row after it when it is hidden.
+func (self *List) position(rows []int) int {
+	for i, row := range rows {
+		if row >= self.SelectedRow {
+			return i
+		}
+	}
+	return MaxInt(len(rows)-1, 0)
+}
+
+// visibleLines returns the number of lines rows are drawn on.
+func (self *List) visibleLines() int {
+	if self.searchBarShown() {
+		return MaxInt(self.Inner.Dy()-1, 1)
+	}
+	return self.Inner.Dy()
+}
+
+// matchRunes returns the positions of the runes of text matching query, case-insensitively,
+// or nil if it doesn't match. Every occurrence is returned for a substring match; a fuzzy match
+// returns the first runes of text spelling out query in order.
+func matchRunes(text string, query string, fuzzy bool) []int {
+	runes := []rune(strings.ToLower(text))
+	needle := []rune(strings.ToLower(query))
+	positions := []int{}
+	if fuzzy {
+		j := 0
+		for i := 0; i < len(runes) && j < len(needle); i++ {
+			if runes[i] == needle[j] || (unicode.IsSpace(needle[j]) && unicode.IsSpace(runes[i])) {
+				positions = append(positions, i)
+				j++
+			}
+		}
+		if j < len(needle) {
+			return nil
+		}
+		return positions
+	}
+	for i := 0; i+len(needle) <= len(runes); i++ {
+		if string(runes[i:i+len(needle)]) == string(needle) {
+			for k := i; k < i+len(needle); k++ {
+				positions = append(positions, k)
+			}
+			i += MaxInt(len(needle)-1, 0)
+		}
 	}
+	if len(positions) == 0 && len(needle) > 0 {
+		return nil
+	}
+	return positions
 }
 
 func (self *List) Draw(buf *Buffer) {
 	self.Block.Draw(buf)
+	self.spans = self.spans[:0]
+
+	if self.Horizontal {
+		self.drawHorizontal(buf)
+		return
+	}
 
 	point := self.Inner.Min
+	maxY := self.Inner.Min.Y + self.visibleLines()
 
-	// adjusts view into widget
-	if self.SelectedRow >= self.Inner.Dy()+self.topRow {
-		self.topRow = self.SelectedRow - self.Inner.Dy() + 1
-	} else if self.SelectedRow < self.topRow {
-		self.topRow = self.SelectedRow
+	entries := self.entries()
+	rows := self.visibleRows()
+	if len(rows) > 0 {
+		self.SelectedRow = rows[self.position(rows)]
+	}
+	selected := 0
+	for i, entry := range entries {
+		if entry.row == self.SelectedRow {
+			selected = i
+		}
+	}
+
+	self.topRow = MaxInt(MinInt(self.topRow, len(entries)-1), 0)
+
+	// adjusts view into widget, keeping the loading row in view below the last row
+	anchor := selected
+	if self.Loading && selected == len(entries)-1 {
+		anchor++
+	}
+	if anchor >= self.visibleLines()+self.topRow {
+		self.topRow = anchor - self.visibleLines() + 1
+	} else if selected < self.topRow {
+		self.topRow = selected
+	}
+	// keep the header of the first row of a section in view
+	if selected == self.topRow && selected > 0 && entries[selected-1].row < 0 && self.visibleLines() > 1 {
+		self.topRow--
 	}
 
 	// draw rows
-	for row := self.topRow; row < len(self.Rows) && point.Y < self.Inner.Max.Y; row++ {
-		cells := ParseStyles(self.Rows[row], self.TextStyle)
+	for i := self.topRow; i < len(entries) && point.Y < maxY; i++ {
+		row := entries[i].row
+		startY := point.Y
+		if row < 0 {
+			self.drawSectionHeader(buf, self.Sections[entries[i].section], point)
+			point = image.Pt(self.Inner.Min.X, point.Y+1)
+			self.spans = append(self.spans, listSpan{entries[i], image.Rect(self.Inner.Min.X, startY, self.Inner.Max.X, point.Y)})
+			continue
+		}
+		item := self.item(row)
+		itemStyle := self.itemStyle(item)
+		cells := ParseStyles(item.Text, itemStyle)
+		if query := self.query.text(); query != "" {
+			for _, k := range matchRunes(CellsToString(cells), query, self.FuzzySearch) {
+				cells[k].Style = self.MatchStyle
+			}
+		}
+		if item.Icon != 0 {
+			cells = append([]Cell{NewCell(item.Icon, itemStyle), NewCell(' ', itemStyle)}, cells...)
+		}
+		if self.MultiSelect {
+			check := Theme.List.Unchecked
+			if self.marked[row] {
+				check = Theme.List.Checked
+			}
+			cells = append([]Cell{NewCell(check, self.TextStyle), NewCell(' ', self.TextStyle)}, cells...)
+		}
+
+		// the secondary text takes the right end of the first line
+		width := self.Inner.Dx()
+		if item.Secondary != "" {
+			secondaryStyle := self.SecondaryStyle
+			if row == self.SelectedRow {
+				secondaryStyle = self.SelectedRowStyle
+			}
+			secondary := TrimString(item.Secondary, MaxInt(self.Inner.Dx()/2, 1))
+			secondaryWidth := rw.StringWidth(secondary)
+			buf.SetString(secondary, secondaryStyle, image.Pt(self.Inner.Max.X-secondaryWidth, point.Y))
+			width = MaxInt(width-secondaryWidth-1, 1)
+		}
+		maxX := self.Inner.Min.X + width
+
 		if self.WrapText {
-			cells = WrapCells(cells, uint(self.Inner.Dx()))
+			cells = WrapCells(cells, uint(width))
 		}
-		for j := 0; j < len(cells) && point.Y < self.Inner.Max.Y; j++ {
+		cells = self.reorderRow(cells, width)
+		for j := 0; j < len(cells) && point.Y < maxY; j++ {
 			style := cells[j].Style
-			if row == self.SelectedRow {
+			if row == self.SelectedRow && style != self.MatchStyle {
 				style = self.SelectedRowStyle
 			}
 			if cells[j].Rune == '\n' {
 				point = image.Pt(self.Inner.Min.X, point.Y+1)
 			} else {
-				if point.X+1 == self.Inner.Max.X+1 && len(cells) > self.Inner.Dx() {
+				if point.X == maxX && len(cells) > width {
 					buf.SetCell(NewCell(ELLIPSES, style), point.Add(image.Pt(-1, 0)))
 					break
 				} else {
@@ -66,36 +508,522 @@ func (self *List) Draw(buf *Buffer) {
 			}
 		}
 		point = image.Pt(self.Inner.Min.X, point.Y+1)
+		self.spans = append(self.spans, listSpan{entries[i], image.Rect(self.Inner.Min.X, startY, self.Inner.Max.X, MinInt(point.Y, maxY))})
+	}
+
+	// draw loading row
+	if self.Loading && point.Y < maxY {
+		buf.SetString(TrimString(self.LoadingText, self.Inner.Dx()), self.TextStyle, point)
+	}
+
+	// draw search bar
+	if self.searchBarShown() {
+		self.drawSearchBar(buf, image.Pt(self.Inner.Min.X, self.Inner.Max.Y-1), self.Inner.Dx()-1, self.TextStyle)
+	}
+
+	// draw the scrollbar, counting the loading row
+	shownRows := len(entries)
+	if self.Loading {
+		shownRows++
+	}
+	self.scrollbar.Style = self.ScrollbarStyle
+	self.scrollbar.Draw(buf, image.Rect(self.Inner.Max.X-1, self.Inner.Min.Y, self.Inner.Max.X, maxY),
+		self.topRow, self.visibleLines(), shownRows)
+}
+
+// Accessibility implements Accessible with the Title and the text of SelectedRow.
+func (self *List) Accessibility() (string, bool, string) {
+	if self.rowCount() == 0 {
+		return self.Title, false, ""
+	}
+	row := MaxInt(MinInt(self.SelectedRow, self.rowCount()-1), 0)
+	return self.Title, false, CellsToString(ParseStyles(self.item(row).Text, StyleClear))
+}
+
+// listState is the state of a List saved by SaveState.
+type listState struct {
+	SelectedRow int   `json:"selectedRow"`
+	TopRow      int   `json:"topRow"`
+	Marked      []int `json:"marked,omitempty"`
+}
+
+// SaveState implements Persistent with SelectedRow, the scroll position, and the rows marked with
+// ToggleSelection.
+func (self *List) SaveState() ([]byte, error) {
+	return json.Marshal(listState{self.SelectedRow, self.topRow, self.Selected()})
+}
+
+// RestoreState implements Persistent. Rows past the end of the List are left out.
+func (self *List) RestoreState(data []byte) error {
+	state := listState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	count := self.rowCount()
+	self.SelectedRow = MaxInt(MinInt(state.SelectedRow, count-1), 0)
+	self.topRow = MaxInt(MinInt(state.TopRow, count-1), 0)
+	self.marked = make(map[int]bool)
+	for _, row := range state.Marked {
+		if row >= 0 && row < count {
+			self.marked[row] = true
+		}
+	}
+	return nil
+}
+
+// reorderRow returns the lines of a row in the order they are drawn in, with its right-to-left
+// lines trimmed to width and padded to be aligned right. Rows without right-to-left text are
+// returned as they are.
+func (self *List) reorderRow(cells []Cell, width int) []Cell {
+	lines := SplitCells(cells, '\n')
+	direction := ResolveDirection(cells, self.Direction)
+	reordered := make([]Cell, 0, len(cells))
+	changed := false
+	for i, line := range lines {
+		line, order, alignment := reorderLine(line, width, direction, AlignLeft)
+		if order != nil {
+			changed = true
+			line, _ = alignLine(line, width, alignment, true)
+		}
+		if i > 0 {
+			reordered = append(reordered, NewCell('\n'))
+		}
+		reordered = append(reordered, line...)
+	}
+	if !changed {
+		return cells
+	}
+	return reordered
+}
+
+// horizontalGap is the number of columns between rows in Horizontal mode.
+const horizontalGap = 2
+
+// rowCells returns the cells of a row in Horizontal mode: its check box, icon, and text.
+func (self *List) rowCells(row int) []Cell {
+	item := self.item(row)
+	style := self.itemStyle(item)
+	if row == self.SelectedRow {
+		style = self.SelectedRowStyle
+	}
+	cells := ParseStyles(item.Text, style)
+	if row == self.SelectedRow {
+		for i := range cells {
+			cells[i].Style = style
+		}
+	}
+	if query := self.query.text(); query != "" {
+		for _, k := range matchRunes(CellsToString(cells), query, self.FuzzySearch) {
+			cells[k].Style = self.MatchStyle
+		}
+	}
+	if item.Icon != 0 {
+		cells = append([]Cell{NewCell(item.Icon, style), NewCell(' ', style)}, cells...)
+	}
+	if self.MultiSelect {
+		check := Theme.List.Unchecked
+		if self.marked[row] {
+			check = Theme.List.Checked
+		}
+		cells = append([]Cell{NewCell(check, style), NewCell(' ', style)}, cells...)
+	}
+	cells, _, _ = reorderLine(cells, self.Inner.Dx(), self.Direction, AlignLeft)
+	return TrimCells(cells, self.Inner.Dx())
+}
+
+// drawHorizontal draws the rows left to right, scrolled so that SelectedRow is in view.
+func (self *List) drawHorizontal(buf *Buffer) {
+	rows := self.visibleRows()
+	if len(rows) == 0 {
+		return
+	}
+	selected := self.position(rows)
+	self.SelectedRow = rows[selected]
+
+	widths := make([]int, len(rows))
+	total := 0
+	for i, row := range rows {
+		widths[i] = rw.StringWidth(CellsToString(self.rowCells(row)))
+		total += widths[i] + horizontalGap
+	}
+	total -= horizontalGap
+
+	// leave room for the arrows when the rows don't fit
+	minX, maxX := self.Inner.Min.X, self.Inner.Max.X
+	if total > self.Inner.Dx() && self.Inner.Dx() > 2 {
+		minX, maxX = minX+1, maxX-1
+	}
+
+	// adjusts view into widget
+	if selected < self.topRow {
+		self.topRow = selected
+	}
+	for self.topRow < selected {
+		width := -horizontalGap
+		for i := self.topRow; i <= selected; i++ {
+			width += widths[i] + horizontalGap
+		}
+		if width <= maxX-minX {
+			break
+		}
+		self.topRow++
+	}
+	self.topRow = MinInt(self.topRow, len(rows)-1)
+
+	x := minX
+	i := self.topRow
+	for ; i < len(rows) && x < maxX; i++ {
+		for _, cx := range BuildCellWithXArray(self.rowCells(rows[i])) {
+			if x+cx.X >= maxX {
+				break
+			}
+			buf.SetCell(cx.Cell, image.Pt(x+cx.X, self.Inner.Min.Y))
+		}
+		span := image.Rect(x, self.Inner.Min.Y, MinInt(x+widths[i], maxX), self.Inner.Min.Y+1)
+		self.spans = append(self.spans, listSpan{listEntry{rows[i], self.sectionOf(rows[i])}, span})
+		x += widths[i] + horizontalGap
 	}
 
-	// draw UP_ARROW if needed
 	if self.topRow > 0 {
-		buf.SetCell(
-			NewCell(UP_ARROW, NewStyle(ColorWhite)),
-			image.Pt(self.Inner.Max.X-1, self.Inner.Min.Y),
-		)
+		buf.SetCell(NewCell(QUOTA_LEFT, NewStyle(ColorWhite)), image.Pt(self.Inner.Min.X, self.Inner.Min.Y))
+	}
+	if i < len(rows) || x-horizontalGap > maxX {
+		buf.SetCell(NewCell(QUOTA_RIGHT, NewStyle(ColorWhite)), image.Pt(self.Inner.Max.X-1, self.Inner.Min.Y))
+	}
+}
+
+// entryAt returns the row or section header drawn at p by the last Draw.
+func (self *List) entryAt(p image.Point) (listEntry, bool) {
+	for _, span := range self.spans {
+		if p.In(span.rect) {
+			return span.listEntry, true
+		}
+	}
+	return listEntry{}, false
+}
+
+// MoveUp moves SelectedRow above the row before it and keeps it selected.
+func (self *List) MoveUp() {
+	self.moveSelected(-1)
+}
+
+// MoveDown moves SelectedRow below the row after it and keeps it selected.
+func (self *List) MoveDown() {
+	self.moveSelected(1)
+}
+
+// moveSelected moves SelectedRow to the position of the row shown amount rows away from it.
+func (self *List) moveSelected(amount int) {
+	rows := self.visibleRows()
+	if len(rows) == 0 {
+		return
+	}
+	selected := self.position(rows)
+	target := rows[MaxInt(MinInt(selected+amount, len(rows)-1), 0)]
+	if target == self.SelectedRow {
+		return
+	}
+	order := self.moveRow(self.SelectedRow, target, nil)
+	if self.OnReorder != nil {
+		self.OnReorder(order)
+	}
+}
+
+// moveRow moves the row at from to the index to, carrying its marked state, and selects it.
+// It returns order with the same move applied, starting from the current order when order is nil.
+func (self *List) moveRow(from, to int, order []int) []int {
+	if order == nil {
+		order = make([]int, self.rowCount())
+		for i := range order {
+			order[i] = i
+		}
+	}
+	moved := order[from]
+	order = append(order[:from], order[from+1:]...)
+	order = append(order[:to], append([]int{moved}, order[to:]...)...)
+
+	if self.Items != nil {
+		item := self.Items[from]
+		self.Items = append(self.Items[:from], self.Items[from+1:]...)
+		self.Items = append(self.Items[:to], append([]ListItem{item}, self.Items[to:]...)...)
+	} else {
+		row := self.Rows[from]
+		self.Rows = append(self.Rows[:from], self.Rows[from+1:]...)
+		self.Rows = append(self.Rows[:to], append([]string{row}, self.Rows[to:]...)...)
 	}
 
-	// draw DOWN_ARROW if needed
-	if len(self.Rows) > int(self.topRow)+self.Inner.Dy() {
-		buf.SetCell(
-			NewCell(DOWN_ARROW, NewStyle(ColorWhite)),
-			image.Pt(self.Inner.Max.X-1, self.Inner.Max.Y-1),
-		)
+	marked := make(map[int]bool, len(self.marked))
+	for i, ok := range self.marked {
+		switch {
+		case i == from:
+			i = to
+		case from < to && i > from && i <= to:
+			i--
+		case to < from && i >= to && i < from:
+			i++
+		}
+		marked[i] = ok
 	}
+	self.marked = marked
+	self.SelectedRow = to
+	return order
+}
+
+// HandleReorderKey moves SelectedRow for <M-<Up>> and <M-<Down>>, or <M-k> and <M-j>,
+// and reports whether the keyboard event ID was used.
+func (self *List) HandleReorderKey(id string) bool {
+	switch id {
+	case "<M-<Up>>", "<M-k>", "<M-<Left>>":
+		self.MoveUp()
+	case "<M-<Down>>", "<M-j>", "<M-<Right>>":
+		self.MoveDown()
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouseDrag moves a row by dragging it with the left mouse button and reports whether the
+// event moved the row or finished moving it. Pressing the button isn't reported as used so that
+// it can still select the row. OnReorder is called once the button is released.
+func (self *List) HandleMouseDrag(e Event) bool {
+	m, ok := e.Payload.(Mouse)
+	if !ok || self.scrollbar.Dragging() || self.scrollbar.Contains(image.Pt(m.X, m.Y)) {
+		return false
+	}
+	switch {
+	case e.ID == "<MouseRelease>":
+		if self.dragRow < 0 {
+			return false
+		}
+		self.dragRow = -1
+		order := self.dragOrder
+		self.dragOrder = nil
+		if order == nil {
+			return false
+		}
+		if self.OnReorder != nil {
+			self.OnReorder(order)
+		}
+		return true
+	case e.ID != "<MouseLeft>":
+		return false
+	}
+	entry, ok := self.entryAt(image.Pt(m.X, m.Y))
+	if !m.Drag || self.dragRow < 0 {
+		if !m.Drag && ok && entry.row >= 0 {
+			self.dragRow = entry.row
+		}
+		return false
+	}
+	if ok && entry.row >= 0 && entry.row != self.dragRow {
+		self.dragOrder = self.moveRow(self.dragRow, entry.row, self.dragOrder)
+		self.dragRow = entry.row
+	}
+	return true
+}
+
+// DragStart implements DragSource, dragging the row at p as a ListItem.
+func (self *List) DragStart(p image.Point) (DragItem, bool) {
+	entry, ok := self.entryAt(p)
+	if !ok || entry.row < 0 {
+		return DragItem{}, false
+	}
+	self.dragged = entry.row
+	item := self.item(entry.row)
+	return DragItem{Text: item.Text, Value: item}, true
+}
+
+// DragEnd implements DragSource, removing the row dragged into another widget.
+func (self *List) DragEnd(item DragItem, target DropTarget) {
+	if target == nil || target == DropTarget(self) || self.dragged >= self.rowCount() {
+		return
+	}
+	self.removeRow(self.dragged)
+	if self.OnDragOut != nil {
+		self.OnDragOut(self.dragged)
+	}
+}
+
+// Drop implements DropTarget, inserting the item at the row it is released on, or after the last
+// row below them. The rows of the List itself are moved there, calling OnReorder, and the items of
+// other widgets are inserted as a ListItem, or a row with their Text when they aren't one.
+func (self *List) Drop(item DragItem, p image.Point) bool {
+	row := self.rowCount()
+	if entry, ok := self.entryAt(p); ok && entry.row >= 0 {
+		row = entry.row
+	} else if ok {
+		row = self.Sections[entry.section].Start
+	}
+	if item.Source == DragSource(self) {
+		row = MinInt(row, self.rowCount()-1)
+		if row != self.dragged {
+			order := self.moveRow(self.dragged, row, nil)
+			if self.OnReorder != nil {
+				self.OnReorder(order)
+			}
+		}
+		return true
+	}
+	if self.OnDrop != nil && !self.OnDrop(item, row) {
+		return false
+	}
+	listItem, ok := item.Value.(ListItem)
+	if !ok {
+		listItem = ListItem{Text: item.Text}
+	}
+	self.insertRow(row, listItem)
+	return true
+}
+
+// insertRow inserts an item at index i, shifting the marked rows and sections after it, and selects it.
+func (self *List) insertRow(i int, item ListItem) {
+	if self.Items != nil {
+		self.Items = append(self.Items[:i], append([]ListItem{item}, self.Items[i:]...)...)
+	} else {
+		self.Rows = append(self.Rows[:i], append([]string{item.Text}, self.Rows[i:]...)...)
+	}
+	self.shiftRows(i, 1)
+	self.SelectedRow = i
+}
+
+// removeRow removes the row at index i, shifting the marked rows and sections after it.
+func (self *List) removeRow(i int) {
+	if self.Items != nil {
+		self.Items = append(self.Items[:i], self.Items[i+1:]...)
+	} else {
+		self.Rows = append(self.Rows[:i], self.Rows[i+1:]...)
+	}
+	delete(self.marked, i)
+	self.shiftRows(i, -1)
+	if self.SelectedRow > i {
+		self.SelectedRow--
+	}
+	self.SelectedRow = MaxInt(MinInt(self.SelectedRow, self.rowCount()-1), 0)
+}
+
+// shiftRows moves the marked rows and the sections after index i by amount.
+func (self *List) shiftRows(i, amount int) {
+	marked := make(map[int]bool, len(self.marked))
+	for row, ok := range self.marked {
+		if row > i || (amount > 0 && row == i) {
+			row += amount
+		}
+		marked[row] = ok
+	}
+	self.marked = marked
+	for k := range self.Sections {
+		if self.Sections[k].Start > i {
+			self.Sections[k].Start += amount
+		}
+	}
+}
+
+// HandleMouse selects the row clicked with the left mouse button, calls OnActivate for a double-click,
+// toggles a section when its header is clicked, and scrolls with the mouse wheel and the scrollbar.
+// It reports whether the event was used.
+func (self *List) HandleMouse(e Event) bool {
+	if offset, ok := self.scrollbar.HandleMouse(e); ok {
+		self.scrollTo(offset)
+		return true
+	}
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Rectangle) {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.ScrollAmount(-WheelSteps(e))
+	case "<MouseWheelDown>":
+		self.ScrollAmount(WheelSteps(e))
+	case "<MouseLeft>":
+		entry, ok := self.entryAt(p)
+		if !ok || e.Payload.(Mouse).Drag {
+			return false
+		}
+		if entry.row < 0 {
+			self.ToggleSection(entry.section)
+			return true
+		}
+		if e.Payload.(Mouse).Clicks == 2 && entry.row == self.SelectedRow {
+			if self.OnActivate != nil {
+				self.OnActivate(entry.row)
+			}
+			return true
+		}
+		self.SelectedRow = entry.row
+		self.checkEnd()
+	default:
+		return false
+	}
+	return true
+}
+
+// drawSectionHeader draws the title of a section with a sign telling whether it is collapsed.
+func (self *List) drawSectionHeader(buf *Buffer, section ListSection, point image.Point) {
+	sign := Theme.List.Expanded
+	if section.Collapsed {
+		sign = Theme.List.Collapsed
+	}
+	title := string(sign) + " " + section.Title
+	buf.SetString(TrimString(title, self.Inner.Dx()), self.SectionStyle, point)
+}
+
+// scrollTo shows the rows from the entry at offset, like the scrollbar dragged there, selecting the
+// nearest row in view when the selected one is scrolled out of it.
+func (self *List) scrollTo(offset int) {
+	entries := self.entries()
+	if len(entries) == 0 {
+		return
+	}
+	self.topRow = MaxInt(MinInt(offset, len(entries)-1), 0)
+	last := MinInt(self.topRow+self.visibleLines(), len(entries)) - 1
+	selected := -1
+	for i, entry := range entries {
+		if entry.row == self.SelectedRow {
+			selected = i
+		}
+	}
+	if selected >= self.topRow && selected <= last {
+		return
+	}
+	if selected < self.topRow {
+		for i := self.topRow; i <= last; i++ {
+			if entries[i].row >= 0 {
+				self.SelectedRow = entries[i].row
+				break
+			}
+		}
+	} else {
+		for i := last; i >= self.topRow; i-- {
+			if entries[i].row >= 0 {
+				self.SelectedRow = entries[i].row
+				break
+			}
+		}
+	}
+	self.checkEnd()
 }
 
 // ScrollAmount scrolls by amount given. If amount is < 0, then scroll up.
 // There is no need to set self.topRow, as this will be set automatically when drawn,
 // since if the selected item is off screen then the topRow variable will change accordingly.
+// While searching, only the rows matching the query are scrolled through.
 func (self *List) ScrollAmount(amount int) {
-	if len(self.Rows)-int(self.SelectedRow) <= amount {
-		self.SelectedRow = len(self.Rows) - 1
-	} else if int(self.SelectedRow)+amount < 0 {
-		self.SelectedRow = 0
+	rows := self.visibleRows()
+	if len(rows) == 0 {
+		return
+	}
+	selected := self.position(rows)
+	if len(rows)-selected <= amount {
+		selected = len(rows) - 1
+	} else if selected+amount < 0 {
+		selected = 0
 	} else {
-		self.SelectedRow += amount
+		selected += amount
 	}
+	self.SelectedRow = rows[selected]
+	self.checkEnd()
 }
 
 func (self *List) ScrollUp() {
@@ -106,31 +1034,51 @@ func (self *List) ScrollDown() {
 	self.ScrollAmount(1)
 }
 
+// ScrollLeft selects the previous row. It is the same as ScrollUp, for Horizontal mode.
+func (self *List) ScrollLeft() {
+	self.ScrollAmount(-1)
+}
+
+// ScrollRight selects the next row. It is the same as ScrollDown, for Horizontal mode.
+func (self *List) ScrollRight() {
+	self.ScrollAmount(1)
+}
+
 func (self *List) ScrollPageUp() {
 	// If an item is selected below top row, then go to the top row.
-	if self.SelectedRow > self.topRow {
-		self.SelectedRow = self.topRow
+	entries := self.entries()
+	top := -1
+	for i := self.topRow; i < len(entries) && top < 0; i++ {
+		top = entries[i].row
+	}
+	if top >= 0 && self.SelectedRow > top {
+		self.SelectedRow = top
 	} else {
-		self.ScrollAmount(-self.Inner.Dy())
+		self.ScrollAmount(-self.visibleLines())
 	}
 }
 
 func (self *List) ScrollPageDown() {
-	self.ScrollAmount(self.Inner.Dy())
+	self.ScrollAmount(self.visibleLines())
 }
 
 func (self *List) ScrollHalfPageUp() {
-	self.ScrollAmount(-int(FloorFloat64(float64(self.Inner.Dy()) / 2)))
+	self.ScrollAmount(-int(FloorFloat64(float64(self.visibleLines()) / 2)))
 }
 
 func (self *List) ScrollHalfPageDown() {
-	self.ScrollAmount(int(FloorFloat64(float64(self.Inner.Dy()) / 2)))
+	self.ScrollAmount(int(FloorFloat64(float64(self.visibleLines()) / 2)))
 }
 
 func (self *List) ScrollTop() {
-	self.SelectedRow = 0
+	if rows := self.visibleRows(); len(rows) > 0 {
+		self.SelectedRow = rows[0]
+	}
 }
 
 func (self *List) ScrollBottom() {
-	self.SelectedRow = len(self.Rows) - 1
+	if rows := self.visibleRows(); len(rows) > 0 {
+		self.SelectedRow = rows[len(rows)-1]
+	}
+	self.checkEnd()
 }
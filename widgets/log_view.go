@@ -0,0 +1,520 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// LogLevel is the severity of a LogEntry, which sets its color in a LogView.
+type LogLevel uint
+
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarning
+	LogError
+)
+
+func (self LogLevel) String() string {
+	switch self {
+	case LogDebug:
+		return "DEBUG"
+	case LogWarning:
+		return "WARN"
+	case LogError:
+		return "ERROR"
+	}
+	return "INFO"
+}
+
+// LogEntry is a line of a LogView. Fields are drawn after the Message as key=value pairs sorted by key.
+type LogEntry struct {
+	Time    time.Time
+	Level   LogLevel
+	Message string
+	Fields  map[string]interface{}
+}
+
+// LogView shows the last Capacity LogEntries, one per line, colored by level. Levels can be hidden
+// with SetLevelVisible, and entries matching the query set with Search are highlighted.
+// Follow keeps the newest entry in view; scrolling up suspends it until the view is scrolled back
+// to the end.
+type LogView struct {
+	Block
+	// Capacity is the number of entries kept, dropping the oldest ones.
+	Capacity int
+
+	TextStyle   Style
+	TimeStyle   Style
+	FieldStyle  Style
+	MatchStyle  Style
+	LevelStyles map[LogLevel]Style
+
+	// ShowTime draws the Time of the entries with TimeFormat.
+	ShowTime   bool
+	TimeFormat string
+	Follow     bool
+
+	ScrollbarStyle Style
+
+	// entries is a ring buffer of length Capacity holding count entries from start.
+	entries []LogEntry
+	start   int
+	count   int
+
+	hidden map[LogLevel]bool
+	searchBar
+
+	topLine   int
+	following bool
+	// match is the index, among the shown entries, of the match NextMatch and PreviousMatch moved to.
+	match int
+}
+
+func NewLogView() *LogView {
+	return &LogView{
+		Block:      *NewBlock(),
+		Capacity:   1000,
+		TextStyle:  Theme.LogView.Text,
+		TimeStyle:  Theme.LogView.Time,
+		FieldStyle: Theme.LogView.Field,
+		MatchStyle: Theme.LogView.Match,
+		LevelStyles: map[LogLevel]Style{
+			LogDebug:   Theme.LogView.Debug,
+			LogInfo:    Theme.LogView.Info,
+			LogWarning: Theme.LogView.Warning,
+			LogError:   Theme.LogView.Error,
+		},
+		ShowTime:       true,
+		TimeFormat:     "15:04:05",
+		Follow:         true,
+		ScrollbarStyle: Theme.Paragraph.Scrollbar,
+		hidden:         make(map[LogLevel]bool),
+		following:      true,
+		match:          -1,
+	}
+}
+
+// Add appends entries, dropping the oldest ones beyond Capacity. A zero Time is set to the current time.
+func (self *LogView) Add(entries ...LogEntry) {
+	if self.Capacity <= 0 {
+		return
+	}
+	if len(self.entries) != self.Capacity {
+		self.resize()
+	}
+	for _, entry := range entries {
+		if entry.Time.IsZero() {
+			entry.Time = time.Now()
+		}
+		if self.count < self.Capacity {
+			self.entries[(self.start+self.count)%self.Capacity] = entry
+			self.count++
+			continue
+		}
+		// overwrite the oldest entry, keeping the view on the same entries when not following
+		if !self.hidden[self.entries[self.start].Level] {
+			self.topLine = MaxInt(self.topLine-1, 0)
+			self.match = MaxInt(self.match-1, -1)
+		}
+		self.entries[self.start] = entry
+		self.start = (self.start + 1) % self.Capacity
+	}
+}
+
+// resize moves the entries into a ring buffer of length Capacity, keeping the newest ones.
+func (self *LogView) resize() {
+	entries := self.Entries()
+	if len(entries) > self.Capacity {
+		entries = entries[len(entries)-self.Capacity:]
+	}
+	self.entries = make([]LogEntry, self.Capacity)
+	self.start = 0
+	self.count = copy(self.entries, entries)
+}
+
+// Log adds an entry with the given level and message. fields are alternating keys and values.
+func (self *LogView) Log(level LogLevel, message string, fields ...interface{}) {
+	entry := LogEntry{Level: level, Message: message}
+	if len(fields) > 0 {
+		entry.Fields = make(map[string]interface{}, len(fields)/2)
+		for i := 0; i+1 < len(fields); i += 2 {
+			entry.Fields[fmt.Sprint(fields[i])] = fields[i+1]
+		}
+	}
+	self.Add(entry)
+}
+
+func (self *LogView) Debug(message string, fields ...interface{}) {
+	self.Log(LogDebug, message, fields...)
+}
+
+func (self *LogView) Info(message string, fields ...interface{}) {
+	self.Log(LogInfo, message, fields...)
+}
+
+func (self *LogView) Warning(message string, fields ...interface{}) {
+	self.Log(LogWarning, message, fields...)
+}
+
+func (self *LogView) Error(message string, fields ...interface{}) {
+	self.Log(LogError, message, fields...)
+}
+
+// Write adds a LogEntry for every line of p, with the level named in the line or LogInfo,
+// so a LogView can be used as the output of a logger.
+// It locks the LogView, which is safe to use from other goroutines while it is rendered.
+func (self *LogView) Write(p []byte) (int, error) {
+	self.Lock()
+	defer self.Unlock()
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		self.Add(LogEntry{Level: parseLogLevel(line), Message: line})
+	}
+	return len(p), nil
+}
+
+// parseLogLevel returns the level named by the first word of line naming one, or LogInfo.
+func parseLogLevel(line string) LogLevel {
+	words := strings.FieldsFunc(strings.ToUpper(line), func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z')
+	})
+	for _, word := range words {
+		switch word {
+		case "DEBUG", "TRACE":
+			return LogDebug
+		case "INFO":
+			return LogInfo
+		case "WARN", "WARNING":
+			return LogWarning
+		case "ERROR", "FATAL", "PANIC":
+			return LogError
+		}
+	}
+	return LogInfo
+}
+
+// Entries returns the entries kept, oldest first, including those of hidden levels.
+func (self *LogView) Entries() []LogEntry {
+	entries := make([]LogEntry, 0, self.count)
+	for i := 0; i < self.count; i++ {
+		entries = append(entries, self.entries[(self.start+i)%len(self.entries)])
+	}
+	return entries
+}
+
+// Len returns the number of entries kept.
+func (self *LogView) Len() int {
+	return self.count
+}
+
+// Clear removes every entry.
+func (self *LogView) Clear() {
+	self.entries = nil
+	self.start, self.count = 0, 0
+	self.topLine, self.following, self.match = 0, true, -1
+}
+
+// SetLevelVisible shows or hides the entries of a level.
+func (self *LogView) SetLevelVisible(level LogLevel, visible bool) {
+	if visible {
+		delete(self.hidden, level)
+	} else {
+		self.hidden[level] = true
+	}
+	self.match = -1
+}
+
+// LevelVisible reports whether the entries of a level are shown.
+func (self *LogView) LevelVisible(level LogLevel) bool {
+	return !self.hidden[level]
+}
+
+// ToggleLevel shows the entries of a level if they are hidden and hides them otherwise.
+func (self *LogView) ToggleLevel(level LogLevel) {
+	self.SetLevelVisible(level, self.hidden[level])
+}
+
+// ToggleTime shows or hides the time of the entries.
+func (self *LogView) ToggleTime() {
+	self.ShowTime = !self.ShowTime
+}
+
+// shown returns the entries of the visible levels, oldest first.
+func (self *LogView) shown() []LogEntry {
+	entries := make([]LogEntry, 0, self.count)
+	for _, entry := range self.Entries() {
+		if !self.hidden[entry.Level] {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Search highlights the occurrences of query in the entries shown, ignoring case.
+// An empty query clears the search.
+func (self *LogView) Search(query string) {
+	self.query.setText(query)
+	self.resetMatch()
+}
+
+// StartSearch opens a search bar on the last line of the LogView for typing the query.
+func (self *LogView) StartSearch() {
+	self.openSearch()
+}
+
+// HandleSearchKey applies a keyboard event ID to the search bar and reports whether it was used.
+// <Enter> closes the bar and moves to the next match, <Escape> clears the search.
+func (self *LogView) HandleSearchKey(id string) bool {
+	if !self.handleSearchKey(id, self.resetMatch) {
+		return false
+	}
+	switch id {
+	case "<Enter>":
+		self.NextMatch()
+	case "<Escape>":
+		self.resetMatch()
+	}
+	return true
+}
+
+// resetMatch makes NextMatch and PreviousMatch start from the entries in view as the query changes.
+func (self *LogView) resetMatch() {
+	self.match = -1
+}
+
+// NextMatch scrolls to the next entry matching the search, wrapping around to the first one.
+func (self *LogView) NextMatch() {
+	self.moveMatch(1)
+}
+
+// PreviousMatch scrolls to the previous entry matching the search, wrapping around to the last one.
+func (self *LogView) PreviousMatch() {
+	self.moveMatch(-1)
+}
+
+func (self *LogView) moveMatch(direction int) {
+	if self.query.text() == "" {
+		return
+	}
+	entries := self.shown()
+	from := self.match
+	if from < 0 {
+		from = self.topLine - direction
+		if direction < 0 {
+			from = MinInt(self.topLine+self.lines(), len(entries))
+		}
+	}
+	query := strings.ToLower(self.query.text())
+	for n := 1; n <= len(entries); n++ {
+		i := ((from+direction*n)%len(entries) + len(entries)) % len(entries)
+		if strings.Contains(strings.ToLower(CellsToString(self.entryCells(entries[i]))), query) {
+			self.match = i
+			// keep the match in view
+			if i < self.topLine || i >= self.topLine+self.lines() {
+				self.topLine = MaxInt(i-self.lines()/2, 0)
+			}
+			self.following = false
+			return
+		}
+	}
+}
+
+// entryCells returns the cells of the line drawn for an entry.
+func (self *LogView) entryCells(entry LogEntry) []Cell {
+	cells := []Cell{}
+	if self.ShowTime {
+		cells = append(cells, RunesToStyledCells([]rune(entry.Time.Format(self.TimeFormat)+" "), self.TimeStyle)...)
+	}
+	level := fmt.Sprintf("%-5s ", entry.Level)
+	cells = append(cells, RunesToStyledCells([]rune(level), self.LevelStyles[entry.Level])...)
+	cells = append(cells, RunesToStyledCells([]rune(entry.Message), self.TextStyle)...)
+	keys := make([]string, 0, len(entry.Fields))
+	for key := range entry.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		field := fmt.Sprintf(" %s=%v", key, entry.Fields[key])
+		cells = append(cells, RunesToStyledCells([]rune(field), self.FieldStyle)...)
+	}
+	return cells
+}
+
+// lines returns the number of entries that fit in the LogView, leaving out the search bar.
+func (self *LogView) lines() int {
+	if self.searchBarShown() {
+		return MaxInt(self.Inner.Dy()-1, 0)
+	}
+	return self.Inner.Dy()
+}
+
+func (self *LogView) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+
+	entries := self.shown()
+	height := self.lines()
+	width := self.Inner.Dx()
+	scrollbar := len(entries) > height && width > 1
+	if scrollbar {
+		width--
+	}
+
+	if self.Follow && self.following {
+		self.topLine = len(entries)
+	}
+	self.topLine = MaxInt(MinInt(self.topLine, len(entries)-height), 0)
+	self.following = self.topLine == MaxInt(len(entries)-height, 0)
+
+	for y := 0; y < height && self.topLine+y < len(entries); y++ {
+		cells := self.entryCells(entries[self.topLine+y])
+		if query := self.query.text(); query != "" {
+			style := self.MatchStyle
+			if self.topLine+y == self.match {
+				style.Modifier |= ModifierReverse
+			}
+			highlightMatches(cells, query, style)
+		}
+		for _, cx := range BuildCellWithXArray(TrimCells(cells, width)) {
+			buf.SetCell(cx.Cell, image.Pt(self.Inner.Min.X+cx.X, self.Inner.Min.Y+y))
+		}
+	}
+
+	if self.searchBarShown() {
+		self.drawSearchBar(buf, image.Pt(self.Inner.Min.X, self.Inner.Max.Y-1), self.Inner.Dx()-1, self.TextStyle)
+	}
+
+	if scrollbar {
+		drawScrollbar(buf, self.Inner.Max.X-1, self.Inner.Min.Y, self.Inner.Min.Y+height,
+			self.topLine, height, len(entries), self.ScrollbarStyle)
+	}
+}
+
+// ScrollAmount scrolls the entries by amount lines. If amount is < 0, then scroll up.
+// The scroll position is limited to the number of entries on the next Draw.
+func (self *LogView) ScrollAmount(amount int) {
+	self.topLine = MaxInt(self.topLine+amount, 0)
+	if amount < 0 {
+		self.following = false
+	}
+}
+
+func (self *LogView) ScrollUp() {
+	self.ScrollAmount(-1)
+}
+
+func (self *LogView) ScrollDown() {
+	self.ScrollAmount(1)
+}
+
+func (self *LogView) ScrollPageUp() {
+	self.ScrollAmount(-MaxInt(self.lines()-1, 1))
+}
+
+func (self *LogView) ScrollPageDown() {
+	self.ScrollAmount(MaxInt(self.lines()-1, 1))
+}
+
+func (self *LogView) ScrollTop() {
+	self.topLine = 0
+	self.following = false
+}
+
+// ScrollBottom scrolls to the newest entries and resumes following them.
+func (self *LogView) ScrollBottom() {
+	self.topLine = len(self.shown())
+	self.following = true
+}
+
+// MinimapLines returns the lines of the entries shown, for a Minimap.
+func (self *LogView) MinimapLines() [][]Cell {
+	entries := self.shown()
+	lines := make([][]Cell, len(entries))
+	for i, entry := range entries {
+		lines[i] = self.entryCells(entry)
+	}
+	return lines
+}
+
+// Viewport returns the first entry in view and the number of entries in view.
+func (self *LogView) Viewport() (int, int) {
+	return self.topLine, self.lines()
+}
+
+// ScrollToLine scrolls the entries to show the entry at line first, suspending Follow unless
+// it's the end.
+func (self *LogView) ScrollToLine(line int) {
+	self.topLine = MaxInt(line, 0)
+	self.following = false
+}
+
+// HandleKey applies a keyboard event ID to the LogView and reports whether it was used.
+// "1" to "4" toggle the debug, info, warning, and error levels, "t" toggles the time, "f" toggles
+// Follow, "/" opens the search bar, and "n" and "N" move between matches.
+func (self *LogView) HandleKey(id string) bool {
+	if self.searching {
+		return self.HandleSearchKey(id)
+	}
+	switch id {
+	case "1", "2", "3", "4":
+		self.ToggleLevel(LogLevel(id[0] - '1'))
+	case "t":
+		self.ToggleTime()
+	case "f":
+		self.Follow = !self.Follow
+		if self.Follow {
+			self.ScrollBottom()
+		}
+	case "/":
+		self.StartSearch()
+	case "n":
+		self.NextMatch()
+	case "N":
+		self.PreviousMatch()
+	case "<Escape>":
+		if self.query.text() == "" {
+			return false
+		}
+		self.Search("")
+	case "k", "<Up>":
+		self.ScrollUp()
+	case "j", "<Down>":
+		self.ScrollDown()
+	case "<PageUp>", "<C-b>":
+		self.ScrollPageUp()
+	case "<PageDown>", "<C-f>":
+		self.ScrollPageDown()
+	case "g", "<Home>":
+		self.ScrollTop()
+	case "G", "<End>":
+		self.ScrollBottom()
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse scrolls with the mouse wheel and reports whether the event was used.
+func (self *LogView) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Rectangle) {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.ScrollAmount(-WheelSteps(e))
+	case "<MouseWheelDown>":
+		self.ScrollAmount(WheelSteps(e))
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"regexp"
+	"strings"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+var (
+	markdownHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownBullet  = regexp.MustCompile(`^(\s*)([-*+])\s+(.*)$`)
+	markdownNumber  = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	markdownRule    = regexp.MustCompile(`^\s*((-\s*){3,}|(\*\s*){3,}|(_\s*){3,})$`)
+	markdownFence   = regexp.MustCompile("^\\s*(```|~~~)\\s*(\\S*)")
+)
+
+// MarkdownHeading is a heading of a Markdown document, of Level 1 to 6, starting on Line of the
+// lines it is drawn in.
+type MarkdownHeading struct {
+	Level int
+	Text  string
+	Line  int
+}
+
+// markdownDocument is Markdown text rendered into lines, with the headings, links, and code block
+// lines found in it.
+type markdownDocument struct {
+	lines    [][]Cell
+	headings []MarkdownHeading
+	links    []textLink
+	code     map[int]bool
+}
+
+// renderMarkdown converts Markdown text into lines of cells wrapped to width. Headings, emphasis,
+// inline code, links, lists, blockquotes, rules, and fenced code blocks are styled with the
+// Markdown styles of the Theme. Code blocks are not wrapped.
+func renderMarkdown(text string, width int, style Style) [][]Cell {
+	return renderMarkdownDocument(text, width, style).lines
+}
+
+// renderMarkdownDocument renders text like renderMarkdown, keeping track of its headings, links,
+// and code blocks.
+func renderMarkdownDocument(text string, width int, style Style) markdownDocument {
+	theme := Theme.Paragraph.Markdown
+	doc := markdownDocument{code: map[int]bool{}}
+	lines := [][]Cell{}
+	paragraph := []string{}
+
+	// add wraps cells with their links and appends them to the lines, numbering the links after
+	// those already found
+	add := func(cells []Cell, links []textLink, first, rest []Cell) {
+		wrapped, placed := wrapIndented(cells, links, width, first, rest)
+		count := 0
+		if n := len(doc.links); n > 0 {
+			count = doc.links[n-1].index + 1
+		}
+		for _, link := range placed {
+			link.index += count
+			link.line += len(lines)
+			doc.links = append(doc.links, link)
+		}
+		lines = append(lines, wrapped...)
+	}
+	flush := func() {
+		if len(paragraph) > 0 {
+			cells, links := markdownInline(strings.Join(paragraph, " "), style)
+			add(cells, links, nil, nil)
+			paragraph = paragraph[:0]
+		}
+	}
+
+	source := strings.Split(strings.Replace(text, "\t", "    ", -1), "\n")
+	for i := 0; i < len(source); i++ {
+		line := source[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case markdownFence.MatchString(line):
+			flush()
+			match := markdownFence.FindStringSubmatch(line)
+			code := []string{}
+			for i++; i < len(source) && !strings.HasPrefix(strings.TrimSpace(source[i]), match[1]); i++ {
+				code = append(code, source[i])
+			}
+			if len(code) > 0 {
+				for _, codeLine := range SplitCells(highlightCode(strings.Join(code, "\n")+"\n", match[2], theme.Code), '\n') {
+					doc.code[len(lines)] = true
+					lines = append(lines, codeLine)
+				}
+			}
+		case trimmed == "":
+			flush()
+			if len(lines) > 0 && len(lines[len(lines)-1]) > 0 {
+				lines = append(lines, []Cell{})
+			}
+		case markdownHeading.MatchString(line):
+			flush()
+			heading := markdownHeading.FindStringSubmatch(line)
+			cells, links := markdownInline(heading[2], theme.Heading)
+			doc.headings = append(doc.headings, MarkdownHeading{len(heading[1]), CellsToString(cells), len(lines)})
+			add(cells, links, nil, nil)
+		case markdownRule.MatchString(line):
+			flush()
+			lines = append(lines, RunesToStyledCells([]rune(strings.Repeat(string(HORIZONTAL_LINE), MaxInt(width, 0))), theme.Quote))
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			prefix := RunesToStyledCells([]rune{VERTICAL_LINE, ' '}, theme.Quote)
+			cells, links := markdownInline(quote, theme.Quote)
+			add(cells, links, prefix, prefix)
+		case markdownBullet.MatchString(line) || markdownNumber.MatchString(line):
+			flush()
+			match := markdownBullet.FindStringSubmatch(line)
+			marker := string(DOT)
+			if match == nil {
+				match = markdownNumber.FindStringSubmatch(line)
+				marker = match[2]
+			}
+			indent := strings.Repeat(" ", len(match[1]))
+			first := append(RunesToStyledCells([]rune(indent), style), RunesToStyledCells([]rune(marker+" "), theme.Bullet)...)
+			rest := RunesToStyledCells([]rune(indent+strings.Repeat(" ", len([]rune(marker))+1)), style)
+			cells, links := markdownInline(match[3], style)
+			add(cells, links, first, rest)
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+
+	doc.lines = lines
+	return doc
+}
+
+// markdownInline styles the emphasis, strong emphasis, inline code, and links of a line of Markdown.
+// The links returned hold the indexes of their cells with line 0.
+func markdownInline(text string, style Style) ([]Cell, []textLink) {
+	theme := Theme.Paragraph.Markdown
+	runes := []rune(text)
+	cells := []Cell{}
+	links := []textLink{}
+	strong, emphasis := false, false
+
+	current := func() Style {
+		s := style
+		if strong {
+			s.Modifier |= theme.Strong.Modifier
+		}
+		if emphasis {
+			s.Modifier |= theme.Emphasis.Modifier
+		}
+		return s
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes):
+			i++
+			cells = append(cells, NewCell(runes[i], current()))
+		case r == '`':
+			end := indexRune(runes, '`', i+1)
+			if end < 0 {
+				cells = append(cells, NewCell(r, current()))
+				continue
+			}
+			cells = append(cells, RunesToStyledCells(runes[i+1:end], theme.Code)...)
+			i = end
+		case (r == '*' || r == '_') && i+1 < len(runes) && runes[i+1] == r:
+			strong = !strong
+			i++
+		case r == '*' || (r == '_' && (i == 0 || runes[i-1] == ' ' || emphasis)):
+			emphasis = !emphasis
+		case r == '[':
+			close := indexRune(runes, ']', i+1)
+			if close < 0 || close+1 >= len(runes) || runes[close+1] != '(' || indexRune(runes, ')', close+1) < 0 {
+				cells = append(cells, NewCell(r, current()))
+				continue
+			}
+			link := current()
+			link.Modifier |= theme.Link.Modifier
+			link.Fg = theme.Link.Fg
+			end := indexRune(runes, ')', close+1)
+			links = append(links, textLink{len(links), 0, len(cells), len(cells) + close - i - 1, string(runes[close+2 : end])})
+			cells = append(cells, RunesToStyledCells(runes[i+1:close], link)...)
+			i = end
+		default:
+			cells = append(cells, NewCell(r, current()))
+		}
+	}
+	return cells, links
+}
+
+// indexRune returns the index of the first r in runes at or after start, or -1.
+func indexRune(runes []rune, r rune, start int) int {
+	for i := start; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// wrapIndented wraps cells to width, starting the first line with first and the others with rest,
+// and places the links of the cells on the lines they are drawn on.
+func wrapIndented(cells []Cell, links []textLink, width int, first []Cell, rest []Cell) ([][]Cell, []textLink) {
+	textWidth := MaxInt(width-MaxInt(len(first), len(rest)), 1)
+	wrapped, placed := splitLinks(WrapCells(cells, uint(textWidth)), links)
+	if len(wrapped) == 0 {
+		wrapped = [][]Cell{{}}
+	}
+	lines := make([][]Cell, len(wrapped))
+	for i, line := range wrapped {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		lines[i] = append(append([]Cell{}, prefix...), line...)
+		for j := range placed {
+			if placed[j].line == i {
+				placed[j].from += len(prefix)
+				placed[j].to += len(prefix)
+			}
+		}
+	}
+	return lines, placed
+}
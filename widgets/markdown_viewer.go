@@ -0,0 +1,418 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// MarkdownPosition is a scroll position of a MarkdownViewer: Line lines below the start of the
+// Heading with that text, or below the start of the document when Heading is empty. Unlike a line
+// number, it holds when the text is wrapped to another width or edited above the heading.
+type MarkdownPosition struct {
+	Heading string
+	Line    int
+}
+
+// MarkdownViewer draws Text as a scrollable Markdown document, with its headings listed in a table
+// of contents on the left when ShowTOC is set. n and p scroll to the next and previous heading,
+// <Tab> focuses the next link and <Enter> follows it by calling OnLink. Code blocks are drawn on a
+// background of CodeBlockStyle.
+type MarkdownViewer struct {
+	Block
+	Text      string
+	TextStyle Style
+
+	// ShowTOC draws the headings down to TOCDepth in a sidebar TOCWidth wide, highlighting the one
+	// the document is scrolled to. Clicking a heading scrolls to it.
+	ShowTOC         bool
+	TOCWidth        int
+	TOCDepth        int
+	TOCStyle        Style
+	TOCCurrentStyle Style
+
+	CodeBlockStyle   Style
+	FocusedLinkStyle Style
+	ScrollbarStyle   Style
+
+	// OnLink is called with the url of a link when it is followed with <Enter> or clicked.
+	OnLink func(url string)
+
+	topLine int
+	// position is the scroll position kept while the lines are rendered again for other Text or
+	// another width, or set by SetScrollPosition.
+	position MarkdownPosition
+	reanchor bool
+
+	// doc is the document drawn by the last Draw, for drawnText at drawnWidth, and toc the
+	// area of the table of contents.
+	doc         markdownDocument
+	drawnText   string
+	drawnWidth  int
+	toc         image.Rectangle
+	focusedLink int
+	revealLink  bool
+}
+
+func NewMarkdownViewer() *MarkdownViewer {
+	return &MarkdownViewer{
+		Block:            *NewBlock(),
+		TextStyle:        Theme.MarkdownViewer.Text,
+		ShowTOC:          true,
+		TOCWidth:         24,
+		TOCDepth:         3,
+		TOCStyle:         Theme.MarkdownViewer.TOC,
+		TOCCurrentStyle:  Theme.MarkdownViewer.TOCCurrent,
+		CodeBlockStyle:   Theme.MarkdownViewer.CodeBlock,
+		FocusedLinkStyle: Theme.MarkdownViewer.FocusedLink,
+		ScrollbarStyle:   Theme.MarkdownViewer.Scrollbar,
+		focusedLink:      -1,
+	}
+}
+
+// Headings returns the headings of the document drawn by the last Draw.
+func (self *MarkdownViewer) Headings() []MarkdownHeading {
+	return self.doc.headings
+}
+
+// ScrollPosition returns the scroll position, to be restored with SetScrollPosition, such as when
+// the document is opened again.
+func (self *MarkdownViewer) ScrollPosition() MarkdownPosition {
+	if self.reanchor {
+		return self.position
+	}
+	return self.positionOf(self.topLine)
+}
+
+// SetScrollPosition scrolls to a position returned by ScrollPosition on the next Draw.
+func (self *MarkdownViewer) SetScrollPosition(position MarkdownPosition) {
+	self.position = position
+	self.reanchor = true
+}
+
+// ScrollAmount scrolls the document by amount lines. If amount is < 0, then scroll up.
+func (self *MarkdownViewer) ScrollAmount(amount int) {
+	self.topLine = MaxInt(self.topLine+amount, 0)
+}
+
+func (self *MarkdownViewer) ScrollUp() {
+	self.ScrollAmount(-1)
+}
+
+func (self *MarkdownViewer) ScrollDown() {
+	self.ScrollAmount(1)
+}
+
+func (self *MarkdownViewer) ScrollPageUp() {
+	self.ScrollAmount(-MaxInt(self.Inner.Dy()-1, 1))
+}
+
+func (self *MarkdownViewer) ScrollPageDown() {
+	self.ScrollAmount(MaxInt(self.Inner.Dy()-1, 1))
+}
+
+func (self *MarkdownViewer) ScrollTop() {
+	self.topLine = 0
+}
+
+func (self *MarkdownViewer) ScrollBottom() {
+	self.topLine = len(self.doc.lines)
+}
+
+// currentHeading returns the index of the heading the document is scrolled to, the last one
+// starting at or above the first line in view, or -1 if there is none.
+func (self *MarkdownViewer) currentHeading() int {
+	current := -1
+	for i, heading := range self.doc.headings {
+		if heading.Line > self.topLine {
+			break
+		}
+		current = i
+	}
+	return current
+}
+
+// ScrollToHeading scrolls to the start of heading i of Headings.
+func (self *MarkdownViewer) ScrollToHeading(i int) {
+	if i >= 0 && i < len(self.doc.headings) {
+		self.topLine = self.doc.headings[i].Line
+	}
+}
+
+// NextHeading scrolls to the heading after the one the document is scrolled to.
+func (self *MarkdownViewer) NextHeading() {
+	self.ScrollToHeading(self.currentHeading() + 1)
+}
+
+// PreviousHeading scrolls to the start of the heading the document is scrolled to, or to the one
+// before it when already there.
+func (self *MarkdownViewer) PreviousHeading() {
+	i := self.currentHeading()
+	if i >= 0 && self.doc.headings[i].Line == self.topLine {
+		i--
+	}
+	if i < 0 {
+		self.ScrollTop()
+		return
+	}
+	self.ScrollToHeading(i)
+}
+
+// linkCount returns the number of links of the document drawn by the last Draw.
+func (self *MarkdownViewer) linkCount() int {
+	if n := len(self.doc.links); n > 0 {
+		return self.doc.links[n-1].index + 1
+	}
+	return 0
+}
+
+// NextLink moves the focus to the next link, scrolling it into view.
+func (self *MarkdownViewer) NextLink() {
+	if count := self.linkCount(); count > 0 {
+		self.focusedLink = (self.focusedLink + 1) % count
+		self.revealLink = true
+	}
+}
+
+// PreviousLink moves the focus to the previous link, scrolling it into view.
+func (self *MarkdownViewer) PreviousLink() {
+	if count := self.linkCount(); count > 0 {
+		self.focusedLink = (self.focusedLink - 1 + count) % count
+		self.revealLink = true
+	}
+}
+
+// FocusedLink returns the url of the focused link, and false if no link is focused.
+func (self *MarkdownViewer) FocusedLink() (string, bool) {
+	for _, link := range self.doc.links {
+		if link.index == self.focusedLink {
+			return link.url, true
+		}
+	}
+	return "", false
+}
+
+// ActivateLink calls OnLink with the url of the focused link.
+func (self *MarkdownViewer) ActivateLink() {
+	if url, ok := self.FocusedLink(); ok && self.OnLink != nil {
+		self.OnLink(url)
+	}
+}
+
+// HandleKey scrolls with the arrow keys, j and k, <PageUp>, <PageDown>, <Home>, and <End>, moves
+// between headings with n and p, focuses the next or previous link with <Tab> and <Backspace>,
+// follows it with <Enter>, and shows or hides the table of contents with t.
+// It reports whether the key was used.
+func (self *MarkdownViewer) HandleKey(id string) bool {
+	switch id {
+	case "<Up>", "k":
+		self.ScrollUp()
+	case "<Down>", "j":
+		self.ScrollDown()
+	case "<PageUp>":
+		self.ScrollPageUp()
+	case "<PageDown>", "<Space>":
+		self.ScrollPageDown()
+	case "<Home>", "g":
+		self.ScrollTop()
+	case "<End>", "G":
+		self.ScrollBottom()
+	case "n":
+		self.NextHeading()
+	case "p":
+		self.PreviousHeading()
+	case "<Tab>":
+		self.NextLink()
+	case "<Backspace>":
+		self.PreviousLink()
+	case "<Enter>":
+		self.ActivateLink()
+	case "t":
+		self.ShowTOC = !self.ShowTOC
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse scrolls with the mouse wheel, scrolls to a heading clicked in the table of contents,
+// and follows a clicked link. It reports whether the event was used.
+func (self *MarkdownViewer) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Inner) {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.ScrollAmount(-3 * WheelSteps(e))
+	case "<MouseWheelDown>":
+		self.ScrollAmount(3 * WheelSteps(e))
+	case "<MouseLeft>":
+		if e.Payload.(Mouse).Drag {
+			return false
+		}
+		if p.In(self.toc) {
+			row := 0
+			for i, heading := range self.doc.headings {
+				if heading.Level > self.TOCDepth {
+					continue
+				}
+				if row == p.Y-self.toc.Min.Y {
+					self.ScrollToHeading(i)
+					return true
+				}
+				row++
+			}
+			return false
+		}
+		line := self.topLine + p.Y - self.Inner.Min.Y
+		x := self.Inner.Min.X
+		if !self.toc.Empty() {
+			x = self.toc.Max.X + 1
+		}
+		for _, link := range self.doc.links {
+			if link.line != line || line >= len(self.doc.lines) {
+				continue
+			}
+			cells := BuildCellWithXArray(self.doc.lines[line])
+			if link.to <= len(cells) && p.X >= x+cells[link.from].X && p.X < x+cells[link.to-1].X+rw.RuneWidth(cells[link.to-1].Cell.Rune) {
+				self.focusedLink = link.index
+				self.ActivateLink()
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+	return true
+}
+
+// positionOf returns the scroll position of a line of the document.
+func (self *MarkdownViewer) positionOf(line int) MarkdownPosition {
+	position := MarkdownPosition{Line: line}
+	for _, heading := range self.doc.headings {
+		if heading.Line > line {
+			break
+		}
+		position = MarkdownPosition{heading.Text, line - heading.Line}
+	}
+	return position
+}
+
+// lineOf returns the line of the document at a scroll position.
+func (self *MarkdownViewer) lineOf(position MarkdownPosition) int {
+	if position.Heading == "" {
+		return position.Line
+	}
+	for _, heading := range self.doc.headings {
+		if heading.Text == position.Heading {
+			return heading.Line + position.Line
+		}
+	}
+	return 0
+}
+
+func (self *MarkdownViewer) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	area := self.Inner
+	self.toc = image.Rectangle{}
+	if self.ShowTOC && self.TOCWidth > 0 && area.Dx() > 2*self.TOCWidth {
+		self.toc = image.Rect(area.Min.X, area.Min.Y, area.Min.X+self.TOCWidth, area.Max.Y)
+		area.Min.X = self.toc.Max.X + 1
+	}
+	if area.Empty() {
+		return
+	}
+
+	// leave the last column to the scrollbar when the document doesn't fit
+	width := area.Dx()
+	if self.Text != self.drawnText || width != self.drawnWidth || self.reanchor {
+		if !self.reanchor {
+			self.position = self.positionOf(self.topLine)
+		}
+		self.doc = renderMarkdownDocument(self.Text, width, self.TextStyle)
+		if len(self.doc.lines) > area.Dy() && width > 1 {
+			width--
+			self.doc = renderMarkdownDocument(self.Text, width, self.TextStyle)
+		}
+		self.drawnText, self.drawnWidth = self.Text, area.Dx()
+		self.topLine = self.lineOf(self.position)
+		self.reanchor = false
+	}
+	lines := self.doc.lines
+
+	// keep the focused link in view after moving the focus
+	if self.revealLink {
+		self.revealLink = false
+		for _, link := range self.doc.links {
+			if link.index == self.focusedLink {
+				self.topLine = MinInt(MaxInt(self.topLine, link.line-area.Dy()+1), link.line)
+				break
+			}
+		}
+	}
+	self.topLine = MaxInt(MinInt(self.topLine, len(lines)-area.Dy()), 0)
+
+	self.position = self.positionOf(self.topLine)
+	current := self.currentHeading()
+
+	for y := 0; y < area.Dy() && self.topLine+y < len(lines); y++ {
+		line := self.topLine + y
+		if self.doc.code[line] {
+			buf.Fill(NewCell(' ', self.CodeBlockStyle), image.Rect(area.Min.X, area.Min.Y+y, area.Min.X+width, area.Min.Y+y+1))
+		}
+		for k, cx := range BuildCellWithXArray(TrimCells(lines[line], width)) {
+			cell := cx.Cell
+			if self.doc.code[line] && cell.Style.Bg == ColorClear {
+				cell.Style.Bg = self.CodeBlockStyle.Bg
+			}
+			for _, link := range self.doc.links {
+				if link.index == self.focusedLink && link.line == line && k >= link.from && k < link.to {
+					cell.Style = self.FocusedLinkStyle
+				}
+			}
+			buf.SetCell(cell, image.Pt(area.Min.X+cx.X, area.Min.Y+y))
+		}
+	}
+	if len(lines) > area.Dy() {
+		drawScrollbar(buf, area.Max.X-1, area.Min.Y, area.Max.Y, self.topLine, area.Dy(), len(lines), self.ScrollbarStyle)
+	}
+
+	if !self.toc.Empty() {
+		self.drawTOC(buf, current)
+	}
+}
+
+// drawTOC draws the headings down to TOCDepth, indented by level, and a line on their right,
+// highlighting the current heading or the one above it when it's deeper than TOCDepth.
+func (self *MarkdownViewer) drawTOC(buf *Buffer, current int) {
+	buf.Fill(NewCell(VERTICAL_LINE, self.TOCStyle), image.Rect(self.toc.Max.X, self.toc.Min.Y, self.toc.Max.X+1, self.toc.Max.Y))
+	for current >= 0 && self.doc.headings[current].Level > self.TOCDepth {
+		current--
+	}
+	y := self.toc.Min.Y
+	for i, heading := range self.doc.headings {
+		if heading.Level > self.TOCDepth {
+			continue
+		}
+		if y >= self.toc.Max.Y {
+			break
+		}
+		style := self.TOCStyle
+		if i == current {
+			style = self.TOCCurrentStyle
+			buf.Fill(NewCell(' ', style), image.Rect(self.toc.Min.X, y, self.toc.Max.X, y+1))
+		}
+		text := strings.Repeat(" ", heading.Level-1) + heading.Text
+		buf.SetString(TrimString(text, self.toc.Dx()), style, image.Pt(self.toc.Min.X, y))
+		y++
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"unicode"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// MenuItem is an entry of a menu, which runs its Action or opens its Submenu when activated.
+type MenuItem struct {
+	Label string
+	// Mnemonic is the key activating the item while its menu is open, underlined in the Label.
+	// The first letter of the Label is used when it is 0.
+	Mnemonic rune
+	// Shortcut is shown at the right of the item, such as the key bound to the same action.
+	Shortcut string
+	Action   func()
+	Submenu  []*MenuItem
+	// Disabled items are drawn with the Disabled style of the Theme and can't be activated.
+	Disabled bool
+	// Separator items are drawn as a line between groups of items.
+	Separator bool
+}
+
+// NewMenuSeparator returns a MenuItem drawn as a line between groups of items.
+func NewMenuSeparator() *MenuItem {
+	return &MenuItem{Separator: true}
+}
+
+// mnemonic returns the index in the Label of the rune activating the item, or -1 if there is none.
+func (self *MenuItem) mnemonic() int {
+	for i, r := range []rune(self.Label) {
+		if self.Mnemonic == 0 && unicode.IsLetter(r) || self.Mnemonic != 0 && unicode.ToLower(r) == unicode.ToLower(self.Mnemonic) {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchesMnemonic reports whether a keyboard event ID is the mnemonic of the item.
+func (self *MenuItem) matchesMnemonic(id string) bool {
+	runes := []rune(id)
+	i := self.mnemonic()
+	return len(runes) == 1 && i >= 0 && unicode.ToLower(runes[0]) == unicode.ToLower([]rune(self.Label)[i])
+}
+
+func (self *MenuItem) selectable() bool {
+	return !self.Separator && !self.Disabled
+}
+
+// menu is an open list of MenuItems drawn as an overlay.
+type menu struct {
+	Block
+	items    []*MenuItem
+	selected int
+}
+
+func newMenu(items []*MenuItem, x, y int) *menu {
+	self := &menu{
+		Block:    *NewBlock(),
+		items:    items,
+		selected: -1,
+	}
+	self.BorderStyle = Theme.Menu.Border
+
+	width := 0
+	for _, item := range items {
+		w := rw.StringWidth(item.Label)
+		if item.Shortcut != "" {
+			w += 2 + rw.StringWidth(item.Shortcut)
+		}
+		if len(item.Submenu) > 0 {
+			w += 2
+		}
+		width = MaxInt(width, w)
+	}
+	self.SetRect(x, y, x+width+4, y+len(items)+2)
+	self.move(1)
+	return self
+}
+
+// move selects the next selectable item in the given direction, wrapping around.
+func (self *menu) move(direction int) {
+	for i := 1; i <= len(self.items); i++ {
+		index := ((self.selected+direction*i)%len(self.items) + len(self.items)) % len(self.items)
+		if self.items[index].selectable() {
+			self.selected = index
+			return
+		}
+	}
+}
+
+// itemAt returns the index of the item drawn at p, or -1.
+func (self *menu) itemAt(p image.Point) int {
+	if !p.In(self.Inner) {
+		return -1
+	}
+	return p.Y - self.Inner.Min.Y
+}
+
+func (self *menu) Draw(buf *Buffer) {
+	buf.Fill(NewCell(' ', Theme.Menu.Item), self.Rectangle)
+	self.Block.Draw(buf)
+
+	for i, item := range self.items {
+		y := self.Inner.Min.Y + i
+		if y >= self.Inner.Max.Y {
+			break
+		}
+		if item.Separator {
+			buf.Fill(NewCell(HORIZONTAL_LINE, self.BorderStyle), image.Rect(self.Inner.Min.X, y, self.Inner.Max.X, y+1))
+			continue
+		}
+		style := Theme.Menu.Item
+		switch {
+		case item.Disabled:
+			style = Theme.Menu.Disabled
+		case i == self.selected:
+			style = Theme.Menu.Selected
+		}
+		buf.Fill(NewCell(' ', style), image.Rect(self.Inner.Min.X, y, self.Inner.Max.X, y+1))
+
+		drawMenuLabel(buf, item, image.Pt(self.Inner.Min.X+1, y), style)
+		right := self.Inner.Max.X - 1
+		if len(item.Submenu) > 0 {
+			buf.SetCell(NewCell(Theme.Menu.SubmenuRune, style), image.Pt(right-1, y))
+			right -= 2
+		}
+		if item.Shortcut != "" {
+			shortcutStyle := Theme.Menu.Shortcut
+			shortcutStyle.Bg = style.Bg
+			if i == self.selected || item.Disabled {
+				shortcutStyle = style
+			}
+			buf.SetString(item.Shortcut, shortcutStyle, image.Pt(right-rw.StringWidth(item.Shortcut), y))
+		}
+	}
+}
+
+// drawMenuLabel draws the Label of an item with its mnemonic underlined.
+func drawMenuLabel(buf *Buffer, item *MenuItem, p image.Point, style Style) {
+	mnemonic := item.mnemonic()
+	for i, r := range []rune(item.Label) {
+		cellStyle := style
+		if i == mnemonic && !item.Disabled {
+			cellStyle.Modifier |= ModifierUnderline
+		}
+		buf.SetCell(NewCell(r, cellStyle), p)
+		p.X += rw.RuneWidth(r)
+	}
+}
+
+// menuStack is a menu and the submenus opened from it, shown as overlays.
+type menuStack struct {
+	menus []*menu
+	// bounds is the area menus are kept within when it isn't empty.
+	bounds image.Rectangle
+}
+
+func (self *menuStack) isOpen() bool {
+	return len(self.menus) > 0
+}
+
+func (self *menuStack) top() *menu {
+	return self.menus[len(self.menus)-1]
+}
+
+// push opens a menu of items with its top left corner at x and y.
+func (self *menuStack) push(items []*MenuItem, x, y int) {
+	m := newMenu(items, x, y)
+	if !self.bounds.Empty() {
+		rect := m.GetRect()
+		dx := MinInt(self.bounds.Max.X-rect.Max.X, 0)
+		dy := MinInt(self.bounds.Max.Y-rect.Max.Y, 0)
+		rect = rect.Add(image.Pt(dx, dy))
+		rect = rect.Add(image.Pt(MaxInt(self.bounds.Min.X-rect.Min.X, 0), MaxInt(self.bounds.Min.Y-rect.Min.Y, 0)))
+		m.SetRect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Max.Y)
+	}
+	self.menus = append(self.menus, m)
+	ShowOverlay(m)
+}
+
+// pop closes the topmost menu.
+func (self *menuStack) pop() {
+	HideOverlay(self.top())
+	self.menus = self.menus[:len(self.menus)-1]
+}
+
+func (self *menuStack) closeAll() {
+	for self.isOpen() {
+		self.pop()
+	}
+}
+
+// openSubmenu opens the submenu of the selected item of the topmost menu beside it.
+func (self *menuStack) openSubmenu() {
+	m := self.top()
+	self.push(m.items[m.selected].Submenu, m.Max.X-1, m.Inner.Min.Y+m.selected-1)
+}
+
+// activate opens the submenu of the selected item of the topmost menu, or closes every menu and
+// runs its Action.
+func (self *menuStack) activate() {
+	m := self.top()
+	if m.selected < 0 || !m.items[m.selected].selectable() {
+		return
+	}
+	item := m.items[m.selected]
+	if len(item.Submenu) > 0 {
+		self.openSubmenu()
+		return
+	}
+	self.closeAll()
+	if item.Action != nil {
+		item.Action()
+	}
+}
+
+// handleKey moves through the topmost menu with <Up> and <Down>, activates an item with <Enter>,
+// <Space>, or its mnemonic, opens submenus with <Right>, and closes them with <Left> or <Escape>.
+// <Left>, <Right>, and <Escape> aren't used on the first menu, so the owner can handle them.
+func (self *menuStack) handleKey(id string) bool {
+	if !self.isOpen() {
+		return false
+	}
+	m := self.top()
+	switch id {
+	case "<Up>", "k", "<C-p>":
+		m.move(-1)
+	case "<Down>", "j", "<C-n>":
+		m.move(1)
+	case "<Enter>", "<Space>":
+		self.activate()
+	case "<Right>", "l":
+		if m.selected < 0 || len(m.items[m.selected].Submenu) == 0 {
+			return false
+		}
+		self.openSubmenu()
+	case "<Left>", "h", "<Escape>":
+		if len(self.menus) == 1 {
+			return false
+		}
+		self.pop()
+	default:
+		for i, item := range m.items {
+			if item.selectable() && item.matchesMnemonic(id) {
+				m.selected = i
+				self.activate()
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// handleMouse activates a clicked item, closing the submenus above its menu, and reports whether
+// the event was on a menu.
+func (self *menuStack) handleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok {
+		return false
+	}
+	for i := len(self.menus) - 1; i >= 0; i-- {
+		m := self.menus[i]
+		if !p.In(m.GetRect()) {
+			continue
+		}
+		if e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag {
+			return true
+		}
+		for len(self.menus) > i+1 {
+			self.pop()
+		}
+		if index := m.itemAt(p); index >= 0 && m.items[index].selectable() {
+			m.selected = index
+			self.activate()
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// MenuBar is a row of menus, such as File, Edit, and View, whose items drop down as overlays
+// shown with ShowOverlay. A menu is opened by clicking it, with <F10>, or with <M-x> where x is
+// its mnemonic. The MenuBar is drawn on the first row of its rect when it has no border.
+type MenuBar struct {
+	Block
+	// Menus are the entries of the bar, whose Submenu holds the items of their menu.
+	Menus       []*MenuItem
+	TextStyle   Style
+	ActiveStyle Style
+
+	active int
+	stack  menuStack
+	// spans holds the area of each menu drawn by the last Draw.
+	spans []image.Rectangle
+}
+
+func NewMenuBar(menus ...*MenuItem) *MenuBar {
+	self := &MenuBar{
+		Block:       *NewBlock(),
+		Menus:       menus,
+		TextStyle:   Theme.Menu.Bar,
+		ActiveStyle: Theme.Menu.Selected,
+		active:      -1,
+	}
+	self.Border = false
+	return self
+}
+
+// IsOpen reports whether a menu is open.
+func (self *MenuBar) IsOpen() bool {
+	return self.stack.isOpen()
+}
+
+// Active returns the index of the open menu, or -1 if none is.
+func (self *MenuBar) Active() int {
+	if !self.IsOpen() {
+		return -1
+	}
+	return self.active
+}
+
+// Open drops down the menu at index, closing any other.
+func (self *MenuBar) Open(index int) {
+	if index < 0 || index >= len(self.Menus) {
+		return
+	}
+	self.Close()
+	self.active = index
+	x := self.row().Min.X
+	for _, menu := range self.Menus[:index] {
+		x += rw.StringWidth(menu.Label) + 2
+	}
+	self.stack.push(self.Menus[index].Submenu, x, self.row().Max.Y)
+}
+
+// Close closes the open menu and its submenus.
+func (self *MenuBar) Close() {
+	self.stack.closeAll()
+	self.active = -1
+}
+
+// row returns the area the menus are drawn in.
+func (self *MenuBar) row() image.Rectangle {
+	area := self.Rectangle
+	if self.Border {
+		area = self.Inner
+	}
+	return image.Rect(area.Min.X, area.Min.Y, area.Max.X, area.Min.Y+1)
+}
+
+// HandleKey opens a menu with <F10> or <M-x> where x is its mnemonic. While a menu is open, <Up>
+// and <Down> move through its items, <Enter> or an item's mnemonic activates it, <Left> and
+// <Right> move between menus, and <Escape> closes them. It reports whether the key was used.
+func (self *MenuBar) HandleKey(id string) bool {
+	if len(self.Menus) == 0 {
+		return false
+	}
+	if strings.HasPrefix(id, "<M-") && strings.HasSuffix(id, ">") {
+		key := strings.TrimSuffix(strings.TrimPrefix(id, "<M-"), ">")
+		for i, menu := range self.Menus {
+			if !menu.Disabled && menu.matchesMnemonic(key) {
+				self.Open(i)
+				return true
+			}
+		}
+	}
+	if !self.IsOpen() {
+		if id == "<F10>" {
+			self.Open(0)
+			return true
+		}
+		return false
+	}
+	if self.stack.handleKey(id) {
+		return true
+	}
+	switch id {
+	case "<Left>", "h":
+		self.Open((self.active + len(self.Menus) - 1) % len(self.Menus))
+	case "<Right>", "l":
+		self.Open((self.active + 1) % len(self.Menus))
+	case "<Escape>", "<F10>":
+		self.Close()
+	}
+	// keys are kept from the rest of the application while a menu is open
+	return true
+}
+
+// HandleMouse opens or closes a clicked menu, activates clicked items, and closes the menus on a
+// click anywhere else. It reports whether the event was used.
+func (self *MenuBar) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok {
+		return false
+	}
+	if self.stack.handleMouse(e) {
+		return true
+	}
+	if e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag {
+		return false
+	}
+	for i, span := range self.spans {
+		if p.In(span) {
+			if self.Active() == i {
+				self.Close()
+			} else if !self.Menus[i].Disabled {
+				self.Open(i)
+			}
+			return true
+		}
+	}
+	if self.IsOpen() {
+		self.Close()
+		return true
+	}
+	return false
+}
+
+func (self *MenuBar) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	row := self.row()
+	buf.Fill(NewCell(' ', self.TextStyle), row)
+
+	self.spans = self.spans[:0]
+	x := row.Min.X
+	for i, menu := range self.Menus {
+		width := rw.StringWidth(menu.Label) + 2
+		if x+width > row.Max.X {
+			break
+		}
+		style := self.TextStyle
+		switch {
+		case menu.Disabled:
+			style = Theme.Menu.Disabled
+			style.Bg = self.TextStyle.Bg
+		case i == self.Active():
+			style = self.ActiveStyle
+		}
+		span := image.Rect(x, row.Min.Y, x+width, row.Max.Y)
+		buf.Fill(NewCell(' ', style), span)
+		drawMenuLabel(buf, menu, image.Pt(x+1, row.Min.Y), style)
+		self.spans = append(self.spans, span)
+		x += width
+	}
+}
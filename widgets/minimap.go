@@ -0,0 +1,138 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// MinimapSource is a scrollable widget a Minimap can show, such as a Paragraph, LogView, or Table.
+type MinimapSource interface {
+	Drawable
+	// MinimapLines returns the lines of the content as they are drawn.
+	MinimapLines() [][]Cell
+	// Viewport returns the index of the first line in view and the number of lines in view.
+	Viewport() (int, int)
+	// ScrollToLine scrolls the content to show line first.
+	ScrollToLine(line int)
+}
+
+// Minimap draws an overview of the content of its Source shrunk to fit in braille dots, each
+// standing for one or more lines and columns of characters, with each cell colored like the first
+// character it covers and the lines in view of the Source shaded with the background of
+// ViewportStyle. Clicking or dragging the mouse scrolls the Source to center the lines under it.
+// The Minimap reads the Source as it was last drawn, so it's rendered after the Source.
+type Minimap struct {
+	Block
+	Source MinimapSource
+	// TextStyle colors the characters of the Source drawn without a foreground color.
+	TextStyle     Style
+	ViewportStyle Style
+
+	// scale is the number of lines, and columns the number of characters, of a dot in the last
+	// Draw.
+	scale   int
+	columns int
+}
+
+func NewMinimap(source MinimapSource) *Minimap {
+	return &Minimap{
+		Block:         *NewBlock(),
+		Source:        source,
+		TextStyle:     Theme.Minimap.Text,
+		ViewportStyle: Theme.Minimap.Viewport,
+	}
+}
+
+func (self *Minimap) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	if self.Source == nil || self.Inner.Dx() <= 0 || self.Inner.Dy() <= 0 {
+		return
+	}
+	self.Source.Lock()
+	lines := self.Source.MinimapLines()
+	top, height := self.Source.Viewport()
+	self.Source.Unlock()
+
+	self.scale = MaxInt((len(lines)+self.Inner.Dy()*4-1)/(self.Inner.Dy()*4), 1)
+	widest := 0
+	for _, line := range lines {
+		widest = MaxInt(widest, rw.StringWidth(CellsToString(line)))
+	}
+	self.columns = MaxInt((widest+self.Inner.Dx()*2-1)/(self.Inner.Dx()*2), 1)
+
+	// a dot is set for the lines and columns it stands for holding a character, and a cell has
+	// the color of the first character found in it
+	dots := make([][]rune, self.Inner.Dy())
+	colors := make([][]Color, self.Inner.Dy())
+	for y := range dots {
+		dots[y] = make([]rune, self.Inner.Dx())
+		colors[y] = make([]Color, self.Inner.Dx())
+		for x := range colors[y] {
+			colors[y][x] = ColorClear
+		}
+	}
+	for i, line := range lines {
+		dy := i / self.scale
+		for _, cx := range BuildCellWithXArray(line) {
+			dx := cx.X / self.columns
+			if dx >= self.Inner.Dx()*2 {
+				break
+			}
+			if cx.Cell.Rune == ' ' || cx.Cell.Rune == '\t' {
+				continue
+			}
+			dots[dy/4][dx/2] |= BRAILLE[dy%4][dx%2]
+			if colors[dy/4][dx/2] == ColorClear {
+				colors[dy/4][dx/2] = cx.Cell.Style.Fg
+				if colors[dy/4][dx/2] == ColorClear {
+					colors[dy/4][dx/2] = self.TextStyle.Fg
+				}
+			}
+		}
+	}
+
+	for y := range dots {
+		background := ColorClear
+		if first := y * 4 * self.scale; first < top+height && first+4*self.scale > top && first < len(lines) {
+			background = self.ViewportStyle.Bg
+		}
+		for x, dot := range dots[y] {
+			cell := NewCell(' ', NewStyle(ColorClear, background))
+			if dot != 0 {
+				cell = NewCell(BRAILLE_OFFSET|dot, NewStyle(colors[y][x], background))
+			}
+			buf.SetCell(cell, image.Pt(self.Inner.Min.X+x, self.Inner.Min.Y+y))
+		}
+	}
+}
+
+// HandleMouse scrolls the Source to center the lines clicked or dragged over, or by three lines
+// with the mouse wheel, and reports whether the event was used.
+func (self *Minimap) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Inner) || self.Source == nil {
+		return false
+	}
+	self.Source.Lock()
+	defer self.Source.Unlock()
+	top, height := self.Source.Viewport()
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.Source.ScrollToLine(MaxInt(top-3*WheelSteps(e), 0))
+	case "<MouseWheelDown>":
+		self.Source.ScrollToLine(top + 3*WheelSteps(e))
+	case "<MouseLeft>":
+		line := (4*(p.Y-self.Inner.Min.Y) + 2) * MaxInt(self.scale, 1)
+		self.Source.ScrollToLine(MaxInt(line-height/2, 0))
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,280 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// NumberInput is a TextInput holding a number between Min and Max. <Up> and <Down>, the mouse wheel,
+// and the buttons drawn on its right edge change the Value by Step; <PageUp> and <PageDown> by ten
+// Steps. Typed text is read with Parse, and text which can't be read or is out of range is drawn
+// with ErrorStyle and reported by Err until it is fixed. Format writes the Value back as text.
+type NumberInput struct {
+	TextInput
+	Value float64
+	Min   float64
+	Max   float64
+	Step  float64
+
+	// Format and Parse convert the Value to and from text, such as FormatPercent and ParsePercent.
+	Format func(value float64) string
+	Parse  func(text string) (float64, error)
+
+	ButtonStyle Style
+	ErrorStyle  Style
+
+	// OnValueChange is called when the Value changes.
+	OnValueChange func(value float64)
+
+	err error
+	// buttons holds the areas of the decrement and increment buttons drawn by the last Draw.
+	buttons [2]image.Rectangle
+}
+
+func NewNumberInput() *NumberInput {
+	self := &NumberInput{
+		TextInput:   *NewTextInput(),
+		Min:         math.Inf(-1),
+		Max:         math.Inf(1),
+		Step:        1,
+		Format:      FormatNumber,
+		Parse:       ParseNumber,
+		ButtonStyle: Theme.NumberInput.Button,
+		ErrorStyle:  Theme.NumberInput.Error,
+	}
+	self.SetText(self.Format(0))
+	return self
+}
+
+// FormatNumber writes value with the fewest digits needed, like 2.5.
+func FormatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func ParseNumber(text string) (float64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return 0, errors.New("not a number")
+	}
+	return value, nil
+}
+
+// FormatPercent writes a ratio as a percentage, like 25% for 0.25.
+func FormatPercent(value float64) string {
+	return FormatNumber(math.Round(value*10000)/100) + "%"
+}
+
+// ParsePercent reads a percentage, with or without the percent sign, as a ratio.
+func ParsePercent(text string) (float64, error) {
+	value, err := ParseNumber(strings.TrimSuffix(strings.TrimSpace(text), "%"))
+	return value / 100, err
+}
+
+// FormatBytes writes a number of bytes with a binary unit, like 1.5K.
+func FormatBytes(value float64) string {
+	return formatSize(int64(value))
+}
+
+// ParseBytes reads a number of bytes with an optional binary unit, like 512, 1.5K, 2MB, or 4 GiB.
+func ParseBytes(text string) (float64, error) {
+	text = strings.TrimSpace(text)
+	end := strings.IndexFunc(text, unicode.IsLetter)
+	if end < 0 {
+		end = len(text)
+	}
+	value, err := ParseNumber(text[:end])
+	if err != nil {
+		return 0, err
+	}
+	unit := strings.ToUpper(strings.TrimSpace(text[end:]))
+	unit = strings.TrimSuffix(strings.TrimSuffix(unit, "B"), "I")
+	if unit == "" {
+		return value, nil
+	}
+	power := strings.Index("KMGTPE", unit)
+	if len(unit) != 1 || power < 0 {
+		return 0, fmt.Errorf("unknown unit %q", text[end:])
+	}
+	return value * math.Pow(1024, float64(power+1)), nil
+}
+
+// FormatSeconds writes a number of seconds as a duration, like 1m30s.
+func FormatSeconds(value float64) string {
+	return time.Duration(value * float64(time.Second)).String()
+}
+
+// ParseSeconds reads a duration, like 1m30s, or a plain number of seconds.
+func ParseSeconds(text string) (float64, error) {
+	if value, err := ParseNumber(text); err == nil {
+		return value, nil
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(text))
+	if err != nil {
+		return 0, errors.New("not a duration")
+	}
+	return d.Seconds(), nil
+}
+
+// Err returns why the text can't be used as the Value, or nil if it can.
+func (self *NumberInput) Err() error {
+	return self.err
+}
+
+// SetValue sets the Value, limited to Min and Max, and replaces the text with it.
+func (self *NumberInput) SetValue(value float64) {
+	value = math.Max(math.Min(value, self.Max), self.Min)
+	self.SetText(self.Format(value))
+	self.err = nil
+	self.setValue(value)
+}
+
+func (self *NumberInput) setValue(value float64) {
+	if value == self.Value {
+		return
+	}
+	self.Value = value
+	if self.OnValueChange != nil {
+		self.OnValueChange(value)
+	}
+}
+
+// Increment adds steps times Step to the Value. Negative steps decrement it.
+func (self *NumberInput) Increment(steps int) {
+	self.SetValue(self.Value + float64(steps)*self.Step)
+}
+
+// parse reads the text, setting the Value if it is valid and Err otherwise.
+func (self *NumberInput) parse() {
+	value, err := self.Parse(self.Text())
+	switch {
+	case err != nil:
+		self.err = err
+	case value < self.Min:
+		self.err = fmt.Errorf("must be at least %s", self.Format(self.Min))
+	case value > self.Max:
+		self.err = fmt.Errorf("must be at most %s", self.Format(self.Max))
+	default:
+		self.err = nil
+		self.setValue(value)
+	}
+}
+
+// SetFocused focuses the NumberInput, and formats valid text once the focus leaves it.
+func (self *NumberInput) SetFocused(focused bool) {
+	if self.Focused && !focused && self.err == nil {
+		self.SetText(self.Format(self.Value))
+	}
+	self.Focused = focused
+}
+
+// Validate is a FormValidator returning Err, for the Form holding the NumberInput to show why its
+// text is invalid.
+func (self *NumberInput) Validate(value interface{}) error {
+	return self.err
+}
+
+// FormValue returns the Value for a Form. It is the last valid one while Err is set.
+func (self *NumberInput) FormValue() interface{} {
+	return self.Value
+}
+
+// HandleKey changes the Value with <Up>, <Down>, <PageUp>, and <PageDown> and edits the text with
+// the other keys while the NumberInput is Focused. <Enter> formats valid text before it is passed
+// on to the TextInput. It reports whether the key was used.
+func (self *NumberInput) HandleKey(id string) bool {
+	if !self.Focused {
+		return false
+	}
+	switch id {
+	case "<Up>":
+		self.Increment(1)
+		return true
+	case "<Down>":
+		self.Increment(-1)
+		return true
+	case "<PageUp>":
+		self.Increment(10)
+		return true
+	case "<PageDown>":
+		self.Increment(-10)
+		return true
+	case "<Enter>":
+		if self.err == nil {
+			self.SetText(self.Format(self.Value))
+		}
+	}
+	before := self.Text()
+	if !self.TextInput.HandleKey(id) {
+		return false
+	}
+	if self.Text() != before {
+		self.parse()
+	}
+	return true
+}
+
+// HandleMouse changes the Value with the buttons and the mouse wheel, and moves the cursor to a
+// clicked rune. It reports whether the event was used.
+func (self *NumberInput) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Rectangle) {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.Increment(WheelSteps(e))
+	case "<MouseWheelDown>":
+		self.Increment(-WheelSteps(e))
+	case "<MouseLeft>":
+		if e.Payload.(Mouse).Drag {
+			return false
+		}
+		switch {
+		case p.In(self.buttons[0]):
+			self.Increment(-1)
+		case p.In(self.buttons[1]):
+			self.Increment(1)
+		default:
+			return self.TextInput.HandleMouse(e)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func (self *NumberInput) Draw(buf *Buffer) {
+	if self.Inner.Dx() < 3 {
+		self.buttons = [2]image.Rectangle{}
+		self.TextInput.Draw(buf)
+		return
+	}
+
+	// leave the last two columns to the buttons while the text is drawn
+	inner := self.Inner
+	self.Inner.Max.X -= 2
+	style := self.TextStyle
+	if self.err != nil {
+		self.TextStyle = self.ErrorStyle
+	}
+	self.TextInput.Draw(buf)
+	self.Inner, self.TextStyle = inner, style
+
+	y := self.Inner.Min.Y
+	self.buttons[0] = image.Rect(self.Inner.Max.X-2, y, self.Inner.Max.X-1, y+1)
+	self.buttons[1] = image.Rect(self.Inner.Max.X-1, y, self.Inner.Max.X, y+1)
+	buf.SetCell(NewCell(Theme.NumberInput.DecrementRune, self.ButtonStyle), self.buttons[0].Min)
+	buf.SetCell(NewCell(Theme.NumberInput.IncrementRune, self.ButtonStyle), self.buttons[1].Min)
+}
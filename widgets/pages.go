@@ -0,0 +1,276 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"strconv"
+	"time"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// Page is a layout held by Pages, such as a Grid, shown under its Name.
+type Page struct {
+	Name    string
+	Content Drawable
+
+	// focus is the widget focused on the page with SetFocus.
+	focus Drawable
+}
+
+// Pages holds several full-screen layouts, such as the Grids of different dashboards, and shows one
+// of them at a time, sized to fill the Pages, or their Inner area with a border. A page is shown by
+// its number with the keys 1 to 9, by its name with ShowName, or after the current one with ] and [.
+// Each page remembers the widget focused on it, and with a Transition the page shown slides in over
+// the previous one.
+type Pages struct {
+	Block
+	Items []*Page
+	// Transition is the time the page shown takes to slide in, from the right when it comes after
+	// the previous one and from the left otherwise. It is shown at once when zero.
+	Transition time.Duration
+
+	// OnChange is called with the index of the page shown after it changes.
+	OnChange func(index int)
+	// OnFrame is called from the goroutine of the transition after every frame, so that the
+	// application can Render it.
+	OnFrame func()
+
+	current int
+	// previous is the page sliding out during a transition started at start, progress being the
+	// fraction of it done.
+	previous  int
+	start     time.Time
+	progress  float64
+	animation *Animation
+	// shown is the content of the page shown, which is hidden once another one has slid in.
+	shown Drawable
+}
+
+func NewPages() *Pages {
+	self := &Pages{
+		Block:    *NewBlock(),
+		progress: 1,
+	}
+	self.Border = false
+	self.animation = NewAnimation(time.Second/30, self.step)
+	self.animation.OnFrame = func() {
+		if self.OnFrame != nil {
+			self.OnFrame()
+		}
+	}
+	return self
+}
+
+// AddPage appends a page named name holding content and returns it.
+func (self *Pages) AddPage(name string, content Drawable) *Page {
+	page := &Page{Name: name, Content: content}
+	self.Items = append(self.Items, page)
+	return page
+}
+
+// Current returns the index of the page shown.
+func (self *Pages) Current() int {
+	return self.current
+}
+
+// CurrentPage returns the page shown, or nil if there are no Items.
+func (self *Pages) CurrentPage() *Page {
+	if self.current < 0 || self.current >= len(self.Items) {
+		return nil
+	}
+	return self.Items[self.current]
+}
+
+// Index returns the index of the page named name, or -1 if there is none.
+func (self *Pages) Index(name string) int {
+	for i, page := range self.Items {
+		if page.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Show shows page i, sliding it in when there is a Transition, and calls OnChange.
+// The widget focused on the page shown, and on the one it replaces, are told so with SetFocused
+// when they have it.
+func (self *Pages) Show(i int) {
+	if i < 0 || i >= len(self.Items) || i == self.current {
+		return
+	}
+	self.setFocused(false)
+	self.previous, self.current = self.current, i
+	self.setFocused(true)
+	if self.Transition > 0 {
+		self.start, self.progress = time.Now(), 0
+		self.animation.Start()
+	}
+	if self.OnChange != nil {
+		self.OnChange(i)
+	}
+}
+
+// ShowName shows the page named name and reports whether there is one.
+func (self *Pages) ShowName(name string) bool {
+	i := self.Index(name)
+	if i < 0 {
+		return false
+	}
+	self.Show(i)
+	return true
+}
+
+// Next shows the next page, wrapping around to the first.
+func (self *Pages) Next() {
+	if len(self.Items) > 0 {
+		self.Show((self.current + 1) % len(self.Items))
+	}
+}
+
+// Previous shows the previous page, wrapping around to the last.
+func (self *Pages) Previous() {
+	if len(self.Items) > 0 {
+		self.Show((self.current + len(self.Items) - 1) % len(self.Items))
+	}
+}
+
+// SetFocus records the widget focused on the page shown, like one of the widgets of its Grid,
+// so it is focused again when the page is shown again.
+func (self *Pages) SetFocus(item Drawable) {
+	if page := self.CurrentPage(); page != nil {
+		self.setFocused(false)
+		page.focus = item
+		self.setFocused(true)
+	}
+}
+
+// Focused returns the widget focused on the page shown with SetFocus, or the page's content.
+func (self *Pages) Focused() Drawable {
+	page := self.CurrentPage()
+	if page == nil {
+		return nil
+	}
+	if page.focus != nil {
+		return page.focus
+	}
+	return page.Content
+}
+
+// setFocused calls SetFocused on the widget focused on the page shown, if it has it.
+func (self *Pages) setFocused(focused bool) {
+	if item, ok := self.Focused().(interface{ SetFocused(bool) }); ok {
+		item.SetFocused(focused)
+	}
+}
+
+// HandleKey shows the page numbered by the keys 1 to 9, and the next or previous page with ] and [.
+// It reports whether the key was used.
+func (self *Pages) HandleKey(id string) bool {
+	switch id {
+	case "]":
+		self.Next()
+	case "[":
+		self.Previous()
+	default:
+		n, err := strconv.Atoi(id)
+		if err != nil || n < 1 || n > 9 || n > len(self.Items) {
+			return false
+		}
+		self.Show(n - 1)
+	}
+	return true
+}
+
+// Sliding reports whether a transition is in progress.
+func (self *Pages) Sliding() bool {
+	return self.progress < 1
+}
+
+// step moves the transition on, stopping the animation once it is done.
+func (self *Pages) step(now time.Time) {
+	self.progress = float64(now.Sub(self.start)) / float64(self.Transition)
+	if self.Transition <= 0 || self.progress >= 1 {
+		self.progress = 1
+		self.animation.Stop()
+	}
+}
+
+// area returns the area the pages are drawn in, the whole Pages without a border.
+func (self *Pages) area() image.Rectangle {
+	if self.Border {
+		return self.Inner
+	}
+	return self.Rectangle
+}
+
+// drawPage draws page i into a buffer the size of area, derived from the one of Draw.
+func (self *Pages) drawPage(parent *Buffer, i int, area image.Rectangle) *Buffer {
+	buf := parent.Derive(area)
+	if i < 0 || i >= len(self.Items) || self.Items[i].Content == nil {
+		return buf
+	}
+	content := self.Items[i].Content
+	content.SetRect(area.Min.X, area.Min.Y, area.Max.X, area.Max.Y)
+	content.Lock()
+	content.Draw(buf)
+	content.Unlock()
+	return buf
+}
+
+// showContent calls the Lifecycle callbacks of the content of the page shown, hiding the content
+// of the page shown before once the transition is over.
+func (self *Pages) showContent(buf *Buffer) {
+	content := self.Items[self.current].Content
+	if content != self.shown && !self.Sliding() {
+		if shown := self.shown; shown != nil {
+			buf.Defer(func() { Hide(shown) })
+		}
+		self.shown = content
+	}
+	if content != nil {
+		buf.Defer(func() { Show(content) })
+	}
+}
+
+func (self *Pages) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	area := self.area()
+	if len(self.Items) == 0 || area.Empty() {
+		return
+	}
+	self.current = MaxInt(MinInt(self.current, len(self.Items)-1), 0)
+	defer self.showContent(buf)
+
+	page := self.drawPage(buf, self.current, area)
+	if !self.Sliding() {
+		buf.Composite(page)
+		return
+	}
+
+	// ease out, the page slowing down as it comes in; the graphics are left out until it's in place
+	eased := 1 - (1-self.progress)*(1-self.progress)
+	offset := int(float64(area.Dx()) * (1 - eased))
+	if self.current < self.previous {
+		offset = -offset
+	}
+	width := area.Dx()
+	if offset > 0 {
+		width = -width
+	}
+	for _, slide := range []struct {
+		buf   *Buffer
+		shift int
+	}{{self.drawPage(buf, self.previous, area), offset + width}, {page, offset}} {
+		for y := slide.buf.Min.Y; y < slide.buf.Max.Y; y++ {
+			for x := slide.buf.Min.X; x < slide.buf.Max.X; x++ {
+				if q := image.Pt(x+slide.shift, y); q.In(area) {
+					buf.SetCell(slide.buf.GetCell(image.Pt(x, y)), q)
+				}
+			}
+		}
+	}
+}
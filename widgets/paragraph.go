@@ -5,7 +5,12 @@
 package widgets
 
 import (
+	"encoding/json"
 	"image"
+	"regexp"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
 
 	. "github.com/reaalkhalil/termui"
 )
@@ -15,34 +20,804 @@ type Paragraph struct {
 	Text      string
 	TextStyle Style
 	WrapText  bool
+
+	// Markdown renders Text as Markdown with the styles of Theme.Paragraph.Markdown instead of
+	// parsing style markup. Markdown is always wrapped.
+	Markdown bool
+
+	// TextAlignment aligns the lines of the text. A line of Text starting with [align:left],
+	// [align:center], [align:right], or [align:justify] is aligned that way instead.
+	// The last line of a justified line of Text is aligned left.
+	TextAlignment Alignment
+
+	// Direction is the base direction of the lines of Text, which are drawn in the order of the
+	// Unicode bidirectional algorithm, so that Arabic and Hebrew text reads right to left. With
+	// DirectionAuto, every line of Text takes the direction of its first letter. Right-to-left lines
+	// with AlignLeft are aligned right, where they start. Source code and ANSIParse text aren't reordered.
+	Direction Direction
+
+	// Language, when set, draws Text as source code highlighted by the Lexer of that language in Lexers,
+	// with tabs expanded to TabWidth columns. Style markup, links, and alignment aren't parsed.
+	Language string
+	TabWidth int
+
+	// ANSIParse converts the ANSI escape sequences in Text, such as the colored output of other
+	// programs, into styles with ParseANSI instead of parsing style markup.
+	ANSIParse bool
+
+	// Follow keeps the last line of the text in view as text is appended. Scrolling up suspends it
+	// until ScrollBottom is called or the text is scrolled back down to its end.
+	Follow bool
+	// MaxLines, when greater than 0, is the number of lines of Text kept by AppendText,
+	// which drops the oldest lines.
+	MaxLines int
+
+	// ScrollbarStyle is used for the scrollbar drawn on the right edge when the text is taller than the Paragraph.
+	ScrollbarStyle Style
+
+	// Spans of Text written as [label](link:url) are links drawn with LinkStyle. NextLink and
+	// PreviousLink move the focus between them, drawn with FocusedLinkStyle, and OnLink is called
+	// with the url of a link when it is activated with ActivateLink or clicked.
+	LinkStyle        Style
+	FocusedLinkStyle Style
+	OnLink           func(url string)
+
+	// SelectionStyle is used for the text selected with the mouse or keyboard.
+	SelectionStyle Style
+
+	topLine   int
+	following bool
+
+	// lines and links drawn by the last Draw
+	drawnLines [][]Cell
+	drawnLinks []textLink
+	// markupCache holds the last lines of markupLines, which are only laid out again when the text
+	// or its layout changes
+	markupCache map[paragraphLinesKey]paragraphLines
+
+	focusedLink int
+	revealLink  bool
+
+	// selection from anchor to cursor, both included
+	selected  bool
+	selecting bool
+	anchor    textPosition
+	cursor    textPosition
+	scrollbar Scrollbar
+}
+
+// textPosition is the index of a cell in the lines of a Paragraph.
+type textPosition struct {
+	line int
+	col  int
+}
+
+func (self textPosition) before(other textPosition) bool {
+	return self.line < other.line || (self.line == other.line && self.col < other.col)
 }
 
 func NewParagraph() *Paragraph {
 	return &Paragraph{
-		Block:     *NewBlock(),
-		TextStyle: Theme.Paragraph.Text,
-		WrapText:  true,
+		Block:            *NewBlock(),
+		TextStyle:        Theme.Paragraph.Text,
+		WrapText:         true,
+		TabWidth:         4,
+		ScrollbarStyle:   Theme.Paragraph.Scrollbar,
+		SelectionStyle:   Theme.Paragraph.Selection,
+		LinkStyle:        Theme.Paragraph.Link,
+		FocusedLinkStyle: Theme.Paragraph.FocusedLink,
+		focusedLink:      -1,
+		following:        true,
+	}
+}
+
+// AppendText adds s to the end of Text, dropping the oldest lines beyond MaxLines.
+func (self *Paragraph) AppendText(s string) {
+	self.Text += s
+	if self.MaxLines <= 0 {
+		return
+	}
+	newlines := strings.Count(self.Text, "\n")
+	if strings.HasSuffix(self.Text, "\n") {
+		newlines--
+	}
+	for ; newlines >= self.MaxLines; newlines-- {
+		self.Text = self.Text[strings.IndexByte(self.Text, '\n')+1:]
+	}
+}
+
+// AppendLine adds line to Text on a new line, dropping the oldest lines beyond MaxLines.
+func (self *Paragraph) AppendLine(line string) {
+	if self.Text != "" && !strings.HasSuffix(self.Text, "\n") {
+		line = "\n" + line
 	}
+	self.AppendText(line)
+}
+
+// Write appends p to Text with AppendText so a Paragraph can be used as the output of a logger.
+// It locks the Paragraph, which is safe to use from other goroutines while it is rendered.
+func (self *Paragraph) Write(p []byte) (int, error) {
+	self.Lock()
+	defer self.Unlock()
+	self.AppendText(string(p))
+	return len(p), nil
+}
+
+var alignmentMarkup = map[string]Alignment{
+	"[align:left]":    AlignLeft,
+	"[align:center]":  AlignCenter,
+	"[align:right]":   AlignRight,
+	"[align:justify]": AlignJustify,
+}
+
+// lines returns the text split into the lines drawn, wrapped to width when WrapText is set, and aligned.
+// Links and alignment markup are only parsed outside of Markdown mode.
+func (self *Paragraph) lines(width int) ([][]Cell, []textLink) {
+	if self.Language != "" {
+		cells := highlightCode(expandTabs(self.Text, self.TabWidth), self.Language, self.TextStyle)
+		if self.WrapText {
+			cells = WrapCells(cells, uint(width))
+		}
+		return SplitCells(cells, '\n'), nil
+	}
+	if self.ANSIParse {
+		cells := ParseANSI(expandTabs(self.Text, self.TabWidth), self.TextStyle)
+		if self.WrapText {
+			cells = WrapCells(cells, uint(width))
+		}
+		return SplitCells(cells, '\n'), nil
+	}
+	if self.Markdown {
+		lines := renderMarkdown(self.Text, width, self.TextStyle)
+		for i := range lines {
+			line, _, alignment := reorderLine(lines[i], 0, self.Direction, self.TextAlignment)
+			lines[i], _ = alignLine(line, width, alignment, i == len(lines)-1 || len(lines[i+1]) == 0)
+		}
+		return lines, nil
+	}
+
+	key := paragraphLinesKey{self.Text, width, self.TextStyle, self.LinkStyle, self.WrapText, self.TextAlignment, self.Direction}
+	if cached, ok := self.markupCache[key]; ok {
+		return cached.lines, cached.links
+	}
+	lines, links := self.markupLines(width)
+	// Draw lays the text out for two widths, with and without the scrollbar
+	if len(self.markupCache) >= 2 {
+		self.markupCache = nil
+	}
+	if self.markupCache == nil {
+		self.markupCache = map[paragraphLinesKey]paragraphLines{}
+	}
+	self.markupCache[key] = paragraphLines{lines, links}
+	return lines, links
+}
+
+type paragraphLinesKey struct {
+	text      string
+	width     int
+	textStyle Style
+	linkStyle Style
+	wrap      bool
+	alignment Alignment
+	direction Direction
+}
+
+type paragraphLines struct {
+	lines [][]Cell
+	links []textLink
+}
+
+// markupLines returns the lines of the text with its style, link, and alignment markup parsed.
+func (self *Paragraph) markupLines(width int) ([][]Cell, []textLink) {
+	cells, links := parseLinks(self.Text, self.TextStyle, self.LinkStyle)
+	sourceLines, sourceLinks := splitLinks(cells, links)
+
+	lines := [][]Cell{}
+	placed := []textLink{}
+	for i, source := range sourceLines {
+		alignment := self.TextAlignment
+		skip := 0
+		text := CellsToString(source)
+		for markup, a := range alignmentMarkup {
+			if strings.HasPrefix(text, markup) {
+				alignment, skip = a, len(markup)
+				break
+			}
+		}
+		source = source[skip:]
+		direction := ResolveDirection(source, self.Direction)
+
+		lineLinks := []textLink{}
+		for _, link := range sourceLinks {
+			if link.line == i {
+				link.line, link.from, link.to = 0, MaxInt(link.from-skip, 0), link.to-skip
+				lineLinks = append(lineLinks, link)
+			}
+		}
+		if self.WrapText {
+			source = WrapCells(source, uint(width))
+		}
+		wrapped, wrappedLinks := splitLinks(source, lineLinks)
+		if len(wrapped) == 0 {
+			wrapped = [][]Cell{{}}
+		}
+
+		for j, line := range wrapped {
+			last := j == len(wrapped)-1
+			line, order, lineAlignment := reorderLine(line, 0, direction, alignment)
+			if order != nil && direction == DirectionRTL && alignment == AlignJustify && last {
+				lineAlignment = AlignRight
+			}
+			aligned, index := alignLine(line, width, lineAlignment, last)
+			for _, link := range wrappedLinks {
+				if link.line == j {
+					link.line = len(lines)
+					if order != nil {
+						link.from, link.to = visualSpan(order, link.from, link.to)
+					}
+					link.from, link.to = index(link.from), index(link.to-1)+1
+					placed = append(placed, link)
+				}
+			}
+			lines = append(lines, aligned)
+		}
+	}
+	return lines, placed
+}
+
+// alignLine pads line to be aligned within width, stretching its spaces for AlignJustify unless it is
+// the last line of a paragraph. It returns the aligned line and a function giving the new index of a cell.
+func alignLine(line []Cell, width int, alignment Alignment, last bool) ([]Cell, func(int) int) {
+	identity := func(i int) int { return i }
+	extra := width - rw.StringWidth(CellsToString(line))
+	if extra <= 0 || len(line) == 0 {
+		return line, identity
+	}
+
+	switch alignment {
+	case AlignCenter, AlignRight:
+		pad := extra
+		if alignment == AlignCenter {
+			pad = extra / 2
+		}
+		padding := make([]Cell, pad)
+		for i := range padding {
+			padding[i] = NewCell(' ', line[0].Style)
+		}
+		return append(padding, line...), func(i int) int { return i + pad }
+	case AlignJustify:
+		if last {
+			return line, identity
+		}
+		// the spaces between words, leaving out the indentation
+		gaps := []int{}
+		for i, cell := range line {
+			if cell.Rune == ' ' && i > 0 && line[i-1].Rune != ' ' {
+				gaps = append(gaps, i)
+			}
+		}
+		if len(gaps) == 0 {
+			return line, identity
+		}
+		index := make([]int, len(line)+1)
+		justified := make([]Cell, 0, len(line)+extra)
+		for i, cell := range line {
+			index[i] = len(justified)
+			justified = append(justified, cell)
+			for g, gap := range gaps {
+				if gap != i {
+					continue
+				}
+				n := extra / len(gaps)
+				if g < extra%len(gaps) {
+					n++
+				}
+				for ; n > 0; n-- {
+					justified = append(justified, NewCell(' ', cell.Style))
+				}
+			}
+		}
+		index[len(line)] = len(justified)
+		return justified, func(i int) int { return index[MinInt(MaxInt(i, 0), len(line))] }
+	}
+	return line, identity
+}
+
+// reorderLine returns a line in the order it is drawn in with ReorderCells, trimmed to width
+// when it is reordered and wider, along with the index in line of every cell and the alignment
+// it is drawn with: AlignLeft aligns right-to-left lines to the right, where they start.
+// The index is nil when the line is drawn as it is.
+func reorderLine(line []Cell, width int, direction Direction, alignment Alignment) ([]Cell, []int, Alignment) {
+	reordered, index := ReorderCells(line, direction)
+	if index == nil {
+		return line, nil, alignment
+	}
+	if width > 0 && rw.StringWidth(CellsToString(line)) > width {
+		reordered, index = ReorderCells(TrimCells(line, width), direction)
+	}
+	if alignment == AlignLeft && ResolveDirection(line, direction) == DirectionRTL {
+		alignment = AlignRight
+	}
+	return reordered, index, alignment
+}
+
+// visualSpan returns the span a reordered line draws the cells from..to of the line in, with
+// the index returned by reorderLine.
+func visualSpan(index []int, from, to int) (int, int) {
+	first, last := len(index), -1
+	for i, k := range index {
+		if k >= from && k < to {
+			first, last = MinInt(first, i), MaxInt(last, i)
+		}
+	}
+	if first > last {
+		return from, from
+	}
+	return first, last + 1
 }
 
 func (self *Paragraph) Draw(buf *Buffer) {
 	self.Block.Draw(buf)
 
-	cells := ParseStyles(self.Text, self.TextStyle)
-	if self.WrapText {
-		cells = WrapCells(cells, uint(self.Inner.Dx()))
+	width := self.Inner.Dx()
+	rows, links := self.lines(width)
+	// leave the last column to the scrollbar when the text doesn't fit
+	scrollbar := len(rows) > self.Inner.Dy() && width > 1
+	if scrollbar {
+		width--
+		rows, links = self.lines(width)
+	}
+	self.drawnLines, self.drawnLinks = rows, links
+
+	// keep the focused link in view after moving the focus
+	if self.revealLink {
+		self.revealLink = false
+		for _, link := range links {
+			if link.index == self.focusedLink {
+				self.topLine = MinInt(MaxInt(self.topLine, link.line-self.Inner.Dy()+1), link.line)
+				break
+			}
+		}
 	}
 
-	rows := SplitCells(cells, '\n')
+	// keep the cursor in view while selecting with the keyboard
+	if self.selecting {
+		if self.cursor.line < self.topLine {
+			self.topLine = self.cursor.line
+		} else if self.cursor.line >= self.topLine+self.Inner.Dy() {
+			self.topLine = self.cursor.line - self.Inner.Dy() + 1
+		}
+	}
+	if self.Follow && self.following && !self.selecting {
+		self.topLine = len(rows)
+	}
+	self.topLine = MaxInt(MinInt(self.topLine, len(rows)-self.Inner.Dy()), 0)
+	self.following = self.topLine == MaxInt(len(rows)-self.Inner.Dy(), 0)
 
-	for y, row := range rows {
+	start, end := self.selectionRange()
+	for y, row := range rows[self.topLine:] {
 		if y+self.Inner.Min.Y >= self.Inner.Max.Y {
 			break
 		}
-		row = TrimCells(row, self.Inner.Dx())
-		for _, cx := range BuildCellWithXArray(row) {
+		row = TrimCells(row, width)
+		for k, cx := range BuildCellWithXArray(row) {
 			x, cell := cx.X, cx.Cell
+			if p := (textPosition{self.topLine + y, k}); self.selected && !p.before(start) && !end.before(p) {
+				cell.Style = self.SelectionStyle
+			} else if link, ok := self.linkAt(p); ok && link.index == self.focusedLink {
+				cell.Style = self.FocusedLinkStyle
+			}
 			buf.SetCell(cell, image.Pt(x, y).Add(self.Inner.Min))
 		}
+		// show the cursor past the end of a line
+		if self.selecting && self.cursor.line == self.topLine+y && self.cursor.col >= len(row) && len(row) < width {
+			buf.SetCell(NewCell(' ', self.SelectionStyle), image.Pt(rw.StringWidth(CellsToString(row)), y).Add(self.Inner.Min))
+		}
+	}
+
+	area := image.Rectangle{}
+	if scrollbar {
+		area = image.Rect(self.Inner.Max.X-1, self.Inner.Min.Y, self.Inner.Max.X, self.Inner.Max.Y)
+	}
+	self.scrollbar.Style = self.ScrollbarStyle
+	self.scrollbar.Draw(buf, area, self.topLine, self.Inner.Dy(), len(rows))
+}
+
+// ScrollAmount scrolls the text by amount lines. If amount is < 0, then scroll up.
+// The scroll position is limited to the length of the text on the next Draw.
+func (self *Paragraph) ScrollAmount(amount int) {
+	self.topLine = MaxInt(self.topLine+amount, 0)
+	if amount < 0 {
+		self.following = false
+	}
+}
+
+func (self *Paragraph) ScrollUp() {
+	self.ScrollAmount(-1)
+}
+
+func (self *Paragraph) ScrollDown() {
+	self.ScrollAmount(1)
+}
+
+func (self *Paragraph) ScrollPageUp() {
+	self.ScrollAmount(-MaxInt(self.Inner.Dy()-1, 1))
+}
+
+func (self *Paragraph) ScrollPageDown() {
+	self.ScrollAmount(MaxInt(self.Inner.Dy()-1, 1))
+}
+
+func (self *Paragraph) ScrollTop() {
+	self.topLine = 0
+	self.following = false
+}
+
+// ScrollBottom scrolls to the last lines of the text.
+func (self *Paragraph) ScrollBottom() {
+	lines, _ := self.lines(self.Inner.Dx())
+	self.topLine = len(lines)
+	self.following = true
+}
+
+// paragraphState is the state of a Paragraph saved by SaveState.
+type paragraphState struct {
+	TopLine   int  `json:"topLine"`
+	Following bool `json:"following"`
+}
+
+// SaveState implements Persistent with the scroll position, and whether the Paragraph follows
+// the end of its text with Follow.
+func (self *Paragraph) SaveState() ([]byte, error) {
+	return json.Marshal(paragraphState{self.topLine, self.following})
+}
+
+// RestoreState implements Persistent. The scroll position is kept within the text by Draw.
+func (self *Paragraph) RestoreState(data []byte) error {
+	state := paragraphState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	self.topLine, self.following = MaxInt(state.TopLine, 0), state.Following
+	return nil
+}
+
+// MinimapLines returns the lines of the text drawn by the last Draw, for a Minimap.
+func (self *Paragraph) MinimapLines() [][]Cell {
+	if self.drawnLines == nil {
+		lines, _ := self.lines(self.Inner.Dx())
+		return lines
+	}
+	return self.drawnLines
+}
+
+// Viewport returns the first line in view and the number of lines in view.
+func (self *Paragraph) Viewport() (int, int) {
+	return self.topLine, self.Inner.Dy()
+}
+
+// ScrollToLine scrolls the text to show line first, suspending Follow unless it's the end.
+func (self *Paragraph) ScrollToLine(line int) {
+	self.topLine = MaxInt(line, 0)
+	self.following = false
+}
+
+// HandleMouse scrolls with the mouse wheel and the scrollbar, and reports whether the event was used.
+func (self *Paragraph) HandleMouse(e Event) bool {
+	if offset, ok := self.scrollbar.HandleMouse(e); ok {
+		self.ScrollToLine(offset)
+		return true
+	}
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Rectangle) {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.ScrollAmount(-WheelSteps(e))
+	case "<MouseWheelDown>":
+		self.ScrollAmount(WheelSteps(e))
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleScrollKey scrolls for <Up>, <Down>, <PageUp>, <PageDown>, <Home>, and <End>
+// and reports whether the keyboard event ID was used.
+func (self *Paragraph) HandleScrollKey(id string) bool {
+	switch id {
+	case "<Up>":
+		self.ScrollUp()
+	case "<Down>":
+		self.ScrollDown()
+	case "<PageUp>":
+		self.ScrollPageUp()
+	case "<PageDown>":
+		self.ScrollPageDown()
+	case "<Home>":
+		self.ScrollTop()
+	case "<End>":
+		self.ScrollBottom()
+	default:
+		return false
+	}
+	return true
+}
+
+// selectionRange returns the ends of the selection in order.
+func (self *Paragraph) selectionRange() (textPosition, textPosition) {
+	if self.cursor.before(self.anchor) {
+		return self.cursor, self.anchor
+	}
+	return self.anchor, self.cursor
+}
+
+// SelectedText returns the text selected, with the lines it spans separated by newlines.
+func (self *Paragraph) SelectedText() string {
+	if !self.selected {
+		return ""
+	}
+	start, end := self.selectionRange()
+	lines := []string{}
+	for i := start.line; i <= end.line && i < len(self.drawnLines); i++ {
+		line := self.drawnLines[i]
+		from, to := 0, len(line)
+		if i == start.line {
+			from = MinInt(start.col, len(line))
+		}
+		if i == end.line {
+			to = MinInt(end.col+1, len(line))
+		}
+		lines = append(lines, CellsToString(line[from:MaxInt(to, from)]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Copy puts the selected text on the clipboard with CopyToClipboard and ends the selection.
+func (self *Paragraph) Copy() error {
+	text := self.SelectedText()
+	self.ClearSelection()
+	if text == "" {
+		return nil
+	}
+	return CopyToClipboard(text)
+}
+
+// ClearSelection removes the selection.
+func (self *Paragraph) ClearSelection() {
+	self.selected, self.selecting = false, false
+}
+
+// StartSelection starts selecting with the keyboard from the first line in view.
+// HandleSelectionKey then moves the end of the selection.
+func (self *Paragraph) StartSelection() {
+	self.anchor = textPosition{self.topLine, 0}
+	self.cursor = self.anchor
+	self.selected, self.selecting = true, true
+}
+
+// Selecting reports whether a keyboard selection is in progress.
+func (self *Paragraph) Selecting() bool {
+	return self.selecting
+}
+
+// HandleSelectionKey applies a keyboard event ID to the selection started with StartSelection and
+// reports whether it was used. The arrow keys, <Home>, and <End> move the end of the selection,
+// <Space> starts it again from there, <Enter> or y copies it, and <Escape> cancels it.
+func (self *Paragraph) HandleSelectionKey(id string) bool {
+	if !self.selecting {
+		return false
+	}
+	lineLength := func(line int) int {
+		if line < len(self.drawnLines) {
+			return len(self.drawnLines[line])
+		}
+		return 0
+	}
+	switch id {
+	case "<Left>", "h":
+		if self.cursor.col > 0 {
+			self.cursor.col--
+		} else if self.cursor.line > 0 {
+			self.cursor.line--
+			self.cursor.col = MaxInt(lineLength(self.cursor.line)-1, 0)
+		}
+	case "<Right>", "l":
+		if self.cursor.col < lineLength(self.cursor.line)-1 {
+			self.cursor.col++
+		} else if self.cursor.line < len(self.drawnLines)-1 {
+			self.cursor = textPosition{self.cursor.line + 1, 0}
+		}
+	case "<Up>", "k":
+		self.cursor.line = MaxInt(self.cursor.line-1, 0)
+	case "<Down>", "j":
+		self.cursor.line = MaxInt(MinInt(self.cursor.line+1, len(self.drawnLines)-1), 0)
+	case "<Home>", "0":
+		self.cursor.col = 0
+	case "<End>", "$":
+		self.cursor.col = MaxInt(lineLength(self.cursor.line)-1, 0)
+	case "<Space>":
+		self.anchor = self.cursor
+	case "<Enter>", "y":
+		self.Copy()
+	case "<Escape>":
+		self.ClearSelection()
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouseSelect selects text by dragging over it with the left mouse button and reports whether
+// the event was used. The selection stays after the button is released, until Copy or ClearSelection.
+func (self *Paragraph) HandleMouseSelect(e Event) bool {
+	m, ok := e.Payload.(Mouse)
+	if !ok {
+		return false
+	}
+	p := image.Pt(m.X, m.Y)
+	if self.scrollbar.Dragging() || self.scrollbar.Contains(p) {
+		return false
+	}
+	switch {
+	case e.ID == "<MouseRelease>":
+		return self.selected && !self.selecting
+	case e.ID != "<MouseLeft>":
+		return false
+	case !m.Drag:
+		if !p.In(self.Inner) {
+			return false
+		}
+		self.selecting = false
+		self.anchor = self.positionAt(p)
+		self.cursor = self.anchor
+		self.selected = false
+		return true
+	default:
+		self.cursor = self.positionAt(p)
+		self.selected = true
+		return true
+	}
+}
+
+// positionAt returns the position of the cell drawn at p by the last Draw.
+func (self *Paragraph) positionAt(p image.Point) textPosition {
+	line := MaxInt(MinInt(self.topLine+p.Y-self.Inner.Min.Y, len(self.drawnLines)-1), 0)
+	position := textPosition{line, 0}
+	if line >= len(self.drawnLines) {
+		return position
+	}
+	for k, cx := range BuildCellWithXArray(self.drawnLines[line]) {
+		if self.Inner.Min.X+cx.X > p.X {
+			break
+		}
+		position.col = k
+	}
+	return position
+}
+
+// textLink is the part of a link drawn on one line, from the cell at index from up to to.
+// index tells apart the links of the text, which can span several lines.
+type textLink struct {
+	index    int
+	line     int
+	from, to int
+	url      string
+}
+
+var linkPattern = regexp.MustCompile(`\[([^\]]*)\]\(link:([^)]*)\)`)
+
+// parseLinks parses the style markup of text, drawing the labels of links with linkStyle.
+// The links returned hold the indexes of their cells with line 0.
+func parseLinks(text string, style Style, linkStyle Style) ([]Cell, []textLink) {
+	cells := []Cell{}
+	links := []textLink{}
+	last := 0
+	for i, match := range linkPattern.FindAllStringSubmatchIndex(text, -1) {
+		cells = append(cells, ParseStyles(text[last:match[0]], style)...)
+		label := []rune(text[match[2]:match[3]])
+		links = append(links, textLink{i, 0, len(cells), len(cells) + len(label), text[match[4]:match[5]]})
+		cells = append(cells, RunesToStyledCells(label, linkStyle)...)
+		last = match[1]
+	}
+	cells = append(cells, ParseStyles(text[last:], style)...)
+	return cells, links
+}
+
+// splitLinks splits cells into lines at newlines, placing the links on the lines they are drawn on.
+func splitLinks(cells []Cell, links []textLink) ([][]Cell, []textLink) {
+	lines := [][]Cell{}
+	placed := []textLink{}
+	line := []Cell{}
+	for i, cell := range cells {
+		if cell.Rune == '\n' {
+			lines = append(lines, line)
+			line = []Cell{}
+			continue
+		}
+		for _, link := range links {
+			if i < link.from || i >= link.to {
+				continue
+			}
+			if n := len(placed); n > 0 && placed[n-1].index == link.index && placed[n-1].line == len(lines) {
+				placed[n-1].to++
+			} else {
+				placed = append(placed, textLink{link.index, len(lines), len(line), len(line) + 1, link.url})
+			}
+		}
+		line = append(line, cell)
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines, placed
+}
+
+// linkAt returns the link drawn at a position of the text.
+func (self *Paragraph) linkAt(p textPosition) (textLink, bool) {
+	for _, link := range self.drawnLinks {
+		if link.line == p.line && p.col >= link.from && p.col < link.to {
+			return link, true
+		}
+	}
+	return textLink{}, false
+}
+
+// linkCount returns the number of links drawn by the last Draw.
+func (self *Paragraph) linkCount() int {
+	count := 0
+	for _, link := range self.drawnLinks {
+		count = MaxInt(count, link.index+1)
+	}
+	return count
+}
+
+// NextLink moves the focus to the next link, scrolling it into view.
+func (self *Paragraph) NextLink() {
+	if count := self.linkCount(); count > 0 {
+		self.focusedLink = (self.focusedLink + 1) % count
+		self.revealLink = true
+	}
+}
+
+// PreviousLink moves the focus to the previous link, scrolling it into view.
+func (self *Paragraph) PreviousLink() {
+	if count := self.linkCount(); count > 0 {
+		self.focusedLink = (self.focusedLink - 1 + count) % count
+		self.revealLink = true
+	}
+}
+
+// FocusedLink returns the url of the focused link, and false if no link is focused.
+func (self *Paragraph) FocusedLink() (string, bool) {
+	for _, link := range self.drawnLinks {
+		if link.index == self.focusedLink {
+			return link.url, true
+		}
+	}
+	return "", false
+}
+
+// ActivateLink calls OnLink with the url of the focused link.
+func (self *Paragraph) ActivateLink() {
+	if url, ok := self.FocusedLink(); ok && self.OnLink != nil {
+		self.OnLink(url)
+	}
+}
+
+// HandleMouseLink focuses and activates the link clicked with the left mouse button
+// and reports whether the event was used.
+func (self *Paragraph) HandleMouseLink(e Event) bool {
+	m, ok := e.Payload.(Mouse)
+	if !ok || e.ID != "<MouseLeft>" || m.Drag || !image.Pt(m.X, m.Y).In(self.Inner) {
+		return false
+	}
+	link, ok := self.linkAt(self.positionAt(image.Pt(m.X, m.Y)))
+	if !ok {
+		return false
 	}
+	self.focusedLink = link.index
+	self.ActivateLink()
+	return true
 }
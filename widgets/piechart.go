@@ -3,6 +3,9 @@ package widgets
 import (
 	"image"
 	"math"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
 
 	. "github.com/reaalkhalil/termui"
 )
@@ -23,22 +26,57 @@ type PieChart struct {
 	Colors         []Color       // colors to by cycled through
 	LabelFormatter PieChartLabel // callback function for labels
 	AngleOffset    float64       // which angle to start drawing at? (see piechartOffsetUp)
+	// InnerRadius leaves the middle of the chart hollow, drawing a donut. It is a fraction of the
+	// radius between 0 and 1.
+	InnerRadius float64
+	// CenterText is drawn in the middle of a donut, like a total or a percentage.
+	CenterText      string
+	CenterTextStyle Style
+	// Labels name the slices in the legend.
+	Labels []string
+	// ShowLegend lists the slices with their colors, Labels, and formatted values at the right.
+	ShowLegend  bool
+	LegendStyle Style
+	// LabelsOutside draws the formatted values outside of the circle with leader lines to their
+	// slices, instead of inside the slices.
+	LabelsOutside bool
 }
 
 // NewPieChart Creates a new pie chart with reasonable defaults and no labels.
 func NewPieChart() *PieChart {
 	return &PieChart{
-		Block:       *NewBlock(),
-		Colors:      Theme.PieChart.Slices,
-		AngleOffset: piechartOffsetUp,
+		Block:           *NewBlock(),
+		Colors:          Theme.PieChart.Slices,
+		AngleOffset:     piechartOffsetUp,
+		CenterTextStyle: Theme.PieChart.CenterText,
+		LegendStyle:     Theme.PieChart.Legend,
 	}
 }
 
 func (self *PieChart) Draw(buf *Buffer) {
 	self.Block.Draw(buf)
 
-	center := self.Inner.Min.Add(self.Inner.Size().Div(2))
-	radius := MinFloat64(float64(self.Inner.Dx()/2/xStretch), float64(self.Inner.Dy()/2))
+	area := self.Inner
+	if self.ShowLegend {
+		area.Max.X -= self.drawLegend(buf)
+	}
+
+	labels := make([]string, len(self.Data))
+	labelWidth := 0
+	if self.LabelFormatter != nil {
+		for i, v := range self.Data {
+			labels[i] = self.LabelFormatter(i, v)
+			labelWidth = MaxInt(labelWidth, rw.StringWidth(labels[i]))
+		}
+	}
+
+	center := area.Min.Add(area.Size().Div(2))
+	radius := MinFloat64(float64(area.Dx()/2/xStretch), float64(area.Dy()/2))
+	if self.LabelsOutside {
+		// leave room for the leader lines and labels around the circle
+		radius = MinFloat64(float64(area.Dx()/2-labelWidth-1)/xStretch-2, float64(area.Dy()/2-2))
+		radius = MaxFloat64(radius, 1)
+	}
 
 	// compute slice sizes
 	sum := SumFloat64Slice(self.Data)
@@ -47,8 +85,10 @@ func (self *PieChart) Draw(buf *Buffer) {
 		sliceSizes[i] = v / sum * fullCircle
 	}
 
+	innerRadius := radius * MaxFloat64(MinFloat64(self.InnerRadius, 1), 0)
 	borderCircle := &circle{center, radius}
-	middleCircle := circle{Point: center, radius: radius / 2.0}
+	innerCircle := circle{Point: center, radius: innerRadius}
+	middleCircle := circle{Point: center, radius: (radius + innerRadius) / 2.0}
 
 	// draw sectors
 	phi := self.AngleOffset
@@ -56,6 +96,9 @@ func (self *PieChart) Draw(buf *Buffer) {
 		for j := 0.0; j < size; j += resolutionFactor {
 			borderPoint := borderCircle.at(phi + j)
 			line := line{P1: center, P2: borderPoint}
+			if innerRadius > 0 {
+				line.P1 = innerCircle.at(phi + j)
+			}
 			line.draw(NewCell(SHADED_BLOCKS[1], NewStyle(SelectColor(self.Colors, i))), buf)
 		}
 		phi += size
@@ -65,18 +108,91 @@ func (self *PieChart) Draw(buf *Buffer) {
 	if self.LabelFormatter != nil {
 		phi = self.AngleOffset
 		for i, size := range sliceSizes {
+			style := NewStyle(SelectColor(self.Colors, i))
+			if self.LabelsOutside {
+				self.drawOutsideLabel(buf, area, labels[i], style, center, radius, phi+size/2.0)
+				phi += size
+				continue
+			}
 			labelPoint := middleCircle.at(phi + size/2.0)
-			if len(self.Data) == 1 {
+			if len(self.Data) == 1 && innerRadius == 0 {
 				labelPoint = center
 			}
 			buf.SetString(
-				self.LabelFormatter(i, self.Data[i]),
-				NewStyle(SelectColor(self.Colors, i)),
+				labels[i],
+				style,
 				image.Pt(labelPoint.X, labelPoint.Y),
 			)
 			phi += size
 		}
 	}
+
+	// draw center text
+	if self.CenterText != "" {
+		width := area.Dx()
+		if innerRadius > 0 {
+			width = int(xStretch*innerRadius)*2 - 1
+		}
+		lines := strings.Split(self.CenterText, "\n")
+		for i, text := range lines {
+			text = TrimString(text, width)
+			buf.SetString(
+				text,
+				self.CenterTextStyle,
+				image.Pt(center.X-rw.StringWidth(text)/2, center.Y-len(lines)/2+i),
+			)
+		}
+	}
+}
+
+// drawOutsideLabel draws a label next to the circle at angle phi, with a dotted leader line
+// from the edge of the circle.
+func (self *PieChart) drawOutsideLabel(buf *Buffer, area image.Rectangle, label string, style Style, center image.Point, radius float64, phi float64) {
+	for r := radius + .5; r < radius+2; r += .5 {
+		point := circle{center, r}.at(phi)
+		if point.In(area) {
+			buf.SetCell(NewCell(DOT, style), point)
+		}
+	}
+	anchor := circle{center, radius + 2}.at(phi)
+	if anchor.Y < area.Min.Y || anchor.Y >= area.Max.Y {
+		return
+	}
+	if math.Cos(phi) >= 0 {
+		label = TrimString(label, area.Max.X-anchor.X-1)
+		buf.SetString(label, style, image.Pt(anchor.X+1, anchor.Y))
+	} else {
+		label = TrimString(label, anchor.X-area.Min.X)
+		buf.SetString(label, style, image.Pt(anchor.X-rw.StringWidth(label), anchor.Y))
+	}
+}
+
+// drawLegend lists the slices at the right of the chart and returns the width it takes up.
+func (self *PieChart) drawLegend(buf *Buffer) int {
+	entries := make([]string, len(self.Data))
+	width := 0
+	for i, v := range self.Data {
+		parts := []string{}
+		if i < len(self.Labels) {
+			parts = append(parts, self.Labels[i])
+		}
+		if self.LabelFormatter != nil {
+			parts = append(parts, self.LabelFormatter(i, v))
+		}
+		entries[i] = strings.Join(parts, " ")
+		width = MaxInt(width, rw.StringWidth(entries[i])+2)
+	}
+	width = MinInt(width, self.Inner.Dx()/2)
+	x := self.Inner.Max.X - width
+	y := self.Inner.Min.Y + MaxInt((self.Inner.Dy()-len(entries))/2, 0)
+	for i, entry := range entries {
+		if y+i >= self.Inner.Max.Y {
+			break
+		}
+		buf.SetCell(NewCell(BARS[len(BARS)-1], NewStyle(SelectColor(self.Colors, i))), image.Pt(x, y+i))
+		buf.SetString(TrimString(entry, width-2), self.LegendStyle, image.Pt(x+2, y+i))
+	}
+	return width + 1
 }
 
 type circle struct {
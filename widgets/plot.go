@@ -115,6 +115,10 @@ func (self *Plot) renderBraille(buf *Buffer, drawArea image.Rectangle, minVal, m
 		}
 	case LineChart:
 		for i, line := range self.Data {
+			if len(line) < 2 {
+				// a line needs two points
+				continue
+			}
 			previousHeight := int((line[1] / maxVal) * float64(drawArea.Dy()-1))
 			for j, val := range line[1:] {
 				height := int((val / maxVal) * float64(drawArea.Dy()-1))
@@ -134,6 +138,10 @@ func (self *Plot) renderBraille(buf *Buffer, drawArea image.Rectangle, minVal, m
 		}
 	case LineChartScaled:
 		for i, line := range self.Data {
+			if len(line) < 2 {
+				// a line needs two points
+				continue
+			}
 			previousHeight := int((line[1] - minVal) / (maxVal - minVal) * float64(drawArea.Dy()-1))
 			for j, val := range line[1:] {
 				height := int((val - minVal) / (maxVal - minVal) * float64(drawArea.Dy()-1))
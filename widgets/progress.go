@@ -0,0 +1,296 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// ProgressTask counts the work done towards a Total, timing it to estimate its rate and the time
+// left. Its methods may be called from any goroutine.
+type ProgressTask struct {
+	Label string
+
+	lock     sync.Mutex
+	total    int64
+	done     int64
+	started  time.Time
+	finished time.Time
+	err      error
+}
+
+// NewProgressTask returns a task with a label and total amount of work, started now.
+func NewProgressTask(label string, total int64) *ProgressTask {
+	return &ProgressTask{
+		Label:   label,
+		total:   total,
+		started: time.Now(),
+	}
+}
+
+// Add counts n more units of work as done, finishing the task once the total is reached.
+func (self *ProgressTask) Add(n int64) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.setDone(self.done + n)
+}
+
+// SetDone sets the units of work done, finishing the task once the total is reached.
+func (self *ProgressTask) SetDone(done int64) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.setDone(done)
+}
+
+func (self *ProgressTask) setDone(done int64) {
+	if !self.finished.IsZero() {
+		return
+	}
+	self.done = done
+	if self.total > 0 && self.done >= self.total {
+		self.done = self.total
+		self.finished = time.Now()
+	}
+}
+
+// SetTotal changes the total amount of work, for work discovered along the way.
+func (self *ProgressTask) SetTotal(total int64) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.total = total
+	self.setDone(self.done)
+}
+
+// Finish marks the task as finished before its total is reached.
+func (self *ProgressTask) Finish() {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if self.finished.IsZero() {
+		self.finished = time.Now()
+	}
+}
+
+// Fail marks the task as failed with err.
+func (self *ProgressTask) Fail(err error) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.err = err
+	if self.finished.IsZero() {
+		self.finished = time.Now()
+	}
+}
+
+// Progress returns the units of work done and the total.
+func (self *ProgressTask) Progress() (int64, int64) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	return self.done, self.total
+}
+
+// Finished reports whether the task has finished or failed.
+func (self *ProgressTask) Finished() bool {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	return !self.finished.IsZero()
+}
+
+// Err returns the error the task failed with, or nil.
+func (self *ProgressTask) Err() error {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	return self.err
+}
+
+// Elapsed returns how long the task has been running, or ran for once it has finished.
+func (self *ProgressTask) Elapsed() time.Duration {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	return self.elapsed()
+}
+
+func (self *ProgressTask) elapsed() time.Duration {
+	if !self.finished.IsZero() {
+		return self.finished.Sub(self.started)
+	}
+	return time.Since(self.started)
+}
+
+// Rate returns the average units of work done per second.
+func (self *ProgressTask) Rate() float64 {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	return self.rate()
+}
+
+func (self *ProgressTask) rate() float64 {
+	seconds := self.elapsed().Seconds()
+	if seconds <= 0 {
+		return 0
+	}
+	return float64(self.done) / seconds
+}
+
+// ETA returns the estimated time left at the current Rate, or -1 if it can't be estimated.
+func (self *ProgressTask) ETA() time.Duration {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if !self.finished.IsZero() {
+		return 0
+	}
+	rate := self.rate()
+	if rate <= 0 || self.total <= 0 {
+		return -1
+	}
+	return time.Duration(float64(self.total-self.done) / rate * float64(time.Second))
+}
+
+// Progress shows a bar for each of its Tasks, stacked one per row, with the work done, the rate,
+// and the time left. Finished tasks are drawn with FinishedStyle and failed ones with ErrorStyle
+// and their error.
+type Progress struct {
+	Block
+	Tasks []*ProgressTask
+	// Unit is shown after the rate, such as "files" for "12.5 files/s".
+	Unit string
+
+	LabelStyle    Style
+	BarStyle      Style
+	EmptyStyle    Style
+	FinishedStyle Style
+	ErrorStyle    Style
+	StatsStyle    Style
+}
+
+func NewProgress() *Progress {
+	return &Progress{
+		Block:         *NewBlock(),
+		LabelStyle:    Theme.Progress.Label,
+		BarStyle:      Theme.Progress.Bar,
+		EmptyStyle:    Theme.Progress.Empty,
+		FinishedStyle: Theme.Progress.Finished,
+		ErrorStyle:    Theme.Progress.Error,
+		StatsStyle:    Theme.Progress.Stats,
+	}
+}
+
+// AddTask adds a task with a label and total amount of work, started now, and returns it.
+func (self *Progress) AddTask(label string, total int64) *ProgressTask {
+	task := NewProgressTask(label, total)
+	self.Tasks = append(self.Tasks, task)
+	return task
+}
+
+// Finished reports whether every task has finished or failed.
+func (self *Progress) Finished() bool {
+	for _, task := range self.Tasks {
+		if !task.Finished() {
+			return false
+		}
+	}
+	return true
+}
+
+// formatDuration formats a duration rounded to the second, like 1m05s.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	if d >= time.Minute {
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%ds", int(d.Seconds()))
+}
+
+// stats returns the text shown after the bar of a task.
+func (self *Progress) stats(task *ProgressTask) string {
+	if err := task.Err(); err != nil {
+		return err.Error()
+	}
+	done, total := task.Progress()
+	unit := "/s"
+	if self.Unit != "" {
+		unit = " " + self.Unit + "/s"
+	}
+	if task.Finished() {
+		return fmt.Sprintf("%d/%d in %s", done, total, formatDuration(task.Elapsed()))
+	}
+	eta := "--"
+	if d := task.ETA(); d >= 0 {
+		eta = formatDuration(d)
+	}
+	return fmt.Sprintf("%d/%d %.1f%s ETA %s", done, total, task.Rate(), unit, eta)
+}
+
+func (self *Progress) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+
+	// the labels and stats are given columns as wide as the widest of them so the bars line up
+	labelWidth, statsWidth := 0, 0
+	stats := make([]string, len(self.Tasks))
+	for i, task := range self.Tasks {
+		stats[i] = self.stats(task)
+		labelWidth = MaxInt(labelWidth, rw.StringWidth(task.Label))
+		statsWidth = MaxInt(statsWidth, rw.StringWidth(stats[i]))
+	}
+	labelWidth = MinInt(labelWidth, self.Inner.Dx()/4)
+	statsWidth = MinInt(statsWidth, (self.Inner.Dx()-labelWidth)/2)
+
+	for i, task := range self.Tasks {
+		y := self.Inner.Min.Y + i
+		if y >= self.Inner.Max.Y {
+			break
+		}
+		x := self.Inner.Min.X
+		if labelWidth > 0 {
+			buf.SetString(TrimString(task.Label, labelWidth), self.LabelStyle, image.Pt(x, y))
+			x += labelWidth + 1
+		}
+
+		barStyle, statsStyle := self.BarStyle, self.StatsStyle
+		switch {
+		case task.Err() != nil:
+			barStyle, statsStyle = self.ErrorStyle, self.ErrorStyle
+		case task.Finished():
+			barStyle = self.FinishedStyle
+		}
+		barWidth := self.Inner.Max.X - x - statsWidth - 1
+
+		done, total := task.Progress()
+		ratio := 0.0
+		if total > 0 {
+			ratio = float64(done) / float64(total)
+		}
+		if task.Finished() && task.Err() == nil {
+			ratio = 1
+		}
+		drawProgressBar(buf, image.Rect(x, y, x+barWidth, y+1), ratio, barStyle, self.EmptyStyle)
+		buf.SetString(TrimString(stats[i], statsWidth), statsStyle, image.Pt(x+barWidth+1, y))
+	}
+}
+
+// drawProgressBar fills ratio of a one row area with blocks, using eighths of a cell for the
+// end of the bar.
+func drawProgressBar(buf *Buffer, rect image.Rectangle, ratio float64, style, emptyStyle Style) {
+	if rect.Dx() <= 0 {
+		return
+	}
+	eighths := int(ratio * float64(rect.Dx()*8))
+	for x := rect.Min.X; x < rect.Max.X; x++ {
+		filled := MinInt(MaxInt(eighths-(x-rect.Min.X)*8, 0), 8)
+		if filled == 0 {
+			buf.SetCell(NewCell(SHADED_BLOCKS[1], emptyStyle), image.Pt(x, rect.Min.Y))
+		} else {
+			buf.SetCell(NewCell(HORIZONTAL_BARS[filled], style), image.Pt(x, rect.Min.Y))
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// QRCodeLevel is the error correction level of a QR code, which can be read with about 7%, 15%,
+// 25%, or 30% of it damaged or covered.
+type QRCodeLevel int
+
+const (
+	QRCodeLow QRCodeLevel = iota
+	QRCodeMedium
+	QRCodeQuartile
+	QRCodeHigh
+)
+
+// QRCode draws Text as a QR code with half blocks, two modules per cell, so that modules are
+// square. The highest error correction level from Level up with a code fitting in the QRCode is
+// used, in the smallest version holding Text, and modules are scaled up to fill the QRCode.
+// Text is encoded as UTF-8 bytes, or more compactly when it's only digits, or only uppercase
+// letters, digits, and " $%*+-./:".
+type QRCode struct {
+	Block
+	Text string
+	// Level is the lowest error correction level used.
+	Level QRCodeLevel
+	// QuietZone is the width of the light margin around the code, which scanners need to find it.
+	QuietZone  int
+	DarkColor  Color
+	LightColor Color
+	// TextStyle is used for the message drawn when Text doesn't fit in a QR code or the QRCode.
+	TextStyle Style
+
+	// the code drawn by the last Draw
+	text    string
+	level   QRCodeLevel
+	version int
+	modules [][]bool
+}
+
+func NewQRCode() *QRCode {
+	return &QRCode{
+		Block:      *NewBlock(),
+		QuietZone:  2,
+		DarkColor:  Theme.QRCode.Dark,
+		LightColor: Theme.QRCode.Light,
+		TextStyle:  Theme.QRCode.Text,
+	}
+}
+
+// Version returns the version, from 1 to 40, and the error correction level of the code drawn by
+// the last Draw, or 0 if none was drawn.
+func (self *QRCode) Version() (int, QRCodeLevel) {
+	return self.version, self.level
+}
+
+// encode returns the modules of the code of Text at a level in a version, reusing those of the
+// last Draw when they're the same.
+func (self *QRCode) encode(segment qrSegment, version int, level QRCodeLevel) [][]bool {
+	if self.modules == nil || self.text != self.Text || self.version != version || self.level != level {
+		self.text, self.version, self.level = self.Text, version, level
+		self.modules = encodeQR(segment, version, level)
+	}
+	return self.modules
+}
+
+func (self *QRCode) drawMessage(buf *Buffer, message string) {
+	message = TrimString(message, self.Inner.Dx())
+	x := self.Inner.Min.X + (self.Inner.Dx()-rw.StringWidth(message))/2
+	buf.SetString(message, self.TextStyle, image.Pt(x, self.Inner.Min.Y+(self.Inner.Dy()-1)/2))
+}
+
+func (self *QRCode) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	if self.Inner.Dx() <= 0 || self.Inner.Dy() <= 0 {
+		return
+	}
+	width, height := self.Inner.Dx(), self.Inner.Dy()*2
+
+	segment := newQRSegment(self.Text)
+	version, level := qrVersion(segment, self.Level), self.Level
+	for l := QRCodeHigh; l > self.Level; l-- {
+		if v := qrVersion(segment, l); v > 0 && v*4+17+2*self.QuietZone <= MinInt(width, height) {
+			version, level = v, l
+			break
+		}
+	}
+	if version == 0 {
+		self.version, self.modules = 0, nil
+		self.drawMessage(buf, "Too long for a QR code")
+		return
+	}
+	modules := self.encode(segment, version, level)
+
+	total := len(modules) + 2*self.QuietZone
+	scale := MinInt(width/total, height/total)
+	if scale == 0 {
+		self.drawMessage(buf, fmt.Sprintf("Needs %dx%d", total, (total+1)/2))
+		return
+	}
+
+	// color returns the color of the module drawn at a half row and column of the Inner area
+	left, top := (width-total*scale)/2, (height-total*scale)/2
+	color := func(x, half int) (Color, bool) {
+		x, half = x-left, half-top
+		if x < 0 || half < 0 || x >= total*scale || half >= total*scale {
+			return ColorClear, false
+		}
+		mx, my := x/scale-self.QuietZone, half/scale-self.QuietZone
+		if mx >= 0 && my >= 0 && mx < len(modules) && my < len(modules) && modules[my][mx] {
+			return self.DarkColor, true
+		}
+		return self.LightColor, true
+	}
+	for y := 0; y < self.Inner.Dy(); y++ {
+		for x := 0; x < width; x++ {
+			upper, upperShown := color(x, 2*y)
+			lower, lowerShown := color(x, 2*y+1)
+			var cell Cell
+			switch {
+			case upperShown && lowerShown:
+				cell = NewCell(UPPER_HALF_BLOCK, NewStyle(upper, lower))
+			case upperShown:
+				cell = NewCell(UPPER_HALF_BLOCK, NewStyle(upper))
+			case lowerShown:
+				cell = NewCell(BARS[4], NewStyle(lower))
+			default:
+				continue
+			}
+			buf.SetCell(cell, image.Pt(self.Inner.Min.X+x, self.Inner.Min.Y+y))
+		}
+	}
+}
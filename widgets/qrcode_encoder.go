@@ -0,0 +1,480 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"strings"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// The error correction codewords per block and the number of blocks of every version, indexed by
+// QRCodeLevel and version, from the tables of ISO/IEC 18004.
+var (
+	qrECCodewordsPerBlock = [4][41]int{
+		{0, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+		{0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+		{0, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+		{0, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	}
+	qrECBlocks = [4][41]int{
+		{0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+		{0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+		{0, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+		{0, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+	}
+	// qrFormatLevels are the bits of the levels in the format information.
+	qrFormatLevels = [4]int{1, 0, 3, 2}
+)
+
+const qrAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// qrBits is a sequence of bits appended to most significant first.
+type qrBits []bool
+
+func (self *qrBits) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*self = append(*self, value>>uint(i)&1 != 0)
+	}
+}
+
+// qrSegment is text encoded in the most compact of the numeric, alphanumeric, and byte modes that
+// holds all of it.
+type qrSegment struct {
+	mode int
+	// countBits are the lengths of the character count for versions 1 to 9, 10 to 26, and 27 to 40.
+	countBits [3]int
+	count     int
+	data      qrBits
+}
+
+func newQRSegment(text string) qrSegment {
+	numeric := strings.Trim(text, "0123456789") == ""
+	alphanumeric := true
+	for _, r := range text {
+		if !strings.ContainsRune(qrAlphanumeric, r) {
+			alphanumeric = false
+			break
+		}
+	}
+
+	var segment qrSegment
+	switch {
+	case numeric:
+		segment = qrSegment{mode: 0x1, countBits: [3]int{10, 12, 14}, count: len(text)}
+		for i := 0; i < len(text); i += 3 {
+			group := text[i:MinInt(i+3, len(text))]
+			value := 0
+			for _, digit := range group {
+				value = value*10 + int(digit-'0')
+			}
+			segment.data.append(value, len(group)*3+1)
+		}
+	case alphanumeric:
+		segment = qrSegment{mode: 0x2, countBits: [3]int{9, 11, 13}, count: len(text)}
+		for i := 0; i+1 < len(text); i += 2 {
+			segment.data.append(strings.IndexByte(qrAlphanumeric, text[i])*45+strings.IndexByte(qrAlphanumeric, text[i+1]), 11)
+		}
+		if len(text)%2 == 1 {
+			segment.data.append(strings.IndexByte(qrAlphanumeric, text[len(text)-1]), 6)
+		}
+	default:
+		segment = qrSegment{mode: 0x4, countBits: [3]int{8, 16, 16}, count: len(text)}
+		for i := 0; i < len(text); i++ {
+			segment.data.append(int(text[i]), 8)
+		}
+	}
+	return segment
+}
+
+// bits returns the number of bits of the segment in a version, or -1 if the count doesn't fit.
+func (self qrSegment) bits(version int) int {
+	countBits := self.countBits[0]
+	if version >= 27 {
+		countBits = self.countBits[2]
+	} else if version >= 10 {
+		countBits = self.countBits[1]
+	}
+	if self.count >= 1<<uint(countBits) {
+		return -1
+	}
+	return 4 + countBits + len(self.data)
+}
+
+// qrRawModules returns the number of modules of a version holding data and error correction.
+func qrRawModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		alignments := version/7 + 2
+		result -= (25*alignments-10)*alignments - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// qrDataCodewords returns the number of data codewords of a version at a level.
+func qrDataCodewords(version int, level QRCodeLevel) int {
+	return qrRawModules(version)/8 - qrECCodewordsPerBlock[level][version]*qrECBlocks[level][version]
+}
+
+// qrVersion returns the smallest version holding the segment at a level, or 0 if none does.
+func qrVersion(segment qrSegment, level QRCodeLevel) int {
+	for version := 1; version <= 40; version++ {
+		if bits := segment.bits(version); bits >= 0 && bits <= qrDataCodewords(version, level)*8 {
+			return version
+		}
+	}
+	return 0
+}
+
+// qrMultiply multiplies in GF(2^8) modulo the polynomial x^8 + x^4 + x^3 + x^2 + 1.
+func qrMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11d
+		z ^= int(y>>uint(i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// qrDivisor returns the Reed-Solomon generator polynomial of a degree, without its leading term.
+func qrDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = qrMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrMultiply(root, 0x02)
+	}
+	return result
+}
+
+// qrRemainder returns the error correction codewords of data.
+func qrRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coefficient := range divisor {
+			result[i] ^= qrMultiply(coefficient, factor)
+		}
+	}
+	return result
+}
+
+// qrCodewords returns the data and error correction codewords of a segment in a version,
+// interleaved by block.
+func qrCodewords(segment qrSegment, version int, level QRCodeLevel) []byte {
+	capacity := qrDataCodewords(version, level) * 8
+	bits := qrBits{}
+	bits.append(segment.mode, 4)
+	bits.append(segment.count, segment.bits(version)-4-len(segment.data))
+	bits = append(bits, segment.data...)
+	// the terminator, padding to a byte, and alternating pad bytes
+	bits.append(0, MinInt(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xec; len(bits) < capacity; pad ^= 0xec ^ 0x11 {
+		bits.append(pad, 8)
+	}
+	data := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			data[i/8] |= 1 << uint(7-i%8)
+		}
+	}
+
+	blocks := qrECBlocks[level][version]
+	ecLength := qrECCodewordsPerBlock[level][version]
+	raw := qrRawModules(version) / 8
+	shortBlocks := blocks - raw%blocks
+	shortLength := raw / blocks
+	divisor := qrDivisor(ecLength)
+	blockData := make([][]byte, blocks)
+	for i, k := 0, 0; i < blocks; i++ {
+		length := shortLength - ecLength
+		if i >= shortBlocks {
+			length++
+		}
+		block := append([]byte{}, data[k:k+length]...)
+		k += length
+		ec := qrRemainder(block, divisor)
+		if i < shortBlocks {
+			// a placeholder keeping the blocks aligned while interleaving
+			block = append(block, 0)
+		}
+		blockData[i] = append(block, ec...)
+	}
+
+	result := make([]byte, 0, raw)
+	for i := range blockData[0] {
+		for j, block := range blockData {
+			if i != shortLength-ecLength || j >= shortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// qrMatrix is the grid of modules of a QR code, true for dark ones.
+type qrMatrix struct {
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+func (self *qrMatrix) setFunction(x, y int, dark bool) {
+	self.modules[y][x] = dark
+	self.function[y][x] = true
+}
+
+// qrAlignmentPositions returns the coordinates of the centers of the alignment patterns.
+func qrAlignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	count := version/7 + 2
+	step := (version*8 + count*3 + 5) / (count*4 - 4) * 2
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, position := count-1, version*4+10; i > 0; i, position = i-1, position-step {
+		positions[i] = position
+	}
+	return positions
+}
+
+func (self *qrMatrix) drawFunctionPatterns(version int, level QRCodeLevel) {
+	for i := 0; i < self.size; i++ {
+		self.setFunction(6, i, i%2 == 0)
+		self.setFunction(i, 6, i%2 == 0)
+	}
+	for _, center := range [][2]int{{3, 3}, {self.size - 4, 3}, {3, self.size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := center[0]+dx, center[1]+dy
+				if x >= 0 && x < self.size && y >= 0 && y < self.size {
+					distance := MaxInt(AbsInt(dx), AbsInt(dy))
+					self.setFunction(x, y, distance != 2 && distance != 4)
+				}
+			}
+		}
+	}
+	positions := qrAlignmentPositions(version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// the corners taken by finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					self.setFunction(x+dx, y+dy, MaxInt(AbsInt(dx), AbsInt(dy)) != 1)
+				}
+			}
+		}
+	}
+	self.drawFormat(level, 0)
+
+	if version >= 7 {
+		remainder := version
+		for i := 0; i < 12; i++ {
+			remainder = remainder<<1 ^ (remainder>>11)*0x1f25
+		}
+		bits := version<<12 | remainder
+		for i := 0; i < 18; i++ {
+			dark := bits>>uint(i)&1 != 0
+			a, b := self.size-11+i%3, i/3
+			self.setFunction(a, b, dark)
+			self.setFunction(b, a, dark)
+		}
+	}
+}
+
+// drawFormat draws both copies of the format information of a level and mask.
+func (self *qrMatrix) drawFormat(level QRCodeLevel, mask int) {
+	data := qrFormatLevels[level]<<3 | mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = remainder<<1 ^ (remainder>>9)*0x537
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+	bit := func(i int) bool {
+		return bits>>uint(i)&1 != 0
+	}
+
+	for i := 0; i <= 5; i++ {
+		self.setFunction(8, i, bit(i))
+	}
+	self.setFunction(8, 7, bit(6))
+	self.setFunction(8, 8, bit(7))
+	self.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		self.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		self.setFunction(self.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		self.setFunction(8, self.size-15+i, bit(i))
+	}
+	self.setFunction(8, self.size-8, true)
+}
+
+// drawCodewords places the codewords in the zigzag of two module columns from the bottom right.
+func (self *qrMatrix) drawCodewords(data []byte) {
+	i := 0
+	for right := self.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < self.size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vertical
+				if (right+1)&2 == 0 {
+					y = self.size - 1 - vertical
+				}
+				if !self.function[y][x] && i < len(data)*8 {
+					self.modules[y][x] = data[i/8]>>uint(7-i%8)&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by a mask. Applying it again removes it.
+func (self *qrMatrix) applyMask(mask int) {
+	for y := 0; y < self.size; y++ {
+		for x := 0; x < self.size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !self.function[y][x] {
+				self.modules[y][x] = !self.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the modules by the rules used to choose a mask: runs of five or more modules of
+// a color, 2x2 squares of a color, patterns looking like finders, and an unbalanced dark ratio.
+func (self *qrMatrix) penalty() int {
+	score := 0
+	finder := []bool{true, false, true, true, true, false, true}
+	at := func(x, y int, transposed bool) bool {
+		if transposed {
+			return self.modules[x][y]
+		}
+		return self.modules[y][x]
+	}
+	for _, transposed := range []bool{false, true} {
+		for y := 0; y < self.size; y++ {
+			run := 1
+			for x := 1; x <= self.size; x++ {
+				if x < self.size && at(x, y, transposed) == at(x-1, y, transposed) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+			for x := 0; x+7 <= self.size; x++ {
+				matches := true
+				for k, dark := range finder {
+					if at(x+k, y, transposed) != dark {
+						matches = false
+						break
+					}
+				}
+				if !matches {
+					continue
+				}
+				// four light modules on either side, or the edge of the symbol
+				light := func(from, to int) bool {
+					for k := from; k < to; k++ {
+						if k >= 0 && k < self.size && at(k, y, transposed) {
+							return false
+						}
+					}
+					return true
+				}
+				if light(x-4, x) || light(x+7, x+11) {
+					score += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < self.size; y++ {
+		for x := 0; x < self.size; x++ {
+			if self.modules[y][x] {
+				dark++
+			}
+			if x > 0 && y > 0 && self.modules[y][x] == self.modules[y][x-1] &&
+				self.modules[y][x] == self.modules[y-1][x] && self.modules[y][x] == self.modules[y-1][x-1] {
+				score += 3
+			}
+		}
+	}
+	total := self.size * self.size
+	score += ((AbsInt(dark*20-total*10)+total-1)/total - 1) * 10
+	return score
+}
+
+// encodeQR returns the modules of the QR code of a segment in a version at a level, with the mask
+// scoring the lowest penalty.
+func encodeQR(segment qrSegment, version int, level QRCodeLevel) [][]bool {
+	size := version*4 + 17
+	matrix := &qrMatrix{size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for y := range matrix.modules {
+		matrix.modules[y] = make([]bool, size)
+		matrix.function[y] = make([]bool, size)
+	}
+	matrix.drawFunctionPatterns(version, level)
+	matrix.drawCodewords(qrCodewords(segment, version, level))
+
+	best, bestScore := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		matrix.applyMask(mask)
+		matrix.drawFormat(level, mask)
+		if score := matrix.penalty(); bestScore < 0 || score < bestScore {
+			best, bestScore = mask, score
+		}
+		matrix.applyMask(mask)
+	}
+	matrix.applyMask(best)
+	matrix.drawFormat(level, best)
+	return matrix.modules
+}
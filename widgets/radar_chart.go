@@ -0,0 +1,150 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"math"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// RadarChart draws each series of Data as a polygon over a spoke per axis, the first pointing up and
+// the others following clockwise, with the axis Labels at the ends of the spokes and rings marking
+// fractions of MaxVal.
+type RadarChart struct {
+	Block
+	// Labels holds the name of each axis.
+	Labels []string
+	// Data holds the series, each with a value per axis.
+	Data [][]float64
+	// MaxVal is the value at the ends of the spokes. When zero, it's taken from the Data.
+	MaxVal       float64
+	SeriesLabels []string
+	// ShowLegend draws the SeriesLabels with their colors on the first line of the chart.
+	ShowLegend bool
+	// Rings is the number of rings drawn between the center and the ends of the spokes.
+	Rings int
+
+	LineColors []Color
+	AxesColor  Color
+	LabelStyle Style
+}
+
+func NewRadarChart() *RadarChart {
+	return &RadarChart{
+		Block:      *NewBlock(),
+		ShowLegend: true,
+		Rings:      4,
+		LineColors: Theme.RadarChart.Lines,
+		AxesColor:  Theme.RadarChart.Axes,
+		LabelStyle: Theme.RadarChart.Labels,
+	}
+}
+
+func (self *RadarChart) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	axes := len(self.Labels)
+	for _, series := range self.Data {
+		axes = MaxInt(axes, len(series))
+	}
+	if axes < 3 || self.Inner.Empty() {
+		return
+	}
+
+	area := self.Inner
+	if self.ShowLegend && len(self.SeriesLabels) > 0 && area.Dy() > 1 {
+		colors := make([]Color, len(self.SeriesLabels))
+		for i := range colors {
+			colors[i] = SelectColor(self.LineColors, i)
+		}
+		drawLegend(buf, area.Min, area.Dx(), self.SeriesLabels, colors, self.LabelStyle)
+		area.Min.Y++
+	}
+
+	maxVal := self.MaxVal
+	if maxVal == 0 {
+		for _, series := range self.Data {
+			for _, value := range series {
+				maxVal = math.Max(maxVal, value)
+			}
+		}
+	}
+	if maxVal <= 0 {
+		maxVal = 1
+	}
+
+	canvas := NewCanvas()
+	canvas.Rectangle = area
+	res := canvas.Mode.Resolution()
+
+	// keep a row above and below and the widest label on the sides for the labels; with the
+	// resolution of a cell being about twice as tall as it is wide, the dots are about square
+	labelWidth := 0
+	for _, label := range self.Labels {
+		labelWidth = MaxInt(labelWidth, rw.StringWidth(label)+1)
+	}
+	labelWidth = MinInt(labelWidth, area.Dx()/4)
+	radius := MinInt((area.Dx()-2*labelWidth)*res.X, (area.Dy()-2)*res.Y) / 2
+	if radius < 2 {
+		return
+	}
+	center := image.Pt(
+		(area.Min.X+area.Max.X)*res.X/2,
+		(area.Min.Y+area.Max.Y)*res.Y/2,
+	)
+	// vertex returns the point at distance r along the spoke of axis i
+	vertex := func(i int, r float64) image.Point {
+		angle := -math.Pi/2 + 2*math.Pi*float64(i)/float64(axes)
+		return image.Pt(
+			center.X+int(math.Round(r*math.Cos(angle))),
+			center.Y+int(math.Round(r*math.Sin(angle))),
+		)
+	}
+	polygon := func(r func(i int) float64) []image.Point {
+		points := make([]image.Point, axes+1)
+		for i := 0; i < axes; i++ {
+			points[i] = vertex(i, r(i))
+		}
+		points[axes] = points[0]
+		return points
+	}
+
+	for ring := 1; ring <= self.Rings; ring++ {
+		r := float64(radius*ring) / float64(self.Rings)
+		canvas.SetPolyline(polygon(func(int) float64 { return r }), self.AxesColor)
+	}
+	for i := 0; i < axes; i++ {
+		canvas.SetLine(center, vertex(i, float64(radius)), self.AxesColor)
+	}
+	for s, series := range self.Data {
+		points := polygon(func(i int) float64 {
+			if i >= len(series) {
+				return 0
+			}
+			return math.Max(math.Min(series[i]/maxVal, 1), 0) * float64(radius)
+		})
+		canvas.SetPolyline(points, SelectColor(self.LineColors, s))
+	}
+
+	// put the labels just beyond the ends of the spokes, away from the chart
+	for i, label := range self.Labels {
+		angle := -math.Pi/2 + 2*math.Pi*float64(i)/float64(axes)
+		p := vertex(i, float64(radius)+float64(res.Y))
+		alignment := AlignCenter
+		switch cos := math.Cos(angle); {
+		case cos > 0.3:
+			alignment = AlignLeft
+			p.X += res.X
+		case cos < -0.3:
+			alignment = AlignRight
+			p.X -= res.X
+		}
+		canvas.SetAlignedText(p, label, self.LabelStyle, alignment)
+	}
+	canvas.Draw(buf)
+}
@@ -0,0 +1,158 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// RadioGroup is a set of mutually exclusive Options. The arrow keys move a cursor through them and
+// <Space> or <Enter> selects the option under it; clicking an option selects it.
+type RadioGroup struct {
+	Block
+	Options   []string
+	TextStyle Style
+	// Selected is the index of the selected option, the first by default, or -1 if there is none.
+	Selected int
+	// Horizontal lays the options out on one line instead of one per line.
+	Horizontal bool
+
+	// Focused draws the option under the cursor with FocusedStyle. Keys are only handled while it is set.
+	Focused      bool
+	FocusedStyle Style
+
+	// OnChange is called when another option is selected.
+	OnChange func(index int, option string)
+
+	cursor int
+	// spans holds the area of each option drawn by the last Draw.
+	spans []image.Rectangle
+}
+
+func NewRadioGroup(options ...string) *RadioGroup {
+	return &RadioGroup{
+		Block:        *NewBlock(),
+		Options:      options,
+		TextStyle:    Theme.RadioGroup.Text,
+		FocusedStyle: Theme.RadioGroup.Focused,
+	}
+}
+
+// Select selects the option at index, calling OnChange if it changed.
+func (self *RadioGroup) Select(index int) {
+	if index < 0 || index >= len(self.Options) {
+		return
+	}
+	self.cursor = index
+	if index == self.Selected {
+		return
+	}
+	self.Selected = index
+	if self.OnChange != nil {
+		self.OnChange(index, self.Options[index])
+	}
+}
+
+func (self *RadioGroup) SetFocused(focused bool) {
+	self.Focused = focused
+}
+
+// FormValue returns the selected option for a Form, or "" if there is none.
+func (self *RadioGroup) FormValue() interface{} {
+	if self.Selected < 0 || self.Selected >= len(self.Options) {
+		return ""
+	}
+	return self.Options[self.Selected]
+}
+
+// Accessibility implements Accessible with the Title, Focused, and the selected option.
+func (self *RadioGroup) Accessibility() (string, bool, string) {
+	if self.Selected < 0 || self.Selected >= len(self.Options) {
+		return self.Title, self.Focused, ""
+	}
+	return self.Title, self.Focused, self.Options[self.Selected]
+}
+
+// HandleKey moves the cursor with the arrow keys, or with j and k, and selects the option under it
+// with <Space> or <Enter> while the RadioGroup is Focused. It reports whether the key was used.
+func (self *RadioGroup) HandleKey(id string) bool {
+	if !self.Focused || len(self.Options) == 0 {
+		return false
+	}
+	previous, next := "<Up>", "<Down>"
+	if self.Horizontal {
+		previous, next = "<Left>", "<Right>"
+	}
+	switch id {
+	case previous, "k":
+		self.cursor = MaxInt(self.cursor-1, 0)
+	case next, "j":
+		self.cursor = MinInt(self.cursor+1, len(self.Options)-1)
+	case "<Home>":
+		self.cursor = 0
+	case "<End>":
+		self.cursor = len(self.Options) - 1
+	case "<Space>", "<Enter>":
+		self.Select(self.cursor)
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse selects a clicked option and reports whether the event was used.
+func (self *RadioGroup) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag {
+		return false
+	}
+	for i, span := range self.spans {
+		if p.In(span) {
+			self.Select(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (self *RadioGroup) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+
+	self.spans = self.spans[:0]
+	self.cursor = MaxInt(MinInt(self.cursor, len(self.Options)-1), 0)
+	point := self.Inner.Min
+	for i, option := range self.Options {
+		mark := Theme.RadioGroup.Unselected
+		if i == self.Selected {
+			mark = Theme.RadioGroup.Selected
+		}
+		text := string(mark) + " " + option
+		style := self.TextStyle
+		if self.Focused && i == self.cursor {
+			style = self.FocusedStyle
+		}
+
+		if self.Horizontal {
+			width := MinInt(rw.StringWidth(text), self.Inner.Max.X-point.X)
+			if width <= 0 {
+				break
+			}
+			buf.SetString(TrimString(text, width), style, point)
+			self.spans = append(self.spans, image.Rect(point.X, point.Y, point.X+width, point.Y+1))
+			point.X += width + 2
+		} else {
+			if point.Y >= self.Inner.Max.Y {
+				break
+			}
+			buf.SetString(TrimString(text, self.Inner.Dx()), style, point)
+			self.spans = append(self.spans, image.Rect(point.X, point.Y, self.Inner.Max.X, point.Y+1))
+			point.Y++
+		}
+	}
+}
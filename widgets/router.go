@@ -0,0 +1,243 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// RouteParams are the parameters a view is opened with, like the id of the item a detail view shows.
+type RouteParams map[string]interface{}
+
+// ViewFunc builds the content of a view, like a Grid of widgets, from the parameters it is opened with.
+type ViewFunc func(params RouteParams) Drawable
+
+// Route is a view opened by a Router.
+type Route struct {
+	Name    string
+	Params  RouteParams
+	Content Drawable
+
+	// focus is the widget focused on the view with SetFocus.
+	focus Drawable
+}
+
+// Router shows one view of a stack of views at a time, sized to fill the Router, or its Inner area
+// with a border, for applications with several screens, like a list opening a detail view opening
+// an editor. Views are built from the ViewFuncs added with AddView when they are opened with Push or
+// Replace, and are kept with the state of their widgets, like their scroll position and selection,
+// until they are popped off the stack, so going back with Pop, or <Escape> with HandleKey, shows the
+// previous view as it was left. Each view remembers the widget focused on it, like Pages.
+type Router struct {
+	Block
+
+	// OnChange is called with the route shown after it changes.
+	OnChange func(route *Route)
+
+	views map[string]ViewFunc
+	stack []*Route
+	// shown is the content of the view drawn by the last Draw.
+	shown Drawable
+}
+
+func NewRouter() *Router {
+	self := &Router{
+		Block: *NewBlock(),
+		views: map[string]ViewFunc{},
+	}
+	self.Border = false
+	return self
+}
+
+// AddView registers the view opened under name.
+func (self *Router) AddView(name string, view ViewFunc) {
+	self.views[name] = view
+}
+
+// Current returns the route shown, or nil before the first Push.
+func (self *Router) Current() *Route {
+	if len(self.stack) == 0 {
+		return nil
+	}
+	return self.stack[len(self.stack)-1]
+}
+
+// Stack returns the routes opened, from the first one to the one shown.
+func (self *Router) Stack() []*Route {
+	return append([]*Route{}, self.stack...)
+}
+
+// Path returns the names of the routes opened, from the first one to the one shown, for Breadcrumbs.
+func (self *Router) Path() []string {
+	path := make([]string, len(self.stack))
+	for i, route := range self.stack {
+		path[i] = route.Name
+	}
+	return path
+}
+
+// CanGoBack reports whether there is a view to go back to.
+func (self *Router) CanGoBack() bool {
+	return len(self.stack) > 1
+}
+
+// route builds the view named name.
+func (self *Router) route(name string, params RouteParams) (*Route, error) {
+	view, ok := self.views[name]
+	if !ok {
+		return nil, fmt.Errorf("no view named %q", name)
+	}
+	if params == nil {
+		params = RouteParams{}
+	}
+	return &Route{Name: name, Params: params, Content: view(params)}, nil
+}
+
+// Push opens the view named name with params over the one shown, and calls OnChange.
+func (self *Router) Push(name string, params RouteParams) error {
+	route, err := self.route(name, params)
+	if err != nil {
+		return err
+	}
+	self.setFocused(false)
+	self.stack = append(self.stack, route)
+	self.changed()
+	return nil
+}
+
+// Replace opens the view named name with params in place of the one shown, which is closed, and
+// calls OnChange. Going back then shows the view before the one replaced.
+func (self *Router) Replace(name string, params RouteParams) error {
+	route, err := self.route(name, params)
+	if err != nil {
+		return err
+	}
+	self.setFocused(false)
+	if len(self.stack) > 0 {
+		self.close(self.stack[len(self.stack)-1:])
+		self.stack = self.stack[:len(self.stack)-1]
+	}
+	self.stack = append(self.stack, route)
+	self.changed()
+	return nil
+}
+
+// Pop closes the view shown to go back to the previous one, and calls OnChange. The first view is
+// never closed; Pop reports whether there was a view to go back to.
+func (self *Router) Pop() bool {
+	if !self.CanGoBack() {
+		return false
+	}
+	self.setFocused(false)
+	self.close(self.stack[len(self.stack)-1:])
+	self.stack = self.stack[:len(self.stack)-1]
+	self.changed()
+	return true
+}
+
+// PopTo closes the views over the last one named name, and calls OnChange. It reports whether
+// there was one.
+func (self *Router) PopTo(name string) bool {
+	for i := len(self.stack) - 1; i >= 0; i-- {
+		if self.stack[i].Name != name {
+			continue
+		}
+		if i < len(self.stack)-1 {
+			self.setFocused(false)
+			self.close(self.stack[i+1:])
+			self.stack = self.stack[:i+1]
+			self.changed()
+		}
+		return true
+	}
+	return false
+}
+
+// close unmounts the content of the routes closed.
+func (self *Router) close(routes []*Route) {
+	for _, route := range routes {
+		if route.Content != nil {
+			Unmount(route.Content)
+		}
+	}
+}
+
+// changed focuses the widget focused on the view shown and calls OnChange.
+func (self *Router) changed() {
+	self.setFocused(true)
+	if self.OnChange != nil {
+		self.OnChange(self.Current())
+	}
+}
+
+// SetFocus records the widget focused on the view shown, like one of the widgets of its Grid,
+// so it is focused again when going back to the view.
+func (self *Router) SetFocus(item Drawable) {
+	if route := self.Current(); route != nil {
+		self.setFocused(false)
+		route.focus = item
+		self.setFocused(true)
+	}
+}
+
+// Focused returns the widget focused on the view shown with SetFocus, or the view's content.
+func (self *Router) Focused() Drawable {
+	route := self.Current()
+	if route == nil {
+		return nil
+	}
+	if route.focus != nil {
+		return route.focus
+	}
+	return route.Content
+}
+
+// setFocused calls SetFocused on the widget focused on the view shown, if it has it.
+func (self *Router) setFocused(focused bool) {
+	if item, ok := self.Focused().(interface{ SetFocused(bool) }); ok {
+		item.SetFocused(focused)
+	}
+}
+
+// HandleKey goes back to the previous view with <Escape> and reports whether the key was used.
+// Keys for the view shown are passed to its widgets by the application before calling it.
+func (self *Router) HandleKey(id string) bool {
+	if id == "<Escape>" {
+		return self.Pop()
+	}
+	return false
+}
+
+func (self *Router) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	route := self.Current()
+	var content Drawable
+	if route != nil {
+		content = route.Content
+	}
+	if content != self.shown {
+		if shown := self.shown; shown != nil {
+			buf.Defer(func() { Hide(shown) })
+		}
+		self.shown = content
+	}
+	if content == nil {
+		return
+	}
+	area := self.Inner
+	if !self.Border {
+		area = self.Rectangle
+	}
+	if area.Empty() {
+		return
+	}
+	route.Content.SetRect(area.Min.X, area.Min.Y, area.Max.X, area.Max.Y)
+	route.Content.Lock()
+	route.Content.Draw(buf)
+	route.Content.Unlock()
+	buf.Defer(func() { Show(content) })
+}
@@ -0,0 +1,167 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// Scrollbar is the scroll indicator of a view showing part of its content, drawn as a track with
+// a thumb sized by the share of the content in view. The thumb can be dragged with the mouse, and
+// clicking the track scrolls a page toward the click. Widgets hold one and draw it over the last
+// column, or the last line when Horizontal, of their view.
+//
+//	offset, ok := self.scrollbar.HandleMouse(e)
+//	if ok {
+//		self.ScrollTo(offset)
+//	}
+type Scrollbar struct {
+	// Horizontal lays the scrollbar out on a line, for content scrolled left and right.
+	Horizontal bool
+	Style      Style
+
+	// area is where the scrollbar was last drawn, for a view showing visible of total lines
+	// or columns from offset. It is empty when the content fit.
+	area                   image.Rectangle
+	offset, visible, total int
+	// dragging is set while the thumb is dragged, held at grab along it.
+	dragging bool
+	grab     int
+}
+
+func NewScrollbar() *Scrollbar {
+	return &Scrollbar{
+		Style: Theme.Paragraph.Scrollbar,
+	}
+}
+
+// Shown reports whether the scrollbar was drawn, the content not fitting in its view.
+func (self *Scrollbar) Shown() bool {
+	return !self.area.Empty()
+}
+
+// Contains reports whether p is on the scrollbar.
+func (self *Scrollbar) Contains(p image.Point) bool {
+	return p.In(self.area)
+}
+
+// Dragging reports whether the thumb is being dragged.
+func (self *Scrollbar) Dragging() bool {
+	return self.dragging
+}
+
+// length returns the number of cells of the track.
+func (self *Scrollbar) length() int {
+	if self.Horizontal {
+		return self.area.Dx()
+	}
+	return self.area.Dy()
+}
+
+// thumb returns the start of the thumb in the track and its length.
+func (self *Scrollbar) thumb() (int, int) {
+	length := self.length()
+	size := MinInt(MaxInt(length*self.visible/self.total, 1), length)
+	start := (length - size) * self.offset / (self.total - self.visible)
+	return MaxInt(MinInt(start, length-size), 0), size
+}
+
+// Draw draws the scrollbar in area, a column or a line, for a view showing visible of total lines
+// or columns from offset. Nothing is drawn when they all fit.
+func (self *Scrollbar) Draw(buf *Buffer, area image.Rectangle, offset, visible, total int) {
+	self.area, self.offset, self.visible, self.total = area, offset, visible, total
+	if area.Empty() || total <= visible {
+		self.area = image.Rectangle{}
+		self.dragging = false
+		return
+	}
+	track := VERTICAL_LINE
+	if self.Horizontal {
+		track = HORIZONTAL_LINE
+	}
+	start, size := self.thumb()
+	for i := 0; i < self.length(); i++ {
+		r := track
+		if i >= start && i < start+size {
+			r = SHADED_BLOCKS[4]
+		}
+		point := image.Pt(area.Min.X, area.Min.Y+i)
+		if self.Horizontal {
+			point = image.Pt(area.Min.X+i, area.Min.Y)
+		}
+		buf.SetCell(NewCell(r, self.Style), point)
+	}
+}
+
+// position returns the position of p along the track.
+func (self *Scrollbar) position(p image.Point) int {
+	if self.Horizontal {
+		return p.X - self.area.Min.X
+	}
+	return p.Y - self.area.Min.Y
+}
+
+// clamp returns offset kept within the content.
+func (self *Scrollbar) clamp(offset int) int {
+	return MaxInt(MinInt(offset, self.total-self.visible), 0)
+}
+
+// HandleMouse drags the thumb with the left mouse button, and pages toward the track clicked.
+// It returns the offset the view is scrolled to, and reports whether the event was used.
+func (self *Scrollbar) HandleMouse(e Event) (int, bool) {
+	p, ok := MousePoint(e)
+	if !ok || !self.Shown() {
+		return 0, false
+	}
+	switch e.ID {
+	case "<MouseRelease>":
+		if !self.dragging {
+			return 0, false
+		}
+		self.dragging = false
+		return self.offset, true
+	case "<MouseLeft>":
+	default:
+		return 0, false
+	}
+
+	start, size := self.thumb()
+	if e.Payload.(Mouse).Drag {
+		if !self.dragging {
+			return 0, false
+		}
+		track := self.length() - size
+		if track <= 0 {
+			return self.offset, true
+		}
+		moved := MaxInt(MinInt(self.position(p)-self.grab, track), 0)
+		// rounded so that the thumb stays under the mouse
+		self.offset = self.clamp((moved*(self.total-self.visible) + track/2) / track)
+		return self.offset, true
+	}
+
+	if !self.Contains(p) {
+		return 0, false
+	}
+	pos := self.position(p)
+	switch {
+	case pos < start:
+		self.offset = self.clamp(self.offset - self.visible)
+	case pos >= start+size:
+		self.offset = self.clamp(self.offset + self.visible)
+	default:
+		self.dragging, self.grab = true, pos-start
+	}
+	return self.offset, true
+}
+
+// drawScrollbar draws a vertical scrollbar in the column x from minY to maxY for a view showing
+// visible of total lines starting at top.
+func drawScrollbar(buf *Buffer, x, minY, maxY, top, visible, total int, style Style) {
+	scrollbar := Scrollbar{Style: style}
+	scrollbar.Draw(buf, image.Rect(x, minY, x+1, maxY), top, visible, total)
+}
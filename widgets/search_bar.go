@@ -0,0 +1,173 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// Searchable is a widget a SearchBar can filter, such as a List, Table, or Tree.
+type Searchable interface {
+	Drawable
+	// SetSearchQuery applies query as the search of the widget.
+	SetSearchQuery(query string)
+	// SearchMatches returns the number of items matching the search.
+	SearchMatches() int
+}
+
+// SearchBar is a TextInput whose text is applied as the search of its Target while it is typed,
+// Debounce after the last edit, and which shows the number of matches on its right.
+// <Enter> applies the text at once and <Escape> clears it.
+type SearchBar struct {
+	TextInput
+	Target Searchable
+	// Debounce is how long the text must stay unchanged before it is applied. The search is applied
+	// in termui.Update, after which OnSearch is called for the application to Render the Target.
+	Debounce   time.Duration
+	CountStyle Style
+
+	// OnSearch is called with the query and the number of matches once the query is applied.
+	OnSearch func(query string, matches int)
+
+	matches int
+	timer   *time.Timer
+	// timerLock guards timer and generation, which counts edits to skip outdated timers.
+	timerLock  sync.Mutex
+	generation int
+}
+
+func NewSearchBar(target Searchable) *SearchBar {
+	self := &SearchBar{
+		TextInput:  *NewTextInput(),
+		Target:     target,
+		Debounce:   150 * time.Millisecond,
+		CountStyle: Theme.SearchBar.Count,
+	}
+	self.Placeholder = "Search" + string(ELLIPSES)
+	return self
+}
+
+// Matches returns the number of matches found by the last search applied.
+func (self *SearchBar) Matches() int {
+	return self.matches
+}
+
+// Apply applies the text as the search of the Target now, cancelling a pending one.
+func (self *SearchBar) Apply() {
+	self.timerLock.Lock()
+	self.generation++
+	if self.timer != nil {
+		self.timer.Stop()
+		self.timer = nil
+	}
+	self.timerLock.Unlock()
+	self.apply(self.Text())
+}
+
+func (self *SearchBar) apply(query string) {
+	if self.Target == nil {
+		return
+	}
+	self.Target.Lock()
+	self.Target.SetSearchQuery(query)
+	self.matches = self.Target.SearchMatches()
+	self.Target.Unlock()
+	if self.OnSearch != nil {
+		self.OnSearch(query, self.matches)
+	}
+}
+
+// schedule applies the text once it has stayed unchanged for Debounce.
+func (self *SearchBar) schedule() {
+	if self.Debounce <= 0 {
+		self.Apply()
+		return
+	}
+	self.timerLock.Lock()
+	defer self.timerLock.Unlock()
+	self.generation++
+	generation, query := self.generation, self.Text()
+	if self.timer != nil {
+		self.timer.Stop()
+	}
+	self.timer = time.AfterFunc(self.Debounce, func() {
+		Update(func() {
+			self.timerLock.Lock()
+			current := generation == self.generation
+			self.timerLock.Unlock()
+			if current {
+				self.apply(query)
+			}
+		})
+	})
+}
+
+// Clear empties the text and applies it, showing every item of the Target again.
+func (self *SearchBar) Clear() {
+	self.SetText("")
+	self.Apply()
+}
+
+// HandleKey edits the text while the SearchBar is Focused, applying it after Debounce.
+// It reports whether the key was used.
+func (self *SearchBar) HandleKey(id string) bool {
+	if !self.Focused {
+		return false
+	}
+	switch id {
+	case "<Enter>":
+		self.Apply()
+		if self.OnSubmit != nil {
+			self.OnSubmit(self.Text())
+		}
+		return true
+	case "<Escape>":
+		if self.Text() == "" {
+			return false
+		}
+		self.Clear()
+		return true
+	}
+	before := self.Text()
+	if !self.TextInput.HandleKey(id) {
+		return false
+	}
+	if self.Text() != before {
+		self.schedule()
+	}
+	return true
+}
+
+func (self *SearchBar) Draw(buf *Buffer) {
+	count := ""
+	if self.Text() != "" && self.Target != nil {
+		count = fmt.Sprintf(" %d", self.matches)
+		if self.matches == 1 {
+			count += " match"
+		} else {
+			count += " matches"
+		}
+	}
+	width := rw.StringWidth(count)
+	if width > self.Inner.Dx()/2 {
+		count, width = "", 0
+	}
+
+	// leave room for the count while the text is drawn
+	inner := self.Inner
+	self.Inner.Max.X -= width
+	self.TextInput.Draw(buf)
+	self.Inner = inner
+	if count != "" {
+		buf.SetString(count, self.CountStyle, image.Pt(self.Inner.Max.X-width, self.Inner.Min.Y))
+	}
+}
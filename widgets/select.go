@@ -0,0 +1,246 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"strings"
+	"time"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// TypeaheadTimeout is the longest pause between the keys typed to jump to an option by its prefix.
+var TypeaheadTimeout = time.Second
+
+// Select shows the chosen option of a list of Options and opens a popup list of all of them,
+// drawn as an overlay with ShowOverlay so that it floats over the widgets below the Select.
+type Select struct {
+	Block
+	Options   []string
+	TextStyle Style
+	// Selected is the index of the chosen option.
+	Selected int
+
+	// Focused draws the Select with FocusedStyle. Keys are only handled while it is set.
+	Focused      bool
+	FocusedStyle Style
+
+	// MaxVisible is the number of options shown by the popup before it scrolls.
+	MaxVisible int
+
+	// OnChange is called when another option is chosen.
+	OnChange func(index int, option string)
+
+	popup     *List
+	typeahead string
+	typedAt   time.Time
+}
+
+func NewSelect() *Select {
+	popup := NewList()
+	popup.SelectedRowStyle = Theme.Select.Highlighted
+	popup.TextStyle = Theme.Select.Text
+	return &Select{
+		Block:        *NewBlock(),
+		TextStyle:    Theme.Select.Text,
+		FocusedStyle: Theme.Select.Focused,
+		MaxVisible:   8,
+		popup:        popup,
+	}
+}
+
+// IsOpen reports whether the popup is shown.
+func (self *Select) IsOpen() bool {
+	return IsOverlay(self.popup)
+}
+
+// Open shows the popup below the Select with the chosen option highlighted.
+func (self *Select) Open() {
+	if len(self.Options) == 0 {
+		return
+	}
+	self.popup.Rows = self.Options
+	self.popup.SelectedRow = self.Selected
+	self.placePopup()
+	ShowOverlay(self.popup)
+}
+
+// Close hides the popup without changing the chosen option.
+func (self *Select) Close() {
+	HideOverlay(self.popup)
+}
+
+// Choose makes the option at index the chosen one, calling OnChange if it changed.
+func (self *Select) Choose(index int) {
+	if index < 0 || index >= len(self.Options) || index == self.Selected {
+		return
+	}
+	self.Selected = index
+	if self.OnChange != nil {
+		self.OnChange(index, self.Options[index])
+	}
+}
+
+func (self *Select) placePopup() {
+	height := MinInt(len(self.Options), MaxInt(self.MaxVisible, 1)) + 2
+	self.popup.SetRect(self.Min.X, self.Max.Y-1, self.Max.X, self.Max.Y-1+height)
+}
+
+func (self *Select) SetFocused(focused bool) {
+	self.Focused = focused
+	if !focused {
+		self.Close()
+	}
+}
+
+// FormValue returns the chosen option for a Form, or "" if there are no options.
+func (self *Select) FormValue() interface{} {
+	if self.Selected < 0 || self.Selected >= len(self.Options) {
+		return ""
+	}
+	return self.Options[self.Selected]
+}
+
+// Accessibility implements Accessible with the Title, Focused, and the selected option.
+func (self *Select) Accessibility() (string, bool, string) {
+	if self.Selected < 0 || self.Selected >= len(self.Options) {
+		return self.Title, self.Focused, ""
+	}
+	return self.Title, self.Focused, self.Options[self.Selected]
+}
+
+// findPrefix returns the first option from start, wrapping around, starting with prefix ignoring case.
+func (self *Select) findPrefix(prefix string, start int) int {
+	prefix = strings.ToLower(prefix)
+	for i := 0; i < len(self.Options); i++ {
+		index := (start + i) % len(self.Options)
+		if strings.HasPrefix(strings.ToLower(self.Options[index]), prefix) {
+			return index
+		}
+	}
+	return -1
+}
+
+// typeAhead returns the option starting with the runes typed in quick succession, or current if
+// there is none. Typing the same rune again moves on to the next option starting with it.
+func (self *Select) typeAhead(r rune, current int) int {
+	now := time.Now()
+	start := current
+	if now.Sub(self.typedAt) > TypeaheadTimeout || self.typeahead == string(r) {
+		self.typeahead = ""
+		start++
+	}
+	self.typeahead += string(r)
+	self.typedAt = now
+	if index := self.findPrefix(self.typeahead, start); index >= 0 {
+		return index
+	}
+	return current
+}
+
+// HandleKey handles a keyboard event ID while the Select is Focused and reports whether it was used.
+// <Enter>, <Space>, or <Down> opens the popup. In the popup, <Up> and <Down> move through the options,
+// <Enter> chooses one, and <Escape> closes it. Typing the start of an option moves to it.
+func (self *Select) HandleKey(id string) bool {
+	if !self.Focused || len(self.Options) == 0 {
+		return false
+	}
+	if !self.IsOpen() {
+		switch id {
+		case "<Enter>", "<Space>", "<Down>":
+			self.Open()
+		default:
+			runes := []rune(id)
+			if len(runes) != 1 {
+				return false
+			}
+			self.Choose(self.typeAhead(runes[0], self.Selected))
+		}
+		return true
+	}
+
+	switch id {
+	case "<Up>", "<C-p>":
+		self.popup.ScrollUp()
+	case "<Down>", "<C-n>":
+		self.popup.ScrollDown()
+	case "<PageUp>":
+		self.popup.ScrollPageUp()
+	case "<PageDown>":
+		self.popup.ScrollPageDown()
+	case "<Home>":
+		self.popup.ScrollTop()
+	case "<End>":
+		self.popup.ScrollBottom()
+	case "<Enter>", "<Space>":
+		self.Choose(self.popup.SelectedRow)
+		self.Close()
+	case "<Escape>":
+		self.Close()
+	default:
+		runes := []rune(id)
+		if len(runes) != 1 {
+			return false
+		}
+		self.popup.SelectedRow = self.typeAhead(runes[0], self.popup.SelectedRow)
+	}
+	return true
+}
+
+// HandleMouse opens or closes the popup when the Select is clicked, chooses a clicked option, and
+// closes the popup on a click anywhere else. It reports whether the event was used.
+func (self *Select) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok {
+		return false
+	}
+	if self.IsOpen() && p.In(self.popup.GetRect()) {
+		if e.ID == "<MouseLeft>" && !e.Payload.(Mouse).Drag && p.In(self.popup.Inner) {
+			self.popup.HandleMouse(e)
+			self.Choose(self.popup.SelectedRow)
+			self.Close()
+			return true
+		}
+		return self.popup.HandleMouse(e)
+	}
+	if e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag {
+		return false
+	}
+	if p.In(self.Rectangle) {
+		if self.IsOpen() {
+			self.Close()
+		} else {
+			self.Open()
+		}
+		return true
+	}
+	if self.IsOpen() {
+		self.Close()
+	}
+	return false
+}
+
+func (self *Select) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	if self.Inner.Empty() {
+		return
+	}
+	if self.IsOpen() {
+		self.placePopup()
+	}
+
+	style := self.TextStyle
+	if self.Focused {
+		style = self.FocusedStyle
+	}
+	buf.Fill(NewCell(' ', style), image.Rect(self.Inner.Min.X, self.Inner.Min.Y, self.Inner.Max.X, self.Inner.Min.Y+1))
+	text := ""
+	if self.Selected >= 0 && self.Selected < len(self.Options) {
+		text = self.Options[self.Selected]
+	}
+	buf.SetString(TrimString(text, self.Inner.Dx()-2), style, self.Inner.Min)
+	buf.SetCell(NewCell(DOWN_ARROW, style), image.Pt(self.Inner.Max.X-1, self.Inner.Min.Y))
+}
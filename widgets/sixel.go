@@ -0,0 +1,86 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	colorpalette "image/color/palette"
+	"image/draw"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// encodeSixel returns the sixel sequence drawing img scaled to width by height pixels.
+// The image is dithered to a palette of 256 colors, and mostly transparent pixels are left undrawn.
+func encodeSixel(img image.Image, width, height int) []byte {
+	scaled := scaleImage(img, width, height)
+	paletted := image.NewPaletted(scaled.Bounds(), colorpalette.Plan9)
+	draw.FloydSteinberg.Draw(paletted, paletted.Bounds(), scaled, image.ZP)
+
+	var b bytes.Buffer
+	// P2=1 leaves the pixels that aren't set in any color unchanged
+	fmt.Fprintf(&b, "\x1bP0;1q\"1;1;%d;%d", width, height)
+	used := make([]bool, len(paletted.Palette))
+	for i, index := range paletted.Pix {
+		if scaled.Pix[i*4+3] >= 128 {
+			used[index] = true
+		}
+	}
+	for i, c := range paletted.Palette {
+		if used[i] {
+			r, g, bl, _ := c.RGBA()
+			fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, bl*100/0xffff)
+		}
+	}
+
+	row := make([]byte, width)
+	for top := 0; top < height; top += 6 {
+		bottom := MinInt(top+6, height)
+		colors := []int{}
+		present := make([]bool, len(paletted.Palette))
+		for i := top * width; i < bottom*width; i++ {
+			if index := paletted.Pix[i]; !present[index] && scaled.Pix[i*4+3] >= 128 {
+				present[index] = true
+				colors = append(colors, int(index))
+			}
+		}
+		for _, index := range colors {
+			for x := 0; x < width; x++ {
+				bits := byte(0)
+				for dy := 0; top+dy < bottom; dy++ {
+					i := (top+dy)*width + x
+					if int(paletted.Pix[i]) == index && scaled.Pix[i*4+3] >= 128 {
+						bits |= 1 << uint(dy)
+					}
+				}
+				row[x] = '?' + bits
+			}
+			fmt.Fprintf(&b, "#%d", index)
+			writeSixelRow(&b, bytes.TrimRight(row, "?"))
+			b.WriteByte('$')
+		}
+		b.WriteByte('-')
+	}
+	b.WriteString("\x1b\\")
+	return b.Bytes()
+}
+
+// writeSixelRow writes a row of sixels, shortening runs of the same sixel with the repeat introducer.
+func writeSixelRow(b *bytes.Buffer, row []byte) {
+	for start := 0; start < len(row); {
+		end := start
+		for end < len(row) && row[end] == row[start] {
+			end++
+		}
+		if end-start > 3 {
+			fmt.Fprintf(b, "!%d%c", end-start, row[start])
+		} else {
+			b.Write(row[start:end])
+		}
+		start = end
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// Slider picks a number between Min and Max in increments of Step. It is adjusted with the arrow
+// keys while Focused, or by clicking or dragging along its track.
+type Slider struct {
+	Block
+	Min   float64
+	Max   float64
+	Value float64
+	// Step is the increment the Value snaps to. Values are continuous when it is 0.
+	Step float64
+	// TickInterval is the distance between tick marks drawn below the track, which are hidden when
+	// it is 0 or the Slider has a single row.
+	TickInterval float64
+
+	// ShowValue draws the Value formatted with Format to the right of the track.
+	ShowValue bool
+	Format    string
+
+	TrackStyle  Style
+	FilledStyle Style
+	HandleStyle Style
+	TickStyle   Style
+	ValueStyle  Style
+
+	// Focused draws the handle with FocusedStyle. Keys are only handled while it is set.
+	Focused      bool
+	FocusedStyle Style
+
+	// OnChange is called with the new Value when it changes.
+	OnChange func(value float64)
+
+	track    image.Rectangle
+	dragging bool
+}
+
+func NewSlider() *Slider {
+	return &Slider{
+		Block:        *NewBlock(),
+		Max:          100,
+		Step:         1,
+		ShowValue:    true,
+		Format:       "%g",
+		TrackStyle:   Theme.Slider.Track,
+		FilledStyle:  Theme.Slider.Filled,
+		HandleStyle:  Theme.Slider.Handle,
+		TickStyle:    Theme.Slider.Tick,
+		ValueStyle:   Theme.Slider.Value,
+		FocusedStyle: Theme.Slider.Focused,
+	}
+}
+
+// SetValue snaps value to the nearest Step between Min and Max and calls OnChange if the Value changed.
+func (self *Slider) SetValue(value float64) {
+	if self.Step > 0 {
+		value = self.Min + math.Round((value-self.Min)/self.Step)*self.Step
+	}
+	value = math.Max(math.Min(value, self.Max), self.Min)
+	if value == self.Value {
+		return
+	}
+	self.Value = value
+	if self.OnChange != nil {
+		self.OnChange(value)
+	}
+}
+
+// step returns the amount the arrow keys move the Value by, which is a hundredth of the range for
+// continuous Sliders.
+func (self *Slider) step() float64 {
+	if self.Step > 0 {
+		return self.Step
+	}
+	return (self.Max - self.Min) / 100
+}
+
+func (self *Slider) SetFocused(focused bool) {
+	self.Focused = focused
+}
+
+// FormValue returns the Value for a Form.
+func (self *Slider) FormValue() interface{} {
+	return self.Value
+}
+
+// HandleKey moves the Value by a Step with <Left> and <Right>, or h and l, by ten Steps with
+// <PageDown> and <PageUp>, and to Min or Max with <Home> and <End> while the Slider is Focused.
+// It reports whether the key was used.
+func (self *Slider) HandleKey(id string) bool {
+	if !self.Focused {
+		return false
+	}
+	switch id {
+	case "<Left>", "h", "<Down>", "j":
+		self.SetValue(self.Value - self.step())
+	case "<Right>", "l", "<Up>", "k":
+		self.SetValue(self.Value + self.step())
+	case "<PageDown>":
+		self.SetValue(self.Value - 10*self.step())
+	case "<PageUp>":
+		self.SetValue(self.Value + 10*self.step())
+	case "<Home>":
+		self.SetValue(self.Min)
+	case "<End>":
+		self.SetValue(self.Max)
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse moves the Value to the point clicked on the track, following the pointer while it is
+// dragged, and reports whether the event was used.
+func (self *Slider) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok {
+		return false
+	}
+	switch e.ID {
+	case "<MouseLeft>":
+		if !self.dragging && (e.Payload.(Mouse).Drag || !p.In(self.track)) {
+			return false
+		}
+		self.dragging = true
+		self.SetValue(self.valueAt(p.X))
+		return true
+	case "<MouseRelease>":
+		if self.dragging {
+			self.dragging = false
+			return true
+		}
+	}
+	return false
+}
+
+// valueAt returns the value at column x of the track.
+func (self *Slider) valueAt(x int) float64 {
+	if self.track.Dx() <= 1 {
+		return self.Min
+	}
+	ratio := float64(x-self.track.Min.X) / float64(self.track.Dx()-1)
+	return self.Min + math.Max(math.Min(ratio, 1), 0)*(self.Max-self.Min)
+}
+
+// column returns the column of the track showing value.
+func (self *Slider) column(value float64) int {
+	if self.Max <= self.Min {
+		return self.track.Min.X
+	}
+	ratio := (value - self.Min) / (self.Max - self.Min)
+	return self.track.Min.X + int(math.Round(ratio*float64(self.track.Dx()-1)))
+}
+
+func (self *Slider) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	if self.Inner.Empty() {
+		return
+	}
+
+	self.track = image.Rect(self.Inner.Min.X, self.Inner.Min.Y, self.Inner.Max.X, self.Inner.Min.Y+1)
+	if self.ShowValue {
+		width := MaxInt(rw.StringWidth(fmt.Sprintf(self.Format, self.Min)), rw.StringWidth(fmt.Sprintf(self.Format, self.Max)))
+		width = MaxInt(width, rw.StringWidth(fmt.Sprintf(self.Format, self.Value)))
+		if width+2 < self.Inner.Dx() {
+			self.track.Max.X -= width + 1
+			value := fmt.Sprintf(self.Format, self.Value)
+			buf.SetString(value, self.ValueStyle, image.Pt(self.Inner.Max.X-rw.StringWidth(value), self.Inner.Min.Y))
+		}
+	}
+
+	handle := self.column(self.Value)
+	for x := self.track.Min.X; x < self.track.Max.X; x++ {
+		style := self.TrackStyle
+		if x < handle {
+			style = self.FilledStyle
+		}
+		buf.SetCell(NewCell(HORIZONTAL_LINE, style), image.Pt(x, self.track.Min.Y))
+	}
+	handleStyle := self.HandleStyle
+	if self.Focused {
+		handleStyle = self.FocusedStyle
+	}
+	buf.SetCell(NewCell(Theme.Slider.HandleRune, handleStyle), image.Pt(handle, self.track.Min.Y))
+
+	if self.TickInterval > 0 && self.Inner.Dy() > 1 && self.Max > self.Min {
+		ticks := int(math.Floor((self.Max-self.Min)/self.TickInterval + 1e-9))
+		for i := 0; i <= ticks; i++ {
+			value := self.Min + float64(i)*self.TickInterval
+			buf.SetCell(NewCell(Theme.Slider.TickRune, self.TickStyle), image.Pt(self.column(value), self.track.Min.Y+1))
+		}
+	}
+}
@@ -5,8 +5,11 @@
 package widgets
 
 import (
+	"fmt"
 	"image"
 
+	rw "github.com/mattn/go-runewidth"
+
 	. "github.com/reaalkhalil/termui"
 )
 
@@ -18,77 +21,251 @@ type Sparkline struct {
 	LineColor  Color
 	MaxVal     float64
 	MaxHeight  int // TODO
+	// Marker draws the sparkline with full blocks (default), or with braille dots giving two
+	// data points per cell and four times the vertical resolution.
+	Marker SparklineMarker
+	// ShowValues draws the current, min, and max values of the Data, formatted with NumFormatter,
+	// at the right of the title line, or over the top line if there is no Title.
+	ShowValues   bool
+	ValuesStyle  Style
+	NumFormatter func(float64) string
+	// Baseline is a reference value drawn as a line behind the sparkline when ShowBaseline is set.
+	Baseline      float64
+	ShowBaseline  bool
+	BaselineStyle Style
+	// Thresholds change the color of the bars with their values. Each bar takes the Color of the
+	// highest threshold its value has reached, or LineColor below all of them.
+	Thresholds []SparklineThreshold
+}
+
+// SparklineThreshold is a Value from which Sparkline bars are drawn in Color.
+type SparklineThreshold struct {
+	Value float64
+	Color Color
 }
 
+type SparklineMarker uint
+
+const (
+	SparklineBlock SparklineMarker = iota
+	SparklineBraille
+)
+
 // SparklineGroup is a renderable widget which groups together the given sparklines.
 type SparklineGroup struct {
 	Block
 	Sparklines []*Sparkline
+	// SharedScale draws every sparkline with the highest of their MaxVals, for their heights to
+	// be compared.
+	SharedScale bool
+	// Columns is the number of columns the sparklines are laid out in, filling each column from
+	// top to bottom before the next one.
+	Columns int
+	// ShowCurrent draws the last value of each sparkline, formatted with its NumFormatter, on the
+	// right of its bars, the bars of all the sparklines of a column leaving room for the widest.
+	ShowCurrent  bool
+	CurrentStyle Style
 }
 
 // NewSparkline returns a unrenderable single sparkline that needs to be added to a SparklineGroup
 func NewSparkline() *Sparkline {
 	return &Sparkline{
-		TitleStyle: Theme.Sparkline.Title,
-		LineColor:  Theme.Sparkline.Line,
+		TitleStyle:    Theme.Sparkline.Title,
+		LineColor:     Theme.Sparkline.Line,
+		ValuesStyle:   Theme.Sparkline.Values,
+		BaselineStyle: Theme.Sparkline.Baseline,
+		NumFormatter:  func(n float64) string { return fmt.Sprint(n) },
 	}
 }
 
 func NewSparklineGroup(sls ...*Sparkline) *SparklineGroup {
 	return &SparklineGroup{
-		Block:      *NewBlock(),
-		Sparklines: sls,
+		Block:        *NewBlock(),
+		Sparklines:   sls,
+		Columns:      1,
+		CurrentStyle: Theme.Sparkline.Current,
 	}
 }
 
 func (self *SparklineGroup) Draw(buf *Buffer) {
 	self.Block.Draw(buf)
+	if len(self.Sparklines) == 0 {
+		return
+	}
 
-	sparklineHeight := self.Inner.Dy() / len(self.Sparklines)
-
-	for i, sl := range self.Sparklines {
-		heightOffset := (sparklineHeight * (i + 1))
-		barHeight := sparklineHeight
-		if i == len(self.Sparklines)-1 {
-			heightOffset = self.Inner.Dy()
-			barHeight = self.Inner.Dy() - (sparklineHeight * i)
+	sharedMaxVal := 0.0
+	if self.SharedScale {
+		for _, sl := range self.Sparklines {
+			sharedMaxVal = MaxFloat64(sharedMaxVal, sl.maxVal())
 		}
-		if sl.Title != "" {
-			barHeight--
+	}
+
+	columns := MaxInt(MinInt(self.Columns, len(self.Sparklines)), 1)
+	rows := (len(self.Sparklines) + columns - 1) / columns
+	columns = (len(self.Sparklines) + rows - 1) / rows
+	// the columns are parted by a space
+	columnWidth := (self.Inner.Dx() - (columns - 1)) / columns
+	sparklineHeight := self.Inner.Dy() / rows
+
+	for column := 0; column < columns; column++ {
+		first := column * rows
+		last := MinInt(first+rows, len(self.Sparklines))
+		minX := self.Inner.Min.X + column*(columnWidth+1)
+		maxX := minX + columnWidth
+		if column == columns-1 {
+			maxX = self.Inner.Max.X
 		}
 
-		maxVal := sl.MaxVal
-		if maxVal == 0 {
-			maxVal, _ = GetMaxFloat64FromSlice(sl.Data)
+		currentWidth := 0
+		if self.ShowCurrent {
+			for _, sl := range self.Sparklines[first:last] {
+				if len(sl.Data) > 0 {
+					currentWidth = MaxInt(currentWidth, rw.StringWidth(sl.current())+1)
+				}
+			}
+			currentWidth = MinInt(currentWidth, (maxX-minX)/2)
 		}
 
-		// draw line
-		for j := 0; j < len(sl.Data) && j < self.Inner.Dx(); j++ {
-			data := sl.Data[j]
-			height := int((data / maxVal) * float64(barHeight))
-			sparkChar := BARS[len(BARS)-1]
-			for k := 0; k < height; k++ {
-				buf.SetCell(
-					NewCell(sparkChar, NewStyle(sl.LineColor)),
-					image.Pt(j+self.Inner.Min.X, self.Inner.Min.Y-1+heightOffset-k),
-				)
+		for row, sl := range self.Sparklines[first:last] {
+			minY := self.Inner.Min.Y + row*sparklineHeight
+			maxY := minY + sparklineHeight
+			if row == rows-1 {
+				maxY = self.Inner.Max.Y
 			}
-			if height == 0 {
-				sparkChar = BARS[1]
-				buf.SetCell(
-					NewCell(sparkChar, NewStyle(sl.LineColor)),
-					image.Pt(j+self.Inner.Min.X, self.Inner.Min.Y-1+heightOffset),
-				)
+			maxVal := sharedMaxVal
+			if !self.SharedScale {
+				maxVal = sl.maxVal()
 			}
+			self.drawSparkline(buf, sl, image.Rect(minX, minY, maxX, maxY), maxVal, currentWidth)
 		}
+	}
+}
+
+// maxVal returns the MaxVal, or the highest value of the Data when it is zero.
+func (self *Sparkline) maxVal() float64 {
+	if self.MaxVal != 0 {
+		return self.MaxVal
+	}
+	maxVal, _ := GetMaxFloat64FromSlice(self.Data)
+	return maxVal
+}
+
+// current returns the last value of the Data as text.
+func (self *Sparkline) current() string {
+	return self.NumFormatter(self.Data[len(self.Data)-1])
+}
+
+// drawSparkline draws sl in area, with its title on the first line and its last value on the right
+// of its bars when currentWidth is set.
+func (self *SparklineGroup) drawSparkline(buf *Buffer, sl *Sparkline, area image.Rectangle, maxVal float64, currentWidth int) {
+	rect := area
+	if sl.Title != "" {
+		rect.Min.Y++
+	}
+	rect.Max.X -= currentWidth
+	if rect.Empty() {
+		return
+	}
+
+	if sl.ShowBaseline && maxVal > 0 {
+		// draw baseline, under the line
+		y := rect.Max.Y - 1 - int((sl.Baseline/maxVal)*float64(rect.Dy()))
+		if y >= rect.Min.Y && y < rect.Max.Y {
+			buf.Fill(NewCell(HORIZONTAL_LINE, sl.BaselineStyle), image.Rect(rect.Min.X, y, rect.Max.X, y+1))
+		}
+	}
 
-		if sl.Title != "" {
-			// draw title
-			buf.SetString(
-				TrimString(sl.Title, self.Inner.Dx()),
-				sl.TitleStyle,
-				image.Pt(self.Inner.Min.X, self.Inner.Min.Y-1+heightOffset-barHeight),
+	// draw line
+	if sl.Marker == SparklineBraille {
+		sl.drawBraille(buf, rect, maxVal)
+	} else {
+		sl.drawBlocks(buf, rect, maxVal)
+	}
+
+	if sl.Title != "" {
+		// draw title
+		buf.SetString(
+			TrimString(sl.Title, area.Dx()),
+			sl.TitleStyle,
+			image.Pt(area.Min.X, area.Min.Y),
+		)
+	}
+
+	if sl.ShowValues && len(sl.Data) > 0 {
+		// draw values
+		values := TrimString(sl.values(), area.Dx())
+		buf.SetString(values, sl.ValuesStyle, image.Pt(area.Max.X-rw.StringWidth(values), area.Min.Y))
+	}
+
+	if currentWidth > 0 && len(sl.Data) > 0 {
+		// draw the last value in the middle of the bars
+		current := TrimString(sl.current(), currentWidth-1)
+		y := rect.Min.Y + (rect.Dy()-1)/2
+		buf.SetString(current, self.CurrentStyle, image.Pt(area.Max.X-rw.StringWidth(current), y))
+	}
+}
+
+// values returns the current, min, and max values of the Data as text, like "10 ▼1 ▲15".
+func (self *Sparkline) values() string {
+	minVal, maxVal := self.Data[0], self.Data[0]
+	for _, data := range self.Data {
+		minVal = MinFloat64(minVal, data)
+		maxVal = MaxFloat64(maxVal, data)
+	}
+	return fmt.Sprintf("%s %c%s %c%s",
+		self.NumFormatter(self.Data[len(self.Data)-1]),
+		DOWN_ARROW, self.NumFormatter(minVal), UP_ARROW, self.NumFormatter(maxVal))
+}
+
+func (self *Sparkline) drawBlocks(buf *Buffer, rect image.Rectangle, maxVal float64) {
+	for j := 0; j < len(self.Data) && j < rect.Dx(); j++ {
+		data := self.Data[j]
+		height := int((data / maxVal) * float64(rect.Dy()))
+		style := NewStyle(self.lineColor(data))
+		sparkChar := BARS[len(BARS)-1]
+		for k := 0; k < height; k++ {
+			buf.SetCell(
+				NewCell(sparkChar, style),
+				image.Pt(j+rect.Min.X, rect.Max.Y-1-k),
 			)
 		}
+		if height == 0 {
+			sparkChar = BARS[1]
+			buf.SetCell(
+				NewCell(sparkChar, style),
+				image.Pt(j+rect.Min.X, rect.Max.Y-1),
+			)
+		}
+	}
+}
+
+// drawBraille fills columns of braille dots up from the bottom of rect, two data points per cell.
+// A cell is colored for the higher of its two data points.
+func (self *Sparkline) drawBraille(buf *Buffer, rect image.Rectangle, maxVal float64) {
+	cells := make(map[image.Point]rune)
+	colors := make(map[image.Point]float64)
+	for j := 0; j < len(self.Data) && j < rect.Dx()*2; j++ {
+		height := MaxInt(int((self.Data[j]/maxVal)*float64(rect.Dy()*4)), 1)
+		for k := 0; k < height; k++ {
+			point := image.Pt(rect.Min.X+j/2, rect.Max.Y-1-k/4)
+			if _, ok := cells[point]; !ok || self.Data[j] > colors[point] {
+				colors[point] = self.Data[j]
+			}
+			cells[point] |= BRAILLE[3-k%4][j%2]
+		}
+	}
+	for point, dots := range cells {
+		buf.SetCell(NewCell(BRAILLE_OFFSET+dots, NewStyle(self.lineColor(colors[point]))), point)
+	}
+}
+
+// lineColor returns the color of the threshold reached by data.
+func (self *Sparkline) lineColor(data float64) Color {
+	color, reached, found := self.LineColor, 0.0, false
+	for _, threshold := range self.Thresholds {
+		if data >= threshold.Value && (!found || threshold.Value > reached) {
+			color, reached, found = threshold.Color, threshold.Value, true
+		}
 	}
+	return color
 }
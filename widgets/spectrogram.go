@@ -0,0 +1,165 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// spectrogramDots are the braille dots lit in turn as the density of a cell rises, spread out
+// across it.
+var spectrogramDots = [8][2]int{{3, 0}, {1, 1}, {2, 0}, {0, 1}, {3, 1}, {1, 0}, {2, 1}, {0, 0}}
+
+// Spectrogram shows rows of intensities over time, such as the frequencies of audio or radio
+// signals or a histogram over time, scrolling down as rows are added with AppendRow so that the
+// newest is on top. The buckets of a row are spread across the width, a column taking the
+// highest of its buckets, and intensities are drawn as cells colored with the Colors blended from
+// MinVal to MaxVal, or as braille dots in DotColor with Density. A legend of the scale is drawn
+// on the last row.
+type Spectrogram struct {
+	Block
+	// Rows holds the intensities of each bucket over time, the newest last.
+	Rows [][]float64
+	// MaxRows, when greater than 0, is the number of rows kept by AppendRow, which drops the
+	// oldest rows.
+	MaxRows int
+	// MinVal and MaxVal are the bottom and top of the scale. When both are zero, they're taken from
+	// the Rows.
+	MinVal float64
+	MaxVal float64
+	Colors []Color
+	// Density draws intensities as the number of braille dots lit in a cell rather than colors.
+	Density  bool
+	DotColor Color
+	// ShowLegend draws the scale between MinVal and MaxVal, formatted with NumFormatter, on the
+	// last row.
+	ShowLegend   bool
+	NumFormatter func(float64) string
+	TextStyle    Style
+}
+
+func NewSpectrogram() *Spectrogram {
+	return &Spectrogram{
+		Block:        *NewBlock(),
+		MaxRows:      1000,
+		Colors:       Theme.Spectrogram.Colors,
+		DotColor:     Theme.Spectrogram.Dot,
+		ShowLegend:   true,
+		NumFormatter: func(n float64) string { return fmt.Sprintf("%.3g", n) },
+		TextStyle:    Theme.Spectrogram.Text,
+	}
+}
+
+// AppendRow adds a row of intensities, dropping the oldest rows beyond MaxRows.
+func (self *Spectrogram) AppendRow(row []float64) {
+	self.Rows = append(self.Rows, row)
+	if self.MaxRows > 0 && len(self.Rows) > self.MaxRows {
+		self.Rows = append(self.Rows[:0], self.Rows[len(self.Rows)-self.MaxRows:]...)
+	}
+}
+
+// scale returns MinVal and MaxVal, or the smallest and largest of the rows when both are zero.
+func (self *Spectrogram) scale(rows [][]float64) (float64, float64) {
+	if self.MinVal != 0 || self.MaxVal != 0 {
+		return self.MinVal, self.MaxVal
+	}
+	minVal, maxVal := math.Inf(1), math.Inf(-1)
+	for _, row := range rows {
+		for _, value := range row {
+			if !math.IsNaN(value) {
+				minVal, maxVal = math.Min(minVal, value), math.Max(maxVal, value)
+			}
+		}
+	}
+	if minVal > maxVal {
+		return 0, 0
+	}
+	return minVal, maxVal
+}
+
+// spectrogramLevel returns the position of a value on the scale from minVal to maxVal, from 0
+// to 1.
+func spectrogramLevel(value, minVal, maxVal float64) float64 {
+	if maxVal <= minVal {
+		return 1
+	}
+	return math.Max(math.Min((value-minVal)/(maxVal-minVal), 1), 0)
+}
+
+// spectrogramColumn returns the highest intensity of the buckets of a row drawn in column x of
+// width columns, and false if there are none.
+func spectrogramColumn(row []float64, x, width int) (float64, bool) {
+	from, to := x*len(row)/width, (x+1)*len(row)/width
+	to = MaxInt(to, from+1)
+	value, ok := math.Inf(-1), false
+	for i := from; i < to && i < len(row); i++ {
+		if !math.IsNaN(row[i]) {
+			value, ok = math.Max(value, row[i]), true
+		}
+	}
+	return value, ok
+}
+
+// cell returns the cell drawing an intensity at a level from 0 to 1.
+func (self *Spectrogram) cell(level float64) Cell {
+	if self.Density {
+		r := BRAILLE_OFFSET
+		for _, dot := range spectrogramDots[:int(math.Round(level*8))] {
+			r |= BRAILLE[dot[0]][dot[1]]
+		}
+		return NewCell(r, NewStyle(self.DotColor))
+	}
+	return NewCell(' ', NewStyle(ColorClear, gradientColor(self.Colors, level)))
+}
+
+func (self *Spectrogram) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	if self.Inner.Dx() <= 0 || self.Inner.Dy() <= 0 || (!self.Density && len(self.Colors) == 0) {
+		return
+	}
+	area := self.Inner
+	if self.ShowLegend && area.Dy() > 1 {
+		area.Max.Y--
+	}
+	rows := self.Rows[MaxInt(len(self.Rows)-area.Dy(), 0):]
+	minVal, maxVal := self.scale(rows)
+
+	for i := range rows {
+		row := rows[len(rows)-1-i]
+		if len(row) == 0 {
+			continue
+		}
+		for x := 0; x < area.Dx(); x++ {
+			if value, ok := spectrogramColumn(row, x, area.Dx()); ok {
+				buf.SetCell(self.cell(spectrogramLevel(value, minVal, maxVal)), image.Pt(area.Min.X+x, area.Min.Y+i))
+			}
+		}
+	}
+
+	if area.Max.Y < self.Inner.Max.Y {
+		self.drawLegend(buf, minVal, maxVal, area.Max.Y)
+	}
+}
+
+// drawLegend draws the scale of intensities from minVal to maxVal on the right of row y.
+func (self *Spectrogram) drawLegend(buf *Buffer, minVal, maxVal float64, y int) {
+	cells := RunesToStyledCells([]rune(self.NumFormatter(minVal)+" "), self.TextStyle)
+	steps := 10
+	if self.Density {
+		steps = 9
+	}
+	for i := 0; i < steps; i++ {
+		cells = append(cells, self.cell(float64(i)/float64(steps-1)))
+	}
+	cells = append(cells, RunesToStyledCells([]rune(" "+self.NumFormatter(maxVal)), self.TextStyle)...)
+	x := MaxInt(self.Inner.Max.X-len(cells), self.Inner.Min.X)
+	for i, cell := range TrimCells(cells, self.Inner.Max.X-x) {
+		buf.SetCell(cell, image.Pt(x+i, y))
+	}
+}
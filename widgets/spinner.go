@@ -0,0 +1,104 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// Spinner shows activity of unknown length by cycling through Frames, such as SPINNER_BRAILLE,
+// every Interval while it is started, followed by an optional Label.
+type Spinner struct {
+	Block
+	Frames   []string
+	Interval time.Duration
+	Label    string
+
+	SpinnerStyle Style
+	TextStyle    Style
+
+	// OnFrame is called from the goroutine of the animation after every frame, so that the
+	// application can Render it.
+	OnFrame func()
+
+	frame     int
+	animation *Animation
+	// paused is set while the animation is stopped by the Spinner being hidden.
+	paused bool
+}
+
+func NewSpinner() *Spinner {
+	self := &Spinner{
+		Block:        *NewBlock(),
+		Frames:       SPINNER_BRAILLE,
+		Interval:     80 * time.Millisecond,
+		SpinnerStyle: Theme.Spinner.Spinner,
+		TextStyle:    Theme.Spinner.Text,
+	}
+	self.animation = NewAnimation(self.Interval, func(time.Time) { self.Step() })
+	self.animation.OnFrame = func() {
+		if self.OnFrame != nil {
+			self.OnFrame()
+		}
+	}
+	return self
+}
+
+// Start animates the Spinner, picking up any change to its Interval.
+func (self *Spinner) Start() {
+	self.animation.Stop()
+	self.animation.Interval = self.Interval
+	self.animation.Start()
+}
+
+func (self *Spinner) Stop() {
+	self.animation.Stop()
+	self.paused = false
+}
+
+// OnVisible implements Lifecycle, stopping the animation while the Spinner is hidden.
+func (self *Spinner) OnVisible(visible bool) {
+	if !visible && self.animation.Running() {
+		self.animation.Stop()
+		self.paused = true
+	} else if visible && self.paused {
+		self.animation.Start()
+		self.paused = false
+	}
+	self.Block.OnVisible(visible)
+}
+
+func (self *Spinner) Running() bool {
+	return self.animation.Running()
+}
+
+// Step moves to the next frame. It is called by the animation while the Spinner is started.
+func (self *Spinner) Step() {
+	if len(self.Frames) > 0 {
+		self.frame = (self.frame + 1) % len(self.Frames)
+	}
+}
+
+func (self *Spinner) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	if self.Inner.Empty() {
+		return
+	}
+
+	x := self.Inner.Min.X
+	if len(self.Frames) > 0 {
+		frame := self.Frames[self.frame%len(self.Frames)]
+		buf.SetString(TrimString(frame, self.Inner.Dx()), self.SpinnerStyle, image.Pt(x, self.Inner.Min.Y))
+		x += rw.StringWidth(frame) + 1
+	}
+	if self.Label != "" && x < self.Inner.Max.X {
+		buf.SetString(TrimString(self.Label, self.Inner.Max.X-x), self.TextStyle, image.Pt(x, self.Inner.Min.Y))
+	}
+}
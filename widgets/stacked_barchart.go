@@ -24,44 +24,84 @@ type StackedBarChart struct {
 	BarWidth     int
 	BarGap       int
 	MaxVal       float64
+	// MinVal is the lowest value of the scale. Negative values are stacked down from a zero
+	// baseline. When zero, it's taken from the Data.
+	MinVal        float64
+	BaselineStyle Style
 }
 
 func NewStackedBarChart() *StackedBarChart {
 	return &StackedBarChart{
-		Block:        *NewBlock(),
-		BarColors:    Theme.StackedBarChart.Bars,
-		LabelStyles:  Theme.StackedBarChart.Labels,
-		NumStyles:    Theme.StackedBarChart.Nums,
-		NumFormatter: func(n float64) string { return fmt.Sprint(n) },
-		BarGap:       1,
-		BarWidth:     3,
+		Block:         *NewBlock(),
+		BarColors:     Theme.StackedBarChart.Bars,
+		LabelStyles:   Theme.StackedBarChart.Labels,
+		NumStyles:     Theme.StackedBarChart.Nums,
+		NumFormatter:  func(n float64) string { return fmt.Sprint(n) },
+		BarGap:        1,
+		BarWidth:      3,
+		BaselineStyle: Theme.StackedBarChart.Baseline,
 	}
 }
 
 func (self *StackedBarChart) Draw(buf *Buffer) {
 	self.Block.Draw(buf)
 
-	maxVal := self.MaxVal
-	if maxVal == 0 {
-		for _, data := range self.Data {
-			maxVal = MaxFloat64(maxVal, SumFloat64Slice(data))
+	maxVal, minVal := self.MaxVal, self.MinVal
+	for _, bar := range self.Data {
+		positive, negative := 0.0, 0.0
+		for _, data := range bar {
+			if data < 0 {
+				negative += data
+			} else {
+				positive += data
+			}
+		}
+		if self.MaxVal == 0 {
+			maxVal = MaxFloat64(maxVal, positive)
 		}
+		if self.MinVal == 0 {
+			minVal = MinFloat64(minVal, negative)
+		}
+	}
+
+	// bars are stacked up from the line above zeroYCoordinate and down from the line below it
+	scale := barScale(self.Inner.Dy()-1, maxVal, minVal)
+	zeroYCoordinate := self.Inner.Max.Y - 1
+	if minVal < 0 {
+		zeroYCoordinate = self.Inner.Min.Y + int(MaxFloat64(maxVal, 0)*scale)
+		buf.Fill(
+			NewCell(HORIZONTAL_LINE, self.BaselineStyle),
+			image.Rect(self.Inner.Min.X, zeroYCoordinate, self.Inner.Max.X, zeroYCoordinate+1),
+		)
 	}
 
 	barXCoordinate := self.Inner.Min.X
 
 	for i, bar := range self.Data {
 		// draw stacked bars
-		stackedBarYCoordinate := 0
+		stackedUp, stackedDown := 0, 0
 		for j, data := range bar {
 			// draw each stacked bar
-			height := int((data / maxVal) * float64(self.Inner.Dy()-1))
-			for x := barXCoordinate; x < MinInt(barXCoordinate+self.BarWidth, self.Inner.Max.X); x++ {
-				for y := (self.Inner.Max.Y - 2) - stackedBarYCoordinate; y > (self.Inner.Max.Y-2)-stackedBarYCoordinate-height; y-- {
-					c := NewCell(' ', NewStyle(ColorClear, SelectColor(self.BarColors, j)))
-					buf.SetCell(c, image.Pt(x, y))
-				}
+			height := int(data * scale)
+			rect := image.Rect(
+				barXCoordinate, zeroYCoordinate-stackedUp-height,
+				barXCoordinate+self.BarWidth, zeroYCoordinate-stackedUp,
+			)
+			numberYCoordinate := zeroYCoordinate - stackedUp - 1
+			if data < 0 {
+				rect = image.Rect(
+					barXCoordinate, zeroYCoordinate+1+stackedDown,
+					barXCoordinate+self.BarWidth, zeroYCoordinate+1+stackedDown-height,
+				)
+				numberYCoordinate = zeroYCoordinate + 1 + stackedDown
+				stackedDown -= height
+			} else {
+				stackedUp += height
 			}
+			buf.Fill(
+				NewCell(' ', NewStyle(ColorClear, SelectColor(self.BarColors, j))),
+				rect.Intersect(self.Inner),
+			)
 
 			// draw number
 			numberXCoordinate := barXCoordinate + int((float64(self.BarWidth) / 2)) - 1
@@ -72,10 +112,8 @@ func (self *StackedBarChart) Draw(buf *Buffer) {
 					SelectColor(self.BarColors, j),
 					SelectStyle(self.NumStyles, j+1).Modifier,
 				),
-				image.Pt(numberXCoordinate, (self.Inner.Max.Y-2)-stackedBarYCoordinate),
+				image.Pt(numberXCoordinate, numberYCoordinate),
 			)
-
-			stackedBarYCoordinate += height
 		}
 
 		// draw label
@@ -0,0 +1,236 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"math"
+	"sync"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// StatusSegment is a piece of text of a StatusBar.
+type StatusSegment struct {
+	Text string
+	// Style is used instead of the TextStyle of the StatusBar when it is set.
+	Style Style
+	// Priority decides which segments are hidden first, lowest first, when the bar is too narrow.
+	Priority int
+}
+
+func NewStatusSegment(text string, priority int) *StatusSegment {
+	return &StatusSegment{
+		Text:     text,
+		Priority: priority,
+	}
+}
+
+// StatusBar is a single row bar, like vim's, with a mode indicator and segments aligned to its
+// left, center, and right. When the segments don't fit, those with the lowest Priority are hidden.
+// Flash shows a message in place of the left segments for a while. The StatusBar is drawn on the
+// first row of its rect when it has no border.
+type StatusBar struct {
+	Block
+	Left   []*StatusSegment
+	Center []*StatusSegment
+	Right  []*StatusSegment
+	// Separator is drawn between the segments of a side.
+	Separator string
+
+	// Mode is drawn at the left of the bar, with its style from ModeStyles or ModeStyle, and is
+	// never hidden. It is hidden when it is empty.
+	Mode       string
+	ModeStyle  Style
+	ModeStyles map[string]Style
+
+	TextStyle  Style
+	FlashStyle Style
+
+	// OnFlashEnd is called from its own goroutine when a message shown by Flash expires, so that
+	// the application can Render the bar without it.
+	OnFlashEnd func()
+
+	flashLock  sync.Mutex
+	flash      string
+	flashUntil time.Time
+	flashTimer *time.Timer
+}
+
+func NewStatusBar() *StatusBar {
+	self := &StatusBar{
+		Block:      *NewBlock(),
+		Separator:  " │ ",
+		ModeStyle:  Theme.StatusBar.Mode,
+		TextStyle:  Theme.StatusBar.Text,
+		FlashStyle: Theme.StatusBar.Flash,
+	}
+	self.Border = false
+	return self
+}
+
+// Flash shows message in place of the left segments for duration, replacing any message shown.
+func (self *StatusBar) Flash(message string, duration time.Duration) {
+	self.flashLock.Lock()
+	defer self.flashLock.Unlock()
+	self.flash = message
+	self.flashUntil = time.Now().Add(duration)
+	if self.flashTimer != nil {
+		self.flashTimer.Stop()
+	}
+	self.flashTimer = time.AfterFunc(duration, func() {
+		if self.OnFlashEnd != nil {
+			self.OnFlashEnd()
+		}
+	})
+}
+
+// Flashing returns the message shown by Flash, or "" if it has expired.
+func (self *StatusBar) Flashing() string {
+	self.flashLock.Lock()
+	defer self.flashLock.Unlock()
+	if time.Now().Before(self.flashUntil) {
+		return self.flash
+	}
+	return ""
+}
+
+// statusPiece is a segment laid out on a side of the bar.
+type statusPiece struct {
+	text     string
+	style    Style
+	priority int
+	side     int
+	// mode marks the mode indicator, which is followed by a space instead of the Separator.
+	mode bool
+}
+
+func (self *StatusBar) pieces() []statusPiece {
+	pieces := []statusPiece{}
+	if self.Mode != "" {
+		style, ok := self.ModeStyles[self.Mode]
+		if !ok {
+			style = self.ModeStyle
+		}
+		pieces = append(pieces, statusPiece{" " + self.Mode + " ", style, math.MaxInt32, 0, true})
+	}
+	if flash := self.Flashing(); flash != "" {
+		pieces = append(pieces, statusPiece{flash, self.FlashStyle, math.MaxInt32 - 1, 0, false})
+	} else {
+		pieces = append(pieces, self.sidePieces(self.Left, 0)...)
+	}
+	pieces = append(pieces, self.sidePieces(self.Center, 1)...)
+	return append(pieces, self.sidePieces(self.Right, 2)...)
+}
+
+func (self *StatusBar) sidePieces(segments []*StatusSegment, side int) []statusPiece {
+	pieces := []statusPiece{}
+	for _, segment := range segments {
+		if segment.Text == "" {
+			continue
+		}
+		style := segment.Style
+		if style == (Style{}) {
+			style = self.TextStyle
+		}
+		pieces = append(pieces, statusPiece{segment.Text, style, segment.Priority, side, false})
+	}
+	return pieces
+}
+
+// separator returns what is drawn after a piece when another follows it on its side.
+func (self *StatusBar) separator(piece statusPiece) string {
+	if piece.mode {
+		return " "
+	}
+	return self.Separator
+}
+
+// sideWidth returns the width of the pieces of a side with their separators.
+func (self *StatusBar) sideWidth(pieces []statusPiece, side int) int {
+	width := 0
+	var previous *statusPiece
+	for i, piece := range pieces {
+		if piece.side != side {
+			continue
+		}
+		if previous != nil {
+			width += rw.StringWidth(self.separator(*previous))
+		}
+		width += rw.StringWidth(piece.text)
+		previous = &pieces[i]
+	}
+	return width
+}
+
+// row returns the area the bar is drawn in.
+func (self *StatusBar) row() image.Rectangle {
+	area := self.Rectangle
+	if self.Border {
+		area = self.Inner
+	}
+	return image.Rect(area.Min.X, area.Min.Y, area.Max.X, area.Min.Y+1)
+}
+
+func (self *StatusBar) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	row := self.row()
+	if row.Empty() {
+		return
+	}
+	buf.Fill(NewCell(' ', self.TextStyle), row)
+
+	// hide the lowest priority pieces, the rightmost first among equals, until the sides fit
+	// with a space between them
+	pieces := self.pieces()
+	for len(pieces) > 1 {
+		width := 0
+		sides := 0
+		for side := 0; side < 3; side++ {
+			if w := self.sideWidth(pieces, side); w > 0 {
+				width += w
+				sides++
+			}
+		}
+		if width+sides-1 <= row.Dx() {
+			break
+		}
+		lowest := len(pieces) - 1
+		for i := len(pieces) - 1; i >= 0; i-- {
+			if pieces[i].priority < pieces[lowest].priority {
+				lowest = i
+			}
+		}
+		pieces = append(pieces[:lowest], pieces[lowest+1:]...)
+	}
+
+	left := self.sideWidth(pieces, 0)
+	right := self.sideWidth(pieces, 2)
+	center := self.sideWidth(pieces, 1)
+	starts := [3]int{
+		row.Min.X,
+		MaxInt(row.Min.X+(row.Dx()-center)/2, row.Min.X+left+1),
+		row.Max.X - right,
+	}
+	if left == 0 {
+		starts[1] = MaxInt(row.Min.X+(row.Dx()-center)/2, row.Min.X)
+	}
+	previous := [3]*statusPiece{}
+	for i, piece := range pieces {
+		x := starts[piece.side]
+		if previous[piece.side] != nil {
+			separator := self.separator(*previous[piece.side])
+			buf.SetString(separator, self.TextStyle, image.Pt(x, row.Min.Y))
+			x += rw.StringWidth(separator)
+		}
+		text := TrimString(piece.text, MaxInt(row.Max.X-x, 0))
+		buf.SetString(text, piece.style, image.Pt(x, row.Min.Y))
+		starts[piece.side] = x + rw.StringWidth(text)
+		previous[piece.side] = &pieces[i]
+	}
+}
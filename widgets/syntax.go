@@ -0,0 +1,245 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"strings"
+	"unicode"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+type TokenKind uint
+
+const (
+	TokenText TokenKind = iota
+	TokenKeyword
+	TokenType
+	TokenString
+	TokenNumber
+	TokenComment
+)
+
+// Token is a piece of source code of one kind. Its Text may span several lines.
+type Token struct {
+	Text string
+	Kind TokenKind
+}
+
+// Lexer splits source code into Tokens for syntax highlighting.
+// The Tokens put together must hold all of the source.
+type Lexer interface {
+	Tokens(source string) []Token
+}
+
+// SimpleLexer is a Lexer recognizing keywords, type names, strings, numbers, and comments
+// well enough to highlight most C-like and scripting languages.
+type SimpleLexer struct {
+	Keywords []string
+	Types    []string
+	// LineComments start comments running to the end of the line, like "//" or "#".
+	LineComments []string
+	// BlockComment holds the start and end of comments spanning lines, like "/*" and "*/".
+	BlockComment [2]string
+	// Quotes start and end strings. Backslashes escape the next rune.
+	Quotes string
+}
+
+// Lexers maps the language names used with Paragraph.Language and fenced Markdown code blocks
+// to Lexers. More languages can be added to it.
+var Lexers = map[string]Lexer{}
+
+func init() {
+	c := &SimpleLexer{
+		Keywords: strings.Fields("auto break case const continue default do else enum extern for goto if " +
+			"inline register return sizeof static struct switch typedef union volatile while"),
+		Types:        strings.Fields("char double float int long short signed unsigned void bool size_t"),
+		LineComments: []string{"//"},
+		BlockComment: [2]string{"/*", "*/"},
+		Quotes:       `"'`,
+	}
+	golang := &SimpleLexer{
+		Keywords: strings.Fields("break case chan const continue default defer else fallthrough for func go goto " +
+			"if import interface map package range return select struct switch type var nil true false iota"),
+		Types: strings.Fields("bool byte complex64 complex128 error float32 float64 int int8 int16 int32 int64 " +
+			"rune string uint uint8 uint16 uint32 uint64 uintptr"),
+		LineComments: []string{"//"},
+		BlockComment: [2]string{"/*", "*/"},
+		Quotes:       "\"'`",
+	}
+	javascript := &SimpleLexer{
+		Keywords: strings.Fields("async await break case catch class const continue debugger default delete do " +
+			"else export extends finally for function if import in instanceof let new of return super switch " +
+			"this throw try typeof var void while yield null undefined true false"),
+		LineComments: []string{"//"},
+		BlockComment: [2]string{"/*", "*/"},
+		Quotes:       "\"'`",
+	}
+	python := &SimpleLexer{
+		Keywords: strings.Fields("and as assert async await break class continue def del elif else except " +
+			"finally for from global if import in is lambda nonlocal not or pass raise return try while with " +
+			"yield None True False"),
+		Types:        strings.Fields("bool bytes dict float int list object set str tuple"),
+		LineComments: []string{"#"},
+		Quotes:       `"'`,
+	}
+	shell := &SimpleLexer{
+		Keywords: strings.Fields("case do done elif else esac export fi for function if in local return " +
+			"select then until while"),
+		LineComments: []string{"#"},
+		Quotes:       `"'`,
+	}
+	json := &SimpleLexer{
+		Keywords: strings.Fields("true false null"),
+		Quotes:   `"`,
+	}
+	yaml := &SimpleLexer{
+		Keywords:     strings.Fields("true false null yes no on off"),
+		LineComments: []string{"#"},
+		Quotes:       `"'`,
+	}
+
+	for name, lexer := range map[string]Lexer{
+		"c": c, "cpp": c, "go": golang, "javascript": javascript, "js": javascript, "typescript": javascript,
+		"ts": javascript, "python": python, "py": python, "sh": shell, "bash": shell, "shell": shell,
+		"json": json, "yaml": yaml, "yml": yaml,
+	} {
+		Lexers[name] = lexer
+	}
+}
+
+func (self *SimpleLexer) Tokens(source string) []Token {
+	runes := []rune(source)
+	tokens := []Token{}
+	emit := func(kind TokenKind, start, end int) {
+		if n := len(tokens); n > 0 && tokens[n-1].Kind == kind {
+			tokens[n-1].Text += string(runes[start:end])
+		} else {
+			tokens = append(tokens, Token{string(runes[start:end]), kind})
+		}
+	}
+	hasPrefix := func(i int, prefix string) bool {
+		return prefix != "" && strings.HasPrefix(string(runes[i:MinInt(i+len([]rune(prefix)), len(runes))]), prefix)
+	}
+	isWord := func(r rune) bool {
+		return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+
+	for i := 0; i < len(runes); {
+		start := i
+		switch r := runes[i]; {
+		case self.startsLineComment(hasPrefix, i):
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			emit(TokenComment, start, i)
+		case hasPrefix(i, self.BlockComment[0]):
+			i += len([]rune(self.BlockComment[0]))
+			for i < len(runes) && !hasPrefix(i, self.BlockComment[1]) {
+				i++
+			}
+			i = MinInt(i+len([]rune(self.BlockComment[1])), len(runes))
+			emit(TokenComment, start, i)
+		case strings.ContainsRune(self.Quotes, r):
+			// strings other than raw ones end at the end of the line if they aren't closed
+			for i++; i < len(runes) && runes[i] != r && (runes[i] != '\n' || r == '`'); i++ {
+				if runes[i] == '\\' && r != '`' {
+					i++
+				}
+			}
+			if i < len(runes) && runes[i] == r {
+				i++
+			}
+			i = MinInt(i, len(runes))
+			emit(TokenString, start, i)
+		case unicode.IsDigit(r):
+			for i < len(runes) && (isWord(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			emit(TokenNumber, start, i)
+		case isWord(r):
+			for i < len(runes) && isWord(runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			kind := TokenText
+			if containsString(self.Keywords, word) {
+				kind = TokenKeyword
+			} else if containsString(self.Types, word) {
+				kind = TokenType
+			}
+			emit(kind, start, i)
+		default:
+			i++
+			emit(TokenText, start, i)
+		}
+	}
+	return tokens
+}
+
+func (self *SimpleLexer) startsLineComment(hasPrefix func(int, string) bool, i int) bool {
+	for _, comment := range self.LineComments {
+		if hasPrefix(i, comment) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// expandTabs replaces the tabs of source with spaces up to the next multiple of tabWidth columns.
+func expandTabs(source string, tabWidth int) string {
+	if tabWidth <= 0 || !strings.ContainsRune(source, '\t') {
+		return source
+	}
+	var sb strings.Builder
+	column := 0
+	for _, r := range source {
+		switch r {
+		case '\t':
+			spaces := tabWidth - column%tabWidth
+			sb.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+		case '\n':
+			sb.WriteRune(r)
+			column = 0
+		default:
+			sb.WriteRune(r)
+			column++
+		}
+	}
+	return sb.String()
+}
+
+// highlightCode returns source as cells colored with the Syntax styles of the Theme.
+// Source in a language without a Lexer is drawn with style.
+func highlightCode(source string, language string, style Style) []Cell {
+	lexer, ok := Lexers[strings.ToLower(language)]
+	if !ok {
+		return RunesToStyledCells([]rune(source), style)
+	}
+	theme := Theme.Paragraph.Syntax
+	styles := map[TokenKind]Style{
+		TokenText:    style,
+		TokenKeyword: theme.Keyword,
+		TokenType:    theme.Type,
+		TokenString:  theme.String,
+		TokenNumber:  theme.Number,
+		TokenComment: theme.Comment,
+	}
+	cells := []Cell{}
+	for _, token := range lexer.Tokens(source) {
+		cells = append(cells, RunesToStyledCells([]rune(token.Text), styles[token.Kind])...)
+	}
+	return cells
+}
@@ -5,12 +5,24 @@
 package widgets
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
 	"image"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
 
 	. "github.com/reaalkhalil/termui"
 )
 
-/*Table is like:
+/*
+Table is like:
 ┌ Awesome Table ───────────────────────────────────────────────┐
 │  Col0          | Col1 | Col2 | Col3  | Col4  | Col5  | Col6  |
 │──────────────────────────────────────────────────────────────│
@@ -29,18 +41,747 @@ type Table struct {
 	RowStyles     map[int]Style
 	FillRow       bool
 
+	// WrapCells wraps the text of cells to their column width so rows grow to fit it
+	// instead of being cut off with an ellipsis.
+	WrapCells bool
+
+	// Direction is the base direction of the text of cells, which is drawn in the order of the
+	// Unicode bidirectional algorithm. Right-to-left cells with AlignLeft are aligned right. With
+	// DirectionAuto, every cell takes the direction of its first letter.
+	Direction Direction
+
+	// Provider, when set, is used in place of Rows. Only the rows in view are requested on each Draw,
+	// so sorting, filtering, searching, and editing are not available.
+	Provider TableProvider
+
+	// Header is drawn above Rows and stays in place while they are sorted and scrolled.
+	Header      []string
+	HeaderStyle Style
+
+	// AutoColumnWidths sizes columns without ColumnWidths to fit their widest value,
+	// limited to MinColumnWidth and MaxColumnWidth when they are greater than 0.
+	AutoColumnWidths bool
+	MinColumnWidth   int
+	MaxColumnWidth   int
+
+	// FrozenColumns is the number of leading columns which stay in place when scrolling horizontally.
+	FrozenColumns int
+
+	// Comparators holds per-column less functions used by Sort.
+	// Columns without one are compared with CompareStrings.
+	Comparators map[int]TableComparator
+
+	// Selectable shows a row cursor drawn with SelectedRowStyle which is moved with the Scroll methods.
+	// MultiSelect additionally allows rows to be marked with ToggleSelection and the Extend methods.
+	Selectable       bool
+	MultiSelect      bool
+	SelectedRowStyle Style
+	MarkedRowStyle   Style
+
+	// Paginate shows rows one page of PageSize rows at a time with a "Page X of Y" footer
+	// instead of scrolling smoothly. PageSize defaults to as many rows as fit.
+	Paginate bool
+	PageSize int
+
+	// MatchStyle highlights text matching the search query.
+	MatchStyle Style
+
+	// ScrollbarStyle is used for the scrollbar drawn on the right edge when the rows don't fit.
+	ScrollbarStyle Style
+
+	// Editable lets the cell at SelectedColumn in the row under the cursor be edited in place
+	// after StartEdit. OnEditCommit receives the new value and returns whether to store it in Rows.
+	// OnEditCancel is called when an edit is abandoned. Both callbacks are optional.
+	Editable          bool
+	SelectedColumn    int
+	SelectedCellStyle Style
+	OnEditCommit      func(row, col int, value string) bool
+	OnEditCancel      func(row, col int)
+
+	// History holds the committed edits of cells reverted by Undo and made again by Redo, which
+	// go through OnEditCommit like edits. While a cell is edited, <C-z> and <C-y> undo and redo
+	// the keystrokes editing it.
+	History *History
+
+	// CellStyle, when set, returns the style of each cell in place of its row style.
+	// row is the index in Rows. It is not used for the header or for selected and marked rows.
+	CellStyle func(row, col int, value string) Style
+
 	// ColumnResizer is called on each Draw. Can be used for custom column sizing.
 	ColumnResizer func()
+
+	// OnActivate is called with the index in Rows of the row double-clicked in HandleMouse.
+	OnActivate func(row int)
+
+	sortColumn    int
+	sortAscending bool
+
+	// cursor and topRow are positions in the displayed rows, marked holds indexes of Rows.
+	cursor int
+	topRow int
+	marked map[int]bool
+
+	// columnOffset is the number of unfrozen columns scrolled out of view on the left.
+	columnOffset int
+
+	// resizedWidths holds widths set with ResizeColumn and mouse dragging, by column.
+	resizedWidths map[int]int
+	resizing      int
+	drawnColumns  []int
+	drawnWidths   []int
+	// drawnRows holds the rows drawn by the last Draw.
+	drawnRows []tableRowSpan
+	scrollbar Scrollbar
+
+	filter func([]string) bool
+	searchBar
+
+	editing    bool
+	editor     lineEditor
+	editRow    int
+	editColumn int
 }
 
+// TableComparator reports whether cell value a sorts before cell value b.
+type TableComparator func(a, b string) bool
+
 func NewTable() *Table {
 	return &Table{
-		Block:         *NewBlock(),
-		TextStyle:     Theme.Table.Text,
-		RowSeparator:  true,
-		RowStyles:     make(map[int]Style),
-		ColumnResizer: func() {},
+		Block:             *NewBlock(),
+		TextStyle:         Theme.Table.Text,
+		HeaderStyle:       Theme.Table.Header,
+		SelectedRowStyle:  Theme.Table.SelectedRow,
+		MarkedRowStyle:    Theme.Table.MarkedRow,
+		SelectedCellStyle: Theme.Table.SelectedCell,
+		MatchStyle:        Theme.Table.Match,
+		ScrollbarStyle:    Theme.Table.Scrollbar,
+		RowSeparator:      true,
+		RowStyles:         make(map[int]Style),
+		Comparators:       make(map[int]TableComparator),
+		ColumnResizer:     func() {},
+		History:           NewHistory(),
+		sortColumn:        -1,
+		marked:            make(map[int]bool),
+		resizedWidths:     make(map[int]int),
+		resizing:          -1,
+		editor:            lineEditor{history: NewHistory()},
+	}
+}
+
+// CompareStrings compares cell values lexically, ignoring case.
+func CompareStrings(a, b string) bool {
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+// CompareNumbers compares cell values numerically. Values that are not numbers sort last.
+func CompareNumbers(a, b string) bool {
+	x, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	y, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if errA != nil || errB != nil {
+		return errA == nil && errB != nil
+	}
+	return x < y
+}
+
+// CompareTimes returns a TableComparator for cell values formatted with the given time layout.
+// Values that fail to parse sort last.
+func CompareTimes(layout string) TableComparator {
+	return func(a, b string) bool {
+		x, errA := time.Parse(layout, strings.TrimSpace(a))
+		y, errB := time.Parse(layout, strings.TrimSpace(b))
+		if errA != nil || errB != nil {
+			return errA == nil && errB != nil
+		}
+		return x.Before(y)
+	}
+}
+
+// Sort orders the displayed rows by the given column. Rows keeps its original order,
+// so RowStyles continue to apply to the same rows. A negative column removes sorting.
+func (self *Table) Sort(column int, ascending bool) {
+	self.sortColumn = column
+	self.sortAscending = ascending
+}
+
+// SortColumn returns the sorted column, or -1 if the table is unsorted, and its direction.
+func (self *Table) SortColumn() (int, bool) {
+	return self.sortColumn, self.sortAscending
+}
+
+// SetFilter hides the rows for which keep returns false without changing Rows.
+// A nil function shows every row again.
+func (self *Table) SetFilter(keep func(row []string) bool) {
+	self.filter = keep
+}
+
+// StartSearch opens the search bar on the bottom line. While it is open,
+// HandleSearchKey edits the query, and only rows with a cell containing the query are shown.
+func (self *Table) StartSearch() {
+	self.openSearch()
+}
+
+// ClearSearch closes the search bar and shows the rows it was hiding.
+func (self *Table) ClearSearch() {
+	self.clearSearch()
+}
+
+// SetSearchQuery applies query like one typed in the search bar, without showing the bar, for a
+// SearchBar bound to the Table.
+func (self *Table) SetSearchQuery(query string) {
+	self.linkSearch(query, self.scrollTop)
+}
+
+// SearchMatches returns the number of rows passing the filter and the search query.
+func (self *Table) SearchMatches() int {
+	return self.rowCount()
+}
+
+// HandleSearchKey applies a keyboard event ID to the search bar and reports whether it was used.
+// <Enter> closes the bar keeping the query applied, <Escape> clears it.
+func (self *Table) HandleSearchKey(id string) bool {
+	return self.handleSearchKey(id, self.scrollTop)
+}
+
+// scrollTop moves the cursor to the first row matching the query as it changes.
+func (self *Table) scrollTop() {
+	self.cursor, self.topRow = 0, 0
+}
+
+// matches reports whether a row passes the filter and the search query.
+func (self *Table) matches(row []string) bool {
+	if self.filter != nil && !self.filter(row) {
+		return false
+	}
+	query := strings.ToLower(self.query.text())
+	if query == "" {
+		return true
+	}
+	for _, cell := range row {
+		if strings.Contains(strings.ToLower(CellsToString(ParseStyles(cell, self.TextStyle))), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// TableProvider supplies rows to a Table on demand so only the rows in view are materialized.
+type TableProvider interface {
+	RowCount() int
+	Row(i int) []string
+}
+
+// dataCount returns the number of rows held by the Provider or Rows.
+func (self *Table) dataCount() int {
+	if self.Provider != nil {
+		return self.Provider.RowCount()
+	}
+	return len(self.Rows)
+}
+
+// rowData returns the cells of the row at the given index of the Provider or Rows.
+func (self *Table) rowData(i int) []string {
+	if self.Provider != nil {
+		return self.Provider.Row(i)
+	}
+	return self.Rows[i]
+}
+
+// rowCount returns the number of rows shown after filtering.
+func (self *Table) rowCount() int {
+	if self.Provider != nil || (self.filter == nil && self.query.text() == "") {
+		return self.dataCount()
+	}
+	count := 0
+	for _, row := range self.Rows {
+		if self.matches(row) {
+			count++
+		}
+	}
+	return count
+}
+
+// tableRowSpan is the rows of the screen, from top up to bottom, a row was drawn in.
+type tableRowSpan struct {
+	position    int
+	top, bottom int
+}
+
+// tableRows maps positions in the drawn order to row indexes.
+// A nil index means rows are drawn in their own order.
+type tableRows struct {
+	index []int
+	count int
+}
+
+func (self tableRows) at(i int) int {
+	if self.index == nil {
+		return i
+	}
+	return self.index[i]
+}
+
+// displayRows returns the indexes of rows in the order they are drawn, leaving out filtered rows.
+// Rows from a Provider are never sorted or filtered.
+func (self *Table) displayRows() tableRows {
+	if self.Provider != nil || (self.sortColumn < 0 && self.filter == nil && self.query.text() == "") {
+		return tableRows{count: self.dataCount()}
+	}
+
+	rows := make([]int, 0, len(self.Rows))
+	for i, row := range self.Rows {
+		if self.matches(row) {
+			rows = append(rows, i)
+		}
+	}
+
+	if self.sortColumn >= 0 {
+		less, ok := self.Comparators[self.sortColumn]
+		if !ok {
+			less = CompareStrings
+		}
+		cell := func(row int) string {
+			if self.sortColumn < len(self.Rows[row]) {
+				return self.Rows[row][self.sortColumn]
+			}
+			return ""
+		}
+		sort.SliceStable(rows, func(i, j int) bool {
+			if self.sortAscending {
+				return less(cell(rows[i]), cell(rows[j]))
+			}
+			return less(cell(rows[j]), cell(rows[i]))
+		})
+	}
+
+	return tableRows{rows, len(rows)}
+}
+
+// SelectedRow returns the index in Rows of the row under the cursor, or -1 if there are no rows.
+func (self *Table) SelectedRow() int {
+	rows := self.displayRows()
+	if rows.count == 0 {
+		return -1
+	}
+	self.cursor = MaxInt(MinInt(self.cursor, rows.count-1), 0)
+	return rows.at(self.cursor)
+}
+
+// Accessibility implements Accessible with the Title and, when the Table is Selectable, the
+// cells of the row under the cursor.
+func (self *Table) Accessibility() (string, bool, string) {
+	if !self.Selectable {
+		return self.Title, false, ""
+	}
+	row := self.SelectedRow()
+	if row < 0 {
+		return self.Title, false, ""
+	}
+	return self.Title, false, strings.Join(self.rowData(row), ", ")
+}
+
+// SelectedRows returns the indexes in Rows of all marked rows in ascending order,
+// or just the row under the cursor if none are marked.
+func (self *Table) SelectedRows() []int {
+	selected := []int{}
+	for row, marked := range self.marked {
+		if marked && row < self.dataCount() {
+			selected = append(selected, row)
+		}
+	}
+	if len(selected) == 0 {
+		if row := self.SelectedRow(); row >= 0 {
+			selected = append(selected, row)
+		}
+	}
+	sort.Ints(selected)
+	return selected
+}
+
+// ToggleSelection marks or unmarks the row under the cursor when MultiSelect is enabled.
+func (self *Table) ToggleSelection() {
+	if row := self.SelectedRow(); self.MultiSelect && row >= 0 {
+		self.marked[row] = !self.marked[row]
+	}
+}
+
+// SelectAll marks every row when MultiSelect is enabled.
+func (self *Table) SelectAll() {
+	if self.MultiSelect {
+		for row := 0; row < self.dataCount(); row++ {
+			self.marked[row] = true
+		}
+	}
+}
+
+// ClearSelection unmarks every row.
+func (self *Table) ClearSelection() {
+	self.marked = make(map[int]bool)
+}
+
+// ExtendSelectionUp marks the row under the cursor and the one above it, then moves the cursor up.
+func (self *Table) ExtendSelectionUp() {
+	self.extendSelection(-1)
+}
+
+// ExtendSelectionDown marks the row under the cursor and the one below it, then moves the cursor down.
+func (self *Table) ExtendSelectionDown() {
+	self.extendSelection(1)
+}
+
+func (self *Table) extendSelection(amount int) {
+	if !self.MultiSelect {
+		self.ScrollAmount(amount)
+		return
+	}
+	if row := self.SelectedRow(); row >= 0 {
+		self.marked[row] = true
+	}
+	self.ScrollAmount(amount)
+	if row := self.SelectedRow(); row >= 0 {
+		self.marked[row] = true
+	}
+}
+
+// ScrollAmount moves the cursor by the amount given, or the view when the table isn't Selectable.
+// If amount is < 0, then scroll up. The header stays in place while scrolling.
+// When paginated and not Selectable, the view moves by at least one page.
+func (self *Table) ScrollAmount(amount int) {
+	if self.Selectable {
+		self.cursor = MaxInt(MinInt(self.cursor+amount, self.rowCount()-1), 0)
+	} else if self.Paginate {
+		pages := MaxInt(AbsInt(amount)/self.pageSize(), 1)
+		if amount < 0 {
+			pages = -pages
+		}
+		self.topRow = MaxInt(MinInt(self.topRow+pages*self.pageSize(), self.rowCount()-1), 0)
+	} else if self.WrapCells {
+		// the row heights are only known while drawing, which keeps the last rows in view
+		self.topRow = MaxInt(MinInt(self.topRow+amount, self.rowCount()-1), 0)
+	} else {
+		self.topRow = MaxInt(MinInt(self.topRow+amount, self.rowCount()-self.visibleRowCount()), 0)
+	}
+}
+
+func (self *Table) ScrollUp() {
+	self.ScrollAmount(-1)
+}
+
+func (self *Table) ScrollDown() {
+	self.ScrollAmount(1)
+}
+
+func (self *Table) ScrollPageUp() {
+	self.ScrollAmount(-self.pageSize())
+}
+
+func (self *Table) ScrollPageDown() {
+	self.ScrollAmount(self.pageSize())
+}
+
+// NextPage shows the next page when paginated, moving the cursor to its first row.
+func (self *Table) NextPage() {
+	self.goToPage(self.topRow/self.pageSize() + 1)
+}
+
+// PreviousPage shows the previous page when paginated, moving the cursor to its first row.
+func (self *Table) PreviousPage() {
+	self.goToPage(self.topRow/self.pageSize() - 1)
+}
+
+func (self *Table) goToPage(page int) {
+	pages := (self.rowCount() + self.pageSize() - 1) / self.pageSize()
+	page = MaxInt(MinInt(page, pages-1), 0)
+	self.topRow = page * self.pageSize()
+	self.cursor = self.topRow
+}
+
+// CurrentPage returns the 1-based number of the page shown and the total number of pages.
+func (self *Table) CurrentPage() (int, int) {
+	pages := MaxInt((self.rowCount()+self.pageSize()-1)/self.pageSize(), 1)
+	return self.topRow/self.pageSize() + 1, pages
+}
+
+// pageSize returns the number of rows shown at once.
+func (self *Table) pageSize() int {
+	if self.Paginate && self.PageSize > 0 && self.PageSize < self.visibleRowCount() {
+		return self.PageSize
+	}
+	return self.visibleRowCount()
+}
+
+func (self *Table) ScrollTop() {
+	self.cursor = 0
+	self.topRow = 0
+}
+
+func (self *Table) ScrollBottom() {
+	self.cursor = MaxInt(self.rowCount()-1, 0)
+	self.topRow = MaxInt(self.rowCount()-self.visibleRowCount(), 0)
+}
+
+// MinimapLines returns the rows displayed with their values separated by spaces, for a Minimap.
+// Rows of a Provider are left empty rather than requested.
+func (self *Table) MinimapLines() [][]Cell {
+	rows := self.displayRows()
+	lines := make([][]Cell, rows.count)
+	if self.Provider != nil {
+		return lines
+	}
+	for i := range lines {
+		row := rows.at(i)
+		style := self.TextStyle
+		if rowStyle, ok := self.RowStyles[row]; ok {
+			style = rowStyle
+		}
+		lines[i] = ParseStyles(strings.Join(self.Rows[row], " "), style)
+	}
+	return lines
+}
+
+// Viewport returns the first row in view and the number of rows in view.
+func (self *Table) Viewport() (int, int) {
+	return self.topRow, self.visibleRowCount()
+}
+
+// ScrollToLine scrolls the rows to show row line first, keeping the cursor in view.
+func (self *Table) ScrollToLine(line int) {
+	self.topRow = MaxInt(MinInt(line, self.rowCount()-1), 0)
+	if self.Selectable {
+		self.cursor = MaxInt(MinInt(self.cursor, self.topRow+self.visibleRowCount()-1), self.topRow)
+	}
+}
+
+// visibleRowCount returns how many rows fit between the header and the page footer.
+func (self *Table) visibleRowCount() int {
+	height := self.Inner.Dy()
+	if self.Paginate || self.searchBarShown() {
+		height--
+	}
+	if len(self.Header) > 0 {
+		height--
+		if self.RowSeparator {
+			height--
+		}
+	}
+	if self.RowSeparator {
+		return MaxInt((height+1)/2, 1)
+	}
+	return MaxInt(height, 1)
+}
+
+// styledWidth returns the width of text without its style markup.
+func styledWidth(text string) int {
+	if !strings.ContainsRune(text, '[') {
+		return rw.StringWidth(text)
+	}
+	return rw.StringWidth(CellsToString(ParseStyles(text, StyleClear)))
+}
+
+// columnWidths returns the width of every column: ColumnWidths if set, otherwise the content
+// widths with AutoColumnWidths or an equal share of the width, with resized columns applied.
+func (self *Table) columnWidths() []int {
+	columnCount := self.columnCount()
+	widths := make([]int, 0, columnCount)
+	switch {
+	case len(self.ColumnWidths) > 0:
+		widths = append(widths, self.ColumnWidths...)
+	case self.AutoColumnWidths:
+		measure := func(row []string) {
+			for i := 0; i < len(row) && i < columnCount; i++ {
+				widths[i] = MaxInt(widths[i], styledWidth(row[i]))
+			}
+		}
+		widths = widths[:columnCount]
+		measure(self.Header)
+		if self.Provider != nil {
+			// only the rows in view are measured to keep the cost independent of the row count
+			for i := self.topRow; i < MinInt(self.topRow+self.visibleRowCount(), self.dataCount()); i++ {
+				measure(self.rowData(i))
+			}
+		} else {
+			for _, row := range self.Rows {
+				measure(row)
+			}
+		}
+		for i := range widths {
+			if i == self.sortColumn {
+				widths[i] += 2 // room for the sort indicator
+			}
+			if self.MinColumnWidth > 0 {
+				widths[i] = MaxInt(widths[i], self.MinColumnWidth)
+			}
+			if self.MaxColumnWidth > 0 {
+				widths[i] = MinInt(widths[i], self.MaxColumnWidth)
+			}
+		}
+	case columnCount > 0:
+		columnWidth := self.Inner.Dx() / columnCount
+		for i := 0; i < columnCount; i++ {
+			widths = append(widths, columnWidth)
+		}
+	}
+	for column, width := range self.resizedWidths {
+		if column < len(widths) {
+			widths[column] = width
+		}
+	}
+	return widths
+}
+
+// ResizeColumn changes the width of a column by delta cells, keeping it at least 1 cell wide.
+func (self *Table) ResizeColumn(column, delta int) {
+	widths := self.columnWidths()
+	if column >= 0 && column < len(widths) {
+		self.resizedWidths[column] = MaxInt(widths[column]+delta, 1)
+	}
+}
+
+// ResetColumnWidths discards widths changed with ResizeColumn and mouse dragging.
+func (self *Table) ResetColumnWidths() {
+	self.resizedWidths = make(map[int]int)
+}
+
+// tableState is the state of a Table saved by SaveState.
+type tableState struct {
+	Cursor        int         `json:"cursor"`
+	TopRow        int         `json:"topRow"`
+	ColumnOffset  int         `json:"columnOffset"`
+	SortColumn    int         `json:"sortColumn"`
+	SortAscending bool        `json:"sortAscending"`
+	Marked        []int       `json:"marked,omitempty"`
+	ColumnWidths  map[int]int `json:"columnWidths,omitempty"`
+}
+
+// SaveState implements Persistent with the cursor, the scroll positions, the sort order, the
+// marked rows, and the widths of the columns resized with ResizeColumn or the mouse.
+func (self *Table) SaveState() ([]byte, error) {
+	marked := []int{}
+	for row, ok := range self.marked {
+		if ok {
+			marked = append(marked, row)
+		}
+	}
+	sort.Ints(marked)
+	return json.Marshal(tableState{
+		self.cursor, self.topRow, self.columnOffset, self.sortColumn, self.sortAscending, marked, self.resizedWidths,
+	})
+}
+
+// RestoreState implements Persistent. Rows and columns past the end of the Table are left out.
+func (self *Table) RestoreState(data []byte) error {
+	state := tableState{SortColumn: -1}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	columns := self.columnCount()
+	if state.SortColumn < columns {
+		self.Sort(state.SortColumn, state.SortAscending)
+	}
+	count := self.dataCount()
+	self.cursor = MaxInt(MinInt(state.Cursor, count-1), 0)
+	self.topRow = MaxInt(MinInt(state.TopRow, count-1), 0)
+	self.columnOffset = MaxInt(MinInt(state.ColumnOffset, columns-1), 0)
+	self.marked = make(map[int]bool)
+	for _, row := range state.Marked {
+		if row >= 0 && row < count {
+			self.marked[row] = true
+		}
+	}
+	self.resizedWidths = make(map[int]int)
+	for column, width := range state.ColumnWidths {
+		if column >= 0 && column < columns && width > 0 {
+			self.resizedWidths[column] = width
+		}
 	}
+	return nil
+}
+
+// HandleMouse moves the cursor of a Selectable Table to the row clicked with the left mouse button,
+// calls OnActivate for a double-click, and scrolls with the mouse wheel and the scrollbar.
+// It reports whether the event was used.
+func (self *Table) HandleMouse(e Event) bool {
+	if offset, ok := self.scrollbar.HandleMouse(e); ok {
+		self.ScrollToLine(offset)
+		return true
+	}
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Inner) {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.ScrollAmount(-WheelSteps(e))
+	case "<MouseWheelDown>":
+		self.ScrollAmount(WheelSteps(e))
+	case "<MouseLeft>":
+		m := e.Payload.(Mouse)
+		if m.Drag {
+			return false
+		}
+		for _, span := range self.drawnRows {
+			if p.Y < span.top || p.Y >= span.bottom {
+				continue
+			}
+			if self.Selectable {
+				self.cursor = span.position
+			}
+			if m.Clicks == 2 && self.OnActivate != nil {
+				self.OnActivate(self.displayRows().at(span.position))
+			}
+			return true
+		}
+		return false
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouseResize resizes columns by dragging the separator to the right of a column with the
+// left mouse button. It reports whether the event started, continued, or finished a resize.
+func (self *Table) HandleMouseResize(e Event) bool {
+	m, ok := e.Payload.(Mouse)
+	if !ok {
+		return false
+	}
+	switch {
+	case e.ID == "<MouseRelease>":
+		resizing := self.resizing >= 0
+		self.resizing = -1
+		return resizing
+	case e.ID != "<MouseLeft>":
+		return false
+	case self.resizing >= 0:
+		x := self.Inner.Min.X
+		for _, j := range self.drawnColumns {
+			if j == self.resizing {
+				self.resizedWidths[j] = MaxInt(m.X-x, 1)
+				break
+			}
+			x += self.drawnWidths[j] + 1
+		}
+		return true
+	case m.Y < self.Inner.Min.Y || m.Y >= self.Inner.Max.Y:
+		return false
+	}
+	x := self.Inner.Min.X
+	for _, j := range self.drawnColumns {
+		x += self.drawnWidths[j]
+		if m.X == x {
+			self.resizing = j
+			return true
+		}
+		x++
+	}
+	return false
+}
+
+func (self *Table) columnCount() int {
+	count := len(self.Header)
+	if self.dataCount() > 0 {
+		count = MaxInt(count, len(self.rowData(0)))
+	}
+	return count
 }
 
 func (self *Table) Draw(buf *Buffer) {
@@ -48,89 +789,613 @@ func (self *Table) Draw(buf *Buffer) {
 
 	self.ColumnResizer()
 
-	columnWidths := self.ColumnWidths
+	columnWidths := self.columnWidths()
 	if len(columnWidths) == 0 {
-		columnCount := len(self.Rows[0])
-		columnWidth := self.Inner.Dx() / columnCount
-		for i := 0; i < columnCount; i++ {
-			columnWidths = append(columnWidths, columnWidth)
-		}
+		return
 	}
 
+	columns := self.visibleColumns(len(columnWidths))
+	self.drawnColumns, self.drawnWidths = columns, columnWidths
+	self.drawColumnIndicators(buf, columnWidths, columns)
+
 	yCoordinate := self.Inner.Min.Y
 
-	// draw rows
-	for i := 0; i < len(self.Rows) && yCoordinate < self.Inner.Max.Y; i++ {
-		row := self.Rows[i]
-		colXCoordinate := self.Inner.Min.X
+	// draw header
+	if len(self.Header) > 0 {
+		header := make([]string, len(self.Header))
+		copy(header, self.Header)
+		if self.sortColumn >= 0 && self.sortColumn < len(header) {
+			indicator := DOWN_ARROW
+			if self.sortAscending {
+				indicator = UP_ARROW
+			}
+			header[self.sortColumn] += " " + string(indicator)
+		}
+		yCoordinate += self.drawRow(buf, header, self.HeaderStyle, nil, "", columnWidths, columns, yCoordinate)
+		if self.RowSeparator && yCoordinate < self.Inner.Max.Y && self.dataCount() > 0 {
+			self.drawRowSeparator(buf, yCoordinate)
+			yCoordinate++
+		}
+	}
+
+	rows := self.displayRows()
 
+	// adjusts view into widget
+	self.cursor = MaxInt(MinInt(self.cursor, rows.count-1), 0)
+	if self.Paginate {
+		anchor := self.topRow
+		if self.Selectable {
+			anchor = self.cursor
+		}
+		self.topRow = MaxInt(MinInt(anchor, rows.count-1), 0) / self.pageSize() * self.pageSize()
+	} else if !self.Selectable {
+		if !self.WrapCells {
+			self.topRow = MaxInt(MinInt(self.topRow, rows.count-self.visibleRowCount()), 0)
+		}
+	} else if self.cursor >= self.topRow+self.visibleRowCount() {
+		self.topRow = self.cursor - self.visibleRowCount() + 1
+	} else if self.cursor < self.topRow {
+		self.topRow = self.cursor
+	}
+	rowsYCoordinate := yCoordinate
+	if self.WrapCells && !self.Paginate {
+		self.fitWrappedRows(rows, columnWidths, columns, rowsYCoordinate)
+	}
+
+	// draw rows
+	lastRow := MinInt(rows.count, self.topRow+self.pageSize())
+	drawnRows := self.topRow
+	self.drawnRows = self.drawnRows[:0]
+	for i := self.topRow; i < lastRow && yCoordinate < self.Inner.Max.Y; i++ {
+		row := rows.at(i)
 		rowStyle := self.TextStyle
 		// get the row style if one exists
-		if style, ok := self.RowStyles[i]; ok {
+		if style, ok := self.RowStyles[row]; ok {
 			rowStyle = style
 		}
-
-		if self.FillRow {
-			blankCell := NewCell(' ', rowStyle)
-			buf.Fill(blankCell, image.Rect(self.Inner.Min.X, yCoordinate, self.Inner.Max.X, yCoordinate+1))
-		}
-
-		// draw row cells
-		for j := 0; j < len(row); j++ {
-			col := ParseStyles(row[j], rowStyle)
-			// draw row cell
-			if len(col) > columnWidths[j] || self.TextAlignment == AlignLeft {
-				for _, cx := range BuildCellWithXArray(col) {
-					k, cell := cx.X, cx.Cell
-					if k == columnWidths[j] || colXCoordinate+k == self.Inner.Max.X {
-						cell.Rune = ELLIPSES
-						buf.SetCell(cell, image.Pt(colXCoordinate+k-1, yCoordinate))
-						break
-					} else {
-						buf.SetCell(cell, image.Pt(colXCoordinate+k, yCoordinate))
+		var cellStyle func(int, string) Style
+		if self.CellStyle != nil {
+			cellStyle = func(col int, value string) Style {
+				return self.CellStyle(row, col, value)
+			}
+		}
+		if self.marked[row] {
+			rowStyle = self.MarkedRowStyle
+			cellStyle = nil
+		}
+		if self.Selectable && i == self.cursor {
+			rowStyle = self.SelectedRowStyle
+			cellStyle = nil
+			if self.Editable {
+				cellStyle = func(col int, value string) Style {
+					if col == self.SelectedColumn {
+						return self.SelectedCellStyle
 					}
+					return self.SelectedRowStyle
 				}
-			} else if self.TextAlignment == AlignCenter {
-				xCoordinateOffset := (columnWidths[j] - len(col)) / 2
-				stringXCoordinate := xCoordinateOffset + colXCoordinate
-				for _, cx := range BuildCellWithXArray(col) {
-					k, cell := cx.X, cx.Cell
-					buf.SetCell(cell, image.Pt(stringXCoordinate+k, yCoordinate))
-				}
-			} else if self.TextAlignment == AlignRight {
-				stringXCoordinate := MinInt(colXCoordinate+columnWidths[j], self.Inner.Max.X) - len(col)
-				for _, cx := range BuildCellWithXArray(col) {
-					k, cell := cx.X, cx.Cell
-					buf.SetCell(cell, image.Pt(stringXCoordinate+k, yCoordinate))
+			}
+		}
+
+		height := self.drawRow(buf, self.rowData(row), rowStyle, cellStyle, self.query.text(), columnWidths, columns, yCoordinate)
+		drawnRows = i + 1
+		self.drawnRows = append(self.drawnRows, tableRowSpan{i, yCoordinate, yCoordinate + height})
+
+		// draw the cell being edited over the row
+		if self.editing && row == self.editRow {
+			x := self.Inner.Min.X
+			for _, j := range columns {
+				if j == self.editColumn {
+					width := MinInt(columnWidths[j], self.Inner.Max.X-x)
+					self.editor.draw(buf, image.Pt(x, yCoordinate), width, self.SelectedCellStyle, true)
+					break
 				}
+				x += columnWidths[j] + 1
 			}
+		}
+
+		yCoordinate += height
+
+		// draw horizontal separator
+		if self.RowSeparator && yCoordinate < self.Inner.Max.Y && i != lastRow-1 {
+			self.drawRowSeparator(buf, yCoordinate)
+			yCoordinate++
+		}
+	}
+
+	// draw search bar
+	if self.searchBarShown() {
+		self.drawSearchBar(buf, image.Pt(self.Inner.Min.X, self.Inner.Max.Y-1), self.Inner.Dx()/2, self.TextStyle)
+	}
+
+	if self.Paginate {
+		page, pages := self.CurrentPage()
+		footer := fmt.Sprintf("Page %d of %d", page, pages)
+		buf.SetString(
+			TrimString(footer, self.Inner.Dx()),
+			self.TextStyle,
+			image.Pt(MaxInt(self.Inner.Max.X-len(footer), self.Inner.Min.X), self.Inner.Max.Y-1),
+		)
+		return
+	}
+
+	// draw the scrollbar beside the rows, above the search bar
+	visible := self.visibleRowCount()
+	if self.WrapCells {
+		visible = drawnRows - self.topRow
+	}
+	maxY := self.Inner.Max.Y
+	if self.searchBarShown() {
+		maxY--
+	}
+	self.scrollbar.Style = self.ScrollbarStyle
+	self.scrollbar.Draw(buf, image.Rect(self.Inner.Max.X-1, rowsYCoordinate, self.Inner.Max.X, maxY),
+		self.topRow, visible, rows.count)
+}
+
+// fitWrappedRows moves topRow so that the cursor, or otherwise as many of the last rows as possible,
+// fit below yCoordinate when rows are taller than one line.
+func (self *Table) fitWrappedRows(rows tableRows, columnWidths []int, columns []int, yCoordinate int) {
+	available := self.Inner.Max.Y - yCoordinate
+	if self.searchBarShown() {
+		available--
+	}
+	height := func(i int) int {
+		h := self.rowHeight(self.rowData(rows.at(i)), columnWidths, columns)
+		if self.RowSeparator {
+			h++
+		}
+		return h
+	}
+	// fits reports whether the rows from first to last are drawn in full
+	fits := func(first, last int) bool {
+		total := 0
+		if self.RowSeparator {
+			total--
+		}
+		for i := first; i <= last; i++ {
+			total += height(i)
+			if total > available {
+				return false
+			}
+		}
+		return true
+	}
+
+	if self.Selectable {
+		for self.topRow < self.cursor && !fits(self.topRow, self.cursor) {
+			self.topRow++
+		}
+		return
+	}
+	lastTop := MaxInt(rows.count-1, 0)
+	for lastTop > 0 && fits(lastTop-1, rows.count-1) {
+		lastTop--
+	}
+	self.topRow = MinInt(self.topRow, lastTop)
+}
+
+// visibleColumns returns the indexes of the columns drawn, in order:
+// the frozen columns followed by the rest starting at the horizontal scroll offset.
+func (self *Table) visibleColumns(columnCount int) []int {
+	columns := []int{}
+	frozen := MinInt(MaxInt(self.FrozenColumns, 0), columnCount)
+	for i := 0; i < frozen; i++ {
+		columns = append(columns, i)
+	}
+	self.columnOffset = MaxInt(MinInt(self.columnOffset, columnCount-frozen-1), 0)
+	for i := frozen + self.columnOffset; i < columnCount; i++ {
+		columns = append(columns, i)
+	}
+	return columns
+}
+
+// SelectNextColumn moves the selected cell of an Editable table one column to the right.
+func (self *Table) SelectNextColumn() {
+	self.SelectedColumn = MinInt(self.SelectedColumn+1, self.columnCount()-1)
+	self.revealSelectedColumn()
+}
+
+// SelectPreviousColumn moves the selected cell of an Editable table one column to the left.
+func (self *Table) SelectPreviousColumn() {
+	self.SelectedColumn = MaxInt(self.SelectedColumn-1, 0)
+	self.revealSelectedColumn()
+}
+
+// revealSelectedColumn scrolls horizontally if the selected column is hidden on the left.
+// Columns hidden on the right are revealed by ScrollRight.
+func (self *Table) revealSelectedColumn() {
+	if self.SelectedColumn >= self.FrozenColumns && self.SelectedColumn < self.FrozenColumns+self.columnOffset {
+		self.columnOffset = self.SelectedColumn - self.FrozenColumns
+	}
+}
+
+// StartEdit begins editing the selected cell of an Editable, Selectable table.
+func (self *Table) StartEdit() {
+	row := self.SelectedRow()
+	if !self.Editable || !self.Selectable || self.Provider != nil || row < 0 || self.SelectedColumn >= len(self.Rows[row]) {
+		return
+	}
+	self.editing = true
+	self.editRow, self.editColumn = row, self.SelectedColumn
+	self.editor.setText(self.Rows[row][self.SelectedColumn])
+}
+
+// Editing reports whether a cell is being edited.
+func (self *Table) Editing() bool {
+	return self.editing
+}
+
+// CommitEdit ends editing and stores the new value unless OnEditCommit rejects it,
+// in which case editing continues.
+func (self *Table) CommitEdit() {
+	if !self.editing {
+		return
+	}
+	value := self.editor.text()
+	if self.OnEditCommit != nil && !self.OnEditCommit(self.editRow, self.editColumn, value) {
+		return
+	}
+	if old := self.Rows[self.editRow][self.editColumn]; old != value && self.History != nil {
+		self.History.Push(&tableCellEdit{self, self.editRow, self.editColumn, old, value}, "")
+	}
+	self.Rows[self.editRow][self.editColumn] = value
+	self.editing = false
+}
+
+// tableCellEdit is an edit of a cell committed in a Table.
+type tableCellEdit struct {
+	table    *Table
+	row, col int
+	old, new string
+}
+
+func (self *tableCellEdit) Undo() {
+	self.table.setCell(self.row, self.col, self.old)
+}
+
+func (self *tableCellEdit) Redo() {
+	self.table.setCell(self.row, self.col, self.new)
+}
+
+// setCell stores value in a cell still in Rows unless OnEditCommit rejects it.
+func (self *Table) setCell(row, col int, value string) {
+	if row >= len(self.Rows) || col >= len(self.Rows[row]) {
+		return
+	}
+	if self.OnEditCommit != nil && !self.OnEditCommit(row, col, value) {
+		return
+	}
+	self.Rows[row][col] = value
+}
+
+// Undo reverts the last edit committed to a cell.
+func (self *Table) Undo() {
+	if self.History != nil {
+		self.History.Undo()
+	}
+}
+
+// Redo commits again the last edit reverted by Undo.
+func (self *Table) Redo() {
+	if self.History != nil {
+		self.History.Redo()
+	}
+}
+
+// CancelEdit ends editing without changing the cell.
+func (self *Table) CancelEdit() {
+	if !self.editing {
+		return
+	}
+	self.editing = false
+	if self.OnEditCancel != nil {
+		self.OnEditCancel(self.editRow, self.editColumn)
+	}
+}
+
+// HandleEditKey applies a keyboard event ID to the cell being edited and reports whether it was used.
+// <Enter> commits the edit, <Escape> cancels it, and other keys edit the text, with <C-z> and <C-y>
+// undoing and redoing the keystrokes.
+func (self *Table) HandleEditKey(id string) bool {
+	if !self.editing {
+		return false
+	}
+	switch id {
+	case "<Enter>":
+		self.CommitEdit()
+	case "<Escape>":
+		self.CancelEdit()
+	default:
+		return self.editor.handleKey(id)
+	}
+	return true
+}
+
+// ScrollLeft scrolls the unfrozen columns one column to the left.
+func (self *Table) ScrollLeft() {
+	self.columnOffset = MaxInt(self.columnOffset-1, 0)
+}
+
+// ScrollRight scrolls the unfrozen columns one column to the right.
+// The offset is limited to the number of columns on the next Draw.
+func (self *Table) ScrollRight() {
+	self.columnOffset++
+}
+
+// drawRow draws a row of cells and returns the number of lines it takes up.
+// cellStyle, if not nil, overrides rowStyle per cell. Matches of highlight in the cells are drawn with MatchStyle.
+func (self *Table) drawRow(buf *Buffer, row []string, rowStyle Style, cellStyle func(int, string) Style, highlight string, columnWidths []int, columns []int, yCoordinate int) int {
+	height := self.rowHeight(row, columnWidths, columns)
+	bottom := MinInt(yCoordinate+height, self.Inner.Max.Y)
+
+	if self.FillRow {
+		blankCell := NewCell(' ', rowStyle)
+		buf.Fill(blankCell, image.Rect(self.Inner.Min.X, yCoordinate, self.Inner.Max.X, bottom))
+	}
+
+	// draw row cells
+	colXCoordinate := self.Inner.Min.X
+	for _, j := range columns {
+		if colXCoordinate >= self.Inner.Max.X {
+			break
+		}
+		if j >= len(row) {
 			colXCoordinate += columnWidths[j] + 1
+			continue
+		}
+		style := rowStyle
+		if cellStyle != nil {
+			style = cellStyle(j, row[j])
+		}
+		col := ParseStyles(row[j], style)
+		if highlight != "" {
+			highlightMatches(col, highlight, self.MatchStyle)
+		}
+		for k, line := range self.cellLines(col, columnWidths[j]) {
+			if yCoordinate+k >= bottom {
+				break
+			}
+			self.drawCell(buf, line, columnWidths[j], colXCoordinate, yCoordinate+k)
 		}
+		colXCoordinate += columnWidths[j] + 1
+	}
+
+	// draw vertical separators
+	separatorStyle := self.Block.BorderStyle
 
-		// draw vertical separators
-		separatorStyle := self.Block.BorderStyle
+	separatorXCoordinate := self.Inner.Min.X
+	verticalCell := NewCell(VERTICAL_LINE, separatorStyle)
+	for i, j := range columns {
+		if self.FillRow && i < len(columns)-1 {
+			verticalCell.Style.Bg = rowStyle.Bg
+		} else {
+			verticalCell.Style.Bg = self.Block.BorderStyle.Bg
+		}
 
-		separatorXCoordinate := self.Inner.Min.X
-		verticalCell := NewCell(VERTICAL_LINE, separatorStyle)
-		for i, width := range columnWidths {
-			if self.FillRow && i < len(columnWidths)-1 {
-				verticalCell.Style.Bg = rowStyle.Bg
+		separatorXCoordinate += columnWidths[j]
+		if separatorXCoordinate < self.Inner.Max.X {
+			for y := yCoordinate; y < bottom; y++ {
+				buf.SetCell(verticalCell, image.Pt(separatorXCoordinate, y))
+			}
+		}
+		separatorXCoordinate++
+	}
+
+	return height
+}
+
+// drawCell draws one line of a cell aligned within its column, ending it with an ellipsis when it doesn't fit.
+func (self *Table) drawCell(buf *Buffer, col []Cell, width int, colXCoordinate int, yCoordinate int) {
+	setCell := func(cell Cell, x int) {
+		if x < self.Inner.Max.X {
+			buf.SetCell(cell, image.Pt(x, yCoordinate))
+		}
+	}
+
+	col, _, alignment := reorderLine(col, width, self.Direction, self.TextAlignment)
+	if len(col) > width || alignment == AlignLeft || alignment == AlignJustify {
+		for _, cx := range BuildCellWithXArray(col) {
+			k, cell := cx.X, cx.Cell
+			if k == width || colXCoordinate+k == self.Inner.Max.X {
+				cell.Rune = ELLIPSES
+				buf.SetCell(cell, image.Pt(colXCoordinate+k-1, yCoordinate))
+				break
 			} else {
-				verticalCell.Style.Bg = self.Block.BorderStyle.Bg
+				buf.SetCell(cell, image.Pt(colXCoordinate+k, yCoordinate))
+			}
+		}
+	} else if alignment == AlignCenter {
+		xCoordinateOffset := (width - len(col)) / 2
+		stringXCoordinate := xCoordinateOffset + colXCoordinate
+		for _, cx := range BuildCellWithXArray(col) {
+			k, cell := cx.X, cx.Cell
+			setCell(cell, stringXCoordinate+k)
+		}
+	} else if alignment == AlignRight {
+		stringXCoordinate := MinInt(colXCoordinate+width, self.Inner.Max.X) - len(col)
+		for _, cx := range BuildCellWithXArray(col) {
+			k, cell := cx.X, cx.Cell
+			setCell(cell, stringXCoordinate+k)
+		}
+	}
+}
+
+// cellLines splits a cell into the lines it is drawn on, wrapping it to width when WrapCells is set.
+func (self *Table) cellLines(col []Cell, width int) [][]Cell {
+	if !self.WrapCells || width <= 0 {
+		return [][]Cell{col}
+	}
+	lines := SplitCells(WrapCells(col, uint(width)), '\n')
+	if len(lines) == 0 {
+		return [][]Cell{{}}
+	}
+	return lines
+}
+
+// rowHeight returns the number of lines a row takes up, which is always 1 unless WrapCells is set.
+func (self *Table) rowHeight(row []string, columnWidths []int, columns []int) int {
+	height := 1
+	if !self.WrapCells {
+		return height
+	}
+	for _, j := range columns {
+		if j < len(row) {
+			height = MaxInt(height, len(self.cellLines(ParseStyles(row[j], self.TextStyle), columnWidths[j])))
+		}
+	}
+	return height
+}
+
+// drawColumnIndicators marks the bottom border when columns are scrolled out of view on either side.
+func (self *Table) drawColumnIndicators(buf *Buffer, columnWidths []int, columns []int) {
+	if !self.Border || !self.BorderBottom {
+		return
+	}
+	style := self.Block.BorderStyle
+	if self.columnOffset > 0 {
+		buf.SetCell(NewCell(QUOTA_LEFT, style), image.Pt(self.Inner.Min.X, self.Max.Y-1))
+	}
+	width := 0
+	for _, j := range columns {
+		width += columnWidths[j] + 1
+	}
+	if width-1 > self.Inner.Dx() {
+		buf.SetCell(NewCell(QUOTA_RIGHT, style), image.Pt(self.Inner.Max.X-1, self.Max.Y-1))
+	}
+}
+
+func (self *Table) drawRowSeparator(buf *Buffer, yCoordinate int) {
+	horizontalCell := NewCell(HORIZONTAL_LINE, self.Block.BorderStyle)
+	buf.Fill(horizontalCell, image.Rect(self.Inner.Min.X, yCoordinate, self.Inner.Max.X, yCoordinate+1))
+}
+
+// highlightMatches restyles the cells spelling out case-insensitive occurrences of query.
+func highlightMatches(cells []Cell, query string, style Style) {
+	text := []rune(strings.ToLower(CellsToString(cells)))
+	needle := []rune(strings.ToLower(query))
+	for i := 0; i+len(needle) <= len(text) && len(text) == len(cells); i++ {
+		if string(text[i:i+len(needle)]) == string(needle) {
+			for k := i; k < i+len(needle); k++ {
+				cells[k].Style = style
+			}
+			i += len(needle) - 1
+		}
+	}
+}
+
+// LoadCSV replaces Header and Rows with comma-separated records read from r.
+// The first record becomes the Header.
+func (self *Table) LoadCSV(r io.Reader) error {
+	return self.loadRecords(r, ',')
+}
+
+// LoadTSV replaces Header and Rows with tab-separated records read from r.
+// The first record becomes the Header.
+func (self *Table) LoadTSV(r io.Reader) error {
+	return self.loadRecords(r, '\t')
+}
+
+func (self *Table) loadRecords(r io.Reader, comma rune) error {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		self.setData(nil, nil)
+	} else {
+		self.setData(records[0], records[1:])
+	}
+	return nil
+}
+
+// LoadStructs replaces Header and Rows with the fields of the structs, or pointers to structs, in slice.
+// columns names the fields shown, in order, and defaults to every exported field.
+// The Header holds the field names and the cells are formatted with fmt.Sprint.
+func (self *Table) LoadStructs(slice interface{}, columns ...string) error {
+	value := reflect.ValueOf(slice)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fmt.Errorf("cannot load %T: not a slice", slice)
+	}
+	elemType := value.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot load %T: elements are not structs", slice)
+	}
+
+	if len(columns) == 0 {
+		for i := 0; i < elemType.NumField(); i++ {
+			if field := elemType.Field(i); field.PkgPath == "" {
+				columns = append(columns, field.Name)
 			}
+		}
+	}
+	fields := make([][]int, len(columns))
+	for i, name := range columns {
+		field, ok := elemType.FieldByName(name)
+		if !ok {
+			return fmt.Errorf("cannot load %T: no field %q", slice, name)
+		}
+		fields[i] = field.Index
+	}
 
-			separatorXCoordinate += width
-			buf.SetCell(verticalCell, image.Pt(separatorXCoordinate, yCoordinate))
-			separatorXCoordinate++
+	rows := make([][]string, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		elem := value.Index(i)
+		row := make([]string, len(fields))
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				rows = append(rows, row)
+				continue
+			}
+			elem = elem.Elem()
+		}
+		for j, index := range fields {
+			if field, ok := fieldByIndex(elem, index); ok && field.CanInterface() {
+				row[j] = fmt.Sprint(field.Interface())
+			} else if ok {
+				row[j] = fmt.Sprint(field)
+			}
 		}
+		rows = append(rows, row)
+	}
 
-		yCoordinate++
+	header := make([]string, len(columns))
+	copy(header, columns)
+	self.setData(header, rows)
+	return nil
+}
 
-		// draw horizontal separator
-		horizontalCell := NewCell(HORIZONTAL_LINE, separatorStyle)
-		if self.RowSeparator && yCoordinate < self.Inner.Max.Y && i != len(self.Rows)-1 {
-			buf.Fill(horizontalCell, image.Rect(self.Inner.Min.X, yCoordinate, self.Inner.Max.X, yCoordinate+1))
-			yCoordinate++
+// fieldByIndex is like reflect.Value.FieldByIndex but reports false instead of panicking
+// on a nil embedded pointer.
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return value, false
+			}
+			value = value.Elem()
 		}
+		value = value.Field(x)
+	}
+	return value, true
+}
+
+// setData replaces the Header and Rows and resets the state kept for the previous rows.
+func (self *Table) setData(header []string, rows [][]string) {
+	self.Header = header
+	self.Rows = rows
+	self.Provider = nil
+	self.cursor = 0
+	self.topRow = 0
+	self.marked = make(map[int]bool)
+	self.editing = false
+	if self.History != nil {
+		self.History.Clear()
 	}
 }
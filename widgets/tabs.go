@@ -5,29 +5,139 @@
 package widgets
 
 import (
+	"encoding/json"
 	"image"
 
+	rw "github.com/mattn/go-runewidth"
+
 	. "github.com/reaalkhalil/termui"
 )
 
 // TabPane is a renderable widget which can be used to conditionally render certain tabs/views.
 // TabPane shows a list of Tab names.
 // The currently selected tab can be found through the `ActiveTabIndex` field.
+// When the tabs don't fit, they are scrolled to keep the active tab in view.
+// TabPane can also hold the widgets of each tab in Contents, drawing the active one below the names.
 type TabPane struct {
 	Block
 	TabNames         []string
 	ActiveTabIndex   int
 	ActiveTabStyle   Style
 	InactiveTabStyle Style
+	// Closable draws a close glyph after each tab name. Clicking it calls CloseTab.
+	Closable bool
+	// OnClose is called with the index a tab had after it is closed.
+	OnClose func(index int)
+	// Contents holds the widget of each tab, in the order of TabNames. The widget of the active tab
+	// is sized to fill the TabPane below the names and drawn with it.
+	Contents []Drawable
+
+	// focus holds the widget focused in each tab with SetFocus.
+	focus map[Drawable]Drawable
+
+	// offset is the first tab drawn, and spans the tabs, close glyphs, and scroll arrows drawn
+	// by the last Draw.
+	offset int
+	spans  []tabSpan
+	// shown is the content of the active tab drawn by the last Draw.
+	shown Drawable
 }
 
+// tabSpan is the area of a tab drawn in a TabPane. Index is -1 and -2 for the left and right
+// scroll arrows.
+type tabSpan struct {
+	index int
+	close bool
+	rect  image.Rectangle
+}
+
+const (
+	tabGap         = 3 // " │ " between tabs
+	tabArrowWidth  = 2 // a scroll arrow and a space
+	tabCloseSuffix = 2 // " ×" after closable tab names
+	tabLeftArrow   = -1
+	tabRightArrow  = -2
+)
+
 func NewTabPane(names ...string) *TabPane {
 	return &TabPane{
 		Block:            *NewBlock(),
 		TabNames:         names,
 		ActiveTabStyle:   Theme.Tab.Active,
 		InactiveTabStyle: Theme.Tab.Inactive,
+		focus:            make(map[Drawable]Drawable),
+	}
+}
+
+// AddTab adds a tab named name holding content.
+func (self *TabPane) AddTab(name string, content Drawable) {
+	for len(self.Contents) < len(self.TabNames) {
+		self.Contents = append(self.Contents, nil)
+	}
+	self.TabNames = append(self.TabNames, name)
+	self.Contents = append(self.Contents, content)
+}
+
+// Accessibility implements Accessible with the Title and the name of the active tab.
+func (self *TabPane) Accessibility() (string, bool, string) {
+	if self.ActiveTabIndex < 0 || self.ActiveTabIndex >= len(self.TabNames) {
+		return self.Title, false, ""
+	}
+	return self.Title, false, self.TabNames[self.ActiveTabIndex]
+}
+
+// tabPaneState is the state of a TabPane saved by SaveState.
+type tabPaneState struct {
+	ActiveTab string `json:"activeTab"`
+}
+
+// SaveState implements Persistent with the name of the active tab.
+func (self *TabPane) SaveState() ([]byte, error) {
+	state := tabPaneState{}
+	if self.ActiveTabIndex >= 0 && self.ActiveTabIndex < len(self.TabNames) {
+		state.ActiveTab = self.TabNames[self.ActiveTabIndex]
+	}
+	return json.Marshal(state)
+}
+
+// RestoreState implements Persistent, activating the tab by its name so that the state still
+// applies after tabs are added or reordered.
+func (self *TabPane) RestoreState(data []byte) error {
+	state := tabPaneState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
 	}
+	for i, name := range self.TabNames {
+		if name == state.ActiveTab {
+			self.ActiveTabIndex = i
+		}
+	}
+	return nil
+}
+
+// ActiveContent returns the widget of the active tab, or nil if it has none.
+func (self *TabPane) ActiveContent() Drawable {
+	if self.ActiveTabIndex < 0 || self.ActiveTabIndex >= len(self.Contents) {
+		return nil
+	}
+	return self.Contents[self.ActiveTabIndex]
+}
+
+// SetFocus records the widget focused in the active tab, like one of the widgets of a Grid,
+// so it is focused again when the tab is activated again.
+func (self *TabPane) SetFocus(item Drawable) {
+	if content := self.ActiveContent(); content != nil {
+		self.focus[content] = item
+	}
+}
+
+// Focused returns the widget focused in the active tab with SetFocus, or the tab's content.
+func (self *TabPane) Focused() Drawable {
+	content := self.ActiveContent()
+	if item, ok := self.focus[content]; ok && content != nil {
+		return item
+	}
+	return content
 }
 
 func (self *TabPane) FocusLeft() {
@@ -42,30 +152,201 @@ func (self *TabPane) FocusRight() {
 	}
 }
 
+// NextTab activates the next tab, wrapping around to the first, like Ctrl+Tab.
+func (self *TabPane) NextTab() {
+	if len(self.TabNames) > 0 {
+		self.ActiveTabIndex = (self.ActiveTabIndex + 1) % len(self.TabNames)
+	}
+}
+
+// PreviousTab activates the previous tab, wrapping around to the last, like Ctrl+Shift+Tab.
+func (self *TabPane) PreviousTab() {
+	if len(self.TabNames) > 0 {
+		self.ActiveTabIndex = (self.ActiveTabIndex + len(self.TabNames) - 1) % len(self.TabNames)
+	}
+}
+
+// CloseTab removes tab i, keeping the active tab active if it is another one, and calls OnClose.
+func (self *TabPane) CloseTab(i int) {
+	if i < 0 || i >= len(self.TabNames) {
+		return
+	}
+	self.TabNames = append(self.TabNames[:i], self.TabNames[i+1:]...)
+	if i < len(self.Contents) {
+		if self.Contents[i] != nil {
+			Unmount(self.Contents[i])
+		}
+		delete(self.focus, self.Contents[i])
+		self.Contents = append(self.Contents[:i], self.Contents[i+1:]...)
+	}
+	if self.ActiveTabIndex > i || self.ActiveTabIndex >= len(self.TabNames) {
+		self.ActiveTabIndex = MaxInt(self.ActiveTabIndex-1, 0)
+	}
+	if self.OnClose != nil {
+		self.OnClose(i)
+	}
+}
+
+// HandleMouse activates a clicked tab, closes a tab whose close glyph is clicked, and moves to
+// the previous or next tab with the scroll arrows and the mouse wheel. It reports whether the event was used.
+func (self *TabPane) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Rectangle) {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		for i := 0; i < WheelSteps(e); i++ {
+			self.FocusLeft()
+		}
+	case "<MouseWheelDown>":
+		for i := 0; i < WheelSteps(e); i++ {
+			self.FocusRight()
+		}
+	case "<MouseLeft>":
+		if e.Payload.(Mouse).Drag {
+			return false
+		}
+		for _, span := range self.spans {
+			if !p.In(span.rect) {
+				continue
+			}
+			switch {
+			case span.index == tabLeftArrow:
+				self.FocusLeft()
+			case span.index == tabRightArrow:
+				self.FocusRight()
+			case span.close:
+				self.CloseTab(span.index)
+			default:
+				self.ActiveTabIndex = span.index
+			}
+			return true
+		}
+		return false
+	default:
+		return false
+	}
+	return true
+}
+
+// tabWidth returns the width of tab i without the separator.
+func (self *TabPane) tabWidth(i int) int {
+	width := rw.StringWidth(self.TabNames[i])
+	if self.Closable {
+		width += tabCloseSuffix
+	}
+	return width
+}
+
+// tabsWidth returns the width of the tabs from first to last with the separators between them.
+func (self *TabPane) tabsWidth(first, last int) int {
+	width := 0
+	for i := first; i <= last; i++ {
+		width += self.tabWidth(i) + tabGap
+	}
+	return width - tabGap
+}
+
+// scroll sets the offset so the active tab is in view when the tabs overflow width,
+// leaving room for the scroll arrows.
+func (self *TabPane) scroll(width int) {
+	last := len(self.TabNames) - 1
+	if self.tabsWidth(0, last) <= width {
+		self.offset = 0
+		return
+	}
+	width -= 2 * tabArrowWidth
+	self.offset = MaxInt(MinInt(self.offset, last), 0)
+	// show as many tabs as fit on the right of the offset
+	for self.offset > 0 && self.tabsWidth(self.offset-1, last) <= width {
+		self.offset--
+	}
+	if self.ActiveTabIndex < self.offset {
+		self.offset = self.ActiveTabIndex
+	}
+	for self.offset < self.ActiveTabIndex && self.tabsWidth(self.offset, self.ActiveTabIndex) > width {
+		self.offset++
+	}
+}
+
 func (self *TabPane) Draw(buf *Buffer) {
 	self.Block.Draw(buf)
+	self.spans = self.spans[:0]
+	if len(self.TabNames) == 0 {
+		return
+	}
+	self.ActiveTabIndex = MaxInt(MinInt(self.ActiveTabIndex, len(self.TabNames)-1), 0)
 
+	self.scroll(self.Inner.Dx())
 	xCoordinate := self.Inner.Min.X
-	for i, name := range self.TabNames {
+	maxX := self.Inner.Max.X
+	if self.tabsWidth(0, len(self.TabNames)-1) > self.Inner.Dx() {
+		xCoordinate += tabArrowWidth
+		maxX -= tabArrowWidth
+	}
+
+	last := self.offset - 1
+	for i := self.offset; i < len(self.TabNames); i++ {
+		name := self.TabNames[i]
+		width := self.tabWidth(i)
+		if i > self.offset {
+			if xCoordinate+tabGap+width > maxX {
+				break
+			}
+			buf.SetCell(
+				NewCell(VERTICAL_LINE, NewStyle(ColorWhite)),
+				image.Pt(xCoordinate+1, self.Inner.Min.Y),
+			)
+			xCoordinate += tabGap
+		}
 		ColorPair := self.InactiveTabStyle
 		if i == self.ActiveTabIndex {
 			ColorPair = self.ActiveTabStyle
 		}
 		buf.SetString(
-			TrimString(name, self.Inner.Max.X-xCoordinate),
+			TrimString(name, maxX-xCoordinate),
 			ColorPair,
 			image.Pt(xCoordinate, self.Inner.Min.Y),
 		)
+		self.spans = append(self.spans, tabSpan{i, false, image.Rect(xCoordinate, self.Inner.Min.Y, xCoordinate+rw.StringWidth(name), self.Inner.Min.Y+1)})
+		if self.Closable {
+			closeX := xCoordinate + width - 1
+			buf.SetCell(NewCell(Theme.Tab.Close, ColorPair), image.Pt(closeX, self.Inner.Min.Y))
+			self.spans = append(self.spans, tabSpan{i, true, image.Rect(closeX, self.Inner.Min.Y, closeX+1, self.Inner.Min.Y+1)})
+		}
+		last = i
+		xCoordinate += width
+	}
 
-		xCoordinate += 1 + len(name)
-
-		if i < len(self.TabNames)-1 && xCoordinate < self.Inner.Max.X {
-			buf.SetCell(
-				NewCell(VERTICAL_LINE, NewStyle(ColorWhite)),
-				image.Pt(xCoordinate, self.Inner.Min.Y),
-			)
+	// draw the content of the active tab, hiding the one of the tab active before
+	content := self.ActiveContent()
+	if self.Inner.Dy() <= 1 {
+		content = nil
+	}
+	if content != self.shown {
+		if shown := self.shown; shown != nil {
+			buf.Defer(func() { Hide(shown) })
 		}
+		self.shown = content
+	}
+	if content != nil {
+		content.SetRect(self.Inner.Min.X, self.Inner.Min.Y+1, self.Inner.Max.X, self.Inner.Max.Y)
+		content.Lock()
+		content.Draw(buf)
+		content.Unlock()
+		buf.Defer(func() { Show(content) })
+	}
 
-		xCoordinate += 2
+	// draw scroll arrows
+	if self.offset > 0 {
+		point := image.Pt(self.Inner.Min.X, self.Inner.Min.Y)
+		buf.SetCell(NewCell(QUOTA_LEFT, NewStyle(ColorWhite)), point)
+		self.spans = append(self.spans, tabSpan{tabLeftArrow, false, image.Rect(point.X, point.Y, point.X+1, point.Y+1)})
+	}
+	if last < len(self.TabNames)-1 {
+		point := image.Pt(self.Inner.Max.X-1, self.Inner.Min.Y)
+		buf.SetCell(NewCell(QUOTA_RIGHT, NewStyle(ColorWhite)), point)
+		self.spans = append(self.spans, tabSpan{tabRightArrow, false, image.Rect(point.X, point.Y, point.X+1, point.Y+1)})
 	}
 }
@@ -0,0 +1,224 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// TagInput is an Autocomplete whose typed values become Tags, drawn as chips before the text.
+// <Enter>, <Tab>, or a comma adds the text as a tag, <Backspace> on empty text removes the last tag,
+// and clicking the close sign of a chip removes it. KnownTags not added yet are suggested as the
+// text is typed. The TagInput accepts suggestions through OnAccept, which shouldn't be replaced.
+type TagInput struct {
+	Autocomplete
+	Tags []string
+	// KnownTags are suggested while typing. Strict only accepts tags that are known.
+	KnownTags []string
+	Strict    bool
+	// MaxTags, when greater than 0, is the number of tags accepted.
+	MaxTags   int
+	ChipStyle Style
+
+	// OnTagsChange is called with the Tags after a tag is added or removed.
+	OnTagsChange func(tags []string)
+
+	// chips holds the tag and close sign area of every chip drawn by the last Draw.
+	chips []tagChip
+	// textX is the column the text was drawn from by the last Draw.
+	textX int
+}
+
+type tagChip struct {
+	index int
+	close image.Rectangle
+}
+
+// tagInputMinWidth is the number of columns kept for the text after the chips.
+const tagInputMinWidth = 8
+
+func NewTagInput() *TagInput {
+	self := &TagInput{
+		Autocomplete: *NewAutocomplete(),
+		ChipStyle:    Theme.TagInput.Chip,
+	}
+	self.Source = func(text string) []string {
+		suggestions := []string{}
+		for _, tag := range StaticSuggestions(self.KnownTags)(text) {
+			if self.indexOf(tag) < 0 {
+				suggestions = append(suggestions, tag)
+			}
+		}
+		return suggestions
+	}
+	self.OnAccept = func(string) {
+		self.commit()
+	}
+	return self
+}
+
+// indexOf returns the index of a tag in Tags, ignoring case, or -1 if it isn't there.
+func (self *TagInput) indexOf(tag string) int {
+	for i, t := range self.Tags {
+		if strings.EqualFold(t, tag) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (self *TagInput) known(tag string) bool {
+	for _, t := range self.KnownTags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag adds a tag, trimmed of spaces, and reports whether it was added. Empty and repeated tags
+// aren't, nor are unknown tags when Strict is set or any tag once there are MaxTags.
+func (self *TagInput) AddTag(tag string) bool {
+	tag = strings.TrimSpace(tag)
+	if tag == "" || self.indexOf(tag) >= 0 || (self.Strict && !self.known(tag)) ||
+		(self.MaxTags > 0 && len(self.Tags) >= self.MaxTags) {
+		return false
+	}
+	self.Tags = append(self.Tags, tag)
+	if self.OnTagsChange != nil {
+		self.OnTagsChange(self.Tags)
+	}
+	return true
+}
+
+// RemoveTag removes the tag at index.
+func (self *TagInput) RemoveTag(index int) {
+	if index < 0 || index >= len(self.Tags) {
+		return
+	}
+	self.Tags = append(self.Tags[:index], self.Tags[index+1:]...)
+	if self.OnTagsChange != nil {
+		self.OnTagsChange(self.Tags)
+	}
+}
+
+// commit adds the text as a tag, clearing it if it was added.
+func (self *TagInput) commit() {
+	if self.AddTag(self.Text()) {
+		self.SetText("")
+		self.Close()
+	}
+}
+
+// FormValue returns the Tags for a Form.
+func (self *TagInput) FormValue() interface{} {
+	return append([]string{}, self.Tags...)
+}
+
+// HandleKey adds and removes tags, passing the other keys on to the Autocomplete, while the
+// TagInput is Focused. <Enter> and <Tab> with empty text are left to a Form.
+// It reports whether the key was used.
+func (self *TagInput) HandleKey(id string) bool {
+	if !self.Focused {
+		return false
+	}
+	if self.IsOpen() && (id == "<Enter>" || id == "<Tab>") {
+		self.Accept()
+		return true
+	}
+	switch id {
+	case "<Enter>", "<Tab>":
+		if self.Text() == "" {
+			return false
+		}
+		self.commit()
+		return true
+	case ",":
+		self.commit()
+		return true
+	case "<Backspace>":
+		if self.Text() == "" && len(self.Tags) > 0 {
+			self.RemoveTag(len(self.Tags) - 1)
+			return true
+		}
+	}
+	return self.Autocomplete.HandleKey(id)
+}
+
+// HandleMouse removes a tag whose close sign is clicked, and otherwise handles the event like an
+// Autocomplete. It reports whether the event was used.
+func (self *TagInput) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok {
+		return false
+	}
+	if e.ID == "<MouseLeft>" && !e.Payload.(Mouse).Drag {
+		for _, chip := range self.chips {
+			if p.In(chip.close) {
+				self.RemoveTag(chip.index)
+				return true
+			}
+		}
+	}
+	// clicks on the text are relative to where it was drawn
+	inner := self.Inner
+	self.Inner.Min.X = MaxInt(self.textX, inner.Min.X)
+	used := self.Autocomplete.HandleMouse(e)
+	self.Inner = inner
+	return used
+}
+
+// chipText returns the text drawn for a tag.
+func chipText(tag string) string {
+	return fmt.Sprintf(" %s %c ", tag, Theme.TagInput.Close)
+}
+
+func (self *TagInput) Draw(buf *Buffer) {
+	self.chips = self.chips[:0]
+	room := self.Inner.Dx() - tagInputMinWidth
+
+	// keep the last tags that fit, with a count of the others
+	first, width := len(self.Tags), 0
+	for first > 0 {
+		w := rw.StringWidth(chipText(self.Tags[first-1])) + 1
+		more := 0
+		if first > 1 {
+			more = len(fmt.Sprintf("+%d ", first-1))
+		}
+		if width+w+more > room {
+			break
+		}
+		width += w
+		first--
+	}
+
+	x := self.Inner.Min.X
+	y := self.Inner.Min.Y
+	if first > 0 {
+		more := fmt.Sprintf("+%d ", first)
+		buf.SetString(more, self.TextStyle, image.Pt(x, y))
+		x += len(more)
+	}
+	for i := first; i < len(self.Tags); i++ {
+		text := chipText(self.Tags[i])
+		buf.SetString(text, self.ChipStyle, image.Pt(x, y))
+		x += rw.StringWidth(text)
+		self.chips = append(self.chips, tagChip{i, image.Rect(x-2, y, x-1, y+1)})
+		x++
+	}
+	self.textX = x
+
+	// draw the text after the chips
+	inner := self.Inner
+	self.Inner.Min.X = MinInt(x, self.Inner.Max.X)
+	self.Autocomplete.Draw(buf)
+	self.Inner = inner
+}
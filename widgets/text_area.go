@@ -0,0 +1,627 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// TextArea is a multiline text editor. Lines are wrapped at spaces to the width of the widget and
+// scrolled vertically to keep the cursor in view.
+type TextArea struct {
+	Block
+	TextStyle      Style
+	SelectionStyle Style
+	ScrollbarStyle Style
+
+	// Focused draws the cursor. Keys are only handled while it is set.
+	Focused bool
+
+	// OnChange is called with the text after every edit.
+	OnChange func(text string)
+
+	// History holds the edits undone with <C-z> and redone with <C-y>. Consecutive keystrokes typing
+	// or deleting are undone in one step.
+	History *History
+
+	lines  [][]rune
+	cursor textPosition
+	// goal is the column kept by moving up and down through shorter rows, or -1.
+	goal int
+	top  int
+
+	// the selection runs from anchor to cursor while selected is set
+	selected bool
+	anchor   textPosition
+}
+
+type textAreaState struct {
+	text   string
+	cursor textPosition
+}
+
+// textAreaSnapshot is an undo step restoring the text and cursor of a TextArea from before it.
+type textAreaSnapshot struct {
+	area          *TextArea
+	before, after textAreaState
+}
+
+func (self *textAreaSnapshot) Undo() {
+	self.after = self.area.state()
+	self.area.restore(self.before)
+}
+
+func (self *textAreaSnapshot) Redo() {
+	self.area.restore(self.after)
+}
+
+// Merge absorbs the later snapshots of the same TextArea, which Undo reverts along with it.
+func (self *textAreaSnapshot) Merge(next Edit) bool {
+	snapshot, ok := next.(*textAreaSnapshot)
+	return ok && snapshot.area == self.area
+}
+
+// textAreaRow is the part of a line drawn on one row, from start up to end.
+type textAreaRow struct {
+	line, start, end int
+}
+
+func NewTextArea() *TextArea {
+	return &TextArea{
+		Block:          *NewBlock(),
+		TextStyle:      Theme.TextArea.Text,
+		SelectionStyle: Theme.TextArea.Selection,
+		ScrollbarStyle: Theme.TextArea.Scrollbar,
+		History:        NewHistory(),
+		lines:          [][]rune{{}},
+		goal:           -1,
+	}
+}
+
+func (self *TextArea) Text() string {
+	lines := make([]string, len(self.lines))
+	for i, line := range self.lines {
+		lines[i] = string(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SetText replaces the text, moves the cursor to its start, and clears the History.
+func (self *TextArea) SetText(text string) {
+	self.setText(text)
+	self.cursor = textPosition{}
+	self.top = 0
+	self.selected = false
+	self.History.Clear()
+}
+
+func (self *TextArea) setText(text string) {
+	self.lines = [][]rune{}
+	for _, line := range strings.Split(text, "\n") {
+		self.lines = append(self.lines, []rune(line))
+	}
+}
+
+// Cursor returns the line of the cursor and the index of the rune before which text is inserted.
+func (self *TextArea) Cursor() (line, column int) {
+	return self.cursor.line, self.cursor.col
+}
+
+// SelectedText returns the selected text, or "" if nothing is selected.
+func (self *TextArea) SelectedText() string {
+	if !self.selected {
+		return ""
+	}
+	start, end := self.selection()
+	if start.line == end.line {
+		return string(self.lines[start.line][start.col:end.col])
+	}
+	parts := []string{string(self.lines[start.line][start.col:])}
+	for line := start.line + 1; line < end.line; line++ {
+		parts = append(parts, string(self.lines[line]))
+	}
+	parts = append(parts, string(self.lines[end.line][:end.col]))
+	return strings.Join(parts, "\n")
+}
+
+// selection returns the ends of the selection in order.
+func (self *TextArea) selection() (textPosition, textPosition) {
+	if self.anchor.before(self.cursor) {
+		return self.anchor, self.cursor
+	}
+	return self.cursor, self.anchor
+}
+
+// Undo reverts the last group of edits.
+func (self *TextArea) Undo() {
+	self.History.Undo()
+}
+
+// Redo reapplies the last group of edits reverted by Undo.
+func (self *TextArea) Redo() {
+	self.History.Redo()
+}
+
+func (self *TextArea) state() textAreaState {
+	return textAreaState{self.Text(), self.cursor}
+}
+
+func (self *TextArea) restore(state textAreaState) {
+	self.setText(state.text)
+	self.cursor = state.cursor
+	self.selected = false
+	self.changed()
+}
+
+// edit records the state before an edit of a group in the History, which undoes consecutive
+// edits of the same group in one step.
+func (self *TextArea) edit(group string) {
+	self.History.Push(&textAreaSnapshot{area: self, before: self.state()}, group)
+	self.goal = -1
+}
+
+func (self *TextArea) changed() {
+	if self.OnChange != nil {
+		self.OnChange(self.Text())
+	}
+}
+
+// deleteSelection removes the selected text and reports whether there was any.
+func (self *TextArea) deleteSelection() bool {
+	if !self.selected {
+		return false
+	}
+	self.selected = false
+	start, end := self.selection()
+	if start == end {
+		return false
+	}
+	self.edit("")
+	self.remove(start, end)
+	return true
+}
+
+// remove removes the text from start up to end and moves the cursor to start.
+func (self *TextArea) remove(start, end textPosition) {
+	line := append(append([]rune{}, self.lines[start.line][:start.col]...), self.lines[end.line][end.col:]...)
+	self.lines = append(self.lines[:start.line], append([][]rune{line}, self.lines[end.line+1:]...)...)
+	self.cursor = start
+}
+
+func (self *TextArea) insert(r rune) {
+	group := editTyping
+	if r == ' ' || r == '\n' {
+		group = ""
+	}
+	// text replacing a selection is undone along with it
+	if self.deleteSelection() {
+		self.History.Continue(group)
+	} else {
+		self.edit(group)
+	}
+	line := self.lines[self.cursor.line]
+	if r == '\n' {
+		rest := append([]rune{}, line[self.cursor.col:]...)
+		self.lines[self.cursor.line] = line[:self.cursor.col]
+		self.lines = append(self.lines[:self.cursor.line+1], append([][]rune{rest}, self.lines[self.cursor.line+1:]...)...)
+		self.cursor = textPosition{self.cursor.line + 1, 0}
+		return
+	}
+	line = append(line, 0)
+	copy(line[self.cursor.col+1:], line[self.cursor.col:])
+	line[self.cursor.col] = r
+	self.lines[self.cursor.line] = line
+	self.cursor.col++
+}
+
+// deleteTo deletes the text between the cursor and another position.
+func (self *TextArea) deleteTo(other textPosition) {
+	if other == self.cursor {
+		return
+	}
+	self.edit(editDeleting)
+	if other.before(self.cursor) {
+		self.remove(other, self.cursor)
+	} else {
+		self.remove(self.cursor, other)
+	}
+}
+
+// previous returns the position before p, moving to the end of the previous line from the start of a line.
+func (self *TextArea) previous(p textPosition) textPosition {
+	if p.col > 0 {
+		return textPosition{p.line, p.col - 1}
+	}
+	if p.line > 0 {
+		return textPosition{p.line - 1, len(self.lines[p.line-1])}
+	}
+	return p
+}
+
+// next returns the position after p, moving to the start of the next line from the end of a line.
+func (self *TextArea) next(p textPosition) textPosition {
+	if p.col < len(self.lines[p.line]) {
+		return textPosition{p.line, p.col + 1}
+	}
+	if p.line < len(self.lines)-1 {
+		return textPosition{p.line + 1, 0}
+	}
+	return p
+}
+
+func (self *TextArea) runeAt(p textPosition) rune {
+	if p.col < len(self.lines[p.line]) {
+		return self.lines[p.line][p.col]
+	}
+	return '\n'
+}
+
+func isWordRune(r rune) bool {
+	return r != ' ' && r != '\n' && r != '\t'
+}
+
+// previousWord returns the start of the word before p.
+func (self *TextArea) previousWord(p textPosition) textPosition {
+	for p != (textPosition{}) && !isWordRune(self.runeAt(self.previous(p))) {
+		p = self.previous(p)
+	}
+	for p != (textPosition{}) && isWordRune(self.runeAt(self.previous(p))) {
+		p = self.previous(p)
+	}
+	return p
+}
+
+// nextWord returns the end of the word after p.
+func (self *TextArea) nextWord(p textPosition) textPosition {
+	end := self.end()
+	for p != end && !isWordRune(self.runeAt(p)) {
+		p = self.next(p)
+	}
+	for p != end && isWordRune(self.runeAt(p)) {
+		p = self.next(p)
+	}
+	return p
+}
+
+func (self *TextArea) end() textPosition {
+	return textPosition{len(self.lines) - 1, len(self.lines[len(self.lines)-1])}
+}
+
+// previousParagraph returns the start of the blank line before the paragraph holding line, or the first line.
+func (self *TextArea) previousParagraph(line int) textPosition {
+	for line > 0 && len(self.lines[line]) == 0 {
+		line--
+	}
+	for line > 0 && len(self.lines[line-1]) > 0 {
+		line--
+	}
+	return textPosition{MaxInt(line-1, 0), 0}
+}
+
+// nextParagraph returns the start of the blank line after the paragraph holding line, or the end of the text.
+func (self *TextArea) nextParagraph(line int) textPosition {
+	for line < len(self.lines)-1 && len(self.lines[line]) == 0 {
+		line++
+	}
+	for line < len(self.lines)-1 && len(self.lines[line+1]) > 0 {
+		line++
+	}
+	if line == len(self.lines)-1 {
+		return self.end()
+	}
+	return textPosition{line + 1, 0}
+}
+
+// textWidth is the width of the wrapped text, leaving a column for the scrollbar.
+func (self *TextArea) textWidth() int {
+	return MaxInt(self.Inner.Dx()-1, 1)
+}
+
+// rows wraps the lines at spaces to width columns.
+func (self *TextArea) rows(width int) []textAreaRow {
+	rows := []textAreaRow{}
+	for i, line := range self.lines {
+		start := 0
+		for {
+			end, w, space := start, 0, -1
+			for end < len(line) && w+rw.RuneWidth(line[end]) <= width {
+				if line[end] == ' ' {
+					space = end
+				}
+				w += rw.RuneWidth(line[end])
+				end++
+			}
+			if end == len(line) {
+				rows = append(rows, textAreaRow{i, start, end})
+				break
+			}
+			if space >= start {
+				end = space + 1
+			}
+			if end == start {
+				end++
+			}
+			rows = append(rows, textAreaRow{i, start, end})
+			start = end
+		}
+	}
+	return rows
+}
+
+// rowOf returns the index of the row holding the position p.
+func rowOf(rows []textAreaRow, p textPosition) int {
+	for i, row := range rows {
+		last := i == len(rows)-1 || rows[i+1].line != row.line
+		if row.line == p.line && p.col >= row.start && (p.col < row.end || last) {
+			return i
+		}
+	}
+	return 0
+}
+
+// columnAt returns the index of the rune of row drawn at column x.
+func (self *TextArea) columnAt(row textAreaRow, x int) int {
+	line := self.lines[row.line]
+	for i := row.start; i < row.end; i++ {
+		x -= rw.RuneWidth(line[i])
+		if x < 0 {
+			return i
+		}
+	}
+	if row.end > row.start && row.end < len(line) {
+		// the end of a wrapped row is the start of the next one
+		return row.end - 1
+	}
+	return row.end
+}
+
+// moveRows moves the cursor up or down by a number of rows, keeping its column.
+func (self *TextArea) moveRows(delta int) {
+	rows := self.rows(self.textWidth())
+	current := rowOf(rows, self.cursor)
+	row := rows[current]
+	if self.goal < 0 {
+		self.goal = rw.StringWidth(string(self.lines[row.line][row.start:self.cursor.col]))
+	}
+	target := rows[MaxInt(MinInt(current+delta, len(rows)-1), 0)]
+	self.cursor = textPosition{target.line, self.columnAt(target, self.goal)}
+}
+
+func (self *TextArea) SetFocused(focused bool) {
+	self.Focused = focused
+}
+
+// FormValue returns the text for a Form.
+func (self *TextArea) FormValue() interface{} {
+	return self.Text()
+}
+
+// HandleKey edits the text or moves the cursor with a keyboard event ID while the TextArea is
+// Focused, and reports whether it was used. Besides the arrow keys, <Home>, <End>, <PageUp>,
+// <PageDown>, <Backspace>, <Delete>, and <Enter>:
+//
+//	<C-a> <C-e>  move to the start or end of the line
+//	<M-b> <M-f>  move by words, and <M-{> <M-}> by paragraphs
+//	<C-w>        deletes the word before the cursor
+//	<C-<Space>>  starts a selection extended by moving the cursor, and <Escape> cancels it
+//	<M-w>        copies the selection to the clipboard
+//	<C-z> <C-y>  undo and redo
+func (self *TextArea) HandleKey(id string) bool {
+	if !self.Focused {
+		return false
+	}
+	before := self.Text()
+	moved := true
+	goal := self.goal
+	self.goal = -1
+	switch id {
+	case "<Left>", "<C-b>":
+		self.cursor = self.previous(self.cursor)
+	case "<Right>", "<C-f>":
+		self.cursor = self.next(self.cursor)
+	case "<Up>", "<C-p>":
+		self.goal = goal
+		self.moveRows(-1)
+	case "<Down>", "<C-n>":
+		self.goal = goal
+		self.moveRows(1)
+	case "<PageUp>":
+		self.goal = goal
+		self.moveRows(-MaxInt(self.Inner.Dy()-1, 1))
+	case "<PageDown>":
+		self.goal = goal
+		self.moveRows(MaxInt(self.Inner.Dy()-1, 1))
+	case "<Home>", "<C-a>":
+		self.cursor.col = 0
+	case "<End>", "<C-e>":
+		self.cursor.col = len(self.lines[self.cursor.line])
+	case "<M-b>":
+		self.cursor = self.previousWord(self.cursor)
+	case "<M-f>":
+		self.cursor = self.nextWord(self.cursor)
+	case "<M-{>":
+		self.cursor = self.previousParagraph(self.cursor.line)
+	case "<M-}>":
+		self.cursor = self.nextParagraph(self.cursor.line)
+	case "<C-<Space>>":
+		self.selected, self.anchor = true, self.cursor
+	case "<Escape>":
+		self.selected = false
+	case "<M-w>":
+		if self.selected {
+			CopyToClipboard(self.SelectedText())
+		}
+	case "<C-z>":
+		self.Undo()
+		return true
+	case "<C-y>":
+		self.Redo()
+		return true
+	default:
+		moved = false
+	}
+	if moved {
+		self.History.Break()
+		return true
+	}
+
+	switch id {
+	case "<Backspace>", "<C-<Backspace>>":
+		if !self.deleteSelection() {
+			self.deleteTo(self.previous(self.cursor))
+		}
+	case "<Delete>", "<C-d>":
+		if !self.deleteSelection() {
+			self.deleteTo(self.next(self.cursor))
+		}
+	case "<C-w>":
+		if !self.deleteSelection() {
+			self.deleteTo(self.previousWord(self.cursor))
+		}
+	case "<Enter>":
+		self.insert('\n')
+	case "<Space>":
+		self.insert(' ')
+	default:
+		runes := []rune(id)
+		if len(runes) != 1 {
+			return false
+		}
+		self.insert(runes[0])
+	}
+	if self.Text() != before {
+		self.changed()
+	}
+	return true
+}
+
+// HandlePaste inserts the text of a <Paste> event at the cursor, replacing the selection, while
+// the TextArea is Focused and reports whether it was used. The paste is undone in one step.
+func (self *TextArea) HandlePaste(text string) bool {
+	if !self.Focused {
+		return false
+	}
+	if text == "" {
+		return true
+	}
+	if !self.deleteSelection() {
+		self.edit("")
+	}
+	line := self.lines[self.cursor.line]
+	rest := append([]rune{}, line[self.cursor.col:]...)
+	inserted := [][]rune{}
+	for i, pasted := range strings.Split(text, "\n") {
+		if i == 0 {
+			inserted = append(inserted, append(line[:self.cursor.col:self.cursor.col], []rune(pasted)...))
+		} else {
+			inserted = append(inserted, []rune(pasted))
+		}
+	}
+	last := len(inserted) - 1
+	col := len(inserted[last])
+	inserted[last] = append(inserted[last], rest...)
+	self.lines = append(self.lines[:self.cursor.line], append(inserted, self.lines[self.cursor.line+1:]...)...)
+	self.cursor = textPosition{self.cursor.line + last, col}
+	self.changed()
+	return true
+}
+
+// HandleMouse moves the cursor to a clicked rune and selects text by dragging, reporting whether
+// the event was used. The wheel scrolls the text.
+func (self *TextArea) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Inner) {
+		return false
+	}
+	rows := self.rows(self.textWidth())
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.top = MaxInt(self.top-WheelSteps(e), 0)
+		return true
+	case "<MouseWheelDown>":
+		self.top = MinInt(self.top+WheelSteps(e), MaxInt(len(rows)-self.Inner.Dy(), 0))
+		return true
+	case "<MouseLeft>":
+	default:
+		return false
+	}
+	index := self.top + p.Y - self.Inner.Min.Y
+	if index >= len(rows) {
+		index = len(rows) - 1
+	}
+	row := rows[index]
+	position := textPosition{row.line, self.columnAt(row, p.X-self.Inner.Min.X)}
+	if m := e.Payload.(Mouse); m.Drag {
+		if !self.selected {
+			self.selected, self.anchor = true, self.cursor
+		}
+	} else {
+		self.selected = false
+	}
+	self.cursor = position
+	self.goal = -1
+	self.History.Break()
+	return true
+}
+
+// Accessibility implements Accessible with the Title and Focused.
+func (self *TextArea) Accessibility() (string, bool, string) {
+	return self.Title, self.Focused, ""
+}
+
+func (self *TextArea) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	if self.Inner.Empty() {
+		return
+	}
+
+	width := self.textWidth()
+	rows := self.rows(width)
+	height := self.Inner.Dy()
+	cursorRow := rowOf(rows, self.cursor)
+	if cursorRow < self.top {
+		self.top = cursorRow
+	} else if cursorRow >= self.top+height {
+		self.top = cursorRow - height + 1
+	}
+	self.top = MaxInt(MinInt(self.top, len(rows)-height), 0)
+
+	start, end := self.selection()
+	cursorStyle := self.TextStyle
+	cursorStyle.Modifier |= ModifierReverse
+	for y := 0; y < height && self.top+y < len(rows); y++ {
+		row := rows[self.top+y]
+		line := self.lines[row.line]
+		x := 0
+		for i := row.start; i <= row.end; i++ {
+			p := textPosition{row.line, i}
+			style := self.TextStyle
+			if self.selected && !p.before(start) && p.before(end) {
+				style = self.SelectionStyle
+			}
+			isCursor := self.Focused && p == self.cursor && self.top+y == cursorRow
+			if isCursor {
+				style = cursorStyle
+			}
+			r := ' '
+			if i < row.end {
+				r = line[i]
+			} else if !isCursor {
+				break
+			}
+			if x+rw.RuneWidth(r) > width {
+				break
+			}
+			buf.SetCell(NewCell(r, style), image.Pt(self.Inner.Min.X+x, self.Inner.Min.Y+y))
+			x += rw.RuneWidth(r)
+		}
+	}
+	drawScrollbar(buf, self.Inner.Max.X-1, self.Inner.Min.Y, self.Inner.Max.Y, self.top, height, len(rows), self.ScrollbarStyle)
+}
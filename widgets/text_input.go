@@ -0,0 +1,221 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"strings"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// TextInput is a single line of editable text. The text scrolls horizontally when it is wider
+// than the widget, keeping the cursor in view.
+type TextInput struct {
+	Block
+	TextStyle Style
+
+	// Placeholder is drawn with PlaceholderStyle while the text is empty.
+	Placeholder      string
+	PlaceholderStyle Style
+
+	// Focused draws the cursor. Keys are only handled while it is set.
+	Focused bool
+
+	// Masked draws MaskRune in place of every rune of the text, for passwords, unless Revealed is set.
+	// Only the masked text is ever drawn to a Buffer, so it never reaches the screen or anything
+	// reading the Buffer; use Wipe to clear the text from memory once it has been used.
+	Masked   bool
+	MaskRune rune
+	Revealed bool
+
+	// OnChange is called with the text after every edit, and OnSubmit when Enter is pressed.
+	// Enter isn't handled without OnSubmit, leaving it to a Form.
+	OnChange func(text string)
+	OnSubmit func(text string)
+
+	// Direction is the base direction the text is drawn with, in the order of the Unicode
+	// bidirectional algorithm. Right-to-left text is aligned right, and Left and Right move the
+	// cursor the way they point in it. DirectionAuto takes the direction of the first letter.
+	Direction Direction
+
+	// History holds the edits undone with <C-z> and redone with <C-y>, or none when it is nil.
+	// Consecutive keystrokes typing or deleting are undone in one step. The edits of a Masked
+	// TextInput aren't recorded, so passwords aren't copied into it.
+	History *History
+
+	editor lineEditor
+}
+
+func NewTextInput() *TextInput {
+	return &TextInput{
+		Block:            *NewBlock(),
+		TextStyle:        Theme.TextInput.Text,
+		PlaceholderStyle: Theme.TextInput.Placeholder,
+		MaskRune:         Theme.TextInput.Mask,
+		History:          NewHistory(),
+	}
+}
+
+func (self *TextInput) Text() string {
+	return self.editor.text()
+}
+
+// SetText replaces the text and moves the cursor to its end without calling OnChange, and clears
+// the History.
+func (self *TextInput) SetText(text string) {
+	self.configure()
+	self.editor.setText(text)
+}
+
+// configure sets up the editor for the text of the TextInput.
+func (self *TextInput) configure() {
+	self.editor.direction = self.Direction
+	self.editor.history = self.History
+	if self.Masked {
+		self.editor.history = nil
+	}
+}
+
+// Undo reverts the last group of edits and calls OnChange.
+func (self *TextInput) Undo() {
+	if self.History != nil && self.History.Undo() {
+		self.changed()
+	}
+}
+
+// Redo reapplies the last group of edits reverted by Undo and calls OnChange.
+func (self *TextInput) Redo() {
+	if self.History != nil && self.History.Redo() {
+		self.changed()
+	}
+}
+
+func (self *TextInput) changed() {
+	if self.OnChange != nil {
+		self.OnChange(self.Text())
+	}
+}
+
+// Wipe overwrites the runes of the text before emptying it, so that a password doesn't linger in memory.
+// OnChange isn't called.
+func (self *TextInput) Wipe() {
+	runes := self.editor.runes[:cap(self.editor.runes)]
+	for i := range runes {
+		runes[i] = 0
+	}
+	self.editor.runes = runes[:0]
+	self.editor.cursor = 0
+	self.editor.offset = 0
+	if self.History != nil {
+		self.History.Clear()
+	}
+}
+
+// ToggleReveal shows or masks the text of a Masked TextInput.
+func (self *TextInput) ToggleReveal() {
+	self.Revealed = !self.Revealed
+}
+
+// Cursor returns the index of the rune before which text is inserted.
+func (self *TextInput) Cursor() int {
+	return self.editor.cursor
+}
+
+func (self *TextInput) SetCursor(cursor int) {
+	self.editor.cursor = MaxInt(MinInt(cursor, len(self.editor.runes)), 0)
+}
+
+func (self *TextInput) SetFocused(focused bool) {
+	self.Focused = focused
+}
+
+// FormValue returns the text for a Form.
+func (self *TextInput) FormValue() interface{} {
+	return self.Text()
+}
+
+// HandleKey edits the text with a keyboard event ID while the TextInput is Focused and reports
+// whether it was used. Left, Right, Home, End, Backspace, and Delete work as usual, and the
+// readline keys <C-a>, <C-e>, <C-b>, <C-f>, <C-d>, <C-w>, <C-u>, and <C-k> are supported, along
+// with <C-z> and <C-y> to undo and redo.
+func (self *TextInput) HandleKey(id string) bool {
+	if !self.Focused {
+		return false
+	}
+	if id == "<Enter>" {
+		if self.OnSubmit == nil {
+			return false
+		}
+		self.OnSubmit(self.Text())
+		return true
+	}
+	before := self.Text()
+	self.configure()
+	if !self.editor.handleKey(id) {
+		return false
+	}
+	if text := self.Text(); text != before && self.OnChange != nil {
+		self.OnChange(text)
+	}
+	return true
+}
+
+// HandlePaste inserts the text of a <Paste> event at the cursor while the TextInput is Focused,
+// with its lines joined by spaces, and reports whether it was used.
+func (self *TextInput) HandlePaste(text string) bool {
+	if !self.Focused {
+		return false
+	}
+	if text == "" {
+		return true
+	}
+	self.configure()
+	self.editor.insertText(strings.Replace(text, "\n", " ", -1))
+	self.changed()
+	return true
+}
+
+// HandleMouse moves the cursor to a clicked rune and reports whether the event was used.
+func (self *TextInput) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || e.ID != "<MouseLeft>" || !p.In(self.Inner) {
+		return false
+	}
+	self.editor.cursor = self.editor.runeAt(p.X - self.Inner.Min.X)
+	return true
+}
+
+// Accessibility implements Accessible with the Title, or the Placeholder without one, and Focused.
+// The text isn't announced, since a Masked TextInput holds passwords.
+func (self *TextInput) Accessibility() (string, bool, string) {
+	if self.Title == "" {
+		return self.Placeholder, self.Focused, ""
+	}
+	return self.Title, self.Focused, ""
+}
+
+func (self *TextInput) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+
+	point := self.Inner.Min
+	if self.Inner.Empty() {
+		return
+	}
+	if len(self.editor.runes) == 0 && self.Placeholder != "" {
+		buf.SetString(TrimString(self.Placeholder, self.Inner.Dx()), self.PlaceholderStyle, point)
+		if self.Focused {
+			cursorStyle := self.PlaceholderStyle
+			cursorStyle.Modifier |= ModifierReverse
+			buf.SetCell(NewCell([]rune(self.Placeholder)[0], cursorStyle), point)
+		}
+		return
+	}
+	self.editor.mask = 0
+	if self.Masked && !self.Revealed {
+		self.editor.mask = self.MaskRune
+	}
+	self.configure()
+	self.editor.draw(buf, point, self.Inner.Dx(), self.TextStyle, self.Focused)
+}
@@ -0,0 +1,250 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// ToastLevel is the severity of a toast, which sets its color.
+type ToastLevel uint
+
+const (
+	ToastInfo ToastLevel = iota
+	ToastSuccess
+	ToastWarning
+	ToastError
+)
+
+func (self ToastLevel) String() string {
+	switch self {
+	case ToastSuccess:
+		return "Success"
+	case ToastWarning:
+		return "Warning"
+	case ToastError:
+		return "Error"
+	}
+	return "Info"
+}
+
+// Corner is a corner of an area.
+type Corner uint
+
+const (
+	CornerTopRight Corner = iota
+	CornerTopLeft
+	CornerBottomRight
+	CornerBottomLeft
+)
+
+type toast struct {
+	message string
+	level   ToastLevel
+	timer   *time.Timer
+	rect    image.Rectangle
+}
+
+// Toaster stacks transient messages, toasts, in a corner of Bounds. It is shown with ShowOverlay
+// while it holds toasts, so that every Render draws it above the layout. At most MaxVisible toasts
+// are shown at a time; the others wait for them to be dismissed. Each toast is dismissed
+// Duration after it is shown, or when it is clicked.
+type Toaster struct {
+	Block
+	// Bounds is the area the toasts are stacked in, usually the terminal.
+	Bounds     image.Rectangle
+	Corner     Corner
+	Width      int
+	MaxVisible int
+	// Duration is how long a toast is shown, or forever if it is 0.
+	Duration time.Duration
+
+	TextStyle   Style
+	LevelStyles map[ToastLevel]Style
+
+	// OnChange is called when toasts are dismissed by their timer, from its own goroutine, so that
+	// the application can Render without them.
+	OnChange func()
+
+	toasts []*toast
+}
+
+func NewToaster(bounds image.Rectangle) *Toaster {
+	self := &Toaster{
+		Block:      *NewBlock(),
+		Bounds:     bounds,
+		Width:      40,
+		MaxVisible: 3,
+		Duration:   4 * time.Second,
+		TextStyle:  Theme.Toast.Text,
+		LevelStyles: map[ToastLevel]Style{
+			ToastInfo:    Theme.Toast.Info,
+			ToastSuccess: Theme.Toast.Success,
+			ToastWarning: Theme.Toast.Warning,
+			ToastError:   Theme.Toast.Error,
+		},
+	}
+	self.Border = false
+	return self
+}
+
+// Push adds a toast with a message and severity, which is announced as an alert.
+func (self *Toaster) Push(level ToastLevel, message string) {
+	Announce(AnnounceAlert, level.String()+": "+message)
+	self.Lock()
+	defer self.Unlock()
+	self.toasts = append(self.toasts, &toast{message: message, level: level})
+	self.layout()
+}
+
+func (self *Toaster) Info(message string) {
+	self.Push(ToastInfo, message)
+}
+
+func (self *Toaster) Success(message string) {
+	self.Push(ToastSuccess, message)
+}
+
+func (self *Toaster) Warning(message string) {
+	self.Push(ToastWarning, message)
+}
+
+func (self *Toaster) Error(message string) {
+	self.Push(ToastError, message)
+}
+
+// Len returns the number of toasts, shown or waiting.
+func (self *Toaster) Len() int {
+	self.Lock()
+	defer self.Unlock()
+	return len(self.toasts)
+}
+
+// DismissAll removes every toast.
+func (self *Toaster) DismissAll() {
+	self.Lock()
+	defer self.Unlock()
+	for _, t := range self.toasts {
+		if t.timer != nil {
+			t.timer.Stop()
+		}
+	}
+	self.toasts = nil
+	self.layout()
+}
+
+func (self *Toaster) dismiss(t *toast) bool {
+	for i, other := range self.toasts {
+		if other == t {
+			if t.timer != nil {
+				t.timer.Stop()
+			}
+			self.toasts = append(self.toasts[:i:i], self.toasts[i+1:]...)
+			self.layout()
+			return true
+		}
+	}
+	return false
+}
+
+// HandleMouse dismisses a clicked toast and reports whether the event was used.
+func (self *Toaster) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag {
+		return false
+	}
+	self.Lock()
+	defer self.Unlock()
+	for _, t := range self.visible() {
+		if p.In(t.rect) {
+			return self.dismiss(t)
+		}
+	}
+	return false
+}
+
+func (self *Toaster) visible() []*toast {
+	return self.toasts[:MinInt(len(self.toasts), MaxInt(self.MaxVisible, 1))]
+}
+
+// lines wraps the message of a toast to the width of its box.
+func (self *Toaster) lines(t *toast) [][]Cell {
+	cells := RunesToStyledCells([]rune(t.message), self.TextStyle)
+	return SplitCells(WrapCells(cells, uint(MaxInt(self.Width-4, 1))), '\n')
+}
+
+// layout stacks the visible toasts from the Corner, starts their timers, and shows the Toaster
+// as an overlay over them, hiding it first so that the next Render clears the area it covered.
+func (self *Toaster) layout() {
+	HideOverlay(self)
+	visible := self.visible()
+	if len(visible) == 0 {
+		return
+	}
+
+	width := MinInt(self.Width, self.Bounds.Dx())
+	top := self.Corner == CornerTopLeft || self.Corner == CornerTopRight
+	left := self.Corner == CornerTopLeft || self.Corner == CornerBottomLeft
+	x := self.Bounds.Max.X - width
+	if left {
+		x = self.Bounds.Min.X
+	}
+	y := self.Bounds.Min.Y
+	if !top {
+		y = self.Bounds.Max.Y
+	}
+
+	area := image.Rectangle{}
+	for _, t := range visible {
+		height := len(self.lines(t)) + 2
+		if top {
+			t.rect = image.Rect(x, y, x+width, y+height)
+			y += height
+		} else {
+			t.rect = image.Rect(x, y-height, x+width, y)
+			y -= height
+		}
+		area = area.Union(t.rect)
+
+		if t.timer == nil && self.Duration > 0 {
+			t := t
+			t.timer = time.AfterFunc(self.Duration, func() {
+				self.Lock()
+				dismissed := self.dismiss(t)
+				self.Unlock()
+				if dismissed && self.OnChange != nil {
+					self.OnChange()
+				}
+			})
+		}
+	}
+	self.SetRect(area.Min.X, area.Min.Y, area.Max.X, area.Max.Y)
+	ShowOverlay(self)
+}
+
+func (self *Toaster) Draw(buf *Buffer) {
+	for _, t := range self.visible() {
+		style := self.LevelStyles[t.level]
+		block := NewBlock()
+		block.Title = " " + t.level.String() + " "
+		block.TitleStyle = style
+		block.BorderStyle = style
+		block.SetRect(t.rect.Min.X, t.rect.Min.Y, t.rect.Max.X, t.rect.Max.Y)
+		buf.Fill(NewCell(' ', self.TextStyle), t.rect)
+		block.Draw(buf)
+		for i, line := range self.lines(t) {
+			point := image.Pt(block.Inner.Min.X+1, block.Inner.Min.Y+i)
+			for _, cell := range line {
+				buf.SetCell(cell, point)
+				point.X += rw.RuneWidth(cell.Rune)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package widgets
 
 import (
+	"encoding/json"
 	"fmt"
 	"image"
 	"strings"
@@ -16,18 +17,38 @@ type TreeNode struct {
 	Value    fmt.Stringer
 	Expanded bool
 	Nodes    []*TreeNode
+	// Lazy marks a node whose Nodes are loaded by Tree.OnExpand when it is first expanded.
+	Lazy bool
+	// Checked is the state of the node's checkbox when the Tree has Checkboxes. A node with
+	// children is checked when all of them are.
+	Checked bool
 
 	// level stores the node level in the tree.
 	level int
+	// loading is set while the Nodes of a Lazy node are being fetched.
+	loading bool
+	// partial is set on unchecked nodes with some checked descendants.
+	partial bool
+	// placeholder is set on the node shown while children are loading.
+	placeholder bool
+}
+
+// treeText is the Value of the placeholder node shown while children are loading, and of the
+// nodes made from the text of items dropped into the Tree.
+type treeText string
+
+func (self treeText) String() string {
+	return string(self)
 }
 
 // TreeWalkFn is a function used for walking a Tree.
 // To interrupt the walking process function should return false.
 type TreeWalkFn func(*TreeNode) bool
 
-func (self *TreeNode) parseStyles(style Style) []Cell {
+// prefix returns the indentation, expansion sign, and checkbox drawn before the node's text.
+func (self *TreeNode) prefix(checkboxes bool) string {
 	var sb strings.Builder
-	if len(self.Nodes) == 0 {
+	if len(self.Nodes) == 0 && !self.Lazy {
 		sb.WriteString(strings.Repeat(treeIndent, self.level+1))
 	} else {
 		sb.WriteString(strings.Repeat(treeIndent, self.level))
@@ -38,8 +59,18 @@ func (self *TreeNode) parseStyles(style Style) []Cell {
 		}
 		sb.WriteByte(' ')
 	}
-	sb.WriteString(self.Value.String())
-	return ParseStyles(sb.String(), style)
+	if checkboxes && !self.placeholder {
+		switch {
+		case self.Checked:
+			sb.WriteRune(Theme.Tree.Checked)
+		case self.partial:
+			sb.WriteRune(Theme.Tree.Partial)
+		default:
+			sb.WriteRune(Theme.Tree.Unchecked)
+		}
+		sb.WriteByte(' ')
+	}
+	return sb.String()
 }
 
 // Tree is a tree widget.
@@ -49,11 +80,36 @@ type Tree struct {
 	SelectedRowStyle Style
 	WrapText         bool
 	SelectedRow      int
+	// Checkboxes draws a checkbox before each node, checked, unchecked, or partially checked
+	// when only some of the node's descendants are.
+	Checkboxes bool
+	// OnExpand is called when a Lazy node is expanded and returns its children. If it returns nil,
+	// LoadingText is shown under the node until SetChildren is called, so children can be fetched
+	// in the background.
+	OnExpand    func(node *TreeNode) []*TreeNode
+	LoadingText string
+	MatchStyle  Style
+	// NodeRenderer, if not nil, returns the cells drawn for the text of a node in place of its Value,
+	// for icons, badges, and multi-colored text. The cells are drawn as given on the selected row.
+	NodeRenderer func(node *TreeNode, selected bool) []Cell
+	// ScrollbarStyle is used for the scrollbar drawn on the right edge when the rows don't fit.
+	ScrollbarStyle Style
+
+	// OnDrop, when set, is called before an item dragged with a DragDrop is added to the Nodes of
+	// parent at index, or to the top-level nodes when parent is nil, and rejects it by returning
+	// false. OnDragOut is called after the node dragged into another widget is removed.
+	OnDrop    func(item DragItem, parent *TreeNode, index int) bool
+	OnDragOut func(node *TreeNode)
 
 	nodes []*TreeNode
 	// rows is flatten nodes for rendering.
 	rows   []*TreeNode
 	topRow int
+
+	searchBar
+	// dragged is the node dragged out with a DragDrop.
+	dragged   *TreeNode
+	scrollbar Scrollbar
 }
 
 // NewTree creates a new Tree widget.
@@ -63,11 +119,15 @@ func NewTree() *Tree {
 		TextStyle:        Theme.Tree.Text,
 		SelectedRowStyle: Theme.Tree.Text,
 		WrapText:         true,
+		LoadingText:      "Loading" + string(ELLIPSES),
+		MatchStyle:       Theme.Tree.Match,
+		ScrollbarStyle:   Theme.Tree.Scrollbar,
 	}
 }
 
 func (self *Tree) SetNodes(nodes []*TreeNode) {
 	self.nodes = nodes
+	self.updateChecked()
 	self.prepareNodes()
 }
 
@@ -83,12 +143,46 @@ func (self *Tree) prepareNode(node *TreeNode, level int) {
 	node.level = level
 
 	if node.Expanded {
+		if node.loading {
+			self.rows = append(self.rows, &TreeNode{Value: treeText(self.LoadingText), level: level + 1, placeholder: true})
+		}
 		for _, n := range node.Nodes {
 			self.prepareNode(n, level+1)
 		}
 	}
 }
 
+// SetChildren sets the Nodes of a Lazy node loaded in the background, replacing the loading placeholder.
+func (self *Tree) SetChildren(node *TreeNode, nodes []*TreeNode) {
+	self.setChildren(node, nodes)
+	node.loading = false
+	self.prepareNodes()
+}
+
+// setChildren sets the loaded Nodes of a Lazy node. They are checked if the node was.
+func (self *Tree) setChildren(node *TreeNode, nodes []*TreeNode) {
+	node.Nodes = nodes
+	node.Lazy = false
+	if node.Checked {
+		self.setChecked(node, true)
+	}
+	self.updateChecked()
+}
+
+// expand expands node, loading its children with OnExpand if it is Lazy.
+func (self *Tree) expand(node *TreeNode) {
+	if node.Lazy && !node.loading && self.OnExpand != nil {
+		if nodes := self.OnExpand(node); nodes != nil {
+			self.setChildren(node, nodes)
+		} else {
+			node.loading = true
+		}
+	}
+	if len(node.Nodes) > 0 || node.loading {
+		node.Expanded = true
+	}
+}
+
 func (self *Tree) Walk(fn TreeWalkFn) {
 	for _, n := range self.nodes {
 		if !self.walk(n, fn) {
@@ -114,25 +208,45 @@ func (self *Tree) walk(n *TreeNode, fn TreeWalkFn) bool {
 func (self *Tree) Draw(buf *Buffer) {
 	self.Block.Draw(buf)
 	point := self.Inner.Min
+	maxY := self.Inner.Min.Y + self.visibleLines()
 
 	// adjusts view into widget
-	if self.SelectedRow >= self.Inner.Dy()+self.topRow {
-		self.topRow = self.SelectedRow - self.Inner.Dy() + 1
+	if self.SelectedRow >= self.visibleLines()+self.topRow {
+		self.topRow = self.SelectedRow - self.visibleLines() + 1
 	} else if self.SelectedRow < self.topRow {
 		self.topRow = self.SelectedRow
 	}
 
 	// draw rows
-	for row := self.topRow; row < len(self.rows) && point.Y < self.Inner.Max.Y; row++ {
-		cells := self.rows[row].parseStyles(self.TextStyle)
+	for row := self.topRow; row < len(self.rows) && point.Y < maxY; row++ {
+		node := self.rows[row]
+		selected := row == self.SelectedRow
+		prefixStyle := self.TextStyle
+		if selected {
+			prefixStyle = self.SelectedRowStyle
+		}
+		var text []Cell
+		if self.NodeRenderer != nil && !node.placeholder {
+			text = self.NodeRenderer(node, selected)
+		} else {
+			text = ParseStyles(node.Value.String(), prefixStyle)
+			if selected {
+				for k := range text {
+					text[k].Style = self.SelectedRowStyle
+				}
+			}
+		}
+		if query := self.query.text(); query != "" && !node.placeholder {
+			for _, k := range matchRunes(CellsToString(text), query, false) {
+				text[k].Style = self.MatchStyle
+			}
+		}
+		cells := append(RunesToStyledCells([]rune(node.prefix(self.Checkboxes)), prefixStyle), text...)
 		if self.WrapText {
 			cells = WrapCells(cells, uint(self.Inner.Dx()))
 		}
-		for j := 0; j < len(cells) && point.Y < self.Inner.Max.Y; j++ {
+		for j := 0; j < len(cells) && point.Y < maxY; j++ {
 			style := cells[j].Style
-			if row == self.SelectedRow {
-				style = self.SelectedRowStyle
-			}
 			if point.X+1 == self.Inner.Max.X+1 && len(cells) > self.Inner.Dx() {
 				buf.SetCell(NewCell(ELLIPSES, style), point.Add(image.Pt(-1, 0)))
 			} else {
@@ -143,20 +257,162 @@ func (self *Tree) Draw(buf *Buffer) {
 		point = image.Pt(self.Inner.Min.X, point.Y+1)
 	}
 
-	// draw UP_ARROW if needed
-	if self.topRow > 0 {
-		buf.SetCell(
-			NewCell(UP_ARROW, NewStyle(ColorWhite)),
-			image.Pt(self.Inner.Max.X-1, self.Inner.Min.Y),
-		)
+	// draw search bar
+	if self.searchBarShown() {
+		self.drawSearchBar(buf, image.Pt(self.Inner.Min.X, self.Inner.Max.Y-1), self.Inner.Dx()-1, self.TextStyle)
+	}
+
+	self.scrollbar.Style = self.ScrollbarStyle
+	self.scrollbar.Draw(buf, image.Rect(self.Inner.Max.X-1, self.Inner.Min.Y, self.Inner.Max.X, maxY),
+		self.topRow, self.visibleLines(), len(self.rows))
+}
+
+// visibleLines returns the number of lines available for rows, leaving out the search bar.
+func (self *Tree) visibleLines() int {
+	if self.searchBarShown() {
+		return MaxInt(self.Inner.Dy()-1, 1)
 	}
+	return self.Inner.Dy()
+}
+
+// StartSearch opens the search bar on the bottom line. While it is open, HandleSearchKey edits the query,
+// selecting the first node matching it from the selected one on and expanding the nodes above it.
+func (self *Tree) StartSearch() {
+	self.openSearch()
+}
+
+// ClearSearch closes the search bar and removes the highlighting of matches.
+func (self *Tree) ClearSearch() {
+	self.clearSearch()
+}
 
-	// draw DOWN_ARROW if needed
-	if len(self.rows) > int(self.topRow)+self.Inner.Dy() {
-		buf.SetCell(
-			NewCell(DOWN_ARROW, NewStyle(ColorWhite)),
-			image.Pt(self.Inner.Max.X-1, self.Inner.Max.Y-1),
-		)
+// SetSearchQuery applies query like one typed in the search bar, without showing the bar, for a
+// SearchBar bound to the Tree.
+func (self *Tree) SetSearchQuery(query string) {
+	self.linkSearch(query, self.firstMatch)
+}
+
+// SearchMatches returns the number of nodes matching the search query, including those in
+// collapsed nodes.
+func (self *Tree) SearchMatches() int {
+	query := self.query.text()
+	if query == "" {
+		return 0
+	}
+	count := 0
+	var visit func(nodes []*TreeNode)
+	visit = func(nodes []*TreeNode) {
+		for _, node := range nodes {
+			if matchRunes(CellsToString(ParseStyles(node.Value.String(), self.TextStyle)), query, false) != nil {
+				count++
+			}
+			visit(node.Nodes)
+		}
+	}
+	visit(self.nodes)
+	return count
+}
+
+// HandleSearchKey applies a keyboard event ID to the search bar and reports whether it was used.
+// <Enter> closes the bar keeping the matches highlighted, <Escape> clears the query.
+// Once the bar is closed, n and N select the next and previous matches.
+func (self *Tree) HandleSearchKey(id string) bool {
+	if !self.searching {
+		if self.query.text() == "" {
+			return false
+		}
+		switch id {
+		case "n":
+			self.NextMatch()
+		case "N":
+			self.PreviousMatch()
+		case "<Escape>":
+			self.ClearSearch()
+		default:
+			return false
+		}
+		return true
+	}
+	switch id {
+	case "<Down>", "<C-n>":
+		self.NextMatch()
+	case "<Up>", "<C-p>":
+		self.PreviousMatch()
+	default:
+		return self.handleSearchKey(id, self.firstMatch)
+	}
+	return true
+}
+
+// NextMatch selects the next node matching the search query, expanding the nodes above it.
+func (self *Tree) NextMatch() {
+	self.findMatch(1)
+}
+
+// PreviousMatch selects the previous node matching the search query, expanding the nodes above it.
+func (self *Tree) PreviousMatch() {
+	self.findMatch(-1)
+}
+
+// firstMatch selects the first node matching the search query from the selected one on.
+func (self *Tree) firstMatch() {
+	self.findMatch(0)
+}
+
+// findMatch selects the first matching node found going from the selected node in direction,
+// wrapping around the tree. A direction of 0 searches forward starting with the selected node.
+func (self *Tree) findMatch(direction int) {
+	query := self.query.text()
+	if query == "" {
+		return
+	}
+
+	// every node in order with its ancestors, whether they are expanded or not
+	type treePath struct {
+		node      *TreeNode
+		ancestors []*TreeNode
+	}
+	paths := []treePath{}
+	var visit func(node *TreeNode, ancestors []*TreeNode)
+	visit = func(node *TreeNode, ancestors []*TreeNode) {
+		paths = append(paths, treePath{node, ancestors})
+		children := append(append([]*TreeNode{}, ancestors...), node)
+		for _, n := range node.Nodes {
+			visit(n, children)
+		}
+	}
+	for _, node := range self.nodes {
+		visit(node, nil)
+	}
+
+	start := 0
+	selected := self.SelectedNode()
+	for i, path := range paths {
+		if path.node == selected {
+			start = i
+		}
+	}
+	step := direction
+	if direction == 0 {
+		step = 1
+		start--
+	}
+	for k := 1; k <= len(paths); k++ {
+		path := paths[((start+step*k)%len(paths)+len(paths))%len(paths)]
+		text := CellsToString(ParseStyles(path.node.Value.String(), self.TextStyle))
+		if matchRunes(text, query, false) == nil {
+			continue
+		}
+		for _, ancestor := range path.ancestors {
+			ancestor.Expanded = true
+		}
+		self.prepareNodes()
+		for row, node := range self.rows {
+			if node == path.node {
+				self.SelectedRow = row
+			}
+		}
+		return
 	}
 }
 
@@ -180,6 +436,266 @@ func (self *Tree) SelectedNode() *TreeNode {
 	return self.rows[self.SelectedRow]
 }
 
+// Accessibility implements Accessible with the Title and the text of the selected node.
+func (self *Tree) Accessibility() (string, bool, string) {
+	if node := self.SelectedNode(); node != nil {
+		return self.Title, false, node.Value.String()
+	}
+	return self.Title, false, ""
+}
+
+// rowAt returns the index in rows of the node drawn on the line of p, and false if there is none.
+func (self *Tree) rowAt(p image.Point) (int, bool) {
+	if !p.In(self.Inner) || p.Y >= self.Inner.Min.Y+self.visibleLines() {
+		return 0, false
+	}
+	row := self.topRow + p.Y - self.Inner.Min.Y
+	return row, row < len(self.rows)
+}
+
+// HandleMouse selects the row clicked with the left mouse button, expands or collapses it with a
+// double-click, and scrolls with the mouse wheel and the scrollbar. It reports whether the event
+// was used.
+func (self *Tree) HandleMouse(e Event) bool {
+	if offset, ok := self.scrollbar.HandleMouse(e); ok {
+		self.scrollTo(offset)
+		return true
+	}
+	p, ok := MousePoint(e)
+	if !ok || !p.In(self.Rectangle) {
+		return false
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		self.ScrollAmount(-WheelSteps(e))
+	case "<MouseWheelDown>":
+		self.ScrollAmount(WheelSteps(e))
+	case "<MouseLeft>":
+		row, ok := self.rowAt(p)
+		if !ok || e.Payload.(Mouse).Drag {
+			return false
+		}
+		if e.Payload.(Mouse).Clicks == 2 && row == self.SelectedRow {
+			self.ToggleExpand()
+			return true
+		}
+		self.SelectedRow = row
+	default:
+		return false
+	}
+	return true
+}
+
+// scrollTo shows the rows from offset, like the scrollbar dragged there, keeping the selection
+// in view.
+func (self *Tree) scrollTo(offset int) {
+	if len(self.rows) == 0 {
+		return
+	}
+	self.topRow = MaxInt(MinInt(offset, len(self.rows)-1), 0)
+	last := MinInt(self.topRow+self.visibleLines(), len(self.rows)) - 1
+	self.SelectedRow = MaxInt(MinInt(self.SelectedRow, last), self.topRow)
+}
+
+// parentOf returns the parent of node, which is nil for a top-level node, and the index of node
+// among its siblings, which is -1 if it isn't in the Tree.
+func (self *Tree) parentOf(node *TreeNode) (*TreeNode, int) {
+	if i := treeNodeIndex(self.nodes, node); i >= 0 {
+		return nil, i
+	}
+	var parent *TreeNode
+	index := -1
+	self.Walk(func(n *TreeNode) bool {
+		if i := treeNodeIndex(n.Nodes, node); i >= 0 {
+			parent, index = n, i
+			return false
+		}
+		return true
+	})
+	return parent, index
+}
+
+func treeNodeIndex(nodes []*TreeNode, node *TreeNode) int {
+	for i, n := range nodes {
+		if n == node {
+			return i
+		}
+	}
+	return -1
+}
+
+// siblings returns the Nodes of parent, or the top-level nodes when parent is nil.
+func (self *Tree) siblings(parent *TreeNode) *[]*TreeNode {
+	if parent == nil {
+		return &self.nodes
+	}
+	return &parent.Nodes
+}
+
+// removeNode removes node from the Tree along with its descendants.
+func (self *Tree) removeNode(node *TreeNode) {
+	parent, i := self.parentOf(node)
+	if i < 0 {
+		return
+	}
+	nodes := self.siblings(parent)
+	*nodes = append((*nodes)[:i], (*nodes)[i+1:]...)
+}
+
+// DragStart implements DragSource, dragging the node at p as a *TreeNode along with its descendants.
+func (self *Tree) DragStart(p image.Point) (DragItem, bool) {
+	row, ok := self.rowAt(p)
+	if !ok || self.rows[row].placeholder {
+		return DragItem{}, false
+	}
+	self.dragged = self.rows[row]
+	return DragItem{Text: self.dragged.Value.String(), Value: self.dragged}, true
+}
+
+// DragEnd implements DragSource, removing the node dragged into another widget.
+func (self *Tree) DragEnd(item DragItem, target DropTarget) {
+	node := self.dragged
+	self.dragged = nil
+	if node == nil || target == nil || target == DropTarget(self) {
+		return
+	}
+	self.removeNode(node)
+	self.refresh(self.SelectedNode())
+	if self.OnDragOut != nil {
+		self.OnDragOut(node)
+	}
+}
+
+// Drop implements DropTarget. An item released on a node with children becomes its last child,
+// expanding it, one released on a leaf is added after it, and one released below the nodes is
+// added after the last top-level node. Nodes are moved along with their descendants, and the
+// items of other widgets which aren't a *TreeNode are added as a node with their Text.
+func (self *Tree) Drop(item DragItem, p image.Point) bool {
+	node, ok := item.Value.(*TreeNode)
+	if !ok {
+		node = &TreeNode{Value: treeText(item.Text)}
+	}
+	var parent *TreeNode
+	index := len(self.nodes)
+	if row, ok := self.rowAt(p); ok {
+		over := self.rows[row]
+		switch {
+		case over.placeholder || over == node:
+			return false
+		case len(over.Nodes) > 0:
+			parent, index = over, len(over.Nodes)
+		default:
+			parent, index = self.parentOf(over)
+			index++
+		}
+	}
+	if item.Source == DragSource(self) {
+		// a node can't be moved into its own descendants
+		for ancestor := parent; ancestor != nil; ancestor, _ = self.parentOf(ancestor) {
+			if ancestor == node {
+				return false
+			}
+		}
+		if oldParent, i := self.parentOf(node); oldParent == parent && i < index {
+			index--
+		}
+	}
+	if self.OnDrop != nil && !self.OnDrop(item, parent, index) {
+		return false
+	}
+	if item.Source == DragSource(self) {
+		self.removeNode(node)
+	}
+	nodes := self.siblings(parent)
+	*nodes = append((*nodes)[:index], append([]*TreeNode{node}, (*nodes)[index:]...)...)
+	if parent != nil {
+		parent.Expanded = true
+	}
+	self.refresh(node)
+	return true
+}
+
+// refresh lays out the rows again after nodes were added or removed, and selects selected if it
+// is still shown.
+func (self *Tree) refresh(selected *TreeNode) {
+	self.updateChecked()
+	self.prepareNodes()
+	for i, row := range self.rows {
+		if row == selected {
+			self.SelectedRow = i
+		}
+	}
+	self.SelectedRow = MaxInt(MinInt(self.SelectedRow, len(self.rows)-1), 0)
+}
+
+// treeState is the state of a Tree saved by SaveState. Nodes are saved by the path of the Values
+// of the nodes leading to them.
+type treeState struct {
+	Expanded [][]string `json:"expanded,omitempty"`
+	Selected []string   `json:"selected,omitempty"`
+	TopRow   int        `json:"topRow"`
+}
+
+// walkPaths calls fn with every node and the String of the Values of the nodes leading to it,
+// ending with its own, parents first. The children of a node are walked after fn returns.
+func (self *Tree) walkPaths(nodes []*TreeNode, path []string, fn func(node *TreeNode, path []string)) {
+	for _, node := range nodes {
+		nodePath := append(path[:len(path):len(path)], node.Value.String())
+		fn(node, nodePath)
+		self.walkPaths(node.Nodes, nodePath, fn)
+	}
+}
+
+// SaveState implements Persistent with the expanded nodes, the selected node, and the scroll position.
+func (self *Tree) SaveState() ([]byte, error) {
+	state := treeState{TopRow: self.topRow}
+	selected := self.SelectedNode()
+	self.walkPaths(self.nodes, nil, func(node *TreeNode, path []string) {
+		if node.Expanded {
+			state.Expanded = append(state.Expanded, path)
+		}
+		if node == selected {
+			state.Selected = path
+		}
+	})
+	return json.Marshal(state)
+}
+
+// RestoreState implements Persistent. Nodes are found by the path of their Values, so the state
+// is restored for the nodes still in the Tree. Lazy nodes are expanded with OnExpand, but the
+// nodes it loads in the background aren't expanded.
+func (self *Tree) RestoreState(data []byte) error {
+	state := treeState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	expanded := map[string]bool{}
+	for _, path := range state.Expanded {
+		expanded[strings.Join(path, "\x00")] = true
+	}
+	selected := strings.Join(state.Selected, "\x00")
+	var selectedNode *TreeNode
+	self.walkPaths(self.nodes, nil, func(node *TreeNode, path []string) {
+		key := strings.Join(path, "\x00")
+		if expanded[key] {
+			self.expand(node)
+		} else {
+			node.Expanded = false
+		}
+		if key == selected {
+			selectedNode = node
+		}
+	})
+	self.prepareNodes()
+	for i, row := range self.rows {
+		if row == selectedNode {
+			self.SelectedRow = i
+		}
+	}
+	self.topRow = MaxInt(MinInt(state.TopRow, len(self.rows)-1), 0)
+	return nil
+}
+
 func (self *Tree) ScrollUp() {
 	self.ScrollAmount(-1)
 }
@@ -223,21 +739,21 @@ func (self *Tree) Collapse() {
 }
 
 func (self *Tree) Expand() {
-	node := self.rows[self.SelectedRow]
-	if len(node.Nodes) > 0 {
-		self.rows[self.SelectedRow].Expanded = true
-	}
+	self.expand(self.rows[self.SelectedRow])
 	self.prepareNodes()
 }
 
 func (self *Tree) ToggleExpand() {
 	node := self.rows[self.SelectedRow]
-	if len(node.Nodes) > 0 {
-		node.Expanded = !node.Expanded
+	if node.Expanded {
+		node.Expanded = false
+	} else {
+		self.expand(node)
 	}
 	self.prepareNodes()
 }
 
+// ExpandAll expands every node with children. Lazy nodes are left for OnExpand.
 func (self *Tree) ExpandAll() {
 	self.Walk(func(n *TreeNode) bool {
 		if len(n.Nodes) > 0 {
@@ -255,3 +771,60 @@ func (self *Tree) CollapseAll() {
 	})
 	self.prepareNodes()
 }
+
+// ToggleChecked checks or unchecks the selected node along with all of its descendants.
+// A partially checked node becomes checked.
+func (self *Tree) ToggleChecked() {
+	node := self.SelectedNode()
+	if node == nil || node.placeholder {
+		return
+	}
+	self.setChecked(node, !node.Checked)
+	self.updateChecked()
+}
+
+// CheckedNodes returns the checked nodes in the order they appear in the tree.
+func (self *Tree) CheckedNodes() []*TreeNode {
+	nodes := []*TreeNode{}
+	self.Walk(func(n *TreeNode) bool {
+		if n.Checked {
+			nodes = append(nodes, n)
+		}
+		return true
+	})
+	return nodes
+}
+
+func (self *Tree) setChecked(node *TreeNode, checked bool) {
+	node.Checked = checked
+	for _, n := range node.Nodes {
+		self.setChecked(n, checked)
+	}
+}
+
+// updateChecked propagates the states of the checkboxes up to the parents.
+func (self *Tree) updateChecked() {
+	for _, node := range self.nodes {
+		updateChecked(node)
+	}
+}
+
+// updateChecked sets the state of node from its descendants and reports whether any of them is checked.
+func updateChecked(node *TreeNode) bool {
+	if len(node.Nodes) == 0 {
+		node.partial = false
+		return node.Checked
+	}
+	all, some := true, false
+	for _, n := range node.Nodes {
+		if updateChecked(n) {
+			some = true
+		}
+		if !n.Checked {
+			all = false
+		}
+	}
+	node.Checked = all
+	node.partial = some && !all
+	return some
+}
@@ -0,0 +1,380 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// TreemapNode is an item of a Treemap, weighted by its Value or, when it has Nodes, by theirs.
+type TreemapNode struct {
+	Label string
+	Value float64
+	Nodes []*TreemapNode
+}
+
+// Weight returns the Value of a leaf, or the sum of the weights of the Nodes.
+func (self *TreemapNode) Weight() float64 {
+	if len(self.Nodes) == 0 {
+		return math.Max(self.Value, 0)
+	}
+	weight := 0.0
+	for _, node := range self.Nodes {
+		weight += node.Weight()
+	}
+	return weight
+}
+
+// sortedNodes returns the Nodes with a weight, heaviest first.
+func (self *TreemapNode) sortedNodes() []*TreemapNode {
+	nodes := []*TreemapNode{}
+	for _, node := range self.Nodes {
+		if node.Weight() > 0 {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Weight() > nodes[j].Weight()
+	})
+	return nodes
+}
+
+// Treemap shows the Nodes of a TreemapNode as rectangles sized by their weight, below Breadcrumbs
+// of the path to the node shown. Rectangles hold the Nodes of their own node, Depth levels down.
+// The arrow keys select the nearest rectangle in their direction, <Enter> or a double-click shows
+// the Nodes of the selected node, and <Backspace>, <Escape>, or clicking the Breadcrumbs goes back.
+type Treemap struct {
+	Block
+	Colors []Color
+	// TextStyle is the style of the labels, drawn over the Colors. Its Bg is not used.
+	TextStyle     Style
+	SelectedStyle Style
+	// NumFormatter formats the weight drawn after each label.
+	NumFormatter func(float64) string
+	// Depth is the number of levels drawn, the nodes shown being the first.
+	Depth int
+
+	// Breadcrumbs shows the path from the root to the node shown, on the first row.
+	Breadcrumbs *Breadcrumbs
+
+	// OnChange is called with the node shown after moving down or back up.
+	OnChange func(node *TreemapNode)
+
+	root *TreemapNode
+	// path holds the nodes shown below the root.
+	path     []*TreemapNode
+	selected *TreemapNode
+	// rects holds the area of each node shown drawn by the last Draw.
+	rects []treemapRect
+}
+
+type treemapRect struct {
+	node *TreemapNode
+	rect image.Rectangle
+}
+
+func NewTreemap() *Treemap {
+	self := &Treemap{
+		Block:         *NewBlock(),
+		Colors:        Theme.Treemap.Colors,
+		TextStyle:     Theme.Treemap.Text,
+		SelectedStyle: Theme.Treemap.Selected,
+		NumFormatter:  func(n float64) string { return fmt.Sprint(n) },
+		Depth:         2,
+		Breadcrumbs:   NewBreadcrumbs(),
+	}
+	self.Breadcrumbs.OnChange = func(segments []string) {
+		self.path = self.path[:MaxInt(len(segments)-1, 0)]
+		self.changed(nil)
+	}
+	return self
+}
+
+// SetRoot shows the Nodes of root.
+func (self *Treemap) SetRoot(root *TreemapNode) {
+	self.root, self.path, self.selected, self.rects = root, nil, nil, nil
+	self.Breadcrumbs.SetPath("", "")
+	if root != nil {
+		self.Breadcrumbs.Push(root.Label)
+	}
+}
+
+// Root returns the node given to SetRoot.
+func (self *Treemap) Root() *TreemapNode {
+	return self.root
+}
+
+// Current returns the node whose Nodes are shown.
+func (self *Treemap) Current() *TreemapNode {
+	if len(self.path) > 0 {
+		return self.path[len(self.path)-1]
+	}
+	return self.root
+}
+
+// Selected returns the selected node among those shown, or nil if none is shown.
+func (self *Treemap) Selected() *TreemapNode {
+	current := self.Current()
+	if current == nil {
+		return nil
+	}
+	nodes := current.sortedNodes()
+	for _, node := range nodes {
+		if node == self.selected {
+			return node
+		}
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[0]
+}
+
+// changed selects selected, or the first node shown when it is nil, and calls OnChange.
+func (self *Treemap) changed(selected *TreemapNode) {
+	self.selected, self.rects = selected, nil
+	if self.OnChange != nil {
+		self.OnChange(self.Current())
+	}
+}
+
+// DrillDown shows the Nodes of the selected node and reports whether it has any.
+func (self *Treemap) DrillDown() bool {
+	selected := self.Selected()
+	if selected == nil || len(selected.sortedNodes()) == 0 {
+		return false
+	}
+	self.path = append(self.path, selected)
+	self.Breadcrumbs.Push(selected.Label)
+	self.changed(nil)
+	return true
+}
+
+// Up shows the Nodes of the parent of the node shown, selecting it, and reports whether there is
+// a parent.
+func (self *Treemap) Up() bool {
+	if len(self.path) == 0 {
+		return false
+	}
+	child := self.path[len(self.path)-1]
+	self.path = self.path[:len(self.path)-1]
+	self.Breadcrumbs.Pop()
+	self.changed(child)
+	return true
+}
+
+// move selects the nearest rectangle in the direction (dx, dy) from the selected one.
+func (self *Treemap) move(dx, dy int) {
+	selected := self.Selected()
+	var from image.Rectangle
+	for _, r := range self.rects {
+		if r.node == selected {
+			from = r.rect
+		}
+	}
+	center := func(r image.Rectangle) image.Point {
+		return r.Min.Add(r.Max).Div(2)
+	}
+	best, bestDistance := (*TreemapNode)(nil), 0
+	for _, r := range self.rects {
+		// the candidate must start beyond the selected rectangle in the direction
+		if (dx > 0 && r.rect.Min.X < from.Max.X) || (dx < 0 && r.rect.Max.X > from.Min.X) ||
+			(dy > 0 && r.rect.Min.Y < from.Max.Y) || (dy < 0 && r.rect.Max.Y > from.Min.Y) {
+			continue
+		}
+		d := center(r.rect).Sub(center(from))
+		// cells are about twice as tall as they are wide
+		distance := AbsInt(d.X) + 2*AbsInt(d.Y)
+		if best == nil || distance < bestDistance {
+			best, bestDistance = r.node, distance
+		}
+	}
+	if best != nil {
+		self.selected = best
+	}
+}
+
+// HandleKey selects a node with the arrow keys, shows the Nodes of the selected node with <Enter>,
+// and goes back up with <Backspace> or <Escape>. It reports whether the key was used.
+func (self *Treemap) HandleKey(id string) bool {
+	if self.root == nil {
+		return false
+	}
+	switch id {
+	case "<Left>", "h":
+		self.move(-1, 0)
+	case "<Right>", "l":
+		self.move(1, 0)
+	case "<Up>", "k":
+		self.move(0, -1)
+	case "<Down>", "j":
+		self.move(0, 1)
+	case "<Enter>":
+		return self.DrillDown()
+	case "<Backspace>", "<Escape>":
+		return self.Up()
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse selects a clicked node, shows the Nodes of a double-clicked one, and passes clicks on
+// the Breadcrumbs on to them. It reports whether the event was used.
+func (self *Treemap) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || e.ID != "<MouseLeft>" || e.Payload.(Mouse).Drag || !p.In(self.Inner) {
+		return false
+	}
+	if self.Breadcrumbs.HandleMouse(e) {
+		return true
+	}
+	for _, r := range self.rects {
+		if !p.In(r.rect) {
+			continue
+		}
+		if e.Payload.(Mouse).Clicks == 2 && r.node == self.Selected() {
+			self.DrillDown()
+			return true
+		}
+		self.selected = r.node
+		return true
+	}
+	return false
+}
+
+// squarify splits area into a rectangle per weight, sorted heaviest first, keeping them as close to
+// squares as it can by filling rows along the shorter side of the area left.
+func squarify(weights []float64, area image.Rectangle) []image.Rectangle {
+	rects := make([]image.Rectangle, len(weights))
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	if total <= 0 || area.Empty() {
+		return rects
+	}
+
+	// work in square units, cells being about twice as tall as they are wide
+	x, y := float64(area.Min.X)/2, float64(area.Min.Y)
+	w, h := float64(area.Dx())/2, float64(area.Dy())
+	scale := w * h / total
+	worst := func(sum, min, max, side float64) float64 {
+		return math.Max(side*side*max/(sum*sum), sum*sum/(side*side*min))
+	}
+	toRect := func(x0, y0, x1, y1 float64) image.Rectangle {
+		return image.Rect(int(math.Round(x0*2)), int(math.Round(y0)), int(math.Round(x1*2)), int(math.Round(y1)))
+	}
+
+	for i := 0; i < len(weights); {
+		side := math.Min(w, h)
+		// grow the row while it makes its worst aspect ratio better
+		sum, min, max := weights[i]*scale, weights[i]*scale, weights[i]*scale
+		j := i + 1
+		for ; j < len(weights); j++ {
+			a := weights[j] * scale
+			if worst(sum+a, math.Min(min, a), math.Max(max, a), side) > worst(sum, min, max, side) {
+				break
+			}
+			sum, min, max = sum+a, math.Min(min, a), math.Max(max, a)
+		}
+		if j == len(weights) {
+			// the last row takes what is left, hiding rounding errors
+			sum = w * h
+		}
+
+		if w >= h {
+			// a column on the left
+			width := sum / h
+			top := y
+			for k := i; k < j; k++ {
+				height := weights[k] * scale / width
+				if k == j-1 {
+					height = y + h - top
+				}
+				rects[k] = toRect(x, top, x+width, top+height)
+				top += height
+			}
+			x, w = x+width, w-width
+		} else {
+			// a row on top
+			height := sum / w
+			left := x
+			for k := i; k < j; k++ {
+				width := weights[k] * scale / height
+				if k == j-1 {
+					width = x + w - left
+				}
+				rects[k] = toRect(left, y, left+width, y+height)
+				left += width
+			}
+			y, h = y+height, h-height
+		}
+		i = j
+	}
+	return rects
+}
+
+// drawNodes draws nodes in area, each with its color, or with color when it is set, and the nodes
+// within them down to depth levels. Rectangles are parted by a column where they meet, drawn with a
+// line within a rectangle of the same color.
+func (self *Treemap) drawNodes(buf *Buffer, nodes []*TreemapNode, area image.Rectangle, depth int, color *Color) {
+	weights := make([]float64, len(nodes))
+	for i, node := range nodes {
+		weights[i] = node.Weight()
+	}
+	for i, rect := range squarify(weights, area) {
+		c := SelectColor(self.Colors, i)
+		if color != nil {
+			c = *color
+		}
+		if rect.Max.X < area.Max.X && rect.Dx() > 1 {
+			rect.Max.X--
+			if color != nil {
+				gap := image.Rect(rect.Max.X, rect.Min.Y, rect.Max.X+1, rect.Max.Y)
+				buf.Fill(NewCell(VERTICAL_LINE, NewStyle(self.TextStyle.Fg, c)), gap)
+			}
+		}
+		if rect.Empty() {
+			continue
+		}
+		if depth == self.Depth {
+			self.rects = append(self.rects, treemapRect{nodes[i], rect})
+		}
+
+		style := NewStyle(self.TextStyle.Fg, c, self.TextStyle.Modifier)
+		buf.Fill(NewCell(' ', style), rect)
+		labelStyle := style
+		if depth == self.Depth && nodes[i] == self.Selected() {
+			labelStyle = self.SelectedStyle
+			buf.Fill(NewCell(' ', labelStyle), image.Rect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Min.Y+1))
+		}
+		label := fmt.Sprintf("%s %s", nodes[i].Label, self.NumFormatter(nodes[i].Weight()))
+		buf.SetString(TrimString(label, rect.Dx()), labelStyle, rect.Min)
+
+		children := nodes[i].sortedNodes()
+		if depth > 1 && len(children) > 0 && rect.Dy() > 2 {
+			self.drawNodes(buf, children, image.Rect(rect.Min.X, rect.Min.Y+1, rect.Max.X, rect.Max.Y), depth-1, &c)
+		}
+	}
+}
+
+func (self *Treemap) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	self.rects = self.rects[:0]
+	if self.root == nil || self.Inner.Dy() < 2 {
+		return
+	}
+	self.Breadcrumbs.SetRect(self.Inner.Min.X, self.Inner.Min.Y, self.Inner.Max.X, self.Inner.Min.Y+1)
+	self.Breadcrumbs.Draw(buf)
+
+	area := image.Rect(self.Inner.Min.X, self.Inner.Min.Y+1, self.Inner.Max.X, self.Inner.Max.Y)
+	self.drawNodes(buf, self.Current().sortedNodes(), area, MaxInt(self.Depth, 1), nil)
+}
@@ -0,0 +1,275 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"fmt"
+	"image"
+
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// WizardStep is a page of a Wizard.
+type WizardStep struct {
+	Title   string
+	Content Drawable
+	// Validate is called by Next before leaving the step. An error keeps the Wizard on the step and
+	// is drawn below the Content. A Form Content is checked with its Validate first.
+	Validate func() error
+}
+
+// Wizard shows its Steps one at a time below a progress indicator, with Back and Next buttons on its
+// last row; Next becomes Finish on the last step. Keys and mouse events are passed on to the Content
+// of the current step first. When the Content doesn't use them, <Enter> goes to the next step,
+// <Backspace> to the previous one, and <Escape> calls OnCancel. A Form Content without an OnSubmit goes to the next step when submitted.
+type Wizard struct {
+	Block
+	Steps []*WizardStep
+
+	TextStyle    Style
+	CurrentStyle Style
+	DoneStyle    Style
+	ErrorStyle   Style
+	ButtonStyle  Style
+
+	BackLabel   string
+	NextLabel   string
+	FinishLabel string
+
+	// OnStepChange is called with the index of the step shown after it changes.
+	OnStepChange func(step int)
+	OnFinish     func()
+	OnCancel     func()
+
+	current int
+	err     error
+	// buttons holds the areas of the Back and Next buttons drawn by the last Draw.
+	buttons [2]image.Rectangle
+}
+
+func NewWizard() *Wizard {
+	return &Wizard{
+		Block:        *NewBlock(),
+		TextStyle:    Theme.Wizard.Text,
+		CurrentStyle: Theme.Wizard.Current,
+		DoneStyle:    Theme.Wizard.Done,
+		ErrorStyle:   Theme.Wizard.Error,
+		ButtonStyle:  Theme.Wizard.Button,
+		BackLabel:    "Back",
+		NextLabel:    "Next",
+		FinishLabel:  "Finish",
+	}
+}
+
+// AddStep appends a step and returns it.
+func (self *Wizard) AddStep(title string, content Drawable) *WizardStep {
+	step := &WizardStep{Title: title, Content: content}
+	if form, ok := content.(*Form); ok && form.OnSubmit == nil {
+		form.OnSubmit = func(map[string]interface{}) {
+			self.Next()
+		}
+	}
+	self.Steps = append(self.Steps, step)
+	return step
+}
+
+// Current returns the index of the step shown.
+func (self *Wizard) Current() int {
+	return self.current
+}
+
+// Err returns the error returned by the validation of the step shown, if any.
+func (self *Wizard) Err() error {
+	return self.err
+}
+
+func (self *Wizard) setCurrent(step int) {
+	self.err = nil
+	if step == self.current {
+		return
+	}
+	self.current = step
+	if self.OnStepChange != nil {
+		self.OnStepChange(step)
+	}
+}
+
+// validate checks the step shown, setting Err, and reports whether it may be left.
+func (self *Wizard) validate() bool {
+	step := self.Steps[self.current]
+	if form, ok := step.Content.(*Form); ok && !form.Validate() {
+		self.err = nil
+		return false
+	}
+	if step.Validate != nil {
+		if self.err = step.Validate(); self.err != nil {
+			return false
+		}
+	}
+	self.err = nil
+	return true
+}
+
+// Next validates the step shown and moves to the next one, or calls OnFinish on the last step.
+// It reports whether the step was valid.
+func (self *Wizard) Next() bool {
+	if len(self.Steps) == 0 || !self.validate() {
+		return false
+	}
+	if self.current == len(self.Steps)-1 {
+		if self.OnFinish != nil {
+			self.OnFinish()
+		}
+		return true
+	}
+	self.setCurrent(self.current + 1)
+	return true
+}
+
+// Back moves to the previous step without validating the step shown.
+func (self *Wizard) Back() {
+	if self.current > 0 {
+		self.setCurrent(self.current - 1)
+	}
+}
+
+// HandleKey passes a keyboard event ID on to the Content of the step shown, moving between the steps
+// with <Enter> and <Backspace> and calling OnCancel with <Escape> when it isn't used.
+// It reports whether the key was used.
+func (self *Wizard) HandleKey(id string) bool {
+	if len(self.Steps) == 0 {
+		return false
+	}
+	if content, ok := self.Steps[self.current].Content.(interface{ HandleKey(string) bool }); ok && content.HandleKey(id) {
+		return true
+	}
+	switch id {
+	case "<Enter>":
+		self.Next()
+	case "<Backspace>":
+		if self.current == 0 {
+			return false
+		}
+		self.Back()
+	case "<Escape>":
+		if self.OnCancel == nil {
+			return false
+		}
+		self.OnCancel()
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleMouse clicks the buttons, passing the other events on to the Content of the step shown.
+// It reports whether the event was used.
+func (self *Wizard) HandleMouse(e Event) bool {
+	p, ok := MousePoint(e)
+	if !ok || len(self.Steps) == 0 {
+		return false
+	}
+	if e.ID == "<MouseLeft>" && !e.Payload.(Mouse).Drag {
+		switch {
+		case p.In(self.buttons[0]):
+			self.Back()
+			return true
+		case p.In(self.buttons[1]):
+			self.Next()
+			return true
+		}
+	}
+	if content, ok := self.Steps[self.current].Content.(interface{ HandleMouse(Event) bool }); ok {
+		return content.HandleMouse(e)
+	}
+	return false
+}
+
+// drawProgress draws the titles of the steps on the first row, marking those done, or only the
+// title of the step shown when they don't fit.
+func (self *Wizard) drawProgress(buf *Buffer) {
+	y := self.Inner.Min.Y
+	counter := fmt.Sprintf(" %d/%d", self.current+1, len(self.Steps))
+	width := self.Inner.Dx() - len(counter)
+
+	full := 0
+	for i, step := range self.Steps {
+		if i > 0 {
+			full += 3
+		}
+		full += rw.StringWidth(step.Title) + 2
+	}
+
+	x := self.Inner.Min.X
+	put := func(s string, style Style) {
+		s = TrimString(s, MaxInt(self.Inner.Min.X+width-x, 0))
+		buf.SetString(s, style, image.Pt(x, y))
+		x += rw.StringWidth(s)
+	}
+	if full > width {
+		put(fmt.Sprintf("%c %s", SELECTED, self.Steps[self.current].Title), self.CurrentStyle)
+	} else {
+		for i, step := range self.Steps {
+			if i > 0 {
+				put(" "+string(HORIZONTAL_LINE)+" ", self.TextStyle)
+			}
+			switch {
+			case i < self.current:
+				put(fmt.Sprintf("%c %s", CHECK, step.Title), self.DoneStyle)
+			case i == self.current:
+				put(fmt.Sprintf("%c %s", SELECTED, step.Title), self.CurrentStyle)
+			default:
+				put(fmt.Sprintf("%c %s", UNSELECTED, step.Title), self.TextStyle)
+			}
+		}
+	}
+	if width >= 0 {
+		buf.SetString(counter, self.TextStyle, image.Pt(self.Inner.Min.X+width, y))
+	}
+}
+
+func (self *Wizard) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	self.buttons = [2]image.Rectangle{}
+	if len(self.Steps) == 0 || self.Inner.Dy() < 3 {
+		return
+	}
+	self.current = MinInt(self.current, len(self.Steps)-1)
+	self.drawProgress(buf)
+
+	content := self.Steps[self.current].Content
+	content.SetRect(self.Inner.Min.X, self.Inner.Min.Y+1, self.Inner.Max.X, self.Inner.Max.Y-1)
+	content.Lock()
+	content.Draw(buf)
+	content.Unlock()
+
+	// draw the buttons on the last row, right-aligned, with the error on their left
+	y := self.Inner.Max.Y - 1
+	next := self.NextLabel
+	if self.current == len(self.Steps)-1 {
+		next = self.FinishLabel
+	}
+	x := self.Inner.Max.X
+	button := func(label string) image.Rectangle {
+		text := fmt.Sprintf("[ %s ]", label)
+		if x-rw.StringWidth(text) < self.Inner.Min.X {
+			return image.Rectangle{}
+		}
+		x -= rw.StringWidth(text)
+		buf.SetString(text, self.ButtonStyle, image.Pt(x, y))
+		return image.Rect(x, y, x+rw.StringWidth(text), y+1)
+	}
+	self.buttons[1] = button(next)
+	if self.current > 0 {
+		x--
+		self.buttons[0] = button(self.BackLabel)
+	}
+	if self.err != nil {
+		message := fmt.Sprintf("%c %v", ERROR, self.err)
+		buf.SetString(TrimString(message, MaxInt(x-self.Inner.Min.X-1, 0)), self.ErrorStyle, image.Pt(self.Inner.Min.X, y))
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	. "github.com/reaalkhalil/termui"
+)
+
+// yamlLine is a line of YAML with its indentation taken off, numbered from 1.
+type yamlLine struct {
+	indent int
+	text   string
+	number int
+}
+
+// yamlParser parses the block style of YAML most documents are written in: mappings, sequences,
+// plain and quoted scalars, literal and folded block scalars, and comments. Flow collections are
+// read as JSON, and anchors, tags, and multiple documents aren't supported. Mappings are decoded
+// into an inspectorObject to keep the order of their keys.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func parseYAML(data string) (interface{}, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(strings.Replace(data, "\t", "    ", -1), "\n") {
+		raw = strings.TrimRight(raw, " \r")
+		text := strings.TrimLeft(raw, " ")
+		p.lines = append(p.lines, yamlLine{len(raw) - len(text), text, i + 1})
+	}
+	line := p.peek()
+	if line == nil {
+		return nil, nil
+	}
+	value, err := p.parseBlock(line.indent)
+	if err != nil {
+		return nil, err
+	}
+	if line := p.peek(); line != nil {
+		return nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+	}
+	return value, nil
+}
+
+// peek returns the next line holding content, skipping blank lines, comments, and document
+// markers, or nil at the end.
+func (self *yamlParser) peek() *yamlLine {
+	for ; self.pos < len(self.lines); self.pos++ {
+		text := self.lines[self.pos].text
+		if text != "" && text[0] != '#' && text != "---" && text != "..." {
+			return &self.lines[self.pos]
+		}
+	}
+	return nil
+}
+
+func isYAMLItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// parseBlock parses the mapping, sequence, or scalar starting on the next line, at indent.
+func (self *yamlParser) parseBlock(indent int) (interface{}, error) {
+	line := self.peek()
+	if isYAMLItem(line.text) {
+		return self.parseSequence(indent)
+	}
+	if _, _, ok := splitYAMLKey(line.text); ok {
+		return self.parseMapping(indent)
+	}
+	self.pos++
+	return parseYAMLScalar(line.text), nil
+}
+
+func (self *yamlParser) parseSequence(indent int) (interface{}, error) {
+	items := []interface{}{}
+	for line := self.peek(); line != nil && line.indent == indent && isYAMLItem(line.text); line = self.peek() {
+		rest := strings.TrimLeft(line.text[1:], " ")
+		var item interface{}
+		var err error
+		if rest == "" {
+			self.pos++
+			if child := self.peek(); child != nil && child.indent > indent {
+				item, err = self.parseBlock(child.indent)
+			}
+		} else {
+			// parse the rest of the line as a block starting where it does, so that the lines of
+			// a mapping in the item continue at its indentation
+			line.indent += len(line.text) - len(rest)
+			line.text = rest
+			item, err = self.parseBlock(line.indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (self *yamlParser) parseMapping(indent int) (interface{}, error) {
+	object := inspectorObject{}
+	for line := self.peek(); line != nil && line.indent == indent && !isYAMLItem(line.text); line = self.peek() {
+		key, rest, ok := splitYAMLKey(line.text)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected a key", line.number)
+		}
+		self.pos++
+		var value interface{}
+		switch {
+		case rest == "|" || rest == "|-" || rest == ">" || rest == ">-":
+			value = self.parseBlockScalar(indent, rest)
+		case rest != "":
+			value = parseYAMLScalar(rest)
+		default:
+			// the value is on the next lines, more indented, or a sequence at the same indentation
+			if child := self.peek(); child != nil && (child.indent > indent || (child.indent == indent && isYAMLItem(child.text))) {
+				var err error
+				if value, err = self.parseBlock(child.indent); err != nil {
+					return nil, err
+				}
+			}
+		}
+		object = append(object, inspectorMember{key, value})
+	}
+	return object, nil
+}
+
+// parseBlockScalar reads the lines more indented than indent as a literal (|) or folded (>) block
+// scalar, with a final newline unless the style ends with -.
+func (self *yamlParser) parseBlockScalar(indent int, style string) string {
+	lines := []string{}
+	contentIndent := -1
+	for ; self.pos < len(self.lines); self.pos++ {
+		line := self.lines[self.pos]
+		if line.text == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if line.indent <= indent {
+			break
+		}
+		if contentIndent < 0 {
+			contentIndent = line.indent
+		}
+		lines = append(lines, strings.Repeat(" ", MaxInt(line.indent-contentIndent, 0))+line.text)
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var text string
+	if style[0] == '|' {
+		text = strings.Join(lines, "\n")
+	} else {
+		// folded lines are joined with spaces, and blank lines become newlines
+		for i, line := range lines {
+			switch {
+			case line == "":
+				text += "\n"
+			case i > 0 && lines[i-1] != "":
+				text += " " + line
+			default:
+				text += line
+			}
+		}
+	}
+	if !strings.HasSuffix(style, "-") && len(lines) > 0 {
+		text += "\n"
+	}
+	return text
+}
+
+// splitYAMLKey splits a line of a mapping into its key and the rest of the line after the colon.
+func splitYAMLKey(text string) (string, string, bool) {
+	if text[0] == '"' || text[0] == '\'' {
+		end := closingQuote(text)
+		if end < 0 || !strings.HasPrefix(text[end+1:], ":") {
+			return "", "", false
+		}
+		rest := text[end+2:]
+		if rest != "" && rest[0] != ' ' {
+			return "", "", false
+		}
+		return unquoteYAML(text[:end+1]), strings.TrimSpace(rest), true
+	}
+	if i := strings.Index(text, ": "); i > 0 {
+		return text[:i], strings.TrimSpace(text[i+2:]), true
+	}
+	if strings.HasSuffix(text, ":") && len(text) > 1 {
+		return text[:len(text)-1], "", true
+	}
+	return "", "", false
+}
+
+// closingQuote returns the index of the quote closing the string text starts with, or -1.
+func closingQuote(text string) int {
+	quote := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case text[i] == quote && quote == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+func unquoteYAML(text string) string {
+	if text[0] == '\'' {
+		return strings.Replace(text[1:len(text)-1], "''", "'", -1)
+	}
+	if s, err := strconv.Unquote(text); err == nil {
+		return s
+	}
+	return text[1 : len(text)-1]
+}
+
+// parseYAMLScalar returns the value of a scalar: a string, a json.Number, a bool, nil, or the
+// value of a flow collection read as JSON.
+func parseYAMLScalar(text string) interface{} {
+	if text[0] == '"' || text[0] == '\'' {
+		if end := closingQuote(text); end > 0 {
+			return unquoteYAML(text[:end+1])
+		}
+		return text
+	}
+	if i := strings.Index(text, " #"); i >= 0 {
+		text = strings.TrimSpace(text[:i])
+	}
+	switch text {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	}
+	if _, err := strconv.ParseFloat(text, 64); err == nil {
+		return json.Number(text)
+	}
+	if text[0] == '[' || text[0] == '{' {
+		if value, err := decodeJSON(text); err == nil {
+			return value
+		}
+	}
+	return text
+}